@@ -0,0 +1,109 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// defaultAccept is the media type the client asks for when no other is set for the
+// service or request. The structs of the SDK are written against it, the versions of the
+// APIs being part of their paths.
+const defaultAccept = "application/json"
+
+// ErrUnexpectedContentType is matched by errors.Is for the errors of requests whose
+// response has another media type than the one asked for.
+var ErrUnexpectedContentType = errors.New("unexpected response content type")
+
+// ContentTypeError is returned by Do, along with the response, instead of decoding a
+// JSON response whose media type isn't the one the request accepted, such as a
+// versioned media type a gateway now defaults to. The structs of the SDK may not match
+// the shape of such responses.
+type ContentTypeError struct {
+	Method      string
+	URL         string
+	Accept      string
+	ContentType string
+}
+
+func (e *ContentTypeError) Error() string {
+	return fmt.Sprintf("%v: %v %v accepted %v, got %v", ErrUnexpectedContentType, e.Method, e.URL, e.Accept, e.ContentType)
+}
+
+// Is makes errors.Is(err, ErrUnexpectedContentType) report true.
+func (e *ContentTypeError) Is(target error) bool {
+	return target == ErrUnexpectedContentType
+}
+
+type acceptKey struct{}
+
+// WithAccept returns a copy of ctx carrying the media type the requests made with it
+// accept, which takes precedence over the one of their service. See
+// Client.WithServiceAccept.
+func WithAccept(ctx context.Context, mediaType string) context.Context {
+	return context.WithValue(ctx, acceptKey{}, mediaType)
+}
+
+// Accept returns the media type set on ctx with WithAccept, if any.
+func Accept(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	mediaType, _ := ctx.Value(acceptKey{}).(string)
+	return mediaType
+}
+
+// WithServiceAccept pins the media type of the responses of the requests whose path
+// starts with the given segment, such as "papi", rather than application/json. It is
+// sent in their Accept header, and Do refuses to decode JSON responses of another media
+// type with a *ContentTypeError. WithServiceAccept must not be called while the client
+// is in use; it returns c so that calls can be chained.
+func (c *Client) WithServiceAccept(service, mediaType string) *Client {
+	if c.serviceAccept == nil {
+		c.serviceAccept = map[string]string{}
+	}
+	c.serviceAccept[strings.Trim(service, "/")] = mediaType
+	return c
+}
+
+// accept returns the media type the request for urlStr accepts by default.
+func (c *Client) accept(urlStr string) string {
+	if mediaType, ok := c.serviceAccept[serviceOf(urlStr)]; ok {
+		return mediaType
+	}
+	return defaultAccept
+}
+
+// checkContentType returns a *ContentTypeError if resp is JSON of another media type
+// than the one req accepted. Responses that don't claim to be JSON, such as those
+// without a Content-Type, are left for the decoder to judge.
+func checkContentType(req *http.Request, resp *http.Response) error {
+	accept := req.Header.Get("Accept")
+	want := mediaType(accept)
+	if want == "" || want == "*/*" {
+		return nil
+	}
+
+	got := mediaType(resp.Header.Get("Content-Type"))
+	if !isJSONMediaType(got) || got == want {
+		return nil
+	}
+
+	return &ContentTypeError{Method: req.Method, URL: req.URL.String(), Accept: accept, ContentType: resp.Header.Get("Content-Type")}
+}
+
+// mediaType returns the first media type of a Content-Type or Accept header, without
+// its parameters, in lower case. It is cheaper than mime.ParseMediaType, which checks
+// the parameters too.
+func mediaType(header string) string {
+	if i := strings.IndexAny(header, ",;"); i >= 0 {
+		header = header[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(header))
+}
+
+func isJSONMediaType(mt string) bool {
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
+}
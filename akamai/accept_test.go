@@ -0,0 +1,86 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+const dnsV3 = "application/vnd.akamai.config-dns.v3+json"
+
+// recordAccept records the Accept headers of the requests the server receives.
+func recordAccept(srv *akamaitest.Server) *[]string {
+	var accepted []string
+	next := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accepted = append(accepted, r.Header.Get("Accept"))
+		next.ServeHTTP(w, r)
+	})
+	return &accepted
+}
+
+func TestAcceptDefault(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	accepted := recordAccept(srv)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	zone, _, err := client.FastDNSv2.GetZone(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "example.com", zone.GetZone())
+	assert.Equal(t, []string{"application/json"}, *accepted)
+
+	// A gateway serving another version of the API is caught before decoding.
+	srv.ContentType = dnsV3
+	_, resp, err := client.FastDNSv2.GetZone(ctx, "example.com")
+	assert.True(t, errors.Is(err, akamai.ErrUnexpectedContentType), "got %v", err)
+	var ce *akamai.ContentTypeError
+	if assert.True(t, errors.As(err, &ce)) {
+		assert.Equal(t, "application/json", ce.Accept)
+		assert.Equal(t, dnsV3, ce.ContentType)
+		assert.Equal(t, "GET", ce.Method)
+	}
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// Parameters of the media type don't matter.
+	srv.ContentType = "application/json; charset=utf-8"
+	if _, _, err := client.FastDNSv2.GetZone(ctx, "example.com"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+}
+
+func TestWithServiceAccept(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	accepted := recordAccept(srv)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	client.WithServiceAccept("config-dns", dnsV3)
+
+	// The pinned media type is sent, and the default one refused.
+	_, _, err := client.FastDNSv2.GetZone(ctx, "example.com")
+	assert.True(t, errors.Is(err, akamai.ErrUnexpectedContentType), "got %v", err)
+
+	srv.ContentType = dnsV3
+	if _, _, err := client.FastDNSv2.GetZone(ctx, "example.com"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// WithAccept overrides the media type of the service for a request.
+	_, _, err = client.FastDNSv2.GetZone(akamai.WithAccept(ctx, "application/json"), "example.com")
+	var ce *akamai.ContentTypeError
+	if assert.True(t, errors.As(err, &ce), "got %v", err) {
+		assert.Equal(t, "application/json", ce.Accept)
+	}
+
+	assert.Equal(t, []string{dnsV3, dnsV3, "application/json"}, *accepted)
+}
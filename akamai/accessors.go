@@ -0,0 +1,1795 @@
+// Code generated by gen-accessors; DO NOT EDIT.
+
+package akamai
+
+// GetMaintenance returns the Maintenance field if it's non-nil, zero value otherwise.
+func (x *AkamaiError) GetMaintenance() *MaintenanceWindow {
+	if x == nil || x.Maintenance == nil {
+		return nil
+	}
+	return x.Maintenance
+}
+
+// GetCIDR returns the CIDR field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetCIDR() string {
+	if x == nil || x.CIDR == nil {
+		return ""
+	}
+	return *x.CIDR
+}
+
+// GetCIDRID returns the CIDRID field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetCIDRID() int {
+	if x == nil || x.CIDRID == nil {
+		return 0
+	}
+	return *x.CIDRID
+}
+
+// GetCIDRMask returns the CIDRMask field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetCIDRMask() string {
+	if x == nil || x.CIDRMask == nil {
+		return ""
+	}
+	return *x.CIDRMask
+}
+
+// GetChangeDate returns the ChangeDate field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetChangeDate() string {
+	if x == nil || x.ChangeDate == nil {
+		return ""
+	}
+	return *x.ChangeDate
+}
+
+// GetCreationDate returns the CreationDate field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetCreationDate() string {
+	if x == nil || x.CreationDate == nil {
+		return ""
+	}
+	return *x.CreationDate
+}
+
+// GetEffectiveDate returns the EffectiveDate field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetEffectiveDate() string {
+	if x == nil || x.EffectiveDate == nil {
+		return ""
+	}
+	return *x.EffectiveDate
+}
+
+// GetLastAction returns the LastAction field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetLastAction() string {
+	if x == nil || x.LastAction == nil {
+		return ""
+	}
+	return *x.LastAction
+}
+
+// GetMaxIP returns the MaxIP field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetMaxIP() string {
+	if x == nil || x.MaxIP == nil {
+		return ""
+	}
+	return *x.MaxIP
+}
+
+// GetMinIP returns the MinIP field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetMinIP() string {
+	if x == nil || x.MinIP == nil {
+		return ""
+	}
+	return *x.MinIP
+}
+
+// GetPort returns the Port field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetPort() string {
+	if x == nil || x.Port == nil {
+		return ""
+	}
+	return *x.Port
+}
+
+// GetServiceID returns the ServiceID field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetServiceID() int {
+	if x == nil || x.ServiceID == nil {
+		return 0
+	}
+	return *x.ServiceID
+}
+
+// GetServiceName returns the ServiceName field if it's non-nil, zero value otherwise.
+func (x *CIDRBlock) GetServiceName() string {
+	if x == nil || x.ServiceName == nil {
+		return ""
+	}
+	return *x.ServiceName
+}
+
+// GetRecordChange returns the RecordChange field if it's non-nil, zero value otherwise.
+func (x *ChangeAttribution) GetRecordChange() *RecordHistoryEntry {
+	if x == nil || x.RecordChange == nil {
+		return nil
+	}
+	return x.RecordChange
+}
+
+// GetPage returns the Page field if it's non-nil, zero value otherwise.
+func (x *ChangeListMetadata) GetPage() int {
+	if x == nil || x.Page == nil {
+		return 0
+	}
+	return *x.Page
+}
+
+// GetPageSize returns the PageSize field if it's non-nil, zero value otherwise.
+func (x *ChangeListMetadata) GetPageSize() int {
+	if x == nil || x.PageSize == nil {
+		return 0
+	}
+	return *x.PageSize
+}
+
+// GetTotalElements returns the TotalElements field if it's non-nil, zero value otherwise.
+func (x *ChangeListMetadata) GetTotalElements() int {
+	if x == nil || x.TotalElements == nil {
+		return 0
+	}
+	return *x.TotalElements
+}
+
+// GetZone returns the Zone field if it's non-nil, zero value otherwise.
+func (x *ChangeListMetadata) GetZone() string {
+	if x == nil || x.Zone == nil {
+		return ""
+	}
+	return *x.Zone
+}
+
+// GetMetadata returns the Metadata field if it's non-nil, zero value otherwise.
+func (x *ChangeListRecords) GetMetadata() *ChangeListMetadata {
+	if x == nil || x.Metadata == nil {
+		return nil
+	}
+	return x.Metadata
+}
+
+// GetResult returns the Result field if it's non-nil, zero value otherwise.
+func (x *ChangeListValidationError) GetResult() *ValidationResult {
+	if x == nil || x.Result == nil {
+		return nil
+	}
+	return x.Result
+}
+
+// GetAction returns the Action field if it's non-nil, zero value otherwise.
+func (x *ChangeRequest) GetAction() string {
+	if x == nil || x.Action == nil {
+		return ""
+	}
+	return *x.Action
+}
+
+// GetChangeID returns the ChangeID field if it's non-nil, zero value otherwise.
+func (x *ChangeRequest) GetChangeID() int {
+	if x == nil || x.ChangeID == nil {
+		return 0
+	}
+	return *x.ChangeID
+}
+
+// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+func (x *ChangeRequest) GetStatus() string {
+	if x == nil || x.Status == nil {
+		return ""
+	}
+	return *x.Status
+}
+
+// GetStatusMessage returns the StatusMessage field if it's non-nil, zero value otherwise.
+func (x *ChangeRequest) GetStatusMessage() string {
+	if x == nil || x.StatusMessage == nil {
+		return ""
+	}
+	return *x.StatusMessage
+}
+
+// GetStatusUpdateDate returns the StatusUpdateDate field if it's non-nil, zero value otherwise.
+func (x *ChangeRequest) GetStatusUpdateDate() string {
+	if x == nil || x.StatusUpdateDate == nil {
+		return ""
+	}
+	return *x.StatusUpdateDate
+}
+
+// GetContractID returns the ContractID field if it's non-nil, zero value otherwise.
+func (x *Contract) GetContractID() string {
+	if x == nil || x.ContractID == nil {
+		return ""
+	}
+	return *x.ContractID
+}
+
+// GetContractName returns the ContractName field if it's non-nil, zero value otherwise.
+func (x *Contract) GetContractName() string {
+	if x == nil || x.ContractName == nil {
+		return ""
+	}
+	return *x.ContractName
+}
+
+// GetContractTypeName returns the ContractTypeName field if it's non-nil, zero value otherwise.
+func (x *Contract) GetContractTypeName() string {
+	if x == nil || x.ContractTypeName == nil {
+		return ""
+	}
+	return *x.ContractTypeName
+}
+
+// GetContractID returns the ContractID field if it's non-nil, zero value otherwise.
+func (x *ContractAuthorities) GetContractID() string {
+	if x == nil || x.ContractID == nil {
+		return ""
+	}
+	return *x.ContractID
+}
+
+// GetEndDate returns the EndDate field if it's non-nil, zero value otherwise.
+func (x *ContractProduct) GetEndDate() string {
+	if x == nil || x.EndDate == nil {
+		return ""
+	}
+	return *x.EndDate
+}
+
+// GetProductID returns the ProductID field if it's non-nil, zero value otherwise.
+func (x *ContractProduct) GetProductID() string {
+	if x == nil || x.ProductID == nil {
+		return ""
+	}
+	return *x.ProductID
+}
+
+// GetProductName returns the ProductName field if it's non-nil, zero value otherwise.
+func (x *ContractProduct) GetProductName() string {
+	if x == nil || x.ProductName == nil {
+		return ""
+	}
+	return *x.ProductName
+}
+
+// GetStartDate returns the StartDate field if it's non-nil, zero value otherwise.
+func (x *ContractProduct) GetStartDate() string {
+	if x == nil || x.StartDate == nil {
+		return ""
+	}
+	return *x.StartDate
+}
+
+// GetContractID returns the ContractID field if it's non-nil, zero value otherwise.
+func (x *ContractProducts) GetContractID() string {
+	if x == nil || x.ContractID == nil {
+		return ""
+	}
+	return *x.ContractID
+}
+
+// GetZone returns the Zone field if it's non-nil, zero value otherwise.
+func (x *CreateZoneResult) GetZone() *Zone {
+	if x == nil || x.Zone == nil {
+		return nil
+	}
+	return x.Zone
+}
+
+// GetComments returns the Comments field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetComments() string {
+	if x == nil || x.Comments == nil {
+		return ""
+	}
+	return *x.Comments
+}
+
+// GetDNSZone returns the DNSZone field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetDNSZone() string {
+	if x == nil || x.DNSZone == nil {
+		return ""
+	}
+	return *x.DNSZone
+}
+
+// GetEdgeHostnameID returns the EdgeHostnameID field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetEdgeHostnameID() int {
+	if x == nil || x.EdgeHostnameID == nil {
+		return 0
+	}
+	return *x.EdgeHostnameID
+}
+
+// GetIPVersionBehavior returns the IPVersionBehavior field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetIPVersionBehavior() string {
+	if x == nil || x.IPVersionBehavior == nil {
+		return ""
+	}
+	return *x.IPVersionBehavior
+}
+
+// GetMap returns the Map field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetMap() string {
+	if x == nil || x.Map == nil {
+		return ""
+	}
+	return *x.Map
+}
+
+// GetRecordName returns the RecordName field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetRecordName() string {
+	if x == nil || x.RecordName == nil {
+		return ""
+	}
+	return *x.RecordName
+}
+
+// GetSecurityType returns the SecurityType field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetSecurityType() string {
+	if x == nil || x.SecurityType == nil {
+		return ""
+	}
+	return *x.SecurityType
+}
+
+// GetSerialNumber returns the SerialNumber field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetSerialNumber() int {
+	if x == nil || x.SerialNumber == nil {
+		return 0
+	}
+	return *x.SerialNumber
+}
+
+// GetSlotNumber returns the SlotNumber field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetSlotNumber() int {
+	if x == nil || x.SlotNumber == nil {
+		return 0
+	}
+	return *x.SlotNumber
+}
+
+// GetTTL returns the TTL field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetTTL() int {
+	if x == nil || x.TTL == nil {
+		return 0
+	}
+	return *x.TTL
+}
+
+// GetUseDefaultMap returns the UseDefaultMap field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetUseDefaultMap() bool {
+	if x == nil || x.UseDefaultMap == nil {
+		return false
+	}
+	return *x.UseDefaultMap
+}
+
+// GetUseDefaultTTL returns the UseDefaultTTL field if it's non-nil, zero value otherwise.
+func (x *EdgeHostname) GetUseDefaultTTL() bool {
+	if x == nil || x.UseDefaultTTL == nil {
+		return false
+	}
+	return *x.UseDefaultTTL
+}
+
+// GetExpiry returns the Expiry field if it's non-nil, zero value otherwise.
+func (x *EdgeKVAccessToken) GetExpiry() string {
+	if x == nil || x.Expiry == nil {
+		return ""
+	}
+	return *x.Expiry
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (x *EdgeKVAccessToken) GetName() string {
+	if x == nil || x.Name == nil {
+		return ""
+	}
+	return *x.Name
+}
+
+// GetUUID returns the UUID field if it's non-nil, zero value otherwise.
+func (x *EdgeKVAccessToken) GetUUID() string {
+	if x == nil || x.UUID == nil {
+		return ""
+	}
+	return *x.UUID
+}
+
+// GetValue returns the Value field if it's non-nil, zero value otherwise.
+func (x *EdgeKVAccessToken) GetValue() string {
+	if x == nil || x.Value == nil {
+		return ""
+	}
+	return *x.Value
+}
+
+// GetGeoLocation returns the GeoLocation field if it's non-nil, zero value otherwise.
+func (x *EdgeKVNamespace) GetGeoLocation() string {
+	if x == nil || x.GeoLocation == nil {
+		return ""
+	}
+	return *x.GeoLocation
+}
+
+// GetGroupID returns the GroupID field if it's non-nil, zero value otherwise.
+func (x *EdgeKVNamespace) GetGroupID() int {
+	if x == nil || x.GroupID == nil {
+		return 0
+	}
+	return int(*x.GroupID)
+}
+
+// GetNamespace returns the Namespace field if it's non-nil, zero value otherwise.
+func (x *EdgeKVNamespace) GetNamespace() string {
+	if x == nil || x.Namespace == nil {
+		return ""
+	}
+	return *x.Namespace
+}
+
+// GetRetentionInSeconds returns the RetentionInSeconds field if it's non-nil, zero value otherwise.
+func (x *EdgeKVNamespace) GetRetentionInSeconds() int {
+	if x == nil || x.RetentionInSeconds == nil {
+		return 0
+	}
+	return *x.RetentionInSeconds
+}
+
+// GetAccountStatus returns the AccountStatus field if it's non-nil, zero value otherwise.
+func (x *EdgeKVStoreStatus) GetAccountStatus() string {
+	if x == nil || x.AccountStatus == nil {
+		return ""
+	}
+	return *x.AccountStatus
+}
+
+// GetCPCode returns the CPCode field if it's non-nil, zero value otherwise.
+func (x *EdgeKVStoreStatus) GetCPCode() string {
+	if x == nil || x.CPCode == nil {
+		return ""
+	}
+	return string(*x.CPCode)
+}
+
+// GetProductionStatus returns the ProductionStatus field if it's non-nil, zero value otherwise.
+func (x *EdgeKVStoreStatus) GetProductionStatus() string {
+	if x == nil || x.ProductionStatus == nil {
+		return ""
+	}
+	return *x.ProductionStatus
+}
+
+// GetStagingStatus returns the StagingStatus field if it's non-nil, zero value otherwise.
+func (x *EdgeKVStoreStatus) GetStagingStatus() string {
+	if x == nil || x.StagingStatus == nil {
+		return ""
+	}
+	return *x.StagingStatus
+}
+
+// GetDescription returns the Description field if it's non-nil, zero value otherwise.
+func (x *FirewallService) GetDescription() string {
+	if x == nil || x.Description == nil {
+		return ""
+	}
+	return *x.Description
+}
+
+// GetServiceID returns the ServiceID field if it's non-nil, zero value otherwise.
+func (x *FirewallService) GetServiceID() int {
+	if x == nil || x.ServiceID == nil {
+		return 0
+	}
+	return *x.ServiceID
+}
+
+// GetServiceName returns the ServiceName field if it's non-nil, zero value otherwise.
+func (x *FirewallService) GetServiceName() string {
+	if x == nil || x.ServiceName == nil {
+		return ""
+	}
+	return *x.ServiceName
+}
+
+// GetEmail returns the Email field if it's non-nil, zero value otherwise.
+func (x *FirewallSubscription) GetEmail() string {
+	if x == nil || x.Email == nil {
+		return ""
+	}
+	return *x.Email
+}
+
+// GetServiceID returns the ServiceID field if it's non-nil, zero value otherwise.
+func (x *FirewallSubscription) GetServiceID() int {
+	if x == nil || x.ServiceID == nil {
+		return 0
+	}
+	return *x.ServiceID
+}
+
+// GetServiceName returns the ServiceName field if it's non-nil, zero value otherwise.
+func (x *FirewallSubscription) GetServiceName() string {
+	if x == nil || x.ServiceName == nil {
+		return ""
+	}
+	return *x.ServiceName
+}
+
+// GetSignupDate returns the SignupDate field if it's non-nil, zero value otherwise.
+func (x *FirewallSubscription) GetSignupDate() string {
+	if x == nil || x.SignupDate == nil {
+		return ""
+	}
+	return *x.SignupDate
+}
+
+// GetGroupID returns the GroupID field if it's non-nil, zero value otherwise.
+func (x *Group) GetGroupID() int {
+	if x == nil || x.GroupID == nil {
+		return 0
+	}
+	return *x.GroupID
+}
+
+// GetGroupName returns the GroupName field if it's non-nil, zero value otherwise.
+func (x *Group) GetGroupName() string {
+	if x == nil || x.GroupName == nil {
+		return ""
+	}
+	return *x.GroupName
+}
+
+// GetPage returns the Page field if it's non-nil, zero value otherwise.
+func (x *ListZoneRecordMetadata) GetPage() int {
+	if x == nil || x.Page == nil {
+		return 0
+	}
+	return *x.Page
+}
+
+// GetPageSize returns the PageSize field if it's non-nil, zero value otherwise.
+func (x *ListZoneRecordMetadata) GetPageSize() int {
+	if x == nil || x.PageSize == nil {
+		return 0
+	}
+	return *x.PageSize
+}
+
+// GetTotalElements returns the TotalElements field if it's non-nil, zero value otherwise.
+func (x *ListZoneRecordMetadata) GetTotalElements() int {
+	if x == nil || x.TotalElements == nil {
+		return 0
+	}
+	return *x.TotalElements
+}
+
+// GetZone returns the Zone field if it's non-nil, zero value otherwise.
+func (x *ListZoneRecordMetadata) GetZone() string {
+	if x == nil || x.Zone == nil {
+		return ""
+	}
+	return *x.Zone
+}
+
+// GetMetadata returns the Metadata field if it's non-nil, zero value otherwise.
+func (x *ListZoneRecordSets) GetMetadata() *ListZoneRecordMetadata {
+	if x == nil || x.Metadata == nil {
+		return nil
+	}
+	return x.Metadata
+}
+
+// GetBulk returns the Bulk field if it's non-nil, zero value otherwise.
+func (x *OnboardOptions) GetBulk() *BulkOptions {
+	if x == nil || x.Bulk == nil {
+		return nil
+	}
+	return x.Bulk
+}
+
+// GetCheckpoint returns the Checkpoint field if it's non-nil, zero value otherwise.
+func (x *OnboardOptions) GetCheckpoint() *OnboardCheckpoint {
+	if x == nil || x.Checkpoint == nil {
+		return nil
+	}
+	return x.Checkpoint
+}
+
+// GetBreakpoints returns the Breakpoints field if it's non-nil, zero value otherwise.
+func (x *Policy) GetBreakpoints() *PolicyBreakpoints {
+	if x == nil || x.Breakpoints == nil {
+		return nil
+	}
+	return x.Breakpoints
+}
+
+// GetDateCreated returns the DateCreated field if it's non-nil, zero value otherwise.
+func (x *Policy) GetDateCreated() string {
+	if x == nil || x.DateCreated == nil {
+		return ""
+	}
+	return *x.DateCreated
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (x *Policy) GetID() string {
+	if x == nil || x.ID == nil {
+		return ""
+	}
+	return *x.ID
+}
+
+// GetOutput returns the Output field if it's non-nil, zero value otherwise.
+func (x *Policy) GetOutput() *PolicyOutput {
+	if x == nil || x.Output == nil {
+		return nil
+	}
+	return x.Output
+}
+
+// GetPreviousVersion returns the PreviousVersion field if it's non-nil, zero value otherwise.
+func (x *Policy) GetPreviousVersion() int {
+	if x == nil || x.PreviousVersion == nil {
+		return 0
+	}
+	return *x.PreviousVersion
+}
+
+// GetRolloutDuration returns the RolloutDuration field if it's non-nil, zero value otherwise.
+func (x *Policy) GetRolloutDuration() int {
+	if x == nil || x.RolloutDuration == nil {
+		return 0
+	}
+	return *x.RolloutDuration
+}
+
+// GetUser returns the User field if it's non-nil, zero value otherwise.
+func (x *Policy) GetUser() string {
+	if x == nil || x.User == nil {
+		return ""
+	}
+	return *x.User
+}
+
+// GetVersion returns the Version field if it's non-nil, zero value otherwise.
+func (x *Policy) GetVersion() int {
+	if x == nil || x.Version == nil {
+		return 0
+	}
+	return *x.Version
+}
+
+// GetTotalItems returns the TotalItems field if it's non-nil, zero value otherwise.
+func (x *PolicyHistory) GetTotalItems() int {
+	if x == nil || x.TotalItems == nil {
+		return 0
+	}
+	return *x.TotalItems
+}
+
+// GetAction returns the Action field if it's non-nil, zero value otherwise.
+func (x *PolicyHistoryItem) GetAction() string {
+	if x == nil || x.Action == nil {
+		return ""
+	}
+	return *x.Action
+}
+
+// GetDateCreated returns the DateCreated field if it's non-nil, zero value otherwise.
+func (x *PolicyHistoryItem) GetDateCreated() string {
+	if x == nil || x.DateCreated == nil {
+		return ""
+	}
+	return *x.DateCreated
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (x *PolicyHistoryItem) GetID() string {
+	if x == nil || x.ID == nil {
+		return ""
+	}
+	return *x.ID
+}
+
+// GetUser returns the User field if it's non-nil, zero value otherwise.
+func (x *PolicyHistoryItem) GetUser() string {
+	if x == nil || x.User == nil {
+		return ""
+	}
+	return *x.User
+}
+
+// GetVersion returns the Version field if it's non-nil, zero value otherwise.
+func (x *PolicyHistoryItem) GetVersion() int {
+	if x == nil || x.Version == nil {
+		return 0
+	}
+	return *x.Version
+}
+
+// GetTotalItems returns the TotalItems field if it's non-nil, zero value otherwise.
+func (x *PolicyList) GetTotalItems() int {
+	if x == nil || x.TotalItems == nil {
+		return 0
+	}
+	return *x.TotalItems
+}
+
+// GetAdaptiveQuality returns the AdaptiveQuality field if it's non-nil, zero value otherwise.
+func (x *PolicyOutput) GetAdaptiveQuality() int {
+	if x == nil || x.AdaptiveQuality == nil {
+		return 0
+	}
+	return *x.AdaptiveQuality
+}
+
+// GetPerceptualQuality returns the PerceptualQuality field if it's non-nil, zero value otherwise.
+func (x *PolicyOutput) GetPerceptualQuality() string {
+	if x == nil || x.PerceptualQuality == nil {
+		return ""
+	}
+	return *x.PerceptualQuality
+}
+
+// GetPreferModernFormats returns the PreferModernFormats field if it's non-nil, zero value otherwise.
+func (x *PolicyOutput) GetPreferModernFormats() bool {
+	if x == nil || x.PreferModernFormats == nil {
+		return false
+	}
+	return *x.PreferModernFormats
+}
+
+// GetQuality returns the Quality field if it's non-nil, zero value otherwise.
+func (x *PolicyOutput) GetQuality() int {
+	if x == nil || x.Quality == nil {
+		return 0
+	}
+	return *x.Quality
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (x *PolicySet) GetID() string {
+	if x == nil || x.ID == nil {
+		return ""
+	}
+	return *x.ID
+}
+
+// GetLastModified returns the LastModified field if it's non-nil, zero value otherwise.
+func (x *PolicySet) GetLastModified() string {
+	if x == nil || x.LastModified == nil {
+		return ""
+	}
+	return *x.LastModified
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (x *PolicySet) GetName() string {
+	if x == nil || x.Name == nil {
+		return ""
+	}
+	return *x.Name
+}
+
+// GetRegion returns the Region field if it's non-nil, zero value otherwise.
+func (x *PolicySet) GetRegion() string {
+	if x == nil || x.Region == nil {
+		return ""
+	}
+	return *x.Region
+}
+
+// GetType returns the Type field if it's non-nil, zero value otherwise.
+func (x *PolicySet) GetType() string {
+	if x == nil || x.Type == nil {
+		return ""
+	}
+	return *x.Type
+}
+
+// GetUser returns the User field if it's non-nil, zero value otherwise.
+func (x *PolicySet) GetUser() string {
+	if x == nil || x.User == nil {
+		return ""
+	}
+	return *x.User
+}
+
+// GetDescription returns the Description field if it's non-nil, zero value otherwise.
+func (x *PolicyUpdateResponse) GetDescription() string {
+	if x == nil || x.Description == nil {
+		return ""
+	}
+	return *x.Description
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (x *PolicyUpdateResponse) GetID() string {
+	if x == nil || x.ID == nil {
+		return ""
+	}
+	return *x.ID
+}
+
+// GetOperationPerformed returns the OperationPerformed field if it's non-nil, zero value otherwise.
+func (x *PolicyUpdateResponse) GetOperationPerformed() string {
+	if x == nil || x.OperationPerformed == nil {
+		return ""
+	}
+	return *x.OperationPerformed
+}
+
+// GetDetail returns the Detail field if it's non-nil, zero value otherwise.
+func (x *PurgeResult) GetDetail() string {
+	if x == nil || x.Detail == nil {
+		return ""
+	}
+	return *x.Detail
+}
+
+// GetEstimatedSeconds returns the EstimatedSeconds field if it's non-nil, zero value otherwise.
+func (x *PurgeResult) GetEstimatedSeconds() int {
+	if x == nil || x.EstimatedSeconds == nil {
+		return 0
+	}
+	return *x.EstimatedSeconds
+}
+
+// GetHTTPStatus returns the HTTPStatus field if it's non-nil, zero value otherwise.
+func (x *PurgeResult) GetHTTPStatus() int {
+	if x == nil || x.HTTPStatus == nil {
+		return 0
+	}
+	return *x.HTTPStatus
+}
+
+// GetPurgeID returns the PurgeID field if it's non-nil, zero value otherwise.
+func (x *PurgeResult) GetPurgeID() string {
+	if x == nil || x.PurgeID == nil {
+		return ""
+	}
+	return *x.PurgeID
+}
+
+// GetSupportID returns the SupportID field if it's non-nil, zero value otherwise.
+func (x *PurgeResult) GetSupportID() string {
+	if x == nil || x.SupportID == nil {
+		return ""
+	}
+	return *x.SupportID
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (x *RecordSet) GetName() string {
+	if x == nil || x.Name == nil {
+		return ""
+	}
+	return *x.Name
+}
+
+// GetState returns the State field if it's non-nil, zero value otherwise.
+func (x *RecordSet) GetState() string {
+	if x == nil || x.State == nil {
+		return ""
+	}
+	return *x.State
+}
+
+// GetTTL returns the TTL field if it's non-nil, zero value otherwise.
+func (x *RecordSet) GetTTL() int {
+	if x == nil || x.TTL == nil {
+		return 0
+	}
+	return *x.TTL
+}
+
+// GetType returns the Type field if it's non-nil, zero value otherwise.
+func (x *RecordSet) GetType() string {
+	if x == nil || x.Type == nil {
+		return ""
+	}
+	return *x.Type
+}
+
+// GetTTL returns the TTL field if it's non-nil, zero value otherwise.
+func (x *RecordSetCreateRequest) GetTTL() int {
+	if x == nil || x.TTL == nil {
+		return 0
+	}
+	return *x.TTL
+}
+
+// GetLabel returns the Label field if it's non-nil, zero value otherwise.
+func (x *ReportColumn) GetLabel() string {
+	if x == nil || x.Label == nil {
+		return ""
+	}
+	return *x.Label
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (x *ReportColumn) GetName() string {
+	if x == nil || x.Name == nil {
+		return ""
+	}
+	return *x.Name
+}
+
+// GetMetadata returns the Metadata field if it's non-nil, zero value otherwise.
+func (x *ReportData) GetMetadata() *ReportMetadata {
+	if x == nil || x.Metadata == nil {
+		return nil
+	}
+	return x.Metadata
+}
+
+// GetDescription returns the Description field if it's non-nil, zero value otherwise.
+func (x *ReportFilter) GetDescription() string {
+	if x == nil || x.Description == nil {
+		return ""
+	}
+	return *x.Description
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (x *ReportFilter) GetName() string {
+	if x == nil || x.Name == nil {
+		return ""
+	}
+	return *x.Name
+}
+
+// GetType returns the Type field if it's non-nil, zero value otherwise.
+func (x *ReportFilter) GetType() string {
+	if x == nil || x.Type == nil {
+		return ""
+	}
+	return *x.Type
+}
+
+// GetAvailableDataEnds returns the AvailableDataEnds field if it's non-nil, zero value otherwise.
+func (x *ReportMetadata) GetAvailableDataEnds() string {
+	if x == nil || x.AvailableDataEnds == nil {
+		return ""
+	}
+	return *x.AvailableDataEnds
+}
+
+// GetEnd returns the End field if it's non-nil, zero value otherwise.
+func (x *ReportMetadata) GetEnd() string {
+	if x == nil || x.End == nil {
+		return ""
+	}
+	return *x.End
+}
+
+// GetInterval returns the Interval field if it's non-nil, zero value otherwise.
+func (x *ReportMetadata) GetInterval() string {
+	if x == nil || x.Interval == nil {
+		return ""
+	}
+	return *x.Interval
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (x *ReportMetadata) GetName() string {
+	if x == nil || x.Name == nil {
+		return ""
+	}
+	return *x.Name
+}
+
+// GetObjectType returns the ObjectType field if it's non-nil, zero value otherwise.
+func (x *ReportMetadata) GetObjectType() string {
+	if x == nil || x.ObjectType == nil {
+		return ""
+	}
+	return *x.ObjectType
+}
+
+// GetOutputType returns the OutputType field if it's non-nil, zero value otherwise.
+func (x *ReportMetadata) GetOutputType() string {
+	if x == nil || x.OutputType == nil {
+		return ""
+	}
+	return *x.OutputType
+}
+
+// GetStart returns the Start field if it's non-nil, zero value otherwise.
+func (x *ReportMetadata) GetStart() string {
+	if x == nil || x.Start == nil {
+		return ""
+	}
+	return *x.Start
+}
+
+// GetSuppliedDataEnds returns the SuppliedDataEnds field if it's non-nil, zero value otherwise.
+func (x *ReportMetadata) GetSuppliedDataEnds() string {
+	if x == nil || x.SuppliedDataEnds == nil {
+		return ""
+	}
+	return *x.SuppliedDataEnds
+}
+
+// GetVersion returns the Version field if it's non-nil, zero value otherwise.
+func (x *ReportMetadata) GetVersion() string {
+	if x == nil || x.Version == nil {
+		return ""
+	}
+	return string(*x.Version)
+}
+
+// GetDescription returns the Description field if it's non-nil, zero value otherwise.
+func (x *ReportMetric) GetDescription() string {
+	if x == nil || x.Description == nil {
+		return ""
+	}
+	return *x.Description
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (x *ReportMetric) GetName() string {
+	if x == nil || x.Name == nil {
+		return ""
+	}
+	return *x.Name
+}
+
+// GetUnit returns the Unit field if it's non-nil, zero value otherwise.
+func (x *ReportMetric) GetUnit() string {
+	if x == nil || x.Unit == nil {
+		return ""
+	}
+	return *x.Unit
+}
+
+// GetErr returns the Err field if it's non-nil, zero value otherwise.
+func (x *ReportRangeError) GetErr() *AkamaiError {
+	if x == nil || x.Err == nil {
+		return nil
+	}
+	return x.Err
+}
+
+// GetBusinessObjectName returns the BusinessObjectName field if it's non-nil, zero value otherwise.
+func (x *ReportVersion) GetBusinessObjectName() string {
+	if x == nil || x.BusinessObjectName == nil {
+		return ""
+	}
+	return *x.BusinessObjectName
+}
+
+// GetDataRetentionDays returns the DataRetentionDays field if it's non-nil, zero value otherwise.
+func (x *ReportVersion) GetDataRetentionDays() int {
+	if x == nil || x.DataRetentionDays == nil {
+		return 0
+	}
+	return *x.DataRetentionDays
+}
+
+// GetDescription returns the Description field if it's non-nil, zero value otherwise.
+func (x *ReportVersion) GetDescription() string {
+	if x == nil || x.Description == nil {
+		return ""
+	}
+	return *x.Description
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (x *ReportVersion) GetName() string {
+	if x == nil || x.Name == nil {
+		return ""
+	}
+	return *x.Name
+}
+
+// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+func (x *ReportVersion) GetStatus() string {
+	if x == nil || x.Status == nil {
+		return ""
+	}
+	return *x.Status
+}
+
+// GetVersion returns the Version field if it's non-nil, zero value otherwise.
+func (x *ReportVersion) GetVersion() int {
+	if x == nil || x.Version == nil {
+		return 0
+	}
+	return int(*x.Version)
+}
+
+// GetTimings returns the Timings field if it's non-nil, zero value otherwise.
+func (x *Response) GetTimings() *Timings {
+	if x == nil || x.Timings == nil {
+		return nil
+	}
+	return x.Timings
+}
+
+// GetCreatedBy returns the CreatedBy field if it's non-nil, zero value otherwise.
+func (x *Sandbox) GetCreatedBy() string {
+	if x == nil || x.CreatedBy == nil {
+		return ""
+	}
+	return *x.CreatedBy
+}
+
+// GetCreatedDate returns the CreatedDate field if it's non-nil, zero value otherwise.
+func (x *Sandbox) GetCreatedDate() string {
+	if x == nil || x.CreatedDate == nil {
+		return ""
+	}
+	return *x.CreatedDate
+}
+
+// GetIsClonable returns the IsClonable field if it's non-nil, zero value otherwise.
+func (x *Sandbox) GetIsClonable() bool {
+	if x == nil || x.IsClonable == nil {
+		return false
+	}
+	return *x.IsClonable
+}
+
+// GetJWTToken returns the JWTToken field if it's non-nil, zero value otherwise.
+func (x *Sandbox) GetJWTToken() string {
+	if x == nil || x.JWTToken == nil {
+		return ""
+	}
+	return *x.JWTToken
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (x *Sandbox) GetName() string {
+	if x == nil || x.Name == nil {
+		return ""
+	}
+	return *x.Name
+}
+
+// GetRequestHints returns the RequestHints field if it's non-nil, zero value otherwise.
+func (x *Sandbox) GetRequestHints() *SandboxRequestHints {
+	if x == nil || x.RequestHints == nil {
+		return nil
+	}
+	return x.RequestHints
+}
+
+// GetSandboxID returns the SandboxID field if it's non-nil, zero value otherwise.
+func (x *Sandbox) GetSandboxID() string {
+	if x == nil || x.SandboxID == nil {
+		return ""
+	}
+	return *x.SandboxID
+}
+
+// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+func (x *Sandbox) GetStatus() string {
+	if x == nil || x.Status == nil {
+		return ""
+	}
+	return *x.Status
+}
+
+// GetCPCode returns the CPCode field if it's non-nil, zero value otherwise.
+func (x *SandboxCreateRequest) GetCPCode() *SandboxCPCode {
+	if x == nil || x.CPCode == nil {
+		return nil
+	}
+	return x.CPCode
+}
+
+// GetCreateFromProperty returns the CreateFromProperty field if it's non-nil, zero value otherwise.
+func (x *SandboxCreateRequest) GetCreateFromProperty() *SandboxPropertySpecifier {
+	if x == nil || x.CreateFromProperty == nil {
+		return nil
+	}
+	return x.CreateFromProperty
+}
+
+// GetCPCode returns the CPCode field if it's non-nil, zero value otherwise.
+func (x *SandboxProperty) GetCPCode() int {
+	if x == nil || x.CPCode == nil {
+		return 0
+	}
+	return int(*x.CPCode)
+}
+
+// GetSandboxPropertyID returns the SandboxPropertyID field if it's non-nil, zero value otherwise.
+func (x *SandboxProperty) GetSandboxPropertyID() string {
+	if x == nil || x.SandboxPropertyID == nil {
+		return ""
+	}
+	return *x.SandboxPropertyID
+}
+
+// GetHostname returns the Hostname field if it's non-nil, zero value otherwise.
+func (x *SandboxRequestHints) GetHostname() string {
+	if x == nil || x.Hostname == nil {
+		return ""
+	}
+	return *x.Hostname
+}
+
+// GetIsClonable returns the IsClonable field if it's non-nil, zero value otherwise.
+func (x *SandboxUpdateRequest) GetIsClonable() bool {
+	if x == nil || x.IsClonable == nil {
+		return false
+	}
+	return *x.IsClonable
+}
+
+// GetAttribution returns the Attribution field if it's non-nil, zero value otherwise.
+func (x *SyncChange) GetAttribution() *ChangeAttribution {
+	if x == nil || x.Attribution == nil {
+		return nil
+	}
+	return x.Attribution
+}
+
+// GetCurrent returns the Current field if it's non-nil, zero value otherwise.
+func (x *SyncChange) GetCurrent() *RecordSet {
+	if x == nil || x.Current == nil {
+		return nil
+	}
+	return x.Current
+}
+
+// GetDesired returns the Desired field if it's non-nil, zero value otherwise.
+func (x *SyncChange) GetDesired() *RecordSetCreateRequest {
+	if x == nil || x.Desired == nil {
+		return nil
+	}
+	return x.Desired
+}
+
+// GetBulk returns the Bulk field if it's non-nil, zero value otherwise.
+func (x *SyncOptions) GetBulk() *BulkOptions {
+	if x == nil || x.Bulk == nil {
+		return nil
+	}
+	return x.Bulk
+}
+
+// GetAlgorithm returns the Algorithm field if it's non-nil, zero value otherwise.
+func (x *TSIGKey) GetAlgorithm() string {
+	if x == nil || x.Algorithm == nil {
+		return ""
+	}
+	return *x.Algorithm
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (x *TSIGKey) GetName() string {
+	if x == nil || x.Name == nil {
+		return ""
+	}
+	return *x.Name
+}
+
+// GetSecret returns the Secret field if it's non-nil, zero value otherwise.
+func (x *TSIGKey) GetSecret() string {
+	if x == nil || x.Secret == nil {
+		return ""
+	}
+	return *x.Secret
+}
+
+// GetActivationState returns the ActivationState field if it's non-nil, zero value otherwise.
+func (x *Zone) GetActivationState() ActivationState {
+	if x == nil || x.ActivationState == nil {
+		return ""
+	}
+	return *x.ActivationState
+}
+
+// GetAliasCount returns the AliasCount field if it's non-nil, zero value otherwise.
+func (x *Zone) GetAliasCount() int {
+	if x == nil || x.AliasCount == nil {
+		return 0
+	}
+	return *x.AliasCount
+}
+
+// GetComment returns the Comment field if it's non-nil, zero value otherwise.
+func (x *Zone) GetComment() string {
+	if x == nil || x.Comment == nil {
+		return ""
+	}
+	return *x.Comment
+}
+
+// GetContractID returns the ContractID field if it's non-nil, zero value otherwise.
+func (x *Zone) GetContractID() string {
+	if x == nil || x.ContractID == nil {
+		return ""
+	}
+	return *x.ContractID
+}
+
+// GetEndCustomerID returns the EndCustomerID field if it's non-nil, zero value otherwise.
+func (x *Zone) GetEndCustomerID() string {
+	if x == nil || x.EndCustomerID == nil {
+		return ""
+	}
+	return *x.EndCustomerID
+}
+
+// GetGroupID returns the GroupID field if it's non-nil, zero value otherwise.
+func (x *Zone) GetGroupID() int {
+	if x == nil || x.GroupID == nil {
+		return 0
+	}
+	return *x.GroupID
+}
+
+// GetLastActivationDate returns the LastActivationDate field if it's non-nil, zero value otherwise.
+func (x *Zone) GetLastActivationDate() string {
+	if x == nil || x.LastActivationDate == nil {
+		return ""
+	}
+	return *x.LastActivationDate
+}
+
+// GetLastModifiedBy returns the LastModifiedBy field if it's non-nil, zero value otherwise.
+func (x *Zone) GetLastModifiedBy() string {
+	if x == nil || x.LastModifiedBy == nil {
+		return ""
+	}
+	return *x.LastModifiedBy
+}
+
+// GetLastModifiedDate returns the LastModifiedDate field if it's non-nil, zero value otherwise.
+func (x *Zone) GetLastModifiedDate() string {
+	if x == nil || x.LastModifiedDate == nil {
+		return ""
+	}
+	return *x.LastModifiedDate
+}
+
+// GetSignAndServe returns the SignAndServe field if it's non-nil, zero value otherwise.
+func (x *Zone) GetSignAndServe() bool {
+	if x == nil || x.SignAndServe == nil {
+		return false
+	}
+	return *x.SignAndServe
+}
+
+// GetSignAndServeAlgo returns the SignAndServeAlgo field if it's non-nil, zero value otherwise.
+func (x *Zone) GetSignAndServeAlgo() string {
+	if x == nil || x.SignAndServeAlgo == nil {
+		return ""
+	}
+	return *x.SignAndServeAlgo
+}
+
+// GetTSIGKey returns the TSIGKey field if it's non-nil, zero value otherwise.
+func (x *Zone) GetTSIGKey() *TSIGKey {
+	if x == nil || x.TSIGKey == nil {
+		return nil
+	}
+	return x.TSIGKey
+}
+
+// GetTarget returns the Target field if it's non-nil, zero value otherwise.
+func (x *Zone) GetTarget() string {
+	if x == nil || x.Target == nil {
+		return ""
+	}
+	return *x.Target
+}
+
+// GetType returns the Type field if it's non-nil, zero value otherwise.
+func (x *Zone) GetType() string {
+	if x == nil || x.Type == nil {
+		return ""
+	}
+	return *x.Type
+}
+
+// GetVersionID returns the VersionID field if it's non-nil, zero value otherwise.
+func (x *Zone) GetVersionID() string {
+	if x == nil || x.VersionID == nil {
+		return ""
+	}
+	return *x.VersionID
+}
+
+// GetZone returns the Zone field if it's non-nil, zero value otherwise.
+func (x *Zone) GetZone() string {
+	if x == nil || x.Zone == nil {
+		return ""
+	}
+	return *x.Zone
+}
+
+// GetMetadata returns the Metadata field if it's non-nil, zero value otherwise.
+func (x *ZoneChange) GetMetadata() *ZoneMetadata {
+	if x == nil || x.Metadata == nil {
+		return nil
+	}
+	return x.Metadata
+}
+
+// GetSignAndServe returns the SignAndServe field if it's non-nil, zero value otherwise.
+func (x *ZoneCreateRequest) GetSignAndServe() bool {
+	if x == nil || x.SignAndServe == nil {
+		return false
+	}
+	return *x.SignAndServe
+}
+
+// GetExpirationDate returns the ExpirationDate field if it's non-nil, zero value otherwise.
+func (x *ZoneDeleteResponse) GetExpirationDate() string {
+	if x == nil || x.ExpirationDate == nil {
+		return ""
+	}
+	return *x.ExpirationDate
+}
+
+// GetFailureCount returns the FailureCount field if it's non-nil, zero value otherwise.
+func (x *ZoneDeleteResponse) GetFailureCount() int {
+	if x == nil || x.FailureCount == nil {
+		return 0
+	}
+	return *x.FailureCount
+}
+
+// GetIsComplete returns the IsComplete field if it's non-nil, zero value otherwise.
+func (x *ZoneDeleteResponse) GetIsComplete() bool {
+	if x == nil || x.IsComplete == nil {
+		return false
+	}
+	return *x.IsComplete
+}
+
+// GetRequestID returns the RequestID field if it's non-nil, zero value otherwise.
+func (x *ZoneDeleteResponse) GetRequestID() string {
+	if x == nil || x.RequestID == nil {
+		return ""
+	}
+	return *x.RequestID
+}
+
+// GetSuccessCount returns the SuccessCount field if it's non-nil, zero value otherwise.
+func (x *ZoneDeleteResponse) GetSuccessCount() int {
+	if x == nil || x.SuccessCount == nil {
+		return 0
+	}
+	return *x.SuccessCount
+}
+
+// GetZonesSubmitted returns the ZonesSubmitted field if it's non-nil, zero value otherwise.
+func (x *ZoneDeleteResponse) GetZonesSubmitted() int {
+	if x == nil || x.ZonesSubmitted == nil {
+		return 0
+	}
+	return *x.ZonesSubmitted
+}
+
+// GetRequestID returns the RequestID field if it's non-nil, zero value otherwise.
+func (x *ZoneDeleteResult) GetRequestID() string {
+	if x == nil || x.RequestID == nil {
+		return ""
+	}
+	return *x.RequestID
+}
+
+// GetMetadata returns the Metadata field if it's non-nil, zero value otherwise.
+func (x *ZoneList) GetMetadata() *ZoneListMetadata {
+	if x == nil || x.Metadata == nil {
+		return nil
+	}
+	return x.Metadata
+}
+
+// GetPage returns the Page field if it's non-nil, zero value otherwise.
+func (x *ZoneListMetadata) GetPage() int {
+	if x == nil || x.Page == nil {
+		return 0
+	}
+	return *x.Page
+}
+
+// GetPageSize returns the PageSize field if it's non-nil, zero value otherwise.
+func (x *ZoneListMetadata) GetPageSize() int {
+	if x == nil || x.PageSize == nil {
+		return 0
+	}
+	return *x.PageSize
+}
+
+// GetShowAll returns the ShowAll field if it's non-nil, zero value otherwise.
+func (x *ZoneListMetadata) GetShowAll() bool {
+	if x == nil || x.ShowAll == nil {
+		return false
+	}
+	return *x.ShowAll
+}
+
+// GetTotalElements returns the TotalElements field if it's non-nil, zero value otherwise.
+func (x *ZoneListMetadata) GetTotalElements() int {
+	if x == nil || x.TotalElements == nil {
+		return 0
+	}
+	return *x.TotalElements
+}
+
+// GetPage returns the Page field if it's non-nil, zero value otherwise.
+func (x *ZoneListOptions) GetPage() int {
+	if x == nil || x.Page == nil {
+		return 0
+	}
+	return *x.Page
+}
+
+// GetPageSize returns the PageSize field if it's non-nil, zero value otherwise.
+func (x *ZoneListOptions) GetPageSize() int {
+	if x == nil || x.PageSize == nil {
+		return 0
+	}
+	return *x.PageSize
+}
+
+// GetActivationState returns the ActivationState field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetActivationState() ActivationState {
+	if x == nil || x.ActivationState == nil {
+		return ""
+	}
+	return *x.ActivationState
+}
+
+// GetAliasCount returns the AliasCount field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetAliasCount() int {
+	if x == nil || x.AliasCount == nil {
+		return 0
+	}
+	return *x.AliasCount
+}
+
+// GetComment returns the Comment field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetComment() string {
+	if x == nil || x.Comment == nil {
+		return ""
+	}
+	return *x.Comment
+}
+
+// GetContractID returns the ContractID field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetContractID() string {
+	if x == nil || x.ContractID == nil {
+		return ""
+	}
+	return *x.ContractID
+}
+
+// GetEndCustomerID returns the EndCustomerID field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetEndCustomerID() string {
+	if x == nil || x.EndCustomerID == nil {
+		return ""
+	}
+	return *x.EndCustomerID
+}
+
+// GetGroupID returns the GroupID field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetGroupID() int {
+	if x == nil || x.GroupID == nil {
+		return 0
+	}
+	return *x.GroupID
+}
+
+// GetLastActivationDate returns the LastActivationDate field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetLastActivationDate() string {
+	if x == nil || x.LastActivationDate == nil {
+		return ""
+	}
+	return *x.LastActivationDate
+}
+
+// GetLastModifiedBy returns the LastModifiedBy field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetLastModifiedBy() string {
+	if x == nil || x.LastModifiedBy == nil {
+		return ""
+	}
+	return *x.LastModifiedBy
+}
+
+// GetLastModifiedDate returns the LastModifiedDate field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetLastModifiedDate() string {
+	if x == nil || x.LastModifiedDate == nil {
+		return ""
+	}
+	return *x.LastModifiedDate
+}
+
+// GetSignAndServe returns the SignAndServe field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetSignAndServe() bool {
+	if x == nil || x.SignAndServe == nil {
+		return false
+	}
+	return *x.SignAndServe
+}
+
+// GetSignAndServeAlgorithm returns the SignAndServeAlgorithm field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetSignAndServeAlgorithm() string {
+	if x == nil || x.SignAndServeAlgorithm == nil {
+		return ""
+	}
+	return *x.SignAndServeAlgorithm
+}
+
+// GetTSIGKey returns the TSIGKey field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetTSIGKey() *TSIGKey {
+	if x == nil || x.TSIGKey == nil {
+		return nil
+	}
+	return x.TSIGKey
+}
+
+// GetTarget returns the Target field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetTarget() string {
+	if x == nil || x.Target == nil {
+		return ""
+	}
+	return *x.Target
+}
+
+// GetType returns the Type field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetType() string {
+	if x == nil || x.Type == nil {
+		return ""
+	}
+	return *x.Type
+}
+
+// GetVersionId returns the VersionId field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetVersionId() string {
+	if x == nil || x.VersionId == nil {
+		return ""
+	}
+	return *x.VersionId
+}
+
+// GetZone returns the Zone field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadata) GetZone() string {
+	if x == nil || x.Zone == nil {
+		return ""
+	}
+	return *x.Zone
+}
+
+// GetMetadata returns the Metadata field if it's non-nil, zero value otherwise.
+func (x *ZoneMetadataResult) GetMetadata() *ZoneMetadata {
+	if x == nil || x.Metadata == nil {
+		return nil
+	}
+	return x.Metadata
+}
+
+// GetMetadata returns the Metadata field if it's non-nil, zero value otherwise.
+func (x *ZoneSummary) GetMetadata() *ZoneMetadata {
+	if x == nil || x.Metadata == nil {
+		return nil
+	}
+	return x.Metadata
+}
+
+// GetActivationState returns the ActivationState field if it's non-nil, zero value otherwise.
+func (x *ZoneVersion) GetActivationState() ActivationState {
+	if x == nil || x.ActivationState == nil {
+		return ""
+	}
+	return *x.ActivationState
+}
+
+// GetLastActivationDate returns the LastActivationDate field if it's non-nil, zero value otherwise.
+func (x *ZoneVersion) GetLastActivationDate() string {
+	if x == nil || x.LastActivationDate == nil {
+		return ""
+	}
+	return *x.LastActivationDate
+}
+
+// GetLastModifiedBy returns the LastModifiedBy field if it's non-nil, zero value otherwise.
+func (x *ZoneVersion) GetLastModifiedBy() string {
+	if x == nil || x.LastModifiedBy == nil {
+		return ""
+	}
+	return *x.LastModifiedBy
+}
+
+// GetLastModifiedDate returns the LastModifiedDate field if it's non-nil, zero value otherwise.
+func (x *ZoneVersion) GetLastModifiedDate() string {
+	if x == nil || x.LastModifiedDate == nil {
+		return ""
+	}
+	return *x.LastModifiedDate
+}
+
+// GetVersionID returns the VersionID field if it's non-nil, zero value otherwise.
+func (x *ZoneVersion) GetVersionID() string {
+	if x == nil || x.VersionID == nil {
+		return ""
+	}
+	return *x.VersionID
+}
+
+// GetMetadata returns the Metadata field if it's non-nil, zero value otherwise.
+func (x *ZoneVersionList) GetMetadata() *ZoneVersionListMetadata {
+	if x == nil || x.Metadata == nil {
+		return nil
+	}
+	return x.Metadata
+}
+
+// GetPage returns the Page field if it's non-nil, zero value otherwise.
+func (x *ZoneVersionListMetadata) GetPage() int {
+	if x == nil || x.Page == nil {
+		return 0
+	}
+	return *x.Page
+}
+
+// GetPageSize returns the PageSize field if it's non-nil, zero value otherwise.
+func (x *ZoneVersionListMetadata) GetPageSize() int {
+	if x == nil || x.PageSize == nil {
+		return 0
+	}
+	return *x.PageSize
+}
+
+// GetShowAll returns the ShowAll field if it's non-nil, zero value otherwise.
+func (x *ZoneVersionListMetadata) GetShowAll() bool {
+	if x == nil || x.ShowAll == nil {
+		return false
+	}
+	return *x.ShowAll
+}
+
+// GetTotalElements returns the TotalElements field if it's non-nil, zero value otherwise.
+func (x *ZoneVersionListMetadata) GetTotalElements() int {
+	if x == nil || x.TotalElements == nil {
+		return 0
+	}
+	return *x.TotalElements
+}
+
+// GetZone returns the Zone field if it's non-nil, zero value otherwise.
+func (x *ZoneVersionListMetadata) GetZone() string {
+	if x == nil || x.Zone == nil {
+		return ""
+	}
+	return *x.Zone
+}
+
+// GetContract returns the Contract field if it's non-nil, zero value otherwise.
+func (x *ZoneWithContract) GetContract() *Contract {
+	if x == nil || x.Contract == nil {
+		return nil
+	}
+	return x.Contract
+}
+
+// GetZone returns the Zone field if it's non-nil, zero value otherwise.
+func (x *ZoneWithContract) GetZone() *Zone {
+	if x == nil || x.Zone == nil {
+		return nil
+	}
+	return x.Zone
+}
+
+// GetBulk returns the Bulk field if it's non-nil, zero value otherwise.
+func (x *ZonesMetadataOptions) GetBulk() *BulkOptions {
+	if x == nil || x.Bulk == nil {
+		return nil
+	}
+	return x.Bulk
+}
@@ -0,0 +1,109 @@
+package akamai
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointerHelpers(t *testing.T) {
+	assert.Equal(t, "PRIMARY", *String("PRIMARY"))
+	assert.Equal(t, 42, *Int(42))
+	assert.Equal(t, int64(42), *Int64(42))
+	assert.Equal(t, true, *Bool(true))
+
+	assert.Equal(t, "PRIMARY", StringValue(String("PRIMARY")))
+	assert.Equal(t, 42, IntValue(Int(42)))
+	assert.Equal(t, int64(42), Int64Value(Int64(42)))
+	assert.Equal(t, true, BoolValue(Bool(true)))
+
+	assert.Equal(t, "", StringValue(nil))
+	assert.Equal(t, 0, IntValue(nil))
+	assert.Equal(t, int64(0), Int64Value(nil))
+	assert.Equal(t, false, BoolValue(nil))
+}
+
+func TestAccessorsNil(t *testing.T) {
+	var zm *ZoneMetadata
+	assert.Equal(t, "", zm.GetLastActivationDate())
+	assert.Equal(t, 0, zm.GetAliasCount())
+	assert.Equal(t, false, zm.GetSignAndServe())
+
+	var zl *ZoneList
+	assert.Equal(t, 0, zl.GetMetadata().GetTotalElements())
+
+	z := &Zone{Zone: String("example.com")}
+	assert.Equal(t, "example.com", z.GetZone())
+	assert.Equal(t, "", z.GetLastActivationDate())
+}
+
+var basicTypes = map[string]bool{"bool": true, "float64": true, "int": true, "int64": true, "string": true}
+
+// TestAccessorsUpToDate makes sure every exported pointer field has an accessor,
+// so new fields don't go without one because go generate wasn't run.
+func TestAccessorsUpToDate(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	methods := map[string]bool{}
+	var fields []string
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					if d.Recv == nil || len(d.Recv.List) != 1 {
+						continue
+					}
+					if star, ok := d.Recv.List[0].Type.(*ast.StarExpr); ok {
+						if ident, ok := star.X.(*ast.Ident); ok {
+							methods[ident.Name+"."+d.Name.Name] = true
+						}
+					}
+				case *ast.GenDecl:
+					if d.Tok != token.TYPE {
+						continue
+					}
+					for _, spec := range d.Specs {
+						ts := spec.(*ast.TypeSpec)
+						st, ok := ts.Type.(*ast.StructType)
+						if !ok || !ts.Name.IsExported() || ts.Name.Name == "Client" {
+							continue
+						}
+						for _, field := range st.Fields.List {
+							star, ok := field.Type.(*ast.StarExpr)
+							if !ok {
+								continue
+							}
+							ident, ok := star.X.(*ast.Ident)
+							if !ok || !ident.IsExported() && !basicTypes[ident.Name] {
+								continue
+							}
+							for _, name := range field.Names {
+								if name.IsExported() {
+									fields = append(fields, ts.Name.Name+".Get"+name.Name)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, f := range fields {
+		if !methods[f] {
+			t.Errorf("missing accessor %v, run go generate ./akamai", f)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package akamai
+
+import (
+	"context"
+	"net/http"
+)
+
+type accountSwitchKeyKey struct{}
+
+// WithAccountSwitchKey returns a copy of ctx carrying the account switch key of the
+// requests made with it, which takes precedence over the client's AccountSwitchKey. It
+// lets one client act on several accounts. The methods that make several requests,
+// such as the ListAll helpers and the pollers, make them all with the context they are
+// given, and so on the same account.
+func WithAccountSwitchKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, accountSwitchKeyKey{}, key)
+}
+
+// AccountSwitchKey returns the account switch key set on ctx with WithAccountSwitchKey,
+// if any.
+func AccountSwitchKey(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	key, _ := ctx.Value(accountSwitchKeyKey{}).(string)
+	return key
+}
+
+// setAccountSwitchKey sends req with the account switch key of ctx, if it has one and
+// the request was made for another account. As the key is part of the query, the
+// request is signed again.
+func (c *Client) setAccountSwitchKey(ctx context.Context, req *http.Request) error {
+	key := AccountSwitchKey(ctx)
+	if key == "" {
+		return nil
+	}
+
+	if req.URL.Query().Get("accountSwitchKey") == key {
+		return nil
+	}
+	req.URL.RawQuery = setQueryParam(req.URL.RawQuery, "accountSwitchKey", key)
+
+	return c.signRequest(req, nil)
+}
+
+// accountCacheKey returns key qualified by the account switch key of ctx, so that the
+// data cached for one account is not served to another.
+func accountCacheKey(ctx context.Context, key string) string {
+	if account := AccountSwitchKey(ctx); account != "" {
+		return "account=" + account + " " + key
+	}
+	return key
+}
@@ -0,0 +1,121 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAccountSwitchKey(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var keys []string
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		if err := VerifyRequest(r, client.Credentials); err != nil {
+			t.Errorf("expect a valid signature, got %v", err)
+		}
+		keys = append(keys, r.URL.Query().Get("accountSwitchKey"))
+		fmt.Fprint(w, `{"metadata": {"totalElements": 0}, "zones": []}`)
+	})
+
+	ctx := context.Background()
+	customer := WithAccountSwitchKey(ctx, "1-CUSTOMER")
+	assert.Equal(t, "1-CUSTOMER", AccountSwitchKey(customer))
+	assert.Equal(t, "", AccountSwitchKey(ctx))
+
+	list := func(ctx context.Context) {
+		t.Helper()
+		if _, _, err := client.FastDNSv2.ListZones(ctx, &ZoneListOptions{Types: "PRIMARY"}); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	}
+
+	list(ctx)
+	list(customer)
+
+	client.AccountSwitchKey = "1-CLIENT"
+	list(ctx)
+	list(customer)
+
+	assert.Equal(t, []string{"", "1-CUSTOMER", "1-CLIENT", "1-CUSTOMER"}, keys)
+}
+
+// TestWithAccountSwitchKeyRawQuery checks that the key of the context is appended to the
+// query as it is encoded, and that the request is signed over the query it is sent with.
+func TestWithAccountSwitchKeyRawQuery(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.AccountSwitchKey = "1-CLIENT"
+
+	var queries []string
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		if err := VerifyRequest(r, client.Credentials); err != nil {
+			t.Errorf("expect a valid signature, got %v", err)
+		}
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprint(w, `{"metadata": {"totalElements": 0}, "zones": []}`)
+	})
+
+	req, err := client.NewRequest("GET", "config-dns/v2/zones?search=a%20b&z=1&a=2", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if _, err := client.Do(WithAccountSwitchKey(context.Background(), "1-CUSTOMER"), req, nil); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"search=a%20b&z=1&a=2&accountSwitchKey=1-CUSTOMER"}, queries)
+}
+
+func TestWithAccountSwitchKeyListAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var keys []string
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.URL.Query().Get("accountSwitchKey"))
+		if r.URL.Query().Get("page") == "1" {
+			fmt.Fprint(w, `{"metadata": {"totalElements": 2}, "zones": [{"zone": "a.com"}]}`)
+		} else {
+			fmt.Fprint(w, `{"metadata": {"totalElements": 2}, "zones": [{"zone": "b.com"}]}`)
+		}
+	})
+
+	client.AccountSwitchKey = "1-CLIENT"
+	ctx := WithAccountSwitchKey(context.Background(), "1-CUSTOMER")
+	zones, err := client.FastDNSv2.ListAllZones(ctx, &ZoneListOptions{PageSize: Int(1)})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, zones, 2)
+	assert.Equal(t, []string{"1-CUSTOMER", "1-CUSTOMER"}, keys, "every page is listed on the same account")
+}
+
+func TestDataCacheAccountSwitchKey(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/config-dns/v2/data/contracts", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"contracts": [{"contractId": "%v"}]}`, r.URL.Query().Get("accountSwitchKey"))
+	})
+
+	cache := NewDataCache(client.FastDNSv2, time.Minute)
+	client.FastDNSv2 = cache
+
+	for _, key := range []string{"1-A", "1-B", "1-A"} {
+		contracts, _, err := cache.ListContracts(WithAccountSwitchKey(context.Background(), key), nil)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		if assert.Len(t, contracts, 1) {
+			assert.Equal(t, key, contracts[0].GetContractID())
+		}
+	}
+	assert.Equal(t, 2, requests, "each account is cached apart")
+}
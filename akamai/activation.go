@@ -0,0 +1,59 @@
+package akamai
+
+import "errors"
+
+// ActivationState is the activation state of a zone, as reported by its Zone,
+// ZoneMetadata and ZoneVersion. The API may report states the SDK doesn't know: they
+// are kept verbatim, including when encoded back to JSON, and are neither terminal nor
+// errors, so that pollers keep waiting on them.
+type ActivationState string
+
+// Activation states of a zone.
+const (
+	// ZoneNew is the state of a zone that was created but never activated.
+	ZoneNew ActivationState = "NEW"
+
+	// ZoneAdded is the state of a zone whose creation is being propagated.
+	ZoneAdded ActivationState = "ADDED"
+
+	// ZonePending is the state of a zone whose changes are being activated.
+	ZonePending ActivationState = "PENDING"
+
+	// ZoneActive is the activation state of a zone whose changes are being served.
+	ZoneActive ActivationState = "ACTIVE"
+
+	// ZoneInactive is the state of a zone that is not served.
+	ZoneInactive ActivationState = "INACTIVE"
+
+	// ZoneError is the state of a zone whose activation failed.
+	ZoneError ActivationState = "ERROR"
+)
+
+// ActivationStateOf returns a pointer to the ActivationState value passed in.
+func ActivationStateOf(v ActivationState) *ActivationState {
+	return &v
+}
+
+// Known reports whether s is one of the activation states the SDK defines.
+func (s ActivationState) Known() bool {
+	switch s {
+	case ZoneNew, ZoneAdded, ZonePending, ZoneActive, ZoneInactive, ZoneError:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether s is a state a zone stays in until it is changed again:
+// ZoneActive, ZoneInactive or ZoneError.
+func (s ActivationState) IsTerminal() bool {
+	return s == ZoneActive || s == ZoneInactive || s == ZoneError
+}
+
+// IsError reports whether s is ZoneError.
+func (s ActivationState) IsError() bool {
+	return s == ZoneError
+}
+
+// ErrZoneActivationFailed is matched by errors.Is for the errors of the wait helpers
+// that stopped because the zone was in the ZoneError state.
+var ErrZoneActivationFailed = errors.New("zone activation failed")
@@ -0,0 +1,57 @@
+package akamai_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+func TestActivationState(t *testing.T) {
+	tests := []struct {
+		state                    akamai.ActivationState
+		known, terminal, isError bool
+	}{
+		{akamai.ZoneNew, true, false, false},
+		{akamai.ZoneAdded, true, false, false},
+		{akamai.ZonePending, true, false, false},
+		{akamai.ZoneActive, true, true, false},
+		{akamai.ZoneInactive, true, true, false},
+		{akamai.ZoneError, true, true, true},
+		{"MIGRATING", false, false, false},
+		{"active", false, false, false},
+		{"", false, false, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.known, tt.state.Known(), "%q", tt.state)
+		assert.Equal(t, tt.terminal, tt.state.IsTerminal(), "%q", tt.state)
+		assert.Equal(t, tt.isError, tt.state.IsError(), "%q", tt.state)
+	}
+}
+
+func TestActivationStateJSON(t *testing.T) {
+	// States the SDK doesn't know are kept verbatim.
+	for _, in := range []string{
+		`{"zone":"example.com","activationState":"PENDING"}`,
+		`{"zone":"example.com","activationState":"MIGRATING"}`,
+		`{"zone":"example.com"}`,
+	} {
+		var zm akamai.ZoneMetadata
+		if err := json.Unmarshal([]byte(in), &zm); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		out, err := json.Marshal(&zm)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.JSONEq(t, in, string(out))
+	}
+
+	var z akamai.Zone
+	if err := json.Unmarshal([]byte(`{"activationState":"MIGRATING"}`), &z); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, akamai.ActivationState("MIGRATING"), z.GetActivationState())
+	assert.False(t, z.GetActivationState().Known())
+}
@@ -1,3 +1,5 @@
+//go:generate go run gen-accessors.go
+
 package akamai
 
 import (
@@ -7,10 +9,13 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-querystring/query"
 
@@ -22,6 +27,11 @@ const (
 )
 
 // Client creates an Akamai client to make requests against the Akamai API.
+//
+// A Client is safe for concurrent use by multiple goroutines once it is configured. Its
+// exported fields, and the options of its With methods, are read without
+// synchronization by the requests in flight, so they must be set before the client is
+// used and not changed afterwards.
 type Client struct {
 	// HTTP client used to make API calls.
 	client *http.Client
@@ -29,17 +39,129 @@ type Client struct {
 	// User agent used when communicating with the API.
 	UserAgent string
 
+	// AuditActorHeader and AuditReasonHeader are the headers the audit actor and
+	// reason of the context of a request are sent in, as set with WithAuditActor and
+	// WithAuditReason. They default to DefaultAuditActorHeader and
+	// DefaultAuditReasonHeader; empty headers are not sent.
+	AuditActorHeader  string
+	AuditReasonHeader string
+
 	// BaseURL contains the API URL.
 	BaseURL *url.URL
 
+	// serviceBaseURLs holds the base URLs set with WithServiceBaseURL, by the leading
+	// segment of the paths of the service.
+	serviceBaseURLs map[string]*url.URL
+
+	// serviceAccept holds the media types set with WithServiceAccept, by the leading
+	// segment of the paths of the service.
+	serviceAccept map[string]string
+
+	// readOnly and readOnlyAllow are set with WithReadOnly.
+	readOnly      bool
+	readOnlyAllow []string
+
+	// protectedZones are the patterns set with WithProtectedZones.
+	protectedZones []string
+
+	// transport is the transport NewClient built, if it wasn't given an http.Client.
+	transport *http.Transport
+
+	// timings and timingsHook are set with WithTimings.
+	timings     bool
+	timingsHook func(req *http.Request, t *Timings)
+
+	// deprecationHook is set with WithDeprecationHook, and deprecations counts the
+	// responses that reported a deprecation by endpoint.
+	deprecationHook func(d *Deprecation)
+	deprecationsMu  sync.Mutex
+	deprecations    map[string]int
+
+	// idempotencyStore is set with WithIdempotencyStore, and idempotencyLocks
+	// serializes the purges under the same idempotency key.
+	idempotencyStore IdempotencyStore
+	idempotencyLocks *ZoneLocks
+
+	// adaptivePaging is set with WithAdaptivePaging.
+	adaptivePaging *AdaptivePaging
+
+	// pollHook is set with WithPollHook. pollSleep and pollNow replace the sleep and now
+	// of the polls of the wait helpers in tests, so that they don't sleep.
+	pollHook  func(stats *PollStats)
+	pollSleep func(ctx context.Context, d time.Duration) error
+	pollNow   func() time.Time
+
+	// retryClassifier is set with WithRetryClassifier.
+	retryClassifier RetryClassifier
+
+	// wireLogHook and wireLogOptions are set with WithWireLog.
+	wireLogHook    func(l *WireLog)
+	wireLogOptions WireLogOptions
+
+	// lifecycle tracks the requests in flight and the background components, for
+	// Close.
+	lifecycle lifecycle
+
+	// signer is set with WithSigner, and signingDebugHook with WithSigningDebug.
+	signer           RequestSigner
+	signingDebugHook func(req *http.Request, signingString string)
+
 	// Credentials object to use when signing requests.
 	Credentials *credentials.Credentials
 
+	// AccountSwitchKey, if set, is sent as the accountSwitchKey query parameter of every
+	// request, to act on another account than that of the credentials. NewClient sets
+	// it to the account key of the credentials. WithAccountSwitchKey overrides it for
+	// the requests of a context.
+	AccountSwitchKey string
+
+	// DisableNameNormalization makes the FastDNSv2 methods use zone and record names
+	// as given, rather than normalizing them with NormalizeZoneName and
+	// NormalizeRecordName.
+	DisableNameNormalization bool
+
+	// PreserveRecordNameCase makes the FastDNSv2 methods that read record sets return
+	// their names in the case the API does, such as for display, rather than in lower
+	// case. The helpers that match record sets by name, such as PlanRecordSets, ignore
+	// the case of the names either way.
+	PreserveRecordNameCase bool
+
+	// DisableZoneTypeCheck makes CreateRecordSet, UpdateRecordSet and DeleteRecordSet
+	// send their requests without checking that the zone's records are editable. See
+	// ZoneNotEditableError.
+	DisableZoneTypeCheck bool
+
+	// DisableRecordTypeCheck makes CreateRecordSet and UpdateRecordSet send their
+	// requests without checking that the zone supports the record type. See
+	// UnsupportedRecordTypeError.
+	DisableRecordTypeCheck bool
+
+	// ZoneLocks, if set, serializes the operations of the client on the same zone. See
+	// ZoneLocks.
+	ZoneLocks *ZoneLocks
+
+	// zoneTypes caches the types of zones for the zone type check.
+	zoneTypes *ttlCache
+
+	// recordTypes caches the record types of zones for the record type check.
+	recordTypes *ttlCache
+
 	// reuse a single struct rather than allocating one for each service on the heap
 	common service
 
-	// Services of the Akamai API.
-	FastDNSv2 *FastDNSv2Service
+	// fastDNSv2 is the service FastDNSv2 is set to by NewClient. See FastDNSv2Service.
+	fastDNSv2 *FastDNSv2Service
+
+	// Services of the Akamai API. They can be replaced with fakes in tests.
+	FastDNSv2     FastDNSv2API
+	Contracts     ContractsAPI
+	Imaging       ImagingAPI
+	FirewallRules FirewallRulesAPI
+	EdgeKV        EdgeKVAPI
+	Reporting     ReportingAPI
+	HAPI          HAPIAPI
+	Sandbox       SandboxAPI
+	Purge         PurgeAPI
 }
 
 type service struct {
@@ -47,13 +169,17 @@ type service struct {
 }
 
 // NewClient returns an Akamai API client.
-// If no httpClient is provided, http.DefaultClient is used.
+// If no httpClient is provided, one is built with the transport of NewTransport, tuned for
+// the concurrent requests of the bulk and sync methods; see Client.Transport. A given
+// httpClient is used as is, and never modified.
 // The Akamai API uses a unique base URL that is generated for every API client.
 // If this isn't set then there is no default URL we can fall back to and we
 // have to return an error.
 func NewClient(httpClient *http.Client, cc *credentials.Credentials) (*Client, error) {
+	var transport *http.Transport
 	if httpClient == nil {
-		httpClient = http.DefaultClient
+		transport = NewTransport(nil)
+		httpClient = &http.Client{Transport: transport}
 	}
 
 	// If no credentials are set, fall back to .edgerc file, as Akamai docs
@@ -80,50 +206,201 @@ func NewClient(httpClient *http.Client, cc *credentials.Credentials) (*Client, e
 
 	c := &Client{
 		client:      httpClient,
+		transport:   transport,
 		BaseURL:     baseURL,
 		Credentials: cc,
 		UserAgent:   userAgent,
+
+		AccountSwitchKey: creds.AccountKey,
+
+		AuditActorHeader:  DefaultAuditActorHeader,
+		AuditReasonHeader: DefaultAuditReasonHeader,
+
+		zoneTypes:   newTTLCache(zoneTypeTTL),
+		recordTypes: newTTLCache(recordTypesTTL),
 	}
 
 	c.common.client = c
-	c.FastDNSv2 = (*FastDNSv2Service)(&c.common)
+	c.fastDNSv2 = &FastDNSv2Service{client: c}
+	c.FastDNSv2 = c.fastDNSv2
+	c.Contracts = (*ContractsService)(&c.common)
+	c.Imaging = (*ImagingService)(&c.common)
+	c.FirewallRules = (*FirewallRulesService)(&c.common)
+	c.EdgeKV = (*EdgeKVService)(&c.common)
+	c.Reporting = (*ReportingService)(&c.common)
+	c.HAPI = (*HAPIService)(&c.common)
+	c.Sandbox = (*SandboxService)(&c.common)
+	c.Purge = (*PurgeService)(&c.common)
 
 	return c, nil
 }
 
+// WithServiceBaseURL makes the requests whose path starts with the given segment, such
+// as "config-dns" or "papi", go to baseURL rather than BaseURL. This routes the APIs
+// through different gateways. Requests are signed for the host they are sent to, so
+// the gateways must forward them unchanged to Akamai or verify them with the same
+// credentials. Like BaseURL, baseURL needs a trailing slash. WithServiceBaseURL must
+// not be called while the client is in use; it returns c so that calls can be chained.
+func (c *Client) WithServiceBaseURL(service string, baseURL *url.URL) *Client {
+	if c.serviceBaseURLs == nil {
+		c.serviceBaseURLs = map[string]*url.URL{}
+	}
+	c.serviceBaseURLs[strings.Trim(service, "/")] = baseURL
+	return c
+}
+
+// baseURL returns the base URL the request for urlStr is made against.
+func (c *Client) baseURL(urlStr string) *url.URL {
+	if u, ok := c.serviceBaseURLs[serviceOf(urlStr)]; ok {
+		return u
+	}
+	return c.BaseURL
+}
+
+// serviceOf returns the leading segment of the path of urlStr, which names its service.
+func serviceOf(urlStr string) string {
+	service := strings.TrimPrefix(urlStr, "/")
+	if i := strings.IndexAny(service, "/?"); i >= 0 {
+		service = service[:i]
+	}
+	return service
+}
+
+// maxPooledBufferSize is the capacity above which buffers are not returned to their
+// pool, so that a single large body doesn't stay in memory for the life of the program.
+const maxPooledBufferSize = 1 << 20
+
+// encoder is a JSON encoder bound to the buffer it encodes into.
+type encoder struct {
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+var encoderPool = sync.Pool{
+	New: func() interface{} {
+		e := new(encoder)
+		e.enc = json.NewEncoder(&e.buf)
+		e.enc.SetEscapeHTML(false)
+		return e
+	},
+}
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
 // NewRequest creates an API request.
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
-	if !strings.HasSuffix(c.BaseURL.Path, "/") {
-		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
+	var buf []byte
+	var contentType string
+	if body != nil {
+		e := encoderPool.Get().(*encoder)
+		defer func() {
+			if e.buf.Cap() <= maxPooledBufferSize {
+				e.buf.Reset()
+				encoderPool.Put(e)
+			}
+		}()
+
+		err := e.enc.Encode(body)
+		if err != nil {
+			return nil, err
+		}
+
+		// The request holds on to its body until it is sent, so it gets its own copy of
+		// the encoded bytes. The signer hashes the same bytes rather than reading the
+		// request body again.
+		buf = append(make([]byte, 0, e.buf.Len()), e.buf.Bytes()...)
+		contentType = "application/json"
+	}
+
+	return c.newRequest(method, urlStr, buf, contentType)
+}
+
+// NewUploadRequest creates an API request whose body is read from body and sent as is,
+// with the given content type, rather than JSON encoded as by NewRequest. It is meant
+// for bodies such as zone files and JSON patches. Exactly size bytes are read, or all
+// of body if size is negative; a body shorter than size is an error. The bytes are
+// buffered so that the EdgeGrid signature hashes the same bytes that are sent. An empty
+// content type is sent as application/octet-stream.
+func (c *Client) NewUploadRequest(method, urlStr string, body io.Reader, size int64, contentType string) (*http.Request, error) {
+	if body == nil {
+		body = strings.NewReader("")
 	}
 
-	u, err := c.BaseURL.Parse(urlStr)
+	buf := new(bytes.Buffer)
+	var err error
+	if size < 0 {
+		_, err = buf.ReadFrom(body)
+	} else {
+		buf.Grow(int(size))
+		var n int64
+		n, err = io.CopyN(buf, body, size)
+		if err == io.EOF {
+			err = fmt.Errorf("upload body is %d bytes, shorter than its size of %d", n, size)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	var buf io.ReadWriter
-	if body != nil {
-		buf = new(bytes.Buffer)
-		enc := json.NewEncoder(buf)
-		enc.SetEscapeHTML(false)
-		err := enc.Encode(body)
-		if err != nil {
-			return nil, err
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return c.newRequest(method, urlStr, buf.Bytes(), contentType)
+}
+
+// setQueryParam returns rawQuery with the parameter name set to value, in place of any
+// it had. The other parameters are left as they are encoded, as re-encoding the query
+// would turn %20 into + and sort the parameters, which the signature covers.
+func setQueryParam(rawQuery, name, value string) string {
+	var params []string
+	if rawQuery != "" {
+		for _, p := range strings.Split(rawQuery, "&") {
+			if strings.SplitN(p, "=", 2)[0] != name {
+				params = append(params, p)
+			}
 		}
 	}
-	req, err := http.NewRequest(method, u.String(), buf)
+	return strings.Join(append(params, name+"="+url.QueryEscape(value)), "&")
+}
+
+// newRequest creates an API request whose body is sent as is, with the given content type.
+func (c *Client) newRequest(method, urlStr string, body []byte, contentType string) (*http.Request, error) {
+	base := c.baseURL(urlStr)
+	if !strings.HasSuffix(base.Path, "/") {
+		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", base)
+	}
+
+	u, err := base.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	if c.AccountSwitchKey != "" {
+		u.RawQuery = setQueryParam(u.RawQuery, "accountSwitchKey", c.AccountSwitchKey)
+	}
+
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, u.String(), r)
 	if err != nil {
 		return nil, err
 	}
 
 	// We need to sign the request. https://developer.akamai.com/legacy/introduction/Client_Auth.html
-	signer := NewSigner(c.Credentials)
-	signer.Sign(req, buf)
+	if err := c.signRequest(req, body); err != nil {
+		return nil, err
+	}
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
+	req.Header.Set("Accept", c.accept(urlStr))
 
 	if c.UserAgent != "" {
 		req.Header.Set("User-Agent", c.UserAgent)
@@ -132,39 +409,138 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	return req, nil
 }
 
+// Call creates an API request, sends it, and decodes the response into v. It is
+// shorthand for NewRequest followed by Do, for services that need no special handling
+// of the request.
+func (c *Client) Call(ctx context.Context, method, urlStr string, body, v interface{}) (*Response, error) {
+	req, err := c.NewRequest(method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(ctx, req, v)
+}
+
 // Response is an Akamai API response. It wraps http.Response and allows for us to add additional
 // properties in the future.
 type Response struct {
 	*http.Response
+
+	// Location is the URL of the resource a 201 Created response created, from its
+	// Location header, resolved against the URL of the request. It is nil for other
+	// responses and when the header is missing or invalid.
+	Location *url.URL
+
+	// RequestID is the identifier the API gave to the request, from the first of the
+	// requestIDHeaders it sent. Quote it in support cases and audit trails.
+	RequestID string
+
+	// Timings breaks down the time the request took. It is nil unless the client was
+	// set up WithTimings.
+	Timings *Timings
+
+	// BytesWritten is the number of bytes of the body copied into the io.Writer given
+	// to Do as v. If the copy failed, they are the ones written before the error.
+	BytesWritten int64
+
+	// DecodeWarnings lists the elements of a list response that were left out because
+	// they failed to decode. It is only set for the requests made WithLenientDecode.
+	DecodeWarnings []*DecodeWarning
+
+	// Deprecated, Sunset and Warning are what the Deprecation, Sunset and Warning
+	// headers of the response said about the deprecation of the endpoint. See
+	// Deprecation.
+	Deprecated bool
+	Sunset     time.Time
+	Warning    string
+
+	// base resolves the relative links of the response, and linkBody holds its body if
+	// it may contain links. See Links.
+	base     *url.URL
+	linkBody []byte
 }
 
-// Do sends the API request and returns the API response.
+// requestIDHeaders are the headers the APIs echo the identifier of a request in, by
+// preference.
+var requestIDHeaders = []string{"X-Request-Id", "X-Akamai-Request-Id", "X-Trace-Id"}
+
+// requestID returns the identifier of a request from the headers of its response.
+func requestID(h http.Header) string {
+	for _, k := range requestIDHeaders {
+		if id := h.Get(k); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// Do sends the API request and returns the API response. If v is an io.Writer, the
+// response body is copied into it, and an error of the copy, such as a body cut short,
+// is returned; see Response.BytesWritten and WithProgress.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
 	if ctx == nil {
 		// A nil ctx will cause a panic. Just use a background context.
 		ctx = context.Background()
 	}
-	req.WithContext(ctx)
+	if !c.lifecycle.enter() {
+		return nil, ErrClientClosed
+	}
+	defer c.lifecycle.exit()
+	req = req.WithContext(ctx)
+
+	if err := c.checkReadOnly(req); err != nil {
+		return nil, err
+	}
+
+	c.setAuditHeaders(ctx, req)
+	if mediaType := Accept(ctx); mediaType != "" {
+		req.Header.Set("Accept", mediaType)
+	}
+	if etag := IfNoneMatch(ctx); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if err := c.setAccountSwitchKey(ctx, req); err != nil {
+		return nil, err
+	}
+
+	var tt *timingsTrace
+	if c.timings {
+		req, tt = traceTimings(req)
+		defer tt.done(c, req)
+	}
+
+	wl := c.startWireLog(req)
+	if wl != nil {
+		defer wl.done(c)
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
+		wl.fail(err)
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
 		}
 
-		if e, ok := err.(*url.Error); ok {
-			return nil, e
-
-		}
-
-		return nil, err
+		return nil, c.hostError(req, err)
 	}
 
+	wl.response(resp)
 	defer resp.Body.Close()
 
-	response := &Response{Response: resp}
+	response := &Response{Response: resp, base: c.BaseURL, RequestID: requestID(resp.Header)}
+	if tt != nil {
+		response.Timings = tt.t
+	}
+	if resp.StatusCode == http.StatusCreated {
+		response.Location, _ = resp.Location()
+	}
+	c.trackDeprecation(req, resp, response)
+
+	if resp.StatusCode == http.StatusNotModified && IfNoneMatch(ctx) != "" {
+		return response, ErrNotModified
+	}
 
 	err = CheckResponse(resp)
 	if err != nil {
@@ -186,21 +562,69 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	// Do the actual copying into the interface
 	if v != nil {
 		if w, ok := v.(io.Writer); ok {
-			io.Copy(w, resp.Body)
-		} else {
-			decErr := json.NewDecoder(resp.Body).Decode(v)
-			if decErr == io.EOF {
-				decErr = nil // ignore EOF errors caused by empty response body
+			response.BytesWritten, err = copyBody(ctx, w, resp)
+		} else if as, ok := v.(*ArrayStream); ok {
+			if err = checkContentType(req, resp); err == nil {
+				err = as.decode(json.NewDecoder(resp.Body))
 			}
-			if decErr != nil {
-				err = decErr
+		} else if sp, ok := v.(*string); ok && isTextResponse(resp) {
+			// Some APIs answer with plain text messages rather than JSON.
+			b, readErr := ioutil.ReadAll(resp.Body)
+			if readErr != nil {
+				return response, readErr
 			}
+			*sp = string(b)
+		} else if err = checkContentType(req, resp); err == nil {
+			var warnings *[]*DecodeWarning
+			if LenientDecode(ctx) {
+				warnings = &response.DecodeWarnings
+			}
+			err = decodeBody(resp.Body, v, &response.linkBody, warnings)
 		}
 	}
 
 	return response, err
 }
 
+// decodeBody decodes the JSON response body into v. The body is read into a pooled
+// buffer and unmarshaled from there, which allocates less than a json.Decoder. An empty
+// body leaves v untouched. If the body may contain links, a copy is kept in links. If
+// warnings is not nil, the body is decoded leniently into it. See WithLenientDecode.
+func decodeBody(body io.Reader, v interface{}, links *[]byte, warnings *[]*DecodeWarning) error {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			buf.Reset()
+			bufferPool.Put(buf)
+		}
+	}()
+
+	if _, err := buf.ReadFrom(body); err != nil {
+		return err
+	}
+
+	if len(bytes.TrimSpace(buf.Bytes())) == 0 {
+		return nil // ignore errors caused by empty response body
+	}
+
+	if links != nil && mayHaveLinks(buf.Bytes()) {
+		*links = append([]byte(nil), buf.Bytes()...)
+	}
+
+	if warnings != nil {
+		var err error
+		*warnings, err = decodeLenient(buf.Bytes(), v)
+		return err
+	}
+	return json.Unmarshal(buf.Bytes(), v)
+}
+
+// isTextResponse reports whether the response body is plain text rather than JSON.
+func isTextResponse(r *http.Response) bool {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && strings.HasPrefix(mt, "text/")
+}
+
 // CheckResponse checks an API resonse for errors. If an error is found, it is returned.
 // Errors are considered as anything outside of the 200 range of HTTP responses, with the exception
 // being a 202 Accepted response.
@@ -214,15 +638,42 @@ func CheckResponse(r *http.Response) error {
 		return nil
 	}
 
-	var errorResponse AkamaiError
-	data, err := ioutil.ReadAll(r.Body)
-	if err == nil && data != nil {
-		json.Unmarshal(data, &errorResponse)
+	errorResponse := AkamaiError{Response: r, ContentType: r.Header.Get("Content-Type"), RequestID: requestID(r.Header)}
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxErrorBodySize))
+	if err == nil && len(data) > 0 {
+		// Servers that don't set a Content-Type get one sniffed from the body, so
+		// bodies that decode into a problem document are taken as such whatever
+		// their type.
+		err := json.Unmarshal(data, &errorResponse)
+		if err != nil || (!isJSONResponse(r) && !errorResponse.IsAPIError()) {
+			errorResponse.Body = data
+		}
 	}
+	if errorResponse.Status == 0 {
+		errorResponse.Status = r.StatusCode
+	}
+	errorResponse.Maintenance = parseMaintenance(&errorResponse, data, r.Header, time.Now())
 
 	return &errorResponse
 }
 
+// maxErrorBodySize is the number of bytes of the body of an error response that are
+// read and kept in AkamaiError.Body.
+const maxErrorBodySize = 4096
+
+// maxErrorExcerptLen is the length of the excerpt of AkamaiError.Body in the message.
+const maxErrorExcerptLen = 200
+
+// isJSONResponse reports whether the response body is JSON, or of unknown type.
+func isJSONResponse(r *http.Response) bool {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return true
+	}
+	mt, _, err := mime.ParseMediaType(ct)
+	return err == nil && (mt == "application/json" || strings.HasSuffix(mt, "+json"))
+}
+
 // AcceptedError occurs when Akamai returns a 202 Accepted response. This means an asynchronous process
 // has begun and is scheduled on the Akamai side.
 // HTTP 202 is not an error, it's just used to indicate that the results are not ready yet, to check back soon.
@@ -242,14 +693,148 @@ type AkamaiError struct {
 	Status   int    `json:"status"`
 	Title    string `json:"title"`
 	Type     string `json:"type"`
+
+	// Errors lists the fields of the request that are wrong, for the validation
+	// failures whose problem document has an errors array. See FieldErrors.
+	Errors ProblemErrors `json:"errors,omitempty"`
+
+	// Maintenance is the window of the 503 responses the API sends during its
+	// maintenance windows, and nil for the other errors. See ErrServiceMaintenance.
+	Maintenance *MaintenanceWindow `json:"-"`
+
+	// ContentType is the Content-Type of the error response.
+	ContentType string `json:"-"`
+
+	// RequestID is the identifier the API gave to the failed request, if it sent one.
+	// See Response.RequestID.
+	RequestID string `json:"-"`
+
+	// Body holds the first bytes of the error response when it is not a JSON problem
+	// document, such as the HTML pages of gateway errors. It is nil for API errors.
+	Body []byte `json:"-"`
+
+	// Response is the error response, whose body has been read. It is left out of the
+	// JSON form of the error.
+	Response *http.Response `json:"-"`
+}
+
+// akamaiErrorJSON is the JSON form of an AkamaiError, for structured logs.
+type akamaiErrorJSON struct {
+	Method      string             `json:"method,omitempty"`
+	URL         string             `json:"url,omitempty"`
+	Status      int                `json:"status"`
+	Type        string             `json:"type,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Detail      string             `json:"detail,omitempty"`
+	Instance    string             `json:"instance,omitempty"`
+	Errors      ProblemErrors      `json:"errors,omitempty"`
+	Maintenance *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	RequestID   string             `json:"requestId,omitempty"`
+	ContentType string             `json:"contentType,omitempty"`
+	Body        string             `json:"body,omitempty"`
+}
+
+// MarshalJSON encodes the error for structured logs: the method and URL of the request,
+// the status and problem document fields with their errors, the request ID, and an
+// excerpt of a non-JSON body of at most 200 bytes. The Response is never encoded, so
+// the form stays small. It decodes back into an AkamaiError with the same problem
+// document fields.
+func (e *AkamaiError) MarshalJSON() ([]byte, error) {
+	out := akamaiErrorJSON{
+		Status:      e.Status,
+		Type:        e.Type,
+		Title:       e.Title,
+		Detail:      e.Detail,
+		Instance:    e.Instance,
+		Errors:      e.Errors,
+		Maintenance: e.Maintenance,
+		RequestID:   e.RequestID,
+		ContentType: e.ContentType,
+	}
+	if e.Response != nil && e.Response.Request != nil {
+		out.Method = e.Response.Request.Method
+		if u := e.Response.Request.URL; u != nil {
+			out.URL = u.String()
+		}
+	}
+	if e.Body != nil {
+		out.Body = excerpt(e.Body, maxErrorExcerptLen)
+	}
+	return json.Marshal(out)
+}
+
+// Temporary reports whether the request may succeed if made again: it was rate limited
+// with a 429, or failed with a server error. This is for callers implementing their own
+// retries.
+func (e *AkamaiError) Temporary() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= 500
 }
 
 func (e *AkamaiError) Error() string {
+	if e.Body == nil && !e.IsAPIError() {
+		return fmt.Sprintf("HTTP Status: %v. Empty response%v.", e.Status, e.contentType())
+	}
+	if e.Body != nil {
+		return fmt.Sprintf("HTTP Status: %v. Non-JSON response%v: %v", e.Status, e.contentType(), excerpt(e.Body, maxErrorExcerptLen))
+	}
+	if len(e.Errors) > 0 {
+		return fmt.Sprintf("HTTP Status: %v. %v: %v. Errors: %v.", e.Status, e.Title, e.Detail, e.Errors)
+	}
+	if e.Maintenance != nil && !e.Maintenance.End.IsZero() {
+		return fmt.Sprintf("HTTP Status: %v. %v: %v. Maintenance until %v.", e.Status, e.Title, e.Detail, e.Maintenance.End.Format(time.RFC3339))
+	}
 	return fmt.Sprintf("HTTP Status: %v. %v: %v.", e.Status, e.Title, e.Detail)
 }
 
+// Is makes errors.Is(err, ErrServiceMaintenance) report true for the errors of the
+// maintenance windows of the API.
+func (e *AkamaiError) Is(target error) bool {
+	return target == ErrServiceMaintenance && e.Maintenance != nil
+}
+
+// FieldErrors returns the messages of the Errors of the problem document by the JSON
+// pointer or name of the field they are about. It is empty if the document had no
+// errors array.
+func (e *AkamaiError) FieldErrors() map[string]string {
+	return e.Errors.FieldErrors()
+}
+
+func (e *AkamaiError) contentType() string {
+	if e.ContentType == "" {
+		return ""
+	}
+	return " (" + e.ContentType + ")"
+}
+
+// IsAPIError reports whether the error was returned by the API, as a JSON problem
+// document, rather than by a gateway or proxy in front of it.
+func (e *AkamaiError) IsAPIError() bool {
+	return e.Body == nil && (e.Title != "" || e.Detail != "" || e.Type != "")
+}
+
+// excerpt returns b on a single line with its runs of whitespace collapsed, cut to n
+// bytes.
+func excerpt(b []byte, n int) string {
+	s := strings.Join(strings.Fields(string(b)), " ")
+	if len(s) > n {
+		s = strings.ToValidUTF8(s[:n], "") + "..."
+	}
+	return s
+}
+
+// AddOptions adds the parameters in opt as URL query parameters to s. opt must be
+// a struct whose fields may contain "url" tags, as understood by
+// github.com/google/go-querystring. A nil opt leaves s unchanged.
+func AddOptions(s string, opt interface{}) (string, error) {
+	return addOptions(s, opt)
+}
+
 // addOptions adds the parameters in opt as URL query parameters to s. opt
 // must be a struct whose fields may contain "url" tags.
+//
+// The parameters are appended to the query of s, which is kept byte for byte rather
+// than decoded and encoded again, and spaces are encoded as %20 rather than "+", so
+// that values holding URL metacharacters reach the API as they were given.
 func addOptions(s string, opt interface{}) (string, error) {
 	v := reflect.ValueOf(opt)
 	if v.Kind() == reflect.Ptr && v.IsNil() {
@@ -266,6 +851,62 @@ func addOptions(s string, opt interface{}) (string, error) {
 		return s, err
 	}
 
-	u.RawQuery = qs.Encode()
+	// url.QueryEscape encodes "+" itself as %2B, so the remaining ones are spaces.
+	encoded := strings.Replace(qs.Encode(), "+", "%20", -1)
+	switch {
+	case encoded == "":
+	case u.RawQuery == "":
+		u.RawQuery = encoded
+	default:
+		u.RawQuery += "&" + encoded
+	}
 	return u.String(), nil
 }
+
+// String returns a pointer to the string value passed in.
+func String(v string) *string { return &v }
+
+// StringValue returns the value of the string pointer passed in, or "" if the
+// pointer is nil.
+func StringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// Int returns a pointer to the int value passed in.
+func Int(v int) *int { return &v }
+
+// IntValue returns the value of the int pointer passed in, or 0 if the pointer
+// is nil.
+func IntValue(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// Int64 returns a pointer to the int64 value passed in.
+func Int64(v int64) *int64 { return &v }
+
+// Int64Value returns the value of the int64 pointer passed in, or 0 if the
+// pointer is nil.
+func Int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// Bool returns a pointer to the bool value passed in.
+func Bool(v bool) *bool { return &v }
+
+// BoolValue returns the value of the bool pointer passed in, or false if the
+// pointer is nil.
+func BoolValue(v *bool) bool {
+	if v == nil {
+		return false
+	}
+	return *v
+}
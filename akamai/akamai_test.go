@@ -0,0 +1,467 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// setup sets up a test HTTP server along with an akamai.Client that is
+// configured to talk to that test server. Tests should register handlers on
+// mux which provide mock responses for the API method being tested.
+func setup() (client *Client, mux *http.ServeMux, serverURL string, teardown func()) {
+	mux = http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	creds := credentials.NewStaticCredentials(
+		akamaiTestClientSecret,
+		akamaiTestClientToken,
+		akamaiTestAccessToken,
+		server.Listener.Addr().String(),
+	)
+
+	client, err := NewClient(nil, creds)
+	if err != nil {
+		panic(err)
+	}
+
+	u, _ := url.Parse(server.URL + "/")
+	client.BaseURL = u
+
+	return client, mux, server.URL, server.Close
+}
+
+func testMethod(t *testing.T, r *http.Request, want string) {
+	t.Helper()
+	assert.Equal(t, want, r.Method, "Request method")
+}
+
+func TestNewClientBaseURL(t *testing.T) {
+	creds := credentials.NewStaticCredentials("secret", "client", "access", "akaa-baseurl.luna.akamaiapis.net")
+	c, err := NewClient(nil, creds)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Equal(t, "https://akaa-baseurl.luna.akamaiapis.net/", c.BaseURL.String())
+	assert.Equal(t, userAgent, c.UserAgent)
+}
+
+func TestWithServiceBaseURL(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	gateway := http.NewServeMux()
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+	u, _ := url.Parse(server.URL + "/")
+	assert.Equal(t, client, client.WithServiceBaseURL("config-dns", u))
+
+	var dns, papi []error
+	gateway.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		dns = append(dns, VerifyRequest(r, client.Credentials))
+	})
+	mux.HandleFunc("/papi/v1/contracts", func(w http.ResponseWriter, r *http.Request) {
+		papi = append(papi, VerifyRequest(r, client.Credentials))
+	})
+
+	for _, path := range []string{"/config-dns/v2/zones?showAll=true", "config-dns/v2/zones", "papi/v1/contracts"} {
+		if _, err := client.Call(context.Background(), "GET", path, nil, nil); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	}
+
+	if assert.Len(t, dns, 2) {
+		assert.NoError(t, dns[0])
+		assert.NoError(t, dns[1])
+	}
+	if assert.Len(t, papi, 1) {
+		assert.NoError(t, papi[0])
+	}
+
+	req, err := client.NewRequest("GET", "config-dns-other/v1/x", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, client.BaseURL.Host, req.URL.Host)
+
+	client.WithServiceBaseURL("config-dns", &url.URL{Scheme: "https", Host: "gateway.example", Path: "/dns"})
+	_, err = client.NewRequest("GET", "config-dns/v2/zones", nil)
+	assert.Error(t, err)
+}
+
+func TestCheckResponseErrorBodies(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		wantMsg     string
+		wantBody    string
+		wantAPI     bool
+	}{
+		{
+			name:        "problem",
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+			body:        `{"type":"https://problems.luna.akamaiapis.net/config-dns/v2/not-found","title":"Not Found","status":404,"detail":"Zone example.com does not exist"}`,
+			wantMsg:     "HTTP Status: 404. Not Found: Zone example.com does not exist.",
+			wantAPI:     true,
+		},
+		{
+			name:        "html gateway",
+			status:      http.StatusBadGateway,
+			contentType: "text/html; charset=utf-8",
+			body:        "<HTML><HEAD>\n<TITLE>Bad Gateway</TITLE>\n</HEAD><BODY>\n<H1>Bad Gateway</H1>\nReference&#32;&#35;0&#46;1\n</BODY></HTML>\n",
+			wantMsg:     "HTTP Status: 502. Non-JSON response (text/html; charset=utf-8): <HTML><HEAD> <TITLE>Bad Gateway</TITLE> </HEAD><BODY> <H1>Bad Gateway</H1> Reference&#32;&#35;0&#46;1 </BODY></HTML>",
+			wantBody:    "<HTML><HEAD>\n<TITLE>Bad Gateway</TITLE>\n</HEAD><BODY>\n<H1>Bad Gateway</H1>\nReference&#32;&#35;0&#46;1\n</BODY></HTML>\n",
+		},
+		{
+			name:        "plain text",
+			status:      http.StatusForbidden,
+			contentType: "text/plain",
+			body:        "Access Denied",
+			wantMsg:     "HTTP Status: 403. Non-JSON response (text/plain): Access Denied",
+			wantBody:    "Access Denied",
+		},
+		{
+			name:        "malformed json",
+			status:      http.StatusInternalServerError,
+			contentType: "application/json",
+			body:        `{"title":`,
+			wantMsg:     `HTTP Status: 500. Non-JSON response (application/json): {"title":`,
+			wantBody:    `{"title":`,
+		},
+		{
+			name:    "empty",
+			status:  http.StatusInternalServerError,
+			wantMsg: "HTTP Status: 500. Empty response.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Response{
+				StatusCode: tt.status,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+			}
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			var aerr *AkamaiError
+			if !errors.As(CheckResponse(r), &aerr) {
+				t.Fatalf("expect *AkamaiError")
+			}
+			assert.Equal(t, tt.status, aerr.Status)
+			assert.Equal(t, tt.contentType, aerr.ContentType)
+			assert.Equal(t, tt.wantMsg, aerr.Error())
+			assert.Equal(t, tt.wantBody, string(aerr.Body))
+			assert.Equal(t, tt.wantAPI, aerr.IsAPIError())
+		})
+	}
+}
+
+func TestCheckResponseErrorBodyExcerpt(t *testing.T) {
+	r := &http.Response{
+		StatusCode: http.StatusBadGateway,
+		Header:     http.Header{"Content-Type": {"text/html"}},
+		Body:       ioutil.NopCloser(strings.NewReader(strings.Repeat("<p>gateway</p>\n", 1000))),
+	}
+
+	var aerr *AkamaiError
+	if !errors.As(CheckResponse(r), &aerr) {
+		t.Fatalf("expect *AkamaiError")
+	}
+	assert.Len(t, aerr.Body, maxErrorBodySize)
+	assert.True(t, strings.HasSuffix(aerr.Error(), "..."), aerr.Error())
+	assert.False(t, strings.Contains(aerr.Error(), "\n"))
+	assert.True(t, len(aerr.Error()) < 300, aerr.Error())
+}
+
+func TestSearchEscaping(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var raw, search string
+	mux.HandleFunc("/config-dns/v2/zones/example.com/recordsets", func(w http.ResponseWriter, r *http.Request) {
+		if err := VerifyRequest(r, client.Credentials); err != nil {
+			t.Errorf("expect nil, got %v", err)
+		}
+		raw, search = r.URL.RawQuery, r.URL.Query().Get("search")
+		fmt.Fprint(w, `{"recordsets": []}`)
+	})
+
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{"a&b=c", "search=a%26b%3Dc"},
+		{"www example", "search=www%20example"},
+		{"a+b", "search=a%2Bb"},
+		{"100%;#?/", "search=100%25%3B%23%3F%2F"},
+		{"bücher.例え", "search=b%C3%BCcher.%E4%BE%8B%E3%81%88"},
+	}
+	for _, tt := range tests {
+		_, _, err := client.FastDNSv2.GetZoneRecordSets(context.Background(), "example.com", &ListZoneRecordSetOptions{Search: tt.search, PageSize: 10})
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.Equal(t, "pageSize=10&"+tt.want, raw)
+		assert.Equal(t, tt.search, search)
+	}
+
+	_, _, err := client.FastDNSv2.GetZoneRecordSets(context.Background(), "example.com", &ListZoneRecordSetOptions{Search: "www\r\nHost: evil"})
+	assert.Error(t, err)
+}
+
+func TestAddOptionsKeepsQuery(t *testing.T) {
+	u, err := addOptions("config-dns/v2/zones?contractIds=1-A%2B1&x", &ZoneListOptions{Search: "a b"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "config-dns/v2/zones?contractIds=1-A%2B1&x&search=a%20b", u)
+
+	u, err = addOptions("config-dns/v2/zones?page=2", &ZoneListOptions{})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "config-dns/v2/zones?page=2", u)
+}
+
+func TestSignEscapedPath(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var path string
+	mux.HandleFunc("/config-dns/v2/zones/", func(w http.ResponseWriter, r *http.Request) {
+		if err := VerifyRequest(r, client.Credentials); err != nil {
+			t.Errorf("expect nil, got %v", err)
+		}
+		path = r.URL.EscapedPath()
+		fmt.Fprint(w, `{}`)
+	})
+
+	_, err := client.Call(context.Background(), "GET", "config-dns/v2/zones/a%2Fb%20c", nil, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "/config-dns/v2/zones/a%2Fb%20c", path)
+}
+
+func TestNewUploadRequest(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	type received struct {
+		contentType string
+		length      int64
+		body        string
+	}
+	var got received
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		if err := VerifyRequest(r, client.Credentials); err != nil {
+			t.Errorf("expect nil, got %v", err)
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		got = received{r.Header.Get("Content-Type"), r.ContentLength, string(b)}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	zoneFile := "example.com. 300 IN A 192.0.2.1\nwww.example.com. 300 IN CNAME example.com.\n"
+	patch := `[{"op": "replace", "path": "/ttl", "value": 60}]`
+	tests := []struct {
+		name        string
+		method      string
+		body        string
+		size        int64
+		contentType string
+		want        received
+	}{
+		{"zone file", "POST", zoneFile, int64(len(zoneFile)), "text/dns", received{"text/dns", int64(len(zoneFile)), zoneFile}},
+		{"json patch", "PATCH", patch, -1, "application/json-patch+json", received{"application/json-patch+json", int64(len(patch)), patch}},
+		{"size shorter than body", "POST", zoneFile, 10, "text/dns", received{"text/dns", 10, zoneFile[:10]}},
+		{"default content type", "POST", "\x00\x01", 2, "", received{"application/octet-stream", 2, "\x00\x01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := client.NewUploadRequest(tt.method, "upload", strings.NewReader(tt.body), tt.size, tt.contentType)
+			if err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+			if _, err := client.Do(context.Background(), req, nil); err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := client.NewUploadRequest("POST", "upload", strings.NewReader("short"), 10, "text/dns")
+	if assert.Error(t, err) {
+		assert.Equal(t, "upload body is 5 bytes, shorter than its size of 10", err.Error())
+	}
+}
+
+func TestResponseLocation(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	var status int
+	var location string
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		if location != "" {
+			w.Header().Set("Location", location)
+		}
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{"zone": "example.com", "type": "PRIMARY"}`)
+	})
+
+	tests := []struct {
+		name     string
+		status   int
+		location string
+		want     string
+	}{
+		{"relative", http.StatusCreated, "/config-dns/v2/zones/example.com", serverURL + "/config-dns/v2/zones/example.com"},
+		{"absolute", http.StatusCreated, "https://akab-other.luna.akamaiapis.net/config-dns/v2/zones/example.com", "https://akab-other.luna.akamaiapis.net/config-dns/v2/zones/example.com"},
+		{"missing", http.StatusCreated, "", ""},
+		{"not created", http.StatusOK, "/config-dns/v2/zones/example.com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, location = tt.status, tt.location
+
+			_, resp, err := client.FastDNSv2.CreateZone(context.Background(), "1-ABCDE", &ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+			if err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+			assert.Equal(t, "req-1", resp.RequestID)
+			if tt.want == "" {
+				assert.Nil(t, resp.Location)
+				return
+			}
+			if assert.NotNil(t, resp.Location) {
+				assert.Equal(t, tt.want, resp.Location.String())
+			}
+		})
+	}
+}
+
+func TestRequestIDInErrors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.Header().Set("X-Trace-Id", "trace-42")
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"title": "Conflict", "detail": "Zone example.com already exists", "status": 409}`)
+	})
+
+	_, resp, err := client.FastDNSv2.CreateZone(context.Background(), "1-ABCDE", &ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	assert.Equal(t, "trace-42", resp.RequestID)
+
+	oe, ok := OperationFromError(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, "trace-42", oe.RequestID)
+		assert.Contains(t, oe.Error(), "(request trace-42)")
+	}
+	var ae *AkamaiError
+	if assert.True(t, errors.As(err, &ae)) {
+		assert.Equal(t, "trace-42", ae.RequestID)
+	}
+}
+
+func TestAkamaiErrorJSON(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/zones/missing.com", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.Header().Set("X-Request-Id", "req-404")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"type": "https://problems.luna.akamaiapis.net/config-dns/not-found", "title": "Not Found", "detail": "Zone missing.com does not exist", "instance": "/config-dns/v2/zones/missing.com", "status": 404}`)
+	})
+	mux.HandleFunc("/config-dns/v2/zones/busy.com", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "<html><body>"+strings.Repeat("Service Unavailable ", 1000)+"</body></html>")
+	})
+
+	_, _, err := client.FastDNSv2.GetZone(context.Background(), "missing.com")
+	var ae *AkamaiError
+	if !errors.As(err, &ae) {
+		t.Fatalf("expect *AkamaiError, got %v", err)
+	}
+	assert.NotNil(t, ae.Response)
+	assert.False(t, ae.Temporary())
+
+	b, err := json.Marshal(ae)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "GET", fields["method"])
+	assert.Equal(t, serverURL+"/config-dns/v2/zones/missing.com", fields["url"])
+	assert.Equal(t, "req-404", fields["requestId"])
+	assert.Nil(t, fields["body"])
+
+	var decoded AkamaiError
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, ae.Type, decoded.Type)
+	assert.Equal(t, ae.Title, decoded.Title)
+	assert.Equal(t, ae.Detail, decoded.Detail)
+	assert.Equal(t, ae.Instance, decoded.Instance)
+	assert.Equal(t, ae.Status, decoded.Status)
+
+	// The body of a gateway error is cut to an excerpt.
+	_, _, err = client.FastDNSv2.GetZone(context.Background(), "busy.com")
+	if !errors.As(err, &ae) {
+		t.Fatalf("expect *AkamaiError, got %v", err)
+	}
+	assert.True(t, ae.Temporary())
+	b, err = json.Marshal(ae)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, len(b) < 512, "%d bytes", len(b))
+	fields = nil
+	if err := json.Unmarshal(b, &fields); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, float64(503), fields["status"])
+	assert.True(t, strings.HasPrefix(fields["body"].(string), "<html><body>Service Unavailable"))
+}
+
+func TestAkamaiErrorTemporary(t *testing.T) {
+	for status, temporary := range map[int]bool{
+		http.StatusBadRequest:          false,
+		http.StatusNotFound:            false,
+		http.StatusConflict:            false,
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+	} {
+		assert.Equal(t, temporary, (&AkamaiError{Status: status}).Temporary(), status)
+	}
+}
@@ -0,0 +1,62 @@
+package akamaitest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// Timestamp and nonce the requests of the clients of NewStaticTestClient are signed
+// with, so that their signatures are the same on every run.
+const (
+	TestTimestamp = "20260101T00:00:00+0000"
+	TestNonce     = "00000000-0000-4000-8000-000000000000"
+)
+
+// NewStaticTestClient returns a client making its requests against the test server at
+// serverURL, such as the URL of an httptest.Server, without real credentials. Its
+// credentials are the static TestClientSecret, TestClientToken and TestAccessToken, for
+// the host of serverURL, and it signs its requests with TestTimestamp and TestNonce.
+func NewStaticTestClient(t testing.TB, serverURL string) *akamai.Client {
+	t.Helper()
+
+	client, err := newStaticTestClient(nil, serverURL)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	return client
+}
+
+func newStaticTestClient(httpClient *http.Client, serverURL string) (*akamai.Client, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("server URL %q has no host", serverURL)
+	}
+
+	creds := testCredentials(u.Host)
+	client, err := akamai.NewClient(httpClient, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	base := *u
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	client.BaseURL = &base
+
+	return client.WithSigner(&akamai.Signer{Credentials: creds, Timestamp: TestTimestamp, Nonce: TestNonce}), nil
+}
+
+// testCredentials returns the static test credentials for host.
+func testCredentials(host string) *credentials.Credentials {
+	return credentials.NewStaticCredentials(TestClientSecret, TestClientToken, TestAccessToken, host)
+}
@@ -0,0 +1,52 @@
+package akamaitest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+func TestNewStaticTestClient(t *testing.T) {
+	var auth []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := akamai.VerifyRequest(r, testCredentials(r.Host)); err != nil {
+			t.Errorf("expect nil, got %v", err)
+		}
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"zones": []}`))
+	}))
+	defer ts.Close()
+
+	client := NewStaticTestClient(t, ts.URL)
+	assert.Equal(t, ts.URL+"/", client.BaseURL.String())
+
+	// The requests are signed for the host of the server, and the same on every run.
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.FastDNSv2.ListZones(context.Background(), nil); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	}
+	if assert.Len(t, auth, 2) {
+		assert.Contains(t, auth[0], "timestamp="+TestTimestamp)
+		assert.Contains(t, auth[0], "nonce="+TestNonce)
+		assert.Equal(t, auth[0], auth[1])
+	}
+}
+
+func TestNewExampleServer(t *testing.T) {
+	client, srv := NewExampleServer()
+	defer srv.Close()
+	srv.VerifySignatures = true
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+
+	zm, _, err := client.FastDNSv2.GetZone(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "example.com", zm.GetZone())
+}
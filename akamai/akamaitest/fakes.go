@@ -0,0 +1,1154 @@
+// Package akamaitest provides fakes of the akamai services for use in the tests of
+// code built on the SDK.
+package akamaitest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+// Call records a single call made to one of the fake services.
+type Call struct {
+	// Method is the name of the service method that was called.
+	Method string
+	// Args holds the arguments of the call, without the context.
+	Args []interface{}
+}
+
+// recorder keeps track of the calls made to a fake service.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made to the fake service so far, in order.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// NewClient returns an akamai.Client whose services are all backed by fakes. The
+// client has no credentials and makes no requests, so it is only useful for code that
+// goes through the service fields.
+func NewClient() (*akamai.Client, *Fakes) {
+	f := &Fakes{
+		FastDNSv2:     &FastDNSv2{},
+		Contracts:     &Contracts{},
+		Imaging:       &Imaging{},
+		FirewallRules: &FirewallRules{},
+		EdgeKV:        &EdgeKV{},
+		Reporting:     &Reporting{},
+		HAPI:          &HAPI{},
+		Sandbox:       &Sandbox{},
+		Purge:         &Purge{},
+	}
+	c := &akamai.Client{
+		FastDNSv2:     f.FastDNSv2,
+		Contracts:     f.Contracts,
+		Imaging:       f.Imaging,
+		FirewallRules: f.FirewallRules,
+		EdgeKV:        f.EdgeKV,
+		Reporting:     f.Reporting,
+		HAPI:          f.HAPI,
+		Sandbox:       f.Sandbox,
+		Purge:         f.Purge,
+	}
+	return c, f
+}
+
+// Fakes holds the fakes behind the services of a client returned by NewClient.
+type Fakes struct {
+	FastDNSv2     *FastDNSv2
+	Contracts     *Contracts
+	Imaging       *Imaging
+	FirewallRules *FirewallRules
+	EdgeKV        *EdgeKV
+	Reporting     *Reporting
+	HAPI          *HAPI
+	Sandbox       *Sandbox
+	Purge         *Purge
+}
+
+// FastDNSv2 is a fake akamai.FastDNSv2API. Every call is recorded; the call is answered by
+// the matching Func field when it is set, and with zero values otherwise.
+type FastDNSv2 struct {
+	recorder
+
+	ListZonesFunc                 func(context.Context, *akamai.ZoneListOptions) (*akamai.ZoneList, *akamai.Response, error)
+	GetZoneFunc                   func(context.Context, string) (*akamai.ZoneMetadata, *akamai.Response, error)
+	CreateZoneFunc                func(context.Context, string, *akamai.ZoneCreateRequest) (*akamai.Zone, *akamai.Response, error)
+	UpdateZoneFunc                func(context.Context, *akamai.ZoneCreateRequest) (*akamai.Zone, *akamai.Response, error)
+	DeleteZoneFunc                func(context.Context, *akamai.ZoneDeleteRequest, *akamai.ZoneDeleteOptions) (*akamai.ZoneDeleteResponse, *akamai.Response, error)
+	DeleteZoneStatusFunc          func(context.Context, string) (*akamai.ZoneDeleteResponse, *akamai.Response, error)
+	DeleteZoneResultFunc          func(context.Context, string) (*akamai.ZoneDeleteResult, *akamai.Response, error)
+	GetRecordSetFunc              func(context.Context, *akamai.RecordSetOptions) (*akamai.RecordSet, *akamai.Response, error)
+	CreateRecordSetFunc           func(context.Context, *akamai.RecordSetCreateRequest) (*akamai.RecordSet, *akamai.Response, error)
+	UpdateRecordSetFunc           func(context.Context, *akamai.RecordSetCreateRequest) (*akamai.RecordSet, *akamai.Response, error)
+	DeleteRecordSetFunc           func(context.Context, *akamai.RecordSetOptions) (*akamai.Response, error)
+	GetZoneRecordSetsFunc         func(context.Context, string, *akamai.ListZoneRecordSetOptions) (*akamai.ListZoneRecordSets, *akamai.Response, error)
+	GetZoneContractFunc           func(context.Context, string) (*akamai.Contract, *akamai.Response, error)
+	CreateChangeListFunc          func(context.Context, *akamai.ChangeListOptions) (*akamai.ChangeList, *akamai.Response, error)
+	GetChangeListFunc             func(context.Context, string) (*akamai.ChangeList, *akamai.Response, error)
+	GetChangeListRecordSetsFunc   func(context.Context, string, *akamai.ChangeListOptions) (*akamai.ChangeListRecords, *akamai.Response, error)
+	DeleteChangeListFunc          func(context.Context, string) (*akamai.Response, error)
+	SubmitChangeListFunc          func(context.Context, string) (*akamai.Response, error)
+	PlanRecordSetsFunc            func(context.Context, string, []*akamai.RecordSetCreateRequest, *akamai.SyncOptions) (*akamai.SyncPlan, error)
+	ApplySyncPlanFunc             func(context.Context, *akamai.SyncPlan, *akamai.SyncOptions) error
+	SyncRecordSetsFunc            func(context.Context, string, []*akamai.RecordSetCreateRequest, *akamai.SyncOptions) (*akamai.SyncPlan, error)
+	SummarizeZonesFunc            func(context.Context, []string, *akamai.BulkOptions) []*akamai.ZoneSummary
+	WaitForZoneActiveFunc         func(context.Context, string, time.Duration) (*akamai.ZoneMetadata, error)
+	ReplaceRecordSetsFunc         func(context.Context, string, []*akamai.RecordSetCreateRequest) (*akamai.Response, error)
+	VerifyDelegationFunc          func(context.Context, string, akamai.NSResolver) error
+	OnboardZonesFunc              func(context.Context, []akamai.ZoneSpec, *akamai.OnboardOptions) ([]*akamai.OnboardProgress, *akamai.OnboardCheckpoint)
+	ListGroupsFunc                func(context.Context, *akamai.DataOptions) ([]*akamai.Group, *akamai.Response, error)
+	ListContractsFunc             func(context.Context, *akamai.DataOptions) ([]*akamai.Contract, *akamai.Response, error)
+	GetAuthoritiesFunc            func(context.Context, []string) ([]*akamai.ContractAuthorities, *akamai.Response, error)
+	GetRecordTypesFunc            func(context.Context, string) ([]string, *akamai.Response, error)
+	WaitForDeleteZoneFunc         func(context.Context, *akamai.ZoneDeleteResponse, *akamai.Response, time.Duration) (*akamai.ZoneDeleteResult, error)
+	SetZoneCommentFunc            func(context.Context, string, string) (*akamai.Zone, *akamai.Response, error)
+	SetZoneEndCustomerIDFunc      func(context.Context, string, string) (*akamai.Zone, *akamai.Response, error)
+	ListZonesByGroupFunc          func(context.Context, int, *akamai.ZoneListOptions) (*akamai.ZoneList, *akamai.Response, error)
+	ChangeZoneGroupFunc           func(context.Context, string, int) (*akamai.Zone, *akamai.Response, error)
+	WaitForZoneGroupFunc          func(context.Context, string, int, time.Duration) (*akamai.ZoneMetadata, error)
+	DeleteChangeListIfFunc        func(context.Context, string, *akamai.DeleteChangeListOptions) (*akamai.Response, error)
+	ListZoneVersionsFunc          func(context.Context, string, *akamai.ZoneVersionListOptions) (*akamai.ZoneVersionList, *akamai.Response, error)
+	GetZoneVersionRecordSetsFunc  func(context.Context, string, string, *akamai.ListZoneRecordSetOptions) (*akamai.ListZoneRecordSets, *akamai.Response, error)
+	GetRecordHistoryFunc          func(context.Context, string, string, string, int) ([]*akamai.RecordHistoryEntry, error)
+	ListAllZonesFunc              func(context.Context, *akamai.ZoneListOptions) ([]*akamai.Zone, error)
+	ListAllZoneRecordSetsFunc     func(context.Context, string, *akamai.ListZoneRecordSetOptions) ([]*akamai.RecordSet, error)
+	GetRecordTypesForNameFunc     func(context.Context, string, string) ([]string, *akamai.Response, error)
+	VerifyRecordServedFunc        func(context.Context, string, string, string, []string, *akamai.VerifyServedOptions) ([]*akamai.AuthorityAnswer, error)
+	CheckZoneCapacityFunc         func(context.Context, string, int) (int, error)
+	SupportedRecordTypesFunc      func(context.Context, string, string) ([]string, error)
+	ListZonesWithContractsFunc    func(context.Context, *akamai.ZoneListOptions) ([]*akamai.ZoneWithContract, []error, error)
+	ValidateChangeListFunc        func(context.Context, string) (*akamai.ValidationResult, *akamai.Response, error)
+	SubmitValidatedChangeListFunc func(context.Context, string) (*akamai.ValidationResult, *akamai.Response, error)
+	GetRecordSetsChangedSinceFunc func(context.Context, string, string, *akamai.ChangedSinceOptions) (*akamai.SyncPlan, error)
+	GetZoneLabelsFunc             func(context.Context, string) (akamai.ZoneLabels, *akamai.Response, error)
+	SetZoneLabelFunc              func(context.Context, string, string, string) (*akamai.Zone, *akamai.Response, error)
+	ListZonesByLabelFunc          func(context.Context, string, string) ([]*akamai.Zone, error)
+	ForEachRecordSetFunc          func(context.Context, string, *akamai.ListZoneRecordSetOptions, func(*akamai.RecordSet) error) error
+	CompareZoneVersionsFunc       func(context.Context, string, string, string) (*akamai.SyncPlan, error)
+	ClaimRecordFunc               func(context.Context, string, string, string, string) error
+	ReleaseRecordFunc             func(context.Context, string, string, string, string) error
+	ListOwnedRecordsFunc          func(context.Context, string, string) ([]*akamai.RecordClaim, error)
+	DelegationReportFunc          func(context.Context, []string, int, akamai.NSResolver) (*akamai.DelegationReport, error)
+	ZoneStatsFunc                 func(context.Context, string) (*akamai.ZoneStats, error)
+	FindDanglingCNAMEsFunc        func(context.Context, []string, *akamai.DanglingCNAMEOptions) ([]*akamai.DanglingCNAME, error)
+	CreateZoneWithOptionsFunc     func(context.Context, string, *akamai.ZoneCreateRequest, *akamai.CreateZoneOptions) (*akamai.CreateZoneResult, *akamai.Response, error)
+	GetZonesMetadataFunc          func(context.Context, []string, *akamai.ZonesMetadataOptions) (map[string]*akamai.ZoneMetadataResult, error)
+}
+
+// ListZones implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ListZones(ctx context.Context, opt *akamai.ZoneListOptions) (*akamai.ZoneList, *akamai.Response, error) {
+	f.record("ListZones", opt)
+	if f.ListZonesFunc != nil {
+		return f.ListZonesFunc(ctx, opt)
+	}
+	return nil, nil, nil
+}
+
+// GetZone implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetZone(ctx context.Context, zone string) (*akamai.ZoneMetadata, *akamai.Response, error) {
+	f.record("GetZone", zone)
+	if f.GetZoneFunc != nil {
+		return f.GetZoneFunc(ctx, zone)
+	}
+	return nil, nil, nil
+}
+
+// CreateZone implements akamai.FastDNSv2API.
+func (f *FastDNSv2) CreateZone(ctx context.Context, cid string, zone *akamai.ZoneCreateRequest) (*akamai.Zone, *akamai.Response, error) {
+	f.record("CreateZone", cid, zone)
+	if f.CreateZoneFunc != nil {
+		return f.CreateZoneFunc(ctx, cid, zone)
+	}
+	return nil, nil, nil
+}
+
+// UpdateZone implements akamai.FastDNSv2API.
+func (f *FastDNSv2) UpdateZone(ctx context.Context, zone *akamai.ZoneCreateRequest) (*akamai.Zone, *akamai.Response, error) {
+	f.record("UpdateZone", zone)
+	if f.UpdateZoneFunc != nil {
+		return f.UpdateZoneFunc(ctx, zone)
+	}
+	return nil, nil, nil
+}
+
+// DeleteZone implements akamai.FastDNSv2API.
+func (f *FastDNSv2) DeleteZone(ctx context.Context, zd *akamai.ZoneDeleteRequest, zdo *akamai.ZoneDeleteOptions) (*akamai.ZoneDeleteResponse, *akamai.Response, error) {
+	f.record("DeleteZone", zd, zdo)
+	if f.DeleteZoneFunc != nil {
+		return f.DeleteZoneFunc(ctx, zd, zdo)
+	}
+	return nil, nil, nil
+}
+
+// DeleteZoneStatus implements akamai.FastDNSv2API.
+func (f *FastDNSv2) DeleteZoneStatus(ctx context.Context, rid string) (*akamai.ZoneDeleteResponse, *akamai.Response, error) {
+	f.record("DeleteZoneStatus", rid)
+	if f.DeleteZoneStatusFunc != nil {
+		return f.DeleteZoneStatusFunc(ctx, rid)
+	}
+	return nil, nil, nil
+}
+
+// DeleteZoneResult implements akamai.FastDNSv2API.
+func (f *FastDNSv2) DeleteZoneResult(ctx context.Context, rid string) (*akamai.ZoneDeleteResult, *akamai.Response, error) {
+	f.record("DeleteZoneResult", rid)
+	if f.DeleteZoneResultFunc != nil {
+		return f.DeleteZoneResultFunc(ctx, rid)
+	}
+	return nil, nil, nil
+}
+
+// GetRecordSet implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetRecordSet(ctx context.Context, opt *akamai.RecordSetOptions) (*akamai.RecordSet, *akamai.Response, error) {
+	f.record("GetRecordSet", opt)
+	if f.GetRecordSetFunc != nil {
+		return f.GetRecordSetFunc(ctx, opt)
+	}
+	return nil, nil, nil
+}
+
+// CreateRecordSet implements akamai.FastDNSv2API.
+func (f *FastDNSv2) CreateRecordSet(ctx context.Context, rs *akamai.RecordSetCreateRequest) (*akamai.RecordSet, *akamai.Response, error) {
+	f.record("CreateRecordSet", rs)
+	if f.CreateRecordSetFunc != nil {
+		return f.CreateRecordSetFunc(ctx, rs)
+	}
+	return nil, nil, nil
+}
+
+// UpdateRecordSet implements akamai.FastDNSv2API.
+func (f *FastDNSv2) UpdateRecordSet(ctx context.Context, rs *akamai.RecordSetCreateRequest) (*akamai.RecordSet, *akamai.Response, error) {
+	f.record("UpdateRecordSet", rs)
+	if f.UpdateRecordSetFunc != nil {
+		return f.UpdateRecordSetFunc(ctx, rs)
+	}
+	return nil, nil, nil
+}
+
+// DeleteRecordSet implements akamai.FastDNSv2API.
+func (f *FastDNSv2) DeleteRecordSet(ctx context.Context, opt *akamai.RecordSetOptions) (*akamai.Response, error) {
+	f.record("DeleteRecordSet", opt)
+	if f.DeleteRecordSetFunc != nil {
+		return f.DeleteRecordSetFunc(ctx, opt)
+	}
+	return nil, nil
+}
+
+// GetZoneRecordSets implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetZoneRecordSets(ctx context.Context, zone string, opt *akamai.ListZoneRecordSetOptions) (*akamai.ListZoneRecordSets, *akamai.Response, error) {
+	f.record("GetZoneRecordSets", zone, opt)
+	if f.GetZoneRecordSetsFunc != nil {
+		return f.GetZoneRecordSetsFunc(ctx, zone, opt)
+	}
+	return nil, nil, nil
+}
+
+// GetZoneContract implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetZoneContract(ctx context.Context, zone string) (*akamai.Contract, *akamai.Response, error) {
+	f.record("GetZoneContract", zone)
+	if f.GetZoneContractFunc != nil {
+		return f.GetZoneContractFunc(ctx, zone)
+	}
+	return nil, nil, nil
+}
+
+// CreateChangeList implements akamai.FastDNSv2API.
+func (f *FastDNSv2) CreateChangeList(ctx context.Context, cl *akamai.ChangeListOptions) (*akamai.ChangeList, *akamai.Response, error) {
+	f.record("CreateChangeList", cl)
+	if f.CreateChangeListFunc != nil {
+		return f.CreateChangeListFunc(ctx, cl)
+	}
+	return nil, nil, nil
+}
+
+// GetChangeList implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetChangeList(ctx context.Context, zone string) (*akamai.ChangeList, *akamai.Response, error) {
+	f.record("GetChangeList", zone)
+	if f.GetChangeListFunc != nil {
+		return f.GetChangeListFunc(ctx, zone)
+	}
+	return nil, nil, nil
+}
+
+// GetChangeListRecordSets implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetChangeListRecordSets(ctx context.Context, zone string, opt *akamai.ChangeListOptions) (*akamai.ChangeListRecords, *akamai.Response, error) {
+	f.record("GetChangeListRecordSets", zone, opt)
+	if f.GetChangeListRecordSetsFunc != nil {
+		return f.GetChangeListRecordSetsFunc(ctx, zone, opt)
+	}
+	return nil, nil, nil
+}
+
+// DeleteChangeList implements akamai.FastDNSv2API.
+func (f *FastDNSv2) DeleteChangeList(ctx context.Context, zone string) (*akamai.Response, error) {
+	f.record("DeleteChangeList", zone)
+	if f.DeleteChangeListFunc != nil {
+		return f.DeleteChangeListFunc(ctx, zone)
+	}
+	return nil, nil
+}
+
+// SubmitChangeList implements akamai.FastDNSv2API.
+func (f *FastDNSv2) SubmitChangeList(ctx context.Context, zone string) (*akamai.Response, error) {
+	f.record("SubmitChangeList", zone)
+	if f.SubmitChangeListFunc != nil {
+		return f.SubmitChangeListFunc(ctx, zone)
+	}
+	return nil, nil
+}
+
+// PlanRecordSets implements akamai.FastDNSv2API.
+func (f *FastDNSv2) PlanRecordSets(ctx context.Context, zone string, desired []*akamai.RecordSetCreateRequest, opt *akamai.SyncOptions) (*akamai.SyncPlan, error) {
+	f.record("PlanRecordSets", zone, desired, opt)
+	if f.PlanRecordSetsFunc != nil {
+		return f.PlanRecordSetsFunc(ctx, zone, desired, opt)
+	}
+	return nil, nil
+}
+
+// ApplySyncPlan implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ApplySyncPlan(ctx context.Context, plan *akamai.SyncPlan, opt *akamai.SyncOptions) error {
+	f.record("ApplySyncPlan", plan, opt)
+	if f.ApplySyncPlanFunc != nil {
+		return f.ApplySyncPlanFunc(ctx, plan, opt)
+	}
+	return nil
+}
+
+// SyncRecordSets implements akamai.FastDNSv2API.
+func (f *FastDNSv2) SyncRecordSets(ctx context.Context, zone string, desired []*akamai.RecordSetCreateRequest, opt *akamai.SyncOptions) (*akamai.SyncPlan, error) {
+	f.record("SyncRecordSets", zone, desired, opt)
+	if f.SyncRecordSetsFunc != nil {
+		return f.SyncRecordSetsFunc(ctx, zone, desired, opt)
+	}
+	return nil, nil
+}
+
+// SummarizeZones implements akamai.FastDNSv2API.
+func (f *FastDNSv2) SummarizeZones(ctx context.Context, zones []string, opt *akamai.BulkOptions) []*akamai.ZoneSummary {
+	f.record("SummarizeZones", zones, opt)
+	if f.SummarizeZonesFunc != nil {
+		return f.SummarizeZonesFunc(ctx, zones, opt)
+	}
+	return nil
+}
+
+// WaitForZoneActive implements akamai.FastDNSv2API.
+func (f *FastDNSv2) WaitForZoneActive(ctx context.Context, zone string, interval time.Duration) (*akamai.ZoneMetadata, error) {
+	f.record("WaitForZoneActive", zone, interval)
+	if f.WaitForZoneActiveFunc != nil {
+		return f.WaitForZoneActiveFunc(ctx, zone, interval)
+	}
+	return nil, nil
+}
+
+// ReplaceRecordSets implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ReplaceRecordSets(ctx context.Context, zone string, rs []*akamai.RecordSetCreateRequest) (*akamai.Response, error) {
+	f.record("ReplaceRecordSets", zone, rs)
+	if f.ReplaceRecordSetsFunc != nil {
+		return f.ReplaceRecordSetsFunc(ctx, zone, rs)
+	}
+	return nil, nil
+}
+
+// VerifyDelegation implements akamai.FastDNSv2API.
+func (f *FastDNSv2) VerifyDelegation(ctx context.Context, zone string, r akamai.NSResolver) error {
+	f.record("VerifyDelegation", zone, r)
+	if f.VerifyDelegationFunc != nil {
+		return f.VerifyDelegationFunc(ctx, zone, r)
+	}
+	return nil
+}
+
+// OnboardZones implements akamai.FastDNSv2API.
+func (f *FastDNSv2) OnboardZones(ctx context.Context, specs []akamai.ZoneSpec, opt *akamai.OnboardOptions) ([]*akamai.OnboardProgress, *akamai.OnboardCheckpoint) {
+	f.record("OnboardZones", specs, opt)
+	if f.OnboardZonesFunc != nil {
+		return f.OnboardZonesFunc(ctx, specs, opt)
+	}
+	return nil, nil
+}
+
+// ListGroups implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ListGroups(ctx context.Context, opt *akamai.DataOptions) ([]*akamai.Group, *akamai.Response, error) {
+	f.record("ListGroups", opt)
+	if f.ListGroupsFunc != nil {
+		return f.ListGroupsFunc(ctx, opt)
+	}
+	return nil, nil, nil
+}
+
+// ListContracts implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ListContracts(ctx context.Context, opt *akamai.DataOptions) ([]*akamai.Contract, *akamai.Response, error) {
+	f.record("ListContracts", opt)
+	if f.ListContractsFunc != nil {
+		return f.ListContractsFunc(ctx, opt)
+	}
+	return nil, nil, nil
+}
+
+// GetAuthorities implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetAuthorities(ctx context.Context, contractIDs []string) ([]*akamai.ContractAuthorities, *akamai.Response, error) {
+	f.record("GetAuthorities", contractIDs)
+	if f.GetAuthoritiesFunc != nil {
+		return f.GetAuthoritiesFunc(ctx, contractIDs)
+	}
+	return nil, nil, nil
+}
+
+// GetRecordTypes implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetRecordTypes(ctx context.Context, zone string) ([]string, *akamai.Response, error) {
+	f.record("GetRecordTypes", zone)
+	if f.GetRecordTypesFunc != nil {
+		return f.GetRecordTypesFunc(ctx, zone)
+	}
+	return nil, nil, nil
+}
+
+// WaitForDeleteZone implements akamai.FastDNSv2API.
+func (f *FastDNSv2) WaitForDeleteZone(ctx context.Context, zd *akamai.ZoneDeleteResponse, resp *akamai.Response, interval time.Duration) (*akamai.ZoneDeleteResult, error) {
+	f.record("WaitForDeleteZone", zd, resp, interval)
+	if f.WaitForDeleteZoneFunc != nil {
+		return f.WaitForDeleteZoneFunc(ctx, zd, resp, interval)
+	}
+	return nil, nil
+}
+
+// SetZoneComment implements akamai.FastDNSv2API.
+func (f *FastDNSv2) SetZoneComment(ctx context.Context, zone string, comment string) (*akamai.Zone, *akamai.Response, error) {
+	f.record("SetZoneComment", zone, comment)
+	if f.SetZoneCommentFunc != nil {
+		return f.SetZoneCommentFunc(ctx, zone, comment)
+	}
+	return nil, nil, nil
+}
+
+// SetZoneEndCustomerID implements akamai.FastDNSv2API.
+func (f *FastDNSv2) SetZoneEndCustomerID(ctx context.Context, zone string, id string) (*akamai.Zone, *akamai.Response, error) {
+	f.record("SetZoneEndCustomerID", zone, id)
+	if f.SetZoneEndCustomerIDFunc != nil {
+		return f.SetZoneEndCustomerIDFunc(ctx, zone, id)
+	}
+	return nil, nil, nil
+}
+
+// ListZonesByGroup implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ListZonesByGroup(ctx context.Context, gid int, opt *akamai.ZoneListOptions) (*akamai.ZoneList, *akamai.Response, error) {
+	f.record("ListZonesByGroup", gid, opt)
+	if f.ListZonesByGroupFunc != nil {
+		return f.ListZonesByGroupFunc(ctx, gid, opt)
+	}
+	return nil, nil, nil
+}
+
+// ChangeZoneGroup implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ChangeZoneGroup(ctx context.Context, zone string, gid int) (*akamai.Zone, *akamai.Response, error) {
+	f.record("ChangeZoneGroup", zone, gid)
+	if f.ChangeZoneGroupFunc != nil {
+		return f.ChangeZoneGroupFunc(ctx, zone, gid)
+	}
+	return nil, nil, nil
+}
+
+// WaitForZoneGroup implements akamai.FastDNSv2API.
+func (f *FastDNSv2) WaitForZoneGroup(ctx context.Context, zone string, gid int, interval time.Duration) (*akamai.ZoneMetadata, error) {
+	f.record("WaitForZoneGroup", zone, gid, interval)
+	if f.WaitForZoneGroupFunc != nil {
+		return f.WaitForZoneGroupFunc(ctx, zone, gid, interval)
+	}
+	return nil, nil
+}
+
+// DeleteChangeListIf implements akamai.FastDNSv2API.
+func (f *FastDNSv2) DeleteChangeListIf(ctx context.Context, zone string, opt *akamai.DeleteChangeListOptions) (*akamai.Response, error) {
+	f.record("DeleteChangeListIf", zone, opt)
+	if f.DeleteChangeListIfFunc != nil {
+		return f.DeleteChangeListIfFunc(ctx, zone, opt)
+	}
+	return nil, nil
+}
+
+// ListZoneVersions implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ListZoneVersions(ctx context.Context, zone string, opt *akamai.ZoneVersionListOptions) (*akamai.ZoneVersionList, *akamai.Response, error) {
+	f.record("ListZoneVersions", zone, opt)
+	if f.ListZoneVersionsFunc != nil {
+		return f.ListZoneVersionsFunc(ctx, zone, opt)
+	}
+	return nil, nil, nil
+}
+
+// GetZoneVersionRecordSets implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetZoneVersionRecordSets(ctx context.Context, zone string, versionID string, opt *akamai.ListZoneRecordSetOptions) (*akamai.ListZoneRecordSets, *akamai.Response, error) {
+	f.record("GetZoneVersionRecordSets", zone, versionID, opt)
+	if f.GetZoneVersionRecordSetsFunc != nil {
+		return f.GetZoneVersionRecordSetsFunc(ctx, zone, versionID, opt)
+	}
+	return nil, nil, nil
+}
+
+// GetRecordHistory implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetRecordHistory(ctx context.Context, zone string, name string, rtype string, limit int) ([]*akamai.RecordHistoryEntry, error) {
+	f.record("GetRecordHistory", zone, name, rtype, limit)
+	if f.GetRecordHistoryFunc != nil {
+		return f.GetRecordHistoryFunc(ctx, zone, name, rtype, limit)
+	}
+	return nil, nil
+}
+
+// ListAllZones implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ListAllZones(ctx context.Context, opt *akamai.ZoneListOptions) ([]*akamai.Zone, error) {
+	f.record("ListAllZones", opt)
+	if f.ListAllZonesFunc != nil {
+		return f.ListAllZonesFunc(ctx, opt)
+	}
+	return nil, nil
+}
+
+// ListAllZoneRecordSets implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ListAllZoneRecordSets(ctx context.Context, zone string, opt *akamai.ListZoneRecordSetOptions) ([]*akamai.RecordSet, error) {
+	f.record("ListAllZoneRecordSets", zone, opt)
+	if f.ListAllZoneRecordSetsFunc != nil {
+		return f.ListAllZoneRecordSetsFunc(ctx, zone, opt)
+	}
+	return nil, nil
+}
+
+// GetRecordTypesForName implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetRecordTypesForName(ctx context.Context, zone string, name string) ([]string, *akamai.Response, error) {
+	f.record("GetRecordTypesForName", zone, name)
+	if f.GetRecordTypesForNameFunc != nil {
+		return f.GetRecordTypesForNameFunc(ctx, zone, name)
+	}
+	return nil, nil, nil
+}
+
+// VerifyRecordServed implements akamai.FastDNSv2API.
+func (f *FastDNSv2) VerifyRecordServed(ctx context.Context, zone string, name string, rtype string, expectedRdata []string, opt *akamai.VerifyServedOptions) ([]*akamai.AuthorityAnswer, error) {
+	f.record("VerifyRecordServed", zone, name, rtype, expectedRdata, opt)
+	if f.VerifyRecordServedFunc != nil {
+		return f.VerifyRecordServedFunc(ctx, zone, name, rtype, expectedRdata, opt)
+	}
+	return nil, nil
+}
+
+// CheckZoneCapacity implements akamai.FastDNSv2API.
+func (f *FastDNSv2) CheckZoneCapacity(ctx context.Context, contractID string, zonesToAdd int) (int, error) {
+	f.record("CheckZoneCapacity", contractID, zonesToAdd)
+	if f.CheckZoneCapacityFunc != nil {
+		return f.CheckZoneCapacityFunc(ctx, contractID, zonesToAdd)
+	}
+	return 0, nil
+}
+
+// SupportedRecordTypes implements akamai.FastDNSv2API.
+func (f *FastDNSv2) SupportedRecordTypes(ctx context.Context, zone string, name string) ([]string, error) {
+	f.record("SupportedRecordTypes", zone, name)
+	if f.SupportedRecordTypesFunc != nil {
+		return f.SupportedRecordTypesFunc(ctx, zone, name)
+	}
+	return nil, nil
+}
+
+// ListZonesWithContracts implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ListZonesWithContracts(ctx context.Context, opt *akamai.ZoneListOptions) ([]*akamai.ZoneWithContract, []error, error) {
+	f.record("ListZonesWithContracts", opt)
+	if f.ListZonesWithContractsFunc != nil {
+		return f.ListZonesWithContractsFunc(ctx, opt)
+	}
+	return nil, nil, nil
+}
+
+// ValidateChangeList implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ValidateChangeList(ctx context.Context, zone string) (*akamai.ValidationResult, *akamai.Response, error) {
+	f.record("ValidateChangeList", zone)
+	if f.ValidateChangeListFunc != nil {
+		return f.ValidateChangeListFunc(ctx, zone)
+	}
+	return nil, nil, nil
+}
+
+// SubmitValidatedChangeList implements akamai.FastDNSv2API.
+func (f *FastDNSv2) SubmitValidatedChangeList(ctx context.Context, zone string) (*akamai.ValidationResult, *akamai.Response, error) {
+	f.record("SubmitValidatedChangeList", zone)
+	if f.SubmitValidatedChangeListFunc != nil {
+		return f.SubmitValidatedChangeListFunc(ctx, zone)
+	}
+	return nil, nil, nil
+}
+
+// GetRecordSetsChangedSince implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetRecordSetsChangedSince(ctx context.Context, zone string, sinceVersionID string, opt *akamai.ChangedSinceOptions) (*akamai.SyncPlan, error) {
+	f.record("GetRecordSetsChangedSince", zone, sinceVersionID, opt)
+	if f.GetRecordSetsChangedSinceFunc != nil {
+		return f.GetRecordSetsChangedSinceFunc(ctx, zone, sinceVersionID, opt)
+	}
+	return nil, nil
+}
+
+// GetZoneLabels implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetZoneLabels(ctx context.Context, zone string) (akamai.ZoneLabels, *akamai.Response, error) {
+	f.record("GetZoneLabels", zone)
+	if f.GetZoneLabelsFunc != nil {
+		return f.GetZoneLabelsFunc(ctx, zone)
+	}
+	return nil, nil, nil
+}
+
+// SetZoneLabel implements akamai.FastDNSv2API.
+func (f *FastDNSv2) SetZoneLabel(ctx context.Context, zone string, key string, value string) (*akamai.Zone, *akamai.Response, error) {
+	f.record("SetZoneLabel", zone, key, value)
+	if f.SetZoneLabelFunc != nil {
+		return f.SetZoneLabelFunc(ctx, zone, key, value)
+	}
+	return nil, nil, nil
+}
+
+// ListZonesByLabel implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ListZonesByLabel(ctx context.Context, key string, value string) ([]*akamai.Zone, error) {
+	f.record("ListZonesByLabel", key, value)
+	if f.ListZonesByLabelFunc != nil {
+		return f.ListZonesByLabelFunc(ctx, key, value)
+	}
+	return nil, nil
+}
+
+// ForEachRecordSet implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ForEachRecordSet(ctx context.Context, zone string, opt *akamai.ListZoneRecordSetOptions, fn func(rs *akamai.RecordSet) error) error {
+	f.record("ForEachRecordSet", zone, opt, fn)
+	if f.ForEachRecordSetFunc != nil {
+		return f.ForEachRecordSetFunc(ctx, zone, opt, fn)
+	}
+	return nil
+}
+
+// CompareZoneVersions implements akamai.FastDNSv2API.
+func (f *FastDNSv2) CompareZoneVersions(ctx context.Context, zone string, v1 string, v2 string) (*akamai.SyncPlan, error) {
+	f.record("CompareZoneVersions", zone, v1, v2)
+	if f.CompareZoneVersionsFunc != nil {
+		return f.CompareZoneVersionsFunc(ctx, zone, v1, v2)
+	}
+	return nil, nil
+}
+
+// ClaimRecord implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ClaimRecord(ctx context.Context, zone string, name string, rtype string, owner string) error {
+	f.record("ClaimRecord", zone, name, rtype, owner)
+	if f.ClaimRecordFunc != nil {
+		return f.ClaimRecordFunc(ctx, zone, name, rtype, owner)
+	}
+	return nil
+}
+
+// ReleaseRecord implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ReleaseRecord(ctx context.Context, zone string, name string, rtype string, owner string) error {
+	f.record("ReleaseRecord", zone, name, rtype, owner)
+	if f.ReleaseRecordFunc != nil {
+		return f.ReleaseRecordFunc(ctx, zone, name, rtype, owner)
+	}
+	return nil
+}
+
+// ListOwnedRecords implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ListOwnedRecords(ctx context.Context, zone string, owner string) ([]*akamai.RecordClaim, error) {
+	f.record("ListOwnedRecords", zone, owner)
+	if f.ListOwnedRecordsFunc != nil {
+		return f.ListOwnedRecordsFunc(ctx, zone, owner)
+	}
+	return nil, nil
+}
+
+// DelegationReport implements akamai.FastDNSv2API.
+func (f *FastDNSv2) DelegationReport(ctx context.Context, zones []string, concurrency int, r akamai.NSResolver) (*akamai.DelegationReport, error) {
+	f.record("DelegationReport", zones, concurrency, r)
+	if f.DelegationReportFunc != nil {
+		return f.DelegationReportFunc(ctx, zones, concurrency, r)
+	}
+	return nil, nil
+}
+
+// ZoneStats implements akamai.FastDNSv2API.
+func (f *FastDNSv2) ZoneStats(ctx context.Context, zone string) (*akamai.ZoneStats, error) {
+	f.record("ZoneStats", zone)
+	if f.ZoneStatsFunc != nil {
+		return f.ZoneStatsFunc(ctx, zone)
+	}
+	return nil, nil
+}
+
+// FindDanglingCNAMEs implements akamai.FastDNSv2API.
+func (f *FastDNSv2) FindDanglingCNAMEs(ctx context.Context, zones []string, opt *akamai.DanglingCNAMEOptions) ([]*akamai.DanglingCNAME, error) {
+	f.record("FindDanglingCNAMEs", zones, opt)
+	if f.FindDanglingCNAMEsFunc != nil {
+		return f.FindDanglingCNAMEsFunc(ctx, zones, opt)
+	}
+	return nil, nil
+}
+
+// CreateZoneWithOptions implements akamai.FastDNSv2API.
+func (f *FastDNSv2) CreateZoneWithOptions(ctx context.Context, cid string, zone *akamai.ZoneCreateRequest, opt *akamai.CreateZoneOptions) (*akamai.CreateZoneResult, *akamai.Response, error) {
+	f.record("CreateZoneWithOptions", cid, zone, opt)
+	if f.CreateZoneWithOptionsFunc != nil {
+		return f.CreateZoneWithOptionsFunc(ctx, cid, zone, opt)
+	}
+	return nil, nil, nil
+}
+
+// GetZonesMetadata implements akamai.FastDNSv2API.
+func (f *FastDNSv2) GetZonesMetadata(ctx context.Context, zones []string, opt *akamai.ZonesMetadataOptions) (map[string]*akamai.ZoneMetadataResult, error) {
+	f.record("GetZonesMetadata", zones, opt)
+	if f.GetZonesMetadataFunc != nil {
+		return f.GetZonesMetadataFunc(ctx, zones, opt)
+	}
+	return nil, nil
+}
+
+// Contracts is a fake akamai.ContractsAPI. Every call is recorded; the call is answered by
+// the matching Func field when it is set, and with zero values otherwise.
+type Contracts struct {
+	recorder
+
+	ListContractsFunc            func(context.Context) ([]string, *akamai.Response, error)
+	ListProductsFunc             func(context.Context, string) (*akamai.ContractProducts, *akamai.Response, error)
+	FindContractsWithProductFunc func(context.Context, string) ([]string, error)
+}
+
+// ListContracts implements akamai.ContractsAPI.
+func (f *Contracts) ListContracts(ctx context.Context) ([]string, *akamai.Response, error) {
+	f.record("ListContracts")
+	if f.ListContractsFunc != nil {
+		return f.ListContractsFunc(ctx)
+	}
+	return nil, nil, nil
+}
+
+// ListProducts implements akamai.ContractsAPI.
+func (f *Contracts) ListProducts(ctx context.Context, contractID string) (*akamai.ContractProducts, *akamai.Response, error) {
+	f.record("ListProducts", contractID)
+	if f.ListProductsFunc != nil {
+		return f.ListProductsFunc(ctx, contractID)
+	}
+	return nil, nil, nil
+}
+
+// FindContractsWithProduct implements akamai.ContractsAPI.
+func (f *Contracts) FindContractsWithProduct(ctx context.Context, product string) ([]string, error) {
+	f.record("FindContractsWithProduct", product)
+	if f.FindContractsWithProductFunc != nil {
+		return f.FindContractsWithProductFunc(ctx, product)
+	}
+	return nil, nil
+}
+
+// Imaging is a fake akamai.ImagingAPI. Every call is recorded; the call is answered by
+// the matching Func field when it is set, and with zero values otherwise.
+type Imaging struct {
+	recorder
+
+	ListPolicySetsFunc   func(context.Context, string) ([]*akamai.PolicySet, *akamai.Response, error)
+	GetPolicySetFunc     func(context.Context, string, string) (*akamai.PolicySet, *akamai.Response, error)
+	ListPoliciesFunc     func(context.Context, string, string, string) (*akamai.PolicyList, *akamai.Response, error)
+	GetPolicyFunc        func(context.Context, string, string, string, string) (*akamai.Policy, *akamai.Response, error)
+	PutPolicyFunc        func(context.Context, string, string, string, string, *akamai.Policy) (*akamai.PolicyUpdateResponse, *akamai.Response, error)
+	GetPolicyHistoryFunc func(context.Context, string, string, string, string) (*akamai.PolicyHistory, *akamai.Response, error)
+}
+
+// ListPolicySets implements akamai.ImagingAPI.
+func (f *Imaging) ListPolicySets(ctx context.Context, contractID string) ([]*akamai.PolicySet, *akamai.Response, error) {
+	f.record("ListPolicySets", contractID)
+	if f.ListPolicySetsFunc != nil {
+		return f.ListPolicySetsFunc(ctx, contractID)
+	}
+	return nil, nil, nil
+}
+
+// GetPolicySet implements akamai.ImagingAPI.
+func (f *Imaging) GetPolicySet(ctx context.Context, contractID string, policySetID string) (*akamai.PolicySet, *akamai.Response, error) {
+	f.record("GetPolicySet", contractID, policySetID)
+	if f.GetPolicySetFunc != nil {
+		return f.GetPolicySetFunc(ctx, contractID, policySetID)
+	}
+	return nil, nil, nil
+}
+
+// ListPolicies implements akamai.ImagingAPI.
+func (f *Imaging) ListPolicies(ctx context.Context, contractID string, policySetID string, network string) (*akamai.PolicyList, *akamai.Response, error) {
+	f.record("ListPolicies", contractID, policySetID, network)
+	if f.ListPoliciesFunc != nil {
+		return f.ListPoliciesFunc(ctx, contractID, policySetID, network)
+	}
+	return nil, nil, nil
+}
+
+// GetPolicy implements akamai.ImagingAPI.
+func (f *Imaging) GetPolicy(ctx context.Context, contractID string, policySetID string, network string, policyID string) (*akamai.Policy, *akamai.Response, error) {
+	f.record("GetPolicy", contractID, policySetID, network, policyID)
+	if f.GetPolicyFunc != nil {
+		return f.GetPolicyFunc(ctx, contractID, policySetID, network, policyID)
+	}
+	return nil, nil, nil
+}
+
+// PutPolicy implements akamai.ImagingAPI.
+func (f *Imaging) PutPolicy(ctx context.Context, contractID string, policySetID string, network string, policyID string, policy *akamai.Policy) (*akamai.PolicyUpdateResponse, *akamai.Response, error) {
+	f.record("PutPolicy", contractID, policySetID, network, policyID, policy)
+	if f.PutPolicyFunc != nil {
+		return f.PutPolicyFunc(ctx, contractID, policySetID, network, policyID, policy)
+	}
+	return nil, nil, nil
+}
+
+// GetPolicyHistory implements akamai.ImagingAPI.
+func (f *Imaging) GetPolicyHistory(ctx context.Context, contractID string, policySetID string, network string, policyID string) (*akamai.PolicyHistory, *akamai.Response, error) {
+	f.record("GetPolicyHistory", contractID, policySetID, network, policyID)
+	if f.GetPolicyHistoryFunc != nil {
+		return f.GetPolicyHistoryFunc(ctx, contractID, policySetID, network, policyID)
+	}
+	return nil, nil, nil
+}
+
+// FirewallRules is a fake akamai.FirewallRulesAPI. Every call is recorded; the call is answered by
+// the matching Func field when it is set, and with zero values otherwise.
+type FirewallRules struct {
+	recorder
+
+	ListServicesFunc               func(context.Context) ([]*akamai.FirewallService, *akamai.Response, error)
+	ListSubscriptionsFunc          func(context.Context) (*akamai.FirewallSubscriptions, *akamai.Response, error)
+	UpdateSubscriptionsFunc        func(context.Context, *akamai.FirewallSubscriptions) (*akamai.FirewallSubscriptions, *akamai.Response, error)
+	ListCIDRBlocksFunc             func(context.Context, *akamai.CIDRBlockListOptions) ([]*akamai.CIDRBlock, *akamai.Response, error)
+	ListCIDRBlocksChangedSinceFunc func(context.Context, time.Time) ([]*akamai.CIDRBlock, error)
+}
+
+// ListServices implements akamai.FirewallRulesAPI.
+func (f *FirewallRules) ListServices(ctx context.Context) ([]*akamai.FirewallService, *akamai.Response, error) {
+	f.record("ListServices")
+	if f.ListServicesFunc != nil {
+		return f.ListServicesFunc(ctx)
+	}
+	return nil, nil, nil
+}
+
+// ListSubscriptions implements akamai.FirewallRulesAPI.
+func (f *FirewallRules) ListSubscriptions(ctx context.Context) (*akamai.FirewallSubscriptions, *akamai.Response, error) {
+	f.record("ListSubscriptions")
+	if f.ListSubscriptionsFunc != nil {
+		return f.ListSubscriptionsFunc(ctx)
+	}
+	return nil, nil, nil
+}
+
+// UpdateSubscriptions implements akamai.FirewallRulesAPI.
+func (f *FirewallRules) UpdateSubscriptions(ctx context.Context, subs *akamai.FirewallSubscriptions) (*akamai.FirewallSubscriptions, *akamai.Response, error) {
+	f.record("UpdateSubscriptions", subs)
+	if f.UpdateSubscriptionsFunc != nil {
+		return f.UpdateSubscriptionsFunc(ctx, subs)
+	}
+	return nil, nil, nil
+}
+
+// ListCIDRBlocks implements akamai.FirewallRulesAPI.
+func (f *FirewallRules) ListCIDRBlocks(ctx context.Context, opt *akamai.CIDRBlockListOptions) ([]*akamai.CIDRBlock, *akamai.Response, error) {
+	f.record("ListCIDRBlocks", opt)
+	if f.ListCIDRBlocksFunc != nil {
+		return f.ListCIDRBlocksFunc(ctx, opt)
+	}
+	return nil, nil, nil
+}
+
+// ListCIDRBlocksChangedSince implements akamai.FirewallRulesAPI.
+func (f *FirewallRules) ListCIDRBlocksChangedSince(ctx context.Context, since time.Time) ([]*akamai.CIDRBlock, error) {
+	f.record("ListCIDRBlocksChangedSince", since)
+	if f.ListCIDRBlocksChangedSinceFunc != nil {
+		return f.ListCIDRBlocksChangedSinceFunc(ctx, since)
+	}
+	return nil, nil
+}
+
+// EdgeKV is a fake akamai.EdgeKVAPI. Every call is recorded; the call is answered by
+// the matching Func field when it is set, and with zero values otherwise.
+type EdgeKV struct {
+	recorder
+
+	InitializeStoreFunc   func(context.Context) (*akamai.EdgeKVStoreStatus, *akamai.Response, error)
+	ListNamespacesFunc    func(context.Context, string) (*akamai.EdgeKVNamespaceList, *akamai.Response, error)
+	CreateNamespaceFunc   func(context.Context, string, *akamai.EdgeKVNamespace) (*akamai.EdgeKVNamespace, *akamai.Response, error)
+	GetItemFunc           func(context.Context, *akamai.EdgeKVItemOptions) (string, *akamai.Response, error)
+	UpsertItemFunc        func(context.Context, *akamai.EdgeKVItemOptions, interface{}) (string, *akamai.Response, error)
+	DeleteItemFunc        func(context.Context, *akamai.EdgeKVItemOptions) (string, *akamai.Response, error)
+	CreateAccessTokenFunc func(context.Context, *akamai.EdgeKVAccessTokenRequest) (*akamai.EdgeKVAccessToken, *akamai.Response, error)
+}
+
+// InitializeStore implements akamai.EdgeKVAPI.
+func (f *EdgeKV) InitializeStore(ctx context.Context) (*akamai.EdgeKVStoreStatus, *akamai.Response, error) {
+	f.record("InitializeStore")
+	if f.InitializeStoreFunc != nil {
+		return f.InitializeStoreFunc(ctx)
+	}
+	return nil, nil, nil
+}
+
+// ListNamespaces implements akamai.EdgeKVAPI.
+func (f *EdgeKV) ListNamespaces(ctx context.Context, network string) (*akamai.EdgeKVNamespaceList, *akamai.Response, error) {
+	f.record("ListNamespaces", network)
+	if f.ListNamespacesFunc != nil {
+		return f.ListNamespacesFunc(ctx, network)
+	}
+	return nil, nil, nil
+}
+
+// CreateNamespace implements akamai.EdgeKVAPI.
+func (f *EdgeKV) CreateNamespace(ctx context.Context, network string, ns *akamai.EdgeKVNamespace) (*akamai.EdgeKVNamespace, *akamai.Response, error) {
+	f.record("CreateNamespace", network, ns)
+	if f.CreateNamespaceFunc != nil {
+		return f.CreateNamespaceFunc(ctx, network, ns)
+	}
+	return nil, nil, nil
+}
+
+// GetItem implements akamai.EdgeKVAPI.
+func (f *EdgeKV) GetItem(ctx context.Context, opt *akamai.EdgeKVItemOptions) (string, *akamai.Response, error) {
+	f.record("GetItem", opt)
+	if f.GetItemFunc != nil {
+		return f.GetItemFunc(ctx, opt)
+	}
+	return "", nil, nil
+}
+
+// UpsertItem implements akamai.EdgeKVAPI.
+func (f *EdgeKV) UpsertItem(ctx context.Context, opt *akamai.EdgeKVItemOptions, value interface{}) (string, *akamai.Response, error) {
+	f.record("UpsertItem", opt, value)
+	if f.UpsertItemFunc != nil {
+		return f.UpsertItemFunc(ctx, opt, value)
+	}
+	return "", nil, nil
+}
+
+// DeleteItem implements akamai.EdgeKVAPI.
+func (f *EdgeKV) DeleteItem(ctx context.Context, opt *akamai.EdgeKVItemOptions) (string, *akamai.Response, error) {
+	f.record("DeleteItem", opt)
+	if f.DeleteItemFunc != nil {
+		return f.DeleteItemFunc(ctx, opt)
+	}
+	return "", nil, nil
+}
+
+// CreateAccessToken implements akamai.EdgeKVAPI.
+func (f *EdgeKV) CreateAccessToken(ctx context.Context, token *akamai.EdgeKVAccessTokenRequest) (*akamai.EdgeKVAccessToken, *akamai.Response, error) {
+	f.record("CreateAccessToken", token)
+	if f.CreateAccessTokenFunc != nil {
+		return f.CreateAccessTokenFunc(ctx, token)
+	}
+	return nil, nil, nil
+}
+
+// Reporting is a fake akamai.ReportingAPI. Every call is recorded; the call is answered by
+// the matching Func field when it is set, and with zero values otherwise.
+type Reporting struct {
+	recorder
+
+	GetReportVersionsFunc func(context.Context, string) ([]*akamai.ReportVersion, *akamai.Response, error)
+	RunReportFunc         func(context.Context, string, int, akamai.ReportOptions) (*akamai.ReportData, *akamai.Response, error)
+}
+
+// GetReportVersions implements akamai.ReportingAPI.
+func (f *Reporting) GetReportVersions(ctx context.Context, name string) ([]*akamai.ReportVersion, *akamai.Response, error) {
+	f.record("GetReportVersions", name)
+	if f.GetReportVersionsFunc != nil {
+		return f.GetReportVersionsFunc(ctx, name)
+	}
+	return nil, nil, nil
+}
+
+// RunReport implements akamai.ReportingAPI.
+func (f *Reporting) RunReport(ctx context.Context, name string, version int, opt akamai.ReportOptions) (*akamai.ReportData, *akamai.Response, error) {
+	f.record("RunReport", name, version, opt)
+	if f.RunReportFunc != nil {
+		return f.RunReportFunc(ctx, name, version, opt)
+	}
+	return nil, nil, nil
+}
+
+// HAPI is a fake akamai.HAPIAPI. Every call is recorded; the call is answered by
+// the matching Func field when it is set, and with zero values otherwise.
+type HAPI struct {
+	recorder
+
+	ListEdgeHostnamesFunc    func(context.Context, *akamai.EdgeHostnameListOptions) (*akamai.EdgeHostnameList, *akamai.Response, error)
+	GetEdgeHostnameFunc      func(context.Context, string, string) (*akamai.EdgeHostname, *akamai.Response, error)
+	PatchEdgeHostnameFunc    func(context.Context, string, string, []akamai.JSONPatchOperation) (*akamai.ChangeRequest, *akamai.Response, error)
+	DeleteEdgeHostnameFunc   func(context.Context, string, string) (*akamai.ChangeRequest, *akamai.Response, error)
+	GetChangeRequestFunc     func(context.Context, int) (*akamai.ChangeRequest, *akamai.Response, error)
+	WaitForChangeRequestFunc func(context.Context, int, time.Duration) (*akamai.ChangeRequest, error)
+}
+
+// ListEdgeHostnames implements akamai.HAPIAPI.
+func (f *HAPI) ListEdgeHostnames(ctx context.Context, opt *akamai.EdgeHostnameListOptions) (*akamai.EdgeHostnameList, *akamai.Response, error) {
+	f.record("ListEdgeHostnames", opt)
+	if f.ListEdgeHostnamesFunc != nil {
+		return f.ListEdgeHostnamesFunc(ctx, opt)
+	}
+	return nil, nil, nil
+}
+
+// GetEdgeHostname implements akamai.HAPIAPI.
+func (f *HAPI) GetEdgeHostname(ctx context.Context, recordName string, dnsZone string) (*akamai.EdgeHostname, *akamai.Response, error) {
+	f.record("GetEdgeHostname", recordName, dnsZone)
+	if f.GetEdgeHostnameFunc != nil {
+		return f.GetEdgeHostnameFunc(ctx, recordName, dnsZone)
+	}
+	return nil, nil, nil
+}
+
+// PatchEdgeHostname implements akamai.HAPIAPI.
+func (f *HAPI) PatchEdgeHostname(ctx context.Context, recordName string, dnsZone string, patch []akamai.JSONPatchOperation) (*akamai.ChangeRequest, *akamai.Response, error) {
+	f.record("PatchEdgeHostname", recordName, dnsZone, patch)
+	if f.PatchEdgeHostnameFunc != nil {
+		return f.PatchEdgeHostnameFunc(ctx, recordName, dnsZone, patch)
+	}
+	return nil, nil, nil
+}
+
+// DeleteEdgeHostname implements akamai.HAPIAPI.
+func (f *HAPI) DeleteEdgeHostname(ctx context.Context, recordName string, dnsZone string) (*akamai.ChangeRequest, *akamai.Response, error) {
+	f.record("DeleteEdgeHostname", recordName, dnsZone)
+	if f.DeleteEdgeHostnameFunc != nil {
+		return f.DeleteEdgeHostnameFunc(ctx, recordName, dnsZone)
+	}
+	return nil, nil, nil
+}
+
+// GetChangeRequest implements akamai.HAPIAPI.
+func (f *HAPI) GetChangeRequest(ctx context.Context, changeID int) (*akamai.ChangeRequest, *akamai.Response, error) {
+	f.record("GetChangeRequest", changeID)
+	if f.GetChangeRequestFunc != nil {
+		return f.GetChangeRequestFunc(ctx, changeID)
+	}
+	return nil, nil, nil
+}
+
+// WaitForChangeRequest implements akamai.HAPIAPI.
+func (f *HAPI) WaitForChangeRequest(ctx context.Context, changeID int, interval time.Duration) (*akamai.ChangeRequest, error) {
+	f.record("WaitForChangeRequest", changeID, interval)
+	if f.WaitForChangeRequestFunc != nil {
+		return f.WaitForChangeRequestFunc(ctx, changeID, interval)
+	}
+	return nil, nil
+}
+
+// Sandbox is a fake akamai.SandboxAPI. Every call is recorded; the call is answered by
+// the matching Func field when it is set, and with zero values otherwise.
+type Sandbox struct {
+	recorder
+
+	ListSandboxesFunc func(context.Context) (*akamai.SandboxList, *akamai.Response, error)
+	GetSandboxFunc    func(context.Context, string) (*akamai.Sandbox, *akamai.Response, error)
+	CreateSandboxFunc func(context.Context, *akamai.SandboxCreateRequest) (*akamai.Sandbox, *akamai.Response, error)
+	UpdateSandboxFunc func(context.Context, string, *akamai.SandboxUpdateRequest) (*akamai.Sandbox, *akamai.Response, error)
+	DeleteSandboxFunc func(context.Context, string) (*akamai.Response, error)
+	CloneSandboxFunc  func(context.Context, string, *akamai.SandboxCreateRequest) (*akamai.Sandbox, *akamai.Response, error)
+	RotateJWTFunc     func(context.Context, string) (*akamai.Sandbox, *akamai.Response, error)
+}
+
+// ListSandboxes implements akamai.SandboxAPI.
+func (f *Sandbox) ListSandboxes(ctx context.Context) (*akamai.SandboxList, *akamai.Response, error) {
+	f.record("ListSandboxes")
+	if f.ListSandboxesFunc != nil {
+		return f.ListSandboxesFunc(ctx)
+	}
+	return nil, nil, nil
+}
+
+// GetSandbox implements akamai.SandboxAPI.
+func (f *Sandbox) GetSandbox(ctx context.Context, sandboxID string) (*akamai.Sandbox, *akamai.Response, error) {
+	f.record("GetSandbox", sandboxID)
+	if f.GetSandboxFunc != nil {
+		return f.GetSandboxFunc(ctx, sandboxID)
+	}
+	return nil, nil, nil
+}
+
+// CreateSandbox implements akamai.SandboxAPI.
+func (f *Sandbox) CreateSandbox(ctx context.Context, sb *akamai.SandboxCreateRequest) (*akamai.Sandbox, *akamai.Response, error) {
+	f.record("CreateSandbox", sb)
+	if f.CreateSandboxFunc != nil {
+		return f.CreateSandboxFunc(ctx, sb)
+	}
+	return nil, nil, nil
+}
+
+// UpdateSandbox implements akamai.SandboxAPI.
+func (f *Sandbox) UpdateSandbox(ctx context.Context, sandboxID string, sb *akamai.SandboxUpdateRequest) (*akamai.Sandbox, *akamai.Response, error) {
+	f.record("UpdateSandbox", sandboxID, sb)
+	if f.UpdateSandboxFunc != nil {
+		return f.UpdateSandboxFunc(ctx, sandboxID, sb)
+	}
+	return nil, nil, nil
+}
+
+// DeleteSandbox implements akamai.SandboxAPI.
+func (f *Sandbox) DeleteSandbox(ctx context.Context, sandboxID string) (*akamai.Response, error) {
+	f.record("DeleteSandbox", sandboxID)
+	if f.DeleteSandboxFunc != nil {
+		return f.DeleteSandboxFunc(ctx, sandboxID)
+	}
+	return nil, nil
+}
+
+// CloneSandbox implements akamai.SandboxAPI.
+func (f *Sandbox) CloneSandbox(ctx context.Context, sandboxID string, sb *akamai.SandboxCreateRequest) (*akamai.Sandbox, *akamai.Response, error) {
+	f.record("CloneSandbox", sandboxID, sb)
+	if f.CloneSandboxFunc != nil {
+		return f.CloneSandboxFunc(ctx, sandboxID, sb)
+	}
+	return nil, nil, nil
+}
+
+// RotateJWT implements akamai.SandboxAPI.
+func (f *Sandbox) RotateJWT(ctx context.Context, sandboxID string) (*akamai.Sandbox, *akamai.Response, error) {
+	f.record("RotateJWT", sandboxID)
+	if f.RotateJWTFunc != nil {
+		return f.RotateJWTFunc(ctx, sandboxID)
+	}
+	return nil, nil, nil
+}
+
+var (
+	_ akamai.FastDNSv2API     = (*FastDNSv2)(nil)
+	_ akamai.ContractsAPI     = (*Contracts)(nil)
+	_ akamai.ImagingAPI       = (*Imaging)(nil)
+	_ akamai.FirewallRulesAPI = (*FirewallRules)(nil)
+	_ akamai.EdgeKVAPI        = (*EdgeKV)(nil)
+	_ akamai.ReportingAPI     = (*Reporting)(nil)
+	_ akamai.HAPIAPI          = (*HAPI)(nil)
+	_ akamai.SandboxAPI       = (*Sandbox)(nil)
+)
+
+// Purge is a fake akamai.PurgeAPI. Every call is recorded; the call is answered by the
+// matching Func field when it is set, and with zero values otherwise.
+type Purge struct {
+	recorder
+
+	PurgeFunc func(context.Context, *akamai.PurgeRequest) (*akamai.PurgeResult, *akamai.Response, error)
+}
+
+// Purge implements akamai.PurgeAPI.
+func (f *Purge) Purge(ctx context.Context, p *akamai.PurgeRequest) (*akamai.PurgeResult, *akamai.Response, error) {
+	f.record("Purge", p)
+	if f.PurgeFunc != nil {
+		return f.PurgeFunc(ctx, p)
+	}
+	return nil, nil, nil
+}
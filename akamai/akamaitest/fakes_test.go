@@ -0,0 +1,34 @@
+package akamaitest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+func TestNewClient(t *testing.T) {
+	client, fakes := NewClient()
+
+	fakes.FastDNSv2.GetZoneFunc = func(ctx context.Context, zone string) (*akamai.ZoneMetadata, *akamai.Response, error) {
+		return &akamai.ZoneMetadata{Zone: akamai.String(zone)}, nil, nil
+	}
+
+	zm, _, err := client.FastDNSv2.GetZone(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "example.com", zm.GetZone())
+
+	_, err = client.FastDNSv2.DeleteChangeList(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Equal(t, []Call{
+		{Method: "GetZone", Args: []interface{}{"example.com"}},
+		{Method: "DeleteChangeList", Args: []interface{}{"example.com"}},
+	}, fakes.FastDNSv2.Calls())
+	assert.Empty(t, fakes.Sandbox.Calls())
+}
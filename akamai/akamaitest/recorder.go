@@ -0,0 +1,274 @@
+package akamaitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// RecordEnv is the environment variable that switches recorders to record mode when set
+// to a non-empty value, e.g.:
+//
+//	AKAMAITEST_RECORD=1 go test ./... -run TestCassette
+const RecordEnv = "AKAMAITEST_RECORD"
+
+// CassetteHost replaces the host of the real API in recorded cassettes.
+const CassetteHost = "akaa-akamaitest.luna.akamaiapis.net"
+
+// Headers that are never written to cassettes.
+var sensitiveHeaders = []string{"Authorization", "Set-Cookie"}
+
+// Cassette holds the interactions recorded by a Recorder.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request that is recorded. Query holds the sorted,
+// encoded query string.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records the traffic of a client to a cassette
+// file, or replays it from there.
+//
+// In record mode, requests are sent to the real API with Transport and the sanitized
+// interactions are written to the cassette when the test ends. In replay mode,
+// responses are served from the cassette by matching the method, path and query of the
+// requests. The Authorization header, which holds a signature that changes with every
+// request, is never matched on. A request that doesn't match any recorded interaction
+// fails the test.
+type Recorder struct {
+	// Transport sends the requests in record mode. http.DefaultTransport is used if nil.
+	Transport http.RoundTripper
+
+	// Secrets are replaced by "REDACTED" in recorded request and response bodies.
+	Secrets []string
+
+	t         testing.TB
+	path      string
+	recording bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+	realHost string
+}
+
+// NewRecorder returns a recorder for the cassette at path. It records if RecordEnv is
+// set, and replays the cassette otherwise.
+func NewRecorder(t testing.TB, path string) *Recorder {
+	t.Helper()
+
+	r := &Recorder{
+		t:         t,
+		path:      path,
+		recording: os.Getenv(RecordEnv) != "",
+	}
+
+	if r.recording {
+		t.Cleanup(r.save)
+		return r
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read cassette, record it with %v=1: %v", RecordEnv, err)
+	}
+	if err := json.Unmarshal(b, &r.cassette); err != nil {
+		t.Fatalf("could not parse cassette %v: %v", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return r
+}
+
+// Recording reports whether the recorder is in record mode.
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// NewClient returns a client whose requests go through the recorder. When recording,
+// the client uses the credentials found by the default credentials chain, and they are
+// added to Secrets.
+func (r *Recorder) NewClient() *akamai.Client {
+	r.t.Helper()
+
+	var cc *credentials.Credentials
+	if !r.recording {
+		cc = credentials.NewStaticCredentials(TestClientSecret, TestClientToken, TestAccessToken, CassetteHost)
+	}
+
+	client, err := akamai.NewClient(&http.Client{Transport: r}, cc)
+	if err != nil {
+		r.t.Fatalf("could not create client: %v", err)
+	}
+
+	if r.recording {
+		creds, err := client.Credentials.Get()
+		if err != nil {
+			r.t.Fatalf("could not retrieve credentials: %v", err)
+		}
+		r.Secrets = append(r.Secrets, creds.ClientSecret, creds.ClientToken, creds.AccessToken)
+	}
+
+	return client
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+		Body:   string(body),
+	}
+
+	if r.recording {
+		return r.record(req, recorded)
+	}
+	return r.replay(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	header := resp.Header.Clone()
+	for _, h := range sensitiveHeaders {
+		header.Del(h)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.realHost = req.URL.Host
+	recorded.Body = r.sanitize(recorded.Body)
+	for k, vs := range header {
+		for i, v := range vs {
+			header[k][i] = r.sanitize(v)
+		}
+	}
+
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: header,
+			Body:   r.sanitize(string(b)),
+		},
+	})
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request.Method != recorded.Method || in.Request.Path != recorded.Path || in.Request.Query != recorded.Query {
+			continue
+		}
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+			StatusCode:    in.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	err := fmt.Errorf("no recorded interaction in %v matches %v %v?%v", r.path, recorded.Method, recorded.Path, recorded.Query)
+	r.t.Error(err)
+	return nil, err
+}
+
+// sanitize removes the secrets and the real host from s.
+func (r *Recorder) sanitize(s string) string {
+	for _, secret := range r.Secrets {
+		if secret != "" {
+			s = strings.Replace(s, secret, "REDACTED", -1)
+		}
+	}
+	if r.realHost != "" {
+		s = strings.Replace(s, r.realHost, CassetteHost, -1)
+	}
+	return s
+}
+
+func (r *Recorder) save() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(&r.cassette, "", "  ")
+	if err != nil {
+		r.t.Errorf("could not encode cassette: %v", err)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		r.t.Errorf("could not create cassette directory: %v", err)
+		return
+	}
+
+	if err := ioutil.WriteFile(r.path, append(b, '\n'), 0644); err != nil {
+		r.t.Errorf("could not write cassette: %v", err)
+	}
+}
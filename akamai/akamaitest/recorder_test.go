@@ -0,0 +1,92 @@
+package akamaitest
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// errorRecorder is a testing.TB that keeps the errors reported to it instead of failing
+// the test.
+type errorRecorder struct {
+	testing.TB
+	errors []string
+}
+
+func (e *errorRecorder) Error(args ...interface{}) {
+	e.errors = append(e.errors, fmt.Sprint(args...))
+}
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-session")
+		fmt.Fprintf(w, `{"zone": "example.com", "comment": "token akab-real-client-token", "href": "http://%v/config-dns/v2/zones/example.com"}`, r.Host)
+	}))
+	defer server.Close()
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(RecordEnv, "1")
+
+		rec := NewRecorder(t, path)
+		assert.True(t, rec.Recording())
+		rec.Secrets = []string{"akab-real-client-token"}
+
+		creds := credentials.NewStaticCredentials("real-secret", "akab-real-client-token", "akab-real-access-token", server.Listener.Addr().String())
+		client, err := akamai.NewClient(&http.Client{Transport: rec}, creds)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		client.BaseURL, _ = url.Parse(server.URL + "/")
+
+		if _, _, err := client.FastDNSv2.ListZones(context.Background(), &akamai.ZoneListOptions{Types: "PRIMARY", Page: akamai.Int(2)}); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	})
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	cassette := string(b)
+	assert.NotContains(t, cassette, "akab-real-client-token")
+	assert.NotContains(t, cassette, "secret-session")
+	assert.NotContains(t, cassette, "Authorization")
+	assert.NotContains(t, cassette, server.Listener.Addr().String())
+	assert.Contains(t, cassette, CassetteHost)
+	assert.Contains(t, cassette, "page=2\\u0026types=PRIMARY")
+
+	t.Run("replay", func(t *testing.T) {
+		client := NewRecorder(t, path).NewClient()
+
+		zones, _, err := client.FastDNSv2.ListZones(context.Background(), &akamai.ZoneListOptions{Page: akamai.Int(2), Types: "PRIMARY"})
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.NotNil(t, zones)
+	})
+
+	t.Run("unmatched", func(t *testing.T) {
+		et := &errorRecorder{TB: t}
+		client := NewRecorder(et, path).NewClient()
+
+		_, _, err := client.FastDNSv2.ListZones(context.Background(), nil)
+		assert.Error(t, err)
+		if assert.Len(t, et.errors, 1) {
+			assert.True(t, strings.Contains(et.errors[0], "GET /config-dns/v2/zones?"))
+		}
+	})
+}
@@ -0,0 +1,1445 @@
+package akamaitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// Credentials of the client returned by NewServer. They are only meaningful to the
+// fake server.
+const (
+	TestClientSecret = "akamaitest-client-secret"
+	TestClientToken  = "akab-akamaitest-client-token"
+	TestAccessToken  = "akab-akamaitest-access-token"
+	TestContractID   = "1-AKAMAITEST"
+	TestGroupID      = 1
+)
+
+const (
+	defaultPageSize     = 25
+	defaultMaximumZones = 1000
+)
+
+// Server is a stateful, in-memory fake of the FastDNS v2 API. It stores zones, their
+// record sets, and change lists, and answers with the same status codes and
+// problem+json error bodies as Akamai does.
+type Server struct {
+	*httptest.Server
+
+	// Credentials are the credentials of the client returned by NewServer.
+	Credentials *credentials.Credentials
+
+	// VerifySignatures makes the server reject requests whose EdgeGrid signature doesn't
+	// match Credentials with a 401.
+	VerifySignatures bool
+
+	// AsyncGroupChanges makes the server answer zone group changes with a 202 Accepted,
+	// as the API does for the moves it makes asynchronously.
+	AsyncGroupChanges bool
+
+	// ShowAllLimit caps the number of items of showAll responses, whose total count
+	// still includes them all, as the API truncates the showAll responses of very large
+	// lists. Zero doesn't cap them.
+	ShowAllLimit int
+
+	// MaxPageSize makes the list requests with showAll, or with a pageSize above it,
+	// fail with a 504 Gateway Timeout, as those of very large lists do when the API is
+	// busy. Zero doesn't limit them.
+	MaxPageSize int
+
+	// ModifiedBy is the user the server records as the author of the changes made to
+	// zones from then on. Defaults to TestClientToken.
+	ModifiedBy string
+
+	// DisableVersions makes the zone version endpoints answer 403 Forbidden, as they do
+	// for the accounts without access to the version history of their zones.
+	DisableVersions bool
+
+	// ContentType, if set, is sent as the Content-Type of the JSON responses instead of
+	// application/json, as a gateway serving another version of the API would.
+	ContentType string
+
+	mu             sync.Mutex
+	zones          map[string]*zoneState
+	changeLists    map[string]*changeListState
+	deleteRequests map[string]*akamai.ZoneDeleteResult
+	groups         map[int]string
+	maximumZones   map[string]int
+	requests       []string
+}
+
+type zoneState struct {
+	zone    akamai.Zone
+	records map[string]*akamai.RecordSet
+
+	// versions holds the versions of the zone, oldest first, with the record sets
+	// each had.
+	versions []*zoneVersion
+}
+
+type zoneVersion struct {
+	version akamai.ZoneVersion
+	records map[string]*akamai.RecordSet
+}
+
+type changeListState struct {
+	changeList akamai.ChangeList
+	records    map[string]*akamai.RecordSet
+}
+
+// NewServer starts a fake FastDNS v2 server which is closed when the test ends, and
+// returns a client configured to make its requests against it.
+func NewServer(t testing.TB) (*akamai.Client, *Server) {
+	t.Helper()
+
+	s := newServer()
+	t.Cleanup(s.Close)
+
+	client, err := akamai.NewClient(s.Client(), s.Credentials)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+
+	u, err := url.Parse(s.URL + "/")
+	if err != nil {
+		t.Fatalf("could not parse server URL: %v", err)
+	}
+	client.BaseURL = u
+
+	return client, s
+}
+
+// NewExampleServer starts a fake FastDNS v2 server for Example functions, which have no
+// testing.TB, and returns a client made with NewStaticTestClient to make its requests
+// against it. The caller closes the server. It panics if the client can't be made.
+func NewExampleServer() (*akamai.Client, *Server) {
+	s := newServer()
+	client, err := newStaticTestClient(s.Client(), s.URL)
+	if err != nil {
+		s.Close()
+		panic(fmt.Sprintf("could not create client: %v", err))
+	}
+	return client, s
+}
+
+// newServer starts a fake server whose Credentials are the static test credentials
+// for its host.
+func newServer() *Server {
+	s := &Server{
+		zones:          map[string]*zoneState{},
+		changeLists:    map[string]*changeListState{},
+		deleteRequests: map[string]*akamai.ZoneDeleteResult{},
+		groups:         map[int]string{TestGroupID: "akamaitest"},
+		maximumZones:   map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.Credentials = testCredentials(s.Listener.Addr().String())
+	return s
+}
+
+// AddZone stores a zone as if it had been created through the API. PRIMARY zones get
+// the SOA and NS record sets Akamai creates for them.
+func (s *Server) AddZone(zone *akamai.ZoneCreateRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addZone(TestContractID, zone)
+}
+
+// AddRecordSet stores a record set in an existing zone.
+func (s *Server) AddRecordSet(rs *akamai.RecordSetCreateRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z, ok := s.zones[rs.Zone]
+	if !ok {
+		return fmt.Errorf("zone %v does not exist", rs.Zone)
+	}
+	z.records[recordKey(rs.Name, rs.Type)] = newRecordSet(rs)
+	z.touch(s.modifiedBy())
+	return nil
+}
+
+// AddGroup adds a group zones can be created in and moved to. The server starts with
+// the group TestGroupID, which the zones are created in by default.
+func (s *Server) AddGroup(id int, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups[id] = name
+}
+
+// SetActivationState sets the activation state of an existing zone, such as
+// akamai.ZonePending to have WaitForZoneActive wait for it.
+func (s *Server) SetActivationState(zone string, state akamai.ActivationState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z, ok := s.zones[zone]
+	if !ok {
+		return fmt.Errorf("zone %v does not exist", zone)
+	}
+	z.zone.ActivationState = &state
+	return nil
+}
+
+// SetChangeListModified sets the last modification date of the change list of a
+// zone, e.g. to make it look abandoned.
+func (s *Server) SetChangeListModified(zone string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cl, ok := s.changeLists[zone]
+	if !ok {
+		return fmt.Errorf("zone %v has no change list", zone)
+	}
+	cl.changeList.LastModifiedDate = t.UTC().Format(time.RFC3339)
+	return nil
+}
+
+// SetMaximumZones sets the maximum number of zones of a contract, which defaults to
+// 1000. Zone creations beyond it fail, and a maximum of zero is reported as none.
+func (s *Server) SetMaximumZones(contractID string, max int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maximumZones[akamai.TrimContractPrefix(contractID)] = max
+}
+
+// contractMaximumZones returns the maximum number of zones of a contract, zero if it
+// has none.
+func (s *Server) contractMaximumZones(id string) int {
+	if max, ok := s.maximumZones[id]; ok {
+		return max
+	}
+	return defaultMaximumZones
+}
+
+// contractZoneCount returns the number of zones of a contract.
+func (s *Server) contractZoneCount(id string) int {
+	var count int
+	for _, z := range s.zones {
+		if z.zone.GetContractID() == id {
+			count++
+		}
+	}
+	return count
+}
+
+// Requests returns the requests the server received so far, in order, as the method
+// and path, e.g. "GET /config-dns/v2/zones".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// Zone returns the stored zone, or nil if it does not exist.
+func (s *Server) Zone(name string) *akamai.Zone {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z, ok := s.zones[name]
+	if !ok {
+		return nil
+	}
+	zone := z.zone
+	return &zone
+}
+
+// RecordSets returns the stored record sets of a zone, sorted by name and type.
+func (s *Server) RecordSets(zone string) []*akamai.RecordSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z, ok := s.zones[zone]
+	if !ok {
+		return nil
+	}
+	return sortedRecords(z.records)
+}
+
+func (s *Server) addZone(contractID string, zr *akamai.ZoneCreateRequest) *zoneState {
+	z := &zoneState{
+		zone: akamai.Zone{
+			ContractID:      akamai.String(contractID),
+			GroupID:         akamai.Int(TestGroupID),
+			Zone:            akamai.String(zr.Zone),
+			Type:            akamai.String(strings.ToUpper(zr.Type)),
+			ActivationState: akamai.ActivationStateOf(akamai.ZoneActive),
+		},
+		records: map[string]*akamai.RecordSet{},
+	}
+
+	if z.zone.GetType() == "PRIMARY" {
+		z.records[recordKey(zr.Zone, "SOA")] = newRecordSet(&akamai.RecordSetCreateRequest{
+			Name:  zr.Zone,
+			Type:  "SOA",
+			TTL:   akamai.Int(86400),
+			Rdata: []string{fmt.Sprintf("a1-1.akam.net. hostmaster.%v. 1 3600 600 604800 300", zr.Zone)},
+		})
+		z.records[recordKey(zr.Zone, akamai.RRTypeNs)] = newRecordSet(&akamai.RecordSetCreateRequest{
+			Name:  zr.Zone,
+			Type:  akamai.RRTypeNs,
+			TTL:   akamai.Int(86400),
+			Rdata: []string{"a1-1.akam.net.", "a2-2.akam.net."},
+		})
+	}
+	z.update(zr, s.modifiedBy())
+
+	s.zones[zr.Zone] = z
+	return z
+}
+
+// update applies the mutable fields of a request to the zone.
+func (z *zoneState) update(zr *akamai.ZoneCreateRequest, by string) {
+	z.zone.Comment = optionalString(zr.Comment)
+	z.zone.EndCustomerID = optionalString(zr.EndCustomerID)
+	z.zone.Target = optionalString(zr.Target)
+	if zr.SignAndServe != nil {
+		z.zone.SignAndServe = akamai.Bool(*zr.SignAndServe)
+	} else if z.zone.SignAndServe == nil {
+		z.zone.SignAndServe = akamai.Bool(false)
+	}
+	z.zone.SignAndServeAlgo = optionalString(zr.SignAndServeAlgo)
+	z.zone.Masters = nil
+	for _, m := range zr.Masters {
+		z.zone.Masters = append(z.zone.Masters, akamai.String(m))
+	}
+	z.touch(by)
+}
+
+// touch creates a new version of the zone, made by the user by, holding its current
+// record sets.
+func (z *zoneState) touch(by string) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	z.zone.VersionID = akamai.String(uuid.New().String())
+	z.zone.LastModifiedDate = akamai.String(now)
+	z.zone.LastModifiedBy = akamai.String(by)
+	z.zone.LastActivationDate = akamai.String(now)
+
+	records := make(map[string]*akamai.RecordSet, len(z.records))
+	for k, rs := range z.records {
+		records[k] = rs
+	}
+	z.versions = append(z.versions, &zoneVersion{
+		version: akamai.ZoneVersion{
+			VersionID:          z.zone.VersionID,
+			LastModifiedDate:   z.zone.LastModifiedDate,
+			LastModifiedBy:     z.zone.LastModifiedBy,
+			LastActivationDate: z.zone.LastActivationDate,
+			ActivationState:    z.zone.ActivationState,
+		},
+		records: records,
+	})
+}
+
+// modifiedBy returns the user the changes are recorded as made by.
+func (s *Server) modifiedBy() string {
+	if s.ModifiedBy == "" {
+		return TestClientToken
+	}
+	return s.ModifiedBy
+}
+
+func (z *zoneState) metadata() *akamai.ZoneMetadata {
+	return &akamai.ZoneMetadata{
+		ContractID:            z.zone.ContractID,
+		GroupID:               z.zone.GroupID,
+		Zone:                  z.zone.Zone,
+		Type:                  z.zone.Type,
+		EndCustomerID:         z.zone.EndCustomerID,
+		Target:                z.zone.Target,
+		TSIGKey:               z.zone.TSIGKey,
+		Masters:               z.zone.Masters,
+		AliasCount:            akamai.Int(0),
+		SignAndServe:          akamai.Bool(z.zone.GetSignAndServe()),
+		SignAndServeAlgorithm: z.zone.SignAndServeAlgo,
+		VersionId:             z.zone.VersionID,
+		LastModifiedDate:      z.zone.LastModifiedDate,
+		LastModifiedBy:        z.zone.LastModifiedBy,
+		LastActivationDate:    z.zone.LastActivationDate,
+		ActivationState:       z.zone.ActivationState,
+		Comment:               z.zone.Comment,
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.VerifySignatures {
+		if err := akamai.VerifyRequest(r, s.Credentials); err != nil {
+			writeError(w, r, http.StatusUnauthorized, "Not authorized", err.Error())
+			return
+		}
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/config-dns/v2/")
+	if path == r.URL.Path {
+		writeError(w, r, http.StatusNotFound, "Not Found", "The requested resource does not exist")
+		return
+	}
+	seg := strings.Split(strings.Trim(path, "/"), "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set("X-Request-Id", fmt.Sprintf("req-%d", len(s.requests)))
+	if s.ContentType != "" {
+		w = &contentTypeWriter{ResponseWriter: w, contentType: s.ContentType}
+	}
+	if s.MaxPageSize > 0 && r.Method == "GET" && pageTooLarge(r, s.MaxPageSize) {
+		writeError(w, r, http.StatusGatewayTimeout, "Gateway Timeout", "The list took too long to respond")
+		return
+	}
+
+	switch {
+	case len(seg) == 1 && seg[0] == "zones":
+		switch r.Method {
+		case "GET":
+			s.listZones(w, r)
+		case "POST":
+			s.createZone(w, r)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	case len(seg) == 2 && seg[0] == "zones" && seg[1] == "delete-requests":
+		s.deleteZones(w, r)
+	case len(seg) == 3 && seg[0] == "zones" && seg[1] == "delete-requests":
+		s.deleteZonesStatus(w, r, seg[2])
+	case len(seg) == 4 && seg[0] == "zones" && seg[1] == "delete-requests" && seg[3] == "result":
+		s.deleteZonesResult(w, r, seg[2])
+	case len(seg) == 2 && seg[0] == "zones":
+		switch r.Method {
+		case "GET":
+			s.getZone(w, r, seg[1])
+		case "PUT":
+			s.updateZone(w, r, seg[1])
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	case len(seg) == 3 && seg[0] == "zones" && seg[2] == "recordsets":
+		switch r.Method {
+		case "GET":
+			s.listRecordSets(w, r, seg[1])
+		case "PUT":
+			s.replaceRecordSets(w, r, seg[1])
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	case len(seg) >= 3 && seg[0] == "zones" && seg[2] == "versions" && s.DisableVersions:
+		writeError(w, r, http.StatusForbidden, "Forbidden", "The version history of zones is not available")
+	case len(seg) == 3 && seg[0] == "zones" && seg[2] == "versions":
+		s.listVersions(w, r, seg[1])
+	case len(seg) == 5 && seg[0] == "zones" && seg[2] == "versions" && seg[4] == "recordsets":
+		s.listVersionRecordSets(w, r, seg[1], seg[3])
+	case len(seg) == 3 && seg[0] == "zones" && seg[2] == "group":
+		s.changeZoneGroup(w, r, seg[1])
+	case len(seg) == 3 && seg[0] == "zones" && seg[2] == "contract":
+		s.getZoneContract(w, r, seg[1])
+	case len(seg) == 5 && seg[0] == "zones" && seg[2] == "names" && seg[4] == "types":
+		s.recordTypesForName(w, r, seg[1], seg[3])
+	case len(seg) == 6 && seg[0] == "zones" && seg[2] == "names" && seg[4] == "types":
+		s.recordSet(w, r, seg[1], seg[3], seg[5])
+	case len(seg) == 2 && seg[0] == "data" && seg[1] == "groups":
+		s.listGroups(w, r)
+	case len(seg) == 2 && seg[0] == "data" && seg[1] == "contracts":
+		s.listContracts(w, r)
+	case len(seg) == 2 && seg[0] == "data" && seg[1] == "authorities":
+		s.getAuthorities(w, r)
+	case len(seg) == 3 && seg[0] == "data" && seg[1] == "recordsets" && seg[2] == "types":
+		s.getRecordTypes(w, r)
+	case len(seg) == 1 && seg[0] == "changelists":
+		s.createChangeList(w, r)
+	case len(seg) == 2 && seg[0] == "changelists":
+		switch r.Method {
+		case "GET":
+			s.getChangeList(w, r, seg[1])
+		case "DELETE":
+			s.deleteChangeList(w, r, seg[1])
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	case len(seg) == 3 && seg[0] == "changelists" && seg[2] == "recordsets":
+		s.listChangeListRecordSets(w, r, seg[1])
+	case len(seg) == 3 && seg[0] == "changelists" && seg[2] == "submit":
+		s.submitChangeList(w, r, seg[1])
+	default:
+		writeError(w, r, http.StatusNotFound, "Not Found", "The requested resource does not exist")
+	}
+}
+
+func (s *Server) listZones(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	contracts := splitList(q.Get("contractIds"))
+	types := splitList(strings.ToUpper(q.Get("types")))
+	search := strings.ToLower(q.Get("search"))
+	gid := q.Get("gid")
+
+	names := make([]string, 0, len(s.zones))
+	for name := range s.zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var zones []*akamai.Zone
+	for _, name := range names {
+		z := s.zones[name]
+		if len(contracts) > 0 && !contains(contracts, z.zone.GetContractID()) {
+			continue
+		}
+		if len(types) > 0 && !contains(types, z.zone.GetType()) {
+			continue
+		}
+		if search != "" && !strings.Contains(name, search) {
+			continue
+		}
+		if gid != "" && gid != strconv.Itoa(z.zone.GetGroupID()) {
+			continue
+		}
+		zone := z.zone
+		zones = append(zones, &zone)
+	}
+	sortZones(zones, q.Get("sortBy"))
+
+	showAll := q.Get("showAll") == "true"
+	page, pageSize, ok := pagination(w, r)
+	if !ok {
+		return
+	}
+	total := len(zones)
+	if !showAll {
+		start, end := pageBounds(len(zones), page, pageSize)
+		zones = zones[start:end]
+	} else if s.ShowAllLimit > 0 && len(zones) > s.ShowAllLimit {
+		zones = zones[:s.ShowAllLimit]
+	}
+
+	writeJSON(w, http.StatusOK, &akamai.ZoneList{
+		Metadata: &akamai.ZoneListMetadata{
+			Page:          akamai.Int(page),
+			PageSize:      akamai.Int(pageSize),
+			ShowAll:       akamai.Bool(showAll),
+			TotalElements: akamai.Int(total),
+		},
+		Zones: zones,
+	})
+}
+
+// sortZones sorts zones, listed by name, by the comma-separated fields of sortBy, each
+// in descending order if prefixed with "-". The fields other than zone, type,
+// contractId and lastModifiedDate are ignored.
+func sortZones(zones []*akamai.Zone, sortBy string) {
+	fields := splitList(sortBy)
+	if len(fields) == 0 {
+		return
+	}
+	sort.SliceStable(zones, func(i, j int) bool {
+		for _, f := range fields {
+			desc := strings.HasPrefix(f, "-")
+			var a, b string
+			switch strings.TrimPrefix(f, "-") {
+			case "zone":
+				a, b = zones[i].GetZone(), zones[j].GetZone()
+			case "type":
+				a, b = zones[i].GetType(), zones[j].GetType()
+			case "contractId":
+				a, b = zones[i].GetContractID(), zones[j].GetContractID()
+			case "lastModifiedDate":
+				a, b = zones[i].GetLastModifiedDate(), zones[j].GetLastModifiedDate()
+			}
+			if a != b {
+				return a < b != desc
+			}
+		}
+		return false
+	})
+}
+
+func (s *Server) createZone(w http.ResponseWriter, r *http.Request) {
+	contractID := r.URL.Query().Get("contractId")
+	if contractID == "" {
+		writeError(w, r, http.StatusBadRequest, "Bad Request", "contractId is required")
+		return
+	}
+
+	var zr akamai.ZoneCreateRequest
+	if !readJSON(w, r, &zr) {
+		return
+	}
+	if zr.Zone == "" || zr.Type == "" {
+		writeError(w, r, http.StatusBadRequest, "Bad Request", "zone and type are required")
+		return
+	}
+	if _, ok := s.zones[zr.Zone]; ok {
+		writeError(w, r, http.StatusConflict, "Conflict", fmt.Sprintf("Zone %v already exists", zr.Zone))
+		return
+	}
+	id := akamai.TrimContractPrefix(contractID)
+	if max := s.contractMaximumZones(id); max > 0 && s.contractZoneCount(id) >= max {
+		writeError(w, r, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Contract %v has reached its maximum of %d zones", id, max))
+		return
+	}
+
+	z := s.addZone(id, &zr)
+	w.Header().Set("Location", "/config-dns/v2/zones/"+z.zone.GetZone())
+	writeJSON(w, http.StatusCreated, &z.zone)
+}
+
+func (s *Server) getZone(w http.ResponseWriter, r *http.Request, name string) {
+	z, ok := s.zone(w, r, name)
+	if !ok {
+		return
+	}
+
+	// The entity tag of a zone is its version, and conditional requests for the
+	// version they have are answered with a 304 Not Modified.
+	etag := `"` + z.zone.GetVersionID() + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeJSON(w, http.StatusOK, z.metadata())
+}
+
+func (s *Server) updateZone(w http.ResponseWriter, r *http.Request, name string) {
+	z, ok := s.zone(w, r, name)
+	if !ok {
+		return
+	}
+
+	// The body is decoded as a zone rather than a ZoneCreateRequest, as the API takes
+	// the TSIG key as an object.
+	var zr akamai.Zone
+	if !readJSON(w, r, &zr) {
+		return
+	}
+	if zr.GetZone() != name || (zr.GetType() != "" && !strings.EqualFold(zr.GetType(), z.zone.GetType())) {
+		writeError(w, r, http.StatusBadRequest, "Bad Request", "zone and type cannot be changed")
+		return
+	}
+	if zr.VersionID != nil && zr.GetVersionID() != z.zone.GetVersionID() {
+		writeError(w, r, http.StatusConflict, "Conflict", fmt.Sprintf("Zone %v was changed since version %v", name, zr.GetVersionID()))
+		return
+	}
+
+	z.zone.Comment = zr.Comment
+	z.zone.EndCustomerID = zr.EndCustomerID
+	z.zone.Target = zr.Target
+	z.zone.TSIGKey = zr.TSIGKey
+	z.zone.Masters = zr.Masters
+	if zr.SignAndServe != nil {
+		z.zone.SignAndServe = zr.SignAndServe
+	}
+	z.zone.SignAndServeAlgo = zr.SignAndServeAlgo
+	z.touch(s.modifiedBy())
+	writeJSON(w, http.StatusOK, &z.zone)
+}
+
+func (s *Server) deleteZones(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	var zd akamai.ZoneDeleteRequest
+	if !readJSON(w, r, &zd) {
+		return
+	}
+	if len(zd.Zones) == 0 {
+		writeError(w, r, http.StatusBadRequest, "Bad Request", "zones is required")
+		return
+	}
+
+	id := uuid.New().String()
+	result := &akamai.ZoneDeleteResult{RequestID: akamai.String(id)}
+	for _, name := range zd.Zones {
+		if _, ok := s.zones[name]; !ok {
+			result.FailedZones = append(result.FailedZones, &struct {
+				Zone          *string `json:"zone,omitempty"`
+				FailureReason *string `json:"failureReason,omitempty"`
+			}{
+				Zone:          akamai.String(name),
+				FailureReason: akamai.String("ZONE_NOT_FOUND"),
+			})
+			continue
+		}
+		delete(s.zones, name)
+		delete(s.changeLists, name)
+		result.DeletedZones = append(result.DeletedZones, akamai.String(name))
+	}
+	s.deleteRequests[id] = result
+
+	w.Header().Set("Location", "/config-dns/v2/zones/delete-requests/"+id)
+	writeJSON(w, http.StatusCreated, deleteStatus(result))
+}
+
+func (s *Server) deleteZonesStatus(w http.ResponseWriter, r *http.Request, id string) {
+	result, ok := s.deleteRequest(w, r, id)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, deleteStatus(result))
+}
+
+func (s *Server) deleteZonesResult(w http.ResponseWriter, r *http.Request, id string) {
+	result, ok := s.deleteRequest(w, r, id)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) deleteRequest(w http.ResponseWriter, r *http.Request, id string) (*akamai.ZoneDeleteResult, bool) {
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return nil, false
+	}
+
+	result, ok := s.deleteRequests[id]
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "Not Found", fmt.Sprintf("Delete request %v does not exist", id))
+		return nil, false
+	}
+	return result, true
+}
+
+func deleteStatus(result *akamai.ZoneDeleteResult) *akamai.ZoneDeleteResponse {
+	return &akamai.ZoneDeleteResponse{
+		RequestID:      result.RequestID,
+		ExpirationDate: akamai.String(time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)),
+		ZonesSubmitted: akamai.Int(len(result.DeletedZones) + len(result.FailedZones)),
+		SuccessCount:   akamai.Int(len(result.DeletedZones)),
+		FailureCount:   akamai.Int(len(result.FailedZones)),
+		IsComplete:     akamai.Bool(true),
+	}
+}
+
+func (s *Server) listRecordSets(w http.ResponseWriter, r *http.Request, name string) {
+	z, ok := s.zone(w, r, name)
+	if !ok {
+		return
+	}
+	if z.zone.GetType() == "ALIAS" {
+		writeError(w, r, http.StatusBadRequest, "Bad Request", "Record sets can only be listed for PRIMARY and SECONDARY zones")
+		return
+	}
+
+	list, ok := listRecords(w, r, z.records, s.ShowAllLimit)
+	if !ok {
+		return
+	}
+	list.Metadata.Zone = akamai.String(name)
+	writeJSON(w, http.StatusOK, &akamai.ListZoneRecordSets{
+		Metadata: &akamai.ListZoneRecordMetadata{
+			Zone:          list.Metadata.Zone,
+			Types:         list.Metadata.Types,
+			Page:          list.Metadata.Page,
+			PageSize:      list.Metadata.PageSize,
+			TotalElements: list.Metadata.TotalElements,
+		},
+		RecordSets: list.Recordsets,
+	})
+}
+
+func (s *Server) listVersions(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	z, ok := s.zone(w, r, name)
+	if !ok {
+		return
+	}
+
+	versions := make([]*akamai.ZoneVersion, 0, len(z.versions))
+	for i := len(z.versions) - 1; i >= 0; i-- {
+		v := z.versions[i].version
+		versions = append(versions, &v)
+	}
+
+	showAll := r.URL.Query().Get("showAll") == "true"
+	page, pageSize, ok := pagination(w, r)
+	if !ok {
+		return
+	}
+	total := len(versions)
+	if !showAll {
+		start, end := pageBounds(len(versions), page, pageSize)
+		versions = versions[start:end]
+	} else if s.ShowAllLimit > 0 && len(versions) > s.ShowAllLimit {
+		versions = versions[:s.ShowAllLimit]
+	}
+
+	writeJSON(w, http.StatusOK, &akamai.ZoneVersionList{
+		Metadata: &akamai.ZoneVersionListMetadata{
+			Zone:          akamai.String(name),
+			Page:          akamai.Int(page),
+			PageSize:      akamai.Int(pageSize),
+			ShowAll:       akamai.Bool(showAll),
+			TotalElements: akamai.Int(total),
+		},
+		Versions: versions,
+	})
+}
+
+func (s *Server) listVersionRecordSets(w http.ResponseWriter, r *http.Request, name, id string) {
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	z, ok := s.zone(w, r, name)
+	if !ok {
+		return
+	}
+
+	var version *zoneVersion
+	for _, v := range z.versions {
+		if v.version.GetVersionID() == id {
+			version = v
+		}
+	}
+	if version == nil {
+		writeError(w, r, http.StatusNotFound, "Not Found", fmt.Sprintf("Version %v of zone %v does not exist", id, name))
+		return
+	}
+
+	list, ok := listRecords(w, r, version.records, s.ShowAllLimit)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &akamai.ListZoneRecordSets{
+		Metadata: &akamai.ListZoneRecordMetadata{
+			Zone:          akamai.String(name),
+			Types:         list.Metadata.Types,
+			Page:          list.Metadata.Page,
+			PageSize:      list.Metadata.PageSize,
+			TotalElements: list.Metadata.TotalElements,
+		},
+		RecordSets: list.Recordsets,
+	})
+}
+
+func (s *Server) replaceRecordSets(w http.ResponseWriter, r *http.Request, name string) {
+	z, ok := s.zone(w, r, name)
+	if !ok {
+		return
+	}
+	if z.zone.GetType() != "PRIMARY" {
+		writeError(w, r, http.StatusBadRequest, "Bad Request", "Record sets can only be replaced for PRIMARY zones")
+		return
+	}
+
+	var body akamai.ReplaceRecordSetsRequest
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	records := map[string]*akamai.RecordSet{}
+	for _, rs := range body.RecordSets {
+		if rs.Name == "" || rs.Type == "" || len(rs.Rdata) == 0 || rs.GetTTL() <= 0 {
+			writeError(w, r, http.StatusBadRequest, "Bad Request", "name, type, rdata and ttl are required")
+			return
+		}
+		records[recordKey(rs.Name, rs.Type)] = newRecordSet(rs)
+	}
+	for _, rtype := range []string{"SOA", akamai.RRTypeNs} {
+		if _, ok := records[recordKey(name, rtype)]; !ok {
+			writeError(w, r, http.StatusBadRequest, "Bad Request", fmt.Sprintf("The %v record set of the zone apex is required", rtype))
+			return
+		}
+	}
+
+	z.records = records
+	z.touch(s.modifiedBy())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) changeZoneGroup(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != "PUT" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	z, ok := s.zone(w, r, name)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		GroupID int `json:"groupId"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if _, ok := s.groups[body.GroupID]; !ok {
+		writeError(w, r, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Group %d does not exist", body.GroupID))
+		return
+	}
+
+	z.zone.GroupID = akamai.Int(body.GroupID)
+	z.touch(s.modifiedBy())
+	if s.AsyncGroupChanges {
+		w.Header().Set("Location", "/config-dns/v2/zones/"+name)
+		writeJSON(w, http.StatusAccepted, z.metadata())
+		return
+	}
+	writeJSON(w, http.StatusOK, &z.zone)
+}
+
+func (s *Server) getZoneContract(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	z, ok := s.zone(w, r, name)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &akamai.Contract{
+		ContractID:       z.zone.ContractID,
+		ContractName:     akamai.String("akamaitest"),
+		ContractTypeName: akamai.String("Direct Customer"),
+		Features:         []*string{akamai.String("FASTDNS")},
+		Permissions:      []*string{akamai.String("READ"), akamai.String("WRITE"), akamai.String("ADD")},
+		ZoneCount:        s.contractZoneCount(z.zone.GetContractID()),
+		MaximumZones:     s.contractMaximumZones(z.zone.GetContractID()),
+	})
+}
+
+func (s *Server) recordSet(w http.ResponseWriter, r *http.Request, zone, name, rtype string) {
+	z, ok := s.zone(w, r, zone)
+	if !ok {
+		return
+	}
+
+	key := recordKey(name, rtype)
+	existing, exists := z.records[key]
+
+	switch r.Method {
+	case "GET":
+		if !exists {
+			writeRecordNotFound(w, r, zone, name, rtype)
+			return
+		}
+		writeJSON(w, http.StatusOK, existing)
+	case "POST", "PUT":
+		if r.Method == "POST" && exists {
+			writeError(w, r, http.StatusConflict, "Conflict", fmt.Sprintf("Record set %v %v already exists in zone %v", name, rtype, zone))
+			return
+		}
+		if r.Method == "PUT" && !exists {
+			writeRecordNotFound(w, r, zone, name, rtype)
+			return
+		}
+
+		var rs akamai.RecordSetCreateRequest
+		if !readJSON(w, r, &rs) {
+			return
+		}
+		if rs.Name != name || !strings.EqualFold(rs.Type, rtype) {
+			writeError(w, r, http.StatusBadRequest, "Bad Request", "name and type must match the URL")
+			return
+		}
+		if len(rs.Rdata) == 0 || rs.GetTTL() <= 0 {
+			writeError(w, r, http.StatusBadRequest, "Bad Request", "rdata and ttl are required")
+			return
+		}
+
+		created := newRecordSet(&rs)
+		z.records[key] = created
+		z.touch(s.modifiedBy())
+
+		status := http.StatusOK
+		if r.Method == "POST" {
+			status = http.StatusCreated
+			w.Header().Set("Location", r.URL.Path)
+		}
+		writeJSON(w, status, created)
+	case "DELETE":
+		if !exists {
+			writeRecordNotFound(w, r, zone, name, rtype)
+			return
+		}
+		delete(z.records, key)
+		z.touch(s.modifiedBy())
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) recordTypesForName(w http.ResponseWriter, r *http.Request, zone, name string) {
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	z, ok := s.zone(w, r, zone)
+	if !ok {
+		return
+	}
+
+	types := []string{}
+	for _, rs := range sortedRecords(z.records) {
+		if strings.EqualFold(rs.GetName(), name) {
+			types = append(types, rs.GetType())
+		}
+	}
+	if len(types) == 0 {
+		writeError(w, r, http.StatusNotFound, "Not Found", fmt.Sprintf("Name %v does not exist in zone %v", name, zone))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]string{"types": types})
+}
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	var contracts []*string
+	for _, id := range s.contractIDs() {
+		contracts = append(contracts, akamai.String(id))
+	}
+
+	ids := make([]int, 0, len(s.groups))
+	for id := range s.groups {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	groups := make([]*akamai.Group, len(ids))
+	for i, id := range ids {
+		groups[i] = &akamai.Group{
+			GroupID:     akamai.Int(id),
+			GroupName:   akamai.String(s.groups[id]),
+			ContractIDs: contracts,
+			Permissions: []*string{akamai.String("READ"), akamai.String("WRITE"), akamai.String("ADD")},
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"groups": groups})
+}
+
+func (s *Server) listContracts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	var contracts []*akamai.Contract
+	for _, id := range s.contractIDs() {
+		contracts = append(contracts, &akamai.Contract{
+			ContractID:       akamai.String(id),
+			ContractName:     akamai.String("akamaitest"),
+			ContractTypeName: akamai.String("Direct Customer"),
+			Features:         []*string{akamai.String("FASTDNS")},
+			Permissions:      []*string{akamai.String("READ"), akamai.String("WRITE"), akamai.String("ADD")},
+			ZoneCount:        s.contractZoneCount(id),
+			MaximumZones:     s.contractMaximumZones(id),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"contracts": contracts})
+}
+
+func (s *Server) getAuthorities(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	ids := splitList(r.URL.Query().Get("contractIds"))
+	if len(ids) == 0 {
+		writeError(w, r, http.StatusBadRequest, "Bad Request", "contractIds is required")
+		return
+	}
+
+	var contracts []*akamai.ContractAuthorities
+	for _, id := range ids {
+		if !contains(s.contractIDs(), id) {
+			writeError(w, r, http.StatusForbidden, "Forbidden", fmt.Sprintf("Contract %v is not accessible", id))
+			return
+		}
+		contracts = append(contracts, &akamai.ContractAuthorities{
+			ContractID:  akamai.String(id),
+			Authorities: []*string{akamai.String("a1-1.akam.net."), akamai.String("a2-2.akam.net.")},
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"contracts": contracts})
+}
+
+func (s *Server) getRecordTypes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	if _, ok := s.zone(w, r, r.URL.Query().Get("zone")); !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"types": []string{"A", "AAAA", "AFSDB", "AKAMAICDN", "AKAMAITLC", "CAA", "CNAME", "HINFO", "HTTPS", "LOC", "MX", "NAPTR", "NS", "PTR", "RP", "SOA", "SPF", "SRV", "SSHFP", "SVCB", "TLSA", "TXT"},
+	})
+}
+
+// contractIDs returns the contracts the fake account holds: TestContractID and those
+// of the stored zones.
+func (s *Server) contractIDs() []string {
+	ids := []string{TestContractID}
+	for _, z := range s.zones {
+		if id := z.zone.GetContractID(); id != "" && !contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (s *Server) createChangeList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	name := r.URL.Query().Get("zone")
+	z, ok := s.zone(w, r, name)
+	if !ok {
+		return
+	}
+	if _, exists := s.changeLists[name]; exists && r.URL.Query().Get("overwrite") != "true" {
+		writeError(w, r, http.StatusConflict, "Conflict", fmt.Sprintf("A change list already exists for zone %v", name))
+		return
+	}
+
+	records := map[string]*akamai.RecordSet{}
+	for k, rs := range z.records {
+		records[k] = rs
+	}
+
+	cl := &changeListState{
+		changeList: akamai.ChangeList{
+			ChangeTag:        uuid.New().String(),
+			LastModifiedDate: time.Now().UTC().Format(time.RFC3339),
+			Zone:             name,
+			ZoneVersionId:    z.zone.GetVersionID(),
+		},
+		records: records,
+	}
+	s.changeLists[name] = cl
+
+	writeJSON(w, http.StatusCreated, &cl.changeList)
+}
+
+func (s *Server) getChangeList(w http.ResponseWriter, r *http.Request, name string) {
+	cl, ok := s.changeList(w, r, name)
+	if !ok {
+		return
+	}
+
+	c := cl.changeList
+	c.Stale = s.isStale(cl)
+	writeJSON(w, http.StatusOK, &c)
+}
+
+func (s *Server) deleteChangeList(w http.ResponseWriter, r *http.Request, name string) {
+	if _, ok := s.changeList(w, r, name); !ok {
+		return
+	}
+
+	delete(s.changeLists, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listChangeListRecordSets(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != "GET" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	cl, ok := s.changeList(w, r, name)
+	if !ok {
+		return
+	}
+
+	list, ok := listRecords(w, r, cl.records, s.ShowAllLimit)
+	if !ok {
+		return
+	}
+	list.Metadata.Zone = akamai.String(name)
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) submitChangeList(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != "POST" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	cl, ok := s.changeList(w, r, name)
+	if !ok {
+		return
+	}
+	if s.isStale(cl) {
+		writeError(w, r, http.StatusConflict, "Conflict", fmt.Sprintf("The change list for zone %v is stale", name))
+		return
+	}
+	if r.URL.Query().Get("validateOnly") == "true" {
+		writeJSON(w, http.StatusOK, validateRecords(cl.records))
+		return
+	}
+
+	z := s.zones[name]
+	z.records = cl.records
+	z.touch(s.modifiedBy())
+	delete(s.changeLists, name)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateRecords checks record sets the way the validation of change lists does: a
+// CNAME record can't share its name with records of other types, and TTLs under a
+// minute are warned about.
+func validateRecords(records map[string]*akamai.RecordSet) map[string][]map[string]string {
+	out := map[string][]map[string]string{"errors": {}, "warnings": {}}
+	types := map[string]int{}
+	for _, rs := range records {
+		types[strings.ToLower(rs.GetName())]++
+	}
+	for _, rs := range sortedRecords(records) {
+		issue := func(message string) map[string]string {
+			return map[string]string{"name": rs.GetName(), "type": rs.GetType(), "message": message}
+		}
+		if rs.GetType() == akamai.RRTypeCname && types[strings.ToLower(rs.GetName())] > 1 {
+			out["errors"] = append(out["errors"], issue("A CNAME record can't coexist with other records"))
+		}
+		if rs.GetTTL() < 60 {
+			out["warnings"] = append(out["warnings"], issue(fmt.Sprintf("TTL %d is under 60 seconds", rs.GetTTL())))
+		}
+	}
+	return out
+}
+
+func (s *Server) isStale(cl *changeListState) bool {
+	z, ok := s.zones[cl.changeList.Zone]
+	return !ok || z.zone.GetVersionID() != cl.changeList.ZoneVersionId
+}
+
+func (s *Server) zone(w http.ResponseWriter, r *http.Request, name string) (*zoneState, bool) {
+	z, ok := s.zones[name]
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "Not Found", fmt.Sprintf("Zone %v does not exist", name))
+	}
+	return z, ok
+}
+
+func (s *Server) changeList(w http.ResponseWriter, r *http.Request, name string) (*changeListState, bool) {
+	cl, ok := s.changeLists[name]
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "Not Found", fmt.Sprintf("No change list exists for zone %v", name))
+	}
+	return cl, ok
+}
+
+// listRecords filters and paginates record sets the way both record set listing
+// endpoints do.
+func listRecords(w http.ResponseWriter, r *http.Request, records map[string]*akamai.RecordSet, showAllLimit int) (*akamai.ChangeListRecords, bool) {
+	q := r.URL.Query()
+	types := splitList(strings.ToUpper(q.Get("types")))
+	search := strings.ToLower(q.Get("search"))
+
+	var matched []*akamai.RecordSet
+	for _, rs := range sortedRecords(records) {
+		if len(types) > 0 && !contains(types, rs.GetType()) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(rs.GetName()), search) {
+			continue
+		}
+		matched = append(matched, rs)
+	}
+
+	page, pageSize, ok := pagination(w, r)
+	if !ok {
+		return nil, false
+	}
+	total := len(matched)
+	if q.Get("showAll") != "true" {
+		start, end := pageBounds(len(matched), page, pageSize)
+		matched = matched[start:end]
+	} else if showAllLimit > 0 && len(matched) > showAllLimit {
+		matched = matched[:showAllLimit]
+	}
+
+	var typeList []*string
+	for _, t := range types {
+		typeList = append(typeList, akamai.String(t))
+	}
+
+	return &akamai.ChangeListRecords{
+		Metadata: &akamai.ChangeListMetadata{
+			Types:         typeList,
+			Page:          akamai.Int(page),
+			PageSize:      akamai.Int(pageSize),
+			TotalElements: akamai.Int(total),
+		},
+		Recordsets: matched,
+	}, true
+}
+
+func sortedRecords(records map[string]*akamai.RecordSet) []*akamai.RecordSet {
+	list := make([]*akamai.RecordSet, 0, len(records))
+	for _, rs := range records {
+		list = append(list, rs)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].GetName() != list[j].GetName() {
+			return list[i].GetName() < list[j].GetName()
+		}
+		return list[i].GetType() < list[j].GetType()
+	})
+	return list
+}
+
+func newRecordSet(rs *akamai.RecordSetCreateRequest) *akamai.RecordSet {
+	r := akamai.NewRecordSetFromRequest(rs)
+	r.Type = akamai.String(strings.ToUpper(rs.Type))
+	return r
+}
+
+func recordKey(name, rtype string) string {
+	return strings.ToLower(name) + "/" + strings.ToUpper(rtype)
+}
+
+// pagination reads the page and pageSize query parameters. Pages start at 1.
+func pagination(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	page, pageSize := 1, defaultPageSize
+	for _, p := range []struct {
+		name string
+		v    *int
+	}{{"page", &page}, {"pageSize", &pageSize}} {
+		s := r.URL.Query().Get(p.name)
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			writeError(w, r, http.StatusBadRequest, "Bad Request", fmt.Sprintf("%v must be a positive integer", p.name))
+			return 0, 0, false
+		}
+		*p.v = n
+	}
+	return page, pageSize, true
+}
+
+// pageTooLarge reports whether a request lists more than max items at once.
+func pageTooLarge(r *http.Request, max int) bool {
+	q := r.URL.Query()
+	if q.Get("showAll") == "true" {
+		return true
+	}
+	n, err := strconv.Atoi(q.Get("pageSize"))
+	return err == nil && n > max
+}
+
+// pageBounds returns the slice bounds of a page of a list of total items.
+func pageBounds(total, page, pageSize int) (int, int) {
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return akamai.String(s)
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, r, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Request body is not valid JSON: %v", err))
+		return false
+	}
+	return true
+}
+
+// contentTypeWriter replaces the application/json Content-Type of responses.
+type contentTypeWriter struct {
+	http.ResponseWriter
+	contentType string
+}
+
+func (w *contentTypeWriter) WriteHeader(status int) {
+	if w.Header().Get("Content-Type") == "application/json" {
+		w.Header().Set("Content-Type", w.contentType)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, title, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":     "https://problems.luna.akamaiapis.net/config-dns/" + strings.ToLower(strings.Replace(title, " ", "-", -1)),
+		"title":    title,
+		"detail":   detail,
+		"instance": r.URL.Path,
+		"status":   status,
+	})
+}
+
+func writeRecordNotFound(w http.ResponseWriter, r *http.Request, zone, name, rtype string) {
+	writeError(w, r, http.StatusNotFound, "Not Found", fmt.Sprintf("Record set %v %v does not exist in zone %v", name, rtype, zone))
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, http.StatusMethodNotAllowed, "Method Not Allowed", fmt.Sprintf("%v is not supported", r.Method))
+}
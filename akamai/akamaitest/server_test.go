@@ -0,0 +1,73 @@
+package akamaitest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+func TestServerVerifySignatures(t *testing.T) {
+	client, srv := NewServer(t)
+	srv.VerifySignatures = true
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+
+	if _, _, err := client.FastDNSv2.GetZone(context.Background(), "example.com"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	client.Credentials = credentials.NewStaticCredentials("wrong-secret", TestClientToken, TestAccessToken, srv.Listener.Addr().String())
+	_, resp, err := client.FastDNSv2.GetZone(context.Background(), "example.com")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestServerProblemJSON(t *testing.T) {
+	client, _ := NewServer(t)
+
+	_, resp, err := client.FastDNSv2.GetZone(context.Background(), "missing.com")
+	if assert.Error(t, err) {
+		var aerr *akamai.AkamaiError
+		if assert.True(t, errors.As(err, &aerr)) {
+			assert.Equal(t, http.StatusNotFound, aerr.Status)
+			assert.Equal(t, "Not Found", aerr.Title)
+			assert.Equal(t, "/config-dns/v2/zones/missing.com", aerr.Instance)
+		}
+	}
+	assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+}
+
+func TestServerListZonesPagination(t *testing.T) {
+	client, srv := NewServer(t)
+	for _, z := range []string{"a.com", "b.com", "c.com"} {
+		srv.AddZone(&akamai.ZoneCreateRequest{Zone: z, Type: "PRIMARY"})
+	}
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "d.com", Type: "SECONDARY", Masters: []string{"192.0.2.1"}})
+
+	zones, _, err := client.FastDNSv2.ListZones(context.Background(), &akamai.ZoneListOptions{Page: akamai.Int(2), PageSize: akamai.Int(3)})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 4, zones.GetMetadata().GetTotalElements())
+	if assert.Len(t, zones.Zones, 1) {
+		assert.Equal(t, "d.com", zones.Zones[0].GetZone())
+	}
+
+	zones, _, err = client.FastDNSv2.ListZones(context.Background(), &akamai.ZoneListOptions{Search: "b."})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, zones.Zones, 1)
+
+	_, resp, err := client.FastDNSv2.ListZones(context.Background(), &akamai.ZoneListOptions{Page: akamai.Int(-1)})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	assert.Len(t, srv.RecordSets("a.com"), 2)
+	assert.Empty(t, srv.RecordSets("d.com"))
+	assert.Nil(t, srv.Zone("e.com"))
+}
@@ -0,0 +1,106 @@
+package akamai
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// roundTripperFunc answers requests without going through the network, so allocation
+// counts only cover the client.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func newAllocsTestClient(t *testing.T, status int, body string) *Client {
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Body != nil {
+			ioutil.ReadAll(r.Body)
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+
+	creds := credentials.NewStaticCredentials(akamaiTestClientSecret, akamaiTestClientToken, akamaiTestAccessToken, "akaa-allocs.luna.akamaiapis.net")
+	client, err := NewClient(&http.Client{Transport: transport}, creds)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	client.BaseURL, _ = url.Parse("https://akaa-allocs.luna.akamaiapis.net/")
+
+	return client
+}
+
+// TestAllocsBudget fails when a change makes the request path allocate noticeably more
+// than it does today. Lower the budgets when allocations are reduced.
+func TestAllocsBudget(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are not meaningful with the race detector")
+	}
+	ctx := context.Background()
+	// The TTL is allocated once, so that the budget only counts those of the client.
+	ttl := Int(300)
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		budget float64
+		call   func(c *Client) error
+	}{
+		{
+			name:   "ListZones",
+			status: http.StatusOK,
+			body:   `{"metadata": {"page": 1, "pageSize": 25, "totalElements": 1}, "zones": [{"zone": "example.com", "type": "PRIMARY", "contractId": "1-ABCDE"}]}`,
+			budget: 80,
+			call: func(c *Client) error {
+				_, _, err := c.FastDNSv2.ListZones(ctx, nil)
+				return err
+			},
+		},
+		{
+			name:   "CreateRecordSet",
+			status: http.StatusCreated,
+			body:   `{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["192.0.2.1"]}`,
+			budget: 88,
+			call: func(c *Client) error {
+				_, _, err := c.FastDNSv2.CreateRecordSet(ctx, &RecordSetCreateRequest{
+					Zone:  "example.com",
+					Name:  "www.example.com",
+					Type:  RRTypeA,
+					TTL:   ttl,
+					Rdata: []string{"192.0.2.1"},
+				})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newAllocsTestClient(t, tt.status, tt.body)
+
+			allocs := testing.AllocsPerRun(100, func() {
+				if err := tt.call(client); err != nil {
+					t.Fatalf("expect nil, got %v", err)
+				}
+			})
+
+			t.Logf("%v allocations per call", allocs)
+			if allocs > tt.budget {
+				t.Errorf("%v allocates %v times per call, over its budget of %v", tt.name, allocs, tt.budget)
+			}
+		})
+	}
+}
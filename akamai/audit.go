@@ -0,0 +1,96 @@
+package akamai
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Default headers the audit actor and reason of a context are sent in. See
+// Client.AuditActorHeader and Client.AuditReasonHeader.
+const (
+	DefaultAuditActorHeader  = "X-Audit-Actor"
+	DefaultAuditReasonHeader = "X-Audit-Reason"
+)
+
+type auditKey int
+
+const (
+	auditActorKey auditKey = iota
+	auditReasonKey
+)
+
+// WithAuditActor returns a copy of ctx carrying the human or system on whose behalf
+// the requests made with it are made. The client sends it in its AuditActorHeader and
+// uses it in the comments of the changes made without one. See AuditComment.
+func WithAuditActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, auditActorKey, actor)
+}
+
+// WithAuditReason returns a copy of ctx carrying why the requests made with it are
+// made, which the client uses as WithAuditActor does the actor.
+func WithAuditReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, auditReasonKey, reason)
+}
+
+// AuditActor returns the actor set on ctx with WithAuditActor, if any.
+func AuditActor(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	actor, _ := ctx.Value(auditActorKey).(string)
+	return actor
+}
+
+// AuditReason returns the reason set on ctx with WithAuditReason, if any.
+func AuditReason(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	reason, _ := ctx.Value(auditReasonKey).(string)
+	return reason
+}
+
+// AuditComment returns the comment the client gives to the changes made with ctx
+// without one, such as zones created without a comment: "actor: reason", or either
+// of them alone. It is empty if ctx has neither.
+func AuditComment(ctx context.Context) string {
+	actor, reason := AuditActor(ctx), AuditReason(ctx)
+	switch {
+	case actor != "" && reason != "":
+		return actor + ": " + reason
+	case actor != "":
+		return actor
+	default:
+		return reason
+	}
+}
+
+// auditComment returns comment, or the audit comment of ctx if it is empty.
+func auditComment(ctx context.Context, comment string) string {
+	if comment != "" {
+		return comment
+	}
+	return AuditComment(ctx)
+}
+
+// setAuditHeaders sets the audit headers of req from ctx.
+func (c *Client) setAuditHeaders(ctx context.Context, req *http.Request) {
+	if actor := AuditActor(ctx); actor != "" && c.AuditActorHeader != "" {
+		req.Header.Set(c.AuditActorHeader, headerValue(actor))
+	}
+	if reason := AuditReason(ctx); reason != "" && c.AuditReasonHeader != "" {
+		req.Header.Set(c.AuditReasonHeader, headerValue(reason))
+	}
+}
+
+// headerValue replaces the control characters of s, which are not allowed in header
+// values, with spaces.
+func headerValue(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,65 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditAnnotations(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var headers []http.Header
+	var comments []string
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		headers = append(headers, r.Header)
+
+		var zr ZoneCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&zr); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		comments = append(comments, zr.Comment)
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(&Zone{Zone: String(zr.Zone)})
+	})
+
+	ctx := WithAuditReason(WithAuditActor(context.Background(), "alice@example.com"), "ticket OPS-42\r\nX-Injected: 1")
+	assert.Equal(t, "alice@example.com: ticket OPS-42\r\nX-Injected: 1", AuditComment(ctx))
+
+	zone := &ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"}
+	if _, _, err := client.FastDNSv2.CreateZone(ctx, "1-ABCDE", zone); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "", zone.Comment, "the request must not be modified")
+
+	zone.Comment = "created by hand"
+	if _, _, err := client.FastDNSv2.CreateZone(ctx, "1-ABCDE", zone); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	client.AuditReasonHeader = ""
+	if _, _, err := client.FastDNSv2.CreateZone(WithAuditActor(context.Background(), "sync-bot"), "1-ABCDE", &ZoneCreateRequest{Zone: "example.net", Type: "PRIMARY"}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if _, _, err := client.FastDNSv2.CreateZone(context.Background(), "1-ABCDE", &ZoneCreateRequest{Zone: "example.org", Type: "PRIMARY"}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Equal(t, []string{"alice@example.com: ticket OPS-42\r\nX-Injected: 1", "created by hand", "sync-bot", ""}, comments)
+	if assert.Len(t, headers, 4) {
+		assert.Equal(t, "alice@example.com", headers[0].Get(DefaultAuditActorHeader))
+		assert.Equal(t, "ticket OPS-42  X-Injected: 1", headers[0].Get(DefaultAuditReasonHeader))
+		assert.Empty(t, headers[0].Get("X-Injected"))
+		assert.Equal(t, "alice@example.com", headers[1].Get(DefaultAuditActorHeader))
+		assert.Equal(t, "sync-bot", headers[2].Get(DefaultAuditActorHeader))
+		assert.Empty(t, headers[2].Get(DefaultAuditReasonHeader))
+		assert.Empty(t, headers[3].Get(DefaultAuditActorHeader))
+	}
+}
@@ -0,0 +1,464 @@
+// Package axfr imports zones into Fast DNS from the DNS servers they are migrated from,
+// such as self-hosted BIND, with a zone transfer (AXFR). ImportFromAXFR transfers a zone
+// and converts its records into the record sets of the FastDNS API, and MigrateZone
+// brings the record sets of a zone to those of the transfer with SyncRecordSets.
+//
+// The package has its own minimal DNS client, which only makes zone transfers over
+// TCP, optionally signed with TSIG.
+package axfr
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+// typeNames are the names of the record types the transfers are converted from, by
+// type.
+var typeNames = map[uint16]string{
+	typeA:     akamai.RRTypeA,
+	typeNS:    akamai.RRTypeNs,
+	typeCNAME: akamai.RRTypeCname,
+	typePTR:   akamai.RRTypePtr,
+	typeHINFO: akamai.RRTypeHinfo,
+	typeMX:    akamai.RRTypeMx,
+	typeTXT:   akamai.RRTypeTxt,
+	typeRP:    akamai.RRTypeRp,
+	typeAFSDB: akamai.RRTypeAfsdb,
+	typeAAAA:  akamai.RRTypeAaaa,
+	typeSRV:   akamai.RRTypeSrv,
+	typeNAPTR: akamai.RRTypeNaptr,
+	typeSSHFP: akamai.RRTypeSshfp,
+	typeTLSA:  akamai.RRTypeTlsa,
+	typeSPF:   akamai.RRTypeSpf,
+	typeCAA:   akamai.RRTypeCaa,
+}
+
+// managedTypes are the types of the records Akamai manages itself, which are not
+// imported: the SOA, and the DNSSEC records of signed zones, which Akamai signs anew.
+var managedTypes = map[uint16]bool{
+	typeSOA:        true,
+	typeRRSIG:      true,
+	typeNSEC:       true,
+	typeDNSKEY:     true,
+	typeNSEC3:      true,
+	typeNSEC3PARAM: true,
+	typeCDS:        true,
+	typeCDNSKEY:    true,
+	typeZONEMD:     true,
+}
+
+// Options specifies the optional parameters to Import.
+type Options struct {
+	// KeepApexNS imports the NS record set of the zone apex. It is left out by
+	// default, as it names the servers the zone is migrated from, and Akamai sets the
+	// apex NS of its zones to its own name servers.
+	KeepApexNS bool
+
+	// Timeout bounds the transfer, on top of the deadline of its context. Defaults to
+	// a minute.
+	Timeout time.Duration
+}
+
+// defaultTimeout is the default Timeout of Options.
+const defaultTimeout = time.Minute
+
+// TransferError is the error of a zone transfer the server refused or failed.
+type TransferError struct {
+	Server string
+	Zone   string
+	Rcode  int
+}
+
+func (e *TransferError) Error() string {
+	return fmt.Sprintf("zone transfer of %v from %v failed: %v", e.Zone, e.Server, rcodeName(e.Rcode))
+}
+
+// UnsupportedTypeError is the error of the transfers holding records of a type the
+// FastDNS API has no record sets of, or this package can't convert.
+type UnsupportedTypeError struct {
+	Name string
+	Type string
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("record %v has unsupported type %v", e.Name, e.Type)
+}
+
+// ImportFromAXFR transfers a zone from server, a host with an optional port that
+// defaults to 53, and returns its record sets, as Import does with the default Options.
+func ImportFromAXFR(ctx context.Context, server, zone string, tsig *akamai.TSIGKey) ([]*akamai.RecordSetCreateRequest, error) {
+	return Import(ctx, server, zone, tsig, nil)
+}
+
+// Import transfers a zone from server, a host with an optional port that defaults to
+// 53, and converts its records into record sets, sorted by name and type. The transfer
+// is signed with tsig, if not nil, and its responses must then be signed with it too.
+// opt may be nil.
+//
+// The records of the same name and type make up a record set, whose TTL is the lowest
+// of theirs. The character-strings of a TXT record are joined, and encoded with
+// akamai.QuoteTXT, which splits the values longer than 255 bytes. The SOA, DNSSEC and,
+// unless opt.KeepApexNS is set, apex NS records are left out, as Akamai manages them.
+// A record of a type the FastDNS API has no record sets of fails the import with an
+// *UnsupportedTypeError, rather than being lost in the migration.
+func Import(ctx context.Context, server, zone string, tsig *akamai.TSIGKey, opt *Options) ([]*akamai.RecordSetCreateRequest, error) {
+	if opt == nil {
+		opt = &Options{}
+	}
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+
+	var key *tsigKey
+	if tsig != nil {
+		var err error
+		if key, err = newTSIGKey(tsig); err != nil {
+			return nil, err
+		}
+	}
+
+	timeout := opt.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rrs, err := transfer(ctx, server, zone, key)
+	if err != nil {
+		return nil, err
+	}
+	return recordSets(zone, rrs, opt)
+}
+
+// MigrateOptions specifies the optional parameters to MigrateZone.
+type MigrateOptions struct {
+	Options
+
+	// Sync is passed on to SyncRecordSets. Set its Prune to delete the record sets of
+	// the zone that the transfer doesn't hold.
+	Sync *akamai.SyncOptions
+}
+
+// MigrateZone transfers a zone from server with Import, and brings the record sets of
+// the zone of the same name in Fast DNS to those of the transfer with SyncRecordSets,
+// through api, such as a client's FastDNSv2. The zone must exist, as a PRIMARY zone. It
+// returns the plan of the sync, whose changes hold their individual errors. opt may be
+// nil.
+func MigrateZone(ctx context.Context, api akamai.FastDNSv2API, server, zone string, tsig *akamai.TSIGKey, opt *MigrateOptions) (*akamai.SyncPlan, error) {
+	if opt == nil {
+		opt = &MigrateOptions{}
+	}
+	desired, err := Import(ctx, server, zone, tsig, &opt.Options)
+	if err != nil {
+		return nil, err
+	}
+	return api.SyncRecordSets(ctx, zone, desired, opt.Sync)
+}
+
+// transfer makes the zone transfer, and returns its records, without the SOA that ends
+// it.
+func transfer(ctx context.Context, server, zone string, key *tsigKey) ([]*rr, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+
+	var idb [2]byte
+	if _, err := rand.Read(idb[:]); err != nil {
+		return nil, err
+	}
+	id := binary.BigEndian.Uint16(idb[:])
+	query, err := newQuery(id, zone)
+	if err != nil {
+		return nil, err
+	}
+	var v *verifier
+	if key != nil {
+		var mac []byte
+		query, mac = key.sign(query, time.Now(), nil, nil, false)
+		v = &verifier{key: key, now: time.Now, prior: mac}
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// The connection is closed when ctx is done, to interrupt the reads.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	if _, err := conn.Write(append([]byte{byte(len(query) >> 8), byte(len(query))}, query...)); err != nil {
+		return nil, ctxErr(ctx, err)
+	}
+
+	var rrs []*rr
+	soas := 0
+	for soas < 2 {
+		var l [2]byte
+		if _, err := io.ReadFull(conn, l[:]); err != nil {
+			return nil, ctxErr(ctx, err)
+		}
+		b := make([]byte, binary.BigEndian.Uint16(l[:]))
+		if _, err := io.ReadFull(conn, b); err != nil {
+			return nil, ctxErr(ctx, err)
+		}
+
+		m, err := parseMessage(b)
+		if err != nil {
+			return nil, err
+		}
+		if m.id != id || !m.response() {
+			return nil, errors.New("unexpected message in the zone transfer")
+		}
+		if m.rcode() != 0 {
+			return nil, &TransferError{Server: server, Zone: zone, Rcode: m.rcode()}
+		}
+		if v != nil {
+			if err := v.verify(m); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, r := range m.answers {
+			if len(rrs) == 0 && soas == 0 && r.rtype != typeSOA {
+				return nil, errors.New("zone transfer doesn't start with the SOA record")
+			}
+			if r.rtype == typeSOA {
+				if soas++; soas == 2 {
+					break
+				}
+			}
+			rrs = append(rrs, r)
+		}
+	}
+	if v != nil {
+		if err := v.done(); err != nil {
+			return nil, err
+		}
+	}
+	return rrs, nil
+}
+
+// ctxErr returns the error of ctx if it is done, as that of the connection closed
+// because of it, and err otherwise.
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// recordSets groups the records of a transfer into record sets.
+func recordSets(zone string, rrs []*rr, opt *Options) ([]*akamai.RecordSetCreateRequest, error) {
+	var sets []*akamai.RecordSetCreateRequest
+	byKey := map[string]*akamai.RecordSetCreateRequest{}
+	seen := map[string]bool{}
+	for _, r := range rrs {
+		name := strings.ToLower(r.name)
+		if r.class != classIN || managedTypes[r.rtype] || !inZone(name, zone) {
+			continue
+		}
+		if r.rtype == typeNS && name == zone && !opt.KeepApexNS {
+			continue
+		}
+
+		rtype, ok := typeNames[r.rtype]
+		if !ok {
+			return nil, &UnsupportedTypeError{Name: r.name, Type: typeName(r.rtype)}
+		}
+		rdata, err := formatRdata(r)
+		if err != nil {
+			return nil, fmt.Errorf("record %v %v: %v", r.name, rtype, err)
+		}
+
+		key := name + " " + rtype
+		rs, ok := byKey[key]
+		if !ok {
+			rs = &akamai.RecordSetCreateRequest{Zone: zone, Name: name, Type: rtype, TTL: akamai.Int(int(r.ttl))}
+			byKey[key] = rs
+			sets = append(sets, rs)
+		}
+		if int(r.ttl) < rs.GetTTL() {
+			rs.TTL = akamai.Int(int(r.ttl))
+		}
+		if !seen[key+" "+rdata] {
+			seen[key+" "+rdata] = true
+			rs.Rdata = append(rs.Rdata, rdata)
+		}
+	}
+
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].Name != sets[j].Name {
+			return sets[i].Name < sets[j].Name
+		}
+		return sets[i].Type < sets[j].Type
+	})
+	return sets, nil
+}
+
+// inZone reports whether name is the zone or a name below it.
+func inZone(name, zone string) bool {
+	return name == zone || strings.HasSuffix(name, "."+zone)
+}
+
+// typeName returns the name of a record type, or its number as in RFC 3597.
+func typeName(rtype uint16) string {
+	if name, ok := typeNames[rtype]; ok {
+		return name
+	}
+	switch rtype {
+	case typeDS:
+		return "DS"
+	case typeSOA:
+		return akamai.RRTypeSoa
+	}
+	return "TYPE" + strconv.Itoa(int(rtype))
+}
+
+// rdataReader reads the fields of the rdata of a record.
+type rdataReader struct {
+	r   *rr
+	off int
+	err error
+}
+
+func (d *rdataReader) end() int {
+	return d.r.rdata + d.r.rdlen
+}
+
+func (d *rdataReader) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *rdataReader) uint8() uint8 {
+	if d.err != nil || d.off+1 > d.end() {
+		d.fail(errTruncated)
+		return 0
+	}
+	v := d.r.msg[d.off]
+	d.off++
+	return v
+}
+
+func (d *rdataReader) uint16() uint16 {
+	if d.err != nil || d.off+2 > d.end() {
+		d.fail(errTruncated)
+		return 0
+	}
+	v := binary.BigEndian.Uint16(d.r.msg[d.off:])
+	d.off += 2
+	return v
+}
+
+// name reads a domain name, and returns it fully qualified, with its trailing dot.
+func (d *rdataReader) name() string {
+	if d.err != nil {
+		return ""
+	}
+	name, next, err := readName(d.r.msg, d.off)
+	if err == nil && next > d.end() {
+		err = errTruncated
+	}
+	if err != nil {
+		d.fail(err)
+		return ""
+	}
+	d.off = next
+	return name + "."
+}
+
+// string reads a character-string.
+func (d *rdataReader) string() string {
+	n := int(d.uint8())
+	if d.err != nil || d.off+n > d.end() {
+		d.fail(errTruncated)
+		return ""
+	}
+	s := string(d.r.msg[d.off : d.off+n])
+	d.off += n
+	return s
+}
+
+// rest reads the rest of the rdata.
+func (d *rdataReader) rest() []byte {
+	if d.err != nil {
+		return nil
+	}
+	b := d.r.msg[d.off:d.end()]
+	d.off = d.end()
+	return b
+}
+
+// formatRdata returns the rdata of a record in the presentation format of the FastDNS
+// API.
+func formatRdata(r *rr) (string, error) {
+	d := &rdataReader{r: r, off: r.rdata}
+	var s string
+	switch r.rtype {
+	case typeA, typeAAAA:
+		ip := net.IP(d.rest())
+		if (r.rtype == typeA && len(ip) != net.IPv4len) || (r.rtype == typeAAAA && len(ip) != net.IPv6len) {
+			return "", errors.New("invalid address length")
+		}
+		s = ip.String()
+	case typeNS, typeCNAME, typePTR:
+		s = d.name()
+	case typeMX, typeAFSDB:
+		s = fmt.Sprintf("%d %v", d.uint16(), d.name())
+	case typeRP:
+		s = d.name() + " " + d.name()
+	case typeSRV:
+		s = fmt.Sprintf("%d %d %d %v", d.uint16(), d.uint16(), d.uint16(), d.name())
+	case typeTXT, typeSPF:
+		var value strings.Builder
+		for d.err == nil && d.off < d.end() {
+			value.WriteString(d.string())
+		}
+		s = akamai.QuoteTXT(value.String())
+	case typeHINFO:
+		s = akamai.QuoteTXT(d.string()) + " " + akamai.QuoteTXT(d.string())
+	case typeCAA:
+		flags := d.uint8()
+		tag := d.string()
+		s = fmt.Sprintf("%d %v %v", flags, tag, akamai.QuoteTXT(string(d.rest())))
+	case typeNAPTR:
+		order, pref := d.uint16(), d.uint16()
+		flags, services, regexp := d.string(), d.string(), d.string()
+		s = fmt.Sprintf("%d %d %v %v %v %v", order, pref, akamai.QuoteTXT(flags), akamai.QuoteTXT(services), akamai.QuoteTXT(regexp), d.name())
+	case typeSSHFP:
+		s = fmt.Sprintf("%d %d %v", d.uint8(), d.uint8(), strings.ToUpper(hex.EncodeToString(d.rest())))
+	case typeTLSA:
+		s = fmt.Sprintf("%d %d %d %v", d.uint8(), d.uint8(), d.uint8(), strings.ToUpper(hex.EncodeToString(d.rest())))
+	default:
+		return "", &UnsupportedTypeError{Name: r.name, Type: typeName(r.rtype)}
+	}
+	if d.err != nil {
+		return "", d.err
+	}
+	if d.off != d.end() {
+		return "", errors.New("trailing bytes in rdata")
+	}
+	return s, nil
+}
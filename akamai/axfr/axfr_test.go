@@ -0,0 +1,339 @@
+package axfr
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+// testRR is a record served by a testServer, whose rdata is already encoded.
+type testRR struct {
+	name  string
+	rtype uint16
+	ttl   uint32
+	rdata []byte
+}
+
+// testServer is an in-process DNS server that answers zone transfers over TCP with
+// the given messages.
+type testServer struct {
+	messages [][]testRR
+
+	// key, if set, must sign the requests, and signs the responses. unsigned leaves
+	// the messages of the transfer between the first and the last unsigned.
+	key      *tsigKey
+	unsigned bool
+
+	// rcode fails the transfers with a response code, tamper alters the responses
+	// after they are signed, and hang never answers.
+	rcode  int
+	tamper bool
+	hang   bool
+}
+
+// start listens on a local port and serves the transfers, until the end of the test.
+// It returns the address of the server.
+func (s *testServer) start(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func (s *testServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	var l [2]byte
+	if _, err := io.ReadFull(conn, l[:]); err != nil {
+		return
+	}
+	query := make([]byte, binary.BigEndian.Uint16(l[:]))
+	if _, err := io.ReadFull(conn, query); err != nil {
+		return
+	}
+	if s.hang {
+		io.Copy(io.Discard, conn)
+		return
+	}
+	q, err := parseMessage(query)
+	if err != nil {
+		return
+	}
+	_, qend, _ := readName(query, headerLen)
+
+	rcode := s.rcode
+	var v *verifier
+	if s.key != nil {
+		v = &verifier{key: s.key, now: time.Now}
+		if v.verify(q) != nil {
+			rcode = 9
+		}
+	}
+
+	messages := s.messages
+	if rcode != 0 {
+		messages = [][]testRR{nil}
+	}
+	var prior, unsigned []byte
+	if v != nil {
+		prior = v.prior
+	}
+	for i, records := range messages {
+		var b builder
+		b.header(header{id: q.id, flags: 0x8400 | uint16(rcode), qdcount: 1, ancount: uint16(len(records))})
+		b.bytes(query[headerLen : qend+4])
+		for _, r := range records {
+			rdata := r.rdata
+			// The rdata of "@" is the name of the zone, compressed to the question.
+			if string(rdata) == "@" {
+				rdata = []byte{0xc0, headerLen}
+			}
+			b.rr(r.name, r.rtype, classIN, r.ttl, rdata)
+		}
+		msg := b.buf
+		last := i == len(messages)-1
+		if v != nil && rcode == 0 {
+			if i == 0 || last || !s.unsigned {
+				msg, prior = s.key.sign(msg, time.Now(), prior, unsigned, i > 0)
+				unsigned = nil
+			} else {
+				unsigned = append(unsigned, msg...)
+			}
+		}
+		if s.tamper && last {
+			// Flip the case of the first letter of the first record.
+			msg[qend+4+1] ^= 0x20
+		}
+		conn.Write(append([]byte{byte(len(msg) >> 8), byte(len(msg))}, msg...))
+	}
+}
+
+func rdataName(name string) []byte {
+	var b builder
+	b.name(name)
+	return b.buf
+}
+
+func rdataIP(ip string) []byte {
+	p := net.ParseIP(ip)
+	if p4 := p.To4(); p4 != nil {
+		return p4
+	}
+	return p
+}
+
+func rdataStrings(s ...string) []byte {
+	var b builder
+	for _, v := range s {
+		b.uint8(uint8(len(v)))
+		b.bytes([]byte(v))
+	}
+	return b.buf
+}
+
+func rdataPrefixed(prefix []byte, name string) []byte {
+	return append(append([]byte(nil), prefix...), rdataName(name)...)
+}
+
+func soa() testRR {
+	var b builder
+	b.name("ns1.example.net")
+	b.name("hostmaster.example.com")
+	for _, v := range []uint32{2024010101, 3600, 600, 604800, 300} {
+		b.uint32(v)
+	}
+	return testRR{"example.com", typeSOA, 3600, b.buf}
+}
+
+// testZone returns the messages of the transfer of a small zone.
+func testZone() [][]testRR {
+	long := strings.Repeat("k", 255) + strings.Repeat("m", 45)
+	return [][]testRR{
+		{
+			soa(),
+			{"example.com", typeNS, 86400, rdataName("ns1.example.net")},
+			{"example.com", typeNS, 86400, rdataName("ns2.example.net")},
+			{"example.com", typeMX, 3600, rdataPrefixed([]byte{0, 10}, "mail.example.com")},
+			{"WWW.example.com", typeA, 300, rdataIP("192.0.2.1")},
+			{"www.example.com", typeA, 60, rdataIP("192.0.2.2")},
+			{"www.example.com", typeA, 300, rdataIP("192.0.2.2")},
+			{"www.example.com", typeAAAA, 300, rdataIP("2001:db8::1")},
+		},
+		{
+			{"example.com", typeTXT, 3600, rdataStrings("v=spf1 ", "-all")},
+			{"dkim._domainkey.example.com", typeTXT, 3600, rdataStrings(long[:255], long[255:])},
+			{"_sip._tcp.example.com", typeSRV, 300, rdataPrefixed([]byte{0, 10, 0, 60, 0x13, 0xc4}, "sip.example.com")},
+			{"example.com", typeCAA, 3600, append([]byte{0, 5}, "issueletsencrypt.org"...)},
+			{"alias.example.com", typeCNAME, 300, []byte("@")},
+			{"example.com", typeRRSIG, 3600, []byte{1, 2, 3}},
+		},
+		{
+			{"sub.example.com", typeNS, 86400, rdataName("ns1.sub.example.com")},
+			{"ns1.sub.example.com", typeA, 86400, rdataIP("192.0.2.53")},
+			soa(),
+		},
+	}
+}
+
+func testKey(secret string) *akamai.TSIGKey {
+	return &akamai.TSIGKey{Name: akamai.String("transfer."), Algorithm: akamai.String("hmac-sha256"), Secret: akamai.String(secret)}
+}
+
+func summarize(sets []*akamai.RecordSetCreateRequest) []string {
+	var out []string
+	for _, rs := range sets {
+		out = append(out, rs.Name+" "+rs.Type+" "+strconv.Itoa(rs.GetTTL())+" "+strings.Join(rs.Rdata, " | "))
+	}
+	return out
+}
+
+func TestImportFromAXFR(t *testing.T) {
+	addr := (&testServer{messages: testZone()}).start(t)
+	ctx := context.Background()
+
+	sets, err := ImportFromAXFR(ctx, addr, "Example.com.", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{
+		`_sip._tcp.example.com SRV 300 10 60 5060 sip.example.com.`,
+		`alias.example.com CNAME 300 example.com.`,
+		`dkim._domainkey.example.com TXT 3600 "` + strings.Repeat("k", 255) + `" "` + strings.Repeat("m", 45) + `"`,
+		`example.com CAA 3600 0 issue "letsencrypt.org"`,
+		`example.com MX 3600 10 mail.example.com.`,
+		`example.com TXT 3600 "v=spf1 -all"`,
+		`ns1.sub.example.com A 86400 192.0.2.53`,
+		`sub.example.com NS 86400 ns1.sub.example.com.`,
+		`www.example.com A 60 192.0.2.1 | 192.0.2.2`,
+		`www.example.com AAAA 300 2001:db8::1`,
+	}, summarize(sets))
+	for _, rs := range sets {
+		assert.Equal(t, "example.com", rs.Zone)
+	}
+
+	sets, err = Import(ctx, addr, "example.com", nil, &Options{KeepApexNS: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, summarize(sets), "example.com NS 86400 ns1.example.net. | ns2.example.net.")
+}
+
+func TestImportFromAXFRTSIG(t *testing.T) {
+	const secret = "c2VjcmV0LXNlY3JldC1zZWNyZXQ="
+	key, err := newTSIGKey(testKey(secret))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	ctx := context.Background()
+
+	for _, unsigned := range []bool{false, true} {
+		addr := (&testServer{messages: testZone(), key: key, unsigned: unsigned}).start(t)
+		sets, err := ImportFromAXFR(ctx, addr, "example.com", testKey(secret))
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.Len(t, sets, 10)
+	}
+
+	// The server refuses the requests signed with another secret.
+	addr := (&testServer{messages: testZone(), key: key}).start(t)
+	_, err = ImportFromAXFR(ctx, addr, "example.com", testKey("b3RoZXI="))
+	var te *TransferError
+	if assert.True(t, errors.As(err, &te), "got %v", err) {
+		assert.Equal(t, 9, te.Rcode)
+		assert.Contains(t, te.Error(), "NOTAUTH")
+	}
+
+	// The responses must be signed, and not altered.
+	addr = (&testServer{messages: testZone()}).start(t)
+	_, err = ImportFromAXFR(ctx, addr, "example.com", testKey(secret))
+	assert.True(t, errors.Is(err, ErrBadSignature), "got %v", err)
+
+	addr = (&testServer{messages: testZone(), key: key, tamper: true}).start(t)
+	_, err = ImportFromAXFR(ctx, addr, "example.com", testKey(secret))
+	assert.True(t, errors.Is(err, ErrBadSignature), "got %v", err)
+
+	_, err = ImportFromAXFR(ctx, addr, "example.com", &akamai.TSIGKey{Name: akamai.String("transfer"), Algorithm: akamai.String("hmac-whirlpool"), Secret: akamai.String("c2VjcmV0")})
+	assert.Error(t, err)
+}
+
+func TestImportFromAXFRErrors(t *testing.T) {
+	ctx := context.Background()
+
+	addr := (&testServer{messages: testZone(), rcode: 5}).start(t)
+	_, err := ImportFromAXFR(ctx, addr, "example.com", nil)
+	var te *TransferError
+	if assert.True(t, errors.As(err, &te), "got %v", err) {
+		assert.Equal(t, 5, te.Rcode)
+		assert.Equal(t, "example.com", te.Zone)
+	}
+
+	addr = (&testServer{messages: [][]testRR{{soa(), {"example.com", 29, 300, []byte{0, 1, 2}}, soa()}}}).start(t)
+	_, err = ImportFromAXFR(ctx, addr, "example.com", nil)
+	var ut *UnsupportedTypeError
+	if assert.True(t, errors.As(err, &ut), "got %v", err) {
+		assert.Equal(t, "TYPE29", ut.Type)
+	}
+
+	addr = (&testServer{messages: [][]testRR{{soa(), {"www.example.com", typeA, 300, []byte{192, 0, 2}}, soa()}}}).start(t)
+	_, err = ImportFromAXFR(ctx, addr, "example.com", nil)
+	assert.Error(t, err)
+
+	addr = (&testServer{messages: testZone(), hang: true}).start(t)
+	start := time.Now()
+	_, err = Import(ctx, addr, "example.com", nil, &Options{Timeout: 50 * time.Millisecond})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < 5*time.Second)
+}
+
+func TestMigrateZone(t *testing.T) {
+	client, fake := akamaitest.NewServer(t)
+	fake.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	fake.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	fake.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "stale.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.99"}})
+	addr := (&testServer{messages: testZone()}).start(t)
+	ctx := context.Background()
+
+	plan, err := MigrateZone(ctx, client.FastDNSv2, addr, "example.com", nil, &MigrateOptions{Sync: &akamai.SyncOptions{Prune: true}})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 1, plan.Stats().Deletes)
+
+	// The zone now holds the record sets of the transfer, and the apex NS of Akamai.
+	sets, err := ImportFromAXFR(ctx, addr, "example.com", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	plan, err = client.FastDNSv2.PlanRecordSets(ctx, "example.com", sets, &akamai.SyncOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Empty(t, plan.Changes)
+	for _, rs := range fake.RecordSets("example.com") {
+		if rs.GetType() == "NS" && rs.GetName() == "example.com" {
+			assert.NotContains(t, rs.Rdata, akamai.String("ns1.example.net."))
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package axfr
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+// tsigFudge is the time, in seconds, the signatures of the requests are valid for on
+// either side of their time of signing.
+const tsigFudge = 300
+
+// maxUnsignedMessages is the number of consecutive messages of a transfer that may be
+// left unsigned, as RFC 8945 allows servers to sign only every hundredth.
+const maxUnsignedMessages = 99
+
+// tsigAlgorithms are the HMAC algorithms of TSIG, by the name of the algorithm.
+var tsigAlgorithms = map[string]func() hash.Hash{
+	"hmac-md5.sig-alg.reg.int": md5.New,
+	"hmac-sha1":                sha1.New,
+	"hmac-sha224":              sha256.New224,
+	"hmac-sha256":              sha256.New,
+	"hmac-sha384":              sha512.New384,
+	"hmac-sha512":              sha512.New,
+}
+
+// ErrBadSignature is matched by errors.Is for the transfers whose responses are not
+// signed with the TSIG key of the transfer.
+var ErrBadSignature = errors.New("TSIG signature of the response is invalid")
+
+// tsigErrors names the errors of TSIG.
+var tsigErrors = map[uint16]string{
+	16: "BADSIG",
+	17: "BADKEY",
+	18: "BADTIME",
+	22: "BADTRUNC",
+}
+
+// tsigKey is a TSIG key, with the name of its algorithm in canonical form.
+type tsigKey struct {
+	name      string
+	algorithm string
+	secret    []byte
+	hash      func() hash.Hash
+}
+
+// newTSIGKey returns the tsigKey of an Akamai TSIGKey, whose algorithm is named as in
+// the FastDNS API, such as "hmac-sha256", and whose secret is base64 encoded.
+func newTSIGKey(k *akamai.TSIGKey) (*tsigKey, error) {
+	algorithm := strings.ToLower(strings.TrimSuffix(k.GetAlgorithm(), "."))
+	if algorithm == "hmac-md5" {
+		algorithm = "hmac-md5.sig-alg.reg.int"
+	}
+	h, ok := tsigAlgorithms[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TSIG algorithm %q", k.GetAlgorithm())
+	}
+	secret, err := base64.StdEncoding.DecodeString(k.GetSecret())
+	if err != nil {
+		return nil, fmt.Errorf("invalid TSIG secret: %v", err)
+	}
+	name := strings.ToLower(strings.TrimSuffix(k.GetName(), "."))
+	if name == "" {
+		return nil, errors.New("TSIG key has no name")
+	}
+	return &tsigKey{name: name, algorithm: algorithm, secret: secret, hash: h}, nil
+}
+
+// tsig is the rdata of a TSIG record.
+type tsig struct {
+	algorithm  string
+	timeSigned uint64
+	fudge      uint16
+	mac        []byte
+	originalID uint16
+	err        uint16
+	other      []byte
+}
+
+// parseTSIG parses the rdata of the TSIG record r.
+func parseTSIG(r *rr) (*tsig, error) {
+	algorithm, off, err := readName(r.msg, r.rdata)
+	if err != nil {
+		return nil, err
+	}
+	end := r.rdata + r.rdlen
+	if off+10 > end {
+		return nil, errTruncated
+	}
+	t := &tsig{algorithm: strings.ToLower(algorithm)}
+	t.timeSigned = uint64(binary.BigEndian.Uint16(r.msg[off:]))<<32 | uint64(binary.BigEndian.Uint32(r.msg[off+2:]))
+	t.fudge = binary.BigEndian.Uint16(r.msg[off+6:])
+	macLen := int(binary.BigEndian.Uint16(r.msg[off+8:]))
+	off += 10
+	if off+macLen+6 > end {
+		return nil, errTruncated
+	}
+	t.mac = r.msg[off : off+macLen]
+	off += macLen
+	t.originalID = binary.BigEndian.Uint16(r.msg[off:])
+	t.err = binary.BigEndian.Uint16(r.msg[off+2:])
+	otherLen := int(binary.BigEndian.Uint16(r.msg[off+4:]))
+	off += 6
+	if off+otherLen > end {
+		return nil, errTruncated
+	}
+	t.other = r.msg[off : off+otherLen]
+	return t, nil
+}
+
+// variables returns the TSIG variables digested along with a message. timersOnly
+// restricts them to the time signed and fudge, as for the messages of a transfer after
+// the first.
+func (k *tsigKey) variables(t *tsig, timersOnly bool) []byte {
+	var b builder
+	if !timersOnly {
+		b.name(k.name)
+		b.uint16(classANY)
+		b.uint32(0)
+		b.name(k.algorithm)
+	}
+	b.uint16(uint16(t.timeSigned >> 32))
+	b.uint32(uint32(t.timeSigned))
+	b.uint16(t.fudge)
+	if !timersOnly {
+		b.uint16(t.err)
+		b.uint16(uint16(len(t.other)))
+		b.bytes(t.other)
+	}
+	return b.buf
+}
+
+// digest returns the MAC of msgs, preceded by the prior MAC, if any, and followed by
+// the TSIG variables vars.
+func (k *tsigKey) digest(prior, msgs, vars []byte) []byte {
+	h := hmac.New(k.hash, k.secret)
+	if prior != nil {
+		h.Write([]byte{byte(len(prior) >> 8), byte(len(prior))})
+		h.Write(prior)
+	}
+	h.Write(msgs)
+	h.Write(vars)
+	return h.Sum(nil)
+}
+
+// sign returns msg with a TSIG record signing it appended, and the MAC of the record.
+// prior is the MAC of the request a response answers, or of the previous signed
+// message of a transfer, with timersOnly; unsigned are then the messages sent since
+// that one, which are digested along with msg.
+func (k *tsigKey) sign(msg []byte, now time.Time, prior, unsigned []byte, timersOnly bool) ([]byte, []byte) {
+	t := &tsig{algorithm: k.algorithm, timeSigned: uint64(now.Unix()), fudge: tsigFudge, originalID: binary.BigEndian.Uint16(msg)}
+	t.mac = k.digest(prior, append(append([]byte(nil), unsigned...), msg...), k.variables(t, timersOnly))
+
+	var rdata builder
+	rdata.name(k.algorithm)
+	rdata.uint16(uint16(t.timeSigned >> 32))
+	rdata.uint32(uint32(t.timeSigned))
+	rdata.uint16(t.fudge)
+	rdata.uint16(uint16(len(t.mac)))
+	rdata.bytes(t.mac)
+	rdata.uint16(t.originalID)
+	rdata.uint16(t.err)
+	rdata.uint16(0)
+
+	b := builder{buf: append([]byte(nil), msg...)}
+	b.rr(k.name, typeTSIG, classANY, 0, rdata.buf)
+	binary.BigEndian.PutUint16(b.buf[10:], binary.BigEndian.Uint16(b.buf[10:])+1)
+	return b.buf, t.mac
+}
+
+// verifier checks the signatures of the messages of a transfer, as described by RFC
+// 8945 section 5.3.1: the first message must be signed, as must the last, and the
+// messages in between at least every hundredth.
+type verifier struct {
+	key *tsigKey
+	now func() time.Time
+
+	// prior is the MAC of the request, and then of the last signed message.
+	prior  []byte
+	signed bool
+
+	// unsigned holds the messages received since the last signed one.
+	unsigned [][]byte
+}
+
+// verify checks the signature of m, if it has one.
+func (v *verifier) verify(m *message) error {
+	var r *rr
+	if n := len(m.additional); n > 0 && m.additional[n-1].rtype == typeTSIG {
+		r = m.additional[n-1]
+	}
+	if r == nil {
+		if !v.signed {
+			return fmt.Errorf("%w: the response is not signed", ErrBadSignature)
+		}
+		if len(v.unsigned) == maxUnsignedMessages {
+			return fmt.Errorf("%w: more than %d consecutive messages are not signed", ErrBadSignature, maxUnsignedMessages)
+		}
+		v.unsigned = append(v.unsigned, m.raw)
+		return nil
+	}
+
+	t, err := parseTSIG(r)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(r.name, v.key.name) || t.algorithm != v.key.algorithm {
+		return fmt.Errorf("%w: signed with key %v, algorithm %v", ErrBadSignature, r.name, t.algorithm)
+	}
+	if t.err != 0 {
+		name, ok := tsigErrors[t.err]
+		if !ok {
+			name = fmt.Sprint(t.err)
+		}
+		return fmt.Errorf("%w: the server reported %v", ErrBadSignature, name)
+	}
+
+	// The message is digested as it was before it was signed: without its TSIG record
+	// and with its original ID.
+	msg := append([]byte(nil), m.raw[:r.start]...)
+	binary.BigEndian.PutUint16(msg, t.originalID)
+	binary.BigEndian.PutUint16(msg[10:], m.arcount-1)
+
+	var msgs []byte
+	for _, u := range v.unsigned {
+		msgs = append(msgs, u...)
+	}
+	msgs = append(msgs, msg...)
+	mac := v.key.digest(v.prior, msgs, v.key.variables(t, v.signed))
+	if !hmac.Equal(mac, t.mac) {
+		return ErrBadSignature
+	}
+
+	now := uint64(v.now().Unix())
+	if now+uint64(t.fudge) < t.timeSigned || t.timeSigned+uint64(t.fudge) < now {
+		return fmt.Errorf("%w: signed at %v, outside of the fudge of %ds", ErrBadSignature, time.Unix(int64(t.timeSigned), 0).UTC(), t.fudge)
+	}
+
+	v.prior = t.mac
+	v.signed = true
+	v.unsigned = nil
+	return nil
+}
+
+// done checks that the last message of the transfer was signed.
+func (v *verifier) done() error {
+	if len(v.unsigned) > 0 {
+		return fmt.Errorf("%w: the last message is not signed", ErrBadSignature)
+	}
+	return nil
+}
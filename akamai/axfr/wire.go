@@ -0,0 +1,359 @@
+package axfr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The DNS types and classes the transfers deal with.
+const (
+	typeA          = 1
+	typeNS         = 2
+	typeCNAME      = 5
+	typeSOA        = 6
+	typePTR        = 12
+	typeHINFO      = 13
+	typeMX         = 15
+	typeTXT        = 16
+	typeRP         = 17
+	typeAFSDB      = 18
+	typeAAAA       = 28
+	typeSRV        = 33
+	typeNAPTR      = 35
+	typeDS         = 43
+	typeSSHFP      = 44
+	typeRRSIG      = 46
+	typeNSEC       = 47
+	typeDNSKEY     = 48
+	typeNSEC3      = 50
+	typeNSEC3PARAM = 51
+	typeTLSA       = 52
+	typeCDS        = 59
+	typeCDNSKEY    = 60
+	typeZONEMD     = 63
+	typeSPF        = 99
+	typeTSIG       = 250
+	typeAXFR       = 252
+	typeCAA        = 257
+
+	classIN  = 1
+	classANY = 255
+)
+
+// headerLen is the length of the header of a DNS message.
+const headerLen = 12
+
+// rcodeNames names the response codes of the failed transfers.
+var rcodeNames = map[int]string{
+	1:  "FORMERR",
+	2:  "SERVFAIL",
+	3:  "NXDOMAIN",
+	4:  "NOTIMP",
+	5:  "REFUSED",
+	9:  "NOTAUTH",
+	10: "NOTZONE",
+}
+
+func rcodeName(rcode int) string {
+	if name, ok := rcodeNames[rcode]; ok {
+		return name
+	}
+	return "RCODE" + strconv.Itoa(rcode)
+}
+
+var errTruncated = errors.New("truncated DNS message")
+
+// header is the header of a DNS message.
+type header struct {
+	id      uint16
+	flags   uint16
+	qdcount uint16
+	ancount uint16
+	nscount uint16
+	arcount uint16
+}
+
+// response reports whether the message is a response.
+func (h *header) response() bool {
+	return h.flags&0x8000 != 0
+}
+
+// rcode returns the response code of the message.
+func (h *header) rcode() int {
+	return int(h.flags & 0xf)
+}
+
+// rr is a resource record of a message, whose rdata is left in wire format until it is
+// formatted, as its names may point to other parts of the message.
+type rr struct {
+	name  string
+	rtype uint16
+	class uint16
+	ttl   uint32
+
+	// msg is the message the record was read from, start the offset of the record in
+	// it, and rdata the offset of its rdata, of length rdlen.
+	msg   []byte
+	start int
+	rdata int
+	rdlen int
+}
+
+// data returns the rdata of the record.
+func (r *rr) data() []byte {
+	return r.msg[r.rdata : r.rdata+r.rdlen]
+}
+
+// message is a parsed DNS message. The records of the question and authority sections
+// are skipped.
+type message struct {
+	header
+	answers    []*rr
+	additional []*rr
+	raw        []byte
+}
+
+// parseMessage parses a DNS message.
+func parseMessage(b []byte) (*message, error) {
+	if len(b) < headerLen {
+		return nil, errTruncated
+	}
+	m := &message{raw: b}
+	m.id = binary.BigEndian.Uint16(b[0:])
+	m.flags = binary.BigEndian.Uint16(b[2:])
+	m.qdcount = binary.BigEndian.Uint16(b[4:])
+	m.ancount = binary.BigEndian.Uint16(b[6:])
+	m.nscount = binary.BigEndian.Uint16(b[8:])
+	m.arcount = binary.BigEndian.Uint16(b[10:])
+
+	off := headerLen
+	for i := 0; i < int(m.qdcount); i++ {
+		_, next, err := readName(b, off)
+		if err != nil {
+			return nil, err
+		}
+		if off = next + 4; off > len(b) {
+			return nil, errTruncated
+		}
+	}
+
+	sections := []struct {
+		count int
+		rrs   *[]*rr
+	}{
+		{int(m.ancount), &m.answers},
+		{int(m.nscount), nil},
+		{int(m.arcount), &m.additional},
+	}
+	for _, s := range sections {
+		for i := 0; i < s.count; i++ {
+			r, next, err := readRR(b, off)
+			if err != nil {
+				return nil, err
+			}
+			if s.rrs != nil {
+				*s.rrs = append(*s.rrs, r)
+			}
+			off = next
+		}
+	}
+	return m, nil
+}
+
+// readRR reads the resource record at off in msg, and returns it with the offset that
+// follows it.
+func readRR(msg []byte, off int) (*rr, int, error) {
+	name, off2, err := readName(msg, off)
+	if err != nil {
+		return nil, 0, err
+	}
+	if off2+10 > len(msg) {
+		return nil, 0, errTruncated
+	}
+	r := &rr{
+		name:  name,
+		rtype: binary.BigEndian.Uint16(msg[off2:]),
+		class: binary.BigEndian.Uint16(msg[off2+2:]),
+		ttl:   binary.BigEndian.Uint32(msg[off2+4:]),
+		msg:   msg,
+		start: off,
+		rdata: off2 + 10,
+		rdlen: int(binary.BigEndian.Uint16(msg[off2+8:])),
+	}
+	if r.rdata+r.rdlen > len(msg) {
+		return nil, 0, errTruncated
+	}
+	return r, r.rdata + r.rdlen, nil
+}
+
+// readName reads the possibly compressed domain name at off in msg, in presentation
+// format without its trailing dot, and returns it with the offset that follows it. The
+// root is "".
+func readName(msg []byte, off int) (string, int, error) {
+	var b strings.Builder
+	next := -1
+	for jumps := 0; ; {
+		if off >= len(msg) {
+			return "", 0, errTruncated
+		}
+		c := int(msg[off])
+		switch c & 0xc0 {
+		case 0x00:
+			if c == 0 {
+				if next < 0 {
+					next = off + 1
+				}
+				return b.String(), next, nil
+			}
+			if off+1+c > len(msg) {
+				return "", 0, errTruncated
+			}
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			writeLabel(&b, msg[off+1:off+1+c])
+			if b.Len() > 1024 {
+				return "", 0, errors.New("domain name too long")
+			}
+			off += 1 + c
+		case 0xc0:
+			if off+2 > len(msg) {
+				return "", 0, errTruncated
+			}
+			ptr := int(binary.BigEndian.Uint16(msg[off:]) & 0x3fff)
+			if next < 0 {
+				next = off + 2
+			}
+			// A pointer must point back, which also rules out loops.
+			if ptr >= off || jumps > 126 {
+				return "", 0, errors.New("invalid domain name compression pointer")
+			}
+			off = ptr
+			jumps++
+		default:
+			return "", 0, fmt.Errorf("invalid domain name label type %#x", c&0xc0)
+		}
+	}
+}
+
+// writeLabel writes a label in presentation format, escaping dots, backslashes and the
+// bytes that aren't printable.
+func writeLabel(b *strings.Builder, label []byte) {
+	for _, c := range label {
+		switch {
+		case c == '.' || c == '\\' || c == '"' || c == '(' || c == ')' || c == ';' || c == '@' || c == '$':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c <= ' ' || c >= 0x7f:
+			fmt.Fprintf(b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
+
+// builder builds DNS messages.
+type builder struct {
+	buf []byte
+}
+
+func (b *builder) header(h header) {
+	b.uint16(h.id)
+	b.uint16(h.flags)
+	b.uint16(h.qdcount)
+	b.uint16(h.ancount)
+	b.uint16(h.nscount)
+	b.uint16(h.arcount)
+}
+
+func (b *builder) uint8(v uint8) {
+	b.buf = append(b.buf, v)
+}
+
+func (b *builder) uint16(v uint16) {
+	b.buf = append(b.buf, byte(v>>8), byte(v))
+}
+
+func (b *builder) uint32(v uint32) {
+	b.buf = append(b.buf, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (b *builder) bytes(v []byte) {
+	b.buf = append(b.buf, v...)
+}
+
+// name writes a domain name in presentation format, such as those readName reads,
+// uncompressed.
+func (b *builder) name(name string) error {
+	name = strings.TrimSuffix(name, ".")
+	var label []byte
+	flush := func() error {
+		if len(label) == 0 || len(label) > 63 {
+			return fmt.Errorf("invalid label in domain name %q", name)
+		}
+		b.uint8(uint8(len(label)))
+		b.bytes(label)
+		label = label[:0]
+		return nil
+	}
+
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; {
+		case c == '\\' && i+3 < len(name) && isDigit(name[i+1]) && isDigit(name[i+2]) && isDigit(name[i+3]):
+			v, _ := strconv.Atoi(name[i+1 : i+4])
+			if v > 255 {
+				return fmt.Errorf("invalid escape in domain name %q", name)
+			}
+			label = append(label, byte(v))
+			i += 3
+		case c == '\\' && i+1 < len(name):
+			label = append(label, name[i+1])
+			i++
+		case c == '.':
+			if err := flush(); err != nil {
+				return err
+			}
+		default:
+			label = append(label, c)
+		}
+	}
+	if name != "" {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+	b.uint8(0)
+	return nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// rr writes a resource record whose rdata is already encoded.
+func (b *builder) rr(name string, rtype, class uint16, ttl uint32, rdata []byte) error {
+	if err := b.name(name); err != nil {
+		return err
+	}
+	b.uint16(rtype)
+	b.uint16(class)
+	b.uint32(ttl)
+	b.uint16(uint16(len(rdata)))
+	b.bytes(rdata)
+	return nil
+}
+
+// newQuery returns the AXFR query of a zone.
+func newQuery(id uint16, zone string) ([]byte, error) {
+	var b builder
+	b.header(header{id: id, qdcount: 1})
+	if err := b.name(zone); err != nil {
+		return nil, err
+	}
+	b.uint16(typeAXFR)
+	b.uint16(classIN)
+	return b.buf, nil
+}
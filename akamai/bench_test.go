@@ -0,0 +1,54 @@
+package akamai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func BenchmarkListZones(b *testing.B) {
+	client, srv := akamaitest.NewServer(b)
+	for _, z := range []string{"a.com", "b.com", "c.com", "d.com", "e.com"} {
+		srv.AddZone(&akamai.ZoneCreateRequest{Zone: z, Type: "PRIMARY", Comment: "benchmark zone"})
+	}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := client.FastDNSv2.ListZones(ctx, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCreateRecordSet(b *testing.B) {
+	client, srv := akamaitest.NewServer(b)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	rs := &akamai.RecordSetCreateRequest{
+		Zone:  "example.com",
+		Name:  "www.example.com",
+		Type:  akamai.RRTypeTxt,
+		TTL:   akamai.Int(300),
+		Rdata: []string{"v=spf1 include:_spf.example.com ~all", "google-site-verification=abcdefghijklmnopqrstuvwxyz"},
+	}
+	opt := &akamai.RecordSetOptions{Zone: rs.Zone, Name: rs.Name, Type: rs.Type}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := client.FastDNSv2.CreateRecordSet(ctx, rs); err != nil {
+			b.Fatal(err)
+		}
+
+		b.StopTimer()
+		if _, err := client.FastDNSv2.DeleteRecordSet(ctx, opt); err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+	}
+}
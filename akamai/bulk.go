@@ -0,0 +1,124 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultBulkConcurrency is the number of calls RunBulk makes at once when no
+// concurrency is given. It stays well under the rate limits of the Akamai APIs.
+const defaultBulkConcurrency = 4
+
+// ErrBulkAborted is the error of the items that RunBulk did not run because too many
+// calls failed in a row.
+var ErrBulkAborted = errors.New("bulk run aborted after too many consecutive failures")
+
+// BulkOptions configures RunBulk and the helpers built on it.
+type BulkOptions struct {
+	// Concurrency is the maximum number of calls in flight. Defaults to 4.
+	Concurrency int
+
+	// Interval is the minimum time between the start of two calls, to stay under an
+	// API's rate limit. Zero starts calls as soon as a worker is free.
+	Interval time.Duration
+
+	// MaxConsecutiveFailures aborts the run once that many calls have failed in a row.
+	// Zero never aborts.
+	MaxConsecutiveFailures int
+}
+
+// BulkResult is the outcome of the call made for one item by RunBulk.
+type BulkResult[R any] struct {
+	Value R
+	Err   error
+}
+
+// RunBulk calls fn for every item with bounded concurrency, and returns the outcomes in
+// the order of items. It stops starting calls when ctx is done or the run is aborted;
+// the items that were never started get ctx.Err() or ErrBulkAborted as their error.
+// Calls in flight when the run is aborted see their context canceled.
+func RunBulk[T, R any](ctx context.Context, items []T, opt *BulkOptions, fn func(context.Context, T) (R, error)) []BulkResult[R] {
+	if opt == nil {
+		opt = &BulkOptions{}
+	}
+	concurrency := opt.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var tick <-chan time.Time
+	if opt.Interval > 0 {
+		ticker := time.NewTicker(opt.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var (
+		results     = make([]BulkResult[R], len(items))
+		started     = make([]bool, len(items))
+		sem         = make(chan struct{}, concurrency)
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		consecutive int
+		aborted     bool
+	)
+
+	for i, item := range items {
+		if tick != nil && i > 0 {
+			select {
+			case <-tick:
+			case <-runCtx.Done():
+			}
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-runCtx.Done():
+		}
+		if runCtx.Err() != nil {
+			break
+		}
+
+		started[i] = true
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			v, err := fn(runCtx, item)
+			results[i] = BulkResult[R]{Value: v, Err: err}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				consecutive = 0
+				return
+			}
+			consecutive++
+			if opt.MaxConsecutiveFailures > 0 && consecutive >= opt.MaxConsecutiveFailures {
+				aborted = true
+				cancel()
+			}
+		}(i, item)
+	}
+
+	wg.Wait()
+
+	for i := range items {
+		if started[i] {
+			continue
+		}
+		if aborted {
+			results[i].Err = ErrBulkAborted
+		} else {
+			results[i].Err = ctx.Err()
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,89 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBulkOrderAndConcurrency(t *testing.T) {
+	items := make([]int, 500)
+	for i := range items {
+		items[i] = i
+	}
+
+	var inFlight, maxInFlight int64
+	results := RunBulk(context.Background(), items, &BulkOptions{Concurrency: 64}, func(ctx context.Context, i int) (int, error) {
+		n := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		for {
+			m := atomic.LoadInt64(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt64(&maxInFlight, m, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		if i%7 == 0 {
+			return 0, errors.New("multiple of seven")
+		}
+		return i * 2, nil
+	})
+
+	if assert.Len(t, results, len(items)) {
+		for i, r := range results {
+			if i%7 == 0 {
+				assert.Error(t, r.Err)
+				continue
+			}
+			assert.NoError(t, r.Err)
+			assert.Equal(t, i*2, r.Value)
+		}
+	}
+	assert.True(t, maxInFlight <= 64, "at most 64 calls in flight, got %v", maxInFlight)
+	assert.True(t, maxInFlight > 1, "calls run concurrently")
+}
+
+func TestRunBulkAbortAfterConsecutiveFailures(t *testing.T) {
+	items := make([]int, 100)
+
+	var calls int64
+	results := RunBulk(context.Background(), items, &BulkOptions{Concurrency: 1, MaxConsecutiveFailures: 3}, func(ctx context.Context, i int) (int, error) {
+		atomic.AddInt64(&calls, 1)
+		return 0, errors.New("failed")
+	})
+
+	assert.Equal(t, int64(3), calls)
+	assert.Error(t, results[2].Err)
+	assert.NotEqual(t, ErrBulkAborted, results[2].Err)
+	assert.Equal(t, ErrBulkAborted, results[3].Err)
+	assert.Equal(t, ErrBulkAborted, results[99].Err)
+}
+
+func TestRunBulkContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	items := make([]int, 100)
+
+	var calls int64
+	results := RunBulk(ctx, items, &BulkOptions{Concurrency: 2}, func(ctx context.Context, i int) (int, error) {
+		if atomic.AddInt64(&calls, 1) == 10 {
+			cancel()
+		}
+		return 1, nil
+	})
+
+	assert.True(t, calls < 100)
+	assert.Equal(t, context.Canceled, results[99].Err)
+}
+
+func TestRunBulkInterval(t *testing.T) {
+	start := time.Now()
+	RunBulk(context.Background(), []int{1, 2, 3, 4}, &BulkOptions{Concurrency: 4, Interval: 10 * time.Millisecond}, func(ctx context.Context, i int) (int, error) {
+		return i, nil
+	})
+	assert.True(t, time.Since(start) >= 30*time.Millisecond)
+}
@@ -0,0 +1,107 @@
+package akamai
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// volatileFields are the JSON fields CanonicalJSON leaves out by default, as they change
+// with every change to a zone whatever the change.
+var volatileFields = map[string]bool{
+	"lastActivationDate": true,
+	"lastModifiedBy":     true,
+	"lastModifiedDate":   true,
+	"versionId":          true,
+}
+
+// CanonicalJSONOptions configures CanonicalJSON.
+type CanonicalJSONOptions struct {
+	// KeepVolatile keeps the fields that change with every version of a zone, such as
+	// lastModifiedDate and versionId, which are left out otherwise.
+	KeepVolatile bool
+
+	// Indent, if set, indents the output with it.
+	Indent string
+}
+
+// CanonicalJSON serializes v to JSON in a form that only changes when v does, for
+// artifacts kept under version control: the keys of objects are sorted, and the
+// volatile fields are left out unless opt.KeepVolatile is set. The order of arrays is
+// kept, so record sets should be sorted with SortRecordSets first. The output ends with
+// a newline.
+func CanonicalJSON(v interface{}, opt *CanonicalJSONOptions) ([]byte, error) {
+	if opt == nil {
+		opt = &CanonicalJSONOptions{}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Going through generic values sorts the keys of structs as those of maps are, and
+	// json.Number keeps numbers as they were written.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	if !opt.KeepVolatile {
+		generic = dropVolatile(generic)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", opt.Indent)
+	if err := enc.Encode(generic); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// dropVolatile removes the volatileFields from the objects of v, at any depth.
+func dropVolatile(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if volatileFields[k] {
+				delete(v, k)
+				continue
+			}
+			v[k] = dropVolatile(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = dropVolatile(e)
+		}
+	}
+	return v
+}
+
+// SortRecordSets returns copies of record sets sorted by name, type and rdata, with the
+// rdata of each sorted, so that exports don't depend on the order the API listed them
+// in. Names are compared without regard to case or a trailing dot.
+func SortRecordSets(records []*RecordSet) []*RecordSet {
+	sorted := make([]*RecordSet, len(records))
+	for i, rs := range records {
+		c := *rs
+		c.Rdata = append([]*string(nil), rs.Rdata...)
+		sort.Slice(c.Rdata, func(i, j int) bool {
+			return StringValue(c.Rdata[i]) < StringValue(c.Rdata[j])
+		})
+		sorted[i] = &c
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if ka, kb := syncKey(a.GetName(), a.GetType()), syncKey(b.GetName(), b.GetType()); ka != kb {
+			return ka < kb
+		}
+		return strings.Join(currentRdata(a), "\n") < strings.Join(currentRdata(b), "\n")
+	})
+	return sorted
+}
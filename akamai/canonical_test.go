@@ -0,0 +1,154 @@
+package akamai_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+// fixtureExport is a zone and its record sets, in the order an API could list them.
+func fixtureExport() (*akamai.Zone, []*akamai.RecordSet) {
+	zone := &akamai.Zone{
+		Zone:             akamai.String("example.com"),
+		Type:             akamai.String("PRIMARY"),
+		ContractID:       akamai.String("1-AAAAA"),
+		VersionID:        akamai.String("0f3b9d8e"),
+		LastModifiedDate: akamai.String("2026-10-16T12:00:00Z"),
+		LastModifiedBy:   akamai.String("alice"),
+		ActivationState:  akamai.ActivationStateOf(akamai.ZoneActive),
+	}
+	rs := func(name, rtype string, ttl int, rdata ...string) *akamai.RecordSet {
+		r := &akamai.RecordSet{Name: akamai.String(name), Type: akamai.String(rtype), TTL: akamai.Int(ttl)}
+		for _, d := range rdata {
+			r.Rdata = append(r.Rdata, akamai.String(d))
+		}
+		return r
+	}
+	return zone, []*akamai.RecordSet{
+		rs("www.example.com", "A", 300, "192.0.2.2", "192.0.2.1"),
+		rs("example.com", "NS", 86400, "a2.akam.net.", "a1.akam.net."),
+		rs("WWW.example.com.", "AAAA", 300, "2001:db8::1"),
+		rs("api.example.com", "CNAME", 300, "www.example.com."),
+		rs("example.com", "MX", 3600, "20 mx2.example.com.", "10 mx1.example.com."),
+		rs("example.com", "TXT", 3600, `"v=spf1 -all"`, `"<verification>"`),
+	}
+}
+
+func shuffled[T any](rnd *rand.Rand, items []T) []T {
+	s := append([]T(nil), items...)
+	rnd.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+	return s
+}
+
+func TestCanonicalJSONExportIsStable(t *testing.T) {
+	zone, records := fixtureExport()
+	render := func(records []*akamai.RecordSet) []byte {
+		t.Helper()
+		b, err := akamai.CanonicalJSON(map[string]interface{}{
+			"zone":       zone,
+			"recordSets": akamai.SortRecordSets(records),
+		}, &akamai.CanonicalJSONOptions{Indent: "  "})
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		return b
+	}
+
+	want := render(records)
+	checkGolden(t, "../testdata/export/zone.json", want)
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		if got := render(shuffled(rnd, records)); !bytes.Equal(want, got) {
+			t.Fatalf("run %d: expect identical output, got\n%s", i, got)
+		}
+	}
+}
+
+func TestCanonicalJSONVolatileFields(t *testing.T) {
+	zone, _ := fixtureExport()
+
+	b, err := akamai.CanonicalJSON(zone, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, `{"activationState":"ACTIVE","contractId":"1-AAAAA","type":"PRIMARY","zone":"example.com"}`+"\n", string(b))
+
+	b, err = akamai.CanonicalJSON(zone, &akamai.CanonicalJSONOptions{KeepVolatile: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "alice", fields["lastModifiedBy"])
+	assert.Equal(t, "0f3b9d8e", fields["versionId"])
+}
+
+func TestSortRecordSets(t *testing.T) {
+	_, records := fixtureExport()
+	sorted := akamai.SortRecordSets(records)
+
+	var got []string
+	for _, rs := range sorted {
+		got = append(got, rs.GetName()+" "+rs.GetType())
+	}
+	assert.Equal(t, []string{
+		"api.example.com CNAME",
+		"example.com MX",
+		"example.com NS",
+		"example.com TXT",
+		"www.example.com A",
+		"WWW.example.com. AAAA",
+	}, got)
+	assert.Equal(t, []*string{akamai.String("192.0.2.1"), akamai.String("192.0.2.2")}, sorted[4].Rdata)
+	assert.Equal(t, "192.0.2.2", *records[0].Rdata[0], "the record sets given are left alone")
+}
+
+func TestSyncPlanMarshalJSONIsStable(t *testing.T) {
+	plan := fixturePlan()
+	want, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	var diff bytes.Buffer
+	if err := plan.WriteDiff(&diff); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		p := fixturePlan()
+		p.Changes = shuffled(rnd, p.Changes)
+		for _, c := range p.Changes {
+			if c.Desired != nil {
+				c.Desired.Rdata = shuffled(rnd, c.Desired.Rdata)
+			}
+			if c.Current != nil {
+				c.Current.Rdata = shuffled(rnd, c.Current.Rdata)
+			}
+		}
+
+		got, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		if !bytes.Equal(want, got) {
+			t.Fatalf("run %d: expect identical output, got\n%s", i, got)
+		}
+
+		var gotDiff bytes.Buffer
+		if err := p.WriteDiff(&gotDiff); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		if !bytes.Equal(diff.Bytes(), gotDiff.Bytes()) {
+			t.Fatalf("run %d: expect identical diff, got\n%s", i, gotDiff.Bytes())
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// UnlimitedZoneCapacity is the capacity CheckZoneCapacity returns for the contracts that
+// report no maximum number of zones.
+const UnlimitedZoneCapacity = -1
+
+// ErrInsufficientZoneCapacity is matched by errors.Is for the errors of the zone
+// creations a contract has no room for.
+var ErrInsufficientZoneCapacity = errors.New("insufficient zone capacity")
+
+// ZoneCapacityError is returned by CheckZoneCapacity when adding zones to a contract
+// would exceed its maximum number of zones.
+type ZoneCapacityError struct {
+	ContractID   string
+	MaximumZones int
+	ZoneCount    int
+	ZonesToAdd   int
+}
+
+func (e *ZoneCapacityError) Error() string {
+	return fmt.Sprintf("%v: contract %v holds %d of its %d zones, %d more can't be added",
+		ErrInsufficientZoneCapacity, e.ContractID, e.ZoneCount, e.MaximumZones, e.ZonesToAdd)
+}
+
+// Is makes errors.Is(err, ErrInsufficientZoneCapacity) report true.
+func (e *ZoneCapacityError) Is(target error) bool {
+	return target == ErrInsufficientZoneCapacity
+}
+
+// CheckZoneCapacity returns the number of zones that can still be created on a contract,
+// and a *ZoneCapacityError if zonesToAdd of them can't. Contracts that report no
+// maximum have an UnlimitedZoneCapacity.
+//
+// The contract is looked up with the client's ListContracts, so that a DataCache
+// installed on the client serves it; its zone count is then as old as the cache entry.
+func (s *FastDNSv2Service) CheckZoneCapacity(ctx context.Context, contractID string, zonesToAdd int) (int, error) {
+	id := TrimContractPrefix(contractID)
+
+	contracts, _, err := s.client.FastDNSv2.ListContracts(ctx, nil)
+	if err != nil {
+		return 0, wrapOp("CheckZoneCapacity", "", "", "", err)
+	}
+
+	for _, c := range contracts {
+		if TrimContractPrefix(c.GetContractID()) != id {
+			continue
+		}
+		if c.MaximumZones <= 0 {
+			return UnlimitedZoneCapacity, nil
+		}
+
+		remaining := c.MaximumZones - c.ZoneCount
+		if remaining < 0 {
+			remaining = 0
+		}
+		if zonesToAdd > remaining {
+			return remaining, &ZoneCapacityError{ContractID: id, MaximumZones: c.MaximumZones, ZoneCount: c.ZoneCount, ZonesToAdd: zonesToAdd}
+		}
+		return remaining, nil
+	}
+
+	return 0, wrapOp("CheckZoneCapacity", "", "", "", fmt.Errorf("contract %v is not accessible", id))
+}
@@ -0,0 +1,96 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestCheckZoneCapacity(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+	for i := 0; i < 8; i++ {
+		srv.AddZone(&akamai.ZoneCreateRequest{Zone: fmt.Sprintf("z%d.example", i), Type: "PRIMARY"})
+	}
+	srv.SetMaximumZones(akamaitest.TestContractID, 10)
+
+	tests := []struct {
+		name       string
+		zonesToAdd int
+		remaining  int
+		enough     bool
+	}{
+		{"under the limit", 1, 2, true},
+		{"exactly at the limit", 2, 2, true},
+		{"over the limit", 3, 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining, err := client.FastDNSv2.CheckZoneCapacity(ctx, "ctr_"+akamaitest.TestContractID, tt.zonesToAdd)
+			assert.Equal(t, tt.remaining, remaining)
+			if tt.enough {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, akamai.ErrInsufficientZoneCapacity))
+			var cerr *akamai.ZoneCapacityError
+			if assert.True(t, errors.As(err, &cerr)) {
+				assert.Equal(t, akamai.ZoneCapacityError{ContractID: akamaitest.TestContractID, MaximumZones: 10, ZoneCount: 8, ZonesToAdd: 3}, *cerr)
+			}
+		})
+	}
+
+	srv.SetMaximumZones(akamaitest.TestContractID, 0)
+	remaining, err := client.FastDNSv2.CheckZoneCapacity(ctx, akamaitest.TestContractID, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, akamai.UnlimitedZoneCapacity, remaining)
+
+	_, err = client.FastDNSv2.CheckZoneCapacity(ctx, "9-UNKNOWN", 1)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, akamai.ErrInsufficientZoneCapacity))
+}
+
+func TestOnboardZonesCapacity(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.SetMaximumZones(akamaitest.TestContractID, 2)
+	specs := onboardSpecs("a.example", "b.example", "c.example")
+	for i := range specs {
+		specs[i].ContractID = akamaitest.TestContractID
+	}
+	opt := &akamai.OnboardOptions{
+		RetryInterval:  time.Millisecond,
+		PollInterval:   time.Millisecond,
+		SkipDelegation: true,
+	}
+
+	// The contract has no room for the three zones: none is created.
+	progress, _ := client.FastDNSv2.OnboardZones(context.Background(), specs, opt)
+	for _, p := range progress {
+		assert.Equal(t, akamai.OnboardPending, p.Step, p.Zone)
+		assert.True(t, errors.Is(p.Err, akamai.ErrInsufficientZoneCapacity), p.Zone)
+	}
+	assert.NotContains(t, srv.Requests(), "POST /config-dns/v2/zones")
+
+	// Without the check, the zones past the limit fail on their creation.
+	opt.SkipCapacityCheck = true
+	progress, _ = client.FastDNSv2.OnboardZones(context.Background(), specs, opt)
+	var done, failed int
+	for _, p := range progress {
+		if p.Err == nil {
+			done++
+		} else {
+			failed++
+			assert.False(t, errors.Is(p.Err, akamai.ErrInsufficientZoneCapacity))
+		}
+	}
+	assert.Equal(t, 2, done)
+	assert.Equal(t, 1, failed)
+}
@@ -0,0 +1,43 @@
+package akamai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+// The tests below replay cassettes recorded against the real API. Re-record them with:
+//
+//	AKAMAITEST_RECORD=1 go test ./akamai -run TestCassette
+
+func TestCassetteContractsListProducts(t *testing.T) {
+	client := akamaitest.NewRecorder(t, "../testdata/cassettes/contracts_list_products.json").NewClient()
+
+	products, _, err := client.Contracts.ListProducts(context.Background(), "ctr_1-ABCDE")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Equal(t, "1-ABCDE", *products.ContractID)
+	if assert.Len(t, products.Products, 2) {
+		assert.Equal(t, "M-LC-160976", *products.Products[0].ProductID)
+		assert.Equal(t, "Ion Standard", *products.Products[0].ProductName)
+		assert.Equal(t, "2019-01-01T00:00:00Z", *products.Products[0].StartDate)
+		assert.Nil(t, products.Products[1].EndDate)
+	}
+}
+
+func TestCassetteReportingGetReportVersions(t *testing.T) {
+	client := akamaitest.NewRecorder(t, "../testdata/cassettes/reporting_get_report_versions.json").NewClient()
+
+	versions, _, err := client.Reporting.GetReportVersions(context.Background(), "hits-by-cpcode")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, versions, 1) {
+		assert.Equal(t, 92, *versions[0].DataRetentionDays)
+		assert.Len(t, versions[0].TimeIntervals, 3)
+	}
+}
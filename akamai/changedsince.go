@@ -0,0 +1,115 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ErrZoneVersionExpired is matched by errors.Is for the errors of
+// GetRecordSetsChangedSince calls whose reference version Akamai no longer retains,
+// when no snapshot was given to fall back on.
+var ErrZoneVersionExpired = errors.New("zone version is no longer retained")
+
+// ChangedSinceOptions configures GetRecordSetsChangedSince.
+type ChangedSinceOptions struct {
+	// Snapshot holds the record sets of the zone as of the reference version, as the
+	// caller stored them. They are compared with the current record sets when the
+	// version has aged out of Akamai's retention.
+	Snapshot []*RecordSet
+}
+
+// GetRecordSetsChangedSince returns the changes made to the record sets of a zone since
+// one of its versions, such as the one an exporter last ran against, as a SyncPlan: the
+// Current record sets of its changes are those of the reference version, and the Desired
+// ones are those of the zone now. Creates are the record sets added since, and deletes
+// those removed. The plan is only a report; it is not meant to be applied.
+//
+// The record sets of the reference version are listed with GetZoneVersionRecordSets. If
+// Akamai no longer retains the version, those of opt.Snapshot are used instead, or an
+// error matching ErrZoneVersionExpired is returned without one.
+func (s *FastDNSv2Service) GetRecordSetsChangedSince(ctx context.Context, zone, sinceVersionID string, opt *ChangedSinceOptions) (*SyncPlan, error) {
+	if opt == nil {
+		opt = &ChangedSinceOptions{}
+	}
+
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, wrapOp("GetRecordSetsChangedSince", zone, "", "", err)
+	}
+
+	since := opt.Snapshot
+	list, _, err := s.GetZoneVersionRecordSets(ctx, zone, sinceVersionID, &ListZoneRecordSetOptions{ShowAll: true})
+	switch {
+	case err == nil:
+		since = list.RecordSets
+	case !isStatus(err, http.StatusNotFound):
+		return nil, wrapOp("GetRecordSetsChangedSince", zone, "", "", err)
+	case opt.Snapshot == nil:
+		return nil, wrapOp("GetRecordSetsChangedSince", zone, "", "", fmt.Errorf("%w: version %v, and no snapshot was given", ErrZoneVersionExpired, sinceVersionID))
+	}
+
+	current, err := s.ListAllZoneRecordSets(ctx, zone, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffZones(zone, since, current), nil
+}
+
+// DiffZones compares two snapshots of the record sets of a zone, and returns the
+// changes that turn those of a into those of b as a SyncPlan, sorted by name and type:
+// creates are the record sets only b holds, deletes those only a holds, and updates
+// those whose TTL or rdata differ. The Current record sets of the changes are from a,
+// and the Desired ones from b. The plan is only a report; it is not meant to be applied.
+// Its WriteDiff renders it as a unified diff, and it marshals to a stable JSON form.
+//
+// Rdata is compared in the normalized form of RecordSetEqual, so that record sets
+// whose rdata is only written differently, such as a host name with or without its
+// trailing dot, are unchanged.
+func DiffZones(zone string, a, b []*RecordSet) *SyncPlan {
+	old := map[string]*RecordSet{}
+	for _, rs := range a {
+		old[syncKey(rs.GetName(), rs.GetType())] = rs
+	}
+
+	plan := &SyncPlan{Zone: zone, UnchangedTypes: map[string]int{}}
+	seen := map[string]bool{}
+	for _, rs := range b {
+		key := syncKey(rs.GetName(), rs.GetType())
+		seen[key] = true
+
+		d := rs.ToCreateRequest(zone)
+		d.Name, d.Type = strings.TrimSuffix(d.Name, "."), strings.ToUpper(d.Type)
+
+		cur, ok := old[key]
+		switch {
+		case !ok:
+			plan.Changes = append(plan.Changes, &SyncChange{Action: SyncCreate, Name: d.Name, Type: d.Type, Desired: d})
+		case !recordSetEqual(cur, d):
+			plan.Changes = append(plan.Changes, &SyncChange{Action: SyncUpdate, Name: d.Name, Type: d.Type, Current: cur, Desired: d})
+		default:
+			plan.Unchanged++
+			plan.UnchangedTypes[d.Type]++
+		}
+	}
+
+	for key, cur := range old {
+		if !seen[key] {
+			plan.Changes = append(plan.Changes, &SyncChange{Action: SyncDelete, Name: strings.TrimSuffix(cur.GetName(), "."), Type: strings.ToUpper(cur.GetType()), Current: cur})
+		}
+	}
+
+	sort.Slice(plan.Changes, func(i, j int) bool {
+		x, y := plan.Changes[i], plan.Changes[j]
+		if x.Name != y.Name {
+			return x.Name < y.Name
+		}
+		return x.Type < y.Type
+	})
+
+	return plan
+}
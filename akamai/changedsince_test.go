@@ -0,0 +1,159 @@
+package akamai_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+// changedSinceZone sets up a zone and returns its version and record sets before
+// www is changed, mail removed and api added.
+func changedSinceZone(t *testing.T) (*akamai.Client, string, []*akamai.RecordSet) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	for _, rs := range []*akamai.RecordSetCreateRequest{
+		{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+		{Zone: "example.com", Name: "mail.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.9"}},
+	} {
+		if err := srv.AddRecordSet(rs); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	}
+	since := srv.Zone("example.com").GetVersionID()
+	snapshot := srv.RecordSets("example.com")
+
+	for _, rs := range []*akamai.RecordSetCreateRequest{
+		{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(60), Rdata: []string{"192.0.2.2"}},
+		{Zone: "example.com", Name: "api.example.com", Type: "AAAA", TTL: akamai.Int(300), Rdata: []string{"2001:db8::1"}},
+	} {
+		if err := srv.AddRecordSet(rs); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	}
+	if _, err := client.FastDNSv2.DeleteRecordSet(ctx, &akamai.RecordSetOptions{Zone: "example.com", Name: "mail.example.com", Type: "A"}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	return client, since, snapshot
+}
+
+func assertChangedSince(t *testing.T, plan *akamai.SyncPlan) {
+	t.Helper()
+
+	type change struct {
+		Action akamai.SyncAction
+		Name   string
+		Type   string
+	}
+	var changes []change
+	for _, c := range plan.Changes {
+		changes = append(changes, change{c.Action, c.Name, c.Type})
+	}
+	assert.Equal(t, []change{
+		{akamai.SyncCreate, "api.example.com", "AAAA"},
+		{akamai.SyncDelete, "mail.example.com", "A"},
+		{akamai.SyncUpdate, "www.example.com", "A"},
+	}, changes)
+
+	if assert.Len(t, plan.Changes, 3) {
+		www := plan.Changes[2]
+		assert.Equal(t, 300, www.Current.GetTTL())
+		assert.Equal(t, 60, www.Desired.GetTTL())
+		assert.Equal(t, []string{"192.0.2.2"}, www.Desired.Rdata)
+	}
+	assert.Equal(t, 2, plan.Unchanged, "the SOA and NS records are unchanged")
+}
+
+func TestGetRecordSetsChangedSince(t *testing.T) {
+	client, since, _ := changedSinceZone(t)
+
+	plan, err := client.FastDNSv2.GetRecordSetsChangedSince(context.Background(), "Example.com.", since, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "example.com", plan.Zone)
+	assertChangedSince(t, plan)
+}
+
+func TestGetRecordSetsChangedSinceExpiredVersion(t *testing.T) {
+	client, _, snapshot := changedSinceZone(t)
+	ctx := context.Background()
+
+	// A version Akamai no longer retains is not found; the snapshot is used instead.
+	plan, err := client.FastDNSv2.GetRecordSetsChangedSince(ctx, "example.com", "expired", &akamai.ChangedSinceOptions{Snapshot: snapshot})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assertChangedSince(t, plan)
+
+	_, err = client.FastDNSv2.GetRecordSetsChangedSince(ctx, "example.com", "expired", nil)
+	assert.True(t, errors.Is(err, akamai.ErrZoneVersionExpired), "got %v", err)
+}
+
+func recordSet(name, rtype string, ttl int, rdata ...string) *akamai.RecordSet {
+	rs := &akamai.RecordSet{Name: akamai.String(name), Type: akamai.String(rtype), TTL: akamai.Int(ttl)}
+	for _, r := range rdata {
+		rs.Rdata = append(rs.Rdata, akamai.String(r))
+	}
+	return rs
+}
+
+func TestDiffZones(t *testing.T) {
+	base := []*akamai.RecordSet{
+		recordSet("example.com", "MX", 300, "10 mx1.example.com.", "20 mx2.example.com."),
+		recordSet("www.example.com", "A", 300, "192.0.2.1", "192.0.2.2"),
+	}
+
+	tests := []struct {
+		name      string
+		b         []*akamai.RecordSet
+		action    akamai.SyncAction
+		unchanged int
+	}{
+		{"added", append([]*akamai.RecordSet{recordSet("api.example.com", "CNAME", 300, "www.example.com.")}, base...), akamai.SyncCreate, 2},
+		{"removed", base[:1], akamai.SyncDelete, 1},
+		{"ttl", []*akamai.RecordSet{base[0], recordSet("www.example.com", "A", 60, "192.0.2.1", "192.0.2.2")}, akamai.SyncUpdate, 1},
+		// The MX hosts are only written differently, and are kept.
+		{"rdata", []*akamai.RecordSet{
+			recordSet("example.com", "MX", 300, "20 MX2.example.com", "10 mx1.example.com", "30 mx3.example.com."),
+			base[1],
+		}, akamai.SyncUpdate, 1},
+	}
+	for _, tt := range tests {
+		plan := akamai.DiffZones("example.com", base, tt.b)
+		if assert.Len(t, plan.Changes, 1, tt.name) {
+			assert.Equal(t, tt.action, plan.Changes[0].Action, tt.name)
+		}
+		assert.Equal(t, tt.unchanged, plan.Unchanged, tt.name)
+
+		var b bytes.Buffer
+		if err := plan.WriteDiff(&b); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		checkGolden(t, "../testdata/diff/"+tt.name+".diff", b.Bytes())
+	}
+}
+
+func TestDiffZonesNormalized(t *testing.T) {
+	a := []*akamai.RecordSet{
+		recordSet("www.example.com", "CNAME", 300, "web.example.com."),
+		recordSet("v6.example.com", "AAAA", 300, "2001:db8:0:0::1"),
+		recordSet("txt.example.com", "TXT", 300, `"v=spf1"`),
+	}
+	b := []*akamai.RecordSet{
+		recordSet("WWW.example.com.", "cname", 300, "Web.Example.com"),
+		recordSet("v6.example.com", "AAAA", 300, "2001:db8::1"),
+		recordSet("txt.example.com", "TXT", 300, "v=spf1"),
+	}
+
+	plan := akamai.DiffZones("example.com", a, b)
+	assert.Empty(t, plan.Changes)
+	assert.Equal(t, 3, plan.Unchanged)
+}
@@ -0,0 +1,278 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// defaultChangeFeedPageSize is the number of zones a ChangeFeed lists per page.
+const defaultChangeFeedPageSize = 100
+
+// ChangeEvent is a change of a zone, as seen by a ChangeFeed: the record sets added,
+// modified and removed from one version of the zone to another. Several changes made
+// between two polls are seen as one.
+type ChangeEvent struct {
+	Zone string
+
+	// OldVersionID is the version of the zone at the previous poll, or "" for a zone
+	// that was created since, whose record sets are all Added. VersionID is the
+	// version of the zone the changes lead to.
+	OldVersionID string
+	VersionID    string
+
+	// ModifiedBy and ModifiedDate are the LastModifiedBy and LastModifiedDate of the
+	// zone at VersionID.
+	ModifiedBy   string
+	ModifiedDate string
+
+	// Added, Modified and Removed are the changes of the record sets, as DiffZones
+	// reports them: the Current record sets are those of OldVersionID, and the Desired
+	// ones those of VersionID.
+	Added    []*SyncChange
+	Modified []*SyncChange
+	Removed  []*SyncChange
+
+	// Err is set, and the changes are empty, when the record sets of OldVersionID can
+	// no longer be read, so that the feed moves past the change rather than failing on
+	// it at every poll.
+	Err error
+}
+
+// ChangeFeedState is the position of a ChangeFeed: the versions of the zones as of
+// its last poll. It marshals to JSON, to be stored between runs, so that a feed
+// resumed from it with NewChangeFeed only emits the changes made since.
+type ChangeFeedState struct {
+	// Versions holds the version of each zone, by zone. It is nil until the first poll.
+	Versions map[string]string `json:"versions"`
+
+	// Since is the LastModifiedDate of the zones up to which the feed has seen all
+	// the changes.
+	Since string `json:"since,omitempty"`
+}
+
+// ChangeFeed tails the changes made to the zones of an account. Each Poll lists the
+// zones sorted by LastModifiedDate, newest first, down to those it saw at the previous
+// poll, and for each zone whose version changed since, compares the two versions with
+// CompareZoneVersions and hands a ChangeEvent to OnChange, in the order the zones were
+// modified. The first poll of a feed with no state only records the versions of the
+// zones.
+//
+// The zones deleted are not reported. Its fields must be set before Poll or Run is
+// called, and a ChangeFeed must not be polled concurrently.
+type ChangeFeed struct {
+	api   FastDNSv2API
+	state ChangeFeedState
+
+	// OnChange is called with each ChangeEvent. The version of the zone is only
+	// recorded once it returns nil: an error stops the poll, and the change is
+	// emitted again by the next one.
+	OnChange func(ctx context.Context, ev *ChangeEvent) error
+
+	// OnError, if set, is called by Run with the errors of the polls that fail. Run
+	// goes on after them.
+	OnError func(err error)
+
+	// PageSize is the number of zones listed per page. Defaults to 100.
+	PageSize int
+}
+
+// NewChangeFeed returns a ChangeFeed that reads zones with api, such as a client's
+// FastDNSv2, resuming from state, which may be nil to start a new feed.
+func NewChangeFeed(api FastDNSv2API, state *ChangeFeedState) *ChangeFeed {
+	f := &ChangeFeed{api: api}
+	if state != nil {
+		f.state = *state.copy()
+	}
+	return f
+}
+
+// State returns a copy of the position of the feed, to resume it from later.
+func (f *ChangeFeed) State() *ChangeFeedState {
+	return f.state.copy()
+}
+
+func (s *ChangeFeedState) copy() *ChangeFeedState {
+	c := &ChangeFeedState{Since: s.Since}
+	if s.Versions != nil {
+		c.Versions = make(map[string]string, len(s.Versions))
+		for zone, v := range s.Versions {
+			c.Versions[zone] = v
+		}
+	}
+	return c
+}
+
+// Poll lists the zones modified since the previous poll, and emits their changes to
+// OnChange. It returns the first error of the listing, of a comparison or of OnChange;
+// the changes emitted before it are recorded.
+func (f *ChangeFeed) Poll(ctx context.Context) error {
+	if f.state.Versions == nil {
+		return f.baseline(ctx)
+	}
+
+	changed, err := f.changedZones(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, z := range changed {
+		ev, err := f.event(ctx, z)
+		if err != nil {
+			return err
+		}
+		if f.OnChange != nil {
+			if err := f.OnChange(ctx, ev); err != nil {
+				return err
+			}
+		}
+		f.state.Versions[z.GetZone()] = z.GetVersionID()
+		if d := z.GetLastModifiedDate(); d > f.state.Since {
+			f.state.Since = d
+		}
+	}
+	return nil
+}
+
+// Run polls the feed every interval until ctx is done, and then returns its error, or
+// until the client of the feed is closed, and then returns ErrClientClosed. The errors
+// of the polls are handed to OnError.
+func (f *ChangeFeed) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.New("the interval of a change feed must be positive")
+	}
+
+	parent := ctx
+	ctx, done := startBackground(ctx, f.api)
+	defer done()
+	err := f.run(ctx, interval)
+	if parent.Err() == nil && f.closed() {
+		return ErrClientClosed
+	}
+	return err
+}
+
+// closed reports whether the client of the feed, if any, is closed.
+func (f *ChangeFeed) closed() bool {
+	c := lifecycleClientOf(f.api)
+	return c != nil && c.Closed()
+}
+
+func (f *ChangeFeed) run(ctx context.Context, interval time.Duration) error {
+	for {
+		if err := f.Poll(ctx); err != nil && ctx.Err() == nil && f.OnError != nil {
+			f.OnError(err)
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// baseline records the versions of all the zones, without emitting their changes.
+func (f *ChangeFeed) baseline(ctx context.Context) error {
+	zones, err := f.api.ListAllZones(ctx, &ZoneListOptions{PageSize: Int(f.pageSize())})
+	if err != nil {
+		return err
+	}
+
+	versions := make(map[string]string, len(zones))
+	since := ""
+	for _, z := range zones {
+		versions[z.GetZone()] = z.GetVersionID()
+		if d := z.GetLastModifiedDate(); d > since {
+			since = d
+		}
+	}
+	f.state = ChangeFeedState{Versions: versions, Since: since}
+	return nil
+}
+
+// changedZones returns the zones whose version differs from the recorded one, oldest
+// change first. It pages through the zones newest first, and stops at the first one
+// modified before the last poll.
+func (f *ChangeFeed) changedZones(ctx context.Context) ([]*Zone, error) {
+	var changed []*Zone
+	opt := &ZoneListOptions{SortBy: "-lastModifiedDate,zone", PageSize: Int(f.pageSize())}
+	for page := 1; ; page++ {
+		opt.Page = Int(page)
+		list, _, err := f.api.ListZones(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		done := len(list.Zones) < opt.GetPageSize()
+		for _, z := range list.Zones {
+			if z.GetLastModifiedDate() < f.state.Since {
+				done = true
+				break
+			}
+			if v, ok := f.state.Versions[z.GetZone()]; !ok || v != z.GetVersionID() {
+				changed = append(changed, z)
+			}
+		}
+		if done {
+			break
+		}
+	}
+
+	sort.SliceStable(changed, func(i, j int) bool {
+		if a, b := changed[i].GetLastModifiedDate(), changed[j].GetLastModifiedDate(); a != b {
+			return a < b
+		}
+		return changed[i].GetZone() < changed[j].GetZone()
+	})
+	return changed, nil
+}
+
+// event returns the ChangeEvent of a zone whose version changed.
+func (f *ChangeFeed) event(ctx context.Context, z *Zone) (*ChangeEvent, error) {
+	ev := &ChangeEvent{
+		Zone:         z.GetZone(),
+		OldVersionID: f.state.Versions[z.GetZone()],
+		VersionID:    z.GetVersionID(),
+		ModifiedBy:   z.GetLastModifiedBy(),
+		ModifiedDate: z.GetLastModifiedDate(),
+	}
+
+	var plan *SyncPlan
+	if ev.OldVersionID == "" {
+		current, err := f.api.ListAllZoneRecordSets(ctx, ev.Zone, nil)
+		if err != nil {
+			return nil, err
+		}
+		plan = DiffZones(ev.Zone, nil, current)
+	} else {
+		var err error
+		plan, err = f.api.CompareZoneVersions(ctx, ev.Zone, ev.OldVersionID, ev.VersionID)
+		var ae *AkamaiError
+		if errors.As(err, &ae) && ae.Status == http.StatusNotFound {
+			ev.Err = err
+			return ev, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, c := range plan.Changes {
+		switch c.Action {
+		case SyncCreate:
+			ev.Added = append(ev.Added, c)
+		case SyncUpdate:
+			ev.Modified = append(ev.Modified, c)
+		case SyncDelete:
+			ev.Removed = append(ev.Removed, c)
+		}
+	}
+	return ev, nil
+}
+
+func (f *ChangeFeed) pageSize() int {
+	if f.PageSize > 0 {
+		return f.PageSize
+	}
+	return defaultChangeFeedPageSize
+}
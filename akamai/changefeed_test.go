@@ -0,0 +1,126 @@
+package akamai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestChangeFeed(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		zone := fmt.Sprintf("zone%d.example", i)
+		srv.AddZone(&akamai.ZoneCreateRequest{Zone: zone, Type: "PRIMARY"})
+		srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: zone, Name: "www." + zone, Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	}
+
+	var events []*akamai.ChangeEvent
+	onChange := func(ctx context.Context, ev *akamai.ChangeEvent) error {
+		events = append(events, ev)
+		return nil
+	}
+	feed := akamai.NewChangeFeed(client.FastDNSv2, nil)
+	feed.OnChange = onChange
+	feed.PageSize = 2
+
+	// The first poll only records the versions of the zones.
+	if err := feed.Poll(ctx); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Empty(t, events)
+	assert.Len(t, feed.State().Versions, 5)
+
+	srv.ModifiedBy = "alice"
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "zone3.example", Name: "mail.zone3.example", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.25"}})
+	_, _, err := client.FastDNSv2.UpdateRecordSet(ctx, &akamai.RecordSetCreateRequest{Zone: "zone1.example", Name: "www.zone1.example", Type: "A", TTL: akamai.Int(600), Rdata: []string{"192.0.2.1"}})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if err := feed.Poll(ctx); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, events, 2) {
+		byZone := map[string]*akamai.ChangeEvent{}
+		for _, ev := range events {
+			byZone[ev.Zone] = ev
+			assert.Equal(t, "alice", ev.ModifiedBy)
+			assert.Equal(t, srv.Zone(ev.Zone).GetVersionID(), ev.VersionID)
+			assert.NotEmpty(t, ev.OldVersionID)
+			assert.NoError(t, ev.Err)
+		}
+		if ev := byZone["zone3.example"]; assert.NotNil(t, ev) && assert.Len(t, ev.Added, 1) {
+			assert.Equal(t, "mail.zone3.example", ev.Added[0].Name)
+			assert.Empty(t, ev.Modified)
+			assert.Empty(t, ev.Removed)
+		}
+		if ev := byZone["zone1.example"]; assert.NotNil(t, ev) && assert.Len(t, ev.Modified, 1) {
+			assert.Equal(t, 600, ev.Modified[0].Desired.GetTTL())
+			assert.Equal(t, 300, ev.Modified[0].Current.GetTTL())
+		}
+	}
+
+	// Nothing changed since.
+	events = nil
+	if err := feed.Poll(ctx); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Empty(t, events)
+
+	// An error of OnChange leaves the change to the next poll.
+	srv.ModifiedBy = "bob"
+	if _, err := client.FastDNSv2.DeleteRecordSet(ctx, &akamai.RecordSetOptions{Zone: "zone0.example", Name: "www.zone0.example", Type: "A"}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	errStop := errors.New("stop")
+	feed.OnChange = func(ctx context.Context, ev *akamai.ChangeEvent) error { return errStop }
+	assert.Equal(t, errStop, feed.Poll(ctx))
+
+	// The feed resumes after a restart from its stored state.
+	b, err := json.Marshal(feed.State())
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	var state akamai.ChangeFeedState
+	if err := json.Unmarshal(b, &state); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "zone5.example", Type: "PRIMARY"})
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "zone5.example", Name: "www.zone5.example", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.5"}})
+
+	feed = akamai.NewChangeFeed(client.FastDNSv2, &state)
+	feed.OnChange = onChange
+	feed.PageSize = 2
+	if err := feed.Poll(ctx); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, "zone0.example", events[0].Zone)
+		assert.Equal(t, "bob", events[0].ModifiedBy)
+		if assert.Len(t, events[0].Removed, 1) {
+			assert.Equal(t, "www.zone0.example", events[0].Removed[0].Name)
+		}
+		assert.Equal(t, "zone5.example", events[1].Zone)
+		assert.Empty(t, events[1].OldVersionID)
+		var added []string
+		for _, c := range events[1].Added {
+			added = append(added, c.Name+" "+c.Type)
+		}
+		assert.Contains(t, added, "www.zone5.example A")
+		assert.Empty(t, events[1].Removed)
+	}
+
+	events = nil
+	if err := feed.Poll(ctx); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Empty(t, events)
+	assert.Len(t, feed.State().Versions, 6)
+}
@@ -0,0 +1,169 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Severities of the issues found by ValidateChangeList.
+const (
+	SeverityError   = "ERROR"
+	SeverityWarning = "WARNING"
+)
+
+// ValidationIssue is a problem ValidateChangeList found with a record set of a change
+// list. The issues the API reports as the errors of a problem document name the field
+// they are about, by JSON pointer or name, rather than the record set.
+type ValidationIssue struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Field    string `json:"field,omitempty"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+func (i *ValidationIssue) String() string {
+	subject := strings.TrimSpace(i.Name + " " + i.Type)
+	if subject == "" {
+		subject = i.Field
+	}
+	if subject == "" {
+		return fmt.Sprintf("%v: %v", i.Severity, i.Message)
+	}
+	return fmt.Sprintf("%v %v: %v", i.Severity, subject, i.Message)
+}
+
+// ValidationResult is the report of ValidateChangeList. Errors would fail the submission
+// of the change list; Warnings would not. Both are empty, never nil, when the change
+// list is clean.
+type ValidationResult struct {
+	Zone     string             `json:"zone"`
+	Errors   []*ValidationIssue `json:"errors"`
+	Warnings []*ValidationIssue `json:"warnings"`
+}
+
+// Valid reports whether the change list can be submitted, that is has no errors.
+func (r *ValidationResult) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// ValidateChangeList checks the change list of a zone as SubmitChangeList would, without
+// applying it, and reports the issues found with its record sets. An invalid change list
+// is not an error of the call: it returns a ValidationResult with Errors, including when
+// the API refuses it with a 400 whose problem document lists the errors.
+//
+// Akamai API docs:
+// https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#postchangelistsubmit
+func (s *FastDNSv2Service) ValidateChangeList(ctx context.Context, zone string) (*ValidationResult, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp("ValidateChangeList", zone, "", "", err)
+	}
+
+	u := fmt.Sprintf("/config-dns/v2/changelists/%v/submit?validateOnly=true", zone)
+
+	req, err := s.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, nil, wrapOp("ValidateChangeList", zone, "", "", err)
+	}
+
+	r := &ValidationResult{}
+	resp, err := s.client.Do(WithOperation(ctx, "ValidateChangeList"), req, r)
+	var ae *AkamaiError
+	if errors.As(err, &ae) && ae.Status == http.StatusBadRequest && len(ae.Errors) > 0 {
+		r.Errors, err = problemIssues(ae.Errors), nil
+	}
+	if err != nil {
+		return nil, resp, wrapOp("ValidateChangeList", zone, "", "", err)
+	}
+
+	r.Zone = zone
+	r.Errors = normalizeIssues(r.Errors, SeverityError)
+	r.Warnings = normalizeIssues(r.Warnings, SeverityWarning)
+	return r, resp, nil
+}
+
+// problemIssues returns the errors of a problem document as validation issues.
+func problemIssues(errs ProblemErrors) []*ValidationIssue {
+	issues := make([]*ValidationIssue, len(errs))
+	for i, e := range errs {
+		issues[i] = &ValidationIssue{Field: e.Location(), Message: e.Message(), Severity: SeverityError}
+	}
+	return issues
+}
+
+// normalizeIssues returns issues, never nil, with their types and severities in upper
+// case and the missing severities set to that of their list.
+func normalizeIssues(issues []*ValidationIssue, severity string) []*ValidationIssue {
+	if issues == nil {
+		return []*ValidationIssue{}
+	}
+	for _, i := range issues {
+		i.Type = strings.ToUpper(i.Type)
+		i.Severity = strings.ToUpper(i.Severity)
+		if i.Severity == "" {
+			i.Severity = severity
+		}
+	}
+	return issues
+}
+
+// ErrChangeListInvalid is matched by errors.Is for the errors of
+// SubmitValidatedChangeList calls that refused to submit a change list.
+var ErrChangeListInvalid = errors.New("change list is invalid")
+
+// ChangeListValidationError is returned by SubmitValidatedChangeList when the validation
+// of the change list found errors. Result holds the whole report, warnings included.
+type ChangeListValidationError struct {
+	Result *ValidationResult
+}
+
+func (e *ChangeListValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v: zone %v has %d errors", ErrChangeListInvalid, e.Result.Zone, len(e.Result.Errors))
+	for _, i := range e.Result.Errors {
+		b.WriteString("; " + i.String())
+	}
+	return b.String()
+}
+
+// Is makes errors.Is(err, ErrChangeListInvalid) report true.
+func (e *ChangeListValidationError) Is(target error) bool {
+	return target == ErrChangeListInvalid
+}
+
+// SubmitValidatedChangeList validates the change list of a zone with ValidateChangeList
+// and only submits it if no error was found. Otherwise it returns a
+// *ChangeListValidationError and leaves the change list in place, so that it can be
+// fixed. The warnings don't prevent the submission, and are returned either way.
+func (s *FastDNSv2Service) SubmitValidatedChangeList(ctx context.Context, zone string) (*ValidationResult, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp("SubmitValidatedChangeList", zone, "", "", err)
+	}
+	if err := s.checkZoneProtected(zone); err != nil {
+		return nil, nil, wrapOp("SubmitValidatedChangeList", zone, "", "", err)
+	}
+
+	result, resp, err := s.ValidateChangeList(ctx, zone)
+	if err != nil {
+		if oe, ok := OperationFromError(err); ok {
+			oe.Op = "SubmitValidatedChangeList"
+		}
+		return nil, resp, err
+	}
+	if !result.Valid() {
+		return result, resp, wrapOp("SubmitValidatedChangeList", zone, "", "", &ChangeListValidationError{Result: result})
+	}
+
+	resp, err = s.SubmitChangeList(ctx, zone)
+	if err != nil {
+		if oe, ok := OperationFromError(err); ok {
+			oe.Op = "SubmitValidatedChangeList"
+		}
+	}
+	return result, resp, err
+}
@@ -0,0 +1,103 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const validationFixture = `{
+	"errors": [
+		{"name": "www.example.com", "type": "cname", "message": "A CNAME record can't coexist with other records", "severity": "ERROR"}
+	],
+	"warnings": [
+		{"name": "api.example.com", "type": "A", "message": "TTL 30 is under 60 seconds"},
+		{"name": "example.com", "type": "TXT", "message": "SPF record is too long", "severity": "warning"}
+	]
+}`
+
+func TestValidateChangeList(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/changelists/example.com/submit", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		assert.Equal(t, "true", r.URL.Query().Get("validateOnly"))
+		fmt.Fprint(w, validationFixture)
+	})
+
+	result, _, err := client.FastDNSv2.ValidateChangeList(context.Background(), "Example.com.")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Equal(t, &ValidationResult{
+		Zone: "example.com",
+		Errors: []*ValidationIssue{
+			{Name: "www.example.com", Type: "CNAME", Message: "A CNAME record can't coexist with other records", Severity: SeverityError},
+		},
+		Warnings: []*ValidationIssue{
+			{Name: "api.example.com", Type: "A", Message: "TTL 30 is under 60 seconds", Severity: SeverityWarning},
+			{Name: "example.com", Type: "TXT", Message: "SPF record is too long", Severity: SeverityWarning},
+		},
+	}, result)
+	assert.False(t, result.Valid())
+}
+
+func TestValidateChangeListClean(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/changelists/example.com/submit", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	result, _, err := client.FastDNSv2.ValidateChangeList(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.True(t, result.Valid())
+	assert.NotNil(t, result.Errors)
+	assert.NotNil(t, result.Warnings)
+	assert.Empty(t, result.Errors)
+	assert.Empty(t, result.Warnings)
+}
+
+func TestSubmitValidatedChangeList(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	body := validationFixture
+	submitted := 0
+	mux.HandleFunc("/config-dns/v2/changelists/example.com/submit", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if r.URL.Query().Get("validateOnly") == "true" {
+			fmt.Fprint(w, body)
+			return
+		}
+		submitted++
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	result, _, err := client.FastDNSv2.SubmitValidatedChangeList(context.Background(), "example.com")
+	assert.True(t, errors.Is(err, ErrChangeListInvalid), "got %v", err)
+	var ve *ChangeListValidationError
+	if assert.True(t, errors.As(err, &ve)) {
+		assert.Equal(t, result, ve.Result)
+	}
+	assert.Len(t, result.Warnings, 2)
+	assert.Equal(t, 0, submitted, "an invalid change list is not submitted")
+
+	body = `{"errors": [], "warnings": [{"name": "api.example.com", "type": "A", "message": "TTL 30 is under 60 seconds"}]}`
+	result, _, err = client.FastDNSv2.SubmitValidatedChangeList(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, result.Warnings, 1)
+	assert.Equal(t, 1, submitted, "warnings don't prevent the submission")
+}
@@ -0,0 +1,102 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// stressGoroutines is the number of goroutines the concurrency tests share a client
+// between. They are meant to be run with the race detector.
+const stressGoroutines = 50
+
+// stress runs fn from stressGoroutines goroutines at once, n times each.
+func stress(n int, fn func(g, i int)) {
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for g := 0; g < stressGoroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			<-start
+			for i := 0; i < n; i++ {
+				fn(g, i)
+			}
+		}(g)
+	}
+	close(start)
+	wg.Wait()
+}
+
+func TestClientConcurrentUse(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var hooks int32
+	client.WithTimings(func(req *http.Request, tm *Timings) {}).
+		WithDeprecationHook(func(d *Deprecation) { atomic.AddInt32(&hooks, 1) })
+
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("Authorization"))
+		w.Header().Set("Deprecation", "true")
+		fmt.Fprint(w, `{"metadata": {"totalElements": 1}, "zones": [{"zone": "example.com", "type": "PRIMARY"}]}`)
+	})
+	mux.HandleFunc("/config-dns/v2/zones/example.com", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"zone": "example.com", "type": "PRIMARY"}`)
+	})
+	mux.HandleFunc("/config-dns/v2/zones/example.com/names/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["192.0.2.1"]}`)
+	})
+	mux.HandleFunc("/config-dns/v2/data/recordsets/types", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"types": ["A", "AAAA", "CNAME"]}`)
+	})
+
+	ctx := context.Background()
+	stress(5, func(g, i int) {
+		if _, err := client.Credentials.Get(); err != nil {
+			t.Errorf("expect nil, got %v", err)
+		}
+
+		req, err := client.NewRequest("GET", "config-dns/v2/zones", nil)
+		if err != nil {
+			t.Errorf("expect nil, got %v", err)
+			return
+		}
+		if _, err := client.Do(ctx, req, nil); err != nil {
+			t.Errorf("expect nil, got %v", err)
+		}
+
+		// Through the zone type and record type caches.
+		_, _, err = client.FastDNSv2.CreateRecordSet(ctx, &RecordSetCreateRequest{
+			Zone:  "example.com",
+			Name:  fmt.Sprintf("host%d-%d.example.com", g, i),
+			Type:  "A",
+			TTL:   Int(300),
+			Rdata: []string{"192.0.2.1"},
+		})
+		if err != nil {
+			t.Errorf("expect nil, got %v", err)
+		}
+	})
+
+	assert.Equal(t, stressGoroutines*5, client.DeprecatedEndpoints()["GET /config-dns/v2/zones"])
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hooks))
+}
+
+func TestSignerConcurrentUse(t *testing.T) {
+	signer := NewSigner(credentials.NewStaticCredentials("secret", "client", "access", "akab-host.luna.akamaiapis.net"))
+
+	stress(20, func(g, i int) {
+		req, _ := http.NewRequest("GET", "https://akab-host.luna.akamaiapis.net/config-dns/v2/zones", nil)
+		if err := signer.Sign(req, nil); err != nil {
+			t.Errorf("expect nil, got %v", err)
+		}
+		assert.Contains(t, req.Header.Get("Authorization"), "EG1-HMAC-SHA256")
+	})
+}
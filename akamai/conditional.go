@@ -0,0 +1,30 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotModified is returned by Do, with the response, for the requests made
+// WithIfNoneMatch when the resource still has the given entity tag.
+var ErrNotModified = errors.New("not modified")
+
+type ifNoneMatchKey struct{}
+
+// WithIfNoneMatch returns a copy of ctx with which the requests are sent conditionally
+// on the entity tag of their resource, sent as their If-None-Match header: if the
+// resource still has it, the API answers with a bodyless 304 Not Modified, which Do
+// returns as ErrNotModified. The entity tag of a resource is the ETag header of the
+// responses that return it.
+func WithIfNoneMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, ifNoneMatchKey{}, etag)
+}
+
+// IfNoneMatch returns the entity tag set on ctx with WithIfNoneMatch, if any.
+func IfNoneMatch(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	etag, _ := ctx.Value(ifNoneMatchKey{}).(string)
+	return etag
+}
@@ -0,0 +1,73 @@
+package akamai
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// contractFixtures maps the responses of the FastDNS v2 API kept in
+// testdata/contract/fastdns to the types the SDK decodes them into. Refresh them with:
+//
+//	go run ./internal/capture -zone example.com
+var contractFixtures = map[string]func() interface{}{
+	"list_zones":                  func() interface{} { return new(ZoneList) },
+	"get_zone":                    func() interface{} { return new(ZoneMetadata) },
+	"get_zone_contract":           func() interface{} { return new(Contract) },
+	"get_zone_record_sets":        func() interface{} { return new(ListZoneRecordSets) },
+	"get_record_set":              func() interface{} { return new(RecordSet) },
+	"get_change_list":             func() interface{} { return new(ChangeList) },
+	"get_change_list_record_sets": func() interface{} { return new(ChangeListRecords) },
+	"delete_zone_status":          func() interface{} { return new(ZoneDeleteResponse) },
+	"delete_zone_result":          func() interface{} { return new(ZoneDeleteResult) },
+	"list_groups":                 func() interface{} { return new(groupList) },
+	"list_contracts":              func() interface{} { return new(contractList) },
+	"get_authorities":             func() interface{} { return new(authoritiesList) },
+	"get_record_types":            func() interface{} { return new(recordTypeList) },
+}
+
+// TestContractFastDNSv2 decodes the recorded responses of the API strictly, so that
+// fields the SDK doesn't know about and fields of the wrong type fail the test.
+func TestContractFastDNSv2(t *testing.T) {
+	paths, err := filepath.Glob("../testdata/contract/fastdns/*.json")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	seen := map[string]bool{}
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		seen[name] = true
+
+		t.Run(name, func(t *testing.T) {
+			newValue, ok := contractFixtures[name]
+			if !ok {
+				t.Fatalf("no type is registered for fixture %v", path)
+			}
+
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+
+			dec := json.NewDecoder(bytes.NewReader(b))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(newValue()); err != nil {
+				t.Errorf("%v does not match the SDK: %v", path, err)
+			}
+			if _, err := dec.Token(); err != io.EOF {
+				t.Errorf("%v holds more than one JSON value", path)
+			}
+		})
+	}
+
+	for name := range contractFixtures {
+		assert.True(t, seen[name], "no fixture for %v", name)
+	}
+}
@@ -0,0 +1,124 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ContractsService handles communication with the Contracts API (v1) related
+// endpoints of the Akamai API.
+type ContractsService service
+
+// contractPrefix is the prefix PAPI and other provisioning APIs put in front of
+// contract IDs. The Contracts API itself neither accepts nor returns it.
+const contractPrefix = "ctr_"
+
+// TrimContractPrefix returns the contract ID without the "ctr_" prefix used by
+// the provisioning APIs. IDs without the prefix are returned unchanged.
+func TrimContractPrefix(id string) string {
+	return strings.TrimPrefix(id, contractPrefix)
+}
+
+// ContractProducts holds the response from ListProducts.
+type ContractProducts struct {
+	ContractID *string            `json:"contractId,omitempty"`
+	Products   []*ContractProduct `json:"marketing-products,omitempty"`
+}
+
+// ContractProduct is a marketing product included in a contract.
+type ContractProduct struct {
+	ProductID   *string `json:"marketingProductId,omitempty"`
+	ProductName *string `json:"marketingProductName,omitempty"`
+	StartDate   *string `json:"startDate,omitempty"`
+	EndDate     *string `json:"endDate,omitempty"`
+}
+
+// contractProductsResponse is the envelope the products summaries endpoint
+// wraps the contract's products in.
+type contractProductsResponse struct {
+	Products *ContractProducts `json:"products,omitempty"`
+}
+
+// ListContracts lists the IDs of all contracts the credentials have access to.
+// IDs are returned without the "ctr_" prefix.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/contracts/v1.html#getcontracts
+func (s *ContractsService) ListContracts(ctx context.Context) ([]string, *Response, error) {
+	u := "contract-api/v1/contracts/identifiers"
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []string
+	resp, err := s.client.Do(WithOperation(ctx, "ListContracts"), req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for i, id := range ids {
+		ids[i] = TrimContractPrefix(id)
+	}
+
+	return ids, resp, nil
+}
+
+// ListProducts lists the products included in a contract, with their marketing
+// names and dates. The contract ID may be given with or without the "ctr_" prefix.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/contracts/v1.html#getproductsummaries
+func (s *ContractsService) ListProducts(ctx context.Context, contractID string) (*ContractProducts, *Response, error) {
+	u := fmt.Sprintf("contract-api/v1/contracts/%v/products/summaries", TrimContractPrefix(contractID))
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var p *contractProductsResponse
+	resp, err := s.client.Do(WithOperation(ctx, "ListProducts"), req, &p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if p == nil || p.Products == nil {
+		return &ContractProducts{}, resp, nil
+	}
+
+	if p.Products.ContractID != nil {
+		id := TrimContractPrefix(*p.Products.ContractID)
+		p.Products.ContractID = &id
+	}
+
+	return p.Products, resp, nil
+}
+
+// FindContractsWithProduct returns the IDs of the contracts that include the given product.
+// The product is matched against both the marketing product ID and the marketing product
+// name, ignoring case.
+func (s *ContractsService) FindContractsWithProduct(ctx context.Context, product string) ([]string, error) {
+	ids, _, err := s.ListContracts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []string
+	for _, id := range ids {
+		products, _, err := s.ListProducts(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range products.Products {
+			if (p.ProductID != nil && strings.EqualFold(*p.ProductID, product)) ||
+				(p.ProductName != nil && strings.EqualFold(*p.ProductName, product)) {
+				matches = append(matches, id)
+				break
+			}
+		}
+	}
+
+	return matches, nil
+}
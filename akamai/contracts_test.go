@@ -0,0 +1,80 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const productSummariesFixture = `{
+	"products": {
+		"contractId": "ctr_%s",
+		"marketing-products": [
+			{
+				"marketingProductId": "M-LC-160976",
+				"marketingProductName": "Ion Standard",
+				"startDate": "2019-01-01T00:00:00Z",
+				"endDate": "2020-01-01T00:00:00Z"
+			},
+			{
+				"marketingProductId": "%s",
+				"marketingProductName": "%s"
+			}
+		]
+	}
+}`
+
+func TestTrimContractPrefix(t *testing.T) {
+	assert.Equal(t, "1-ABCDE", TrimContractPrefix("ctr_1-ABCDE"))
+	assert.Equal(t, "1-ABCDE", TrimContractPrefix("1-ABCDE"))
+}
+
+func TestContractsListContracts(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contract-api/v1/contracts/identifiers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `["ctr_1-ABCDE", "1-FGHIJ"]`)
+	})
+
+	ids, _, err := client.Contracts.ListContracts(context.Background())
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Equal(t, []string{"1-ABCDE", "1-FGHIJ"}, ids)
+}
+
+func TestContractsFindContractsWithProduct(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contract-api/v1/contracts/identifiers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `["1-AAAAA", "1-BBBBB", "1-CCCCC"]`)
+	})
+	mux.HandleFunc("/contract-api/v1/contracts/1-AAAAA/products/summaries", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, productSummariesFixture, "1-AAAAA", "M-LC-1", "Fast DNS")
+	})
+	mux.HandleFunc("/contract-api/v1/contracts/1-BBBBB/products/summaries", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, productSummariesFixture, "1-BBBBB", "M-LC-2", "Download Delivery")
+	})
+	mux.HandleFunc("/contract-api/v1/contracts/1-CCCCC/products/summaries", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	ids, err := client.Contracts.FindContractsWithProduct(context.Background(), "fast dns")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"1-AAAAA"}, ids)
+
+	ids, err = client.Contracts.FindContractsWithProduct(context.Background(), "M-LC-160976")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"1-AAAAA", "1-BBBBB"}, ids)
+}
@@ -0,0 +1,170 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// CopyOptions specifies the optional parameters to CopyZone.
+type CopyOptions struct {
+	// ContractID is the contract of the destination account the zone is created on if
+	// it doesn't exist there. It is required to create the zone.
+	ContractID string
+
+	// Comment is the comment of the zone created on the destination.
+	Comment string
+
+	// Rename copies the zone under this name: the origin is replaced by it at the end
+	// of the record names, and of the targets of CNAME and MX records.
+	Rename string
+}
+
+// CopyStatus is the outcome of the copy of a record set.
+type CopyStatus string
+
+// Outcomes of the copy of a record set.
+const (
+	// CopyCopied record sets were written to the destination zone.
+	CopyCopied CopyStatus = "copied"
+
+	// CopySkipped record sets are the SOA and apex NS ones, which the destination zone
+	// keeps its own of.
+	CopySkipped CopyStatus = "skipped"
+
+	// CopyFailed record sets were not written, as their Err tells.
+	CopyFailed CopyStatus = "failed"
+)
+
+// CopyRecordResult is the outcome of the copy of a source record set. Name is its name
+// in the destination zone.
+type CopyRecordResult struct {
+	Name   string
+	Type   string
+	Status CopyStatus
+	Err    error
+}
+
+// CopyResult describes the copy of a zone made by CopyZone.
+type CopyResult struct {
+	Zone string
+
+	// Created reports whether the zone was created on the destination.
+	Created bool
+
+	// RecordSets holds the outcome of every record set of the source zone, in the order
+	// they were listed.
+	RecordSets []*CopyRecordResult
+}
+
+// CopyZone copies a zone from the account of src to the one of dst, such as to clone a
+// production zone into a staging account. The zone is created on dst as a PRIMARY zone
+// if it doesn't exist there, and its record sets are then replaced at once with those
+// of the source, so record sets only found on dst are removed. The SOA and apex NS
+// record sets are not copied: the destination keeps its own.
+//
+// The result reports the outcome of every record set; if writing them fails, they are
+// all reported failed and the error is returned along with the result.
+func CopyZone(ctx context.Context, src, dst *Client, zone string, opt *CopyOptions) (*CopyResult, error) {
+	if opt == nil {
+		opt = &CopyOptions{}
+	}
+
+	origin, err := NormalizeZoneName(zone)
+	if err != nil {
+		return nil, err
+	}
+	target := origin
+	if opt.Rename != "" {
+		if target, err = NormalizeZoneName(opt.Rename); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := src.FastDNSv2.ListAllZoneRecordSets(ctx, origin, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CopyResult{Zone: target}
+	zm, _, err := dst.FastDNSv2.GetZone(ctx, target)
+	switch {
+	case isStatus(err, http.StatusNotFound):
+		if opt.ContractID == "" {
+			return nil, fmt.Errorf("zone %v does not exist on the destination, and no contract was given to create it", target)
+		}
+		zr := &ZoneCreateRequest{Zone: target, Type: "PRIMARY", Comment: opt.Comment}
+		if _, _, err := dst.FastDNSv2.CreateZone(ctx, opt.ContractID, zr); err != nil {
+			return nil, err
+		}
+		result.Created = true
+	case err != nil:
+		return nil, err
+	case !strings.EqualFold(zm.GetType(), "PRIMARY"):
+		return nil, fmt.Errorf("zone %v is a %v zone on the destination, records can only be copied to PRIMARY zones", target, zm.GetType())
+	}
+
+	var records []*RecordSetCreateRequest
+	for _, rs := range list {
+		r := &CopyRecordResult{Name: renameHost(rs.GetName(), origin, target), Type: strings.ToUpper(rs.GetType())}
+		result.RecordSets = append(result.RecordSets, r)
+		if isProtectedRecordSet(origin, rs) {
+			r.Status = CopySkipped
+			continue
+		}
+
+		c := rs.ToCreateRequest(target)
+		c.Name, c.Type = r.Name, r.Type
+		for i, d := range c.Rdata {
+			c.Rdata[i] = renameRdata(r.Type, d, origin, target)
+		}
+		records = append(records, c)
+		r.Status = CopyCopied
+	}
+
+	if err := replaceKeepingApex(ctx, dst.FastDNSv2, target, records); err != nil {
+		for _, r := range result.RecordSets {
+			if r.Status == CopyCopied {
+				r.Status, r.Err = CopyFailed, err
+			}
+		}
+		return result, err
+	}
+
+	return result, nil
+}
+
+// renameHost replaces the origin at the end of a host name by target. Names outside of
+// the origin, and all names if they are the same, are returned unchanged.
+func renameHost(host, origin, target string) string {
+	if origin == target {
+		return host
+	}
+
+	name := strings.TrimSuffix(host, ".")
+	dot := strings.TrimPrefix(host, name)
+	switch lower := strings.ToLower(name); {
+	case lower == origin:
+		return target + dot
+	case strings.HasSuffix(lower, "."+origin):
+		return name[:len(name)-len(origin)] + target + dot
+	}
+	return host
+}
+
+// renameRdata renames the target host of CNAME and MX rdata. The rdata of other types
+// is returned unchanged.
+func renameRdata(rtype, rdata, origin, target string) string {
+	switch rtype {
+	case RRTypeCname:
+		return renameHost(strings.TrimSpace(rdata), origin, target)
+	case RRTypeMx:
+		fields := strings.Fields(rdata)
+		if len(fields) != 2 {
+			return rdata
+		}
+		return fields[0] + " " + renameHost(fields[1], origin, target)
+	}
+	return rdata
+}
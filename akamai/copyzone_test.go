@@ -0,0 +1,109 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestCopyZone(t *testing.T) {
+	src, srcSrv := akamaitest.NewServer(t)
+	dst, dstSrv := akamaitest.NewServer(t)
+	ctx := context.Background()
+
+	srcSrv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	for _, rs := range []*akamai.RecordSetCreateRequest{
+		{Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+		{Name: "ftp.example.com", Type: "CNAME", TTL: akamai.Int(300), Rdata: []string{"www.example.com."}},
+		{Name: "cdn.example.com", Type: "CNAME", TTL: akamai.Int(300), Rdata: []string{"edge.example.net."}},
+		{Name: "example.com", Type: "MX", TTL: akamai.Int(300), Rdata: []string{"10 mail.example.com.", "20 mx.example.org."}},
+		{Name: "sub.example.com", Type: "NS", TTL: akamai.Int(300), Rdata: []string{"ns1.example.org."}},
+	} {
+		rs.Zone = "example.com"
+		if err := srcSrv.AddRecordSet(rs); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	}
+
+	_, err := akamai.CopyZone(ctx, src, dst, "example.com", nil)
+	assert.Error(t, err, "a missing zone can't be created without a contract")
+
+	result, err := akamai.CopyZone(ctx, src, dst, "example.com", &akamai.CopyOptions{ContractID: akamaitest.TestContractID, Rename: "staging.example.net"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, result.Created)
+	assert.Equal(t, "staging.example.net", result.Zone)
+	status := map[string]akamai.CopyStatus{}
+	for _, r := range result.RecordSets {
+		assert.NoError(t, r.Err)
+		status[r.Name+" "+r.Type] = r.Status
+	}
+	assert.Equal(t, map[string]akamai.CopyStatus{
+		"staging.example.net SOA":       akamai.CopySkipped,
+		"staging.example.net NS":        akamai.CopySkipped,
+		"staging.example.net MX":        akamai.CopyCopied,
+		"www.staging.example.net A":     akamai.CopyCopied,
+		"ftp.staging.example.net CNAME": akamai.CopyCopied,
+		"cdn.staging.example.net CNAME": akamai.CopyCopied,
+		"sub.staging.example.net NS":    akamai.CopyCopied,
+	}, status)
+
+	copied := map[string][]string{}
+	for _, rs := range dstSrv.RecordSets("staging.example.net") {
+		for _, d := range rs.Rdata {
+			copied[rs.GetName()+" "+rs.GetType()] = append(copied[rs.GetName()+" "+rs.GetType()], *d)
+		}
+	}
+	assert.Len(t, copied, 7)
+	assert.Equal(t, []string{"192.0.2.1"}, copied["www.staging.example.net A"])
+	assert.Equal(t, []string{"www.staging.example.net."}, copied["ftp.staging.example.net CNAME"])
+	assert.Equal(t, []string{"edge.example.net."}, copied["cdn.staging.example.net CNAME"])
+	assert.Equal(t, []string{"10 mail.staging.example.net.", "20 mx.example.org."}, copied["staging.example.net MX"])
+	assert.Equal(t, []string{"a1-1.akam.net. hostmaster.staging.example.net. 1 3600 600 604800 300"}, copied["staging.example.net SOA"])
+
+	// Copying again replaces the record sets only found on the destination.
+	dstSrv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "staging.example.net", Name: "extra.staging.example.net", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.9"}})
+	result, err = akamai.CopyZone(ctx, src, dst, "example.com", &akamai.CopyOptions{Rename: "staging.example.net"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.False(t, result.Created)
+	assert.Len(t, dstSrv.RecordSets("staging.example.net"), 7)
+
+	// A failed write is reported for every record set that was to be copied.
+	dstSrv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	dst.WithReadOnly(true)
+	result, err = akamai.CopyZone(ctx, src, dst, "example.com", nil)
+	assert.Error(t, err)
+	if assert.NotNil(t, result) {
+		for _, r := range result.RecordSets {
+			if r.Status != akamai.CopySkipped {
+				assert.Equal(t, akamai.CopyFailed, r.Status)
+				assert.Error(t, r.Err)
+			}
+		}
+	}
+}
+
+func TestCopyZoneToSecondary(t *testing.T) {
+	src, srcSrv := akamaitest.NewServer(t)
+	dst, dstSrv := akamaitest.NewServer(t)
+	srcSrv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	dstSrv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "SECONDARY", Masters: []string{"192.0.2.1"}})
+
+	_, err := akamai.CopyZone(context.Background(), src, dst, "example.com", nil)
+	assert.Error(t, err)
+	assert.NotContains(t, dstSrv.Requests(), "PUT /config-dns/v2/zones/example.com/recordsets")
+
+	_, err = akamai.CopyZone(context.Background(), src, dst, "missing.example", nil)
+	var aerr *akamai.AkamaiError
+	if assert.True(t, errors.As(err, &aerr)) {
+		assert.Equal(t, http.StatusNotFound, aerr.Status)
+	}
+}
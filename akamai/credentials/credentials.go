@@ -3,6 +3,7 @@ package credentials
 import (
 	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -20,6 +21,10 @@ type AuthValue struct {
 	// Akamai host
 	Host string
 
+	// Akamai account_key, the optional account switch key of the requests made with
+	// the credentials, for API clients that manage several accounts.
+	AccountKey string
+
 	// Provider used to get credentials
 	ProviderName string
 }
@@ -139,10 +144,11 @@ func (c *Credentials) ExpiresAt() (time.Time, error) {
 // provider's struct.
 //
 // Example:
-//     type AkamaiProvider struct {
-//         Expiry
-//         ...
-//     }
+//
+//	type AkamaiProvider struct {
+//	    Expiry
+//	    ...
+//	}
 type Expiry struct {
 	// The date/time when to expire on
 	expiration time.Time
@@ -189,3 +195,20 @@ type Expirer interface {
 	// The time at which the credentials are no longer valid
 	ExpiresAt() time.Time
 }
+
+// retrievedFlag records whether a provider has retrieved its credentials. It is read
+// and written atomically, as IsExpired may be called while Retrieve runs, such as when
+// the provider is shared by several Credentials.
+type retrievedFlag int32
+
+func (f *retrievedFlag) set(retrieved bool) {
+	var v int32
+	if retrieved {
+		v = 1
+	}
+	atomic.StoreInt32((*int32)(f), v)
+}
+
+func (f *retrievedFlag) get() bool {
+	return atomic.LoadInt32((*int32)(f)) == 1
+}
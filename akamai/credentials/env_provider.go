@@ -1,14 +1,13 @@
 // Package credentials supplies credentials to authenticate requests to the Akamai API.
 // Example of using the environment variable credentials.
 //
-//     creds := credentials.NewEnvCredentials()
-//
-//     // Retrieve the credentials value
-//     credValue, err := creds.Get()
-//     if err != nil {
-//         // handle error
-//     }
+//	creds := credentials.NewEnvCredentials()
 //
+//	// Retrieve the credentials value
+//	credValue, err := creds.Get()
+//	if err != nil {
+//	    // handle error
+//	}
 package credentials
 
 import (
@@ -40,7 +39,7 @@ var (
 // AKAMAI_CLIENT_TOKEN
 // AKAMAI_HOST
 type EnvProvider struct {
-	retrieved bool
+	retrieved retrievedFlag
 }
 
 // NewEnvCredentials returns a pointer to a new Credentials object
@@ -51,12 +50,12 @@ func NewEnvCredentials() *Credentials {
 
 // IsExpired returns if the credentials have been retrieved.
 func (e *EnvProvider) IsExpired() bool {
-	return !e.retrieved
+	return !e.retrieved.get()
 }
 
 // Retrieve retrieves the keys from the environment.
 func (e *EnvProvider) Retrieve() (AuthValue, error) {
-	e.retrieved = false
+	e.retrieved.set(false)
 
 	cs := os.Getenv("AKAMAI_CLIENT_SECRET")
 	if cs == "" {
@@ -79,7 +78,7 @@ func (e *EnvProvider) Retrieve() (AuthValue, error) {
 
 	}
 
-	e.retrieved = true
+	e.retrieved.set(true)
 	return AuthValue{
 		ClientSecret: cs,
 		ClientToken:  ct,
@@ -1,14 +1,13 @@
 // Package credentials supplies credentials to authenticate requests to the Akamai API.
 // Example of using the shared credentials provider to read from ~/.edgerc
 //
-//     creds := credentials.NewSharedCredentials()
-//
-//     // Retrieve the credentials value
-//     credValue, err := creds.Get()
-//     if err != nil {
-//         // handle error
-//     }
+//	creds := credentials.NewSharedCredentials()
 //
+//	// Retrieve the credentials value
+//	credValue, err := creds.Get()
+//	if err != nil {
+//	    // handle error
+//	}
 package credentials
 
 import (
@@ -50,6 +49,7 @@ var (
 // client_token
 // access_token
 // host
+// account_key (optional)
 type SharedCredentialsProvider struct {
 	// Path to the shared credentials file.
 	//
@@ -64,7 +64,7 @@ type SharedCredentialsProvider struct {
 	Profile string
 
 	// retrieved states if the credentials have been successfully retrieved.
-	retrieved bool
+	retrieved retrievedFlag
 }
 
 // NewSharedCredentials returns a pointer to a new Credentials object
@@ -79,7 +79,7 @@ func NewSharedCredentials(filename, profile string) *Credentials {
 // Retrieve reads and extracts the shared credentials from the current
 // users home directory.
 func (p *SharedCredentialsProvider) Retrieve() (AuthValue, error) {
-	p.retrieved = false
+	p.retrieved.set(false)
 
 	filename, err := p.filename()
 	if err != nil {
@@ -91,13 +91,13 @@ func (p *SharedCredentialsProvider) Retrieve() (AuthValue, error) {
 		return AuthValue{ProviderName: SharedCredsProviderName}, err
 	}
 
-	p.retrieved = true
+	p.retrieved.set(true)
 	return creds, nil
 }
 
 // IsExpired returns if the shared credentials have expired.
 func (p *SharedCredentialsProvider) IsExpired() bool {
-	return !p.retrieved
+	return !p.retrieved.get()
 }
 
 // loadProfiles loads from the file pointed to by shared credentials filename for profile.
@@ -139,6 +139,7 @@ func loadProfile(filename, profile string) (AuthValue, error) {
 		ClientToken:  ct.String(),
 		AccessToken:  at.String(),
 		Host:         h.String(),
+		AccountKey:   iniProfile.Key("account_key").String(),
 		ProviderName: SharedCredsProviderName,
 	}, nil
 }
@@ -153,8 +154,8 @@ func (p *SharedCredentialsProvider) filename() (string, error) {
 		return p.Filename, nil
 	}
 
-	if p.Filename = os.Getenv("AKAMAI_ENVRC_FILE"); len(p.Filename) != 0 {
-		return p.Filename, nil
+	if filename := os.Getenv("AKAMAI_ENVRC_FILE"); len(filename) != 0 {
+		return filename, nil
 	}
 
 	// try the default ~/.edgerc location
@@ -168,15 +169,17 @@ func (p *SharedCredentialsProvider) filename() (string, error) {
 
 // profile returns the Akamai shared credentials profile.  If empty will read
 // environment variable "AKAMAI_PROFILE". If that is not set profile will
-// return "default".
+// return "default". Like filename, it leaves p as is, so that Retrieve can be called
+// concurrently.
 func (p *SharedCredentialsProvider) profile() string {
-	if p.Profile == "" {
-		p.Profile = os.Getenv("AKAMAI_PROFILE")
+	profile := p.Profile
+	if profile == "" {
+		profile = os.Getenv("AKAMAI_PROFILE")
 	}
 
-	if p.Profile == "" {
-		p.Profile = "default"
+	if profile == "" {
+		profile = "default"
 	}
 
-	return p.Profile
+	return profile
 }
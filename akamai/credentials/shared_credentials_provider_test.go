@@ -2,6 +2,7 @@ package credentials
 
 import (
 	"os"
+	"sync"
 	"testing"
 )
 
@@ -82,3 +83,61 @@ func TestSharedCredentialsProviderWithoutHostFromProfile(t *testing.T) {
 		t.Errorf("Expect no host, %v", v)
 	}
 }
+
+func TestSharedCredentialsProviderAccountKey(t *testing.T) {
+	os.Clearenv()
+
+	p := SharedCredentialsProvider{Filename: "example_edgerc", Profile: "switch"}
+	creds, err := p.Retrieve()
+	if err != nil {
+		t.Errorf("expect nil, got %v", err)
+	}
+	if e, a := "1-ABCDE", creds.AccountKey; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	p = SharedCredentialsProvider{Filename: "example_edgerc", Profile: ""}
+	if creds, err = p.Retrieve(); err != nil {
+		t.Errorf("expect nil, got %v", err)
+	}
+	if a := creds.AccountKey; a != "" {
+		t.Errorf("expect no account key, got %v", a)
+	}
+}
+
+func TestSharedCredentialsProviderConcurrent(t *testing.T) {
+	os.Clearenv()
+
+	// The provider is shared, and used directly as well as through Credentials, from
+	// 50 goroutines; run with -race.
+	p := &SharedCredentialsProvider{Filename: "example_edgerc", Profile: ""}
+	creds := NewCredentials(p)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if i%2 == 0 {
+					if _, err := p.Retrieve(); err != nil {
+						t.Errorf("expect nil, got %v", err)
+					}
+					p.IsExpired()
+					continue
+				}
+				if j == 5 {
+					creds.Expire()
+				}
+				v, err := creds.Get()
+				if err != nil {
+					t.Errorf("expect nil, got %v", err)
+				}
+				if e, a := "akamaiHost", v.Host; e != a {
+					t.Errorf("expect %v, got %v", e, a)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -40,10 +40,11 @@ func (s *StaticProvider) Retrieve() (AuthValue, error) {
 		return AuthValue{ProviderName: StaticProviderName}, ErrStaticCredentialsEmpty
 	}
 
-	if len(s.AuthValue.ProviderName) == 0 {
-		s.AuthValue.ProviderName = StaticProviderName
+	creds := s.AuthValue
+	if len(creds.ProviderName) == 0 {
+		creds.ProviderName = StaticProviderName
 	}
-	return s.AuthValue, nil
+	return creds, nil
 }
 
 // IsExpired returns if the credentials are expired.
@@ -0,0 +1,185 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DataCache decorates a FastDNSv2API, memoizing the data discovery methods ListGroups,
+// ListContracts, GetAuthorities and GetRecordTypes for a TTL. The other methods are
+// passed through. It is safe for concurrent use; install it on a client so that every
+// goroutine using the client shares it:
+//
+//	client.FastDNSv2 = akamai.NewDataCache(client.FastDNSv2, 10*time.Minute)
+//
+// Concurrent calls that miss the cache make a single request. Errors are not cached.
+// The calls made for different accounts with WithAccountSwitchKey are cached apart.
+// The values returned from the cache are shared between callers and must not be
+// modified; the *Response returned with them is the one of the request that filled
+// the cache.
+type DataCache struct {
+	FastDNSv2API
+
+	cache *ttlCache
+}
+
+// ttlCache memoizes the results of requests for a TTL, by key. It is the store of
+// DataCache and of the zone types the client checks writes against.
+type ttlCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*dataCacheEntry
+}
+
+type dataCacheEntry struct {
+	done    chan struct{}
+	expires time.Time
+	value   interface{}
+	resp    *Response
+	err     error
+}
+
+var _ FastDNSv2API = (*DataCache)(nil)
+
+// NewDataCache returns a DataCache over api whose entries are kept for ttl.
+func NewDataCache(api FastDNSv2API, ttl time.Duration) *DataCache {
+	return &DataCache{
+		FastDNSv2API: api,
+		cache:        newTTLCache(ttl),
+	}
+}
+
+// lifecycleClient returns the client of the API c wraps, for the background components
+// reading zones with c to register with it.
+func (c *DataCache) lifecycleClient() *Client {
+	return lifecycleClientOf(c.FastDNSv2API)
+}
+
+// Invalidate empties the cache, so that the next calls make requests.
+func (c *DataCache) Invalidate() {
+	c.cache.invalidate()
+}
+
+// ListGroups implements FastDNSv2API from the cache.
+func (c *DataCache) ListGroups(ctx context.Context, opt *DataOptions) ([]*Group, *Response, error) {
+	v, resp, err := c.cache.get(ctx, accountCacheKey(ctx, "ListGroups "+dataOptionsKey(opt)), func() (interface{}, *Response, error) {
+		return c.FastDNSv2API.ListGroups(ctx, opt)
+	})
+	groups, _ := v.([]*Group)
+	return groups, resp, err
+}
+
+// ListContracts implements FastDNSv2API from the cache.
+func (c *DataCache) ListContracts(ctx context.Context, opt *DataOptions) ([]*Contract, *Response, error) {
+	v, resp, err := c.cache.get(ctx, accountCacheKey(ctx, "ListContracts "+dataOptionsKey(opt)), func() (interface{}, *Response, error) {
+		return c.FastDNSv2API.ListContracts(ctx, opt)
+	})
+	contracts, _ := v.([]*Contract)
+	return contracts, resp, err
+}
+
+// GetAuthorities implements FastDNSv2API from the cache.
+func (c *DataCache) GetAuthorities(ctx context.Context, contractIDs []string) ([]*ContractAuthorities, *Response, error) {
+	v, resp, err := c.cache.get(ctx, accountCacheKey(ctx, "GetAuthorities "+strings.Join(contractIDs, ",")), func() (interface{}, *Response, error) {
+		return c.FastDNSv2API.GetAuthorities(ctx, contractIDs)
+	})
+	authorities, _ := v.([]*ContractAuthorities)
+	return authorities, resp, err
+}
+
+// GetRecordTypes implements FastDNSv2API from the cache.
+func (c *DataCache) GetRecordTypes(ctx context.Context, zone string) ([]string, *Response, error) {
+	v, resp, err := c.cache.get(ctx, accountCacheKey(ctx, "GetRecordTypes "+zone), func() (interface{}, *Response, error) {
+		return c.FastDNSv2API.GetRecordTypes(ctx, zone)
+	})
+	types, _ := v.([]string)
+	return types, resp, err
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]*dataCacheEntry{},
+	}
+}
+
+// invalidate empties the cache.
+func (c *ttlCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]*dataCacheEntry{}
+}
+
+// set caches value for key, as if it had been fetched.
+func (c *ttlCache) set(key string, value interface{}) {
+	e := &dataCacheEntry{done: make(chan struct{}), expires: c.now().Add(c.ttl), value: value}
+	close(e.done)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = e
+}
+
+// forget drops the cached value of key.
+func (c *ttlCache) forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// get returns the cached value of key, calling fetch to fill the cache if the value
+// is missing or expired. Callers arriving while a fetch is in flight wait for it.
+func (c *ttlCache) get(ctx context.Context, key string, fetch func() (interface{}, *Response, error)) (interface{}, *Response, error) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok {
+		select {
+		case <-e.done:
+			if e.err == nil && c.now().Before(e.expires) {
+				c.mu.Unlock()
+				return e.value, e.resp, nil
+			}
+			ok = false
+		default:
+		}
+	}
+	if !ok {
+		e = &dataCacheEntry{done: make(chan struct{})}
+		c.entries[key] = e
+		c.mu.Unlock()
+
+		e.value, e.resp, e.err = fetch()
+		e.expires = c.now().Add(c.ttl)
+
+		c.mu.Lock()
+		if e.err != nil && c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		close(e.done)
+
+		return e.value, e.resp, e.err
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-e.done:
+		return e.value, e.resp, e.err
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+func dataOptionsKey(opt *DataOptions) string {
+	gid := 0
+	if opt != nil {
+		gid = opt.GroupID
+	}
+	return fmt.Sprintf("gid=%d", gid)
+}
@@ -0,0 +1,137 @@
+package akamai_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func countRequests(srv *akamaitest.Server, request string) int {
+	var n int
+	for _, r := range srv.Requests() {
+		if r == request {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDataCache(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	cache := akamai.NewDataCache(client.FastDNSv2, time.Hour)
+	client.FastDNSv2 = cache
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		groups, _, err := client.FastDNSv2.ListGroups(ctx, nil)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		if assert.Len(t, groups, 1) {
+			assert.Equal(t, "akamaitest", groups[0].GetGroupName())
+		}
+
+		contracts, _, err := client.FastDNSv2.ListContracts(ctx, nil)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		if assert.Len(t, contracts, 1) {
+			assert.Equal(t, akamaitest.TestContractID, contracts[0].GetContractID())
+		}
+
+		authorities, _, err := client.FastDNSv2.GetAuthorities(ctx, []string{akamaitest.TestContractID})
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		if assert.Len(t, authorities, 1) {
+			assert.Len(t, authorities[0].Authorities, 2)
+		}
+
+		types, _, err := client.FastDNSv2.GetRecordTypes(ctx, "example.com")
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.Contains(t, types, "TXT")
+	}
+
+	assert.Equal(t, 1, countRequests(srv, "GET /config-dns/v2/data/groups/"))
+	assert.Equal(t, 1, countRequests(srv, "GET /config-dns/v2/data/contracts"))
+	assert.Equal(t, 1, countRequests(srv, "GET /config-dns/v2/data/authorities"))
+	assert.Equal(t, 1, countRequests(srv, "GET /config-dns/v2/data/recordsets/types"))
+
+	// Different arguments are cached separately.
+	if _, _, err := client.FastDNSv2.ListContracts(ctx, &akamai.DataOptions{GroupID: 1}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 2, countRequests(srv, "GET /config-dns/v2/data/contracts"))
+
+	// The other methods are not cached.
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.FastDNSv2.GetZone(ctx, "example.com"); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	}
+	assert.Equal(t, 2, countRequests(srv, "GET /config-dns/v2/zones/example.com"))
+
+	cache.Invalidate()
+	if _, _, err := client.FastDNSv2.ListGroups(ctx, nil); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 2, countRequests(srv, "GET /config-dns/v2/data/groups/"))
+}
+
+func TestDataCacheExpiry(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	cache := akamai.NewDataCache(client.FastDNSv2, time.Nanosecond)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := cache.ListGroups(ctx, nil); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, 2, countRequests(srv, "GET /config-dns/v2/data/groups/"))
+}
+
+func TestDataCacheErrors(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	cache := akamai.NewDataCache(client.FastDNSv2, time.Hour)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, _, err := cache.GetRecordTypes(ctx, "example.com")
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 2, countRequests(srv, "GET /config-dns/v2/data/recordsets/types"), "errors must not be cached")
+
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	_, _, err := cache.GetRecordTypes(ctx, "example.com")
+	assert.NoError(t, err)
+}
+
+func TestDataCacheConcurrent(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	client.FastDNSv2 = akamai.NewDataCache(client.FastDNSv2, time.Hour)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			contracts, _, err := client.FastDNSv2.ListContracts(ctx, nil)
+			assert.NoError(t, err)
+			assert.Len(t, contracts, 1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, countRequests(srv, "GET /config-dns/v2/data/contracts"))
+}
@@ -0,0 +1,98 @@
+package akamai
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultTTLPolicy gives a TTL to the record sets written without one, whose TTL is
+// nil, by record type. See FastDNSv2Service.WithDefaultTTLPolicy.
+type DefaultTTLPolicy struct {
+	// ByType holds the TTL of each record type, such as "MX", in upper case.
+	// NewDefaultTTLPolicy and WithDefaultTTLPolicy upper-case the types of a policy.
+	ByType map[string]int
+
+	// Default is the TTL of the types ByType doesn't hold. If zero, their record sets
+	// are left without a TTL, and fail with ErrInvalidTTL.
+	Default int
+}
+
+// NewDefaultTTLPolicy returns a DefaultTTLPolicy with the TTLs of byType, whose record
+// types may be in any case, and the given default. Of the types that differ only in
+// case, the one in upper case wins, and otherwise the first in sort order.
+func NewDefaultTTLPolicy(byType map[string]int, def int) *DefaultTTLPolicy {
+	types := make([]string, 0, len(byType))
+	for t := range byType {
+		types = append(types, t)
+	}
+	// In reverse sort order, the types in upper case come last, and are set last.
+	sort.Sort(sort.Reverse(sort.StringSlice(types)))
+
+	p := &DefaultTTLPolicy{ByType: make(map[string]int, len(byType)), Default: def}
+	for _, t := range types {
+		p.ByType[strings.ToUpper(t)] = byType[t]
+	}
+	return p
+}
+
+// RecommendedDefaultTTLs returns a DefaultTTLPolicy with common TTLs: 300 seconds for
+// the A, AAAA and CNAME record sets, which move with the hosts they point to, an hour
+// for MX and TXT, and a day for NS. It has no Default.
+func RecommendedDefaultTTLs() *DefaultTTLPolicy {
+	return &DefaultTTLPolicy{
+		ByType: map[string]int{
+			"A":     300,
+			"AAAA":  300,
+			"CNAME": 300,
+			"MX":    3600,
+			"TXT":   3600,
+			"NS":    86400,
+		},
+	}
+}
+
+// TTL returns the TTL of the record sets of the given type, in any case, written without
+// one, or zero if the policy has none for it.
+func (p *DefaultTTLPolicy) TTL(recordType string) int {
+	if p == nil {
+		return 0
+	}
+	if ttl, ok := p.ByType[strings.ToUpper(recordType)]; ok {
+		return ttl
+	}
+	return p.Default
+}
+
+// WithDefaultTTLPolicy sets the TTLs given to the record sets written with s without
+// one, by CreateRecordSet, UpdateRecordSet, ReplaceRecordSets, PlanRecordSets and
+// SyncRecordSets. A TTL set on a record set always wins. The defaults are applied
+// before the record set is checked against the policies of WithRecordPolicy, such as
+// TTLBoundsPolicy, which see the TTL the record set is written with. The changes of a
+// plan whose TTL was defaulted are marked with TTLDefaulted. A nil policy leaves the
+// record sets without a TTL to fail with ErrInvalidTTL, as they do by default. The
+// policy is copied as NewDefaultTTLPolicy does, so later changes to p don't apply. See
+// Client.FastDNSv2Service to configure the service of a client.
+//
+// WithDefaultTTLPolicy must not be called while s is in use; it returns s so that calls
+// can be chained.
+func (s *FastDNSv2Service) WithDefaultTTLPolicy(p *DefaultTTLPolicy) *FastDNSv2Service {
+	s.defaultTTLPolicy = nil
+	if p != nil {
+		s.defaultTTLPolicy = NewDefaultTTLPolicy(p.ByType, p.Default)
+	}
+	return s
+}
+
+// applyDefaultTTL sets the TTL of rs, if it has none, to the default of s for its type,
+// and reports whether it did.
+func (s *FastDNSv2Service) applyDefaultTTL(rs *RecordSetCreateRequest) bool {
+	if rs.TTL != nil {
+		return false
+	}
+	ttl := s.defaultTTLPolicy.TTL(rs.Type)
+	if ttl == 0 {
+		return false
+	}
+	rs.TTL = Int(ttl)
+	return true
+}
@@ -0,0 +1,171 @@
+package akamai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+func TestDefaultTTLPolicy(t *testing.T) {
+	p := akamai.NewDefaultTTLPolicy(map[string]int{"A": 300, "mx": 3600}, 1800)
+	assert.Equal(t, map[string]int{"A": 300, "MX": 3600}, p.ByType)
+	assert.Equal(t, 300, p.TTL("A"))
+	assert.Equal(t, 3600, p.TTL("MX"))
+	assert.Equal(t, 300, p.TTL("a"))
+	assert.Equal(t, 1800, p.TTL("SRV"))
+
+	// Of the types that differ only in case, the one in upper case wins, however the
+	// map is iterated.
+	for i := 0; i < 20; i++ {
+		p := akamai.NewDefaultTTLPolicy(map[string]int{"mx": 60, "MX": 3600, "Mx": 120, "txt": 300, "Txt": 600}, 0)
+		assert.Equal(t, 3600, p.TTL("mx"))
+		assert.Equal(t, 600, p.TTL("TXT"))
+	}
+
+	var none *akamai.DefaultTTLPolicy
+	assert.Equal(t, 0, none.TTL("A"))
+
+	rec := akamai.RecommendedDefaultTTLs()
+	for rtype, ttl := range map[string]int{"A": 300, "AAAA": 300, "CNAME": 300, "MX": 3600, "TXT": 3600, "NS": 86400, "SRV": 0} {
+		assert.Equal(t, ttl, rec.TTL(rtype), rtype)
+	}
+}
+
+// TestDefaultTTLDataCache checks that the default TTLs of the service of a client apply
+// whether its FastDNSv2 is wrapped in a DataCache before or after they are set.
+func TestDefaultTTLDataCache(t *testing.T) {
+	for _, cacheFirst := range []bool{true, false} {
+		client, srv := newSyncTestServer(t)
+		if cacheFirst {
+			client.FastDNSv2 = akamai.NewDataCache(client.FastDNSv2, time.Minute)
+		}
+		client.FastDNSv2Service().WithDefaultTTLPolicy(akamai.RecommendedDefaultTTLs())
+		if !cacheFirst {
+			client.FastDNSv2 = akamai.NewDataCache(client.FastDNSv2, time.Minute)
+		}
+
+		_, _, err := client.FastDNSv2.CreateRecordSet(context.Background(), &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "api.example.com", Type: "A", Rdata: []string{"192.0.2.20"}})
+		if err != nil {
+			t.Fatalf("cache first %v: expect nil, got %v", cacheFirst, err)
+		}
+		for _, rs := range srv.RecordSets("example.com") {
+			if rs.GetName() == "api.example.com" {
+				assert.Equal(t, 300, rs.GetTTL(), "cache first %v", cacheFirst)
+			}
+		}
+	}
+}
+
+func TestDefaultTTLWrites(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+	ctx := context.Background()
+	ttlOf := func(name, rtype string) int {
+		for _, rs := range srv.RecordSets("example.com") {
+			if rs.GetName() == name && rs.GetType() == rtype {
+				return rs.GetTTL()
+			}
+		}
+		return -1
+	}
+
+	// Without a policy, a record set without a TTL is invalid.
+	_, _, err := client.FastDNSv2.CreateRecordSet(ctx, &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "api.example.com", Type: "A", Rdata: []string{"192.0.2.20"}})
+	assert.True(t, errors.Is(err, akamai.ErrInvalidTTL), "got %v", err)
+
+	// The types of a policy built as a literal are upper-cased too.
+	client.FastDNSv2Service().WithDefaultTTLPolicy(&akamai.DefaultTTLPolicy{ByType: map[string]int{"a": 300, "TXT": 3600}, Default: 1800})
+
+	// The type's default, then the global one, and an explicit TTL over both.
+	for _, rs := range []*akamai.RecordSetCreateRequest{
+		{Zone: "example.com", Name: "api.example.com", Type: "A", Rdata: []string{"192.0.2.20"}},
+		{Zone: "example.com", Name: "_sip._tcp.example.com", Type: "SRV", Rdata: []string{"10 60 5060 sip.example.com."}},
+		{Zone: "example.com", Name: "example.com", Type: "TXT", TTL: akamai.Int(60), Rdata: []string{"\"v=spf1 -all\""}},
+	} {
+		ttl := rs.TTL
+		if _, _, err := client.FastDNSv2.CreateRecordSet(ctx, rs); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.Equal(t, ttl, rs.TTL, "the request given is not modified")
+	}
+	assert.Equal(t, 300, ttlOf("api.example.com", "A"))
+	assert.Equal(t, 1800, ttlOf("_sip._tcp.example.com", "SRV"))
+	assert.Equal(t, 60, ttlOf("example.com", "TXT"))
+
+	_, _, err = client.FastDNSv2.UpdateRecordSet(ctx, &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "example.com", Type: "TXT", Rdata: []string{"\"v=spf1 mx -all\""}})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 3600, ttlOf("example.com", "TXT"))
+
+	var all []*akamai.RecordSetCreateRequest
+	for _, rs := range srv.RecordSets("example.com") {
+		all = append(all, rs.ToCreateRequest("example.com"))
+	}
+	all = append(all, &akamai.RecordSetCreateRequest{Name: "cdn.example.com", Type: "A", Rdata: []string{"192.0.2.30"}})
+	if _, err := client.FastDNSv2.ReplaceRecordSets(ctx, "example.com", all); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 300, ttlOf("cdn.example.com", "A"))
+	assert.Equal(t, 1800, ttlOf("_sip._tcp.example.com", "SRV"))
+
+	// The policies see the defaulted TTL.
+	client.FastDNSv2Service().WithRecordPolicy(akamai.TTLBoundsPolicy(600, 0))
+	_, _, err = client.FastDNSv2.CreateRecordSet(ctx, &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "low.example.com", Type: "A", Rdata: []string{"192.0.2.40"}})
+	assert.True(t, errors.Is(err, akamai.ErrPolicyViolation), "got %v", err)
+	_, _, err = client.FastDNSv2.CreateRecordSet(ctx, &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "high.example.com", Type: "A", TTL: akamai.Int(900), Rdata: []string{"192.0.2.40"}})
+	assert.NoError(t, err)
+}
+
+func TestPlanRecordSetsDefaultTTL(t *testing.T) {
+	client, _ := newSyncTestServer(t)
+	client.FastDNSv2Service().WithDefaultTTLPolicy(akamai.RecommendedDefaultTTLs())
+	ctx := context.Background()
+
+	desired := []*akamai.RecordSetCreateRequest{
+		{Name: "www.example.com", Type: "A", Rdata: []string{"192.0.2.1", "192.0.2.2"}},
+		{Name: "mail.example.com", Type: "A", TTL: akamai.Int(600), Rdata: []string{"192.0.2.10"}},
+		{Name: "old.example.com", Type: "CNAME", Rdata: []string{"mail.example.com."}},
+		{Name: "example.com", Type: "MX", Rdata: []string{"10 mail.example.com."}},
+	}
+	plan, err := client.FastDNSv2.PlanRecordSets(ctx, "example.com", desired, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// www matches its current TTL once defaulted; the others are changes.
+	assert.Equal(t, 1, plan.Unchanged)
+	want := map[string]struct {
+		ttl       int
+		defaulted bool
+	}{
+		"example.com MX":        {3600, true},
+		"mail.example.com A":    {600, false},
+		"old.example.com CNAME": {300, true},
+	}
+	if assert.Len(t, plan.Changes, len(want)) {
+		for _, c := range plan.Changes {
+			w := want[c.Name+" "+c.Type]
+			assert.Equal(t, w.ttl, c.Desired.GetTTL(), c.Name)
+			assert.Equal(t, w.defaulted, c.TTLDefaulted, c.Name)
+		}
+	}
+
+	b, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, string(b), `"ttlDefaulted":true`)
+
+	// The TTL policy hook checks the TTL the plan would write.
+	client.FastDNSv2Service().WithRecordPolicy(akamai.TTLBoundsPolicy(0, 1800))
+	_, err = client.FastDNSv2.PlanRecordSets(ctx, "example.com", desired, nil)
+	var pv *akamai.PolicyViolationError
+	if assert.True(t, errors.As(err, &pv), "got %v", err) {
+		assert.Equal(t, "MX", pv.Type)
+	}
+}
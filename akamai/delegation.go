@@ -0,0 +1,230 @@
+package akamai
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DelegationStatus classifies the delegation of a zone in a DelegationReport.
+type DelegationStatus string
+
+// Statuses of the zones of a DelegationReport.
+const (
+	// Delegated zones are only delegated to the Akamai name servers of their contract.
+	Delegated DelegationStatus = "delegated"
+
+	// PartiallyDelegated zones are delegated to Akamai name servers along with others.
+	PartiallyDelegated DelegationStatus = "partially-delegated"
+
+	// NotDelegated zones are delegated to no Akamai name server, or to none at all.
+	NotDelegated DelegationStatus = "not-delegated"
+
+	// DelegationUnknown zones could not be classified, as their expected authorities
+	// or their delegation could not be looked up.
+	DelegationUnknown DelegationStatus = "unknown"
+)
+
+// ZoneDelegation is the delegation of a zone in a DelegationReport.
+type ZoneDelegation struct {
+	Zone   string
+	Status DelegationStatus
+
+	// Expected are the Akamai name servers the zone should be delegated to, those of
+	// its contract.
+	Expected []string
+
+	// NameServers are the name servers the zone is delegated to, as looked up.
+	NameServers []string
+
+	// Offending are the NameServers that are not among the Expected ones.
+	Offending []string
+
+	// Err is why the status of the zone is DelegationUnknown.
+	Err error
+}
+
+// DelegationReport holds the delegation of zones across a portfolio, in the order they
+// were given to FastDNSv2Service.DelegationReport.
+type DelegationReport struct {
+	Zones []*ZoneDelegation
+}
+
+// Count returns the number of zones of the report with the given status.
+func (r *DelegationReport) Count(status DelegationStatus) int {
+	n := 0
+	for _, z := range r.Zones {
+		if z.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+type delegationReportJSON struct {
+	Counts map[DelegationStatus]int `json:"counts"`
+	Zones  []*zoneDelegationJSON    `json:"zones"`
+}
+
+type zoneDelegationJSON struct {
+	Zone        string           `json:"zone"`
+	Status      DelegationStatus `json:"status"`
+	Expected    []string         `json:"expected,omitempty"`
+	NameServers []string         `json:"nameServers,omitempty"`
+	Offending   []string         `json:"offending,omitempty"`
+	Error       string           `json:"error,omitempty"`
+}
+
+// MarshalJSON serializes the report with the number of zones of each status. The error
+// of a zone of unknown status is included as its message.
+func (r *DelegationReport) MarshalJSON() ([]byte, error) {
+	out := &delegationReportJSON{Counts: map[DelegationStatus]int{}, Zones: []*zoneDelegationJSON{}}
+	for _, z := range r.Zones {
+		out.Counts[z.Status]++
+		zj := &zoneDelegationJSON{Zone: z.Zone, Status: z.Status, Expected: z.Expected, NameServers: z.NameServers, Offending: z.Offending}
+		if z.Err != nil {
+			zj.Error = z.Err.Error()
+		}
+		out.Zones = append(out.Zones, zj)
+	}
+	return json.Marshal(out)
+}
+
+// WriteCSV writes the report to w as CSV, with a header and a row per zone. The name
+// servers of a zone are separated by spaces within their column.
+func (r *DelegationReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"zone", "status", "expected", "name_servers", "offending", "error"})
+	for _, z := range r.Zones {
+		errMsg := ""
+		if z.Err != nil {
+			errMsg = z.Err.Error()
+		}
+		cw.Write([]string{z.Zone, string(z.Status), strings.Join(z.Expected, " "), strings.Join(z.NameServers, " "), strings.Join(z.Offending, " "), errMsg})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// DelegationReport classifies the delegation of zones, such as to find out which of a
+// domain portfolio are actually served by Akamai. For each zone, the Akamai name
+// servers of its contract, from GetAuthorities, are compared with those the zone is
+// delegated to, as looked up with r; a nil r uses net.DefaultResolver. To check the
+// delegation at the parent zone rather than the zone's own NS records, give a resolver
+// that queries the name servers of the parent.
+//
+// The zones are looked up with up to concurrency at once, 4 if it is not positive. A
+// zone whose authorities or delegation can't be looked up is reported with the
+// DelegationUnknown status and its error, and doesn't stop the others; the error
+// returned is that of ctx, if it is done before the report is complete.
+func (s *FastDNSv2Service) DelegationReport(ctx context.Context, zones []string, concurrency int, r NSResolver) (*DelegationReport, error) {
+	if r == nil {
+		r = net.DefaultResolver
+	}
+
+	authorities := &contractAuthorities{contracts: map[string]*contractAuthoritiesEntry{}}
+	results := RunBulk(ctx, zones, &BulkOptions{Concurrency: concurrency}, func(ctx context.Context, zone string) (*ZoneDelegation, error) {
+		return s.zoneDelegation(ctx, zone, r, authorities), nil
+	})
+
+	report := &DelegationReport{}
+	for i, res := range results {
+		d := res.Value
+		if d == nil {
+			d = &ZoneDelegation{Zone: zones[i], Status: DelegationUnknown, Err: res.Err}
+		}
+		report.Zones = append(report.Zones, d)
+	}
+	return report, ctx.Err()
+}
+
+// zoneDelegation classifies the delegation of a zone.
+func (s *FastDNSv2Service) zoneDelegation(ctx context.Context, zone string, r NSResolver, authorities *contractAuthorities) *ZoneDelegation {
+	d := &ZoneDelegation{Zone: zone, Status: DelegationUnknown}
+
+	z, _, err := s.GetZone(ctx, zone)
+	if err != nil {
+		d.Err = err
+		return d
+	}
+	if d.Expected, err = authorities.get(ctx, s, z.GetContractID()); err != nil {
+		d.Err = err
+		return d
+	}
+
+	found, err := r.LookupNS(ctx, zone)
+	if err != nil {
+		d.Err = err
+		return d
+	}
+
+	expected := map[string]bool{}
+	for _, ns := range d.Expected {
+		expected[ns] = true
+	}
+	onAkamai := 0
+	for _, ns := range found {
+		host := normalizeHost(ns.Host)
+		d.NameServers = append(d.NameServers, host)
+		if expected[host] {
+			onAkamai++
+		} else {
+			d.Offending = append(d.Offending, host)
+		}
+	}
+	sort.Strings(d.NameServers)
+	sort.Strings(d.Offending)
+
+	switch {
+	case onAkamai == 0:
+		d.Status = NotDelegated
+	case len(d.Offending) > 0:
+		d.Status = PartiallyDelegated
+	default:
+		d.Status = Delegated
+	}
+	return d
+}
+
+// contractAuthorities caches the Akamai name servers of contracts for a
+// DelegationReport, so that each contract is only looked up once.
+type contractAuthorities struct {
+	mu        sync.Mutex
+	contracts map[string]*contractAuthoritiesEntry
+}
+
+type contractAuthoritiesEntry struct {
+	once sync.Once
+	ns   []string
+	err  error
+}
+
+// get returns the normalized, sorted name servers of a contract.
+func (c *contractAuthorities) get(ctx context.Context, s *FastDNSv2Service, contractID string) ([]string, error) {
+	c.mu.Lock()
+	e, ok := c.contracts[contractID]
+	if !ok {
+		e = &contractAuthoritiesEntry{}
+		c.contracts[contractID] = e
+	}
+	c.mu.Unlock()
+
+	e.once.Do(func() {
+		var list []*ContractAuthorities
+		if list, _, e.err = s.GetAuthorities(ctx, []string{contractID}); e.err != nil {
+			return
+		}
+		for _, ca := range list {
+			for _, ns := range ca.Authorities {
+				e.ns = append(e.ns, normalizeHost(StringValue(ns)))
+			}
+		}
+		sort.Strings(e.ns)
+	})
+	return e.ns, e.err
+}
@@ -0,0 +1,100 @@
+package akamai_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestDelegationReport(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	zones := []string{"full.example", "partial.example", "none.example", "broken.example", "unknown.example"}
+	for _, z := range zones[:4] {
+		srv.AddZone(&akamai.ZoneCreateRequest{Zone: z, Type: "PRIMARY"})
+	}
+	resolver := staticResolver{
+		"full.example":    {"A2-2.akam.net.", "a1-1.akam.net"},
+		"partial.example": {"a1-1.akam.net.", "ns1.example.net."},
+		"none.example":    {"ns2.example.net.", "ns1.example.net."},
+	}
+
+	report, err := client.FastDNSv2.DelegationReport(context.Background(), zones, 3, resolver)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if !assert.Len(t, report.Zones, len(zones)) {
+		return
+	}
+	for i, z := range report.Zones {
+		assert.Equal(t, zones[i], z.Zone)
+	}
+
+	full, partial, none, broken, unknown := report.Zones[0], report.Zones[1], report.Zones[2], report.Zones[3], report.Zones[4]
+	assert.Equal(t, akamai.Delegated, full.Status)
+	assert.Equal(t, []string{"a1-1.akam.net", "a2-2.akam.net"}, full.NameServers)
+	assert.Empty(t, full.Offending)
+
+	assert.Equal(t, akamai.PartiallyDelegated, partial.Status)
+	assert.Equal(t, []string{"ns1.example.net"}, partial.Offending)
+
+	assert.Equal(t, akamai.NotDelegated, none.Status)
+	assert.Equal(t, []string{"ns1.example.net", "ns2.example.net"}, none.Offending)
+	assert.Equal(t, []string{"a1-1.akam.net", "a2-2.akam.net"}, none.Expected)
+
+	// A failed lookup doesn't stop the others.
+	assert.Equal(t, akamai.DelegationUnknown, broken.Status)
+	var dnsErr *net.DNSError
+	assert.True(t, errors.As(broken.Err, &dnsErr), "got %v", broken.Err)
+	assert.Equal(t, akamai.DelegationUnknown, unknown.Status)
+	assert.Error(t, unknown.Err)
+
+	assert.Equal(t, 1, report.Count(akamai.Delegated))
+	assert.Equal(t, 2, report.Count(akamai.DelegationUnknown))
+
+	// The authorities of the contract are only looked up once.
+	authorities := 0
+	for _, r := range srv.Requests() {
+		if r == "GET /config-dns/v2/data/authorities" {
+			authorities++
+		}
+	}
+	assert.Equal(t, 1, authorities)
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	var decoded struct {
+		Counts map[string]int `json:"counts"`
+		Zones  []struct {
+			Zone      string   `json:"zone"`
+			Status    string   `json:"status"`
+			Offending []string `json:"offending"`
+			Error     string   `json:"error"`
+		} `json:"zones"`
+	}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, map[string]int{"delegated": 1, "partially-delegated": 1, "not-delegated": 1, "unknown": 2}, decoded.Counts)
+	assert.Equal(t, "partially-delegated", decoded.Zones[1].Status)
+	assert.Equal(t, []string{"ns1.example.net"}, decoded.Zones[1].Offending)
+	assert.Contains(t, decoded.Zones[3].Error, "no such host")
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if assert.Len(t, lines, 6) {
+		assert.Equal(t, "zone,status,expected,name_servers,offending,error", string(lines[0]))
+		assert.Equal(t, "partial.example,partially-delegated,a1-1.akam.net a2-2.akam.net,a1-1.akam.net ns1.example.net,ns1.example.net,", string(lines[2]))
+	}
+}
@@ -0,0 +1,119 @@
+package akamai
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Deprecation is what the API said about the deprecation of an endpoint in the headers
+// of a response.
+type Deprecation struct {
+	// Method and Path identify the endpoint, such as "GET" and
+	// "/config-dns/v2/zones/example.com".
+	Method string
+	Path   string
+
+	// Deprecated reports whether the response had a Deprecation header, either set to
+	// true or to the date of the deprecation.
+	Deprecated bool
+
+	// Sunset is the time after which the endpoint may stop answering, from the Sunset
+	// header. It is zero if the header is missing or invalid.
+	Sunset time.Time
+
+	// Warning holds the texts of the Warning headers of the response, joined with "; ".
+	Warning string
+}
+
+func (d *Deprecation) any() bool {
+	return d.Deprecated || !d.Sunset.IsZero() || d.Warning != ""
+}
+
+// parseDeprecation returns what the headers h say about the deprecation of the
+// endpoint.
+func parseDeprecation(h http.Header) Deprecation {
+	var d Deprecation
+
+	// The Deprecation header is "true" in earlier drafts of RFC 9745, and the date of
+	// the deprecation in later ones, either as an HTTP date or as "@" followed by a Unix
+	// time.
+	if v := strings.TrimSpace(h.Get("Deprecation")); v != "" && !strings.EqualFold(v, "false") {
+		d.Deprecated = true
+	}
+	if v := h.Get("Sunset"); v != "" {
+		d.Sunset, _ = http.ParseTime(v)
+	}
+	if vs := h.Values("Warning"); len(vs) > 0 {
+		texts := make([]string, len(vs))
+		for i, v := range vs {
+			texts[i] = warningText(v)
+		}
+		d.Warning = strings.Join(texts, "; ")
+	}
+	return d
+}
+
+// warningText returns the warn-text of a Warning header value of RFC 7234, such as
+// `299 - "Deprecated API"`, or the value itself if it isn't of that form.
+func warningText(v string) string {
+	start := strings.IndexByte(v, '"')
+	if start < 0 {
+		return strings.TrimSpace(v)
+	}
+	end := strings.IndexByte(v[start+1:], '"')
+	if end < 0 {
+		return strings.TrimSpace(v)
+	}
+	return v[start+1 : start+1+end]
+}
+
+// WithDeprecationHook makes the client call hook the first time a response of an
+// endpoint has a Deprecation, Sunset or Warning header, so that deprecations are caught
+// before the endpoint disappears, such as by logging them. Later responses of the same
+// endpoint, by method and path, are only counted. See DeprecatedEndpoints. The headers
+// of every response are parsed into its Response whether or not a hook is set.
+//
+// hook may be called from several goroutines at once. WithDeprecationHook must not be
+// called while the client is in use; it returns c so that calls can be chained.
+func (c *Client) WithDeprecationHook(hook func(d *Deprecation)) *Client {
+	c.deprecationHook = hook
+	return c
+}
+
+// DeprecatedEndpoints returns how many responses of each endpoint, by method and path
+// such as "GET /config-dns/v2/zones", reported a deprecation, such as to feed metrics.
+func (c *Client) DeprecatedEndpoints() map[string]int {
+	c.deprecationsMu.Lock()
+	defer c.deprecationsMu.Unlock()
+
+	counts := make(map[string]int, len(c.deprecations))
+	for k, n := range c.deprecations {
+		counts[k] = n
+	}
+	return counts
+}
+
+// trackDeprecation records the deprecation headers of resp, the response to req, in
+// response, and counts them against their endpoint, calling the hook for the first.
+func (c *Client) trackDeprecation(req *http.Request, resp *http.Response, response *Response) {
+	d := parseDeprecation(resp.Header)
+	if !d.any() {
+		return
+	}
+	response.Deprecated, response.Sunset, response.Warning = d.Deprecated, d.Sunset, d.Warning
+
+	key := req.Method + " " + req.URL.Path
+	c.deprecationsMu.Lock()
+	if c.deprecations == nil {
+		c.deprecations = map[string]int{}
+	}
+	c.deprecations[key]++
+	first := c.deprecations[key] == 1
+	c.deprecationsMu.Unlock()
+
+	if first && c.deprecationHook != nil {
+		d.Method, d.Path = req.Method, req.URL.Path
+		c.deprecationHook(&d)
+	}
+}
@@ -0,0 +1,91 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDeprecation(t *testing.T) {
+	sunset := time.Date(2027, time.March, 31, 23, 59, 59, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name   string
+		header http.Header
+		want   Deprecation
+	}{
+		{"none", http.Header{}, Deprecation{}},
+		{"true", http.Header{"Deprecation": {"true"}}, Deprecation{Deprecated: true}},
+		{"false", http.Header{"Deprecation": {"false"}}, Deprecation{}},
+		{"structured date", http.Header{"Deprecation": {"@1788220799"}}, Deprecation{Deprecated: true}},
+		{"HTTP date", http.Header{"Deprecation": {"Sun, 11 Nov 2026 23:59:59 GMT"}}, Deprecation{Deprecated: true}},
+		{"sunset", http.Header{"Sunset": {"Wed, 31 Mar 2027 23:59:59 GMT"}}, Deprecation{Sunset: sunset}},
+		{"invalid sunset", http.Header{"Sunset": {"next spring"}}, Deprecation{}},
+		{"warning", http.Header{"Warning": {`299 - "Deprecated API"`}}, Deprecation{Warning: "Deprecated API"}},
+		{"warning with date", http.Header{"Warning": {`299 akamai.com "Use v3" "Wed, 31 Mar 2027 23:59:59 GMT"`}}, Deprecation{Warning: "Use v3"}},
+		{"warnings", http.Header{"Warning": {`299 - "Deprecated API"`, "see the docs"}}, Deprecation{Warning: "Deprecated API; see the docs"}},
+		{"all", http.Header{"Deprecation": {"true"}, "Sunset": {"Wed, 31 Mar 2027 23:59:59 GMT"}, "Warning": {`299 - "Deprecated API"`}}, Deprecation{Deprecated: true, Sunset: sunset, Warning: "Deprecated API"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseDeprecation(tt.header))
+		})
+	}
+}
+
+func TestDeprecationHook(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", "Wed, 31 Mar 2027 23:59:59 GMT")
+		w.Header().Set("Warning", `299 - "Deprecated API"`)
+		fmt.Fprint(w, `{"zones": []}`)
+	})
+	mux.HandleFunc("/config-dns/v2/zones/example.com", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"zone": "example.com"}`)
+	})
+
+	var mu sync.Mutex
+	var hooked []*Deprecation
+	client.WithDeprecationHook(func(d *Deprecation) {
+		mu.Lock()
+		hooked = append(hooked, d)
+		mu.Unlock()
+	})
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, resp, err := client.FastDNSv2.ListZones(ctx, nil)
+			if assert.NoError(t, err) {
+				assert.True(t, resp.Deprecated)
+				assert.Equal(t, 2027, resp.Sunset.Year())
+				assert.Equal(t, "Deprecated API", resp.Warning)
+			}
+		}()
+	}
+	wg.Wait()
+
+	_, resp, err := client.FastDNSv2.GetZone(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.False(t, resp.Deprecated)
+	assert.True(t, resp.Sunset.IsZero())
+
+	if assert.Len(t, hooked, 1, "the hook is called once per endpoint") {
+		assert.Equal(t, "GET", hooked[0].Method)
+		assert.Equal(t, "/config-dns/v2/zones", hooked[0].Path)
+		assert.True(t, hooked[0].Deprecated)
+	}
+	assert.Equal(t, map[string]int{"GET /config-dns/v2/zones": 5}, client.DeprecatedEndpoints())
+}
@@ -0,0 +1,220 @@
+package akamai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// EdgeKVService handles communication with the EdgeKV (v1) related endpoints
+// of the Akamai API.
+type EdgeKVService service
+
+// Networks an EdgeKV namespace lives on.
+const (
+	EdgeKVNetworkStaging    = "staging"
+	EdgeKVNetworkProduction = "production"
+)
+
+// EdgeKVStoreStatus holds the initialization status of the account's EdgeKV store.
+type EdgeKVStoreStatus struct {
+	AccountStatus    *string         `json:"accountStatus,omitempty"`
+	CPCode           *FlexibleString `json:"cpcode,omitempty"`
+	ProductionStatus *string         `json:"productionStatus,omitempty"`
+	StagingStatus    *string         `json:"stagingStatus,omitempty"`
+}
+
+// EdgeKVNamespace is a namespace of the EdgeKV store.
+type EdgeKVNamespace struct {
+	Namespace          *string      `json:"namespace,omitempty"`
+	RetentionInSeconds *int         `json:"retentionInSeconds,omitempty"`
+	GeoLocation        *string      `json:"geoLocation,omitempty"`
+	GroupID            *FlexibleInt `json:"groupId,omitempty"`
+}
+
+// EdgeKVNamespaceList holds the response from ListNamespaces.
+type EdgeKVNamespaceList struct {
+	Namespaces []*EdgeKVNamespace `json:"namespaces,omitempty"`
+}
+
+// EdgeKVItemOptions specifies the item the EdgeKV item methods act on.
+type EdgeKVItemOptions struct {
+	Network   string
+	Namespace string
+	Group     string
+	Item      string
+}
+
+// EdgeKVAccessTokenRequest specifies the parameters for the CreateAccessToken method.
+type EdgeKVAccessTokenRequest struct {
+	Name                 string              `json:"name"`
+	AllowOnStaging       bool                `json:"allowOnStaging"`
+	AllowOnProduction    bool                `json:"allowOnProduction"`
+	Expiry               string              `json:"expiry"`
+	NamespacePermissions map[string][]string `json:"namespacePermissions"`
+}
+
+// EdgeKVAccessToken is a token EdgeWorkers use to access EdgeKV namespaces.
+type EdgeKVAccessToken struct {
+	Name   *string `json:"name,omitempty"`
+	UUID   *string `json:"uuid,omitempty"`
+	Expiry *string `json:"expiry,omitempty"`
+	Value  *string `json:"value,omitempty"`
+}
+
+// InitializeStore initializes the EdgeKV store of the account. It is safe to call on an
+// account that is already initialized.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#postinitialize
+func (s *EdgeKVService) InitializeStore(ctx context.Context) (*EdgeKVStoreStatus, *Response, error) {
+	req, err := s.client.NewRequest("POST", "edgekv/v1/initialize", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := new(EdgeKVStoreStatus)
+	resp, err := s.client.Do(WithOperation(ctx, "InitializeStore"), req, status)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return status, resp, nil
+}
+
+// ListNamespaces lists the namespaces on the given network, with their details.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#getnamespaces
+func (s *EdgeKVService) ListNamespaces(ctx context.Context, network string) (*EdgeKVNamespaceList, *Response, error) {
+	u := fmt.Sprintf("edgekv/v1/networks/%v/namespaces?details=on", network)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	namespaces := new(EdgeKVNamespaceList)
+	resp, err := s.client.Do(WithOperation(ctx, "ListNamespaces"), req, namespaces)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return namespaces, resp, nil
+}
+
+// CreateNamespace creates a namespace on the given network. The retention and geo-location
+// cannot be changed once the namespace exists.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#postnamespaces
+func (s *EdgeKVService) CreateNamespace(ctx context.Context, network string, ns *EdgeKVNamespace) (*EdgeKVNamespace, *Response, error) {
+	u := fmt.Sprintf("edgekv/v1/networks/%v/namespaces", network)
+
+	req, err := s.client.NewRequest("POST", u, ns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := new(EdgeKVNamespace)
+	resp, err := s.client.Do(WithOperation(ctx, "CreateNamespace"), req, created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return created, resp, nil
+}
+
+func (opt *EdgeKVItemOptions) path() string {
+	return fmt.Sprintf("edgekv/v1/networks/%v/namespaces/%v/groups/%v/items/%v", opt.Network, opt.Namespace, opt.Group, opt.Item)
+}
+
+// GetItem retrieves the value of an item. Values are returned exactly as stored, so
+// JSON values are returned still encoded.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#getitem
+func (s *EdgeKVService) GetItem(ctx context.Context, opt *EdgeKVItemOptions) (string, *Response, error) {
+	req, err := s.client.NewRequest("GET", opt.path(), nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var value bytes.Buffer
+	resp, err := s.client.Do(WithOperation(ctx, "GetItem"), req, &value)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return value.String(), resp, nil
+}
+
+// UpsertItem creates or replaces the value of an item. A string value is stored as plain
+// text; any other value is stored as its JSON encoding. The returned string is the
+// confirmation message of the API.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#putitem
+func (s *EdgeKVService) UpsertItem(ctx context.Context, opt *EdgeKVItemOptions, value interface{}) (string, *Response, error) {
+	var body []byte
+	var contentType string
+
+	switch v := value.(type) {
+	case string:
+		body = []byte(v)
+		contentType = "text/plain"
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", nil, err
+		}
+		body = b
+		contentType = "application/json"
+	}
+
+	req, err := s.client.newRequest("PUT", opt.path(), body, contentType)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var msg string
+	resp, err := s.client.Do(WithOperation(ctx, "UpsertItem"), req, &msg)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return msg, resp, nil
+}
+
+// DeleteItem marks an item for deletion. The returned string is the confirmation
+// message of the API.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#deleteitem
+func (s *EdgeKVService) DeleteItem(ctx context.Context, opt *EdgeKVItemOptions) (string, *Response, error) {
+	req, err := s.client.NewRequest("DELETE", opt.path(), nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var msg string
+	resp, err := s.client.Do(WithOperation(ctx, "DeleteItem"), req, &msg)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return msg, resp, nil
+}
+
+// CreateAccessToken creates a token EdgeWorkers can use to access EdgeKV namespaces.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#posttokens
+func (s *EdgeKVService) CreateAccessToken(ctx context.Context, token *EdgeKVAccessTokenRequest) (*EdgeKVAccessToken, *Response, error) {
+	req, err := s.client.NewRequest("POST", "edgekv/v1/tokens", token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t := new(EdgeKVAccessToken)
+	resp, err := s.client.Do(WithOperation(ctx, "CreateAccessToken"), req, t)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return t, resp, nil
+}
@@ -0,0 +1,163 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEdgeKVNamespaces(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/edgekv/v1/networks/staging/namespaces", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			assert.Equal(t, "on", r.URL.Query().Get("details"))
+			fmt.Fprint(w, `{"namespaces": [{"namespace": "default", "retentionInSeconds": 0, "geoLocation": "US", "groupId": 0}]}`)
+		case "POST":
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"namespace": "marketing", "retentionInSeconds": 86400, "geoLocation": "EU"}`, string(body))
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, string(body))
+		}
+	})
+
+	ctx := context.Background()
+	list, _, err := client.EdgeKV.ListNamespaces(ctx, EdgeKVNetworkStaging)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, list.Namespaces, 1) {
+		assert.Equal(t, "default", *list.Namespaces[0].Namespace)
+	}
+
+	name, retention, geo := "marketing", 86400, "EU"
+	ns, _, err := client.EdgeKV.CreateNamespace(ctx, EdgeKVNetworkStaging, &EdgeKVNamespace{
+		Namespace:          &name,
+		RetentionInSeconds: &retention,
+		GeoLocation:        &geo,
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "EU", *ns.GeoLocation)
+}
+
+func TestEdgeKVTextItem(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var stored string
+	mux.HandleFunc("/edgekv/v1/networks/production/namespaces/default/groups/countries/items/US", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+			body, _ := ioutil.ReadAll(r.Body)
+			stored = string(body)
+			w.Header().Set("Content-Type", "text/plain;charset=UTF-8")
+			fmt.Fprint(w, "Item was upserted in KV store with database 123456, namespace default, group countries, and item US.")
+		case "GET":
+			w.Header().Set("Content-Type", "text/plain;charset=UTF-8")
+			fmt.Fprint(w, stored)
+		case "DELETE":
+			w.Header().Set("Content-Type", "text/plain;charset=UTF-8")
+			fmt.Fprint(w, "Item was marked for deletion from database, namespace default, group countries, and item US.")
+		}
+	})
+
+	ctx := context.Background()
+	opt := &EdgeKVItemOptions{Network: EdgeKVNetworkProduction, Namespace: "default", Group: "countries", Item: "US"}
+
+	msg, _, err := client.EdgeKV.UpsertItem(ctx, opt, "United States")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, msg, "Item was upserted")
+	assert.Equal(t, "United States", stored)
+
+	value, _, err := client.EdgeKV.GetItem(ctx, opt)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "United States", value)
+
+	msg, _, err = client.EdgeKV.DeleteItem(ctx, opt)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, msg, "marked for deletion")
+}
+
+func TestEdgeKVJSONItem(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var stored string
+	mux.HandleFunc("/edgekv/v1/networks/staging/namespaces/default/groups/config/items/flags", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			body, _ := ioutil.ReadAll(r.Body)
+			stored = string(body)
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, "Item was upserted in KV store with database 123456, namespace default, group config, and item flags.")
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, stored)
+		}
+	})
+
+	ctx := context.Background()
+	opt := &EdgeKVItemOptions{Network: EdgeKVNetworkStaging, Namespace: "default", Group: "config", Item: "flags"}
+
+	_, _, err := client.EdgeKV.UpsertItem(ctx, opt, map[string]bool{"beta": true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	value, _, err := client.EdgeKV.GetItem(ctx, opt)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.JSONEq(t, `{"beta": true}`, value)
+}
+
+func TestEdgeKVInitializeAndToken(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/edgekv/v1/initialize", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"accountStatus": "INITIALIZED", "cpcode": "123456", "productionStatus": "INITIALIZED", "stagingStatus": "INITIALIZED"}`)
+	})
+	mux.HandleFunc("/edgekv/v1/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"name": "ew-token", "allowOnStaging": true, "allowOnProduction": false, "expiry": "2020-12-31", "namespacePermissions": {"default": ["r", "w"]}}`, string(body))
+		fmt.Fprint(w, `{"name": "ew-token", "uuid": "5b7b0a32", "expiry": "2020-12-31", "value": "eyJ0eXAi"}`)
+	})
+
+	ctx := context.Background()
+	status, _, err := client.EdgeKV.InitializeStore(ctx)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "INITIALIZED", *status.AccountStatus)
+
+	token, _, err := client.EdgeKV.CreateAccessToken(ctx, &EdgeKVAccessTokenRequest{
+		Name:                 "ew-token",
+		AllowOnStaging:       true,
+		Expiry:               "2020-12-31",
+		NamespacePermissions: map[string][]string{"default": {"r", "w"}},
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "eyJ0eXAi", *token.Value)
+}
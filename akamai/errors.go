@@ -0,0 +1,72 @@
+package akamai
+
+import (
+	"errors"
+	"strings"
+)
+
+// OperationError is the error returned by FastDNSv2Service methods. It records the
+// operation that failed and what it was applied to, and wraps the underlying error,
+// such as an *AkamaiError, which errors.As still finds.
+type OperationError struct {
+	// Op is the name of the method that failed, such as "CreateRecordSet".
+	Op string
+
+	// Zone, Name and Type identify what the operation was applied to. They are empty
+	// when they do not apply to the operation.
+	Zone string
+	Name string
+	Type string
+
+	// RequestID is the identifier the API gave to the failed request, if it sent one.
+	RequestID string
+
+	Err error
+}
+
+func (e *OperationError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Op)
+	if e.Name != "" {
+		b.WriteString(" " + e.Name)
+	}
+	if e.Type != "" {
+		b.WriteString(" " + e.Type)
+	}
+	if e.Zone != "" {
+		b.WriteString(" (zone " + e.Zone + ")")
+	}
+	b.WriteString(": " + e.Err.Error())
+	if e.RequestID != "" {
+		b.WriteString(" (request " + e.RequestID + ")")
+	}
+	return b.String()
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+// OperationFromError returns the operation context of an error returned by a
+// FastDNSv2Service method, for structured logging. It reports false if err carries no
+// operation context.
+func OperationFromError(err error) (*OperationError, bool) {
+	var oe *OperationError
+	if errors.As(err, &oe) {
+		return oe, true
+	}
+	return nil, false
+}
+
+// wrapOp adds the context of an operation to err. A nil err stays nil.
+func wrapOp(op, zone, name, rtype string, err error) error {
+	if err == nil {
+		return nil
+	}
+	oe := &OperationError{Op: op, Zone: zone, Name: name, Type: rtype, Err: err}
+	var ae *AkamaiError
+	if errors.As(err, &ae) {
+		oe.RequestID = ae.RequestID
+	}
+	return oe
+}
@@ -0,0 +1,61 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestOperationErrors(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	ctx := context.Background()
+
+	_, _, err := client.FastDNSv2.CreateRecordSet(ctx, &akamai.RecordSetCreateRequest{
+		Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.2"},
+	})
+	if assert.Error(t, err) {
+		assert.True(t, strings.HasPrefix(err.Error(), "CreateRecordSet www.example.com A (zone example.com): HTTP Status: 409."), err.Error())
+
+		op, ok := akamai.OperationFromError(err)
+		if assert.True(t, ok) {
+			assert.Equal(t, "CreateRecordSet", op.Op)
+			assert.Equal(t, "example.com", op.Zone)
+			assert.Equal(t, "www.example.com", op.Name)
+			assert.Equal(t, "A", op.Type)
+			assert.Equal(t, "req-3", op.RequestID)
+		}
+
+		var aerr *akamai.AkamaiError
+		if assert.True(t, errors.As(err, &aerr)) {
+			assert.Equal(t, http.StatusConflict, aerr.Status)
+		}
+	}
+
+	_, _, err = client.FastDNSv2.GetZoneRecordSets(ctx, "missing.com", nil)
+	if assert.Error(t, err) {
+		assert.True(t, strings.HasPrefix(err.Error(), "GetZoneRecordSets (zone missing.com): HTTP Status: 404."), err.Error())
+	}
+
+	// The context is kept when the error is wrapped again, as the sync helpers do.
+	wrapped := fmt.Errorf("sync failed: %w", err)
+	op, ok := akamai.OperationFromError(wrapped)
+	if assert.True(t, ok) {
+		assert.Equal(t, "GetZoneRecordSets", op.Op)
+		assert.Equal(t, "", op.Name)
+	}
+
+	_, err = client.FastDNSv2.DeleteRecordSet(ctx, &akamai.RecordSetOptions{Zone: "example.com", Name: "www.example.com", Type: "A"})
+	assert.NoError(t, err)
+
+	_, ok = akamai.OperationFromError(errors.New("plain"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,86 @@
+package akamai_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func ExampleFastDNSv2Service_ListZones() {
+	client, srv := akamaitest.NewExampleServer()
+	defer srv.Close()
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.net", Type: "SECONDARY", Masters: []string{"192.0.2.53"}})
+
+	zones, _, err := client.FastDNSv2.ListZones(context.Background(), &akamai.ZoneListOptions{ShowAll: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, z := range zones.Zones {
+		fmt.Println(z.GetZone(), z.GetType(), z.GetActivationState())
+	}
+	// Output:
+	// example.com PRIMARY ACTIVE
+	// example.net SECONDARY ACTIVE
+}
+
+func ExampleFastDNSv2Service_CreateRecordSet() {
+	client, srv := akamaitest.NewExampleServer()
+	defer srv.Close()
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	_, _, err := client.FastDNSv2.CreateRecordSet(ctx, &akamai.RecordSetCreateRequest{
+		Zone:  "example.com",
+		Name:  "www.example.com",
+		Type:  "A",
+		TTL:   akamai.Int(300),
+		Rdata: []string{"192.0.2.1", "192.0.2.2"},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rs, _, err := client.FastDNSv2.GetRecordSet(ctx, &akamai.RecordSetOptions{Zone: "example.com", Name: "www.example.com", Type: "A"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(rs.GetName(), rs.GetTTL(), akamai.StringValue(rs.Rdata[0]), akamai.StringValue(rs.Rdata[1]))
+	// Output:
+	// www.example.com 300 192.0.2.1 192.0.2.2
+}
+
+func ExampleFastDNSv2Service_SubmitChangeList() {
+	client, srv := akamaitest.NewExampleServer()
+	defer srv.Close()
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	// A change list starts from the current version of the zone.
+	cl, _, err := client.FastDNSv2.CreateChangeList(ctx, &akamai.ChangeListOptions{Zone: "example.com"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("change list of", cl.Zone, "stale:", cl.Stale)
+
+	records, _, err := client.FastDNSv2.GetChangeListRecordSets(ctx, "example.com", nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, rs := range records.Recordsets {
+		fmt.Println(rs.GetName(), rs.GetType())
+	}
+
+	if _, err := client.FastDNSv2.SubmitChangeList(ctx, "example.com"); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("submitted")
+	// Output:
+	// change list of example.com stale: false
+	// example.com NS
+	// example.com SOA
+	// submitted
+}
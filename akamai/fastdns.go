@@ -2,31 +2,53 @@ package akamai
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // FastDNSv2Service handles communication with the v2 FastDNS (beta) related endpoints
 // of the Akamai API
-type FastDNSv2Service service
+type FastDNSv2Service struct {
+	client *Client
+
+	// recordPolicies are the policies added with WithRecordPolicy.
+	recordPolicies []RecordPolicy
+
+	// rdataLimits is set with WithRdataLimits.
+	rdataLimits *RdataLimits
+
+	// defaultTTLPolicy is set with WithDefaultTTLPolicy.
+	defaultTTLPolicy *DefaultTTLPolicy
+}
 
 // Zone represents an Akamai zone from the v2 FastDNS API.
 type Zone struct {
-	ContractID         *string   `json:"contractId,omitempty"`
-	Zone               *string   `json:"zone,omitempty"`
-	Type               *string   `json:"type,omitempty"`
-	Comment            *string   `json:"comment,omitempty"`
-	EndCustomerID      *string   `json:"endCustomerId,omitempty"`
-	Target             *string   `json:"target,omitempty"`
-	TSIGKey            *tsigKey  `json:"tsigKey,omitempty"`
-	Masters            []*string `json:"masters,omitempty"`
-	VersionID          *string   `json:"versionId,omitempty"`
-	LastModifiedDate   *string   `json:"lastModifiedDate,omitempty"`
-	LastModifiedBy     *string   `json:"lastModifiedBy,omitempty"`
-	LastActivationDate *string   `json:"lastActivationDate,omitempty"`
-	ActivationState    *string   `json:"activationState,omitempty"`
-}
-
-type tsigKey struct {
+	ContractID         *string          `json:"contractId,omitempty"`
+	GroupID            *int             `json:"groupId,omitempty"`
+	Zone               *string          `json:"zone,omitempty"`
+	Type               *string          `json:"type,omitempty"`
+	Comment            *string          `json:"comment,omitempty"`
+	EndCustomerID      *string          `json:"endCustomerId,omitempty"`
+	Target             *string          `json:"target,omitempty"`
+	TSIGKey            *TSIGKey         `json:"tsigKey,omitempty"`
+	Masters            []*string        `json:"masters,omitempty"`
+	AliasCount         *int             `json:"aliasCount,omitempty"`
+	SignAndServe       *bool            `json:"signAndServe,omitempty"`
+	SignAndServeAlgo   *string          `json:"signAndServeAlgorithm,omitempty"`
+	VersionID          *string          `json:"versionId,omitempty"`
+	LastModifiedDate   *string          `json:"lastModifiedDate,omitempty"`
+	LastModifiedBy     *string          `json:"lastModifiedBy,omitempty"`
+	LastActivationDate *string          `json:"lastActivationDate,omitempty"`
+	ActivationState    *ActivationState `json:"activationState,omitempty"`
+}
+
+// TSIGKey is the key used to authenticate zone transfers of SECONDARY zones. Its
+// String method and Redact mask the secret.
+type TSIGKey struct {
 	Name      *string `json:"name,omitempty"`
 	Algorithm *string `json:"algorithm,omitempty"`
 	Secret    *string `json:"secret,omitempty"`
@@ -35,13 +57,29 @@ type tsigKey struct {
 // ZoneListOptions specifies optional parameters to the FastDNSv2Service.ListZones method.
 type ZoneListOptions struct {
 	ContractIDs string `url:"contractIds,omitempty"`
-	Page        int    `url:"page,omitempty"`
-	PageSize    int    `url:"pageSize,omitempty"`
-	Search      string `url:"search,omitempty"`
-	ShowAll     bool   `url:"showAll,omitempty"`
-	SortBy      string `url:"sortBy,omitempty"`
-	Types       string `url:"types,omitempty"`
-	GroupID     int    `url:"gid,omitempty"`
+
+	// Page and PageSize are left out of the request when nil. Pages count from 1, but
+	// a zero Page or PageSize is sent when set, for the API to reject it.
+	Page     *int `url:"page,omitempty"`
+	PageSize *int `url:"pageSize,omitempty"`
+
+	Search  string `url:"search,omitempty"`
+	ShowAll bool   `url:"showAll,omitempty"`
+	SortBy  string `url:"sortBy,omitempty"`
+	Types   string `url:"types,omitempty"`
+	GroupID int    `url:"gid,omitempty"`
+}
+
+// SetPage sets the Page of o. It returns o so that calls can be chained.
+func (o *ZoneListOptions) SetPage(page int) *ZoneListOptions {
+	o.Page = Int(page)
+	return o
+}
+
+// SetPageSize sets the PageSize of o. It returns o so that calls can be chained.
+func (o *ZoneListOptions) SetPageSize(pageSize int) *ZoneListOptions {
+	o.PageSize = Int(pageSize)
+	return o
 }
 
 // ZoneCreateOptions specifies the optional parameters to the FastDNSV2Service.CreateZone method.
@@ -53,17 +91,30 @@ type ZoneDeleteOptions struct {
 	Force bool `url:"force,omitempty"`
 }
 
-//ZoneCreateRequest specifies the parameters for the CreateZone method.
+// ZoneCreateRequest specifies the parameters for the CreateZone method.
 type ZoneCreateRequest struct {
-	Zone             string   `json:"zone,omitempty"`
-	Type             string   `json:"type,omitempty"`
-	Comment          string   `json:"comment,omitempty"`
-	EndCustomerID    string   `json:"endCustomerId,omitempty"`
-	Target           string   `json:"target,omitempty"`
-	TSIGKey          string   `json:"tsigKey,omitempty"`
-	Masters          []string `json:"masters,omitempty"`
-	SignAndServe     bool     `json:"signAndServe"`
-	SignAndServeAlgo string   `json:"signAndServeAlgorithm,omitempty"`
+	Zone          string   `json:"zone,omitempty"`
+	Type          string   `json:"type,omitempty"`
+	Comment       string   `json:"comment,omitempty"`
+	EndCustomerID string   `json:"endCustomerId,omitempty"`
+	Target        string   `json:"target,omitempty"`
+	TSIGKey       string   `json:"tsigKey,omitempty"`
+	Masters       []string `json:"masters,omitempty"`
+
+	// SignAndServe enables or disables DNSSEC for the zone. It is left out of the
+	// request when nil, so that an update keeps the zone's setting; it used to be a
+	// bool sent as false when unset, which disabled DNSSEC on updates. Callers that
+	// relied on that must now set it to false explicitly, with SetSignAndServe or
+	// Bool(false).
+	SignAndServe     *bool  `json:"signAndServe,omitempty"`
+	SignAndServeAlgo string `json:"signAndServeAlgorithm,omitempty"`
+}
+
+// SetSignAndServe sets whether DNSSEC is enabled for the zone, and returns z so that
+// calls can be chained.
+func (z *ZoneCreateRequest) SetSignAndServe(enabled bool) *ZoneCreateRequest {
+	z.SignAndServe = Bool(enabled)
+	return z
 }
 
 // ZoneList holds a response from ListZones
@@ -74,7 +125,7 @@ type ZoneList struct {
 
 // ZoneListMetadata holds metadata from the ZoneList response
 type ZoneListMetadata struct {
-	ContractIDs   []*string `json:"contractId,omitempty"`
+	ContractIDs   []*string `json:"contractIds,omitempty"`
 	Page          *int      `json:"page,omitempty"`
 	PageSize      *int      `json:"pageSize,omitempty"`
 	ShowAll       *bool     `json:"showAll,omitempty"`
@@ -83,39 +134,53 @@ type ZoneListMetadata struct {
 
 // ZoneMetadata holds the response from GetZone
 type ZoneMetadata struct {
-	ContractID            *string `json:"contractId,omitempty"`
-	Zone                  *string `json:"zone,omitempty"`
-	Type                  *string `json:"type,omitempty"`
-	AliasCount            *int    `json:"aliasCount,omitempty"`
-	SignAndServe          *bool   `json:"signAndServe,omitempty"`
-	SignAndServeAlgorithm *string `json:"signAndServeAlgorithm,omitempty"`
-	VersionId             *string `json:"versionId,omitempty"`
-	LastModifiedDate      *string `json:"lastModifiedDate,omitempty"`
-	LastModifiedBy        *string `json:"lastModifiedBy,omitempty"`
-	LastActivationDate    *string `json:"lastActivationDate,omitempty"`
-	ActivationState       *string `json:"activationState,omitempty"`
-	Comment               *string `json:"comment,omitempty"`
+	ContractID            *string          `json:"contractId,omitempty"`
+	GroupID               *int             `json:"groupId,omitempty"`
+	Zone                  *string          `json:"zone,omitempty"`
+	Type                  *string          `json:"type,omitempty"`
+	EndCustomerID         *string          `json:"endCustomerId,omitempty"`
+	Target                *string          `json:"target,omitempty"`
+	TSIGKey               *TSIGKey         `json:"tsigKey,omitempty"`
+	Masters               []*string        `json:"masters,omitempty"`
+	AliasCount            *int             `json:"aliasCount,omitempty"`
+	SignAndServe          *bool            `json:"signAndServe,omitempty"`
+	SignAndServeAlgorithm *string          `json:"signAndServeAlgorithm,omitempty"`
+	VersionId             *string          `json:"versionId,omitempty"`
+	LastModifiedDate      *string          `json:"lastModifiedDate,omitempty"`
+	LastModifiedBy        *string          `json:"lastModifiedBy,omitempty"`
+	LastActivationDate    *string          `json:"lastActivationDate,omitempty"`
+	ActivationState       *ActivationState `json:"activationState,omitempty"`
+	Comment               *string          `json:"comment,omitempty"`
 }
 
 // ListZones retreives the zones for the authenticated user.
 //
 // Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getzones
 func (s *FastDNSv2Service) ListZones(ctx context.Context, opt *ZoneListOptions) (*ZoneList, *Response, error) {
+	if opt != nil {
+		if err := validateSearch(opt.Search); err != nil {
+			return nil, nil, wrapOp("ListZones", "", "", "", err)
+		}
+		if err := validatePaging(opt.ShowAll, opt.Page != nil || opt.PageSize != nil); err != nil {
+			return nil, nil, wrapOp("ListZones", "", "", "", err)
+		}
+	}
+
 	u := fmt.Sprintf("config-dns/v2/zones")
 	u, err := addOptions(u, opt)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("ListZones", "", "", "", err)
 	}
 
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("ListZones", "", "", "", err)
 	}
 
 	var zones *ZoneList
-	resp, err := s.client.Do(ctx, req, &zones)
+	resp, err := s.client.Do(WithOperation(ctx, "ListZones"), req, &zones)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("ListZones", "", "", "", err)
 	}
 
 	return zones, resp, nil
@@ -125,46 +190,81 @@ func (s *FastDNSv2Service) ListZones(ctx context.Context, opt *ZoneListOptions)
 //
 // Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getzone
 func (s *FastDNSv2Service) GetZone(ctx context.Context, zone string) (*ZoneMetadata, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp("GetZone", zone, "", "", err)
+	}
+
 	u := fmt.Sprintf("config-dns/v2/zones/%v", zone)
 
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("GetZone", zone, "", "", err)
 	}
 
 	var zmeta *ZoneMetadata
-	resp, err := s.client.Do(ctx, req, &zmeta)
+	resp, err := s.client.Do(WithOperation(ctx, "GetZone"), req, &zmeta)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("GetZone", zone, "", "", err)
 	}
+	s.rememberZoneType(zmeta.GetZone(), zmeta.GetType())
 
 	return zmeta, resp, nil
 }
 
-// CreateZone creates a new Zone
+// WaitForZoneActive polls a zone every interval until its activation state is ACTIVE,
+// and returns its metadata. It stops with an error matching ErrZoneActivationFailed if
+// the zone goes to the ZoneError state.
+func (s *FastDNSv2Service) WaitForZoneActive(ctx context.Context, zone string, interval time.Duration) (*ZoneMetadata, error) {
+	return clientPoll(ctx, s.client, "WaitForZoneActive", pollEvery(interval), func(zm *ZoneMetadata) string {
+		return string(zm.GetActivationState())
+	}, func(ctx context.Context) (*ZoneMetadata, bool, error) {
+		zm, _, err := s.GetZone(ctx, zone)
+		if err != nil {
+			return nil, false, err
+		}
+		state := zm.GetActivationState()
+		if state.IsError() {
+			return zm, false, fmt.Errorf("%w: zone %v is in state %v", ErrZoneActivationFailed, zm.GetZone(), state)
+		}
+		return zm, state == ZoneActive, nil
+	})
+}
+
+// CreateZone creates a new Zone. The Location of the response is the URL of the created
+// zone, when the API gives it.
 //
 // Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#postzones
 func (s *FastDNSv2Service) CreateZone(ctx context.Context, cid string, zone *ZoneCreateRequest) (*Zone, *Response, error) {
+	zone, err := s.zoneRequest(zone)
+	if err != nil {
+		return nil, nil, wrapOp("CreateZone", zone.Zone, "", "", err)
+	}
+	if zone != nil {
+		zone.Comment = auditComment(ctx, zone.Comment)
+	}
+
 	u := fmt.Sprintf("config-dns/v2/zones")
 	lo := ZoneCreateOptions{
 		ContractID: cid,
 	}
 
-	u, err := addOptions(u, lo)
+	u, err = addOptions(u, lo)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("CreateZone", zone.Zone, "", "", err)
 	}
 
 	req, err := s.client.NewRequest("POST", u, zone)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("CreateZone", zone.Zone, "", "", err)
 	}
 
 	z := new(Zone)
-	resp, err := s.client.Do(ctx, req, &z)
+	resp, err := s.client.Do(WithOperation(ctx, "CreateZone"), req, &z)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("CreateZone", zone.Zone, "", "", err)
 	}
+	s.rememberZoneType(z.GetZone(), z.GetType())
 
 	return z, resp, nil
 }
@@ -173,17 +273,25 @@ func (s *FastDNSv2Service) CreateZone(ctx context.Context, cid string, zone *Zon
 //
 // Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#putzone
 func (s *FastDNSv2Service) UpdateZone(ctx context.Context, zone *ZoneCreateRequest) (*Zone, *Response, error) {
+	zone, err := s.zoneRequest(zone)
+	if err != nil {
+		return nil, nil, wrapOp("UpdateZone", zone.Zone, "", "", err)
+	}
+	if err := s.checkZoneProtected(zone.Zone); err != nil {
+		return nil, nil, wrapOp("UpdateZone", zone.Zone, "", "", err)
+	}
+
 	u := fmt.Sprintf("config-dns/v2/zones/%v", zone.Zone)
 	req, err := s.client.NewRequest("PUT", u, zone)
 
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("UpdateZone", zone.Zone, "", "", err)
 	}
 
 	z := new(Zone)
-	resp, err := s.client.Do(ctx, req, z)
+	resp, err := s.client.Do(WithOperation(ctx, "UpdateZone"), req, z)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("UpdateZone", zone.Zone, "", "", err)
 	}
 
 	return z, resp, nil
@@ -201,7 +309,61 @@ type ZoneDeleteResponse struct {
 	ZonesSubmitted *int    `json:"zonesSubmitted,omitempty"`
 	SuccessCount   *int    `json:"successCount,omitempty"`
 	FailureCount   *int    `json:"failureCount,omitempty"`
-	IsComplete     *bool   `json:"isComplete"`
+	IsComplete     *bool   `json:"isComplete,omitempty"`
+}
+
+// status describes the progress of the DeleteZone request, for PollStats.
+func (z *ZoneDeleteResponse) status() string {
+	if z.Done() {
+		return "complete"
+	}
+	return fmt.Sprintf("%d/%d processed", z.processed(), z.GetZonesSubmitted())
+}
+
+// Done reports whether the DeleteZone request is complete. Without an isComplete field,
+// it is complete once every submitted zone is counted as a success or a failure.
+func (z *ZoneDeleteResponse) Done() bool {
+	if z == nil {
+		return false
+	}
+	if z.IsComplete != nil {
+		return *z.IsComplete
+	}
+	return z.ZonesSubmitted != nil && z.processed() >= *z.ZonesSubmitted
+}
+
+// Progress returns the fraction of the submitted zones that have been processed,
+// between 0 and 1. It is 1 once the request is done, and 0 if the counts are missing.
+func (z *ZoneDeleteResponse) Progress() float64 {
+	if z.Done() {
+		return 1
+	}
+	if z == nil || z.GetZonesSubmitted() <= 0 {
+		return 0
+	}
+
+	p := float64(z.processed()) / float64(*z.ZonesSubmitted)
+	if p > 1 {
+		p = 1
+	}
+	return p
+}
+
+// Remaining returns the number of submitted zones that have not been processed yet, or
+// 0 if the counts are missing.
+func (z *ZoneDeleteResponse) Remaining() int {
+	if z == nil || z.ZonesSubmitted == nil {
+		return 0
+	}
+	if r := *z.ZonesSubmitted - z.processed(); r > 0 {
+		return r
+	}
+	return 0
+}
+
+// processed returns the number of zones that were deleted or failed to be.
+func (z *ZoneDeleteResponse) processed() int {
+	return z.GetSuccessCount() + z.GetFailureCount()
 }
 
 // ZoneDeleteResult holds the result of  the ZoneDelete request
@@ -210,7 +372,7 @@ type ZoneDeleteResult struct {
 	DeletedZones []*string `json:"successfullyDeletedZones,omitempty"`
 	FailedZones  []*struct {
 		Zone          *string `json:"zone,omitempty"`
-		FailureReason *string `json:"failiureReason,omitempty"`
+		FailureReason *string `json:"failureReason,omitempty"`
 	} `json:"failedZones,omitempty"`
 }
 
@@ -220,17 +382,41 @@ type ZoneDeleteResult struct {
 //
 // Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#postbulkzonedelete
 func (s *FastDNSv2Service) DeleteZone(ctx context.Context, zd *ZoneDeleteRequest, zdo *ZoneDeleteOptions) (*ZoneDeleteResponse, *Response, error) {
+	if zd != nil {
+		zones := make([]string, len(zd.Zones))
+		for i, z := range zd.Zones {
+			n, err := s.zoneName(z)
+			if err != nil {
+				return nil, nil, wrapOp("DeleteZone", z, "", "", err)
+			}
+			if err := s.checkZoneProtected(n); err != nil {
+				return nil, nil, wrapOp("DeleteZone", n, "", "", err)
+			}
+			zones[i] = n
+		}
+		zd = &ZoneDeleteRequest{Zones: zones}
+	}
+
 	u := fmt.Sprintf("config-dns/v2/zones/delete-requests")
 	u, err := addOptions(u, zdo)
+	if err != nil {
+		return nil, nil, wrapOp("DeleteZone", strings.Join(zd.Zones, ","), "", "", err)
+	}
+
 	req, err := s.client.NewRequest("POST", u, zd)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("DeleteZone", strings.Join(zd.Zones, ","), "", "", err)
 	}
 
 	z := new(ZoneDeleteResponse)
-	resp, err := s.client.Do(ctx, req, &z)
+	resp, err := s.client.Do(WithOperation(ctx, "DeleteZone"), req, &z)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("DeleteZone", strings.Join(zd.Zones, ","), "", "", err)
+	}
+	if zd != nil {
+		for _, zone := range zd.Zones {
+			s.forgetZoneType(zone)
+		}
 	}
 
 	return z, resp, nil
@@ -244,36 +430,76 @@ func (s *FastDNSv2Service) DeleteZoneStatus(ctx context.Context, rid string) (*Z
 	u := fmt.Sprintf("config-dns/v2/zones/delete-requests/%v", rid)
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("DeleteZoneStatus", "", "", "", err)
 	}
 
 	z := new(ZoneDeleteResponse)
-	resp, err := s.client.Do(ctx, req, &z)
+	resp, err := s.client.Do(WithOperation(ctx, "DeleteZoneStatus"), req, &z)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("DeleteZoneStatus", "", "", "", err)
 	}
 
 	return z, resp, nil
 }
 
-// DeleteZoneResult retrieves the results from a completed DeleteZone request.
+// DeleteZoneResult retrieves the results from a completed DeleteZone request. If some
+// zones failed to be deleted, the result is returned along with a *PartialError listing
+// them.
 //
 // Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getbulkzonedeleteresult
 func (s *FastDNSv2Service) DeleteZoneResult(ctx context.Context, rid string) (*ZoneDeleteResult, *Response, error) {
 	u := fmt.Sprintf("config-dns/v2/zones/delete-requests/%v/result", rid)
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("DeleteZoneResult", "", "", "", err)
 	}
 
 	z := new(ZoneDeleteResult)
-	resp, err := s.client.Do(ctx, req, &z)
+	resp, err := s.client.Do(WithOperation(ctx, "DeleteZoneResult"), req, &z)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("DeleteZoneResult", "", "", "", err)
 
 	}
 
-	return z, resp, nil
+	return z, resp, wrapOp("DeleteZoneResult", "", "", "", z.partialError())
+}
+
+// WaitForDeleteZone polls the status of a DeleteZone request every interval until it is
+// complete, and returns its result. zd and resp are the values DeleteZone returned. The
+// status is read from the location the response links to, falling back to the one of
+// the request ID, and the result from the location the status links to, falling back to
+// the one below the status. As with DeleteZoneResult, a *PartialError is returned along
+// with the result if some zones failed to be deleted.
+func (s *FastDNSv2Service) WaitForDeleteZone(ctx context.Context, zd *ZoneDeleteResponse, resp *Response, interval time.Duration) (*ZoneDeleteResult, error) {
+	statusURL, ok := resp.Links().Get("location")
+	if !ok {
+		statusURL = fmt.Sprintf("config-dns/v2/zones/delete-requests/%v", zd.GetRequestID())
+	}
+
+	var statusResp *Response
+	_, err := clientPoll(ctx, s.client, "WaitForDeleteZone", pollEvery(interval), (*ZoneDeleteResponse).status, func(ctx context.Context) (*ZoneDeleteResponse, bool, error) {
+		status := new(ZoneDeleteResponse)
+		resp, err := s.client.Call(ctx, "GET", statusURL, nil, status)
+		if err != nil {
+			return nil, false, err
+		}
+		statusResp = resp
+		return status, status.Done(), nil
+	})
+	if err != nil {
+		return nil, wrapOp("WaitForDeleteZone", "", "", "", err)
+	}
+
+	resultURL, ok := statusResp.Links().Get("result")
+	if !ok {
+		resultURL = strings.TrimSuffix(statusURL, "/") + "/result"
+	}
+
+	result := new(ZoneDeleteResult)
+	if _, err := s.client.Call(ctx, "GET", resultURL, nil, result); err != nil {
+		return nil, wrapOp("WaitForDeleteZone", "", "", "", err)
+	}
+	return result, wrapOp("WaitForDeleteZone", "", "", "", result.partialError())
 }
 
 // RecordSet is set of DNS records belonging to a particular DNS name
@@ -297,45 +523,199 @@ type RecordSetCreateRequest struct {
 	Zone  string   `json:"zone,omitempty"`
 	Name  string   `json:"name,omitempty"`
 	Rdata []string `json:"rdata,omitempty"`
-	TTL   int      `json:"ttl,omitempty"`
-	Type  string   `json:"type,omitempty"`
+
+	// TTL is left out of the request when nil, and sent when set, even to zero. The
+	// methods sending record sets give a nil TTL the default of the DefaultTTLPolicy of
+	// the service, if any, and fail with ErrInvalidTTL before making a request if it is
+	// still nil or is not positive.
+	TTL  *int   `json:"ttl,omitempty"`
+	Type string `json:"type,omitempty"`
+
+	// Ensure is only read by PlanRecordSets and SyncRecordSets, where EnsureAbsent makes
+	// the record set a tombstone: one that must not exist. It is not sent to the API.
+	Ensure Ensure `json:"-"`
+}
+
+// ErrInvalidTTL is matched by errors.Is for the record sets whose TTL is not positive.
+var ErrInvalidTTL = errors.New("record set TTL must be positive")
+
+// checkTTL returns an error wrapping ErrInvalidTTL if ttl is nil or not positive.
+func checkTTL(ttl *int) error {
+	switch {
+	case ttl == nil:
+		return fmt.Errorf("%w, and none is set", ErrInvalidTTL)
+	case *ttl <= 0:
+		return fmt.Errorf("%w, not %d", ErrInvalidTTL, *ttl)
+	}
+	return nil
+}
+
+// SetTTL sets the TTL of rs. It returns rs so that calls can be chained.
+func (rs *RecordSetCreateRequest) SetTTL(ttl int) *RecordSetCreateRequest {
+	rs.TTL = Int(ttl)
+	return rs
 }
 
+// ToCreateRequest returns the request creating rs in zone, with the same name, type, TTL
+// and records. The State of rs is not part of a request, and nil records become empty
+// ones. It returns nil if rs is nil.
+func (rs *RecordSet) ToCreateRequest(zone string) *RecordSetCreateRequest {
+	if rs == nil {
+		return nil
+	}
+	r := &RecordSetCreateRequest{Zone: zone, Name: rs.GetName(), Type: rs.GetType()}
+	if rs.TTL != nil {
+		r.TTL = Int(*rs.TTL)
+	}
+	if rs.Rdata != nil {
+		r.Rdata = make([]string, len(rs.Rdata))
+		for i, d := range rs.Rdata {
+			r.Rdata[i] = StringValue(d)
+		}
+	}
+	return r
+}
+
+// NewRecordSetFromRequest returns the record set that rs creates, with the same name,
+// type, TTL and records. The Zone and Ensure of rs are not part of a record set. It
+// returns nil if rs is nil.
+func NewRecordSetFromRequest(rs *RecordSetCreateRequest) *RecordSet {
+	if rs == nil {
+		return nil
+	}
+	r := &RecordSet{Name: String(rs.Name), Type: String(rs.Type)}
+	if rs.TTL != nil {
+		r.TTL = Int(*rs.TTL)
+	}
+	if rs.Rdata != nil {
+		r.Rdata = make([]*string, len(rs.Rdata))
+		for i, d := range rs.Rdata {
+			r.Rdata[i] = String(d)
+		}
+	}
+	return r
+}
+
+// RecordSetsToCreateRequests converts record sets with ToCreateRequest.
+func RecordSetsToCreateRequests(zone string, list []*RecordSet) []*RecordSetCreateRequest {
+	if list == nil {
+		return nil
+	}
+	requests := make([]*RecordSetCreateRequest, len(list))
+	for i, rs := range list {
+		requests[i] = rs.ToCreateRequest(zone)
+	}
+	return requests
+}
+
+// NewRecordSetsFromRequests converts requests with NewRecordSetFromRequest.
+func NewRecordSetsFromRequests(list []*RecordSetCreateRequest) []*RecordSet {
+	if list == nil {
+		return nil
+	}
+	sets := make([]*RecordSet, len(list))
+	for i, rs := range list {
+		sets[i] = NewRecordSetFromRequest(rs)
+	}
+	return sets
+}
+
+// Ensure tells the record set sync whether a desired record set must exist.
+type Ensure string
+
+// States a desired record set can be ensured to be in. The zero value is EnsurePresent.
+const (
+	EnsurePresent Ensure = ""
+	EnsureAbsent  Ensure = "absent"
+)
+
 // GetRecordSet retrieves a single record set for the zone, record name, and record type specified in the URL.
+// Its name is in lower case, unless the client has PreserveRecordNameCase set.
 //
 // Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getzonerecordset
 func (s *FastDNSv2Service) GetRecordSet(ctx context.Context, opt *RecordSetOptions) (*RecordSet, *Response, error) {
+	opt, err := s.recordSetOptions(opt)
+	if err != nil {
+		return nil, nil, wrapOp("GetRecordSet", opt.Zone, opt.Name, opt.Type, err)
+	}
+
 	u := fmt.Sprintf("/config-dns/v2/zones/%v/names/%v/types/%v", opt.Zone, opt.Name, opt.Type)
 
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("GetRecordSet", opt.Zone, opt.Name, opt.Type, err)
 	}
 
 	var rs *RecordSet
-	resp, err := s.client.Do(ctx, req, &rs)
+	resp, err := s.client.Do(WithOperation(ctx, "GetRecordSet"), req, &rs)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("GetRecordSet", opt.Zone, opt.Name, opt.Type, err)
 	}
+	s.readRecordNames([]*RecordSet{rs})
 
 	return rs, resp, nil
 }
 
+// GetRecordTypesForName lists the types of the record sets a name has in a zone.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getzonerecordsettypes
+func (s *FastDNSv2Service) GetRecordTypesForName(ctx context.Context, zone, name string) ([]string, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp("GetRecordTypesForName", zone, name, "", err)
+	}
+	if name, err = s.recordName(name); err != nil {
+		return nil, nil, wrapOp("GetRecordTypesForName", zone, name, "", err)
+	}
+
+	u := fmt.Sprintf("/config-dns/v2/zones/%v/names/%v/types", zone, name)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, wrapOp("GetRecordTypesForName", zone, name, "", err)
+	}
+
+	var l recordTypeList
+	resp, err := s.client.Do(WithOperation(ctx, "GetRecordTypesForName"), req, &l)
+	if err != nil {
+		return nil, resp, wrapOp("GetRecordTypesForName", zone, name, "", err)
+	}
+
+	return l.Types, resp, nil
+}
+
 // CreateRecordSet creates a new Record Set with the specified name and type.
 //
 // https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#postzonerecordset
 func (s *FastDNSv2Service) CreateRecordSet(ctx context.Context, rs *RecordSetCreateRequest) (*RecordSet, *Response, error) {
+	rs, err := s.recordSetRequest(rs)
+	if err != nil {
+		return nil, nil, wrapOp("CreateRecordSet", rs.Zone, rs.Name, rs.Type, err)
+	}
+	if err := s.checkZoneProtected(rs.Zone); err != nil {
+		return nil, nil, wrapOp("CreateRecordSet", rs.Zone, rs.Name, rs.Type, err)
+	}
+	if err := s.checkRecordPolicies(rs); err != nil {
+		return nil, nil, wrapOp("CreateRecordSet", rs.Zone, rs.Name, rs.Type, err)
+	}
+	if err := s.checkZoneEditable(ctx, rs.Zone); err != nil {
+		return nil, nil, wrapOp("CreateRecordSet", rs.Zone, rs.Name, rs.Type, err)
+	}
+	if err := s.checkRecordType(ctx, rs.Zone, rs.Name, rs.Type); err != nil {
+		return nil, nil, wrapOp("CreateRecordSet", rs.Zone, rs.Name, rs.Type, err)
+	}
+
 	u := fmt.Sprintf("/config-dns/v2/zones/%v/names/%v/types/%v", rs.Zone, rs.Name, rs.Type)
 
 	req, err := s.client.NewRequest("POST", u, rs)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("CreateRecordSet", rs.Zone, rs.Name, rs.Type, err)
 	}
 
 	var r *RecordSet
-	resp, err := s.client.Do(ctx, req, &r)
+	resp, err := s.client.Do(WithOperation(ctx, "CreateRecordSet"), req, &r)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("CreateRecordSet", rs.Zone, rs.Name, rs.Type, err)
 	}
 
 	return r, resp, nil
@@ -346,17 +726,34 @@ func (s *FastDNSv2Service) CreateRecordSet(ctx context.Context, rs *RecordSetCre
 //
 // Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#putzonerecordset
 func (s *FastDNSv2Service) UpdateRecordSet(ctx context.Context, rs *RecordSetCreateRequest) (*RecordSet, *Response, error) {
+	rs, err := s.recordSetRequest(rs)
+	if err != nil {
+		return nil, nil, wrapOp("UpdateRecordSet", rs.Zone, rs.Name, rs.Type, err)
+	}
+	if err := s.checkZoneProtected(rs.Zone); err != nil {
+		return nil, nil, wrapOp("UpdateRecordSet", rs.Zone, rs.Name, rs.Type, err)
+	}
+	if err := s.checkRecordPolicies(rs); err != nil {
+		return nil, nil, wrapOp("UpdateRecordSet", rs.Zone, rs.Name, rs.Type, err)
+	}
+	if err := s.checkZoneEditable(ctx, rs.Zone); err != nil {
+		return nil, nil, wrapOp("UpdateRecordSet", rs.Zone, rs.Name, rs.Type, err)
+	}
+	if err := s.checkRecordType(ctx, rs.Zone, rs.Name, rs.Type); err != nil {
+		return nil, nil, wrapOp("UpdateRecordSet", rs.Zone, rs.Name, rs.Type, err)
+	}
+
 	u := fmt.Sprintf("/config-dns/v2/zones/%v/names/%v/types/%v", rs.Zone, rs.Name, rs.Type)
 
 	req, err := s.client.NewRequest("PUT", u, rs)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("UpdateRecordSet", rs.Zone, rs.Name, rs.Type, err)
 	}
 
 	var r *RecordSet
-	resp, err := s.client.Do(ctx, req, &r)
+	resp, err := s.client.Do(WithOperation(ctx, "UpdateRecordSet"), req, &r)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("UpdateRecordSet", rs.Zone, rs.Name, rs.Type, err)
 	}
 
 	return r, resp, nil
@@ -366,13 +763,25 @@ func (s *FastDNSv2Service) UpdateRecordSet(ctx context.Context, rs *RecordSetCre
 //
 // Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#deletezonerecordset
 func (s *FastDNSv2Service) DeleteRecordSet(ctx context.Context, opt *RecordSetOptions) (*Response, error) {
+	opt, err := s.recordSetOptions(opt)
+	if err != nil {
+		return nil, wrapOp("DeleteRecordSet", opt.Zone, opt.Name, opt.Type, err)
+	}
+	if err := s.checkZoneProtected(opt.Zone); err != nil {
+		return nil, wrapOp("DeleteRecordSet", opt.Zone, opt.Name, opt.Type, err)
+	}
+	if err := s.checkZoneEditable(ctx, opt.Zone); err != nil {
+		return nil, wrapOp("DeleteRecordSet", opt.Zone, opt.Name, opt.Type, err)
+	}
+
 	u := fmt.Sprintf("/config-dns/v2/zones/%v/names/%v/types/%v", opt.Zone, opt.Name, opt.Type)
 	req, err := s.client.NewRequest("DELETE", u, nil)
 	if err != nil {
-		return nil, err
+		return nil, wrapOp("DeleteRecordSet", opt.Zone, opt.Name, opt.Type, err)
 	}
 
-	return s.client.Do(ctx, req, nil)
+	resp, err := s.client.Do(WithOperation(ctx, "DeleteRecordSet"), req, nil)
+	return resp, wrapOp("DeleteRecordSet", opt.Zone, opt.Name, opt.Type, err)
 }
 
 // ListZoneRecordSets holds the response from GetZoneRecordSets.
@@ -400,30 +809,126 @@ type ListZoneRecordSetOptions struct {
 	Types    string `url:"types,omitempty"`
 }
 
+// validateSearch checks the Search option of the list methods. Any character may be
+// searched for, as addOptions escapes them, but control characters and invalid UTF-8
+// can't appear in the names the API matches them against.
+func validateSearch(search string) error {
+	if !utf8.ValidString(search) {
+		return fmt.Errorf("invalid search %q: not valid UTF-8", search)
+	}
+	for _, r := range search {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("invalid search %q: holds control characters", search)
+		}
+	}
+	return nil
+}
+
 // GetZoneRecordSets lists all record sets for this zone. Can only be used on PRIMARY
-// and SECONDARY zones. This operation is paginated.
+// and SECONDARY zones. This operation is paginated. The names of the record sets are in
+// lower case, unless the client has PreserveRecordNameCase set.
 //
 // Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getzonerecordsets
 func (s *FastDNSv2Service) GetZoneRecordSets(ctx context.Context, zone string, opt *ListZoneRecordSetOptions) (*ListZoneRecordSets, *Response, error) {
+	var z *ListZoneRecordSets
+	resp, err := s.getZoneRecordSets(ctx, "GetZoneRecordSets", zone, opt, &z)
+	if err != nil {
+		return nil, resp, err
+	}
+	if z != nil {
+		s.readRecordNames(z.RecordSets)
+	}
+	return z, resp, nil
+}
+
+// getZoneRecordSets requests the record sets of zone and decodes them into v, which may
+// be an *ArrayStream. Its errors are those of op.
+func (s *FastDNSv2Service) getZoneRecordSets(ctx context.Context, op, zone string, opt *ListZoneRecordSetOptions, v interface{}) (*Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, wrapOp(op, zone, "", "", err)
+	}
+
+	if opt != nil {
+		if err := validateSearch(opt.Search); err != nil {
+			return nil, wrapOp(op, zone, "", "", err)
+		}
+		if err := validatePaging(opt.ShowAll, opt.Page != 0 || opt.PageSize != 0); err != nil {
+			return nil, wrapOp(op, zone, "", "", err)
+		}
+	}
+
 	u := fmt.Sprintf("/config-dns/v2/zones/%v/recordsets", zone)
 
-	u, err := addOptions(u, opt)
+	u, err = addOptions(u, opt)
 	if err != nil {
-		return nil, nil, err
+		return nil, wrapOp(op, zone, "", "", err)
 	}
 
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, wrapOp(op, zone, "", "", err)
 	}
 
-	var z *ListZoneRecordSets
-	resp, err := s.client.Do(ctx, req, &z)
+	resp, err := s.client.Do(WithOperation(ctx, op), req, v)
 	if err != nil {
-		return nil, resp, err
+		return resp, wrapOp(op, zone, "", "", err)
 	}
 
-	return z, resp, nil
+	return resp, nil
+}
+
+// ReplaceRecordSetsRequest is the body of ReplaceRecordSets.
+type ReplaceRecordSetsRequest struct {
+	RecordSets []*RecordSetCreateRequest `json:"recordsets"`
+}
+
+// ReplaceRecordSets replaces all the record sets of a PRIMARY zone with the given ones,
+// which must include the SOA and apex NS record sets.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#putzonerecordsets
+func (s *FastDNSv2Service) ReplaceRecordSets(ctx context.Context, zone string, rs []*RecordSetCreateRequest) (*Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, wrapOp("ReplaceRecordSets", zone, "", "", err)
+	}
+	if err := s.checkZoneProtected(zone); err != nil {
+		return nil, wrapOp("ReplaceRecordSets", zone, "", "", err)
+	}
+	records := make([]*RecordSetCreateRequest, len(rs))
+	for i, r := range rs {
+		c := *r
+		c.Zone = zone
+		if c.Name, err = s.recordName(r.Name); err != nil {
+			return nil, wrapOp("ReplaceRecordSets", zone, r.Name, r.Type, err)
+		}
+		s.applyDefaultTTL(&c)
+		if err := checkTTL(c.TTL); err != nil {
+			return nil, wrapOp("ReplaceRecordSets", zone, c.Name, c.Type, err)
+		}
+		if err := s.checkRdataLimits(&c); err != nil {
+			return nil, wrapOp("ReplaceRecordSets", zone, c.Name, c.Type, err)
+		}
+		if err := s.checkRecordPolicies(&c); err != nil {
+			return nil, wrapOp("ReplaceRecordSets", zone, c.Name, c.Type, err)
+		}
+		records[i] = &c
+	}
+
+	ctx, unlock, err := s.lockZone(ctx, zone)
+	if err != nil {
+		return nil, wrapOp("ReplaceRecordSets", zone, "", "", err)
+	}
+	defer unlock()
+
+	u := fmt.Sprintf("/config-dns/v2/zones/%v/recordsets", zone)
+	req, err := s.client.NewRequest("PUT", u, &ReplaceRecordSetsRequest{RecordSets: records})
+	if err != nil {
+		return nil, wrapOp("ReplaceRecordSets", zone, "", "", err)
+	}
+
+	resp, err := s.client.Do(WithOperation(ctx, "ReplaceRecordSets"), req, nil)
+	return resp, wrapOp("ReplaceRecordSets", zone, "", "", err)
 }
 
 // Contract holds Akamai's Contract object type. It provides metadata about
@@ -442,18 +947,22 @@ type Contract struct {
 //
 // Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getzonecontract
 func (s *FastDNSv2Service) GetZoneContract(ctx context.Context, zone string) (*Contract, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp("GetZoneContract", zone, "", "", err)
+	}
 
 	u := fmt.Sprintf("/config-dns/v2/zones/%v/contract", zone)
 
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("GetZoneContract", zone, "", "", err)
 	}
 
 	var c *Contract
-	resp, err := s.client.Do(ctx, req, &s)
+	resp, err := s.client.Do(WithOperation(ctx, "GetZoneContract"), req, &c)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("GetZoneContract", zone, "", "", err)
 	}
 
 	return c, resp, nil
@@ -487,18 +996,27 @@ type ChangeList struct {
 // Akamai API docs:
 // https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#postchangelists
 func (s *FastDNSv2Service) CreateChangeList(ctx context.Context, cl *ChangeListOptions) (*ChangeList, *Response, error) {
+	if cl != nil {
+		c := *cl
+		var err error
+		if c.Zone, err = s.zoneName(cl.Zone); err != nil {
+			return nil, nil, wrapOp("CreateChangeList", cl.Zone, "", "", err)
+		}
+		cl = &c
+	}
+
 	u := fmt.Sprintf("/config-dns/v2/changelists")
 	u, err := addOptions(u, cl)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("CreateChangeList", cl.Zone, "", "", err)
 	}
 
 	req, err := s.client.NewRequest("POST", u, nil)
 
 	c := new(ChangeList)
-	resp, err := s.client.Do(ctx, req, &c)
+	resp, err := s.client.Do(WithOperation(ctx, "CreateChangeList"), req, &c)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("CreateChangeList", cl.Zone, "", "", err)
 	}
 
 	return c, resp, nil
@@ -510,17 +1028,22 @@ func (s *FastDNSv2Service) CreateChangeList(ctx context.Context, cl *ChangeListO
 // Akamai API docs:
 // https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getchangelist
 func (s *FastDNSv2Service) GetChangeList(ctx context.Context, zone string) (*ChangeList, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp("GetChangeList", zone, "", "", err)
+	}
+
 	u := fmt.Sprintf("/config-dns/v2/changelists/%v", zone)
 
-	req, err := s.client.NewRequest("POST", u, nil)
+	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("GetChangeList", zone, "", "", err)
 	}
 
 	c := new(ChangeList)
-	resp, err := s.client.Do(ctx, req, &c)
+	resp, err := s.client.Do(WithOperation(ctx, "GetChangeList"), req, &c)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("GetChangeList", zone, "", "", err)
 	}
 
 	return c, resp, nil
@@ -543,25 +1066,43 @@ type ChangeListMetadata struct {
 
 // GetChangeListRecordSets retrieves the current list of record sets from the perspective of this change list.
 // Any changes that have been added to this change list will be reflected in the list of record sets returned.
+// Their names are in lower case, unless the client has PreserveRecordNameCase set.
 //
 // Akamai API docs:
 // https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getchangelistrecordsets
 func (s *FastDNSv2Service) GetChangeListRecordSets(ctx context.Context, zone string, opt *ChangeListOptions) (*ChangeListRecords, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp("GetChangeListRecordSets", zone, "", "", err)
+	}
+
+	if opt != nil {
+		if err := validateSearch(opt.Search); err != nil {
+			return nil, nil, wrapOp("GetChangeListRecordSets", zone, "", "", err)
+		}
+		if err := validatePaging(opt.ShowAll, opt.Page != 0 || opt.PageSize != 0); err != nil {
+			return nil, nil, wrapOp("GetChangeListRecordSets", zone, "", "", err)
+		}
+	}
+
 	u := fmt.Sprintf("/config-dns/v2/changelists/%v/recordsets", zone)
-	u, err := addOptions(u, opt)
+	u, err = addOptions(u, opt)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("GetChangeListRecordSets", zone, "", "", err)
 	}
 
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, wrapOp("GetChangeListRecordSets", zone, "", "", err)
 	}
 
 	c := new(ChangeListRecords)
-	resp, err := s.client.Do(ctx, req, &c)
+	resp, err := s.client.Do(WithOperation(ctx, "GetChangeListRecordSets"), req, &c)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, wrapOp("GetChangeListRecordSets", zone, "", "", err)
+	}
+	if c != nil {
+		s.readRecordNames(c.Recordsets)
 	}
 
 	return c, resp, nil
@@ -573,14 +1114,101 @@ func (s *FastDNSv2Service) GetChangeListRecordSets(ctx context.Context, zone str
 // Akamai API docs:
 // https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#deletechangelist
 func (s *FastDNSv2Service) DeleteChangeList(ctx context.Context, zone string) (*Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, wrapOp("DeleteChangeList", zone, "", "", err)
+	}
+
 	u := fmt.Sprintf("/config-dns/v2/changelists/%v", zone)
 
 	req, err := s.client.NewRequest("DELETE", u, nil)
 	if err != nil {
-		return nil, err
+		return nil, wrapOp("DeleteChangeList", zone, "", "", err)
+	}
+
+	resp, err := s.client.Do(WithOperation(ctx, "DeleteChangeList"), req, nil)
+	return resp, wrapOp("DeleteChangeList", zone, "", "", err)
+}
+
+// DeleteChangeListOptions holds the conditions under which DeleteChangeListIf deletes a
+// change list. Every condition set must hold.
+type DeleteChangeListOptions struct {
+	// OnlyIfOlderThan only deletes change lists last modified at least that long ago.
+	OnlyIfOlderThan time.Duration
+
+	// OnlyIfStale only deletes change lists that are stale, that is made on a version
+	// of the zone that has since changed, and so can't be submitted anymore.
+	OnlyIfStale bool
+}
+
+// ErrChangeListRecentlyModified is matched by errors.Is for the errors of
+// DeleteChangeListIf calls that left a change list in place.
+var ErrChangeListRecentlyModified = errors.New("change list was recently modified")
+
+// ChangeListModifiedError is returned by DeleteChangeListIf when the change list doesn't
+// meet the conditions to be deleted, as it may hold someone else's pending edits.
+type ChangeListModifiedError struct {
+	Zone         string
+	LastModified time.Time
+	Stale        bool
+	Reason       string
+}
+
+func (e *ChangeListModifiedError) Error() string {
+	return fmt.Sprintf("%v: change list of zone %v was not deleted: %v", ErrChangeListRecentlyModified, e.Zone, e.Reason)
+}
+
+// Is makes errors.Is(err, ErrChangeListRecentlyModified) report true.
+func (e *ChangeListModifiedError) Is(target error) bool {
+	return target == ErrChangeListRecentlyModified
+}
+
+// DeleteChangeListIf deletes the change list of a zone only if it meets the conditions
+// of opt, so that cleanups don't discard the fresh edits of others. It fetches the
+// change list first and returns a *ChangeListModifiedError, without deleting it, if a
+// condition doesn't hold. The change list may still be modified between the check and
+// the deletion. With no condition, it deletes as DeleteChangeList does.
+func (s *FastDNSv2Service) DeleteChangeListIf(ctx context.Context, zone string, opt *DeleteChangeListOptions) (*Response, error) {
+	if opt != nil && (opt.OnlyIfOlderThan > 0 || opt.OnlyIfStale) {
+		cl, resp, err := s.GetChangeList(ctx, zone)
+		if err != nil {
+			return resp, wrapOp("DeleteChangeListIf", zone, "", "", err)
+		}
+		if err := checkChangeListDeletable(cl, opt, time.Now()); err != nil {
+			return resp, wrapOp("DeleteChangeListIf", zone, "", "", err)
+		}
+	}
+
+	resp, err := s.DeleteChangeList(ctx, zone)
+	if err != nil {
+		// Keep the operation of the error the one that was called.
+		if oe, ok := OperationFromError(err); ok {
+			oe.Op = "DeleteChangeListIf"
+		}
+	}
+	return resp, err
+}
+
+// checkChangeListDeletable returns a *ChangeListModifiedError if cl doesn't meet the
+// conditions of opt at now.
+func checkChangeListDeletable(cl *ChangeList, opt *DeleteChangeListOptions, now time.Time) error {
+	modified, err := time.Parse(time.RFC3339, cl.LastModifiedDate)
+	refuse := func(reason string) error {
+		return &ChangeListModifiedError{Zone: cl.Zone, LastModified: modified, Stale: cl.Stale, Reason: reason}
 	}
 
-	return s.client.Do(ctx, req, nil)
+	if opt.OnlyIfStale && !cl.Stale {
+		return refuse("it is not stale")
+	}
+	if opt.OnlyIfOlderThan > 0 {
+		if err != nil {
+			return refuse(fmt.Sprintf("its last modification date %q can't be read", cl.LastModifiedDate))
+		}
+		if age := now.Sub(modified); age < opt.OnlyIfOlderThan {
+			return refuse(fmt.Sprintf("it was modified %v ago, less than %v", age.Round(time.Second), opt.OnlyIfOlderThan))
+		}
+	}
+	return nil
 }
 
 // SubmitChangeList applies all of the changes in this change list to the current zone. This
@@ -589,14 +1217,23 @@ func (s *FastDNSv2Service) DeleteChangeList(ctx context.Context, zone string) (*
 // Akamai API docs:
 // https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#postchangelistsubmit
 func (s *FastDNSv2Service) SubmitChangeList(ctx context.Context, zone string) (*Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, wrapOp("SubmitChangeList", zone, "", "", err)
+	}
+	if err := s.checkZoneProtected(zone); err != nil {
+		return nil, wrapOp("SubmitChangeList", zone, "", "", err)
+	}
+
 	u := fmt.Sprintf("/config-dns/v2/changelists/%v/submit", zone)
 
 	req, err := s.client.NewRequest("POST", u, nil)
 	if err != nil {
-		return nil, err
+		return nil, wrapOp("SubmitChangeList", zone, "", "", err)
 	}
 
-	return s.client.Do(ctx, req, nil)
+	resp, err := s.client.Do(WithOperation(ctx, "SubmitChangeList"), req, nil)
+	return resp, wrapOp("SubmitChangeList", zone, "", "", err)
 }
 
 // Resource record types supported by the Akamai FastDNS API
@@ -609,15 +1246,18 @@ const (
 	RRTypeCaa       = "CAA"
 	RRTypeCname     = "CNAME"
 	RRTypeHinfo     = "HINFO"
+	RRTypeHttps     = "HTTPS"
 	RRTypeLoc       = "LOC"
 	RRTypeMx        = "MX"
 	RRTypeNaptr     = "NAPTR"
 	RRTypeNs        = "NS"
 	RRTypePtr       = "PTR"
 	RRTypeRp        = "RP"
+	RRTypeSoa       = "SOA"
 	RRTypeSrv       = "SRV"
 	RRTypeSpf       = "SPF"
 	RRTypeSshfp     = "SSHFP"
+	RRTypeSvcb      = "SVCB"
 	RRTypeTlsa      = "TLSA"
 	RRTypeTxt       = "TXT"
 )
@@ -0,0 +1,134 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DataOptions specifies the optional parameters to the FastDNS v2 data methods.
+type DataOptions struct {
+	GroupID int `url:"gid,omitempty"`
+}
+
+// Group is a group of the account, as returned by ListGroups.
+type Group struct {
+	GroupID     *int      `json:"groupId,omitempty"`
+	GroupName   *string   `json:"groupName,omitempty"`
+	ContractIDs []*string `json:"contractIds,omitempty"`
+	Permissions []*string `json:"permissions,omitempty"`
+}
+
+// ContractAuthorities holds the name servers of the zones of a contract.
+type ContractAuthorities struct {
+	ContractID  *string   `json:"contractId,omitempty"`
+	Authorities []*string `json:"authorities,omitempty"`
+}
+
+type groupList struct {
+	Groups []*Group `json:"groups,omitempty"`
+}
+
+type contractList struct {
+	Contracts []*Contract `json:"contracts,omitempty"`
+}
+
+type authoritiesList struct {
+	Contracts []*ContractAuthorities `json:"contracts,omitempty"`
+}
+
+type recordTypeList struct {
+	Types []string `json:"types,omitempty"`
+}
+
+// ListGroups lists the groups the credentials can create zones in.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getgroups
+func (s *FastDNSv2Service) ListGroups(ctx context.Context, opt *DataOptions) ([]*Group, *Response, error) {
+	u, err := addOptions("/config-dns/v2/data/groups/", opt)
+	if err != nil {
+		return nil, nil, wrapOp("ListGroups", "", "", "", err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, wrapOp("ListGroups", "", "", "", err)
+	}
+
+	var l groupList
+	resp, err := s.client.Do(WithOperation(ctx, "ListGroups"), req, &l)
+	if err != nil {
+		return nil, resp, wrapOp("ListGroups", "", "", "", err)
+	}
+
+	return l.Groups, resp, nil
+}
+
+// ListContracts lists the contracts the credentials can create zones in.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getcontracts
+func (s *FastDNSv2Service) ListContracts(ctx context.Context, opt *DataOptions) ([]*Contract, *Response, error) {
+	u, err := addOptions("/config-dns/v2/data/contracts", opt)
+	if err != nil {
+		return nil, nil, wrapOp("ListContracts", "", "", "", err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, wrapOp("ListContracts", "", "", "", err)
+	}
+
+	var l contractList
+	resp, err := s.client.Do(WithOperation(ctx, "ListContracts"), req, &l)
+	if err != nil {
+		return nil, resp, wrapOp("ListContracts", "", "", "", err)
+	}
+
+	return l.Contracts, resp, nil
+}
+
+// GetAuthorities returns the name servers the zones of each contract are served by,
+// which are the ones to delegate the zones to.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getauthorities
+func (s *FastDNSv2Service) GetAuthorities(ctx context.Context, contractIDs []string) ([]*ContractAuthorities, *Response, error) {
+	u := fmt.Sprintf("/config-dns/v2/data/authorities?contractIds=%v", strings.Join(contractIDs, ","))
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, wrapOp("GetAuthorities", "", "", "", err)
+	}
+
+	var l authoritiesList
+	resp, err := s.client.Do(WithOperation(ctx, "GetAuthorities"), req, &l)
+	if err != nil {
+		return nil, resp, wrapOp("GetAuthorities", "", "", "", err)
+	}
+
+	return l.Contracts, resp, nil
+}
+
+// GetRecordTypes lists the record types that can be created in a zone.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getrecordsettypes
+func (s *FastDNSv2Service) GetRecordTypes(ctx context.Context, zone string) ([]string, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp("GetRecordTypes", zone, "", "", err)
+	}
+
+	u := fmt.Sprintf("/config-dns/v2/data/recordsets/types?zone=%v", zone)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, wrapOp("GetRecordTypes", zone, "", "", err)
+	}
+
+	var l recordTypeList
+	resp, err := s.client.Do(WithOperation(ctx, "GetRecordTypes"), req, &l)
+	if err != nil {
+		return nil, resp, wrapOp("GetRecordTypes", zone, "", "", err)
+	}
+
+	return l.Types, resp, nil
+}
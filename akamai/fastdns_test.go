@@ -0,0 +1,572 @@
+package akamai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestFastDNSv2Zones(t *testing.T) {
+	client, _ := akamaitest.NewServer(t)
+	ctx := context.Background()
+
+	z, _, err := client.FastDNSv2.CreateZone(ctx, "ctr_1-ABCDE", &akamai.ZoneCreateRequest{
+		Zone:    "example.com",
+		Type:    "PRIMARY",
+		Comment: "created by test",
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "example.com", z.GetZone())
+	assert.Equal(t, "1-ABCDE", z.GetContractID())
+
+	_, resp, err := client.FastDNSv2.CreateZone(ctx, "1-ABCDE", &akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	zm, _, err := client.FastDNSv2.GetZone(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "PRIMARY", zm.GetType())
+	assert.Equal(t, "created by test", zm.GetComment())
+	assert.Equal(t, z.GetVersionID(), zm.GetVersionId())
+
+	z, _, err = client.FastDNSv2.UpdateZone(ctx, &akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY", Comment: "updated"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "updated", z.GetComment())
+	assert.NotEqual(t, zm.GetVersionId(), z.GetVersionID())
+
+	contract, _, err := client.FastDNSv2.GetZoneContract(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "1-ABCDE", contract.GetContractID())
+	assert.Equal(t, 1, contract.ZoneCount)
+
+	zones, _, err := client.FastDNSv2.ListZones(ctx, &akamai.ZoneListOptions{Types: "PRIMARY"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 1, zones.GetMetadata().GetTotalElements())
+	if assert.Len(t, zones.Zones, 1) {
+		assert.Equal(t, "example.com", zones.Zones[0].GetZone())
+	}
+
+	del, _, err := client.FastDNSv2.DeleteZone(ctx, &akamai.ZoneDeleteRequest{Zones: []string{"example.com", "missing.com"}}, &akamai.ZoneDeleteOptions{Force: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 1, del.GetSuccessCount())
+	assert.Equal(t, 1, del.GetFailureCount())
+
+	status, _, err := client.FastDNSv2.DeleteZoneStatus(ctx, del.GetRequestID())
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, status.GetIsComplete())
+
+	result, _, err := client.FastDNSv2.DeleteZoneResult(ctx, del.GetRequestID())
+	var pe *akamai.PartialError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expect *PartialError, got %v", err)
+	}
+	assert.Equal(t, []string{"example.com"}, pe.Succeeded)
+	assert.Equal(t, []*akamai.PartialFailure{{Item: "missing.com", Reason: "ZONE_NOT_FOUND"}}, pe.Failed)
+	if assert.Len(t, result.DeletedZones, 1) {
+		assert.Equal(t, "example.com", *result.DeletedZones[0])
+	}
+	if assert.Len(t, result.FailedZones, 1) {
+		assert.Equal(t, "missing.com", *result.FailedZones[0].Zone)
+	}
+
+	_, resp, err = client.FastDNSv2.GetZone(ctx, "example.com")
+	if assert.Error(t, err) {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		var aerr *akamai.AkamaiError
+		if assert.True(t, errors.As(err, &aerr)) {
+			assert.Equal(t, http.StatusNotFound, aerr.Status)
+		}
+	}
+}
+
+func TestFastDNSv2RecordSets(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+
+	rs, _, err := client.FastDNSv2.CreateRecordSet(ctx, &akamai.RecordSetCreateRequest{
+		Zone:  "example.com",
+		Name:  "www.example.com",
+		Type:  akamai.RRTypeA,
+		TTL:   akamai.Int(300),
+		Rdata: []string{"192.0.2.1"},
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 300, rs.GetTTL())
+
+	rs, _, err = client.FastDNSv2.UpdateRecordSet(ctx, &akamai.RecordSetCreateRequest{
+		Zone:  "example.com",
+		Name:  "www.example.com",
+		Type:  akamai.RRTypeA,
+		TTL:   akamai.Int(600),
+		Rdata: []string{"192.0.2.1", "192.0.2.2"},
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, rs.Rdata, 2)
+
+	opt := &akamai.RecordSetOptions{Zone: "example.com", Name: "www.example.com", Type: akamai.RRTypeA}
+	rs, _, err = client.FastDNSv2.GetRecordSet(ctx, opt)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 600, rs.GetTTL())
+
+	list, _, err := client.FastDNSv2.GetZoneRecordSets(ctx, "example.com", &akamai.ListZoneRecordSetOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 3, list.GetMetadata().GetTotalElements())
+	assert.Len(t, list.RecordSets, 2)
+
+	list, _, err = client.FastDNSv2.GetZoneRecordSets(ctx, "example.com", &akamai.ListZoneRecordSetOptions{PageSize: 2, Page: 2})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, list.RecordSets, 1) {
+		assert.Equal(t, "www.example.com", list.RecordSets[0].GetName())
+	}
+
+	if _, err := client.FastDNSv2.DeleteRecordSet(ctx, opt); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	_, resp, err := client.FastDNSv2.GetRecordSet(ctx, opt)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestFastDNSv2ChangeLists(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+
+	cl, _, err := client.FastDNSv2.CreateChangeList(ctx, &akamai.ChangeListOptions{Zone: "example.com"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "example.com", cl.Zone)
+
+	_, resp, err := client.FastDNSv2.CreateChangeList(ctx, &akamai.ChangeListOptions{Zone: "example.com"})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	records, _, err := client.FastDNSv2.GetChangeListRecordSets(ctx, "example.com", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, records.Recordsets, 2)
+
+	if _, err := client.FastDNSv2.SubmitChangeList(ctx, "example.com"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	_, resp, err = client.FastDNSv2.GetChangeList(ctx, "example.com")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	if _, _, err := client.FastDNSv2.CreateChangeList(ctx, &akamai.ChangeListOptions{Zone: "example.com"}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+
+	cl, _, err = client.FastDNSv2.GetChangeList(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, cl.Stale)
+
+	_, err = client.FastDNSv2.SubmitChangeList(ctx, "example.com")
+	assert.Error(t, err)
+
+	if _, err := client.FastDNSv2.DeleteChangeList(ctx, "example.com"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+}
+
+func TestDeleteChangeListIf(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	if _, _, err := client.FastDNSv2.CreateChangeList(ctx, &akamai.ChangeListOptions{Zone: "example.com"}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// A fresh change list is kept.
+	_, err := client.FastDNSv2.DeleteChangeListIf(ctx, "example.com", &akamai.DeleteChangeListOptions{OnlyIfOlderThan: time.Hour})
+	assert.True(t, errors.Is(err, akamai.ErrChangeListRecentlyModified))
+	var merr *akamai.ChangeListModifiedError
+	if assert.True(t, errors.As(err, &merr)) {
+		assert.Equal(t, "example.com", merr.Zone)
+		assert.False(t, merr.Stale)
+	}
+	_, err = client.FastDNSv2.DeleteChangeListIf(ctx, "example.com", &akamai.DeleteChangeListOptions{OnlyIfStale: true})
+	assert.True(t, errors.Is(err, akamai.ErrChangeListRecentlyModified))
+	assert.NotContains(t, srv.Requests(), "DELETE /config-dns/v2/changelists/example.com")
+
+	// An old change list is deleted, unless it must also be stale.
+	if err := srv.SetChangeListModified("example.com", time.Now().Add(-2*time.Hour)); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	_, err = client.FastDNSv2.DeleteChangeListIf(ctx, "example.com", &akamai.DeleteChangeListOptions{OnlyIfOlderThan: time.Hour, OnlyIfStale: true})
+	assert.True(t, errors.Is(err, akamai.ErrChangeListRecentlyModified))
+	if _, err := client.FastDNSv2.DeleteChangeListIf(ctx, "example.com", &akamai.DeleteChangeListOptions{OnlyIfOlderThan: time.Hour}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	_, _, err = client.FastDNSv2.GetChangeList(ctx, "example.com")
+	assert.Error(t, err)
+
+	// A stale change list is deleted, however recent.
+	if _, _, err := client.FastDNSv2.CreateChangeList(ctx, &akamai.ChangeListOptions{Zone: "example.com"}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	if _, err := client.FastDNSv2.DeleteChangeListIf(ctx, "example.com", &akamai.DeleteChangeListOptions{OnlyIfStale: true}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// Missing change lists are reported as by GetChangeList.
+	resp, err := client.FastDNSv2.DeleteChangeListIf(ctx, "example.com", &akamai.DeleteChangeListOptions{OnlyIfStale: true})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	op, ok := akamai.OperationFromError(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, "DeleteChangeListIf", op.Op)
+	}
+}
+
+func TestZoneDeleteResponseProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		done      bool
+		progress  float64
+		remaining int
+	}{
+		{"pending", `{"requestId": "r", "zonesSubmitted": 4, "successCount": 0, "failureCount": 0, "isComplete": false}`, false, 0, 4},
+		{"partial", `{"requestId": "r", "zonesSubmitted": 4, "successCount": 2, "failureCount": 1, "isComplete": false}`, false, 0.75, 1},
+		{"complete", `{"requestId": "r", "zonesSubmitted": 4, "successCount": 3, "failureCount": 1, "isComplete": true}`, true, 1, 0},
+		{"complete without counts", `{"requestId": "r", "isComplete": true}`, true, 1, 0},
+		{"counted without isComplete", `{"requestId": "r", "zonesSubmitted": 2, "successCount": 2}`, true, 1, 0},
+		{"partial without isComplete", `{"requestId": "r", "zonesSubmitted": 2, "failureCount": 1}`, false, 0.5, 1},
+		{"no counts", `{"requestId": "r"}`, false, 0, 0},
+		{"overcounted", `{"requestId": "r", "zonesSubmitted": 1, "successCount": 2, "isComplete": false}`, false, 1, 0},
+	}
+
+	for _, tt := range tests {
+		var z akamai.ZoneDeleteResponse
+		if err := json.Unmarshal([]byte(tt.body), &z); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.Equal(t, tt.done, z.Done(), tt.name)
+		assert.Equal(t, tt.progress, z.Progress(), tt.name)
+		assert.Equal(t, tt.remaining, z.Remaining(), tt.name)
+	}
+
+	var z *akamai.ZoneDeleteResponse
+	assert.False(t, z.Done())
+	assert.Equal(t, 0.0, z.Progress())
+	assert.Equal(t, 0, z.Remaining())
+
+	b, err := json.Marshal(&akamai.ZoneDeleteResponse{RequestID: akamai.String("r")})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"requestId":"r"}`, string(b))
+}
+
+// conflictingTransport changes a zone on the fake server before the first zone update
+// it forwards, as a concurrent writer would.
+type conflictingTransport struct {
+	srv  *akamaitest.Server
+	puts int
+}
+
+func (c *conflictingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == "PUT" {
+		if c.puts++; c.puts == 1 {
+			c.srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "concurrent.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.9"}})
+		}
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSetZoneFields(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "SECONDARY", Comment: "original", EndCustomerID: "cust-1"})
+	ctx := context.Background()
+
+	tsig := &akamai.TSIGKey{Name: akamai.String("transfer"), Algorithm: akamai.String("hmac-sha256"), Secret: akamai.String("c2VjcmV0")}
+	_, err := client.Call(ctx, "PUT", "config-dns/v2/zones/example.com", &akamai.Zone{
+		Zone:             akamai.String("example.com"),
+		Type:             akamai.String("SECONDARY"),
+		Comment:          akamai.String("original"),
+		EndCustomerID:    akamai.String("cust-1"),
+		TSIGKey:          tsig,
+		Masters:          []*string{akamai.String("192.0.2.1"), akamai.String("192.0.2.2")},
+		SignAndServe:     akamai.Bool(true),
+		SignAndServeAlgo: akamai.String("RSA_SHA256"),
+	}, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assertKept := func(z *akamai.Zone) {
+		t.Helper()
+		assert.Equal(t, "SECONDARY", z.GetType())
+		assert.Equal(t, tsig, z.TSIGKey)
+		assert.Equal(t, []*string{akamai.String("192.0.2.1"), akamai.String("192.0.2.2")}, z.Masters)
+		assert.True(t, z.GetSignAndServe())
+		assert.Equal(t, "RSA_SHA256", z.GetSignAndServeAlgo())
+	}
+
+	z, _, err := client.FastDNSv2.SetZoneComment(ctx, "example.com", "billing reconciled")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "billing reconciled", z.GetComment())
+	assert.Equal(t, "cust-1", z.GetEndCustomerID())
+	assertKept(z)
+
+	z, _, err = client.FastDNSv2.SetZoneEndCustomerID(ctx, "example.com", "cust-2")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "billing reconciled", z.GetComment())
+	assert.Equal(t, "cust-2", z.GetEndCustomerID())
+	assertKept(z)
+	assertKept(srv.Zone("example.com"))
+
+	_, _, err = client.FastDNSv2.SetZoneComment(ctx, "missing.example", "x")
+	var aerr *akamai.AkamaiError
+	if assert.True(t, errors.As(err, &aerr)) {
+		assert.Equal(t, http.StatusNotFound, aerr.Status)
+	}
+}
+
+func TestSetZoneFieldsConflict(t *testing.T) {
+	_, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY", Comment: "original"})
+
+	transport := &conflictingTransport{srv: srv}
+	client, err := akamai.NewClient(&http.Client{Transport: transport}, srv.Credentials)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	z, _, err := client.FastDNSv2.SetZoneComment(context.Background(), "example.com", "updated")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "updated", z.GetComment())
+	assert.Equal(t, 2, transport.puts)
+	assert.Equal(t, []string{
+		"GET /config-dns/v2/zones/example.com",
+		"PUT /config-dns/v2/zones/example.com",
+		"GET /config-dns/v2/zones/example.com",
+		"PUT /config-dns/v2/zones/example.com",
+	}, srv.Requests())
+}
+
+func TestZoneCreateRequestSignAndServe(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *akamai.ZoneCreateRequest
+		want string
+	}{
+		{"unset", &akamai.ZoneCreateRequest{Zone: "example.com"}, `{"zone":"example.com"}`},
+		{"true", (&akamai.ZoneCreateRequest{Zone: "example.com"}).SetSignAndServe(true), `{"zone":"example.com","signAndServe":true}`},
+		{"false", &akamai.ZoneCreateRequest{Zone: "example.com", SignAndServe: akamai.Bool(false)}, `{"zone":"example.com","signAndServe":false}`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.req)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.JSONEq(t, tt.want, string(b), tt.name)
+	}
+
+	b, err := json.Marshal(&akamai.SandboxUpdateRequest{Name: "renamed"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.JSONEq(t, `{"name":"renamed"}`, string(b))
+}
+
+// TestRequestZeroValues checks the fields of the request bodies and options for which
+// zero is distinct from unset: they are pointers, left out when nil and sent when set,
+// zero or not.
+func TestRequestZeroValues(t *testing.T) {
+	tests := []struct {
+		name string
+		req  interface{}
+		want string
+	}{
+		{"ttl nil", &akamai.RecordSetCreateRequest{Name: "www.example.com"}, `{"name":"www.example.com"}`},
+		{"ttl zero", &akamai.RecordSetCreateRequest{Name: "www.example.com", TTL: akamai.Int(0)}, `{"name":"www.example.com","ttl":0}`},
+		{"ttl", &akamai.RecordSetCreateRequest{Name: "www.example.com", TTL: akamai.Int(300)}, `{"name":"www.example.com","ttl":300}`},
+		{"retention nil", &akamai.EdgeKVNamespace{Namespace: akamai.String("ns")}, `{"namespace":"ns"}`},
+		{"retention zero", &akamai.EdgeKVNamespace{Namespace: akamai.String("ns"), RetentionInSeconds: akamai.Int(0)}, `{"namespace":"ns","retentionInSeconds":0}`},
+		{"retention", &akamai.EdgeKVNamespace{Namespace: akamai.String("ns"), RetentionInSeconds: akamai.Int(86400)}, `{"namespace":"ns","retentionInSeconds":86400}`},
+		{"quality nil", &akamai.PolicyOutput{}, `{}`},
+		{"quality zero", &akamai.PolicyOutput{Quality: akamai.Int(0)}, `{"quality":0}`},
+		{"quality", &akamai.PolicyOutput{Quality: akamai.Int(85)}, `{"quality":85}`},
+		{"prefer modern formats false", &akamai.PolicyOutput{PreferModernFormats: akamai.Bool(false)}, `{"preferModernFormats":false}`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.req)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.JSONEq(t, tt.want, string(b), tt.name)
+	}
+
+	for _, tt := range []struct {
+		opt  *akamai.ZoneListOptions
+		want string
+	}{
+		{nil, "zones"},
+		{&akamai.ZoneListOptions{}, "zones"},
+		{new(akamai.ZoneListOptions).SetPage(0).SetPageSize(0), "zones?page=0&pageSize=0"},
+		{new(akamai.ZoneListOptions).SetPage(2).SetPageSize(10), "zones?page=2&pageSize=10"},
+	} {
+		u, err := akamai.AddOptions("zones", tt.opt)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.Equal(t, tt.want, u)
+	}
+}
+
+func TestRecordSetInvalidTTL(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	for _, ttl := range []*int{nil, akamai.Int(0), akamai.Int(-1)} {
+		rs := &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: ttl, Rdata: []string{"192.0.2.1"}}
+
+		_, _, err := client.FastDNSv2.CreateRecordSet(ctx, rs)
+		assert.True(t, errors.Is(err, akamai.ErrInvalidTTL), "got %v", err)
+		_, _, err = client.FastDNSv2.UpdateRecordSet(ctx, rs)
+		assert.True(t, errors.Is(err, akamai.ErrInvalidTTL), "got %v", err)
+		_, err = client.FastDNSv2.ReplaceRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{rs})
+		assert.True(t, errors.Is(err, akamai.ErrInvalidTTL), "got %v", err)
+		_, err = client.FastDNSv2.PlanRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{rs}, nil)
+		assert.True(t, errors.Is(err, akamai.ErrInvalidTTL), "got %v", err)
+	}
+	for _, rs := range srv.RecordSets("example.com") {
+		assert.NotEqual(t, "www.example.com", rs.GetName())
+	}
+
+	// Tombstones carry no TTL.
+	_, err := client.FastDNSv2.PlanRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{
+		{Name: "old.example.com", Type: "A", Ensure: akamai.EnsureAbsent},
+	}, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+}
+
+func TestUpdateZoneKeepsSignAndServe(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+
+	_, _, err := client.FastDNSv2.CreateZone(ctx, akamaitest.TestContractID, (&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"}).SetSignAndServe(true))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// Changing the comment leaves DNSSEC enabled.
+	_, _, err = client.FastDNSv2.UpdateZone(ctx, &akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY", Comment: "new comment"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, srv.Zone("example.com").GetSignAndServe())
+	assert.Equal(t, "new comment", srv.Zone("example.com").GetComment())
+
+	_, _, err = client.FastDNSv2.UpdateZone(ctx, (&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"}).SetSignAndServe(false))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.False(t, srv.Zone("example.com").GetSignAndServe())
+}
+
+func TestRecordSetConversion(t *testing.T) {
+	// A request converted to a record set and back loses nothing but its Ensure, which
+	// is not part of a record set.
+	request := func(rs akamai.RecordSetCreateRequest) bool {
+		want := rs
+		want.Ensure = akamai.EnsurePresent
+		got := akamai.NewRecordSetFromRequest(&rs).ToCreateRequest(rs.Zone)
+		return assert.Equal(t, &want, got)
+	}
+	if err := quick.Check(request, nil); err != nil {
+		t.Error(err)
+	}
+
+	// A record set converted to a request and back loses nothing but its State, which
+	// is not part of a request, and the difference between nil and empty fields.
+	recordSet := func(rs akamai.RecordSet) bool {
+		want := akamai.RecordSet{
+			Name: akamai.String(rs.GetName()),
+			Type: akamai.String(rs.GetType()),
+		}
+		if rs.TTL != nil {
+			want.TTL = akamai.Int(*rs.TTL)
+		}
+		if rs.Rdata != nil {
+			want.Rdata = []*string{}
+			for _, d := range rs.Rdata {
+				want.Rdata = append(want.Rdata, akamai.String(akamai.StringValue(d)))
+			}
+		}
+		got := akamai.NewRecordSetFromRequest(rs.ToCreateRequest("example.com"))
+		return assert.Equal(t, &want, got)
+	}
+	if err := quick.Check(recordSet, nil); err != nil {
+		t.Error(err)
+	}
+
+	var nilSet *akamai.RecordSet
+	assert.Nil(t, nilSet.ToCreateRequest("example.com"))
+	assert.Nil(t, akamai.NewRecordSetFromRequest(nil))
+
+	sets := []*akamai.RecordSet{
+		{Name: akamai.String("www.example.com"), Type: akamai.String("A"), TTL: akamai.Int(300), Rdata: []*string{akamai.String("192.0.2.1")}},
+		nil,
+	}
+	requests := akamai.RecordSetsToCreateRequests("example.com", sets)
+	assert.Equal(t, []*akamai.RecordSetCreateRequest{
+		{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+		nil,
+	}, requests)
+	assert.Equal(t, sets, akamai.NewRecordSetsFromRequests(requests))
+	assert.Nil(t, akamai.RecordSetsToCreateRequests("example.com", nil))
+	assert.Nil(t, akamai.NewRecordSetsFromRequests(nil))
+}
@@ -0,0 +1,236 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// FirewallRulesService handles communication with the Firewall Rules Notification (v1)
+// related endpoints of the Akamai API.
+type FirewallRulesService service
+
+// Actions reported in CIDRBlock.LastAction.
+const (
+	CIDRActionAdd    = "add"
+	CIDRActionUpdate = "update"
+	CIDRActionDelete = "delete"
+)
+
+// FirewallService is an Akamai service whose CIDR blocks can be subscribed to.
+type FirewallService struct {
+	ServiceID   *int    `json:"serviceId,omitempty"`
+	ServiceName *string `json:"serviceName,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// FirewallSubscription is a subscription to change notifications for a service's CIDR blocks.
+type FirewallSubscription struct {
+	ServiceID   *int    `json:"serviceId,omitempty"`
+	ServiceName *string `json:"serviceName,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	SignupDate  *string `json:"signupDate,omitempty"`
+}
+
+// FirewallSubscriptions holds the request and response of the subscriptions endpoints.
+type FirewallSubscriptions struct {
+	Subscriptions []*FirewallSubscription `json:"subscriptions"`
+}
+
+// CIDRBlock is a range of addresses Akamai uses to contact origin servers.
+type CIDRBlock struct {
+	CIDRID        *int    `json:"cidrId,omitempty"`
+	ServiceID     *int    `json:"serviceId,omitempty"`
+	ServiceName   *string `json:"serviceName,omitempty"`
+	CIDR          *string `json:"cidr,omitempty"`
+	CIDRMask      *string `json:"cidrMask,omitempty"`
+	Port          *string `json:"port,omitempty"`
+	CreationDate  *string `json:"creationDate,omitempty"`
+	EffectiveDate *string `json:"effectiveDate,omitempty"`
+	ChangeDate    *string `json:"changeDate,omitempty"`
+	MinIP         *string `json:"minIp,omitempty"`
+	MaxIP         *string `json:"maxIp,omitempty"`
+	LastAction    *string `json:"lastAction,omitempty"`
+}
+
+// Prefix parses the block's CIDR and mask into a netip.Prefix. The mask may be given
+// as "/24" or "24", and a CIDR already carrying its mask is accepted as is.
+func (b *CIDRBlock) Prefix() (netip.Prefix, error) {
+	if b.CIDR == nil {
+		return netip.Prefix{}, fmt.Errorf("CIDR block has no cidr")
+	}
+
+	cidr := *b.CIDR
+	if !strings.Contains(cidr, "/") {
+		if b.CIDRMask == nil {
+			return netip.Prefix{}, fmt.Errorf("CIDR block %v has no mask", cidr)
+		}
+		cidr = cidr + "/" + strings.TrimPrefix(*b.CIDRMask, "/")
+	}
+
+	p, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	return p.Masked(), nil
+}
+
+// Range parses the first and last addresses of the block.
+func (b *CIDRBlock) Range() (min, max netip.Addr, err error) {
+	if b.MinIP == nil || b.MaxIP == nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("CIDR block has no address range")
+	}
+
+	min, err = netip.ParseAddr(*b.MinIP)
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, err
+	}
+
+	max, err = netip.ParseAddr(*b.MaxIP)
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, err
+	}
+
+	return min, max, nil
+}
+
+// CIDRBlockListOptions specifies optional parameters to the FirewallRulesService.ListCIDRBlocks method.
+type CIDRBlockListOptions struct {
+	LastAction      string `url:"lastAction,omitempty"`
+	EffectiveDateGt string `url:"effectiveDateGt,omitempty"`
+	EffectiveDateLt string `url:"effectiveDateLt,omitempty"`
+}
+
+// ListServices lists the services whose CIDR blocks can be subscribed to.
+//
+// Akamai API docs: https://developer.akamai.com/api/cloud_security/firewall_rules_notification/v1.html#getservices
+func (s *FirewallRulesService) ListServices(ctx context.Context) ([]*FirewallService, *Response, error) {
+	u := "firewall-rules-manager/v1/services"
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var services []*FirewallService
+	resp, err := s.client.Do(WithOperation(ctx, "ListServices"), req, &services)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return services, resp, nil
+}
+
+// ListSubscriptions lists the services the user is subscribed to.
+//
+// Akamai API docs: https://developer.akamai.com/api/cloud_security/firewall_rules_notification/v1.html#getsubscriptions
+func (s *FirewallRulesService) ListSubscriptions(ctx context.Context) (*FirewallSubscriptions, *Response, error) {
+	u := "firewall-rules-manager/v1/subscriptions"
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subs := new(FirewallSubscriptions)
+	resp, err := s.client.Do(WithOperation(ctx, "ListSubscriptions"), req, subs)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return subs, resp, nil
+}
+
+// UpdateSubscriptions replaces the user's subscriptions with the given set.
+//
+// Akamai API docs: https://developer.akamai.com/api/cloud_security/firewall_rules_notification/v1.html#putsubscriptions
+func (s *FirewallRulesService) UpdateSubscriptions(ctx context.Context, subs *FirewallSubscriptions) (*FirewallSubscriptions, *Response, error) {
+	u := "firewall-rules-manager/v1/subscriptions"
+
+	req, err := s.client.NewRequest("PUT", u, subs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := new(FirewallSubscriptions)
+	resp, err := s.client.Do(WithOperation(ctx, "UpdateSubscriptions"), req, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated, resp, nil
+}
+
+// ListCIDRBlocks lists the CIDR blocks of the services the user is subscribed to.
+//
+// Akamai API docs: https://developer.akamai.com/api/cloud_security/firewall_rules_notification/v1.html#getcidrblocks
+func (s *FirewallRulesService) ListCIDRBlocks(ctx context.Context, opt *CIDRBlockListOptions) ([]*CIDRBlock, *Response, error) {
+	u, err := addOptions("firewall-rules-manager/v1/cidr-blocks", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var blocks []*CIDRBlock
+	resp, err := s.client.Do(WithOperation(ctx, "ListCIDRBlocks"), req, &blocks)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return blocks, resp, nil
+}
+
+// ListCIDRBlocksChangedSince returns only the CIDR blocks that were added or updated
+// on or after since, for incremental updates of origin ACLs. Deleted blocks are
+// not included.
+func (s *FirewallRulesService) ListCIDRBlocksChangedSince(ctx context.Context, since time.Time) ([]*CIDRBlock, error) {
+	blocks, _, err := s.ListCIDRBlocks(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterCIDRBlocksChangedSince(blocks, since), nil
+}
+
+func filterCIDRBlocksChangedSince(blocks []*CIDRBlock, since time.Time) []*CIDRBlock {
+	var changed []*CIDRBlock
+	for _, b := range blocks {
+		if b.LastAction == nil || (*b.LastAction != CIDRActionAdd && *b.LastAction != CIDRActionUpdate) {
+			continue
+		}
+
+		date := b.ChangeDate
+		if date == nil {
+			date = b.EffectiveDate
+		}
+		if date == nil {
+			continue
+		}
+
+		t, err := parseFirewallDate(*date)
+		if err != nil || t.Before(since) {
+			continue
+		}
+
+		changed = append(changed, b)
+	}
+
+	return changed
+}
+
+// parseFirewallDate parses the dates returned by the Firewall Rules Notification API,
+// which are either full timestamps or plain dates.
+func parseFirewallDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	return time.Parse("2006-01-02", s)
+}
@@ -0,0 +1,141 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const cidrBlocksFixture = `[
+	{"cidrId": 1, "serviceId": 7, "serviceName": "SiteShield", "cidr": "23.15.8.0", "cidrMask": "/24",
+	 "minIp": "23.15.8.0", "maxIp": "23.15.8.255", "lastAction": "add", "changeDate": "2019-08-26T12:00:00Z"},
+	{"cidrId": 2, "serviceId": 7, "serviceName": "SiteShield", "cidr": "2600:1401:1::", "cidrMask": "/48",
+	 "minIp": "2600:1401:1::", "maxIp": "2600:1401:1:ffff:ffff:ffff:ffff:ffff", "lastAction": "update", "effectiveDate": "2019-09-01"},
+	{"cidrId": 3, "serviceId": 7, "serviceName": "SiteShield", "cidr": "96.17.0.0", "cidrMask": "/16",
+	 "lastAction": "delete", "changeDate": "2019-09-02T12:00:00Z"},
+	{"cidrId": 4, "serviceId": 7, "serviceName": "SiteShield", "cidr": "104.64.0.0", "cidrMask": "/10",
+	 "lastAction": "add", "changeDate": "2018-01-01T12:00:00Z"}
+]`
+
+func TestCIDRBlockPrefix(t *testing.T) {
+	tests := []struct {
+		cidr, mask string
+		want       string
+	}{
+		{"23.15.8.0", "/24", "23.15.8.0/24"},
+		{"23.15.8.7", "24", "23.15.8.0/24"},
+		{"2600:1401:1::", "/48", "2600:1401:1::/48"},
+		{"2600:1401:1::/48", "", "2600:1401:1::/48"},
+	}
+
+	for _, tt := range tests {
+		b := &CIDRBlock{CIDR: &tt.cidr}
+		if tt.mask != "" {
+			mask := tt.mask
+			b.CIDRMask = &mask
+		}
+
+		p, err := b.Prefix()
+		if err != nil {
+			t.Errorf("%v%v: expect nil, got %v", tt.cidr, tt.mask, err)
+			continue
+		}
+		assert.Equal(t, tt.want, p.String())
+	}
+
+	cidr := "not-an-ip"
+	mask := "/24"
+	_, err := (&CIDRBlock{CIDR: &cidr, CIDRMask: &mask}).Prefix()
+	assert.Error(t, err)
+}
+
+func TestFirewallRulesListCIDRBlocks(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/firewall-rules-manager/v1/cidr-blocks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "add", r.URL.Query().Get("lastAction"))
+		assert.Equal(t, "2019-01-01", r.URL.Query().Get("effectiveDateGt"))
+		fmt.Fprint(w, cidrBlocksFixture)
+	})
+
+	blocks, _, err := client.FirewallRules.ListCIDRBlocks(context.Background(), &CIDRBlockListOptions{
+		LastAction:      CIDRActionAdd,
+		EffectiveDateGt: "2019-01-01",
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if !assert.Len(t, blocks, 4) {
+		return
+	}
+
+	min, max, err := blocks[1].Range()
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, min.Is6())
+	assert.Equal(t, "2600:1401:1:ffff:ffff:ffff:ffff:ffff", max.String())
+
+	p, err := blocks[1].Prefix()
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, p.Contains(max))
+}
+
+func TestFirewallRulesListCIDRBlocksChangedSince(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/firewall-rules-manager/v1/cidr-blocks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, cidrBlocksFixture)
+	})
+
+	since := time.Date(2019, 8, 1, 0, 0, 0, 0, time.UTC)
+	blocks, err := client.FirewallRules.ListCIDRBlocksChangedSince(context.Background(), since)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	var ids []int
+	for _, b := range blocks {
+		ids = append(ids, *b.CIDRID)
+	}
+	assert.Equal(t, []int{1, 2}, ids)
+}
+
+func TestFirewallRulesSubscriptions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/firewall-rules-manager/v1/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"subscriptions": [{"serviceId": 7, "email": "noc@example.com"}]}`, string(body))
+		}
+		fmt.Fprint(w, `{"subscriptions": [{"serviceId": 7, "serviceName": "SiteShield", "email": "noc@example.com", "signupDate": "2019-01-01"}]}`)
+	})
+
+	ctx := context.Background()
+	subs, _, err := client.FirewallRules.ListSubscriptions(ctx)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, subs.Subscriptions, 1) {
+		assert.Equal(t, "SiteShield", *subs.Subscriptions[0].ServiceName)
+	}
+
+	id := 7
+	email := "noc@example.com"
+	_, _, err = client.FirewallRules.UpdateSubscriptions(ctx, &FirewallSubscriptions{
+		Subscriptions: []*FirewallSubscription{{ServiceID: &id, Email: &email}},
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,80 @@
+package akamai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// FlexibleString is a string field that some endpoints send as a JSON number, such as
+// the CP codes and report versions. It decodes from either, and encodes as a string.
+type FlexibleString string
+
+// UnmarshalJSON decodes a JSON string or number. null leaves the string untouched.
+func (s *FlexibleString) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case bytes.Equal(b, []byte("null")):
+		return nil
+	case len(b) > 0 && b[0] == '"':
+		var v string
+		if err := json.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		*s = FlexibleString(v)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("can't decode %s into a string or number", b)
+	}
+	*s = FlexibleString(n)
+	return nil
+}
+
+// FlexibleStringOf returns a pointer to the FlexibleString value of the string passed in.
+func FlexibleStringOf(v string) *FlexibleString {
+	s := FlexibleString(v)
+	return &s
+}
+
+// FlexibleInt is an integer field that some endpoints send as a JSON string, such as
+// group IDs. It decodes from either, and encodes as a number.
+type FlexibleInt int
+
+// UnmarshalJSON decodes a JSON number or a string holding one. Numbers with a fraction,
+// or out of the range of an int, are refused. null leaves the integer untouched.
+func (i *FlexibleInt) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	}
+
+	if v, err := strconv.Atoi(s); err == nil {
+		*i = FlexibleInt(v)
+		return nil
+	}
+	// Some endpoints send integers as floats, such as 12.0.
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) || f < math.MinInt || f > math.MaxInt {
+		return fmt.Errorf("can't decode %s into an integer", b)
+	}
+	*i = FlexibleInt(f)
+	return nil
+}
+
+// FlexibleIntOf returns a pointer to the FlexibleInt value of the int passed in.
+func FlexibleIntOf(v int) *FlexibleInt {
+	i := FlexibleInt(v)
+	return &i
+}
@@ -0,0 +1,93 @@
+package akamai
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlexibleStringUnmarshalJSON(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    FlexibleString
+		wantErr bool
+	}{
+		{in: `"12345"`, want: "12345"},
+		{in: `12345`, want: "12345"},
+		{in: `"grp_12"`, want: "grp_12"},
+		{in: `""`, want: ""},
+		{in: `null`, want: "unchanged"},
+		{in: `12.5`, want: "12.5"},
+		{in: `true`, want: "unchanged", wantErr: true},
+		{in: `{}`, want: "unchanged", wantErr: true},
+	} {
+		t.Run(tt.in, func(t *testing.T) {
+			got := FlexibleString("unchanged")
+			err := json.Unmarshal([]byte(tt.in), &got)
+			assert.Equal(t, tt.wantErr, err != nil, "got %v", err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFlexibleIntUnmarshalJSON(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    FlexibleInt
+		wantErr bool
+	}{
+		{in: `42`, want: 42},
+		{in: `"42"`, want: 42},
+		{in: `-7`, want: -7},
+		{in: `null`, want: 99},
+		{in: `42.0`, want: 42},
+		{in: `"42.0"`, want: 42},
+		{in: `4.2e1`, want: 42},
+		{in: `42.5`, want: 99, wantErr: true},
+		{in: `1e300`, want: 99, wantErr: true},
+		{in: `"grp_42"`, want: 99, wantErr: true},
+		{in: `""`, want: 99, wantErr: true},
+		{in: `false`, want: 99, wantErr: true},
+	} {
+		t.Run(tt.in, func(t *testing.T) {
+			got := FlexibleInt(99)
+			err := json.Unmarshal([]byte(tt.in), &got)
+			assert.Equal(t, tt.wantErr, err != nil, "got %v", err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFlexibleFields(t *testing.T) {
+	var ns EdgeKVNamespace
+	if err := json.Unmarshal([]byte(`{"namespace": "marketing", "groupId": "4711"}`), &ns); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 4711, ns.GetGroupID())
+
+	// The canonical form of each field is sent back.
+	b, err := json.Marshal(&ns)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, `{"namespace":"marketing","groupId":4711}`, string(b))
+
+	var status EdgeKVStoreStatus
+	if err := json.Unmarshal([]byte(`{"cpcode": 123456}`), &status); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "123456", status.GetCPCode())
+	b, err = json.Marshal(&status)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, `{"cpcode":"123456"}`, string(b))
+
+	var meta ReportMetadata
+	if err := json.Unmarshal([]byte(`{"version": 1, "objectIds": [123, "456"]}`), &meta); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "1", meta.GetVersion())
+	assert.Equal(t, []*FlexibleString{FlexibleStringOf("123"), FlexibleStringOf("456")}, meta.ObjectIDs)
+}
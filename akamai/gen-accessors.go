@@ -0,0 +1,168 @@
+//go:build ignore
+// +build ignore
+
+// gen-accessors generates accessor methods for the pointer fields of the structs in
+// this package. The accessors return the zero value when either the receiver or the
+// field is nil, so callers can chain them without nil checks.
+//
+// It is meant to be used by go generate:
+//
+//	go generate ./akamai
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const fileName = "accessors.go"
+
+// zeroValues maps the basic types accessors are generated for, and the string enums, to
+// their zero value. Pointers to structs are returned as is.
+var zeroValues = map[string]string{
+	"bool":    "false",
+	"float64": "0",
+	"int":     "0",
+	"int64":   "0",
+	"string":  `""`,
+
+	"ActivationState": `""`,
+}
+
+// convertTypes maps the types that decode leniently to the basic types their accessors
+// return.
+var convertTypes = map[string]string{
+	"FlexibleInt":    "int",
+	"FlexibleString": "string",
+}
+
+// skipTypes lists the structs that don't get accessors because they aren't API
+// payloads.
+var skipTypes = map[string]bool{
+	"Client": true,
+}
+
+type accessor struct {
+	ReceiverType string
+	FieldName    string
+	FieldType    string
+	ZeroValue    string
+	Deref        bool
+	Convert      bool
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", sourceFilter, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var accessors []accessor
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			accessors = append(accessors, collect(f)...)
+		}
+	}
+
+	sort.Slice(accessors, func(i, j int) bool {
+		if accessors[i].ReceiverType != accessors[j].ReceiverType {
+			return accessors[i].ReceiverType < accessors[j].ReceiverType
+		}
+		return accessors[i].FieldName < accessors[j].FieldName
+	})
+
+	var buf bytes.Buffer
+	if err := sourceTmpl.Execute(&buf, accessors); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(fileName, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func sourceFilter(fi os.FileInfo) bool {
+	return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != fileName
+}
+
+func collect(f *ast.File) []accessor {
+	var accessors []accessor
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok || !ts.Name.IsExported() || skipTypes[ts.Name.Name] {
+				continue
+			}
+
+			for _, field := range st.Fields.List {
+				star, ok := field.Type.(*ast.StarExpr)
+				if !ok {
+					continue
+				}
+				ident, ok := star.X.(*ast.Ident)
+				if !ok || !ident.IsExported() && zeroValues[ident.Name] == "" && convertTypes[ident.Name] == "" {
+					continue
+				}
+
+				for _, name := range field.Names {
+					if !name.IsExported() {
+						continue
+					}
+
+					a := accessor{
+						ReceiverType: ts.Name.Name,
+						FieldName:    name.Name,
+						FieldType:    ident.Name,
+						ZeroValue:    zeroValues[ident.Name],
+						Deref:        zeroValues[ident.Name] != "",
+					}
+					if basic, ok := convertTypes[ident.Name]; ok {
+						a.FieldType, a.ZeroValue = basic, zeroValues[basic]
+						a.Deref, a.Convert = true, true
+					}
+					if !a.Deref {
+						a.FieldType = "*" + ident.Name
+						a.ZeroValue = "nil"
+					}
+					accessors = append(accessors, a)
+				}
+			}
+		}
+	}
+
+	return accessors
+}
+
+var sourceTmpl = template.Must(template.New("source").Parse(`// Code generated by gen-accessors; DO NOT EDIT.
+
+package akamai
+{{range .}}
+// Get{{.FieldName}} returns the {{.FieldName}} field if it's non-nil, zero value otherwise.
+func (x *{{.ReceiverType}}) Get{{.FieldName}}() {{.FieldType}} {
+	if x == nil || x.{{.FieldName}} == nil {
+		return {{.ZeroValue}}
+	}
+	return {{if .Convert}}{{.FieldType}}(*x.{{.FieldName}}){{else}}{{if .Deref}}*{{end}}x.{{.FieldName}}{{end}}
+}
+{{end}}`))
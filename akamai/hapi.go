@@ -0,0 +1,201 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// HAPIService handles communication with the Edge Hostnames API (v1) related
+// endpoints of the Akamai API.
+type HAPIService service
+
+// Statuses of a HAPI change request.
+const (
+	ChangeRequestPending   = "PENDING"
+	ChangeRequestSucceeded = "SUCCEEDED"
+	ChangeRequestFailed    = "FAILED"
+)
+
+// EdgeHostname represents an edge hostname as known by the Edge Hostnames API.
+type EdgeHostname struct {
+	EdgeHostnameID    *int    `json:"edgeHostnameId,omitempty"`
+	RecordName        *string `json:"recordName,omitempty"`
+	DNSZone           *string `json:"dnsZone,omitempty"`
+	SecurityType      *string `json:"securityType,omitempty"`
+	UseDefaultTTL     *bool   `json:"useDefaultTtl,omitempty"`
+	UseDefaultMap     *bool   `json:"useDefaultMap,omitempty"`
+	TTL               *int    `json:"ttl,omitempty"`
+	Map               *string `json:"map,omitempty"`
+	SlotNumber        *int    `json:"slotNumber,omitempty"`
+	IPVersionBehavior *string `json:"ipVersionBehavior,omitempty"`
+	Comments          *string `json:"comments,omitempty"`
+	SerialNumber      *int    `json:"serialNumber,omitempty"`
+}
+
+// EdgeHostnameList holds the response from ListEdgeHostnames.
+type EdgeHostnameList struct {
+	EdgeHostnames []*EdgeHostname `json:"edgeHostnames,omitempty"`
+}
+
+// EdgeHostnameListOptions specifies optional parameters to the HAPIService.ListEdgeHostnames method.
+type EdgeHostnameListOptions struct {
+	RecordNameSubstring string `url:"recordNameSubstring,omitempty"`
+	DNSZone             string `url:"dnsZone,omitempty"`
+}
+
+// JSONPatchOperation is a single operation of a JSON Patch (RFC 6902) document.
+type JSONPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ChangeRequest tracks an asynchronous change to edge hostnames.
+type ChangeRequest struct {
+	ChangeID         *int            `json:"changeId,omitempty"`
+	Action           *string         `json:"action,omitempty"`
+	Status           *string         `json:"status,omitempty"`
+	StatusMessage    *string         `json:"statusMessage,omitempty"`
+	StatusUpdateDate *string         `json:"statusUpdateDate,omitempty"`
+	EdgeHostnames    []*EdgeHostname `json:"edgeHostnames,omitempty"`
+}
+
+// ListEdgeHostnames lists the edge hostnames of the account.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getedgehostnames
+func (s *HAPIService) ListEdgeHostnames(ctx context.Context, opt *EdgeHostnameListOptions) (*EdgeHostnameList, *Response, error) {
+	u, err := addOptions("hapi/v1/edge-hostnames", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(EdgeHostnameList)
+	resp, err := s.client.Do(WithOperation(ctx, "ListEdgeHostnames"), req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
+
+// GetEdgeHostname retrieves a single edge hostname.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getedgehostname
+func (s *HAPIService) GetEdgeHostname(ctx context.Context, recordName, dnsZone string) (*EdgeHostname, *Response, error) {
+	u := fmt.Sprintf("hapi/v1/dns-zones/%v/edge-hostnames/%v", dnsZone, recordName)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	eh := new(EdgeHostname)
+	resp, err := s.client.Do(WithOperation(ctx, "GetEdgeHostname"), req, eh)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return eh, resp, nil
+}
+
+// PatchEdgeHostname changes the TTL or IP version behavior of an edge hostname. Only
+// "/ttl" and "/ipVersionBehavior" can be replaced. The change is applied asynchronously;
+// use GetChangeRequest or WaitForChangeRequest to follow it.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#patchedgehostname
+func (s *HAPIService) PatchEdgeHostname(ctx context.Context, recordName, dnsZone string, patch []JSONPatchOperation) (*ChangeRequest, *Response, error) {
+	u := fmt.Sprintf("hapi/v1/dns-zones/%v/edge-hostnames/%v", dnsZone, recordName)
+
+	b, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest("PATCH", u, b, "application/json-patch+json")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cr := new(ChangeRequest)
+	resp, err := s.client.Do(WithOperation(ctx, "PatchEdgeHostname"), req, cr)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return cr, resp, nil
+}
+
+// DeleteEdgeHostname deletes an edge hostname. The deletion is applied asynchronously;
+// use GetChangeRequest or WaitForChangeRequest to follow it.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#deleteedgehostname
+func (s *HAPIService) DeleteEdgeHostname(ctx context.Context, recordName, dnsZone string) (*ChangeRequest, *Response, error) {
+	u := fmt.Sprintf("hapi/v1/dns-zones/%v/edge-hostnames/%v", dnsZone, recordName)
+
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cr := new(ChangeRequest)
+	resp, err := s.client.Do(WithOperation(ctx, "DeleteEdgeHostname"), req, cr)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return cr, resp, nil
+}
+
+// GetChangeRequest retrieves the status of an edge hostname change request.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getchangerequest
+func (s *HAPIService) GetChangeRequest(ctx context.Context, changeID int) (*ChangeRequest, *Response, error) {
+	u := fmt.Sprintf("hapi/v1/change-requests/%v", changeID)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cr := new(ChangeRequest)
+	resp, err := s.client.Do(WithOperation(ctx, "GetChangeRequest"), req, cr)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return cr, resp, nil
+}
+
+// WaitForChangeRequest polls a change request every interval until it is no longer
+// pending, and returns its final state. A failed change request is returned along with
+// an error.
+func (s *HAPIService) WaitForChangeRequest(ctx context.Context, changeID int, interval time.Duration) (*ChangeRequest, error) {
+	cr, err := clientPoll(ctx, s.client, "WaitForChangeRequest", pollEvery(interval), func(cr *ChangeRequest) string {
+		return StringValue(cr.Status)
+	}, func(ctx context.Context) (*ChangeRequest, bool, error) {
+		cr, _, err := s.GetChangeRequest(ctx, changeID)
+		if err != nil {
+			return nil, false, err
+		}
+		return cr, cr.Status != nil && *cr.Status != ChangeRequestPending, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if *cr.Status == ChangeRequestFailed {
+		msg := ""
+		if cr.StatusMessage != nil {
+			msg = *cr.StatusMessage
+		}
+		return cr, fmt.Errorf("change request %d failed: %v", changeID, msg)
+	}
+	return cr, nil
+}
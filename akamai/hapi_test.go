@@ -0,0 +1,107 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHAPIGetEdgeHostname(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/hapi/v1/dns-zones/edgesuite.net/edge-hostnames/www.example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"edgeHostnameId": 1234, "recordName": "www.example.com", "dnsZone": "edgesuite.net",
+			"securityType": "STANDARD-TLS", "useDefaultTtl": true, "ttl": 21600, "ipVersionBehavior": "IPV4"}`)
+	})
+
+	eh, _, err := client.HAPI.GetEdgeHostname(context.Background(), "www.example.com", "edgesuite.net")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 1234, *eh.EdgeHostnameID)
+	assert.Equal(t, 21600, *eh.TTL)
+	assert.Equal(t, "IPV4", *eh.IPVersionBehavior)
+}
+
+func TestHAPIPatchEdgeHostname(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/hapi/v1/dns-zones/edgesuite.net/edge-hostnames/www.example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		assert.Equal(t, "application/json-patch+json", r.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `[
+			{"op": "replace", "path": "/ttl", "value": 900},
+			{"op": "replace", "path": "/ipVersionBehavior", "value": "IPV6_IPV4_DUALSTACK"}
+		]`, string(body))
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"changeId": 66, "action": "EDIT", "status": "PENDING"}`)
+	})
+
+	cr, _, err := client.HAPI.PatchEdgeHostname(context.Background(), "www.example.com", "edgesuite.net", []JSONPatchOperation{
+		{Op: "replace", Path: "/ttl", Value: 900},
+		{Op: "replace", Path: "/ipVersionBehavior", Value: "IPV6_IPV4_DUALSTACK"},
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 66, *cr.ChangeID)
+	assert.Equal(t, ChangeRequestPending, *cr.Status)
+}
+
+func TestHAPIDeleteEdgeHostnameAndWait(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/hapi/v1/dns-zones/edgesuite.net/edge-hostnames/old.example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"changeId": 67, "action": "DELETE", "status": "PENDING"}`)
+	})
+
+	polls := 0
+	mux.HandleFunc("/hapi/v1/change-requests/67", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		polls++
+		status := ChangeRequestPending
+		if polls >= 3 {
+			status = ChangeRequestSucceeded
+		}
+		fmt.Fprintf(w, `{"changeId": 67, "action": "DELETE", "status": "%s", "statusUpdateDate": "2019-09-01T00:00:00Z"}`, status)
+	})
+
+	ctx := context.Background()
+	cr, _, err := client.HAPI.DeleteEdgeHostname(ctx, "old.example.com", "edgesuite.net")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	done, err := client.HAPI.WaitForChangeRequest(ctx, *cr.ChangeID, time.Millisecond)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, ChangeRequestSucceeded, *done.Status)
+	assert.Equal(t, 3, polls)
+}
+
+func TestHAPIWaitForChangeRequestFailed(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/hapi/v1/change-requests/68", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"changeId": 68, "status": "FAILED", "statusMessage": "edge hostname is in use"}`)
+	})
+
+	cr, err := client.HAPI.WaitForChangeRequest(context.Background(), 68, time.Millisecond)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "edge hostname is in use")
+	}
+	assert.Equal(t, ChangeRequestFailed, *cr.Status)
+}
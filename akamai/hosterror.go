@@ -0,0 +1,87 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HostError is the error of a request that failed before getting a response from the
+// API host named by the credentials, such as when the host can't be resolved or
+// dialed. It says which host was dialed and where its name came from, as a typo in the
+// host key of a .edgerc section otherwise only shows as "no such host".
+//
+// It wraps the *url.Error of the transport, so errors.Is and errors.As still find the
+// underlying net errors, such as a *net.DNSError.
+type HostError struct {
+	// Host is the host the request was sent to.
+	Host string
+
+	// Provider is the name of the credentials provider the host came from, such as
+	// credentials.SharedCredsProviderName.
+	Provider string
+
+	// CredentialsHost is the host key of the credentials, as given.
+	CredentialsHost string
+
+	Err error
+}
+
+func (e *HostError) Error() string {
+	from := "the credentials"
+	if e.Provider != "" {
+		from += " of " + e.Provider
+	}
+
+	if scheme := hostScheme(e.CredentialsHost); scheme != "" {
+		return fmt.Sprintf("%v (the host key of %s is %q; remove its %q prefix, the host key is a bare host name)",
+			e.Err, from, e.CredentialsHost, scheme)
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(e.Err, &dnsErr) {
+		return fmt.Sprintf("%v (host %q from %s; check the host key of the credentials)", e.Err, e.Host, from)
+	}
+	return fmt.Sprintf("%v (host %q from %s)", e.Err, e.Host, from)
+}
+
+func (e *HostError) Unwrap() error {
+	return e.Err
+}
+
+// hostScheme returns the URL scheme a credentials host starts with, such as
+// "https://", or "" if it is a bare host name.
+func hostScheme(host string) string {
+	for _, scheme := range []string{"https://", "http://"} {
+		if len(host) >= len(scheme) && strings.EqualFold(host[:len(scheme)], scheme) {
+			return host[:len(scheme)]
+		}
+	}
+	return ""
+}
+
+// hostError returns err, the error of the transport for req, as a *HostError if req was
+// sent to the host of the client's credentials. Other errors, such as those of the
+// requests sent to a BaseURL set by hand, are returned as is.
+func (c *Client) hostError(req *http.Request, err error) error {
+	if c.Credentials == nil {
+		return err
+	}
+	creds, credsErr := c.Credentials.Get()
+	if credsErr != nil {
+		return err
+	}
+	u, parseErr := url.Parse("https://" + creds.Host)
+	if parseErr != nil || !strings.EqualFold(u.Hostname(), req.URL.Hostname()) {
+		return err
+	}
+	return &HostError{
+		Host:            req.URL.Host,
+		Provider:        creds.ProviderName,
+		CredentialsHost: creds.Host,
+		Err:             err,
+	}
+}
@@ -0,0 +1,79 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// unresolvableClient returns a client for the credentials host whose transport fails
+// to resolve any host, as the resolver does for a typo in the host key.
+func unresolvableClient(t *testing.T, host string) *Client {
+	transport := NewTransport(nil)
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		name, _, _ := net.SplitHostPort(addr)
+		return nil, &net.OpError{Op: "dial", Net: network, Err: &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}}
+	}
+	creds := credentials.NewStaticCredentials("secret", "client", "access", host)
+	c, err := NewClient(&http.Client{Transport: transport}, creds)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	return c
+}
+
+func TestHostError(t *testing.T) {
+	c := unresolvableClient(t, "akab-typo.luna.akamaiapis.net")
+
+	_, _, err := c.FastDNSv2.ListZones(context.Background(), nil)
+
+	var he *HostError
+	if !errors.As(err, &he) {
+		t.Fatalf("expect a *HostError, got %v", err)
+	}
+	assert.Equal(t, "akab-typo.luna.akamaiapis.net", he.Host)
+	assert.Equal(t, credentials.StaticProviderName, he.Provider)
+	assert.Contains(t, err.Error(), `no such host (host "akab-typo.luna.akamaiapis.net" from the credentials of StaticProvider; check the host key of the credentials)`)
+
+	// The net errors are still found.
+	var ue *url.Error
+	assert.True(t, errors.As(err, &ue), "got %v", err)
+	var dnsErr *net.DNSError
+	if assert.True(t, errors.As(err, &dnsErr), "got %v", err) {
+		assert.True(t, dnsErr.IsNotFound)
+	}
+	var ne net.Error
+	assert.True(t, errors.As(err, &ne))
+}
+
+func TestHostErrorScheme(t *testing.T) {
+	c := unresolvableClient(t, "https://akab-host.luna.akamaiapis.net")
+
+	_, _, err := c.FastDNSv2.ListZones(context.Background(), nil)
+
+	var he *HostError
+	if !errors.As(err, &he) {
+		t.Fatalf("expect a *HostError, got %v", err)
+	}
+	assert.Equal(t, "https://akab-host.luna.akamaiapis.net", he.CredentialsHost)
+	assert.Contains(t, err.Error(), `(the host key of the credentials of StaticProvider is "https://akab-host.luna.akamaiapis.net"; remove its "https://" prefix, the host key is a bare host name)`)
+}
+
+func TestHostErrorBaseURL(t *testing.T) {
+	// The requests sent to a BaseURL set by hand are not blamed on the credentials.
+	c := unresolvableClient(t, "akab-host.luna.akamaiapis.net")
+	c.BaseURL, _ = url.Parse("https://proxy.example.com/")
+
+	_, _, err := c.FastDNSv2.ListZones(context.Background(), nil)
+
+	var he *HostError
+	assert.False(t, errors.As(err, &he), "got %v", err)
+	var ue *url.Error
+	assert.True(t, errors.As(err, &ue), "got %v", err)
+}
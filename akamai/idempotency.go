@@ -0,0 +1,292 @@
+package akamai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultIdempotencyTTL is how long the idempotency stores keep a purge when they are
+// given no TTL.
+const DefaultIdempotencyTTL = time.Hour
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx carrying an idempotency key for the purges
+// made with it, such as the ID of the webhook delivery that asked for them. The Fast
+// Purge API has no idempotency of its own, so the client keeps the purges made under a
+// key in its IdempotencyStore, and doesn't send a purge of the same objects under the
+// same key again while the store keeps it. Purges of other objects under the same key
+// are sent. See Client.WithIdempotencyStore.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKey returns the idempotency key set on ctx with WithIdempotencyKey, if any.
+func IdempotencyKey(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key
+}
+
+// IdempotencyRecord is what an IdempotencyStore keeps of a purge.
+type IdempotencyRecord struct {
+	PurgeID          string    `json:"purgeId"`
+	EstimatedSeconds int       `json:"estimatedSeconds,omitempty"`
+	SupportID        string    `json:"supportId,omitempty"`
+	Created          time.Time `json:"created"`
+}
+
+// IdempotencyStore keeps the purges made under idempotency keys for a TTL. Its keys
+// combine the idempotency key with a hash of the purged objects. It must be safe for
+// concurrent use.
+type IdempotencyStore interface {
+	// Get returns the record kept under key, or nil if there is none or it is older
+	// than the TTL of the store.
+	Get(key string) (*IdempotencyRecord, error)
+
+	// Put keeps r under key. The store sets its Created time.
+	Put(key string, r *IdempotencyRecord) error
+}
+
+// WithIdempotencyStore makes the client deduplicate the purges made with an idempotency
+// key in store. See WithIdempotencyKey. A nil store turns deduplication off.
+//
+// WithIdempotencyStore must not be called while the client is in use; it returns c so
+// that calls can be chained.
+func (c *Client) WithIdempotencyStore(store IdempotencyStore) *Client {
+	c.idempotencyStore = store
+	c.idempotencyLocks = NewZoneLocks()
+	return c
+}
+
+// idempotent calls purge unless the store of the client has a purge of the objects of
+// p under key, and keeps the result of purge in the store. Purges under the same store
+// key wait for each other, so that concurrent duplicates are sent once.
+func (c *Client) idempotent(ctx context.Context, key string, p *PurgeRequest, purge func() (*PurgeResult, *Response, error)) (*PurgeResult, *Response, error) {
+	storeKey := accountCacheKey(ctx, key+" "+purgeHash(p))
+
+	_, unlock, err := c.idempotencyLocks.Lock(ctx, storeKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	r, err := c.idempotencyStore.Get(storeKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if r != nil {
+		result := &PurgeResult{
+			PurgeID:   String(r.PurgeID),
+			Duplicate: true,
+		}
+		if r.EstimatedSeconds != 0 {
+			result.EstimatedSeconds = Int(r.EstimatedSeconds)
+		}
+		if r.SupportID != "" {
+			result.SupportID = String(r.SupportID)
+		}
+		return result, nil, nil
+	}
+
+	result, resp, err := purge()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	err = c.idempotencyStore.Put(storeKey, &IdempotencyRecord{
+		PurgeID:          result.GetPurgeID(),
+		EstimatedSeconds: result.GetEstimatedSeconds(),
+		SupportID:        result.GetSupportID(),
+	})
+	return result, resp, err
+}
+
+// purgeHash returns a hash of what p purges: its action, type, network and host, and
+// its set of objects, regardless of their order and repetitions.
+func purgeHash(p *PurgeRequest) string {
+	objects := append([]string(nil), p.Objects...)
+	sort.Strings(objects)
+
+	h := sha256.New()
+	h.Write([]byte(p.path() + "\n" + p.Hostname + "\n"))
+	for i, o := range objects {
+		if i > 0 && o == objects[i-1] {
+			continue
+		}
+		h.Write([]byte(o + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore kept in memory, which deduplicates the
+// purges of a process.
+type MemoryIdempotencyStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	records map[string]*IdempotencyRecord
+}
+
+// NewMemoryIdempotencyStore returns an empty MemoryIdempotencyStore keeping its records
+// for ttl, or DefaultIdempotencyTTL if ttl is not positive.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		ttl:     orDefault(ttl, DefaultIdempotencyTTL),
+		now:     time.Now,
+		records: map[string]*IdempotencyRecord{},
+	}
+}
+
+// Get implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Get(key string) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.records[key]
+	if !ok {
+		return nil, nil
+	}
+	if expiredRecord(r, s.now(), s.ttl) {
+		delete(s.records, key)
+		return nil, nil
+	}
+	copied := *r
+	return &copied, nil
+}
+
+// Put implements IdempotencyStore. The records that expired are dropped.
+func (s *MemoryIdempotencyStore) Put(key string, r *IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, old := range s.records {
+		if expiredRecord(old, now, s.ttl) {
+			delete(s.records, k)
+		}
+	}
+
+	kept := *r
+	kept.Created = now
+	s.records[key] = &kept
+	return nil
+}
+
+// FileIdempotencyStore is an IdempotencyStore kept in a JSON file, so that the purges
+// are deduplicated across restarts, such as those of a webhook receiver. Processes
+// sharing the file don't lock it, so they may each send a duplicate they race on.
+type FileIdempotencyStore struct {
+	path string
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewFileIdempotencyStore returns a FileIdempotencyStore kept in the file at path,
+// which is created by the first Put, keeping its records for ttl, or
+// DefaultIdempotencyTTL if ttl is not positive.
+func NewFileIdempotencyStore(path string, ttl time.Duration) *FileIdempotencyStore {
+	return &FileIdempotencyStore{
+		path: path,
+		ttl:  orDefault(ttl, DefaultIdempotencyTTL),
+		now:  time.Now,
+	}
+}
+
+// Get implements IdempotencyStore.
+func (s *FileIdempotencyStore) Get(key string) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	r, ok := records[key]
+	if !ok || expiredRecord(r, s.now(), s.ttl) {
+		return nil, nil
+	}
+	return r, nil
+}
+
+// Put implements IdempotencyStore. The file is rewritten without the records that
+// expired, and replaced at once.
+func (s *FileIdempotencyStore) Put(key string, r *IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	for k, old := range records {
+		if expiredRecord(old, now, s.ttl) {
+			delete(records, k)
+		}
+	}
+	kept := *r
+	kept.Created = now
+	records[key] = &kept
+
+	b, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+func (s *FileIdempotencyStore) load() (map[string]*IdempotencyRecord, error) {
+	b, err := ioutil.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && strings.TrimSpace(string(b)) == "") {
+		return map[string]*IdempotencyRecord{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	records := map[string]*IdempotencyRecord{}
+	if err := json.Unmarshal(b, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// expiredRecord reports whether r is older than ttl at now.
+func expiredRecord(r *IdempotencyRecord, now time.Time, ttl time.Duration) bool {
+	return !now.Before(r.Created.Add(ttl))
+}
@@ -0,0 +1,227 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ImagingService handles communication with the Image and Video Manager (v2)
+// related endpoints of the Akamai API.
+type ImagingService service
+
+// Networks a policy can be read from or deployed to.
+const (
+	ImagingNetworkStaging    = "staging"
+	ImagingNetworkProduction = "production"
+)
+
+// PolicySet represents an Image and Video Manager policy set. Policy sets group the
+// policies that apply to a set of properties.
+type PolicySet struct {
+	ID           *string   `json:"id,omitempty"`
+	Name         *string   `json:"name,omitempty"`
+	Region       *string   `json:"region,omitempty"`
+	Type         *string   `json:"type,omitempty"`
+	User         *string   `json:"user,omitempty"`
+	Properties   []*string `json:"properties,omitempty"`
+	LastModified *string   `json:"lastModified,omitempty"`
+}
+
+// Policy represents an Image and Video Manager policy. Transformations are free-form
+// and are kept as raw JSON so they round-trip unchanged.
+type Policy struct {
+	ID                            *string            `json:"id,omitempty"`
+	Version                       *int               `json:"version,omitempty"`
+	PreviousVersion               *int               `json:"previousVersion,omitempty"`
+	DateCreated                   *string            `json:"dateCreated,omitempty"`
+	User                          *string            `json:"user,omitempty"`
+	RolloutDuration               *int               `json:"rolloutDuration,omitempty"`
+	Breakpoints                   *PolicyBreakpoints `json:"breakpoints,omitempty"`
+	Output                        *PolicyOutput      `json:"output,omitempty"`
+	Hosts                         []*string          `json:"hosts,omitempty"`
+	Variables                     json.RawMessage    `json:"variables,omitempty"`
+	Transformations               json.RawMessage    `json:"transformations,omitempty"`
+	PostBreakpointTransformations json.RawMessage    `json:"postBreakpointTransformations,omitempty"`
+}
+
+// PolicyBreakpoints holds the image widths a policy derives images for.
+type PolicyBreakpoints struct {
+	Widths []int `json:"widths,omitempty"`
+}
+
+// PolicyOutput holds the output quality settings of a policy.
+type PolicyOutput struct {
+	PerceptualQuality   *string   `json:"perceptualQuality,omitempty"`
+	Quality             *int      `json:"quality,omitempty"`
+	AdaptiveQuality     *int      `json:"adaptiveQuality,omitempty"`
+	PreferModernFormats *bool     `json:"preferModernFormats,omitempty"`
+	AllowedFormats      []*string `json:"allowedFormats,omitempty"`
+	ForcedFormats       []*string `json:"forcedFormats,omitempty"`
+}
+
+// PolicyList holds the response from ListPolicies.
+type PolicyList struct {
+	Items      []*Policy `json:"items,omitempty"`
+	TotalItems *int      `json:"totalItems,omitempty"`
+}
+
+// PolicyUpdateResponse holds the response from PutPolicy.
+type PolicyUpdateResponse struct {
+	ID                 *string `json:"id,omitempty"`
+	OperationPerformed *string `json:"operationPerformed,omitempty"`
+	Description        *string `json:"description,omitempty"`
+}
+
+// PolicyHistory holds the response from GetPolicyHistory.
+type PolicyHistory struct {
+	Items      []*PolicyHistoryItem `json:"items,omitempty"`
+	TotalItems *int                 `json:"totalItems,omitempty"`
+}
+
+// PolicyHistoryItem is a single change in the history of a policy.
+type PolicyHistoryItem struct {
+	ID          *string `json:"id,omitempty"`
+	Version     *int    `json:"version,omitempty"`
+	User        *string `json:"user,omitempty"`
+	Action      *string `json:"action,omitempty"`
+	DateCreated *string `json:"dateCreated,omitempty"`
+}
+
+// newImagingRequest creates an API request carrying the Contract and, if set, the
+// Policy-Set headers the Image and Video Manager API requires.
+func (s *ImagingService) newImagingRequest(method, u, contractID, policySetID string, body interface{}) (*http.Request, error) {
+	req, err := s.client.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Contract", TrimContractPrefix(contractID))
+	if policySetID != "" {
+		req.Header.Set("Policy-Set", policySetID)
+	}
+
+	return req, nil
+}
+
+// ListPolicySets lists the policy sets of a contract.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/image_manager/v2.html#getpolicysets
+func (s *ImagingService) ListPolicySets(ctx context.Context, contractID string) ([]*PolicySet, *Response, error) {
+	u := "imaging/v2/policysets"
+
+	req, err := s.newImagingRequest("GET", u, contractID, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sets []*PolicySet
+	resp, err := s.client.Do(WithOperation(ctx, "ListPolicySets"), req, &sets)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sets, resp, nil
+}
+
+// GetPolicySet retrieves a single policy set.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/image_manager/v2.html#getpolicyset
+func (s *ImagingService) GetPolicySet(ctx context.Context, contractID, policySetID string) (*PolicySet, *Response, error) {
+	u := fmt.Sprintf("imaging/v2/policysets/%v", policySetID)
+
+	req, err := s.newImagingRequest("GET", u, contractID, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var set *PolicySet
+	resp, err := s.client.Do(WithOperation(ctx, "GetPolicySet"), req, &set)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return set, resp, nil
+}
+
+// ListPolicies lists the policies of a policy set on the given network.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/image_manager/v2.html#getpolicies
+func (s *ImagingService) ListPolicies(ctx context.Context, contractID, policySetID, network string) (*PolicyList, *Response, error) {
+	u := fmt.Sprintf("imaging/v2/network/%v/policies", network)
+
+	req, err := s.newImagingRequest("GET", u, contractID, policySetID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var policies *PolicyList
+	resp, err := s.client.Do(WithOperation(ctx, "ListPolicies"), req, &policies)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return policies, resp, nil
+}
+
+// GetPolicy retrieves a single policy of a policy set on the given network.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/image_manager/v2.html#getpolicy
+func (s *ImagingService) GetPolicy(ctx context.Context, contractID, policySetID, network, policyID string) (*Policy, *Response, error) {
+	u := fmt.Sprintf("imaging/v2/network/%v/policies/%v", network, policyID)
+
+	req, err := s.newImagingRequest("GET", u, contractID, policySetID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var p *Policy
+	resp, err := s.client.Do(WithOperation(ctx, "GetPolicy"), req, &p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return p, resp, nil
+}
+
+// PutPolicy creates or replaces a policy of a policy set on the given network. The
+// policy's RolloutDuration controls how many seconds the change takes to roll out.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/image_manager/v2.html#putpolicy
+func (s *ImagingService) PutPolicy(ctx context.Context, contractID, policySetID, network, policyID string, policy *Policy) (*PolicyUpdateResponse, *Response, error) {
+	u := fmt.Sprintf("imaging/v2/network/%v/policies/%v", network, policyID)
+
+	req, err := s.newImagingRequest("PUT", u, contractID, policySetID, policy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := new(PolicyUpdateResponse)
+	resp, err := s.client.Do(WithOperation(ctx, "PutPolicy"), req, p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return p, resp, nil
+}
+
+// GetPolicyHistory retrieves the change history of a policy on the given network.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/image_manager/v2.html#getpolicyhistory
+func (s *ImagingService) GetPolicyHistory(ctx context.Context, contractID, policySetID, network, policyID string) (*PolicyHistory, *Response, error) {
+	u := fmt.Sprintf("imaging/v2/network/%v/policies/history/%v", network, policyID)
+
+	req, err := s.newImagingRequest("GET", u, contractID, policySetID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var h *PolicyHistory
+	resp, err := s.client.Do(WithOperation(ctx, "GetPolicyHistory"), req, &h)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return h, resp, nil
+}
@@ -0,0 +1,138 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const policyFixture = `{
+	"id": "hero-images",
+	"version": 3,
+	"previousVersion": 2,
+	"rolloutDuration": 3600,
+	"breakpoints": {"widths": [320, 640, 1280]},
+	"output": {"perceptualQuality": "mediumHigh", "allowedFormats": ["webp", "jpeg"]},
+	"hosts": ["images.example.com"],
+	"transformations": [
+		{"transformation": "Resize", "width": {"var": "width"}, "height": 200, "type": "normal"},
+		{"transformation": "Composite", "image": {"url": "https://example.com/logo.png"}, "placement": "Over"}
+	],
+	"variables": [{"name": "width", "type": "number", "defaultValue": "400"}]
+}`
+
+func TestImagingListPolicySets(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/imaging/v2/policysets", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "1-ABCDE", r.Header.Get("Contract"))
+		assert.Empty(t, r.Header.Get("Policy-Set"))
+		fmt.Fprint(w, `[{"id": "set-1", "name": "images", "region": "US", "type": "IMAGE", "properties": ["prp_1"]}]`)
+	})
+
+	sets, _, err := client.Imaging.ListPolicySets(context.Background(), "ctr_1-ABCDE")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if assert.Len(t, sets, 1) {
+		assert.Equal(t, "set-1", *sets[0].ID)
+		assert.Equal(t, "IMAGE", *sets[0].Type)
+	}
+}
+
+func TestImagingListPolicies(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/imaging/v2/network/staging/policies", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "1-ABCDE", r.Header.Get("Contract"))
+		assert.Equal(t, "set-1", r.Header.Get("Policy-Set"))
+		fmt.Fprintf(w, `{"items": [%s], "totalItems": 1}`, policyFixture)
+	})
+
+	policies, _, err := client.Imaging.ListPolicies(context.Background(), "1-ABCDE", "set-1", ImagingNetworkStaging)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Equal(t, 1, *policies.TotalItems)
+	if assert.Len(t, policies.Items, 1) {
+		p := policies.Items[0]
+		assert.Equal(t, "hero-images", *p.ID)
+		assert.Equal(t, 3600, *p.RolloutDuration)
+		assert.Equal(t, []int{320, 640, 1280}, p.Breakpoints.Widths)
+		assert.Equal(t, "mediumHigh", *p.Output.PerceptualQuality)
+	}
+}
+
+func TestImagingPolicyRoundTrip(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/imaging/v2/network/production/policies/hero-images", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1-ABCDE", r.Header.Get("Contract"))
+		assert.Equal(t, "set-1", r.Header.Get("Policy-Set"))
+
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, policyFixture)
+		case "PUT":
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, policyFixture, string(body))
+			fmt.Fprint(w, `{"id": "hero-images", "operationPerformed": "UPDATED", "description": "Policy hero-images updated."}`)
+		default:
+			t.Errorf("unexpected method %v", r.Method)
+		}
+	})
+
+	ctx := context.Background()
+	p, _, err := client.Imaging.GetPolicy(ctx, "1-ABCDE", "set-1", ImagingNetworkProduction, "hero-images")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	var transformations []map[string]interface{}
+	if err := json.Unmarshal(p.Transformations, &transformations); err != nil {
+		t.Fatalf("transformations are not valid JSON: %v", err)
+	}
+	assert.Len(t, transformations, 2)
+
+	out, _, err := client.Imaging.PutPolicy(ctx, "1-ABCDE", "set-1", ImagingNetworkProduction, "hero-images", p)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "UPDATED", *out.OperationPerformed)
+}
+
+func TestImagingGetPolicyHistory(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/imaging/v2/network/staging/policies/history/hero-images", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "set-1", r.Header.Get("Policy-Set"))
+		fmt.Fprint(w, `{"items": [
+			{"id": "hero-images", "version": 2, "user": "jdoe", "action": "UPSERT", "dateCreated": "2019-06-01 10:00:00+0000"},
+			{"id": "hero-images", "version": 1, "user": "jdoe", "action": "UPSERT", "dateCreated": "2019-05-01 10:00:00+0000"}
+		], "totalItems": 2}`)
+	})
+
+	h, _, err := client.Imaging.GetPolicyHistory(context.Background(), "1-ABCDE", "set-1", ImagingNetworkStaging, "hero-images")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if assert.Len(t, h.Items, 2) {
+		assert.Equal(t, 2, *h.Items[0].Version)
+		assert.Equal(t, "UPSERT", *h.Items[0].Action)
+	}
+}
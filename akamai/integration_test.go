@@ -0,0 +1,244 @@
+//go:build integration
+// +build integration
+
+package akamai_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// The integration tests run against the real API with the test contract:
+//
+//	AKAMAI_TEST_CONTRACT=1-ABCDE go test -tags integration -run Integration ./akamai/
+//
+// Credentials are read from the AKAMAI_* environment variables when AKAMAI_HOST is set,
+// and from the AKAMAI_TEST_EDGERC_SECTION section of the .edgerc file otherwise. The
+// zones are created below AKAMAI_TEST_DOMAIN, example.com by default.
+
+// integrationZonePrefix starts the name of every zone the integration tests create.
+// mustBeTestZone refuses to touch any other zone.
+const integrationZonePrefix = "akamai-sdk-go-it-"
+
+// integrationPollInterval is how often the tests poll asynchronous operations.
+const integrationPollInterval = 5 * time.Second
+
+// integrationClient returns a client for the real API and the test contract, or skips
+// the test if they are not configured.
+func integrationClient(t *testing.T) (*akamai.Client, string) {
+	t.Helper()
+
+	contract := os.Getenv("AKAMAI_TEST_CONTRACT")
+	if contract == "" {
+		t.Skip("AKAMAI_TEST_CONTRACT is not set")
+	}
+
+	section := os.Getenv("AKAMAI_TEST_EDGERC_SECTION")
+	if section == "" {
+		section = "default"
+	}
+	cc := credentials.NewSharedCredentials("", section)
+	if os.Getenv("AKAMAI_HOST") != "" {
+		cc = credentials.NewEnvCredentials()
+	}
+	if _, err := cc.Get(); err != nil {
+		t.Skipf("no credentials: %v", err)
+	}
+
+	client, err := akamai.NewClient(nil, cc)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	return client, contract
+}
+
+// integrationZone returns a unique name for a throwaway zone.
+func integrationZone(t *testing.T) string {
+	t.Helper()
+
+	domain := os.Getenv("AKAMAI_TEST_DOMAIN")
+	if domain == "" {
+		domain = "example.com"
+	}
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	return fmt.Sprintf("%v%v-%v.%v", integrationZonePrefix, time.Now().UTC().Format("20060102150405"), hex.EncodeToString(b), domain)
+}
+
+// checkTestZone returns an error unless zone is named like the zones integrationZone
+// creates.
+func checkTestZone(zone string) error {
+	name, err := akamai.NormalizeZoneName(zone)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(name, integrationZonePrefix) {
+		return fmt.Errorf("refusing to change zone %q: its name doesn't start with %q", zone, integrationZonePrefix)
+	}
+	return nil
+}
+
+// mustBeTestZone fails the test unless zone was created by integrationZone, so that
+// the tests can never change another zone of the account.
+func mustBeTestZone(t *testing.T, zone string) {
+	t.Helper()
+	if err := checkTestZone(zone); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// deleteIntegrationZone deletes zone and waits for the deletion to complete.
+func deleteIntegrationZone(ctx context.Context, t *testing.T, client *akamai.Client, zone string) error {
+	t.Helper()
+	mustBeTestZone(t, zone)
+
+	zd, resp, err := client.FastDNSv2.DeleteZone(ctx, &akamai.ZoneDeleteRequest{Zones: []string{zone}}, &akamai.ZoneDeleteOptions{Force: true})
+	if err != nil {
+		return err
+	}
+	_, err = client.FastDNSv2.WaitForDeleteZone(ctx, zd, resp, integrationPollInterval)
+	return err
+}
+
+func TestIntegrationZoneLifecycle(t *testing.T) {
+	client, contract := integrationClient(t)
+	zone := integrationZone(t)
+	mustBeTestZone(t, zone)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Minute)
+	defer cancel()
+
+	// Create
+	_, _, err := client.FastDNSv2.CreateZone(ctx, contract, &akamai.ZoneCreateRequest{
+		Zone:    zone,
+		Type:    "PRIMARY",
+		Comment: "akamai-sdk-go integration test",
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	deleted := false
+	t.Cleanup(func() {
+		if deleted {
+			return
+		}
+		// The test context may have expired.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+		if err := deleteIntegrationZone(ctx, t, client, zone); err != nil {
+			t.Errorf("zone %v was not cleaned up: %v", zone, err)
+		}
+	})
+
+	if _, err := client.FastDNSv2.WaitForZoneActive(ctx, zone, integrationPollInterval); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// Records
+	www := &akamai.RecordSetCreateRequest{Zone: zone, Name: "www." + zone, Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}}
+	if _, _, err := client.FastDNSv2.CreateRecordSet(ctx, www); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	rs, _, err := client.FastDNSv2.GetRecordSet(ctx, &akamai.RecordSetOptions{Zone: zone, Name: www.Name, Type: "A"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if rs.GetTTL() != 300 || len(rs.Rdata) != 1 || *rs.Rdata[0] != "192.0.2.1" {
+		t.Fatalf("expect the created record set, got %v", rs)
+	}
+
+	www.Rdata = []string{"192.0.2.1", "192.0.2.2"}
+	if _, _, err := client.FastDNSv2.UpdateRecordSet(ctx, www); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// Change list
+	if _, _, err := client.FastDNSv2.CreateChangeList(ctx, &akamai.ChangeListOptions{Zone: zone}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	pending, _, err := client.FastDNSv2.GetChangeListRecordSets(ctx, zone, &akamai.ChangeListOptions{ShowAll: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if !hasRecordSet(pending.Recordsets, www.Name, "A") {
+		t.Fatalf("expect %v in the change list, got %v", www.Name, pending.Recordsets)
+	}
+	result, _, err := client.FastDNSv2.SubmitValidatedChangeList(ctx, zone)
+	if err != nil {
+		t.Fatalf("expect nil, got %v (%v)", err, result)
+	}
+	if _, err := client.FastDNSv2.WaitForZoneActive(ctx, zone, integrationPollInterval); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// Versions
+	versions, _, err := client.FastDNSv2.ListZoneVersions(ctx, zone, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if len(versions.Versions) < 2 {
+		t.Fatalf("expect a version per change, got %d", len(versions.Versions))
+	}
+
+	// Export
+	records, err := client.FastDNSv2.ListAllZoneRecordSets(ctx, zone, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	export, err := akamai.CanonicalJSON(akamai.SortRecordSets(records), nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	for _, name := range []string{zone, www.Name} {
+		if !strings.Contains(string(export), name) {
+			t.Errorf("expect %v in the export, got %s", name, export)
+		}
+	}
+
+	// Delete
+	if err := deleteIntegrationZone(ctx, t, client, zone); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	deleted = true
+
+	_, _, err = client.FastDNSv2.GetZone(ctx, zone)
+	var aerr *akamai.AkamaiError
+	if !errors.As(err, &aerr) || aerr.Status != http.StatusNotFound {
+		t.Errorf("expect a 404 for the deleted zone, got %v", err)
+	}
+}
+
+// hasRecordSet reports whether records holds the record set of the given name and type.
+func hasRecordSet(records []*akamai.RecordSet, name, rtype string) bool {
+	for _, rs := range records {
+		if n, _ := akamai.NormalizeRecordName(rs.GetName()); n == name && rs.GetType() == rtype {
+			return true
+		}
+	}
+	return false
+}
+
+func TestIntegrationZoneGuard(t *testing.T) {
+	for _, zone := range []string{"example.com", "www." + integrationZonePrefix + "1.example.com", "prod-" + integrationZonePrefix + "1.example.com"} {
+		if checkTestZone(zone) == nil {
+			t.Errorf("expect %q to be refused", zone)
+		}
+	}
+	if err := checkTestZone(integrationZone(t)); err != nil {
+		t.Errorf("expect nil, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package akamai
+
+import (
+	"context"
+	"time"
+)
+
+// The Client exposes its services through the interfaces below rather than the
+// concrete service types, so that consumers can substitute fakes in their tests, such
+// as the ones in the akamaitest package.
+//
+// The interfaces are not meant to be implemented outside of tests. Adding a method to
+// one of them is considered a minor version change, even though it breaks
+// implementations that live outside of this module; removing a method or changing its
+// signature remains a major version change. Fakes should embed the interface, or use
+// the akamaitest fakes which are kept in sync, to keep compiling as methods are added.
+
+// FastDNSv2API is the interface implemented by FastDNSv2Service for the v2 FastDNS API.
+type FastDNSv2API interface {
+	ListZones(ctx context.Context, opt *ZoneListOptions) (*ZoneList, *Response, error)
+	GetZone(ctx context.Context, zone string) (*ZoneMetadata, *Response, error)
+	CreateZone(ctx context.Context, cid string, zone *ZoneCreateRequest) (*Zone, *Response, error)
+	UpdateZone(ctx context.Context, zone *ZoneCreateRequest) (*Zone, *Response, error)
+	DeleteZone(ctx context.Context, zd *ZoneDeleteRequest, zdo *ZoneDeleteOptions) (*ZoneDeleteResponse, *Response, error)
+	DeleteZoneStatus(ctx context.Context, rid string) (*ZoneDeleteResponse, *Response, error)
+	DeleteZoneResult(ctx context.Context, rid string) (*ZoneDeleteResult, *Response, error)
+	GetRecordSet(ctx context.Context, opt *RecordSetOptions) (*RecordSet, *Response, error)
+	CreateRecordSet(ctx context.Context, rs *RecordSetCreateRequest) (*RecordSet, *Response, error)
+	UpdateRecordSet(ctx context.Context, rs *RecordSetCreateRequest) (*RecordSet, *Response, error)
+	DeleteRecordSet(ctx context.Context, opt *RecordSetOptions) (*Response, error)
+	GetZoneRecordSets(ctx context.Context, zone string, opt *ListZoneRecordSetOptions) (*ListZoneRecordSets, *Response, error)
+	GetZoneContract(ctx context.Context, zone string) (*Contract, *Response, error)
+	CreateChangeList(ctx context.Context, cl *ChangeListOptions) (*ChangeList, *Response, error)
+	GetChangeList(ctx context.Context, zone string) (*ChangeList, *Response, error)
+	GetChangeListRecordSets(ctx context.Context, zone string, opt *ChangeListOptions) (*ChangeListRecords, *Response, error)
+	DeleteChangeList(ctx context.Context, zone string) (*Response, error)
+	SubmitChangeList(ctx context.Context, zone string) (*Response, error)
+	PlanRecordSets(ctx context.Context, zone string, desired []*RecordSetCreateRequest, opt *SyncOptions) (*SyncPlan, error)
+	ApplySyncPlan(ctx context.Context, plan *SyncPlan, opt *SyncOptions) error
+	SyncRecordSets(ctx context.Context, zone string, desired []*RecordSetCreateRequest, opt *SyncOptions) (*SyncPlan, error)
+	SummarizeZones(ctx context.Context, zones []string, opt *BulkOptions) []*ZoneSummary
+	WaitForZoneActive(ctx context.Context, zone string, interval time.Duration) (*ZoneMetadata, error)
+	ReplaceRecordSets(ctx context.Context, zone string, rs []*RecordSetCreateRequest) (*Response, error)
+	VerifyDelegation(ctx context.Context, zone string, r NSResolver) error
+	OnboardZones(ctx context.Context, specs []ZoneSpec, opt *OnboardOptions) ([]*OnboardProgress, *OnboardCheckpoint)
+	ListGroups(ctx context.Context, opt *DataOptions) ([]*Group, *Response, error)
+	ListContracts(ctx context.Context, opt *DataOptions) ([]*Contract, *Response, error)
+	GetAuthorities(ctx context.Context, contractIDs []string) ([]*ContractAuthorities, *Response, error)
+	GetRecordTypes(ctx context.Context, zone string) ([]string, *Response, error)
+	WaitForDeleteZone(ctx context.Context, zd *ZoneDeleteResponse, resp *Response, interval time.Duration) (*ZoneDeleteResult, error)
+	SetZoneComment(ctx context.Context, zone, comment string) (*Zone, *Response, error)
+	SetZoneEndCustomerID(ctx context.Context, zone, id string) (*Zone, *Response, error)
+	ListZonesByGroup(ctx context.Context, gid int, opt *ZoneListOptions) (*ZoneList, *Response, error)
+	ChangeZoneGroup(ctx context.Context, zone string, gid int) (*Zone, *Response, error)
+	WaitForZoneGroup(ctx context.Context, zone string, gid int, interval time.Duration) (*ZoneMetadata, error)
+	DeleteChangeListIf(ctx context.Context, zone string, opt *DeleteChangeListOptions) (*Response, error)
+	ListZoneVersions(ctx context.Context, zone string, opt *ZoneVersionListOptions) (*ZoneVersionList, *Response, error)
+	GetZoneVersionRecordSets(ctx context.Context, zone, versionID string, opt *ListZoneRecordSetOptions) (*ListZoneRecordSets, *Response, error)
+	GetRecordHistory(ctx context.Context, zone, name, rtype string, limit int) ([]*RecordHistoryEntry, error)
+	ListAllZones(ctx context.Context, opt *ZoneListOptions) ([]*Zone, error)
+	ListAllZoneRecordSets(ctx context.Context, zone string, opt *ListZoneRecordSetOptions) ([]*RecordSet, error)
+	GetRecordTypesForName(ctx context.Context, zone, name string) ([]string, *Response, error)
+	VerifyRecordServed(ctx context.Context, zone, name, rtype string, expectedRdata []string, opt *VerifyServedOptions) ([]*AuthorityAnswer, error)
+	CheckZoneCapacity(ctx context.Context, contractID string, zonesToAdd int) (int, error)
+	SupportedRecordTypes(ctx context.Context, zone, name string) ([]string, error)
+	ListZonesWithContracts(ctx context.Context, opt *ZoneListOptions) ([]*ZoneWithContract, []error, error)
+	ValidateChangeList(ctx context.Context, zone string) (*ValidationResult, *Response, error)
+	SubmitValidatedChangeList(ctx context.Context, zone string) (*ValidationResult, *Response, error)
+	GetRecordSetsChangedSince(ctx context.Context, zone, sinceVersionID string, opt *ChangedSinceOptions) (*SyncPlan, error)
+	GetZoneLabels(ctx context.Context, zone string) (ZoneLabels, *Response, error)
+	SetZoneLabel(ctx context.Context, zone, key, value string) (*Zone, *Response, error)
+	ListZonesByLabel(ctx context.Context, key, value string) ([]*Zone, error)
+	ForEachRecordSet(ctx context.Context, zone string, opt *ListZoneRecordSetOptions, fn func(rs *RecordSet) error) error
+	CompareZoneVersions(ctx context.Context, zone, v1, v2 string) (*SyncPlan, error)
+	ClaimRecord(ctx context.Context, zone, name, rtype, owner string) error
+	ReleaseRecord(ctx context.Context, zone, name, rtype, owner string) error
+	ListOwnedRecords(ctx context.Context, zone, owner string) ([]*RecordClaim, error)
+	DelegationReport(ctx context.Context, zones []string, concurrency int, r NSResolver) (*DelegationReport, error)
+	ZoneStats(ctx context.Context, zone string) (*ZoneStats, error)
+	FindDanglingCNAMEs(ctx context.Context, zones []string, opt *DanglingCNAMEOptions) ([]*DanglingCNAME, error)
+	CreateZoneWithOptions(ctx context.Context, cid string, zone *ZoneCreateRequest, opt *CreateZoneOptions) (*CreateZoneResult, *Response, error)
+	GetZonesMetadata(ctx context.Context, zones []string, opt *ZonesMetadataOptions) (map[string]*ZoneMetadataResult, error)
+}
+
+// ContractsAPI is the interface implemented by ContractsService for the Contracts API.
+type ContractsAPI interface {
+	ListContracts(ctx context.Context) ([]string, *Response, error)
+	ListProducts(ctx context.Context, contractID string) (*ContractProducts, *Response, error)
+	FindContractsWithProduct(ctx context.Context, product string) ([]string, error)
+}
+
+// ImagingAPI is the interface implemented by ImagingService for the Image and Video Manager API.
+type ImagingAPI interface {
+	ListPolicySets(ctx context.Context, contractID string) ([]*PolicySet, *Response, error)
+	GetPolicySet(ctx context.Context, contractID, policySetID string) (*PolicySet, *Response, error)
+	ListPolicies(ctx context.Context, contractID, policySetID, network string) (*PolicyList, *Response, error)
+	GetPolicy(ctx context.Context, contractID, policySetID, network, policyID string) (*Policy, *Response, error)
+	PutPolicy(ctx context.Context, contractID, policySetID, network, policyID string, policy *Policy) (*PolicyUpdateResponse, *Response, error)
+	GetPolicyHistory(ctx context.Context, contractID, policySetID, network, policyID string) (*PolicyHistory, *Response, error)
+}
+
+// FirewallRulesAPI is the interface implemented by FirewallRulesService for the Firewall Rules Notification API.
+type FirewallRulesAPI interface {
+	ListServices(ctx context.Context) ([]*FirewallService, *Response, error)
+	ListSubscriptions(ctx context.Context) (*FirewallSubscriptions, *Response, error)
+	UpdateSubscriptions(ctx context.Context, subs *FirewallSubscriptions) (*FirewallSubscriptions, *Response, error)
+	ListCIDRBlocks(ctx context.Context, opt *CIDRBlockListOptions) ([]*CIDRBlock, *Response, error)
+	ListCIDRBlocksChangedSince(ctx context.Context, since time.Time) ([]*CIDRBlock, error)
+}
+
+// EdgeKVAPI is the interface implemented by EdgeKVService for the EdgeKV API.
+type EdgeKVAPI interface {
+	InitializeStore(ctx context.Context) (*EdgeKVStoreStatus, *Response, error)
+	ListNamespaces(ctx context.Context, network string) (*EdgeKVNamespaceList, *Response, error)
+	CreateNamespace(ctx context.Context, network string, ns *EdgeKVNamespace) (*EdgeKVNamespace, *Response, error)
+	GetItem(ctx context.Context, opt *EdgeKVItemOptions) (string, *Response, error)
+	UpsertItem(ctx context.Context, opt *EdgeKVItemOptions, value interface{}) (string, *Response, error)
+	DeleteItem(ctx context.Context, opt *EdgeKVItemOptions) (string, *Response, error)
+	CreateAccessToken(ctx context.Context, token *EdgeKVAccessTokenRequest) (*EdgeKVAccessToken, *Response, error)
+}
+
+// ReportingAPI is the interface implemented by ReportingService for the Reporting API.
+type ReportingAPI interface {
+	GetReportVersions(ctx context.Context, name string) ([]*ReportVersion, *Response, error)
+	RunReport(ctx context.Context, name string, version int, opt ReportOptions) (*ReportData, *Response, error)
+}
+
+// HAPIAPI is the interface implemented by HAPIService for the Edge Hostnames API.
+type HAPIAPI interface {
+	ListEdgeHostnames(ctx context.Context, opt *EdgeHostnameListOptions) (*EdgeHostnameList, *Response, error)
+	GetEdgeHostname(ctx context.Context, recordName, dnsZone string) (*EdgeHostname, *Response, error)
+	PatchEdgeHostname(ctx context.Context, recordName, dnsZone string, patch []JSONPatchOperation) (*ChangeRequest, *Response, error)
+	DeleteEdgeHostname(ctx context.Context, recordName, dnsZone string) (*ChangeRequest, *Response, error)
+	GetChangeRequest(ctx context.Context, changeID int) (*ChangeRequest, *Response, error)
+	WaitForChangeRequest(ctx context.Context, changeID int, interval time.Duration) (*ChangeRequest, error)
+}
+
+// SandboxAPI is the interface implemented by SandboxService for the Sandbox API.
+type SandboxAPI interface {
+	ListSandboxes(ctx context.Context) (*SandboxList, *Response, error)
+	GetSandbox(ctx context.Context, sandboxID string) (*Sandbox, *Response, error)
+	CreateSandbox(ctx context.Context, sb *SandboxCreateRequest) (*Sandbox, *Response, error)
+	UpdateSandbox(ctx context.Context, sandboxID string, sb *SandboxUpdateRequest) (*Sandbox, *Response, error)
+	DeleteSandbox(ctx context.Context, sandboxID string) (*Response, error)
+	CloneSandbox(ctx context.Context, sandboxID string, sb *SandboxCreateRequest) (*Sandbox, *Response, error)
+	RotateJWT(ctx context.Context, sandboxID string) (*Sandbox, *Response, error)
+}
+
+// PurgeAPI is the interface implemented by PurgeService for the Fast Purge API.
+type PurgeAPI interface {
+	Purge(ctx context.Context, p *PurgeRequest) (*PurgeResult, *Response, error)
+}
+
+var (
+	_ FastDNSv2API     = (*FastDNSv2Service)(nil)
+	_ ContractsAPI     = (*ContractsService)(nil)
+	_ ImagingAPI       = (*ImagingService)(nil)
+	_ FirewallRulesAPI = (*FirewallRulesService)(nil)
+	_ EdgeKVAPI        = (*EdgeKVService)(nil)
+	_ ReportingAPI     = (*ReportingService)(nil)
+	_ HAPIAPI          = (*HAPIService)(nil)
+	_ SandboxAPI       = (*SandboxService)(nil)
+	_ PurgeAPI         = (*PurgeService)(nil)
+)
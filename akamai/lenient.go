@@ -0,0 +1,194 @@
+package akamai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DecodeWarning describes an element of a list response that could not be decoded in
+// lenient mode, and was left out of the decoded list. See WithLenientDecode.
+type DecodeWarning struct {
+	// Field is the JSON key of the list in the response object, such as "recordsets".
+	// It is empty when the response is the list itself.
+	Field string
+
+	// Index is the position of the element in the list, counting the elements that
+	// were left out.
+	Index int
+
+	// Raw holds the JSON of the element.
+	Raw json.RawMessage
+
+	Err error
+}
+
+func (w *DecodeWarning) String() string {
+	if w.Field == "" {
+		return fmt.Sprintf("element %d: %v", w.Index, w.Err)
+	}
+	return fmt.Sprintf("%v[%d]: %v", w.Field, w.Index, w.Err)
+}
+
+type lenientDecodeKey struct{}
+
+// WithLenientDecode returns a copy of ctx with which the elements of list responses are
+// decoded one by one, such as the record sets of GetZoneRecordSets. The elements that
+// fail to decode are left out of the results and reported in the DecodeWarnings of the
+// Response instead of failing the whole call. The other parts of the response, and
+// responses that are not valid JSON, still fail it. The methods that don't return the
+// Response, such as ListAllZoneRecordSets, drop the warnings.
+//
+// Decoding is strict by default.
+func WithLenientDecode(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lenientDecodeKey{}, true)
+}
+
+// LenientDecode reports whether ctx was set up WithLenientDecode.
+func LenientDecode(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	lenient, _ := ctx.Value(lenientDecodeKey{}).(bool)
+	return lenient
+}
+
+// decodeLenient unmarshals data into v, a pointer to a list or to a struct holding
+// lists, decoding the elements of the lists one by one. The elements that fail to
+// decode are returned as warnings. The error of a strict decode is returned if the
+// failure can't be narrowed down to elements.
+func decodeLenient(data []byte, v interface{}) ([]*DecodeWarning, error) {
+	strictErr := json.Unmarshal(data, v)
+	if strictErr == nil {
+		return nil, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, strictErr
+	}
+	// Start over from what the strict decode partially filled in.
+	rv = rv.Elem()
+	rv.Set(reflect.Zero(rv.Type()))
+	for rv.Kind() == reflect.Ptr {
+		rv.Set(reflect.New(rv.Type().Elem()))
+		rv = rv.Elem()
+	}
+
+	var warnings []*DecodeWarning
+	var err error
+	switch rv.Kind() {
+	case reflect.Slice:
+		warnings, err = decodeElements(data, rv, "")
+	case reflect.Struct:
+		warnings, err = decodeFields(data, rv)
+	default:
+		return nil, strictErr
+	}
+	if err != nil {
+		return nil, strictErr
+	}
+	return warnings, nil
+}
+
+// decodeFields decodes the JSON object data into the struct rv, the lists of its
+// fields element by element.
+func decodeFields(data []byte, rv reflect.Value) ([]*DecodeWarning, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var warnings []*DecodeWarning
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		field, ok := fieldByJSONName(rv, key)
+		if !ok {
+			continue
+		}
+		if field.Kind() == reflect.Slice && bytes.HasPrefix(raw, []byte("[")) {
+			w, err := decodeElements(raw, field, key)
+			if err != nil {
+				return nil, err
+			}
+			warnings = append(warnings, w...)
+			continue
+		}
+		if err := json.Unmarshal(raw, field.Addr().Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return warnings, nil
+}
+
+// decodeElements appends the elements of the JSON array data that decode to the slice
+// rv, and returns a warning for each of the others.
+func decodeElements(data []byte, rv reflect.Value, field string) ([]*DecodeWarning, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := expectDelim(dec, '['); err != nil {
+		return nil, err
+	}
+
+	var warnings []*DecodeWarning
+	elemType := rv.Type().Elem()
+	for i := 0; dec.More(); i++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		elem := reflect.New(elemType)
+		if err := json.Unmarshal(raw, elem.Interface()); err != nil {
+			warnings = append(warnings, &DecodeWarning{Field: field, Index: i, Raw: raw, Err: err})
+			continue
+		}
+		rv.Set(reflect.Append(rv, elem.Elem()))
+	}
+	return warnings, nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("expected %v, got %v", want, tok)
+	}
+	return nil
+}
+
+// fieldByJSONName returns the exported field of the struct rv that the JSON key name
+// decodes into, matching case-insensitively like encoding/json.
+func fieldByJSONName(rv reflect.Value, name string) (reflect.Value, bool) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+		if tag == "" {
+			tag = f.Name
+		}
+		if strings.EqualFold(tag, name) {
+			return rv.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
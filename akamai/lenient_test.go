@@ -0,0 +1,116 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// corruptedRecordSets returns a record set list of n elements whose element bad has a
+// TTL that is not a number.
+func corruptedRecordSets(n, bad int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `{"metadata": {"zone": "example.com", "totalElements": %d}, "recordsets": [`, n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		ttl := "300"
+		if i == bad {
+			ttl = `"soon"`
+		}
+		fmt.Fprintf(&b, `{"name": "host%d.example.com", "type": "A", "ttl": %v, "rdata": ["192.0.2.1"]}`, i, ttl)
+	}
+	b.WriteString("]}")
+	return b.String()
+}
+
+func TestLenientDecode(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/zones/example.com/recordsets", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, corruptedRecordSets(10000, 4242))
+	})
+
+	_, _, err := client.FastDNSv2.GetZoneRecordSets(context.Background(), "example.com", nil)
+	assert.Error(t, err, "decoding is strict by default")
+
+	list, resp, err := client.FastDNSv2.GetZoneRecordSets(WithLenientDecode(context.Background()), "example.com", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 10000, list.Metadata.GetTotalElements())
+	if assert.Len(t, list.RecordSets, 9999) {
+		assert.Equal(t, "host4241.example.com", list.RecordSets[4241].GetName())
+		assert.Equal(t, "host4243.example.com", list.RecordSets[4242].GetName())
+	}
+	if assert.Len(t, resp.DecodeWarnings, 1) {
+		w := resp.DecodeWarnings[0]
+		assert.Equal(t, "recordsets", w.Field)
+		assert.Equal(t, 4242, w.Index)
+		assert.Equal(t, `{"name": "host4242.example.com", "type": "A", "ttl": "soon", "rdata": ["192.0.2.1"]}`, string(w.Raw))
+		var te *json.UnmarshalTypeError
+		assert.True(t, errors.As(w.Err, &te), "got %v", w.Err)
+		assert.True(t, strings.HasPrefix(w.String(), "recordsets[4242]: "), w.String())
+	}
+}
+
+func TestDecodeLenientList(t *testing.T) {
+	var groups []*Group
+	warnings, err := decodeLenient([]byte(`[{"groupId": 1, "groupName": "one"}, {"groupId": "two"}, {"groupId": 3, "groupName": "three"}]`), &groups)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, groups, 2) {
+		assert.Equal(t, 3, groups[1].GetGroupID())
+	}
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "", warnings[0].Field)
+		assert.Equal(t, 1, warnings[0].Index)
+		assert.True(t, strings.HasPrefix(warnings[0].String(), "element 1: "), warnings[0].String())
+	}
+}
+
+func TestLenientDecodeFailures(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	body := ""
+	mux.HandleFunc("/config-dns/v2/zones/example.com/recordsets", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	})
+	ctx := WithLenientDecode(context.Background())
+
+	for _, tt := range []struct {
+		name string
+		body string
+	}{
+		{"invalid JSON", `{"recordsets": [{"name": "www.example.com",]}`},
+		{"corrupted metadata", `{"metadata": {"page": "first"}, "recordsets": []}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			body = tt.body
+			_, resp, err := client.FastDNSv2.GetZoneRecordSets(ctx, "example.com", nil)
+			assert.Error(t, err)
+			if assert.NotNil(t, resp) {
+				assert.Nil(t, resp.DecodeWarnings)
+			}
+		})
+	}
+
+	body = corruptedRecordSets(3, -1)
+	list, resp, err := client.FastDNSv2.GetZoneRecordSets(ctx, "example.com", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, list.RecordSets, 3)
+	assert.Nil(t, resp.DecodeWarnings)
+}
@@ -0,0 +1,164 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultCloseTimeout is how long Close waits for the work in flight to end.
+const DefaultCloseTimeout = 30 * time.Second
+
+// ErrClientClosed is the error of the requests made with a client after Close or
+// Shutdown.
+var ErrClientClosed = errors.New("client is closed")
+
+// lifecycle tracks the requests in flight and the background components of a client,
+// so that Close can stop them and wait for them.
+type lifecycle struct {
+	mu     sync.RWMutex
+	closed bool
+
+	// stops holds the cancel functions of the contexts of the running components, by
+	// the identifier StartBackground gave them.
+	stops map[int]context.CancelFunc
+	next  int
+
+	// wg counts the requests in flight and the running components.
+	wg sync.WaitGroup
+}
+
+// enter records the start of a request, unless the client is closed.
+func (l *lifecycle) enter() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		return false
+	}
+	l.wg.Add(1)
+	return true
+}
+
+// exit records the end of a request entered.
+func (l *lifecycle) exit() {
+	l.wg.Done()
+}
+
+// StartBackground registers a background component built on the client, such as a
+// watcher or a queue drainer, so that Close stops it and waits for it. It returns a
+// copy of ctx that is canceled when the client is closed, which the component must
+// run under, and a function that the component must call once it has stopped. The
+// context is already canceled if the client is closed.
+//
+// The components of this package, such as ZoneWatcher and ChangeFeed, register
+// themselves when they are made with a client's FastDNSv2.
+func (c *Client) StartBackground(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	l := &c.lifecycle
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		cancel()
+		return ctx, func() {}
+	}
+
+	id := l.next
+	l.next++
+	if l.stops == nil {
+		l.stops = map[int]context.CancelFunc{}
+	}
+	l.stops[id] = cancel
+	l.wg.Add(1)
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			l.mu.Lock()
+			delete(l.stops, id)
+			l.mu.Unlock()
+			cancel()
+			l.wg.Done()
+		})
+	}
+}
+
+// Shutdown closes the client: it makes the requests that follow fail with
+// ErrClientClosed, stops the background components registered with StartBackground,
+// and waits for them and for the requests in flight to end, or for ctx to be done,
+// whose error it then returns. The idle connections of the transport NewClient built
+// are closed. Shutdown may be called more than once.
+func (c *Client) Shutdown(ctx context.Context) error {
+	l := &c.lifecycle
+	l.mu.Lock()
+	l.closed = true
+	stops := l.stops
+	l.stops = nil
+	l.mu.Unlock()
+
+	for _, stop := range stops {
+		stop()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		l.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if c.transport != nil {
+		c.transport.CloseIdleConnections()
+	}
+	return nil
+}
+
+// Close shuts the client down, as Shutdown does, waiting up to DefaultCloseTimeout
+// for the work in flight to end.
+func (c *Client) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCloseTimeout)
+	defer cancel()
+	return c.Shutdown(ctx)
+}
+
+// Closed reports whether the client was closed with Close or Shutdown.
+func (c *Client) Closed() bool {
+	c.lifecycle.mu.RLock()
+	defer c.lifecycle.mu.RUnlock()
+	return c.lifecycle.closed
+}
+
+// lifecycleClienter is implemented by FastDNSv2Service, and by the decorators of
+// FastDNSv2API that forward to one, such as DataCache, to give the client the
+// background components reading zones with them register with.
+type lifecycleClienter interface {
+	lifecycleClient() *Client
+}
+
+// lifecycleClient returns the client of s.
+func (s *FastDNSv2Service) lifecycleClient() *Client {
+	return s.client
+}
+
+// lifecycleClientOf returns the client of api, or nil if api is neither a service nor a
+// decorator of one, such as a fake.
+func lifecycleClientOf(api FastDNSv2API) *Client {
+	if l, ok := api.(lifecycleClienter); ok {
+		return l.lifecycleClient()
+	}
+	return nil
+}
+
+// startBackground registers a background component reading zones with api, if api has
+// a client. See StartBackground.
+func startBackground(ctx context.Context, api FastDNSv2API) (context.Context, func()) {
+	if c := lifecycleClientOf(api); c != nil {
+		return c.StartBackground(ctx)
+	}
+	return ctx, func() {}
+}
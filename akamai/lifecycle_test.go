@@ -0,0 +1,159 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+	"github.com/trussworks/akamai-sdk-go/akamai/queue"
+)
+
+// leakedGoroutines returns the stacks of the goroutines running code of the client or
+// of the queue, other than the calling one, waiting a second for them to end.
+func leakedGoroutines() []string {
+	var leaked []string
+	for deadline := time.Now().Add(time.Second); ; {
+		buf := make([]byte, 1<<20)
+		stacks := strings.Split(string(buf[:runtime.Stack(buf, true)]), "\n\n")
+
+		leaked = nil
+		for _, stack := range stacks[1:] {
+			if strings.Contains(stack, "akamai-sdk-go/akamai.") || strings.Contains(stack, "akamai-sdk-go/akamai/queue.") {
+				leaked = append(leaked, stack)
+			}
+		}
+		if len(leaked) == 0 || time.Now().After(deadline) {
+			return leaked
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClientClose(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.net", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	w := akamai.NewZoneWatcher(client.FastDNSv2)
+	var watches []<-chan akamai.ZoneChange
+	for _, zone := range []string{"example.com", "example.net"} {
+		ch, err := w.WatchZone(ctx, zone, 5*time.Millisecond)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		watches = append(watches, ch)
+	}
+
+	feed := akamai.NewChangeFeed(client.FastDNSv2, nil)
+	feedErr := make(chan error, 1)
+	go func() { feedErr <- feed.Run(ctx, 5*time.Millisecond) }()
+
+	q, err := queue.OpenFile(filepath.Join(t.TempDir(), "queue"))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	defer q.Close()
+	if _, err := q.Enqueue(&queue.Operation{Key: "example.com", Method: "GET", Path: "config-dns/v2/zones/example.com"}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	d := &queue.Drainer{Client: client, Queue: q, Interval: 5 * time.Millisecond}
+	drainErr := make(chan error, 1)
+	go func() { drainErr <- d.Run(ctx) }()
+
+	// Let the components poll a few times.
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, q.Stats().Depth)
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, client.Closed())
+
+	for _, ch := range watches {
+		for range ch {
+		}
+	}
+	assert.Equal(t, akamai.ErrClientClosed, <-feedErr)
+	assert.Equal(t, akamai.ErrClientClosed, <-drainErr)
+	assert.Empty(t, leakedGoroutines())
+
+	_, _, err = client.FastDNSv2.GetZone(ctx, "example.com")
+	assert.True(t, errors.Is(err, akamai.ErrClientClosed), "got %v", err)
+	_, err = client.Call(ctx, "GET", "config-dns/v2/zones", nil, nil)
+	assert.Equal(t, akamai.ErrClientClosed, err)
+
+	// The components started after Close stop at once.
+	_, err = w.WatchZone(ctx, "example.com", time.Millisecond)
+	assert.True(t, errors.Is(err, akamai.ErrClientClosed), "got %v", err)
+	bg, done := client.StartBackground(ctx)
+	assert.Equal(t, context.Canceled, bg.Err())
+	done()
+	assert.NoError(t, client.Close())
+}
+
+// TestClientCloseDataCache checks that the components reading zones through a decorator
+// of the client's FastDNSv2, such as DataCache, register with the client too.
+func TestClientCloseDataCache(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	client.FastDNSv2 = akamai.NewDataCache(client.FastDNSv2, time.Minute)
+	ctx := context.Background()
+
+	watch, err := akamai.NewZoneWatcher(client.FastDNSv2).WatchZone(ctx, "example.com", 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	feed := akamai.NewChangeFeed(client.FastDNSv2, nil)
+	feedErr := make(chan error, 1)
+	go func() { feedErr <- feed.Run(ctx, 5*time.Millisecond) }()
+
+	time.Sleep(20 * time.Millisecond)
+	if err := client.Close(); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	for range watch {
+	}
+	assert.Equal(t, akamai.ErrClientClosed, <-feedErr)
+	assert.Empty(t, leakedGoroutines())
+}
+
+func TestClientShutdownWaitsForRequests(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"zone":"example.com"}`))
+	}))
+	defer server.Close()
+	client := akamaitest.NewStaticTestClient(t, server.URL)
+	ctx := context.Background()
+
+	getErr := make(chan error, 1)
+	go func() {
+		_, _, err := client.FastDNSv2.GetZone(ctx, "example.com")
+		getErr <- err
+	}()
+	<-started
+
+	// The request in flight holds the shutdown up until its timeout.
+	shutdownCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, client.Shutdown(shutdownCtx))
+
+	close(release)
+	assert.NoError(t, client.Shutdown(ctx))
+	assert.NoError(t, <-getErr)
+	assert.Empty(t, leakedGoroutines())
+}
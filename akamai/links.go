@@ -0,0 +1,167 @@
+package akamai
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// Links holds the links of a response, by relation. The zero value holds none.
+type Links struct {
+	rels map[string]string
+}
+
+// Get returns the absolute URL of the link with the given relation, and reports
+// whether the response has one.
+func (l Links) Get(rel string) (string, bool) {
+	href, ok := l.rels[rel]
+	return href, ok
+}
+
+// set records a link unless one with the same relation was found first.
+func (l *Links) set(rel, href string, base *url.URL) {
+	if rel == "" || href == "" {
+		return
+	}
+	if _, ok := l.rels[rel]; ok {
+		return
+	}
+
+	if base != nil {
+		u, err := base.Parse(href)
+		if err != nil {
+			return
+		}
+		href = u.String()
+	}
+
+	if l.rels == nil {
+		l.rels = map[string]string{}
+	}
+	l.rels[rel] = href
+}
+
+// Links returns the links of the response, resolved against the client's BaseURL.
+// They are found in, by order of precedence:
+//
+//   - the Location header, as the "location" relation;
+//   - the Link header (RFC 8288), by their rel parameter;
+//   - HAL "_links" objects of a decoded JSON body, by their key;
+//   - "links" arrays of {"rel", "href"} objects of a decoded JSON body;
+//   - the string fields of a decoded JSON body whose name ends in "Link", such as the
+//     "activationLink" of PAPI, by the name of the field.
+//
+// Bodies are only searched when Do decoded them into a value.
+func (r *Response) Links() Links {
+	var l Links
+	if r == nil || r.Response == nil {
+		return l
+	}
+
+	base := r.base
+	if base == nil && r.Request != nil {
+		base = r.Request.URL
+	}
+
+	if loc := r.Header.Get("Location"); loc != "" {
+		l.set("location", loc, base)
+	}
+	for _, h := range r.Header.Values("Link") {
+		parseLinkHeader(&l, h, base)
+	}
+	if r.linkBody != nil {
+		parseBodyLinks(&l, r.linkBody, base)
+	}
+
+	return l
+}
+
+// mayHaveLinks reports whether a JSON body may hold links that parseBodyLinks finds, so
+// that other bodies are not kept around.
+func mayHaveLinks(body []byte) bool {
+	return bytes.Contains(body, []byte(`"_links"`)) ||
+		bytes.Contains(body, []byte(`"links"`)) ||
+		bytes.Contains(body, []byte(`Link"`))
+}
+
+// parseLinkHeader adds the links of a Link header, such as
+// `</zones?page=2>; rel="next", </zones?page=9>; rel="last"`.
+func parseLinkHeader(l *Links, h string, base *url.URL) {
+	for h != "" {
+		start := strings.IndexByte(h, '<')
+		end := strings.IndexByte(h, '>')
+		if start < 0 || end < start {
+			return
+		}
+		href := h[start+1 : end]
+		h = h[end+1:]
+
+		params := h
+		if i := strings.IndexByte(h, '<'); i >= 0 {
+			params, h = h[:i], h[i:]
+		} else {
+			h = ""
+		}
+
+		for _, p := range strings.Split(params, ";") {
+			kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+			if len(kv) != 2 || !strings.EqualFold(kv[0], "rel") {
+				continue
+			}
+			rels := strings.Trim(strings.TrimSpace(strings.TrimRight(kv[1], ", ")), `"`)
+			for _, rel := range strings.Fields(rels) {
+				l.set(rel, href, base)
+			}
+		}
+	}
+}
+
+type halLink struct {
+	Href string `json:"href"`
+}
+
+type relLink struct {
+	Rel  string `json:"rel"`
+	Href string `json:"href"`
+}
+
+// parseBodyLinks adds the links of the top level object of a JSON body.
+func parseBodyLinks(l *Links, body []byte, base *url.URL) {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		return
+	}
+
+	var hal map[string]json.RawMessage
+	if json.Unmarshal(fields["_links"], &hal) == nil {
+		for rel, raw := range hal {
+			var one halLink
+			if json.Unmarshal(raw, &one) == nil {
+				l.set(rel, one.Href, base)
+				continue
+			}
+			var many []halLink
+			if json.Unmarshal(raw, &many) == nil && len(many) > 0 {
+				l.set(rel, many[0].Href, base)
+			}
+		}
+	}
+
+	var links []relLink
+	if json.Unmarshal(fields["links"], &links) == nil {
+		for _, link := range links {
+			l.set(link.Rel, link.Href, base)
+		}
+	}
+
+	for name, raw := range fields {
+		if !strings.HasSuffix(name, "Link") {
+			continue
+		}
+		var href string
+		if json.Unmarshal(raw, &href) == nil {
+			l.set(name, href, base)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseLinksPAPIActivation(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/papi/v1/properties/prp_173136/activations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/papi/v1/properties/prp_173136/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"activationLink": "/papi/v1/properties/prp_173136/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`)
+	})
+
+	var v map[string]string
+	resp, err := client.Call(context.Background(), "POST", "papi/v1/properties/prp_173136/activations", map[string]string{"network": "STAGING"}, &v)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	links := resp.Links()
+	want := serverURL + "/papi/v1/properties/prp_173136/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"
+	href, ok := links.Get("activationLink")
+	assert.True(t, ok)
+	assert.Equal(t, want, href)
+	href, ok = links.Get("location")
+	assert.True(t, ok)
+	assert.Equal(t, want, href)
+	_, ok = links.Get("self")
+	assert.False(t, ok)
+}
+
+func TestResponseLinks(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/sandbox-api/v1/sandboxes/sb_1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("Link", `<https://other.example/sandboxes?page=2>; rel="next", <sandboxes?page=9>; rel="last"`)
+		fmt.Fprint(w, `{
+			"sandboxId": "sb_1",
+			"_links": {"self": {"href": "/sandbox-api/v1/sandboxes/sb_1"}, "properties": [{"href": "properties/p_1"}, {"href": "properties/p_2"}], "next": {"href": "/ignored"}},
+			"links": [{"rel": "clone", "href": "/sandbox-api/v1/sandboxes/sb_1/clone"}]
+		}`)
+	})
+
+	var v map[string]interface{}
+	resp, err := client.Call(context.Background(), "GET", "sandbox-api/v1/sandboxes/sb_1", nil, &v)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	links := resp.Links()
+	for rel, want := range map[string]string{
+		"next":       "https://other.example/sandboxes?page=2",
+		"last":       serverURL + "/sandboxes?page=9",
+		"self":       serverURL + "/sandbox-api/v1/sandboxes/sb_1",
+		"properties": serverURL + "/properties/p_1",
+		"clone":      serverURL + "/sandbox-api/v1/sandboxes/sb_1/clone",
+	} {
+		href, ok := links.Get(rel)
+		assert.True(t, ok, rel)
+		assert.Equal(t, want, href, rel)
+	}
+
+	// Bodies without links are not kept.
+	mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sandboxId": "sb_1"}`)
+	})
+	resp, err = client.Call(context.Background(), "GET", "plain", nil, &v)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Nil(t, resp.linkBody)
+	_, ok := resp.Links().Get("self")
+	assert.False(t, ok)
+
+	var nilResp *Response
+	_, ok = nilResp.Links().Get("location")
+	assert.False(t, ok)
+}
+
+func TestWaitForDeleteZone(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/zones/delete-requests", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.Header().Set("Location", "/config-dns/v2/status/delete/15bc138f")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"requestId": "15bc138f", "expirationDate": "2020-10-28T17:10:04.515792Z", "isComplete": false}`)
+	})
+
+	var polls int
+	mux.HandleFunc("/config-dns/v2/status/delete/15bc138f", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		polls++
+		fmt.Fprintf(w, `{"requestId": "15bc138f", "zonesSubmitted": 1, "successCount": %d, "failureCount": 0, "isComplete": %v}`, polls-1, polls > 1)
+	})
+	mux.HandleFunc("/config-dns/v2/status/delete/15bc138f/result", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"requestId": "15bc138f", "successfullyDeletedZones": ["example.com"], "failedZones": []}`)
+	})
+
+	zd, resp, err := client.FastDNSv2.DeleteZone(context.Background(), &ZoneDeleteRequest{Zones: []string{"example.com"}}, &ZoneDeleteOptions{Force: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	result, err := client.FastDNSv2.WaitForDeleteZone(context.Background(), zd, resp, time.Millisecond)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 2, polls)
+	assert.Equal(t, []*string{String("example.com")}, result.DeletedZones)
+}
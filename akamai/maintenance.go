@@ -0,0 +1,88 @@
+package akamai
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaintenanceProblemType is the type of the problem documents of the 503 responses the
+// API sends during its maintenance windows.
+const MaintenanceProblemType = "https://problems.luna.akamaiapis.net/common/maintenance"
+
+// ErrServiceMaintenance is matched by errors.Is for the errors of the requests the API
+// refused because it was in a maintenance window. They are *AkamaiError whose
+// Maintenance is set.
+var ErrServiceMaintenance = errors.New("service is in maintenance")
+
+// MaintenanceWindow is the maintenance window a 503 response reported, from the
+// maintenanceWindow of its problem document or its Retry-After header.
+type MaintenanceWindow struct {
+	// Start and End bound the window. Either is zero if the response didn't say.
+	Start time.Time `json:"start,omitempty"`
+	End   time.Time `json:"end,omitempty"`
+}
+
+// Remaining returns how long the window lasts after now, or zero if its end is unknown
+// or past.
+func (w *MaintenanceWindow) Remaining(now time.Time) time.Duration {
+	if w == nil || w.End.IsZero() || !w.End.After(now) {
+		return 0
+	}
+	return w.End.Sub(now)
+}
+
+// MaintenanceRemaining returns how long the maintenance window reported by err lasts
+// after now, and reports whether err is an ErrServiceMaintenance. The duration is zero
+// if the end of the window is unknown. Retry loops wait that long rather than backing
+// off as usual.
+func MaintenanceRemaining(err error, now time.Time) (time.Duration, bool) {
+	var ae *AkamaiError
+	if !errors.As(err, &ae) || ae.Maintenance == nil {
+		return 0, false
+	}
+	return ae.Maintenance.Remaining(now), true
+}
+
+// isMaintenanceType reports whether a problem type is that of maintenance windows. The
+// gateways of some APIs have their own prefix, so the last segment is compared.
+func isMaintenanceType(typ string) bool {
+	return typ == MaintenanceProblemType || strings.HasSuffix(typ, "/maintenance")
+}
+
+// parseMaintenance returns the maintenance window of a 503 response, with data the
+// start of its body, or nil if it is not a maintenance response.
+func parseMaintenance(e *AkamaiError, data []byte, h http.Header, now time.Time) *MaintenanceWindow {
+	if e.Status != http.StatusServiceUnavailable || !isMaintenanceType(e.Type) {
+		return nil
+	}
+
+	var doc struct {
+		MaintenanceWindow MaintenanceWindow `json:"maintenanceWindow"`
+	}
+	json.Unmarshal(data, &doc)
+	w := doc.MaintenanceWindow
+
+	if w.End.IsZero() {
+		w.End = parseRetryAfter(h.Get("Retry-After"), now)
+	}
+	return &w
+}
+
+// parseRetryAfter returns the time a Retry-After header says to wait until, either as a
+// number of seconds after now or as an HTTP date, or zero if it is missing or invalid.
+func parseRetryAfter(v string, now time.Time) time.Time {
+	if v == "" {
+		return time.Time{}
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return now.Add(time.Duration(secs) * time.Second)
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,103 @@
+package akamai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// problemResponse returns a 503 response with the problem document of the fixture file
+// and the given headers.
+func problemResponse(t *testing.T, fixture string, header http.Header) *http.Response {
+	b, err := ioutil.ReadFile("../testdata/problems/" + fixture)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/problem+json")
+	return &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     header,
+		Body:       ioutil.NopCloser(bytes.NewReader(b)),
+	}
+}
+
+func TestCheckResponseMaintenance(t *testing.T) {
+	err := CheckResponse(problemResponse(t, "maintenance.json", nil))
+
+	assert.True(t, errors.Is(err, ErrServiceMaintenance), "got %v", err)
+	var ae *AkamaiError
+	if !errors.As(err, &ae) {
+		t.Fatalf("expect an *AkamaiError, got %v", err)
+	}
+	assert.True(t, ae.Temporary())
+	assert.Equal(t, &MaintenanceWindow{
+		Start: time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC),
+		End:   time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC),
+	}, ae.Maintenance)
+	assert.Equal(t, "HTTP Status: 503. Service Unavailable: The API is unavailable during a scheduled maintenance window.. Maintenance until 2026-10-17T04:00:00Z.", ae.Error())
+
+	remaining, ok := MaintenanceRemaining(err, time.Date(2026, 10, 17, 3, 30, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Minute, remaining)
+
+	remaining, ok = MaintenanceRemaining(err, time.Date(2026, 10, 17, 5, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), remaining)
+
+	// The window is kept in structured logs.
+	b, err := json.Marshal(ae)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, string(b), `"maintenanceWindow":{"start":"2026-10-17T02:00:00Z","end":"2026-10-17T04:00:00Z"}`)
+}
+
+func TestCheckResponseMaintenanceRetryAfter(t *testing.T) {
+	// Without a window in the document, its end is taken from Retry-After.
+	body := `{"type": "https://problems.luna.akamaiapis.net/common/maintenance", "title": "Service Unavailable", "status": 503}`
+	for _, retryAfter := range []string{"600", time.Now().Add(10 * time.Minute).UTC().Format(http.TimeFormat)} {
+		err := CheckResponse(&http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Header:     http.Header{"Retry-After": {retryAfter}},
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(body))),
+		})
+
+		remaining, ok := MaintenanceRemaining(err, time.Now())
+		assert.True(t, ok, retryAfter)
+		assert.True(t, remaining > 9*time.Minute && remaining <= 10*time.Minute, "%v: %v", retryAfter, remaining)
+	}
+
+	// Nor with one.
+	err := CheckResponse(&http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(body))),
+	})
+	assert.True(t, errors.Is(err, ErrServiceMaintenance))
+	remaining, ok := MaintenanceRemaining(err, time.Now())
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), remaining)
+}
+
+func TestCheckResponseUnavailable(t *testing.T) {
+	err := CheckResponse(problemResponse(t, "unavailable.json", http.Header{"Retry-After": {"120"}}))
+
+	assert.False(t, errors.Is(err, ErrServiceMaintenance))
+	var ae *AkamaiError
+	if !errors.As(err, &ae) {
+		t.Fatalf("expect an *AkamaiError, got %v", err)
+	}
+	assert.Nil(t, ae.Maintenance)
+	assert.True(t, ae.Temporary())
+	_, ok := MaintenanceRemaining(err, time.Now())
+	assert.False(t, ok)
+}
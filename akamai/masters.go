@@ -0,0 +1,87 @@
+package akamai
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// MasterError describes an entry of the masters of a SECONDARY zone that is not an IP
+// address the API accepts.
+type MasterError struct {
+	Master string
+	Reason string
+}
+
+// MastersValidationError is returned by CreateZone and UpdateZone for SECONDARY zones
+// whose masters hold entries the API would reject. It lists every bad entry.
+type MastersValidationError struct {
+	Entries []*MasterError
+}
+
+func (e *MastersValidationError) Error() string {
+	msgs := make([]string, len(e.Entries))
+	for i, m := range e.Entries {
+		msgs[i] = fmt.Sprintf("%q: %v", m.Master, m.Reason)
+	}
+	return "invalid masters: " + strings.Join(msgs, "; ")
+}
+
+// NormalizeMasters returns the masters of a SECONDARY zone in the form the API takes:
+// IPv4 and IPv6 addresses in their canonical form, without duplicates, in the order
+// they were first given. IPv6 addresses may be given in brackets, as in
+// "[2001:db8::1]". Hostnames and addresses with a port are rejected, as the API
+// accepts neither, with a *MastersValidationError listing every bad entry.
+func NormalizeMasters(masters []string) ([]string, error) {
+	var (
+		normalized []string
+		seen       = map[netip.Addr]bool{}
+		verr       = &MastersValidationError{}
+	)
+	for _, m := range masters {
+		addr, reason := parseMaster(m)
+		if reason != "" {
+			verr.Entries = append(verr.Entries, &MasterError{Master: m, Reason: reason})
+			continue
+		}
+		if !seen[addr] {
+			seen[addr] = true
+			normalized = append(normalized, addr.String())
+		}
+	}
+
+	if len(verr.Entries) > 0 {
+		return nil, verr
+	}
+	return normalized, nil
+}
+
+// parseMaster parses a master, or returns why it is not valid.
+func parseMaster(m string) (netip.Addr, string) {
+	s := strings.TrimSpace(m)
+	if s == "" {
+		return netip.Addr{}, "empty"
+	}
+
+	if ap, err := netip.ParseAddrPort(s); err == nil {
+		return netip.Addr{}, fmt.Sprintf("port %d is not allowed, the API only accepts addresses", ap.Port())
+	}
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		s = s[1 : len(s)-1]
+		if !strings.Contains(s, ":") {
+			return netip.Addr{}, "only IPv6 addresses may be given in brackets"
+		}
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		if strings.Count(s, ":") == 1 {
+			return netip.Addr{}, "ports are not allowed, the API only accepts addresses"
+		}
+		return netip.Addr{}, "not an IP address, masters must be given by address rather than hostname"
+	}
+	if addr.Zone() != "" {
+		return netip.Addr{}, "IPv6 zones are not allowed"
+	}
+	return addr.Unmap(), ""
+}
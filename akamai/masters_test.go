@@ -0,0 +1,91 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestNormalizeMasters(t *testing.T) {
+	tests := []struct {
+		name     string
+		masters  []string
+		expected []string
+		bad      []string
+	}{
+		{"ipv4", []string{"192.0.2.1", " 198.51.100.2 "}, []string{"192.0.2.1", "198.51.100.2"}, nil},
+		{"ipv6", []string{"2001:DB8:0:0::1", "[2001:db8::2]"}, []string{"2001:db8::1", "2001:db8::2"}, nil},
+		{"ipv4-mapped ipv6", []string{"::ffff:192.0.2.1"}, []string{"192.0.2.1"}, nil},
+		{"duplicates", []string{"192.0.2.1", "2001:db8::1", "192.0.2.1", "2001:0db8::0001"}, []string{"192.0.2.1", "2001:db8::1"}, nil},
+		{"none", nil, nil, nil},
+		{"hostname", []string{"192.0.2.1", "ns1.example.com"}, nil, []string{"ns1.example.com"}},
+		{"ipv4 port", []string{"192.0.2.1:53"}, nil, []string{"192.0.2.1:53"}},
+		{"ipv6 port", []string{"[2001:db8::1]:53"}, nil, []string{"[2001:db8::1]:53"}},
+		{"hostname port", []string{"ns1.example.com:53"}, nil, []string{"ns1.example.com:53"}},
+		{"bracketed ipv4", []string{"[192.0.2.1]"}, nil, []string{"[192.0.2.1]"}},
+		{"ipv6 zone", []string{"fe80::1%eth0"}, nil, []string{"fe80::1%eth0"}},
+		{"empty", []string{""}, nil, []string{""}},
+		{"every bad entry", []string{"ns1.example.com", "192.0.2.1", "192.0.2.2:53"}, nil, []string{"ns1.example.com", "192.0.2.2:53"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			masters, err := akamai.NormalizeMasters(tt.masters)
+			if tt.bad == nil {
+				if err != nil {
+					t.Fatalf("expect nil, got %v", err)
+				}
+				assert.Equal(t, tt.expected, masters)
+				return
+			}
+
+			var verr *akamai.MastersValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expect *MastersValidationError, got %v", err)
+			}
+			var bad []string
+			for _, e := range verr.Entries {
+				bad = append(bad, e.Master)
+				assert.NotEmpty(t, e.Reason)
+			}
+			assert.Equal(t, tt.bad, bad)
+		})
+	}
+
+	_, err := akamai.NormalizeMasters([]string{"192.0.2.1:53"})
+	if assert.Error(t, err) {
+		assert.Equal(t, `invalid masters: "192.0.2.1:53": port 53 is not allowed, the API only accepts addresses`, err.Error())
+	}
+}
+
+func TestFastDNSv2ValidatesMasters(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+
+	zr := &akamai.ZoneCreateRequest{Zone: "example.com", Type: "secondary", Masters: []string{"192.0.2.1", "ns1.example.com", "192.0.2.2:53"}}
+	_, _, err := client.FastDNSv2.CreateZone(ctx, "1-ABCDE", zr)
+	var verr *akamai.MastersValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Len(t, verr.Entries, 2)
+	}
+	assert.Empty(t, srv.Requests(), "invalid masters must not be sent")
+
+	zr.Masters = []string{"192.0.2.1", "[2001:DB8::1]", "192.0.2.1"}
+	z, _, err := client.FastDNSv2.CreateZone(ctx, "1-ABCDE", zr)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []*string{akamai.String("192.0.2.1"), akamai.String("2001:db8::1")}, z.Masters)
+	assert.Equal(t, []string{"192.0.2.1", "[2001:DB8::1]", "192.0.2.1"}, zr.Masters, "the request must not be modified")
+
+	_, _, err = client.FastDNSv2.UpdateZone(ctx, &akamai.ZoneCreateRequest{Zone: "example.com", Type: "SECONDARY", Masters: []string{"ns2.example.com"}})
+	assert.True(t, errors.As(err, &verr))
+
+	// Masters are only validated for SECONDARY zones.
+	_, _, err = client.FastDNSv2.CreateZone(ctx, "1-ABCDE", &akamai.ZoneCreateRequest{Zone: "example.net", Type: "PRIMARY", Masters: []string{"ns1.example.com"}})
+	assert.NoError(t, err)
+}
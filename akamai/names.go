@@ -0,0 +1,238 @@
+package akamai
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// Limits on the length of DNS names, in octets of their ASCII form.
+const (
+	maxLabelLength = 63
+	maxNameLength  = 253
+)
+
+// NameError is returned for zone and record names that are not valid DNS names.
+type NameError struct {
+	Name   string
+	Reason string
+}
+
+func (e *NameError) Error() string {
+	return fmt.Sprintf("invalid DNS name %q: %v", e.Name, e.Reason)
+}
+
+// NormalizeZoneName returns a zone name in the form the FastDNS API uses and returns
+// from its list endpoints: lowercase, without trailing dot, and with internationalized
+// labels converted to A-labels ("xn--") with idna.Lookup, which maps them following
+// UTS #46 and normalizes them to NFC, so that names that only differ in case or in
+// their Unicode form normalize the same. ASCII labels may only hold letters, digits and
+// hyphens, and must not start or end with a hyphen; labels are at most 63 octets long
+// once encoded.
+func NormalizeZoneName(name string) (string, error) {
+	return normalizeName(name, false)
+}
+
+// NormalizeRecordName returns a record name in the form the FastDNS API uses, as
+// NormalizeZoneName does. Record names may also hold underscores, as in
+// _dmarc.example.com, and start with a "*" wildcard label.
+func NormalizeRecordName(name string) (string, error) {
+	return normalizeName(name, true)
+}
+
+// zoneName normalizes a zone name given to a FastDNSv2Service method, unless the client
+// disables it. The name is returned unchanged along with the error if it is invalid.
+func (s *FastDNSv2Service) zoneName(name string) (string, error) {
+	if s.client.DisableNameNormalization {
+		return name, nil
+	}
+	n, err := NormalizeZoneName(name)
+	if err != nil {
+		return name, err
+	}
+	return n, nil
+}
+
+// recordName normalizes a record name as zoneName does a zone name.
+func (s *FastDNSv2Service) recordName(name string) (string, error) {
+	if s.client.DisableNameNormalization {
+		return name, nil
+	}
+	n, err := NormalizeRecordName(name)
+	if err != nil {
+		return name, err
+	}
+	return n, nil
+}
+
+// readRecordNames lowercases the names of record sets read from the API, unless the
+// client preserves their case. Owner names are case-insensitive, and lowercase names
+// can be compared and used as map keys as they are.
+func (s *FastDNSv2Service) readRecordNames(rss []*RecordSet) {
+	if s.client.PreserveRecordNameCase {
+		return
+	}
+	for _, rs := range rss {
+		if rs != nil && rs.Name != nil {
+			rs.Name = String(strings.ToLower(*rs.Name))
+		}
+	}
+}
+
+// zoneRequest returns a copy of zr with its zone name normalized, and its masters
+// validated and normalized with NormalizeMasters if it is a SECONDARY zone.
+func (s *FastDNSv2Service) zoneRequest(zr *ZoneCreateRequest) (*ZoneCreateRequest, error) {
+	if zr == nil {
+		return nil, nil
+	}
+	c := *zr
+	var err error
+	if c.Zone, err = s.zoneName(zr.Zone); err != nil {
+		return &c, err
+	}
+	if strings.EqualFold(c.Type, "SECONDARY") {
+		if c.Masters, err = NormalizeMasters(zr.Masters); err != nil {
+			return &c, err
+		}
+	}
+	return &c, nil
+}
+
+// recordSetRequest returns a copy of rs with its zone and record names normalized and
+// the default TTL of its type if it has none, and checks its TTL and rdata limits.
+func (s *FastDNSv2Service) recordSetRequest(rs *RecordSetCreateRequest) (*RecordSetCreateRequest, error) {
+	c := *rs
+	var err error
+	if c.Zone, err = s.zoneName(rs.Zone); err != nil {
+		return &c, err
+	}
+	if c.Name, err = s.recordName(rs.Name); err != nil {
+		return &c, err
+	}
+	s.applyDefaultTTL(&c)
+	if err := checkTTL(c.TTL); err != nil {
+		return &c, err
+	}
+	return &c, s.checkRdataLimits(&c)
+}
+
+// recordSetOptions returns a copy of opt with its zone and record names normalized.
+func (s *FastDNSv2Service) recordSetOptions(opt *RecordSetOptions) (*RecordSetOptions, error) {
+	c := *opt
+	var err error
+	if c.Zone, err = s.zoneName(opt.Zone); err != nil {
+		return &c, err
+	}
+	c.Name, err = s.recordName(opt.Name)
+	return &c, err
+}
+
+func normalizeName(name string, record bool) (string, error) {
+	if !utf8.ValidString(name) {
+		return "", &NameError{Name: name, Reason: "not valid UTF-8"}
+	}
+
+	trimmed := name
+	if !isASCII(name) {
+		trimmed = idnaDots.Replace(name)
+	}
+	trimmed = strings.TrimSuffix(trimmed, ".")
+	if trimmed == "" {
+		return "", &NameError{Name: name, Reason: "empty name"}
+	}
+
+	labels := strings.Split(trimmed, ".")
+	for i, l := range labels {
+		if record && i == 0 && l == "*" {
+			continue
+		}
+
+		ascii, reason := labelToASCII(l, record)
+		if reason != "" {
+			return "", &NameError{Name: name, Reason: fmt.Sprintf("label %q %v", l, reason)}
+		}
+		labels[i] = ascii
+	}
+
+	normalized := strings.Join(labels, ".")
+	if len(normalized) > maxNameLength {
+		return "", &NameError{Name: name, Reason: fmt.Sprintf("longer than %d octets", maxNameLength)}
+	}
+
+	return normalized, nil
+}
+
+// idnaDots are the dots UTS #46 maps to the ASCII dot that separates labels.
+var idnaDots = strings.NewReplacer("\u3002", ".", "\uff0e", ".", "\uff61", ".")
+
+// labelToASCII lowercases a label, and converts it with idna.Lookup if it is not ASCII
+// or is already an A-label ("xn--"), which maps it following UTS #46 and normalizes it
+// to NFC. It returns why the label is invalid, if it is.
+func labelToASCII(label string, underscore bool) (string, string) {
+	if label == "" {
+		return "", "is empty"
+	}
+
+	lower := strings.ToLower(label)
+	if !isASCII(label) || strings.HasPrefix(lower, "xn--") {
+		// UTS #46 lets symbols such as emoji through, which IDNA2008 disallows.
+		for _, r := range label {
+			if r >= utf8.RuneSelf && !unicode.In(r, unicode.Letter, unicode.Digit, unicode.Mark) {
+				return "", fmt.Sprintf("holds the illegal character %q", r)
+			}
+		}
+		ascii, err := idna.Lookup.ToASCII(label)
+		if err != nil {
+			return "", strings.TrimPrefix(err.Error(), "idna: ")
+		}
+		label = ascii
+	} else {
+		label = lower
+		for _, r := range label {
+			switch {
+			case 'a' <= r && r <= 'z', '0' <= r && r <= '9', r == '-':
+			case r == '_' && underscore:
+			default:
+				return "", fmt.Sprintf("holds the illegal character %q", r)
+			}
+		}
+		if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return "", "starts or ends with a hyphen"
+		}
+	}
+
+	if len(label) > maxLabelLength {
+		return "", fmt.Sprintf("is longer than %d octets", maxLabelLength)
+	}
+	return label, ""
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// UnicodeName returns a zone or record name with its A-labels ("xn--") decoded to
+// Unicode, for display. Names are sent to and returned by the FastDNS API in their
+// ASCII form, as NormalizeZoneName and NormalizeRecordName return them.
+func UnicodeName(name string) (string, error) {
+	labels := strings.Split(name, ".")
+	for i, l := range labels {
+		if len(l) < 4 || !strings.EqualFold(l[:4], "xn--") {
+			continue
+		}
+		u, err := idna.Lookup.ToUnicode(l)
+		if err != nil {
+			return name, &NameError{Name: name, Reason: fmt.Sprintf("label %q %v", l, strings.TrimPrefix(err.Error(), "idna: "))}
+		}
+		labels[i] = u
+	}
+	return strings.Join(labels, "."), nil
+}
@@ -0,0 +1,227 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestNormalizeZoneName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		err      string
+	}{
+		{"example.com", "example.com", ""},
+		{"Example.COM.", "example.com", ""},
+		{"bücher.example", "xn--bcher-kva.example", ""},
+		{"Bücher.Example.", "xn--bcher-kva.example", ""},
+		{"münchen.de", "xn--mnchen-3ya.de", ""},
+		{"日本語.jp", "xn--wgv71a119e.jp", ""},
+		{"xn--bcher-kva.example", "xn--bcher-kva.example", ""},
+		{"XN--BCHER-KVA.Example", "xn--bcher-kva.example", ""},
+		{"bu\u0308cher.example", "xn--bcher-kva.example", ""},
+		{"ＢÜＣＨＥＲ.example", "xn--bcher-kva.example", ""},
+		{"bücher。example", "xn--bcher-kva.example", ""},
+		{"straße.de", "xn--strae-oqa.de", ""},
+		{"a-b.example", "a-b.example", ""},
+		{strings.Repeat("a", 63) + ".com", strings.Repeat("a", 63) + ".com", ""},
+		{strings.Repeat("a", 64) + ".com", "", "longer than 63 octets"},
+		{strings.Repeat("ü", 60) + ".com", "", "longer than 63 octets"},
+		{strings.Repeat(strings.Repeat("a", 60)+".", 5) + "com", "", "longer than 253 octets"},
+		{"", "", "empty name"},
+		{".", "", "empty name"},
+		{"example..com", "", "is empty"},
+		{"-example.com", "", "hyphen"},
+		{"exa mple.com", "", "illegal character ' '"},
+		{"_dmarc.example.com", "", "illegal character '_'"},
+		{"*.example.com", "", "illegal character '*'"},
+		{"ex☃.com", "", "illegal character '☃'"},
+		{"bad\xffutf8.com", "", "not valid UTF-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := akamai.NormalizeZoneName(tt.name)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, n)
+				return
+			}
+
+			var ne *akamai.NameError
+			if assert.True(t, errors.As(err, &ne), "expected a NameError, got %v", err) {
+				assert.Equal(t, tt.name, ne.Name)
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
+
+func TestNormalizeRecordName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		err      bool
+	}{
+		{"WWW.Example.com.", "www.example.com", false},
+		{"_dmarc.Example.com", "_dmarc.example.com", false},
+		{"_sip._tcp.example.com", "_sip._tcp.example.com", false},
+		{"*.example.com", "*.example.com", false},
+		{"*.Bücher.example", "*.xn--bcher-kva.example", false},
+		{"_dmarc.Bu\u0308cher.example", "_dmarc.xn--bcher-kva.example", false},
+		{"*.ＢÜＣＨＥＲ.example", "*.xn--bcher-kva.example", false},
+		{"www.*.example.com", "", true},
+		{"w@w.example.com", "", true},
+	}
+
+	for _, tt := range tests {
+		n, err := akamai.NormalizeRecordName(tt.name)
+		if tt.err {
+			assert.Error(t, err, tt.name)
+			continue
+		}
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expected, n)
+	}
+}
+
+func TestUnicodeName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"xn--bcher-kva.example": "bücher.example",
+		"www.xn--mnchen-3ya.de": "www.münchen.de",
+		"_dmarc.example.com":    "_dmarc.example.com",
+		"*.xn--wgv71a119e.jp":   "*.日本語.jp",
+		"plain.example":         "plain.example",
+	} {
+		u, err := akamai.UnicodeName(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, expected, u)
+	}
+
+	_, err := akamai.UnicodeName("xn--a.example")
+	var ne *akamai.NameError
+	assert.True(t, errors.As(err, &ne), "got %v", err)
+}
+
+func TestFastDNSv2NormalizesNames(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+
+	_, _, err := client.FastDNSv2.CreateZone(ctx, "1-ABCDE", &akamai.ZoneCreateRequest{Zone: "Bücher.Example.", Type: "PRIMARY"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.NotNil(t, srv.Zone("xn--bcher-kva.example"))
+
+	rs := &akamai.RecordSetCreateRequest{Zone: "bücher.example", Name: "WWW.Bücher.Example.", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}}
+	if _, _, err := client.FastDNSv2.CreateRecordSet(ctx, rs); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "WWW.Bücher.Example.", rs.Name, "the request must not be modified")
+
+	got, _, err := client.FastDNSv2.GetRecordSet(ctx, &akamai.RecordSetOptions{Zone: "BÜCHER.example", Name: "www.bücher.example", Type: "A"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "www.xn--bcher-kva.example", got.GetName())
+
+	// The names listed by the API and the desired ones compare equal.
+	plan, err := client.FastDNSv2.PlanRecordSets(ctx, "Bücher.example.", []*akamai.RecordSetCreateRequest{
+		{Name: "www.Bücher.example.", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+	}, &akamai.SyncOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, plan.Empty())
+	assert.Equal(t, 1, plan.Unchanged)
+
+	_, _, err = client.FastDNSv2.GetZone(ctx, "bad zone.example")
+	var ne *akamai.NameError
+	assert.True(t, errors.As(err, &ne))
+	op, ok := akamai.OperationFromError(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, "GetZone", op.Op)
+		assert.Equal(t, "bad zone.example", op.Zone)
+	}
+
+	client.DisableNameNormalization = true
+	_, _, err = client.FastDNSv2.GetZone(ctx, "XN--BCHER-KVA.example")
+	assert.Error(t, err, "names are sent as given when normalization is disabled")
+}
+
+func TestRecordNameCase(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	for _, rs := range []*akamai.RecordSetCreateRequest{
+		{Zone: "example.com", Name: "WWW.Example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+		{Zone: "example.com", Name: "_DMARC.example.COM", Type: "TXT", TTL: akamai.Int(3600), Rdata: []string{`"v=DMARC1; p=none"`}},
+	} {
+		if err := srv.AddRecordSet(rs); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	}
+	ctx := context.Background()
+
+	names := func(list []*akamai.RecordSet) []string {
+		var names []string
+		for _, rs := range list {
+			if rs.GetType() != "SOA" && rs.GetType() != "NS" {
+				names = append(names, rs.GetName())
+			}
+		}
+		return names
+	}
+
+	list, _, err := client.FastDNSv2.GetZoneRecordSets(ctx, "example.com", &akamai.ListZoneRecordSetOptions{ShowAll: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"www.example.com", "_dmarc.example.com"}, names(list.RecordSets))
+
+	var each []*akamai.RecordSet
+	err = client.FastDNSv2.ForEachRecordSet(ctx, "example.com", nil, func(rs *akamai.RecordSet) error {
+		each = append(each, rs)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"www.example.com", "_dmarc.example.com"}, names(each))
+
+	rs, _, err := client.FastDNSv2.GetRecordSet(ctx, &akamai.RecordSetOptions{Zone: "example.com", Name: "Www.example.com", Type: "A"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "www.example.com", rs.GetName())
+
+	// Desired and current names in different cases don't make spurious changes, with or
+	// without the names normalized and their case preserved.
+	desired := []*akamai.RecordSetCreateRequest{
+		{Name: "www.EXAMPLE.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+		{Name: "_Dmarc.Example.Com.", Type: "TXT", TTL: akamai.Int(3600), Rdata: []string{`"v=DMARC1; p=none"`}},
+	}
+	for _, c := range []struct{ disableNormalization, preserveCase bool }{{false, false}, {true, false}, {false, true}, {true, true}} {
+		client.DisableNameNormalization = c.disableNormalization
+		client.PreserveRecordNameCase = c.preserveCase
+
+		plan, err := client.FastDNSv2.PlanRecordSets(ctx, "example.com", desired, &akamai.SyncOptions{Prune: true})
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.True(t, plan.Empty(), "%+v: %v", c, plan.Changes)
+		assert.Equal(t, len(desired), plan.Unchanged, "%+v", c)
+	}
+
+	// The names keep the case of the API when it is preserved.
+	list, _, err = client.FastDNSv2.GetZoneRecordSets(ctx, "example.com", &akamai.ListZoneRecordSetOptions{ShowAll: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"WWW.Example.com", "_DMARC.example.COM"}, names(list.RecordSets))
+}
@@ -0,0 +1,415 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of OnboardOptions.
+const (
+	defaultOnboardMaxAttempts   = 3
+	defaultOnboardRetryInterval = 5 * time.Second
+	defaultOnboardPollInterval  = 10 * time.Second
+)
+
+// OnboardStep is the last step of its onboarding a zone has completed.
+type OnboardStep string
+
+// Steps of the onboarding of a zone, in the order they are completed.
+const (
+	OnboardPending        OnboardStep = "pending"
+	OnboardCreated        OnboardStep = "created"
+	OnboardActive         OnboardStep = "active"
+	OnboardRecordsWritten OnboardStep = "records-written"
+	OnboardDone           OnboardStep = "done"
+)
+
+var onboardSteps = []OnboardStep{OnboardPending, OnboardCreated, OnboardActive, OnboardRecordsWritten, OnboardDone}
+
+// ZoneSpec describes a zone to onboard.
+type ZoneSpec struct {
+	ContractID string
+	Zone       ZoneCreateRequest
+
+	// RecordSets replace the record sets of the zone once it is active. The SOA and apex
+	// NS record sets Akamai created are kept unless RecordSets has its own.
+	RecordSets []*RecordSetCreateRequest
+}
+
+// OnboardOptions specifies the optional parameters to the OnboardZones method.
+type OnboardOptions struct {
+	// Bulk configures how many zones are onboarded at once.
+	Bulk *BulkOptions
+
+	// MaxAttempts is the number of times a step is tried when it fails with a rate
+	// limit, server or network error. Defaults to 3.
+	MaxAttempts int
+
+	// RetryInterval is the time before the first retry of a step, doubled for every
+	// following one. Defaults to 5 seconds.
+	RetryInterval time.Duration
+
+	// PollInterval is how often a zone is checked while waiting for it to be active.
+	// Defaults to 10 seconds.
+	PollInterval time.Duration
+
+	// SkipDelegation completes the onboarding once the record sets are written, for
+	// zones whose registrar is not updated yet.
+	SkipDelegation bool
+
+	// SkipCapacityCheck creates the zones without checking first, with
+	// CheckZoneCapacity, that their contracts have room for them.
+	SkipCapacityCheck bool
+
+	// Resolver looks up the delegation of the zones. Defaults to net.DefaultResolver.
+	Resolver NSResolver
+
+	// Checkpoint resumes an interrupted run: zones are onboarded from the step following
+	// the one it records for them.
+	Checkpoint *OnboardCheckpoint
+
+	// Progress is called every time a zone completes a step or fails, with the
+	// checkpoint of the run. Calls are serialized, and cp must not be retained, so the
+	// callback can save cp to resume the run later.
+	Progress func(p OnboardProgress, cp *OnboardCheckpoint)
+}
+
+// OnboardProgress is the state of the onboarding of a zone.
+type OnboardProgress struct {
+	Zone string
+	Step OnboardStep
+
+	// Err holds the error that stopped the onboarding of the zone after Step.
+	Err error
+}
+
+// OnboardCheckpoint records the last step each zone has completed. It is serialized as
+// JSON to resume a run.
+type OnboardCheckpoint struct {
+	Zones map[string]OnboardStep `json:"zones"`
+}
+
+// NSResolver looks up the name servers a domain is delegated to. It is implemented by
+// *net.Resolver.
+type NSResolver interface {
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// DelegationError is returned by VerifyDelegation when a zone is not delegated to the
+// Akamai name servers.
+type DelegationError struct {
+	Zone     string
+	Expected []string
+	Actual   []string
+}
+
+func (e *DelegationError) Error() string {
+	return fmt.Sprintf("zone %v is delegated to %v, expected %v", e.Zone, e.Actual, e.Expected)
+}
+
+// VerifyDelegation checks that the name servers a zone is delegated to, as looked up
+// with r, are the ones of its apex NS record set. A nil r uses net.DefaultResolver.
+func (s *FastDNSv2Service) VerifyDelegation(ctx context.Context, zone string, r NSResolver) error {
+	if r == nil {
+		r = net.DefaultResolver
+	}
+
+	rs, _, err := s.GetRecordSet(ctx, &RecordSetOptions{Zone: zone, Name: zone, Type: RRTypeNs})
+	if err != nil {
+		return err
+	}
+	expected := make([]string, len(rs.Rdata))
+	for i, ns := range rs.Rdata {
+		expected[i] = normalizeHost(StringValue(ns))
+	}
+
+	found, err := r.LookupNS(ctx, zone)
+	if err != nil {
+		return err
+	}
+	actual := make([]string, len(found))
+	for i, ns := range found {
+		actual[i] = normalizeHost(ns.Host)
+	}
+
+	sort.Strings(expected)
+	sort.Strings(actual)
+	if strings.Join(expected, " ") != strings.Join(actual, " ") {
+		return &DelegationError{Zone: zone, Expected: expected, Actual: actual}
+	}
+
+	return nil
+}
+
+// OnboardZones creates zones, waits for them to be active, writes their record sets
+// and verifies their delegation, onboarding several zones at once as configured by
+// opt.Bulk. A zone that fails does not stop the others. It returns the state of each
+// zone, in the order of specs, and the checkpoint from which a new run resumes the
+// zones that are not done.
+func (s *FastDNSv2Service) OnboardZones(ctx context.Context, specs []ZoneSpec, opt *OnboardOptions) ([]*OnboardProgress, *OnboardCheckpoint) {
+	if opt == nil {
+		opt = &OnboardOptions{}
+	}
+
+	cp := &OnboardCheckpoint{Zones: map[string]OnboardStep{}}
+	if opt.Checkpoint != nil {
+		for zone, step := range opt.Checkpoint.Zones {
+			cp.Zones[zone] = step
+		}
+	}
+
+	var mu sync.Mutex
+	report := func(p *OnboardProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+		if p.Err == nil {
+			cp.Zones[p.Zone] = p.Step
+		}
+		if opt.Progress != nil {
+			opt.Progress(*p, cp)
+		}
+	}
+
+	seen := map[string]bool{}
+	duplicate := make([]bool, len(specs))
+	for i := range specs {
+		duplicate[i] = seen[specs[i].Zone.Zone]
+		seen[specs[i].Zone.Zone] = true
+	}
+
+	var capacity map[string]error
+	if !opt.SkipCapacityCheck {
+		capacity = s.checkOnboardCapacity(ctx, specs, duplicate, cp)
+	}
+
+	indexes := make([]int, len(specs))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	results := RunBulk(ctx, indexes, opt.Bulk, func(ctx context.Context, i int) (*OnboardProgress, error) {
+		spec := &specs[i]
+		p := &OnboardProgress{Zone: spec.Zone.Zone, Step: OnboardPending}
+		if duplicate[i] {
+			p.Err = fmt.Errorf("zone %v is specified more than once", p.Zone)
+			return p, nil
+		}
+
+		ctx, unlock, err := s.lockZone(ctx, p.Zone)
+		if err != nil {
+			p.Err = err
+			return p, nil
+		}
+		defer unlock()
+
+		mu.Lock()
+		if step, ok := cp.Zones[p.Zone]; ok {
+			p.Step = step
+		}
+		mu.Unlock()
+
+		if err := capacity[TrimContractPrefix(spec.ContractID)]; err != nil && p.Step == OnboardPending {
+			p.Err = err
+			report(p)
+			return p, nil
+		}
+
+		for p.Step != OnboardDone {
+			next, err := nextOnboardStep(p.Step)
+			if err == nil {
+				err = retryOnboard(ctx, opt, func() error {
+					return s.onboardStep(ctx, spec, p.Step, opt)
+				})
+			}
+			if err != nil {
+				p.Err = err
+				report(p)
+				return p, nil
+			}
+
+			p.Step = next
+			report(p)
+		}
+
+		return p, nil
+	})
+
+	progress := make([]*OnboardProgress, len(specs))
+	for i, r := range results {
+		progress[i] = r.Value
+		if r.Err != nil {
+			step := OnboardPending
+			if done, ok := cp.Zones[specs[i].Zone.Zone]; ok {
+				step = done
+			}
+			progress[i] = &OnboardProgress{Zone: specs[i].Zone.Zone, Step: step, Err: r.Err}
+		}
+	}
+
+	return progress, cp
+}
+
+// checkOnboardCapacity checks that the contracts have room for the zones of specs that
+// are still to be created, and returns the capacity errors by contract. The zones of a
+// contract without room are not created at all, rather than some of them failing
+// halfway. Contracts whose capacity can't be looked up are let through, for the API to
+// answer their zone creations.
+func (s *FastDNSv2Service) checkOnboardCapacity(ctx context.Context, specs []ZoneSpec, duplicate []bool, cp *OnboardCheckpoint) map[string]error {
+	toAdd := map[string]int{}
+	for i, spec := range specs {
+		if step, ok := cp.Zones[spec.Zone.Zone]; (ok && step != OnboardPending) || duplicate[i] {
+			continue
+		}
+		toAdd[TrimContractPrefix(spec.ContractID)]++
+	}
+
+	errs := map[string]error{}
+	for id, n := range toAdd {
+		if _, err := s.CheckZoneCapacity(ctx, id, n); errors.Is(err, ErrInsufficientZoneCapacity) {
+			errs[id] = err
+		}
+	}
+	return errs
+}
+
+// onboardStep makes the step that follows step for a zone.
+func (s *FastDNSv2Service) onboardStep(ctx context.Context, spec *ZoneSpec, step OnboardStep, opt *OnboardOptions) error {
+	zone := spec.Zone.Zone
+
+	switch step {
+	case OnboardPending:
+		_, _, err := s.CreateZone(ctx, spec.ContractID, &spec.Zone)
+		if isStatus(err, http.StatusConflict) {
+			// The zone was created by a run that was interrupted before it could record it.
+			return nil
+		}
+		return err
+	case OnboardCreated:
+		interval := opt.PollInterval
+		if interval <= 0 {
+			interval = defaultOnboardPollInterval
+		}
+		_, err := s.WaitForZoneActive(ctx, zone, interval)
+		return err
+	case OnboardActive:
+		if len(spec.RecordSets) == 0 {
+			return nil
+		}
+		return replaceKeepingApex(ctx, s, zone, spec.RecordSets)
+	case OnboardRecordsWritten:
+		if opt.SkipDelegation {
+			return nil
+		}
+		return s.VerifyDelegation(ctx, zone, opt.Resolver)
+	}
+
+	return fmt.Errorf("unknown onboarding step %q", step)
+}
+
+// replaceKeepingApex replaces the record sets of a zone through s, keeping its SOA and
+// apex NS record sets unless desired has its own.
+func replaceKeepingApex(ctx context.Context, s FastDNSv2API, zone string, desired []*RecordSetCreateRequest) error {
+	list, err := s.ListAllZoneRecordSets(ctx, zone, nil)
+	if err != nil {
+		return err
+	}
+
+	given := map[string]bool{}
+	records := make([]*RecordSetCreateRequest, 0, len(desired)+2)
+	for _, d := range desired {
+		rs := *d
+		rs.Zone = zone
+		given[syncKey(rs.Name, rs.Type)] = true
+		records = append(records, &rs)
+	}
+
+	for _, cur := range list {
+		if !isProtectedRecordSet(zone, cur) || given[syncKey(cur.GetName(), cur.GetType())] {
+			continue
+		}
+		records = append(records, cur.ToCreateRequest(zone))
+	}
+
+	_, err = s.ReplaceRecordSets(ctx, zone, records)
+	return err
+}
+
+func nextOnboardStep(step OnboardStep) (OnboardStep, error) {
+	for i, s := range onboardSteps[:len(onboardSteps)-1] {
+		if s == step {
+			return onboardSteps[i+1], nil
+		}
+	}
+	return "", fmt.Errorf("unknown onboarding step %q", step)
+}
+
+// retryOnboard calls fn until it succeeds, fails with an error that is not worth
+// retrying, or has been tried opt.MaxAttempts times.
+func retryOnboard(ctx context.Context, opt *OnboardOptions, fn func() error) error {
+	attempts := opt.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultOnboardMaxAttempts
+	}
+	wait := opt.RetryInterval
+	if wait <= 0 {
+		wait = defaultOnboardRetryInterval
+	}
+
+	// The poll is done once fn succeeds; retryable errors are kept as its state.
+	spec := PollSpec{Initial: wait, Max: wait << attempts, Multiplier: 2, MaxAttempts: attempts}
+	last, err := Poll(ctx, spec, func(ctx context.Context) (error, bool, error) {
+		err := fn()
+		if err != nil && !isRetryable(err) {
+			return nil, false, err
+		}
+		// A maintenance window is waited out before the usual wait.
+		if remaining, ok := MaintenanceRemaining(err, time.Now()); ok && remaining > 0 {
+			if err := sleepContext(ctx, remaining); err != nil {
+				return nil, false, err
+			}
+		}
+		return err, err == nil, nil
+	})
+	if errors.Is(err, ErrPollTimeout) && !errors.Is(err, context.DeadlineExceeded) {
+		return last
+	}
+	return err
+}
+
+// isRetryable reports whether a call that failed with err may succeed if made again:
+// rate limited calls, server errors and network errors.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var de *DelegationError
+	if errors.As(err, &de) {
+		return false
+	}
+
+	var ae *AkamaiError
+	if errors.As(err, &ae) {
+		return ae.Temporary()
+	}
+
+	var ne net.Error
+	return errors.As(err, &ne)
+}
+
+// isStatus reports whether err is an API error with the given status.
+func isStatus(err error, status int) bool {
+	var ae *AkamaiError
+	return errors.As(err, &ae) && ae.Status == status
+}
+
+func normalizeHost(h string) string {
+	return strings.ToLower(strings.TrimSuffix(h, "."))
+}
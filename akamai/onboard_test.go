@@ -0,0 +1,290 @@
+package akamai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// staticResolver answers NS lookups from a map, and fails for the other names.
+type staticResolver map[string][]string
+
+func (r staticResolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	hosts, ok := r[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	ns := make([]*net.NS, len(hosts))
+	for i, h := range hosts {
+		ns[i] = &net.NS{Host: h}
+	}
+	return ns, nil
+}
+
+var akamaiNameServers = []string{"a1-1.akam.net.", "a2-2.akam.net."}
+
+func onboardSpecs(zones ...string) []akamai.ZoneSpec {
+	specs := make([]akamai.ZoneSpec, len(zones))
+	for i, z := range zones {
+		specs[i] = akamai.ZoneSpec{
+			ContractID: "1-ABCDE",
+			Zone:       akamai.ZoneCreateRequest{Zone: z, Type: "PRIMARY"},
+			RecordSets: []*akamai.RecordSetCreateRequest{
+				{Name: "www." + z, Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+			},
+		}
+	}
+	return specs
+}
+
+func TestWaitForZoneActive(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	srv.SetActivationState("example.com", akamai.ZonePending)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		srv.SetActivationState("example.com", akamai.ZoneActive)
+	}()
+
+	zm, err := client.FastDNSv2.WaitForZoneActive(context.Background(), "example.com", time.Millisecond)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, akamai.ZoneActive, zm.GetActivationState())
+
+	srv.SetActivationState("example.com", akamai.ZonePending)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.FastDNSv2.WaitForZoneActive(ctx, "example.com", time.Millisecond)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// A failed activation stops the wait.
+	srv.SetActivationState("example.com", akamai.ZoneError)
+	zm, err = client.FastDNSv2.WaitForZoneActive(context.Background(), "example.com", time.Millisecond)
+	assert.True(t, errors.Is(err, akamai.ErrZoneActivationFailed), "got %v", err)
+	assert.Equal(t, akamai.ZoneError, zm.GetActivationState())
+}
+
+func TestReplaceRecordSets(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	_, err := client.FastDNSv2.ReplaceRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{
+		{Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+	})
+	assert.Error(t, err, "the SOA and NS record sets are required")
+
+	_, err = client.FastDNSv2.ReplaceRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{
+		{Name: "example.com", Type: "SOA", TTL: akamai.Int(86400), Rdata: []string{"a1-1.akam.net. hostmaster.example.com. 2 3600 600 604800 300"}},
+		{Name: "example.com", Type: "NS", TTL: akamai.Int(86400), Rdata: []string{"a1-1.akam.net."}},
+		{Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, srv.RecordSets("example.com"), 3)
+}
+
+func TestVerifyDelegation(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	err := client.FastDNSv2.VerifyDelegation(ctx, "example.com", staticResolver{"example.com": {"A2-2.akam.net", "a1-1.akam.net."}})
+	assert.NoError(t, err)
+
+	err = client.FastDNSv2.VerifyDelegation(ctx, "example.com", staticResolver{"example.com": {"ns1.registrar.example."}})
+	var de *akamai.DelegationError
+	if assert.True(t, errors.As(err, &de)) {
+		assert.Equal(t, []string{"ns1.registrar.example"}, de.Actual)
+		assert.Equal(t, []string{"a1-1.akam.net", "a2-2.akam.net"}, de.Expected)
+	}
+}
+
+func TestOnboardZones(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "taken.com", Type: "SECONDARY"})
+
+	specs := onboardSpecs("example.com", "example.net", "undelegated.org")
+	specs = append(specs, akamai.ZoneSpec{
+		ContractID: "1-ABCDE",
+		Zone:       akamai.ZoneCreateRequest{Zone: "taken.com", Type: "SECONDARY"},
+		RecordSets: []*akamai.RecordSetCreateRequest{{Name: "www.taken.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}}},
+	})
+
+	var mu sync.Mutex
+	var events []akamai.OnboardProgress
+	progress, cp := client.FastDNSv2.OnboardZones(context.Background(), specs, &akamai.OnboardOptions{
+		Bulk:          &akamai.BulkOptions{Concurrency: 2},
+		RetryInterval: time.Millisecond,
+		PollInterval:  time.Millisecond,
+		Resolver: staticResolver{
+			"example.com":     akamaiNameServers,
+			"example.net":     akamaiNameServers,
+			"undelegated.org": {"ns1.registrar.example."},
+		},
+		Progress: func(p akamai.OnboardProgress, cp *akamai.OnboardCheckpoint) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, p)
+		},
+	})
+
+	if assert.Len(t, progress, 4) {
+		for _, p := range progress[:2] {
+			assert.Equal(t, akamai.OnboardDone, p.Step, p.Zone)
+			assert.NoError(t, p.Err, p.Zone)
+		}
+
+		assert.Equal(t, akamai.OnboardRecordsWritten, progress[2].Step)
+		var de *akamai.DelegationError
+		assert.True(t, errors.As(progress[2].Err, &de))
+
+		assert.Equal(t, "taken.com", progress[3].Zone)
+		assert.Equal(t, akamai.OnboardActive, progress[3].Step)
+		assert.Error(t, progress[3].Err)
+	}
+
+	assert.Equal(t, map[string]akamai.OnboardStep{
+		"example.com":     akamai.OnboardDone,
+		"example.net":     akamai.OnboardDone,
+		"undelegated.org": akamai.OnboardRecordsWritten,
+		"taken.com":       akamai.OnboardActive,
+	}, cp.Zones)
+	assert.Len(t, events, 4+4+4+3)
+
+	records := srv.RecordSets("example.com")
+	if assert.Len(t, records, 3) {
+		assert.Equal(t, "www.example.com", records[2].GetName())
+	}
+}
+
+func TestOnboardZonesResume(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	specs := onboardSpecs("a.example", "b.example", "c.example", "d.example")
+	resolver := staticResolver{}
+	for _, s := range specs {
+		resolver[s.Zone.Zone] = akamaiNameServers
+	}
+
+	// The first run crashes after six steps have been completed, leaving only the
+	// checkpoint saved by the last progress callback behind.
+	ctx, cancel := context.WithCancel(context.Background())
+	var saved []byte
+	completed := 0
+	client.FastDNSv2.OnboardZones(ctx, specs, &akamai.OnboardOptions{
+		Bulk:         &akamai.BulkOptions{Concurrency: 1},
+		PollInterval: time.Millisecond,
+		Resolver:     resolver,
+		Progress: func(p akamai.OnboardProgress, cp *akamai.OnboardCheckpoint) {
+			if completed == 6 {
+				return
+			}
+			completed++
+
+			var err error
+			saved, err = json.Marshal(cp)
+			if err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+			if completed == 6 {
+				cancel()
+			}
+		},
+	})
+
+	var cp akamai.OnboardCheckpoint
+	if err := json.Unmarshal(saved, &cp); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, map[string]akamai.OnboardStep{
+		"a.example": akamai.OnboardDone,
+		"b.example": akamai.OnboardActive,
+	}, cp.Zones)
+	assert.NotNil(t, srv.Zone("b.example"))
+	assert.Nil(t, srv.Zone("c.example"))
+
+	var resumed []akamai.OnboardProgress
+	progress, final := client.FastDNSv2.OnboardZones(context.Background(), specs, &akamai.OnboardOptions{
+		PollInterval: time.Millisecond,
+		Resolver:     resolver,
+		Checkpoint:   &cp,
+		Progress: func(p akamai.OnboardProgress, cp *akamai.OnboardCheckpoint) {
+			resumed = append(resumed, p)
+		},
+	})
+
+	for _, p := range progress {
+		assert.Equal(t, akamai.OnboardDone, p.Step, p.Zone)
+		assert.NoError(t, p.Err, p.Zone)
+		assert.Equal(t, akamai.OnboardDone, final.Zones[p.Zone])
+	}
+
+	steps := map[string][]akamai.OnboardStep{}
+	for _, p := range resumed {
+		steps[p.Zone] = append(steps[p.Zone], p.Step)
+	}
+	assert.Nil(t, steps["a.example"])
+	assert.Equal(t, []akamai.OnboardStep{akamai.OnboardRecordsWritten, akamai.OnboardDone}, steps["b.example"])
+	assert.Len(t, steps["c.example"], 4)
+	assert.Len(t, srv.RecordSets("b.example"), 3)
+}
+
+func TestOnboardZonesRetry(t *testing.T) {
+	var mu sync.Mutex
+	creates := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		creates++
+		if creates < 3 {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"title": "Service Unavailable", "status": 503}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"zone": "example.com"}`))
+	})
+	mux.HandleFunc("/config-dns/v2/zones/example.com", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"zone": "example.com", "activationState": "ACTIVE"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := akamai.NewClient(nil, credentials.NewStaticCredentials("secret", "client", "access", server.Listener.Addr().String()))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	specs := []akamai.ZoneSpec{{ContractID: "1-ABCDE", Zone: akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"}}}
+	opt := &akamai.OnboardOptions{RetryInterval: time.Millisecond, PollInterval: time.Millisecond, SkipDelegation: true}
+
+	progress, _ := client.FastDNSv2.OnboardZones(context.Background(), specs, opt)
+	assert.Equal(t, akamai.OnboardDone, progress[0].Step)
+	assert.NoError(t, progress[0].Err)
+	assert.Equal(t, 3, creates)
+
+	creates = 0
+	opt.MaxAttempts = 2
+	progress, _ = client.FastDNSv2.OnboardZones(context.Background(), specs, opt)
+	assert.Equal(t, akamai.OnboardPending, progress[0].Step)
+	assert.Error(t, progress[0].Err)
+	assert.Equal(t, 2, creates)
+}
@@ -0,0 +1,307 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The ownership registry records which owner, such as a controller or a team, manages
+// each record set of a zone, so that several of them can share a zone without
+// overwriting or pruning each other's record sets, as external-dns does.
+//
+// The claims on the record sets of a name are held in the TXT record set of its
+// registry name, RegistryPrefix followed by the name, with a leading "*" wildcard
+// label written as "_wildcard". Each claim is a TXT value of the form
+//
+//	akamai-owner/v1 owner=<owner> type=<type>
+//
+// where the owner is escaped as a URL query value, so that it may hold any character.
+// The values of the registry TXT record set that are not claims are left untouched.
+const (
+	// RegistryPrefix is prepended to a record name to get the name of its registry
+	// TXT record set.
+	RegistryPrefix = "_akamai-owner."
+
+	// RegistryVersion is the version of the claims written to the registry.
+	RegistryVersion = 1
+
+	// RegistryTTL is the TTL of the registry TXT record sets.
+	RegistryTTL = 300
+)
+
+// registryTag starts the claims of the registry, followed by their version.
+const registryTag = "akamai-owner/v"
+
+// ErrRecordOwned is the error of the changes to a record set claimed by another owner
+// in the ownership registry.
+var ErrRecordOwned = errors.New("record set is owned by another owner")
+
+// RecordOwnedError is returned when an owner changes, claims or releases a record set
+// claimed by another owner, or not claimed at all when an owner is required.
+type RecordOwnedError struct {
+	Zone string
+	Name string
+	Type string
+
+	// Owner is the owner that made the change.
+	Owner string
+
+	// Holder is the owner holding the claim, or "" if the record set is not claimed.
+	// For a claim of a registry version the SDK doesn't know, it is the TXT value of
+	// the claim.
+	Holder string
+}
+
+func (e *RecordOwnedError) Error() string {
+	if e.Holder == "" {
+		return fmt.Sprintf("%v: record set %v %v of zone %v is not owned by %q", ErrRecordOwned, e.Name, e.Type, e.Zone, e.Owner)
+	}
+	return fmt.Sprintf("%v: record set %v %v of zone %v is owned by %q, not %q", ErrRecordOwned, e.Name, e.Type, e.Zone, e.Holder, e.Owner)
+}
+
+// Is makes errors.Is(err, ErrRecordOwned) report true.
+func (e *RecordOwnedError) Is(target error) bool {
+	return target == ErrRecordOwned
+}
+
+// RecordClaim is the claim of an owner on a record set in the ownership registry.
+type RecordClaim struct {
+	Name  string
+	Type  string
+	Owner string
+}
+
+// registryName returns the name of the registry TXT record set of a record name.
+func registryName(name string) string {
+	if name == "*" || strings.HasPrefix(name, "*.") {
+		name = "_wildcard" + name[1:]
+	}
+	return RegistryPrefix + name
+}
+
+// isRegistryName reports whether name is the name of a registry TXT record set.
+func isRegistryName(name string) bool {
+	return len(name) >= len(RegistryPrefix) && strings.EqualFold(name[:len(RegistryPrefix)], RegistryPrefix)
+}
+
+// recordNameOfRegistry returns the record name a registry name holds the claims of.
+func recordNameOfRegistry(name string) string {
+	name = name[len(RegistryPrefix):]
+	if name == "_wildcard" || strings.HasPrefix(name, "_wildcard.") {
+		name = "*" + name[len("_wildcard"):]
+	}
+	return name
+}
+
+// formatClaim returns the TXT value of the claim of owner on the record set of type rtype.
+func formatClaim(owner, rtype string) string {
+	return fmt.Sprintf("%s%d owner=%s type=%s", registryTag, RegistryVersion, url.QueryEscape(owner), strings.ToUpper(rtype))
+}
+
+// parseClaim parses the TXT value of a claim, and returns its owner and record type.
+// It reports false for values that are not claims. The claims of an unknown version
+// are returned with the value as their owner and an empty type, as they can't be read.
+func parseClaim(value string) (owner, rtype string, ok bool) {
+	if !strings.HasPrefix(value, registryTag) {
+		return "", "", false
+	}
+	fields := strings.Fields(value[len(registryTag):])
+	if len(fields) == 0 {
+		return "", "", false
+	}
+	if v, err := strconv.Atoi(fields[0]); err != nil || v != RegistryVersion {
+		return value, "", true
+	}
+
+	for _, f := range fields[1:] {
+		k, v, _ := strings.Cut(f, "=")
+		switch k {
+		case "owner":
+			var err error
+			if owner, err = url.QueryUnescape(v); err != nil {
+				return value, "", true
+			}
+		case "type":
+			rtype = strings.ToUpper(v)
+		}
+	}
+	if owner == "" || rtype == "" {
+		return value, "", true
+	}
+	return owner, rtype, true
+}
+
+// claimHolder returns the holder of the claim on the record set of type rtype held
+// by a registry TXT record set, or "" if there is none. A claim of an unknown version
+// holds all the types of its name, as its types can't be read.
+func claimHolder(rs *RecordSet, rtype string) string {
+	for _, rdata := range currentRdata(rs) {
+		value, err := UnquoteTXT(rdata)
+		if err != nil {
+			continue
+		}
+		owner, t, ok := parseClaim(value)
+		if ok && (t == "" || strings.EqualFold(t, rtype)) {
+			return owner
+		}
+	}
+	return ""
+}
+
+// zoneClaims returns the holders of the claims held by the registry TXT record sets
+// among the record sets of a zone, by syncKey.
+func zoneClaims(list []*RecordSet) map[string]string {
+	registries := map[string]*RecordSet{}
+	for _, rs := range list {
+		if strings.EqualFold(rs.GetType(), "TXT") && isRegistryName(rs.GetName()) {
+			registries[strings.ToLower(recordNameOfRegistry(rs.GetName()))] = rs
+		}
+	}
+
+	claims := map[string]string{}
+	if len(registries) == 0 {
+		return claims
+	}
+	for _, rs := range list {
+		if reg, ok := registries[strings.ToLower(rs.GetName())]; ok {
+			if holder := claimHolder(reg, rs.GetType()); holder != "" {
+				claims[syncKey(rs.GetName(), rs.GetType())] = holder
+			}
+		}
+	}
+	return claims
+}
+
+// ClaimRecord claims the record set of a zone with the given name and type for owner in
+// the ownership registry. The record set doesn't need to exist yet. Claiming a record
+// set already claimed by owner does nothing; one claimed by another owner fails with a
+// *RecordOwnedError.
+//
+// The registry TXT record set is read and written under the lock of the zone in the
+// client's ZoneLocks, which only serializes the claims made within a process: owners
+// sharing a zone from different processes should not claim the same name at once.
+func (s *FastDNSv2Service) ClaimRecord(ctx context.Context, zone, name, rtype, owner string) error {
+	return s.editClaims(ctx, "ClaimRecord", zone, name, rtype, owner, true)
+}
+
+// ReleaseRecord removes the claim of owner on the record set of a zone with the given
+// name and type from the ownership registry; the record set itself is left as is.
+// Releasing a record set that isn't claimed does nothing; one claimed by another owner
+// fails with a *RecordOwnedError. The registry TXT record set is deleted once it holds
+// nothing else.
+func (s *FastDNSv2Service) ReleaseRecord(ctx context.Context, zone, name, rtype, owner string) error {
+	return s.editClaims(ctx, "ReleaseRecord", zone, name, rtype, owner, false)
+}
+
+func (s *FastDNSv2Service) editClaims(ctx context.Context, op, zone, name, rtype, owner string, claim bool) error {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return wrapOp(op, zone, name, rtype, err)
+	}
+	if name, err = s.recordName(name); err != nil {
+		return wrapOp(op, zone, name, rtype, err)
+	}
+	rtype = strings.ToUpper(rtype)
+	if owner == "" {
+		return wrapOp(op, zone, name, rtype, errors.New("an owner is required"))
+	}
+
+	ctx, unlock, err := s.lockZone(ctx, zone)
+	if err != nil {
+		return wrapOp(op, zone, name, rtype, err)
+	}
+	defer unlock()
+
+	reg := registryName(name)
+	cur, _, err := s.GetRecordSet(ctx, &RecordSetOptions{Zone: zone, Name: reg, Type: "TXT"})
+	if err != nil && !isStatus(err, http.StatusNotFound) {
+		return wrapOp(op, zone, name, rtype, err)
+	}
+
+	var existing, rdata []string
+	if cur != nil {
+		existing = currentRdata(cur)
+	}
+	holder := ""
+	for _, r := range existing {
+		if value, err := UnquoteTXT(r); err == nil {
+			if o, t, ok := parseClaim(value); ok && (t == "" || t == rtype) {
+				holder = o
+				if o == owner && t == rtype {
+					continue
+				}
+			}
+		}
+		rdata = append(rdata, r)
+	}
+
+	switch {
+	case holder != "" && holder != owner:
+		return &RecordOwnedError{Zone: zone, Name: name, Type: rtype, Owner: owner, Holder: holder}
+	case claim && holder == owner, !claim && holder == "":
+		return nil
+	case claim:
+		rdata = append(rdata, QuoteTXT(formatClaim(owner, rtype)))
+	}
+
+	rs := &RecordSetCreateRequest{Zone: zone, Name: reg, Type: "TXT", TTL: Int(RegistryTTL), Rdata: rdata}
+	switch {
+	case cur == nil:
+		_, _, err = s.CreateRecordSet(ctx, rs)
+	case len(rdata) == 0:
+		_, err = s.DeleteRecordSet(ctx, &RecordSetOptions{Zone: zone, Name: reg, Type: "TXT"})
+	default:
+		rs.TTL = Int(cur.GetTTL())
+		_, _, err = s.UpdateRecordSet(ctx, rs)
+	}
+	if err != nil {
+		return wrapOp(op, zone, name, rtype, err)
+	}
+	return nil
+}
+
+// ListOwnedRecords lists the claims of owner in the ownership registry of a zone, sorted
+// by name and type. The claims on record sets that don't exist are listed too, so that
+// they can be released.
+func (s *FastDNSv2Service) ListOwnedRecords(ctx context.Context, zone, owner string) ([]*RecordClaim, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, wrapOp("ListOwnedRecords", zone, "", "", err)
+	}
+
+	list, err := s.ListAllZoneRecordSets(ctx, zone, &ListZoneRecordSetOptions{Types: "TXT"})
+	if err != nil {
+		return nil, err
+	}
+
+	var claims []*RecordClaim
+	for _, rs := range list {
+		if !strings.EqualFold(rs.GetType(), "TXT") || !isRegistryName(rs.GetName()) {
+			continue
+		}
+		for _, r := range currentRdata(rs) {
+			value, err := UnquoteTXT(r)
+			if err != nil {
+				continue
+			}
+			if o, t, ok := parseClaim(value); ok && t != "" && o == owner {
+				claims = append(claims, &RecordClaim{Name: recordNameOfRegistry(rs.GetName()), Type: t, Owner: o})
+			}
+		}
+	}
+
+	sort.Slice(claims, func(i, j int) bool {
+		a, b := claims[i], claims[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Type < b.Type
+	})
+	return claims, nil
+}
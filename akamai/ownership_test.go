@@ -0,0 +1,210 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+// registryRdata returns the rdata of the registry TXT record set of name, or nil if it
+// doesn't exist.
+func registryRdata(srv *akamaitest.Server, name string) []string {
+	for _, rs := range srv.RecordSets("example.com") {
+		if rs.GetName() == akamai.RegistryPrefix+name && rs.GetType() == "TXT" {
+			var rdata []string
+			for _, r := range rs.Rdata {
+				rdata = append(rdata, akamai.StringValue(r))
+			}
+			return rdata
+		}
+	}
+	return nil
+}
+
+func TestClaimRecord(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+	ctx := context.Background()
+	const teamA, teamB = "team-a", `team b; "blue"=1`
+
+	if err := client.FastDNSv2.ClaimRecord(ctx, "example.com", "www.example.com", "A", teamA); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	// Claiming again is a no-op.
+	if err := client.FastDNSv2.ClaimRecord(ctx, "example.com", "WWW.example.com.", "a", teamA); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	err := client.FastDNSv2.ClaimRecord(ctx, "example.com", "www.example.com", "A", teamB)
+	var oe *akamai.RecordOwnedError
+	if !errors.As(err, &oe) {
+		t.Fatalf("expect a *RecordOwnedError, got %v", err)
+	}
+	assert.True(t, errors.Is(err, akamai.ErrRecordOwned))
+	assert.Equal(t, teamA, oe.Holder)
+	assert.Equal(t, teamB, oe.Owner)
+	assert.Equal(t, "www.example.com", oe.Name)
+	assert.Equal(t, "A", oe.Type)
+
+	// Another type of the same name shares the registry record set.
+	if err := client.FastDNSv2.ClaimRecord(ctx, "example.com", "www.example.com", "AAAA", teamB); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{
+		`"akamai-owner/v1 owner=team-a type=A"`,
+		`"akamai-owner/v1 owner=team+b%3B+%22blue%22%3D1 type=AAAA"`,
+	}, registryRdata(srv, "www.example.com"))
+
+	owned, err := client.FastDNSv2.ListOwnedRecords(ctx, "example.com", teamA)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []*akamai.RecordClaim{{Name: "www.example.com", Type: "A", Owner: teamA}}, owned)
+	owned, err = client.FastDNSv2.ListOwnedRecords(ctx, "example.com", teamB)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []*akamai.RecordClaim{{Name: "www.example.com", Type: "AAAA", Owner: teamB}}, owned)
+
+	err = client.FastDNSv2.ReleaseRecord(ctx, "example.com", "www.example.com", "A", teamB)
+	assert.True(t, errors.Is(err, akamai.ErrRecordOwned), "got %v", err)
+
+	if err := client.FastDNSv2.ReleaseRecord(ctx, "example.com", "www.example.com", "A", teamA); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	// Releasing again is a no-op.
+	if err := client.FastDNSv2.ReleaseRecord(ctx, "example.com", "www.example.com", "A", teamA); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if err := client.FastDNSv2.ReleaseRecord(ctx, "example.com", "www.example.com", "AAAA", teamB); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Nil(t, registryRdata(srv, "www.example.com"))
+
+	// The record sets themselves are left as is.
+	assert.Len(t, srv.RecordSets("example.com"), 5)
+}
+
+func TestClaimRecordWildcard(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+	ctx := context.Background()
+
+	if err := client.FastDNSv2.ClaimRecord(ctx, "example.com", "*.example.com", "CNAME", "team-a"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.NotNil(t, registryRdata(srv, "_wildcard.example.com"))
+
+	owned, err := client.FastDNSv2.ListOwnedRecords(ctx, "example.com", "team-a")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []*akamai.RecordClaim{{Name: "*.example.com", Type: "CNAME", Owner: "team-a"}}, owned)
+}
+
+func TestRegistryForeignValues(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+	ctx := context.Background()
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: akamai.RegistryPrefix + "www.example.com", Type: "TXT", TTL: akamai.Int(60), Rdata: []string{`"note"`}})
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: akamai.RegistryPrefix + "mail.example.com", Type: "TXT", TTL: akamai.Int(60), Rdata: []string{`"akamai-owner/v9 owner=x;types=A,MX"`}})
+
+	// The values that are not claims are kept.
+	if err := client.FastDNSv2.ClaimRecord(ctx, "example.com", "www.example.com", "A", "team-a"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{`"note"`, `"akamai-owner/v1 owner=team-a type=A"`}, registryRdata(srv, "www.example.com"))
+	if err := client.FastDNSv2.ReleaseRecord(ctx, "example.com", "www.example.com", "A", "team-a"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{`"note"`}, registryRdata(srv, "www.example.com"))
+
+	// The claims of an unknown version hold every type of their name.
+	err := client.FastDNSv2.ClaimRecord(ctx, "example.com", "mail.example.com", "AAAA", "team-a")
+	var oe *akamai.RecordOwnedError
+	if !errors.As(err, &oe) {
+		t.Fatalf("expect a *RecordOwnedError, got %v", err)
+	}
+	assert.Equal(t, "akamai-owner/v9 owner=x;types=A,MX", oe.Holder)
+}
+
+func TestSyncRecordSetsOwners(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+	ctx := context.Background()
+	teamA := &akamai.SyncOptions{Owner: "team-a", Prune: true}
+	teamB := &akamai.SyncOptions{Owner: "team-b", Prune: true}
+
+	// Each owner creates its record sets, and prunes none of the others'.
+	_, err := client.FastDNSv2.SyncRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{
+		{Name: "a.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+	}, teamA)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	plan, err := client.FastDNSv2.SyncRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{
+		{Name: "b.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.2"}},
+	}, teamB)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, plan.Changes, 1) {
+		assert.Equal(t, akamai.SyncCreate, plan.Changes[0].Action)
+	}
+
+	// Neither may change the other's record set, nor one that no owner claims.
+	_, err = client.FastDNSv2.SyncRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{
+		{Name: "a.example.com", Type: "A", TTL: akamai.Int(600), Rdata: []string{"192.0.2.1"}},
+	}, teamB)
+	var oe *akamai.RecordOwnedError
+	if assert.True(t, errors.As(err, &oe), "got %v", err) {
+		assert.Equal(t, "team-a", oe.Holder)
+	}
+	_, err = client.FastDNSv2.PlanRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{
+		{Name: "a.example.com", Type: "A", Ensure: akamai.EnsureAbsent},
+	}, teamB)
+	assert.True(t, errors.Is(err, akamai.ErrRecordOwned), "got %v", err)
+	_, err = client.FastDNSv2.PlanRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{
+		{Name: "mail.example.com", Type: "A", TTL: akamai.Int(600), Rdata: []string{"192.0.2.10"}},
+	}, teamB)
+	if assert.True(t, errors.As(err, &oe), "got %v", err) {
+		assert.Equal(t, "", oe.Holder)
+	}
+
+	// Unless told to adopt it.
+	_, err = client.FastDNSv2.SyncRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{
+		{Name: "b.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.2"}},
+		{Name: "mail.example.com", Type: "A", TTL: akamai.Int(600), Rdata: []string{"192.0.2.10"}},
+	}, &akamai.SyncOptions{Owner: "team-b", Prune: true, AdoptUnowned: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	owned, err := client.FastDNSv2.ListOwnedRecords(ctx, "example.com", "team-b")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []*akamai.RecordClaim{
+		{Name: "b.example.com", Type: "A", Owner: "team-b"},
+		{Name: "mail.example.com", Type: "A", Owner: "team-b"},
+	}, owned)
+
+	// Pruning everything deletes and releases only the owner's record sets.
+	_, err = client.FastDNSv2.SyncRecordSets(ctx, "example.com", nil, teamA)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	var names []string
+	for _, rs := range srv.RecordSets("example.com") {
+		names = append(names, rs.GetName()+" "+rs.GetType())
+	}
+	assert.Equal(t, "_akamai-owner.b.example.com TXT,_akamai-owner.mail.example.com TXT,b.example.com A,example.com NS,example.com SOA,mail.example.com A,old.example.com CNAME,www.example.com A",
+		strings.Join(names, ","))
+
+	// A sync without an owner doesn't prune the registry.
+	plan, err = client.FastDNSv2.PlanRecordSets(ctx, "example.com", nil, &akamai.SyncOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, plan.Changes, 4)
+}
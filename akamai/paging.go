@@ -0,0 +1,335 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// listAllPageSize is the page size the ListAll methods use when the caller gives none,
+// including when they fall back to paging.
+const listAllPageSize = 500
+
+// ErrShowAllWithPaging is returned for list options that set ShowAll along with Page or
+// PageSize. The API ignores the paging with showAll, so combining them is a mistake
+// that returned every item on every page.
+var ErrShowAllWithPaging = errors.New("ShowAll can't be combined with Page or PageSize")
+
+// validatePaging checks the ShowAll option of the list methods against paged, whether
+// they set Page or PageSize.
+func validatePaging(showAll, paged bool) error {
+	if showAll && paged {
+		return ErrShowAllWithPaging
+	}
+	return nil
+}
+
+// optionalInt returns a pointer to v, or nil if v is zero.
+func optionalInt(v int) *int {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+// Defaults of AdaptivePaging.
+const (
+	defaultMinPageSize = 10
+	defaultMaxPageSize = 5000
+	defaultGrowAfter   = 3
+)
+
+// AdaptivePaging configures the ListAll and ForEach methods to adapt the size of the
+// pages they request to how the API copes with them. They start at the PageSize of
+// their options, or 500, and halve it when a page times out or fails with a 5xx status,
+// retrying the page; they double it back after GrowAfter pages in a row succeed. A
+// showAll request that fails so falls back to paging.
+//
+// The pages are requested by absolute offset into the list, so that changing their size
+// midway neither skips nor repeats items: a page that starts before the offset reached
+// has its first items dropped.
+type AdaptivePaging struct {
+	// MinPageSize and MaxPageSize bound the page size. They default to 10 and 5000. A
+	// page that fails at MinPageSize fails the listing.
+	MinPageSize int
+	MaxPageSize int
+
+	// PageTimeout, if set, bounds the time each page may take, as the page size is
+	// halved for pages that take too long, such as on a busy API.
+	PageTimeout time.Duration
+
+	// GrowAfter is the number of pages in a row that must succeed for the page size to
+	// be doubled. It defaults to 3.
+	GrowAfter int
+}
+
+// WithAdaptivePaging makes the ListAll and ForEach methods of the client adapt their page
+// size as configured by p, or stop adapting it if p is nil.
+//
+// WithAdaptivePaging must not be called while the client is in use; it returns c so that
+// calls can be chained.
+func (c *Client) WithAdaptivePaging(p *AdaptivePaging) *Client {
+	c.adaptivePaging = p
+	return c
+}
+
+func (p *AdaptivePaging) bounds() (int, int) {
+	minSize, maxSize := p.MinPageSize, p.MaxPageSize
+	if minSize <= 0 {
+		minSize = defaultMinPageSize
+	}
+	if maxSize <= 0 {
+		maxSize = defaultMaxPageSize
+	}
+	if maxSize < minSize {
+		maxSize = minSize
+	}
+	return minSize, maxSize
+}
+
+func (p *AdaptivePaging) growAfter() int {
+	if p.GrowAfter <= 0 {
+		return defaultGrowAfter
+	}
+	return p.GrowAfter
+}
+
+// overloaded reports whether err, that of a page requested with ctx, is one after which
+// the page is retried at a smaller size: a timeout that is not that of ctx, or a 5xx
+// status.
+func (p *AdaptivePaging) overloaded(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var ae *AkamaiError
+	if errors.As(err, &ae) {
+		return ae.Status >= http.StatusInternalServerError
+	}
+	var ne net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &ne) && ne.Timeout()
+}
+
+// pageContext returns the context a page is requested with, bounded by PageTimeout.
+func (p *AdaptivePaging) pageContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p == nil || p.PageTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, p.PageTimeout)
+}
+
+// ListAllZones returns the zones ListZones lists with opt across all pages.
+//
+// Unless opt sets Page or PageSize, the zones are requested at once with showAll. The
+// API truncates showAll responses of very large lists, which is detected by their total
+// count: the zones are then requested page by page instead. If opt sets Page or
+// PageSize, the zones are requested page by page from Page on; a zero Page or PageSize
+// counts as unset. Zones listed twice, as the list changes between two pages, are only
+// returned once. See WithAdaptivePaging to adapt the page size to slow or failing pages.
+func (s *FastDNSv2Service) ListAllZones(ctx context.Context, opt *ZoneListOptions) ([]*Zone, error) {
+	var o ZoneListOptions
+	if opt != nil {
+		o = *opt
+	}
+	if err := validatePaging(o.ShowAll, o.Page != nil || o.PageSize != nil); err != nil {
+		return nil, wrapOp("ListAllZones", "", "", "", err)
+	}
+
+	zones, err := listAll(ctx, s.client.adaptivePaging, o.GetPage(), o.GetPageSize(), func(z *Zone) string {
+		return strings.ToLower(z.GetZone())
+	}, func(ctx context.Context, showAll bool, page, pageSize int) ([]*Zone, int, error) {
+		o.ShowAll, o.Page, o.PageSize = showAll, optionalInt(page), optionalInt(pageSize)
+		list, _, err := s.ListZones(ctx, &o)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Zones, list.Metadata.GetTotalElements(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// ListAllZoneRecordSets returns the record sets GetZoneRecordSets lists with opt across
+// all pages, requesting them as ListAllZones does zones.
+func (s *FastDNSv2Service) ListAllZoneRecordSets(ctx context.Context, zone string, opt *ListZoneRecordSetOptions) ([]*RecordSet, error) {
+	var o ListZoneRecordSetOptions
+	if opt != nil {
+		o = *opt
+	}
+	if err := validatePaging(o.ShowAll, o.Page != 0 || o.PageSize != 0); err != nil {
+		return nil, wrapOp("ListAllZoneRecordSets", zone, "", "", err)
+	}
+
+	records, err := listAll(ctx, s.client.adaptivePaging, o.Page, o.PageSize, func(rs *RecordSet) string {
+		return syncKey(rs.GetName(), rs.GetType())
+	}, func(ctx context.Context, showAll bool, page, pageSize int) ([]*RecordSet, int, error) {
+		o.ShowAll, o.Page, o.PageSize = showAll, page, pageSize
+		list, _, err := s.GetZoneRecordSets(ctx, zone, &o)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.RecordSets, list.Metadata.GetTotalElements(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ForEachRecordSet calls fn with the record sets GetZoneRecordSets lists with opt across
+// all pages, requesting them as ListAllZoneRecordSets does. The record sets are decoded
+// one by one as the responses are read, and handed to fn as they are, so that the
+// memory used doesn't grow with the size of the zone, but for the names and types kept
+// so that record sets listed twice are only handed once. Their names are lowercased as
+// GetZoneRecordSets does.
+//
+// An error of fn stops the listing, and is returned as is.
+func (s *FastDNSv2Service) ForEachRecordSet(ctx context.Context, zone string, opt *ListZoneRecordSetOptions, fn func(rs *RecordSet) error) error {
+	var o ListZoneRecordSetOptions
+	if opt != nil {
+		o = *opt
+	}
+	if err := validatePaging(o.ShowAll, o.Page != 0 || o.PageSize != 0); err != nil {
+		return wrapOp("ForEachRecordSet", zone, "", "", err)
+	}
+
+	var fnErr error
+	seen := map[string]bool{}
+	return eachPage(ctx, s.client.adaptivePaging, o.Page, o.PageSize, func(ctx context.Context, showAll bool, page, pageSize, skip int) (int, int, error) {
+		o.ShowAll, o.Page, o.PageSize = showAll, page, pageSize
+
+		n := 0
+		var list ListZoneRecordSets
+		stream := NewArrayStream("recordsets", &list, func(rs *RecordSet) error {
+			n++
+			if n <= skip {
+				return nil
+			}
+			s.readRecordNames([]*RecordSet{rs})
+			if k := syncKey(rs.GetName(), rs.GetType()); !seen[k] {
+				seen[k] = true
+				if fnErr = fn(rs); fnErr != nil {
+					return fnErr
+				}
+			}
+			return nil
+		})
+		if _, err := s.getZoneRecordSets(ctx, "ForEachRecordSet", zone, &o, stream); err != nil {
+			if fnErr != nil {
+				return 0, 0, fnErr
+			}
+			return 0, 0, err
+		}
+		return n, list.Metadata.GetTotalElements(), nil
+	})
+}
+
+// eachPage goes through the pages of a paginated list. fetch lists either all the items
+// with showAll, or a page of them ignoring its first skip items, and returns the number
+// of items of the page with the total count the API reports. page and pageSize are the
+// ones the caller gave, which select paging when set; otherwise the items are requested
+// at once with showAll, falling back to paging if the response is truncated.
+//
+// With adaptive paging, the page size changes as configured by ap, and the pages are
+// requested so as to continue from the absolute offset reached.
+func eachPage(ctx context.Context, ap *AdaptivePaging, page, pageSize int, fetch func(ctx context.Context, showAll bool, page, pageSize, skip int) (int, int, error)) error {
+	if page == 0 && pageSize == 0 {
+		pctx, cancel := ap.pageContext(ctx)
+		n, total, err := fetch(pctx, true, 0, 0, 0)
+		cancel()
+		switch {
+		case err == nil && total <= n:
+			return nil
+		case err != nil && (ap == nil || !ap.overloaded(ctx, err)):
+			return err
+		}
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = listAllPageSize
+	}
+
+	offset := (page - 1) * pageSize
+	size, minSize, maxSize := pageSize, pageSize, pageSize
+	if ap != nil {
+		minSize, maxSize = ap.bounds()
+		if size < minSize {
+			size = minSize
+		}
+		if size > maxSize {
+			size = maxSize
+		}
+	}
+
+	for succeeded := 0; ; {
+		page := offset/size + 1
+		start := (page - 1) * size
+
+		pctx, cancel := ap.pageContext(ctx)
+		n, total, err := fetch(pctx, false, page, size, offset-start)
+		cancel()
+		if err != nil {
+			if ap == nil || size <= minSize || !ap.overloaded(ctx, err) {
+				return err
+			}
+			if size /= 2; size < minSize {
+				size = minSize
+			}
+			succeeded = 0
+			continue
+		}
+
+		if start+n > offset {
+			offset = start + n
+		}
+		if n < size || offset >= total {
+			return nil
+		}
+
+		// The page size only grows at offsets it divides, not to request the items
+		// already listed again.
+		if succeeded++; ap != nil && succeeded >= ap.growAfter() && size < maxSize {
+			grown := size * 2
+			if grown > maxSize {
+				grown = maxSize
+			}
+			if offset%grown == 0 {
+				size, succeeded = grown, 0
+			}
+		}
+	}
+}
+
+// listAll collects the items of a paginated list, going through its pages with
+// eachPage. fetch lists either all the items with showAll, or a page of them, and
+// returns them with the total count the API reports. Items listed twice, as the list
+// changes between two pages, are only returned once, and those of a truncated showAll
+// response are dropped for the pages that follow it.
+func listAll[T any](ctx context.Context, ap *AdaptivePaging, page, pageSize int, key func(T) string, fetch func(ctx context.Context, showAll bool, page, pageSize int) ([]T, int, error)) ([]T, error) {
+	var all []T
+	seen := map[string]bool{}
+	err := eachPage(ctx, ap, page, pageSize, func(ctx context.Context, showAll bool, page, pageSize, skip int) (int, int, error) {
+		items, total, err := fetch(ctx, showAll, page, pageSize)
+		if err != nil || showAll && total > len(items) {
+			return len(items), total, err
+		}
+		for i, item := range items {
+			if k := key(item); i >= skip && !seen[k] {
+				seen[k] = true
+				all = append(all, item)
+			}
+		}
+		return len(items), total, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,203 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestShowAllWithPaging(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+
+	_, _, err := client.FastDNSv2.ListZones(ctx, &akamai.ZoneListOptions{ShowAll: true, PageSize: akamai.Int(10)})
+	assert.True(t, errors.Is(err, akamai.ErrShowAllWithPaging))
+	_, _, err = client.FastDNSv2.ListZones(ctx, &akamai.ZoneListOptions{ShowAll: true, Page: akamai.Int(0)})
+	assert.True(t, errors.Is(err, akamai.ErrShowAllWithPaging))
+	_, _, err = client.FastDNSv2.GetZoneRecordSets(ctx, "example.com", &akamai.ListZoneRecordSetOptions{ShowAll: true, Page: 2})
+	assert.True(t, errors.Is(err, akamai.ErrShowAllWithPaging))
+	_, _, err = client.FastDNSv2.GetChangeListRecordSets(ctx, "example.com", &akamai.ChangeListOptions{ShowAll: true, Page: 1, PageSize: 10})
+	assert.True(t, errors.Is(err, akamai.ErrShowAllWithPaging))
+	_, err = client.FastDNSv2.ListAllZones(ctx, &akamai.ZoneListOptions{ShowAll: true, Page: akamai.Int(1)})
+	assert.True(t, errors.Is(err, akamai.ErrShowAllWithPaging))
+	assert.Empty(t, srv.Requests())
+}
+
+func TestListAllZones(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+	for i := 0; i < 12; i++ {
+		srv.AddZone(&akamai.ZoneCreateRequest{Zone: fmt.Sprintf("z%02d.example", i), Type: "PRIMARY"})
+	}
+	names := func(zones []*akamai.Zone) []string {
+		var n []string
+		for _, z := range zones {
+			n = append(n, z.GetZone())
+		}
+		return n
+	}
+
+	// Without paging, the zones are requested at once.
+	zones, err := client.FastDNSv2.ListAllZones(ctx, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, zones, 12)
+	assert.Equal(t, []string{"GET /config-dns/v2/zones"}, srv.Requests())
+
+	// Truncated showAll responses fall back to paging.
+	srv.ShowAllLimit = 5
+	zones, err = client.FastDNSv2.ListAllZones(ctx, &akamai.ZoneListOptions{Types: "PRIMARY"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, zones, 12)
+	assert.Equal(t, "z00.example", zones[0].GetZone())
+	assert.Equal(t, "z11.example", zones[11].GetZone())
+	assert.Len(t, srv.Requests(), 3, "the showAll request and a single page of 500")
+
+	// Paging given by the caller is followed from its page on.
+	zones, err = client.FastDNSv2.ListAllZones(ctx, &akamai.ZoneListOptions{Page: akamai.Int(2), PageSize: akamai.Int(5)})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"z05.example", "z06.example", "z07.example", "z08.example", "z09.example", "z10.example", "z11.example"}, names(zones))
+	assert.Len(t, srv.Requests(), 5)
+}
+
+func TestListAllZoneRecordSetsTruncated(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	for i := 0; i < 1200; i++ {
+		srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: fmt.Sprintf("h%04d.example.com", i), Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	}
+	srv.ShowAllLimit = 1000
+
+	records, err := client.FastDNSv2.ListAllZoneRecordSets(ctx, "example.com", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, records, 1202)
+
+	assert.Len(t, srv.Requests(), 4, "the truncated showAll request and three pages of 500")
+
+	// The record sets of a truncated zone are all synced against.
+	plan, err := client.FastDNSv2.PlanRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{
+		{Name: "h1199.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, plan.Empty(), "h1199 exists past the truncation and must not be created")
+	assert.Equal(t, 1, plan.Unchanged)
+}
+
+// recordNames returns the names of record sets.
+func recordNames(records []*akamai.RecordSet) []string {
+	var names []string
+	for _, rs := range records {
+		names = append(names, rs.GetName())
+	}
+	return names
+}
+
+func TestListAllAdaptivePaging(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	for i := 0; i < 1234; i++ {
+		srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: fmt.Sprintf("h%04d.example.com", i), Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	}
+	want := recordNames(srv.RecordSets("example.com"))
+
+	// Pages of more than 150 record sets fail.
+	srv.MaxPageSize = 150
+	_, err := client.FastDNSv2.ListAllZoneRecordSets(ctx, "example.com", nil)
+	assert.True(t, isStatus(err, 504), "got %v", err)
+
+	client.WithAdaptivePaging(&akamai.AdaptivePaging{MinPageSize: 50, MaxPageSize: 1000, GrowAfter: 2})
+	records, err := client.FastDNSv2.ListAllZoneRecordSets(ctx, "example.com", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, want, recordNames(records))
+
+	var streamed []*akamai.RecordSet
+	err = client.FastDNSv2.ForEachRecordSet(ctx, "example.com", &akamai.ListZoneRecordSetOptions{PageSize: 1000}, func(rs *akamai.RecordSet) error {
+		streamed = append(streamed, rs)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, want, recordNames(streamed))
+
+	// The page size never goes below MinPageSize.
+	srv.MaxPageSize = 20
+	_, err = client.FastDNSv2.ListAllZoneRecordSets(ctx, "example.com", nil)
+	assert.True(t, isStatus(err, 504), "got %v", err)
+}
+
+func TestListAllAdaptivePagingTimeout(t *testing.T) {
+	const total = 1000
+	var mu sync.Mutex
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		mu.Lock()
+		requests = append(requests, fmt.Sprintf("%d/%d", page, pageSize))
+		mu.Unlock()
+
+		// Past the first page, pages of more than 100 record sets take too long.
+		if page > 1 && pageSize > 100 {
+			<-r.Context().Done()
+			return
+		}
+
+		var records []string
+		for i := (page - 1) * pageSize; i < page*pageSize && i < total; i++ {
+			records = append(records, fmt.Sprintf(`{"name": "h%04d.example.com", "type": "A", "ttl": 300, "rdata": ["192.0.2.1"]}`, i))
+		}
+		fmt.Fprintf(w, `{"metadata": {"totalElements": %d}, "recordsets": [%s]}`, total, strings.Join(records, ","))
+	}))
+	defer ts.Close()
+
+	client := akamaitest.NewStaticTestClient(t, ts.URL)
+	client.WithAdaptivePaging(&akamai.AdaptivePaging{PageTimeout: 50 * time.Millisecond, GrowAfter: 4})
+
+	records, err := client.FastDNSv2.ListAllZoneRecordSets(context.Background(), "example.com", &akamai.ListZoneRecordSetOptions{PageSize: 125})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, records, total) {
+		for i, rs := range records {
+			if rs.GetName() != fmt.Sprintf("h%04d.example.com", i) {
+				t.Fatalf("expect h%04d.example.com at %d, got %v", i, i, rs.GetName())
+			}
+		}
+	}
+
+	// The page of 62 record sets after the first 125 starts at 124, whose record set
+	// is dropped.
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"1/125", "2/125", "3/62", "4/62", "5/62", "6/62"}, requests[:6])
+}
+
+func isStatus(err error, status int) bool {
+	var ae *akamai.AkamaiError
+	return errors.As(err, &ae) && ae.Status == status
+}
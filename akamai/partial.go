@@ -0,0 +1,53 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPartialFailure is matched by errors.Is for the errors of bulk requests that failed
+// for some of their items.
+var ErrPartialFailure = errors.New("bulk request partially failed")
+
+// PartialFailure is an item of a bulk request that failed, with the reason Akamai gave.
+type PartialFailure struct {
+	Item   string
+	Reason string
+}
+
+// PartialError is returned by the methods reading the outcome of bulk requests, along
+// with the outcome, when Akamai reports that some items failed even though the request
+// itself succeeded. Succeeded and Failed list the items of each kind, so that callers
+// can go on with the successful ones. Succeeded is empty when every item failed.
+type PartialError struct {
+	Succeeded []string
+	Failed    []*PartialFailure
+}
+
+func (e *PartialError) Error() string {
+	first := e.Failed[0]
+	return fmt.Sprintf("%v: %d of %d items failed, first: %v: %v",
+		ErrPartialFailure, len(e.Failed), len(e.Succeeded)+len(e.Failed), first.Item, first.Reason)
+}
+
+// Is makes errors.Is(err, ErrPartialFailure) report true.
+func (e *PartialError) Is(target error) bool {
+	return target == ErrPartialFailure
+}
+
+// partialError returns a *PartialError listing the zones the deletion failed for, or
+// nil if there are none.
+func (r *ZoneDeleteResult) partialError() error {
+	if r == nil || len(r.FailedZones) == 0 {
+		return nil
+	}
+
+	e := &PartialError{Succeeded: []string{}}
+	for _, z := range r.DeletedZones {
+		e.Succeeded = append(e.Succeeded, StringValue(z))
+	}
+	for _, z := range r.FailedZones {
+		e.Failed = append(e.Failed, &PartialFailure{Item: StringValue(z.Zone), Reason: StringValue(z.FailureReason)})
+	}
+	return e
+}
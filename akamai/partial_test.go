@@ -0,0 +1,82 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteZoneResultPartialFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want *PartialError
+	}{
+		{
+			name: "all succeeded",
+			body: `{"requestId": "15bc138f", "successfullyDeletedZones": ["a.com", "b.com"], "failedZones": []}`,
+		},
+		{
+			name: "all failed",
+			body: `{"requestId": "15bc138f", "failedZones": [
+				{"zone": "a.com", "failureReason": "ZONE_NOT_FOUND"},
+				{"zone": "b.com", "failureReason": "ZONE_LOCKED"}
+			]}`,
+			want: &PartialError{
+				Succeeded: []string{},
+				Failed:    []*PartialFailure{{Item: "a.com", Reason: "ZONE_NOT_FOUND"}, {Item: "b.com", Reason: "ZONE_LOCKED"}},
+			},
+		},
+		{
+			name: "mixed",
+			body: `{"requestId": "15bc138f", "successfullyDeletedZones": ["a.com"], "failedZones": [
+				{"zone": "b.com", "failureReason": "ZONE_LOCKED"}
+			]}`,
+			want: &PartialError{
+				Succeeded: []string{"a.com"},
+				Failed:    []*PartialFailure{{Item: "b.com", Reason: "ZONE_LOCKED"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/config-dns/v2/zones/delete-requests/15bc138f/result", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				fmt.Fprint(w, tt.body)
+			})
+
+			result, _, err := client.FastDNSv2.DeleteZoneResult(context.Background(), "15bc138f")
+			if result == nil {
+				t.Fatalf("expect a result, got %v", err)
+			}
+			assert.Equal(t, "15bc138f", result.GetRequestID())
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, ErrPartialFailure))
+			var pe *PartialError
+			if assert.True(t, errors.As(err, &pe), "got %v", err) {
+				assert.Equal(t, tt.want, pe)
+			}
+			assert.Contains(t, err.Error(), "DeleteZoneResult")
+		})
+	}
+}
+
+func TestPartialErrorMessage(t *testing.T) {
+	err := &PartialError{
+		Succeeded: []string{"a.com"},
+		Failed:    []*PartialFailure{{Item: "b.com", Reason: "ZONE_LOCKED"}, {Item: "c.com", Reason: "ZONE_NOT_FOUND"}},
+	}
+	assert.Equal(t, "bulk request partially failed: 2 of 3 items failed, first: b.com: ZONE_LOCKED", err.Error())
+}
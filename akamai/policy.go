@@ -0,0 +1,135 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPolicyViolation is matched by errors.Is for the writes of record sets that a
+// policy of the client rejected.
+var ErrPolicyViolation = errors.New("record set violates policy")
+
+// RecordPolicy checks a record set before the client writes it, and returns an error to
+// reject it. The record set has its zone and name normalized. A policy names the rule it
+// enforces by returning a *PolicyViolationError; any other error is wrapped in one
+// without a rule.
+//
+// The tombstones given to PlanRecordSets are checked too, with their Ensure set to
+// EnsureAbsent and the TTL and rdata of the record set they delete, so that policies can
+// restrict deletions.
+type RecordPolicy func(rs *RecordSetCreateRequest) error
+
+// PolicyViolationError is returned, before any request is made, for the writes of
+// record sets that a RecordPolicy rejected. See FastDNSv2Service.WithRecordPolicy.
+type PolicyViolationError struct {
+	// Rule names the rule that was violated, such as "ttl-bounds".
+	Rule string
+
+	// Zone, Name and Type identify the record set. They are filled in by the client.
+	Zone string
+	Name string
+	Type string
+
+	Err error
+}
+
+func (e *PolicyViolationError) Error() string {
+	var b strings.Builder
+	b.WriteString(ErrPolicyViolation.Error())
+	if e.Rule != "" {
+		b.WriteString(" " + e.Rule)
+	}
+	fmt.Fprintf(&b, ": %v %v", e.Name, e.Type)
+	if e.Zone != "" {
+		b.WriteString(" (zone " + e.Zone + ")")
+	}
+	if e.Err != nil {
+		b.WriteString(": " + e.Err.Error())
+	}
+	return b.String()
+}
+
+// Is makes errors.Is(err, ErrPolicyViolation) report true.
+func (e *PolicyViolationError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+func (e *PolicyViolationError) Unwrap() error {
+	return e.Err
+}
+
+// WithRecordPolicy adds policies that the record sets written with s must satisfy.
+// They are checked in order by CreateRecordSet, UpdateRecordSet and ReplaceRecordSets
+// before sending anything, and by PlanRecordSets for the record sets it would create or
+// update and the tombstones it would delete, so that violations show up in dry runs.
+// The first violation fails the call with a *PolicyViolationError. See
+// Client.FastDNSv2Service to configure the service of a client.
+//
+// WithRecordPolicy must not be called while s is in use; it returns s so that calls can
+// be chained.
+func (s *FastDNSv2Service) WithRecordPolicy(policies ...RecordPolicy) *FastDNSv2Service {
+	s.recordPolicies = append(s.recordPolicies, policies...)
+	return s
+}
+
+// checkRecordPolicies returns the first violation of the policies of s by rs, as a
+// *PolicyViolationError.
+func (s *FastDNSv2Service) checkRecordPolicies(rs *RecordSetCreateRequest) error {
+	for _, p := range s.recordPolicies {
+		err := p(rs)
+		if err == nil {
+			continue
+		}
+
+		var pv *PolicyViolationError
+		if !errors.As(err, &pv) {
+			pv = &PolicyViolationError{Err: err}
+		}
+		v := *pv
+		v.Zone, v.Name, v.Type = rs.Zone, rs.Name, strings.ToUpper(rs.Type)
+		return &v
+	}
+	return nil
+}
+
+// TTLBoundsPolicy rejects record sets whose TTL is under min or over max seconds. A
+// zero bound is not enforced. Tombstones, and the record sets whose name starts with
+// one of the exempt labels, such as "_acme-challenge", are not checked.
+func TTLBoundsPolicy(min, max int, exempt ...string) RecordPolicy {
+	return func(rs *RecordSetCreateRequest) error {
+		if rs.Ensure == EnsureAbsent {
+			return nil
+		}
+		label := strings.ToLower(strings.SplitN(rs.Name, ".", 2)[0])
+		for _, e := range exempt {
+			if label == strings.ToLower(e) {
+				return nil
+			}
+		}
+
+		switch ttl := rs.GetTTL(); {
+		case min > 0 && ttl < min:
+			return &PolicyViolationError{Rule: "ttl-bounds", Err: fmt.Errorf("TTL %d is under the minimum of %d", ttl, min)}
+		case max > 0 && ttl > max:
+			return &PolicyViolationError{Rule: "ttl-bounds", Err: fmt.Errorf("TTL %d is over the maximum of %d", ttl, max)}
+		}
+		return nil
+	}
+}
+
+// ForbiddenTypesPolicy rejects record sets of the given types. Their deletion is
+// allowed.
+func ForbiddenTypesPolicy(types ...string) RecordPolicy {
+	return func(rs *RecordSetCreateRequest) error {
+		if rs.Ensure == EnsureAbsent {
+			return nil
+		}
+		for _, t := range types {
+			if strings.EqualFold(rs.Type, t) {
+				return &PolicyViolationError{Rule: "forbidden-types", Err: fmt.Errorf("%v records are forbidden", strings.ToUpper(t))}
+			}
+		}
+		return nil
+	}
+}
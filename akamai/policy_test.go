@@ -0,0 +1,142 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+func TestTTLBoundsPolicy(t *testing.T) {
+	policy := akamai.TTLBoundsPolicy(300, 86400, "_acme-challenge")
+
+	tests := []struct {
+		name string
+		ttl  int
+		ok   bool
+	}{
+		{"www.example.com", 300, true},
+		{"www.example.com", 60, false},
+		{"www.example.com", 86401, false},
+		{"_acme-challenge.www.example.com", 60, true},
+		{"_ACME-Challenge.example.com", 60, true},
+		{"acme.example.com", 60, false},
+	}
+	for _, tt := range tests {
+		err := policy(&akamai.RecordSetCreateRequest{Name: tt.name, Type: "TXT", TTL: akamai.Int(tt.ttl)})
+		if tt.ok {
+			assert.NoError(t, err, tt.name)
+			continue
+		}
+		var pv *akamai.PolicyViolationError
+		if assert.True(t, errors.As(err, &pv), tt.name) {
+			assert.Equal(t, "ttl-bounds", pv.Rule)
+		}
+	}
+
+	assert.NoError(t, akamai.TTLBoundsPolicy(0, 0)(&akamai.RecordSetCreateRequest{Name: "www.example.com", TTL: akamai.Int(1)}))
+}
+
+func TestForbiddenTypesPolicy(t *testing.T) {
+	policy := akamai.ForbiddenTypesPolicy("spf", "HINFO")
+	assert.NoError(t, policy(&akamai.RecordSetCreateRequest{Type: "TXT"}))
+	assert.True(t, errors.Is(policy(&akamai.RecordSetCreateRequest{Type: "SPF"}), akamai.ErrPolicyViolation))
+	assert.True(t, errors.Is(policy(&akamai.RecordSetCreateRequest{Type: "hinfo"}), akamai.ErrPolicyViolation))
+}
+
+// TestRecordPolicyDataCache checks that the policies of the service of a client apply
+// whether its FastDNSv2 is wrapped in a DataCache before or after they are added.
+func TestRecordPolicyDataCache(t *testing.T) {
+	for _, cacheFirst := range []bool{true, false} {
+		client, _ := newSyncTestServer(t)
+		if cacheFirst {
+			client.FastDNSv2 = akamai.NewDataCache(client.FastDNSv2, time.Minute)
+		}
+		client.FastDNSv2Service().WithRecordPolicy(akamai.TTLBoundsPolicy(600, 0))
+		if !cacheFirst {
+			client.FastDNSv2 = akamai.NewDataCache(client.FastDNSv2, time.Minute)
+		}
+
+		_, _, err := client.FastDNSv2.CreateRecordSet(context.Background(), &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "api.example.com", Type: "A", TTL: akamai.Int(60), Rdata: []string{"192.0.2.20"}})
+		assert.True(t, errors.Is(err, akamai.ErrPolicyViolation), "cache first %v: got %v", cacheFirst, err)
+	}
+}
+
+func TestRecordPolicyWritePaths(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+	var checked []string
+	client.FastDNSv2Service().WithRecordPolicy(func(rs *akamai.RecordSetCreateRequest) error {
+		checked = append(checked, rs.Name+" "+rs.Type)
+		return nil
+	}, akamai.TTLBoundsPolicy(300, 0))
+	ctx := context.Background()
+
+	low := &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.Example.com", Type: "a", TTL: akamai.Int(60), Rdata: []string{"192.0.2.1"}}
+	writes := map[string]func() error{
+		"CreateRecordSet": func() error {
+			_, _, err := client.FastDNSv2.CreateRecordSet(ctx, low)
+			return err
+		},
+		"UpdateRecordSet": func() error {
+			_, _, err := client.FastDNSv2.UpdateRecordSet(ctx, low)
+			return err
+		},
+		"ReplaceRecordSets": func() error {
+			_, err := client.FastDNSv2.ReplaceRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{low})
+			return err
+		},
+		"PlanRecordSets": func() error {
+			_, err := client.FastDNSv2.PlanRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{low}, nil)
+			return err
+		},
+		"SyncRecordSets": func() error {
+			_, err := client.FastDNSv2.SyncRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{low}, nil)
+			return err
+		},
+	}
+	for op, write := range writes {
+		checked = nil
+		err := write()
+		assert.Equal(t, []string{"www.example.com a"}, checked, op)
+
+		var pv *akamai.PolicyViolationError
+		if assert.True(t, errors.As(err, &pv), op) {
+			assert.Equal(t, "ttl-bounds", pv.Rule)
+			assert.Equal(t, "example.com", pv.Zone)
+			assert.Equal(t, "www.example.com", pv.Name)
+			assert.Equal(t, "A", pv.Type)
+			assert.Equal(t, "record set violates policy ttl-bounds: www.example.com A (zone example.com): TTL 60 is under the minimum of 300", pv.Error())
+		}
+		if oe, ok := akamai.OperationFromError(err); assert.True(t, ok, op) && op != "SyncRecordSets" {
+			assert.Equal(t, op, oe.Op)
+		}
+	}
+	for _, r := range srv.Requests() {
+		assert.True(t, strings.HasPrefix(r, "GET "), r)
+	}
+
+	// The planner only checks the record sets it would write: www.example.com is
+	// unchanged.
+	checked = nil
+	plan, err := client.FastDNSv2.PlanRecordSets(ctx, "example.com", syncDesired, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, plan.Changes, 2)
+	assert.Equal(t, []string{"mail.example.com A", "api.example.com CNAME"}, checked)
+
+	// Errors of other types are wrapped without a rule.
+	client.FastDNSv2Service().WithRecordPolicy(func(rs *akamai.RecordSetCreateRequest) error {
+		return errors.New("no changes on Fridays")
+	})
+	_, _, err = client.FastDNSv2.CreateRecordSet(ctx, &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "new.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	var pv *akamai.PolicyViolationError
+	if assert.True(t, errors.As(err, &pv)) {
+		assert.Equal(t, "", pv.Rule)
+		assert.Equal(t, "record set violates policy: new.example.com A (zone example.com): no changes on Fridays", pv.Error())
+	}
+}
@@ -0,0 +1,250 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Defaults of the zero fields of a PollSpec.
+const (
+	defaultPollInitial    = time.Second
+	defaultPollMax        = time.Minute
+	defaultPollMultiplier = 2
+)
+
+// PollSpec configures the waits between the attempts of Poll.
+type PollSpec struct {
+	// Initial is the wait after the first attempt. Defaults to 1s.
+	Initial time.Duration
+
+	// Max caps the wait between two attempts. Defaults to 1m.
+	Max time.Duration
+
+	// Multiplier is the factor the wait grows by after every attempt. Defaults to 2;
+	// set it to 1 to poll at a fixed interval.
+	Multiplier float64
+
+	// Jitter randomizes every wait by up to that fraction of it, in both directions,
+	// so that many pollers started together spread out. Zero waits exactly.
+	Jitter float64
+
+	// MaxAttempts gives up after that many attempts. Zero never gives up.
+	MaxAttempts int
+
+	// sleep waits for d or until ctx is done, and now tells the time. Tests replace
+	// them so that they don't sleep.
+	sleep func(ctx context.Context, d time.Duration) error
+	now   func() time.Time
+}
+
+// pollEvery returns the spec of a poll at a fixed interval, as the wait helpers make.
+func pollEvery(interval time.Duration) PollSpec {
+	return PollSpec{Initial: interval, Max: interval, Multiplier: 1}
+}
+
+// ErrPollTimeout is matched by errors.Is for the errors of the polls that gave up.
+var ErrPollTimeout = errors.New("polling timed out")
+
+// PollTimeoutError is returned by Poll when it gives up before the polled operation is
+// done, because its context's deadline passed or it made its maximum number of
+// attempts. State is the last state fn returned, for diagnostics.
+type PollTimeoutError struct {
+	Attempts int
+	Elapsed  time.Duration
+	State    interface{}
+
+	// Err is context.DeadlineExceeded if the deadline passed, nil otherwise.
+	Err error
+}
+
+func (e *PollTimeoutError) Error() string {
+	msg := fmt.Sprintf("%v after %d attempts in %v", ErrPollTimeout, e.Attempts, e.Elapsed.Round(time.Millisecond))
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrPollTimeout) report true.
+func (e *PollTimeoutError) Is(target error) bool {
+	return target == ErrPollTimeout
+}
+
+func (e *PollTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// PollStats describes a poll, to tell the time spent waiting for the asynchronous
+// processing of Akamai from the time spent elsewhere. Its durations suit histograms.
+type PollStats struct {
+	// Op is the helper that polled, such as "WaitForZoneActive", or "" for Poll.
+	Op string
+
+	// Attempts is the number of times the operation was polled.
+	Attempts int
+
+	// Waited is the time spent waiting between the attempts, and Elapsed the time the
+	// whole poll took, the attempts included.
+	Waited  time.Duration
+	Elapsed time.Duration
+
+	// Statuses are the statuses of the operation seen by the attempts, in order, such as
+	// the activation states of a zone, and Final the last one. Poll records none.
+	Statuses []string
+	Final    string
+
+	// Err is the error the poll ended with, if any.
+	Err error
+}
+
+type pollStatsKey struct{}
+
+// WithPollStats returns a copy of ctx that makes the polls made with it, by Poll or
+// the wait helpers such as WaitForZoneActive, record their PollStats into stats, once
+// they are done. A call making several polls records the last one.
+func WithPollStats(ctx context.Context, stats *PollStats) context.Context {
+	return context.WithValue(ctx, pollStatsKey{}, stats)
+}
+
+// WithPollHook makes the client call hook with the PollStats of every poll made by its
+// wait helpers, such as WaitForZoneActive and WaitForDeleteZone, once it is done, such
+// as to feed metrics.
+//
+// hook may be called from several goroutines at once. WithPollHook must not be called
+// while the client is in use; it returns c so that calls can be chained.
+func (c *Client) WithPollHook(hook func(stats *PollStats)) *Client {
+	c.pollHook = hook
+	return c
+}
+
+// Poll calls fn until it reports that the operation is done, and returns the state fn
+// returned last. fn is called right away, then after waits growing exponentially as
+// configured by spec. An error from fn stops the poll and is returned as is.
+//
+// If ctx is canceled, Poll returns ctx.Err(). If ctx's deadline passes or spec's
+// maximum number of attempts is reached, it returns a *PollTimeoutError.
+func Poll[T any](ctx context.Context, spec PollSpec, fn func(ctx context.Context) (T, bool, error)) (T, error) {
+	state, stats, err := poll(ctx, spec, nil, fn)
+	recordPollStats(ctx, stats)
+	return state, err
+}
+
+// clientPoll polls as Poll does for the wait helper op of c, recording the status of
+// every attempt, and hands its PollStats to the hook of c.
+func clientPoll[T any](ctx context.Context, c *Client, op string, spec PollSpec, status func(T) string, fn func(ctx context.Context) (T, bool, error)) (T, error) {
+	if c.pollSleep != nil {
+		spec.sleep, spec.now = c.pollSleep, c.pollNow
+	}
+	state, stats, err := poll(ctx, spec, status, fn)
+	stats.Op = op
+	recordPollStats(ctx, stats)
+	if c.pollHook != nil {
+		c.pollHook(stats)
+	}
+	return state, err
+}
+
+// recordPollStats copies stats into those set on ctx with WithPollStats, if any.
+func recordPollStats(ctx context.Context, stats *PollStats) {
+	if dst, ok := ctx.Value(pollStatsKey{}).(*PollStats); ok && dst != nil {
+		*dst = *stats
+	}
+}
+
+// poll implements Poll, and describes the poll, with the statuses of the states if
+// status is set. status must accept the states returned along with errors, which may be
+// nil.
+func poll[T any](ctx context.Context, spec PollSpec, status func(T) string, fn func(ctx context.Context) (T, bool, error)) (T, *PollStats, error) {
+	initial := spec.Initial
+	if initial <= 0 {
+		initial = defaultPollInitial
+	}
+	max := spec.Max
+	if max <= 0 {
+		max = defaultPollMax
+	}
+	multiplier := spec.Multiplier
+	if multiplier <= 0 {
+		multiplier = defaultPollMultiplier
+	}
+	sleep := spec.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+	now := spec.now
+	if now == nil {
+		now = time.Now
+	}
+
+	stats := &PollStats{}
+	start := now()
+	done := func(state T, err error) (T, *PollStats, error) {
+		stats.Elapsed, stats.Err = now().Sub(start), err
+		return state, stats, err
+	}
+
+	wait := initial
+	for attempt := 1; ; attempt++ {
+		stats.Attempts = attempt
+		state, ok, err := fn(ctx)
+		if status != nil {
+			// A state returned with an error, such as a zone in the ERROR state, has a
+			// status too.
+			if st := status(state); err == nil || st != "" {
+				stats.Final = st
+				stats.Statuses = append(stats.Statuses, st)
+			}
+		}
+		if err != nil {
+			return done(state, err)
+		}
+		if ok {
+			return done(state, nil)
+		}
+
+		timeout := func(err error) (T, *PollStats, error) {
+			return done(state, &PollTimeoutError{Attempts: attempt, Elapsed: now().Sub(start), State: state, Err: err})
+		}
+		if spec.MaxAttempts > 0 && attempt >= spec.MaxAttempts {
+			return timeout(nil)
+		}
+
+		slept := now()
+		err = sleep(ctx, jitter(wait, spec.Jitter))
+		stats.Waited += now().Sub(slept)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return timeout(err)
+			}
+			return done(state, err)
+		}
+
+		if wait = time.Duration(float64(wait) * multiplier); wait > max {
+			wait = max
+		}
+	}
+}
+
+// jitter randomizes d by up to fraction of it, in both directions.
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return d
+	}
+	return time.Duration(float64(d) * (1 + fraction*(2*rand.Float64()-1)))
+}
+
+// sleepContext waits for d, or returns ctx.Err() if ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,236 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSleep records the waits of a poll instead of sleeping, and returns the error of
+// the context once its deadline would have passed.
+type fakeSleep struct {
+	waits    []time.Duration
+	deadline time.Duration
+	cancel   int
+}
+
+func (f *fakeSleep) sleep(ctx context.Context, d time.Duration) error {
+	f.waits = append(f.waits, d)
+	if f.cancel > 0 && len(f.waits) >= f.cancel {
+		return context.Canceled
+	}
+
+	var total time.Duration
+	for _, w := range f.waits {
+		total += w
+	}
+	if f.deadline > 0 && total >= f.deadline {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+func TestPollBackoff(t *testing.T) {
+	fs := &fakeSleep{}
+	spec := PollSpec{Initial: time.Second, Max: 10 * time.Second, Multiplier: 3, sleep: fs.sleep}
+
+	calls := 0
+	state, err := Poll(context.Background(), spec, func(ctx context.Context) (int, bool, error) {
+		calls++
+		return calls, calls == 5, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, state)
+	assert.Equal(t, []time.Duration{time.Second, 3 * time.Second, 9 * time.Second, 10 * time.Second}, fs.waits)
+}
+
+func TestPollDefaults(t *testing.T) {
+	fs := &fakeSleep{}
+	calls := 0
+	_, err := Poll(context.Background(), PollSpec{sleep: fs.sleep}, func(ctx context.Context) (struct{}, bool, error) {
+		calls++
+		return struct{}{}, calls == 9, nil
+	})
+	assert.NoError(t, err)
+
+	var want []time.Duration
+	for d := defaultPollInitial; len(want) < 8; d *= 2 {
+		if d > defaultPollMax {
+			d = defaultPollMax
+		}
+		want = append(want, d)
+	}
+	assert.Equal(t, want, fs.waits)
+}
+
+func TestPollJitter(t *testing.T) {
+	fs := &fakeSleep{}
+	spec := PollSpec{Initial: time.Second, Multiplier: 1, Jitter: 0.5, MaxAttempts: 50, sleep: fs.sleep}
+
+	_, err := Poll(context.Background(), spec, func(ctx context.Context) (bool, bool, error) {
+		return false, false, nil
+	})
+	assert.True(t, errors.Is(err, ErrPollTimeout))
+
+	varied := false
+	for _, w := range fs.waits {
+		assert.True(t, w >= 500*time.Millisecond && w <= 1500*time.Millisecond, w.String())
+		varied = varied || w != time.Second
+	}
+	assert.True(t, varied)
+}
+
+func TestPollTimeout(t *testing.T) {
+	fs := &fakeSleep{}
+	spec := PollSpec{Initial: time.Second, MaxAttempts: 3, sleep: fs.sleep}
+
+	_, err := Poll(context.Background(), spec, func(ctx context.Context) (string, bool, error) {
+		return "PENDING", false, nil
+	})
+	var pe *PollTimeoutError
+	if assert.True(t, errors.As(err, &pe)) {
+		assert.Equal(t, 3, pe.Attempts)
+		assert.Equal(t, "PENDING", pe.State)
+		assert.Nil(t, pe.Err)
+	}
+	assert.True(t, errors.Is(err, ErrPollTimeout))
+	assert.Len(t, fs.waits, 2)
+
+	// The context's deadline passes during the third wait.
+	fs = &fakeSleep{deadline: 5 * time.Second}
+	spec = PollSpec{Initial: time.Second, sleep: fs.sleep}
+	state, err := Poll(context.Background(), spec, func(ctx context.Context) (string, bool, error) {
+		return "PENDING", false, nil
+	})
+	assert.Equal(t, "PENDING", state)
+	if assert.True(t, errors.As(err, &pe)) {
+		assert.Equal(t, 3, pe.Attempts)
+		assert.Equal(t, "PENDING", pe.State)
+	}
+	assert.True(t, errors.Is(err, ErrPollTimeout))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestPollErrors(t *testing.T) {
+	fs := &fakeSleep{cancel: 2}
+	spec := PollSpec{Initial: time.Second, sleep: fs.sleep}
+
+	_, err := Poll(context.Background(), spec, func(ctx context.Context) (int, bool, error) {
+		return 0, false, nil
+	})
+	assert.Equal(t, context.Canceled, err)
+
+	boom := errors.New("boom")
+	calls := 0
+	_, err = Poll(context.Background(), PollSpec{sleep: (&fakeSleep{}).sleep}, func(ctx context.Context) (int, bool, error) {
+		calls++
+		if calls == 2 {
+			return 0, false, boom
+		}
+		return 0, false, nil
+	})
+	assert.Equal(t, boom, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestPollContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := Poll(ctx, pollEvery(time.Millisecond), func(ctx context.Context) (int, bool, error) {
+		return 0, false, nil
+	})
+	assert.True(t, errors.Is(err, ErrPollTimeout))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+// fakeClock is a clock that only moves when told to, or when a poll sleeps on it.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func (c *fakeClock) sleep(ctx context.Context, d time.Duration) error {
+	c.advance(d)
+	return ctx.Err()
+}
+
+func TestPollStats(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	clock := &fakeClock{t: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	client.pollSleep, client.pollNow = clock.sleep, clock.now
+
+	var hooked []*PollStats
+	client.WithPollHook(func(stats *PollStats) {
+		hooked = append(hooked, stats)
+	})
+
+	// The zone becomes active at the third request, each of which takes 200ms.
+	states := []string{"PENDING", "PENDING", "ACTIVE", "ERROR"}
+	requests := 0
+	mux.HandleFunc("/config-dns/v2/zones/example.com", func(w http.ResponseWriter, r *http.Request) {
+		clock.advance(200 * time.Millisecond)
+		fmt.Fprintf(w, `{"zone": "example.com", "activationState": %q}`, states[requests])
+		requests++
+	})
+
+	var stats PollStats
+	if _, err := client.FastDNSv2.WaitForZoneActive(WithPollStats(context.Background(), &stats), "example.com", 10*time.Second); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "WaitForZoneActive", stats.Op)
+	assert.Equal(t, 3, stats.Attempts)
+	assert.Equal(t, 20*time.Second, stats.Waited)
+	assert.Equal(t, 20*time.Second+600*time.Millisecond, stats.Elapsed)
+	assert.Equal(t, []string{"PENDING", "PENDING", "ACTIVE"}, stats.Statuses)
+	assert.Equal(t, "ACTIVE", stats.Final)
+	assert.NoError(t, stats.Err)
+	if assert.Len(t, hooked, 1) {
+		assert.Equal(t, &stats, hooked[0])
+	}
+
+	// The state of a failed zone is recorded along with the error.
+	_, err := client.FastDNSv2.WaitForZoneActive(WithPollStats(context.Background(), &stats), "example.com", 10*time.Second)
+	assert.True(t, errors.Is(err, ErrZoneActivationFailed), "got %v", err)
+	assert.Equal(t, 1, stats.Attempts)
+	assert.Equal(t, time.Duration(0), stats.Waited)
+	assert.Equal(t, []string{"ERROR"}, stats.Statuses)
+	assert.Equal(t, err, stats.Err)
+	assert.Len(t, hooked, 2)
+}
+
+func TestPollStatsTimeout(t *testing.T) {
+	clock := &fakeClock{}
+	spec := PollSpec{Initial: time.Second, Multiplier: 2, MaxAttempts: 3, sleep: clock.sleep, now: clock.now}
+
+	var stats PollStats
+	_, err := Poll(WithPollStats(context.Background(), &stats), spec, func(ctx context.Context) (string, bool, error) {
+		return "PENDING", false, nil
+	})
+	assert.True(t, errors.Is(err, ErrPollTimeout))
+	assert.Equal(t, "", stats.Op)
+	assert.Equal(t, 3, stats.Attempts)
+	assert.Equal(t, 3*time.Second, stats.Waited)
+	assert.Equal(t, 3*time.Second, stats.Elapsed)
+	assert.Empty(t, stats.Statuses, "Poll doesn't know the statuses of its states")
+	assert.Equal(t, err, stats.Err)
+}
@@ -0,0 +1,65 @@
+package akamai
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// ClientPool holds a Client for each profile of an .edgerc file, for programs that work
+// with several Akamai accounts at once. Clients are created the first time their profile
+// is asked for, and kept for the life of the pool.
+//
+// The clients share the transport of the pool's HTTP client, and so its connections,
+// but nothing else: each has its own credentials, read from its profile, and its own
+// caches and settings, such as its AccountSwitchKey.
+type ClientPool struct {
+	// Configure, if set, is called on each client created, such as to set up its
+	// ZoneLocks or protected zones. It must be set before the pool is used.
+	Configure func(profile string, c *Client)
+
+	httpClient *http.Client
+	filename   string
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// NewClientPool returns a pool of the clients of the profiles of filename, which is
+// found as by credentials.SharedCredentialsProvider if empty. The clients make their
+// requests with copies of httpClient that share its transport. If httpClient is nil,
+// they share a transport built by NewTransport.
+func NewClientPool(httpClient *http.Client, filename string) *ClientPool {
+	if httpClient == nil {
+		httpClient = &http.Client{Transport: NewTransport(nil)}
+	}
+	return &ClientPool{httpClient: httpClient, filename: filename, clients: map[string]*Client{}}
+}
+
+// ForProfile returns the client of a profile, creating it if needed. It fails if the
+// credentials of the profile can't be read; the creation is then tried again on the
+// next call.
+func (p *ClientPool) ForProfile(profile string) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.clients[profile]; ok {
+		return c, nil
+	}
+
+	hc := *p.httpClient
+	if hc.Transport == nil {
+		hc.Transport = http.DefaultTransport
+	}
+	c, err := NewClient(&hc, credentials.NewSharedCredentials(p.filename, profile))
+	if err != nil {
+		return nil, err
+	}
+	if p.Configure != nil {
+		p.Configure(profile, c)
+	}
+
+	p.clients[profile] = c
+	return c, nil
+}
@@ -0,0 +1,121 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingTransport counts the requests made through it.
+type countingTransport struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.n++
+	t.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClientPool(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.Header.Get("X-Profile")] = r.URL.Query().Get("accountSwitchKey")
+		mu.Unlock()
+		fmt.Fprint(w, `{"zones": []}`)
+	}))
+	defer server.Close()
+	base, _ := url.Parse(server.URL + "/")
+
+	transport := &countingTransport{}
+	pool := NewClientPool(&http.Client{Transport: transport}, "../testdata/edgerc")
+	pool.Configure = func(profile string, c *Client) {
+		c.BaseURL = base
+		c.UserAgent = profile
+	}
+
+	a, err := pool.ForProfile("tenant-a")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	b, err := pool.ForProfile("tenant-b")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	own, err := pool.ForProfile("own")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	again, err := pool.ForProfile("tenant-a")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, a == again, "clients are created once per profile")
+
+	// The clients share the transport, but not their credentials or settings.
+	assert.True(t, a.client != b.client)
+	assert.True(t, a.client.Transport == b.client.Transport)
+	assert.True(t, a.Credentials != b.Credentials)
+	assert.Equal(t, "1-AAAAA", a.AccountSwitchKey)
+	assert.Equal(t, "1-BBBBB", b.AccountSwitchKey)
+	assert.Equal(t, "", own.AccountSwitchKey)
+
+	ca, _ := a.Credentials.Get()
+	cb, _ := b.Credentials.Get()
+	assert.Equal(t, "tokenA", ca.ClientToken)
+	assert.Equal(t, "tokenB", cb.ClientToken)
+
+	for profile, c := range map[string]*Client{"tenant-a": a, "tenant-b": b, "own": own} {
+		req, err := c.NewRequest("GET", "config-dns/v2/zones", nil)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		req.Header.Set("X-Profile", profile)
+		if _, err := c.Do(context.Background(), req, nil); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	}
+	assert.Equal(t, map[string]string{"tenant-a": "1-AAAAA", "tenant-b": "1-BBBBB", "own": ""}, keys)
+	assert.Equal(t, 3, transport.n)
+
+	// Changing the settings of a client leaves the others alone.
+	a.AccountSwitchKey = "1-CCCCC"
+	assert.Equal(t, "1-BBBBB", b.AccountSwitchKey)
+
+	_, err = pool.ForProfile("missing")
+	assert.Error(t, err)
+}
+
+func TestNewRequestAccountSwitchKey(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+	client.AccountSwitchKey = "B-1"
+
+	// The query is kept as it is encoded, with the key appended.
+	req, err := client.NewRequest("GET", "config-dns/v2/zones?search=a%20b&z=1&a=2", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "search=a%20b&z=1&a=2&accountSwitchKey=B-1", req.URL.RawQuery)
+	if err := VerifyRequest(req, client.Credentials); err != nil {
+		t.Errorf("expect a valid signature, got %v", err)
+	}
+
+	// A key already in the query is replaced.
+	req, err = client.NewRequest("GET", "config-dns/v2/zones?accountSwitchKey=B-2&search=a%20b", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "search=a%20b&accountSwitchKey=B-1", req.URL.RawQuery)
+}
@@ -0,0 +1,77 @@
+package akamai
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ProblemError is an entry of the errors array of a problem document, such as the 400
+// responses of the FastDNS validations, which lists the fields of the request that are
+// wrong. The field is named by a JSON pointer into the request body, such as
+// "/recordsets/2/ttl", or by its name.
+type ProblemError struct {
+	Type    string `json:"type,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+	Pointer string `json:"pointer,omitempty"`
+	Field   string `json:"field,omitempty"`
+}
+
+// UnmarshalJSON decodes an entry of an errors array. Entries that are plain strings,
+// which some endpoints send, are taken as the detail.
+func (e *ProblemError) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		*e = ProblemError{}
+		return json.Unmarshal(b, &e.Detail)
+	}
+	type problemError ProblemError
+	return json.Unmarshal(b, (*problemError)(e))
+}
+
+// Location returns the pointer of the entry, or its field if it has no pointer.
+func (e *ProblemError) Location() string {
+	if e.Pointer != "" {
+		return e.Pointer
+	}
+	return e.Field
+}
+
+// Message returns the detail of the entry, or its title if it has no detail.
+func (e *ProblemError) Message() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return e.Title
+}
+
+// ProblemErrors is the errors array of a problem document.
+type ProblemErrors []*ProblemError
+
+// FieldErrors returns the messages of the errors by their location, such as
+// "/recordsets/0/ttl". The messages of several errors about the same location are
+// joined with "; ", and those of the errors without a location are under "".
+func (errs ProblemErrors) FieldErrors() map[string]string {
+	fields := make(map[string]string, len(errs))
+	for _, e := range errs {
+		loc := e.Location()
+		if msg, ok := fields[loc]; ok {
+			fields[loc] = msg + "; " + e.Message()
+			continue
+		}
+		fields[loc] = e.Message()
+	}
+	return fields
+}
+
+// String lists the errors on a single line.
+func (errs ProblemErrors) String() string {
+	parts := make([]string, len(errs))
+	for i, e := range errs {
+		if loc := e.Location(); loc != "" {
+			parts[i] = loc + ": " + e.Message()
+			continue
+		}
+		parts[i] = e.Message()
+	}
+	return strings.Join(parts, "; ")
+}
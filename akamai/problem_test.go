@@ -0,0 +1,102 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serveProblem answers the requests to path with the problem document of the fixture
+// file, as a 400.
+func serveProblem(t *testing.T, mux *http.ServeMux, path, fixture string) {
+	b, err := ioutil.ReadFile("../testdata/problems/" + fixture)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(b)
+	})
+}
+
+func TestAkamaiErrorFieldErrors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.DisableZoneTypeCheck = true
+	client.DisableRecordTypeCheck = true
+
+	serveProblem(t, mux, "/config-dns/v2/zones/example.com/names/www.example.com/types/MX", "recordset-invalid.json")
+
+	_, _, err := client.FastDNSv2.CreateRecordSet(context.Background(), &RecordSetCreateRequest{
+		Zone:  "example.com",
+		Name:  "www.example.com",
+		Type:  "MX",
+		TTL:   Int(5),
+		Rdata: []string{"10 mx.example.com.", "mail.example.com"},
+	})
+	var ae *AkamaiError
+	if !errors.As(err, &ae) {
+		t.Fatalf("expect an *AkamaiError, got %v", err)
+	}
+	assert.Equal(t, map[string]string{
+		"/ttl":     "TTL must be between 30 and 86400 seconds.",
+		"/rdata/1": "MX rdata must be a preference followed by a host name.; mail.example.com is not fully qualified.",
+	}, ae.FieldErrors())
+	if assert.Len(t, ae.Errors, 3) {
+		assert.Equal(t, "Invalid TTL", ae.Errors[0].Title)
+		assert.Equal(t, "https://problems.luna.akamaiapis.net/authoritative-dns/errors/invalid-ttl", ae.Errors[0].Type)
+	}
+	assert.True(t, strings.Contains(ae.Error(), "Errors: /ttl: TTL must be between 30 and 86400 seconds.; /rdata/1: "), ae.Error())
+
+	// The errors are kept in structured logs.
+	b, err := json.Marshal(ae)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	var decoded AkamaiError
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, ae.Errors, decoded.Errors)
+}
+
+func TestAkamaiErrorWithoutErrors(t *testing.T) {
+	ae := &AkamaiError{Status: http.StatusNotFound, Title: "Not Found", Detail: "Zone example.com was not found."}
+	assert.Equal(t, map[string]string{}, ae.FieldErrors())
+	assert.Equal(t, "HTTP Status: 404. Not Found: Zone example.com was not found..", ae.Error())
+}
+
+func TestValidateChangeListProblem(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	serveProblem(t, mux, "/config-dns/v2/changelists/example.com/submit", "changelist-invalid.json")
+
+	result, resp, err := client.FastDNSv2.ValidateChangeList(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.False(t, result.Valid())
+	assert.Equal(t, []*ValidationIssue{}, result.Warnings)
+
+	var got []string
+	for _, i := range result.Errors {
+		got = append(got, i.String())
+	}
+	assert.Equal(t, []string{
+		"ERROR api.example.com: api.example.com has a CNAME record set and other record sets.",
+		"ERROR /recordsets/4/ttl: TTL must be between 30 and 86400 seconds.",
+		"ERROR: The zone has no NS record set at its apex.",
+	}, got)
+
+	_, _, err = client.FastDNSv2.SubmitValidatedChangeList(context.Background(), "example.com")
+	assert.True(t, errors.Is(err, ErrChangeListInvalid), "got %v", err)
+}
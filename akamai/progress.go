@@ -0,0 +1,67 @@
+package akamai
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// maxDrainSize is how much of the rest of a response body is read and discarded when
+// copying it into an io.Writer fails, so that the connection can be reused. Larger
+// bodies are closed unread, which closes their connection.
+const maxDrainSize = 64 << 10
+
+// ProgressFunc is called as the body of a response is copied into an io.Writer given to
+// Client.Do, with the number of bytes written so far and the size of the body, or -1 if
+// the response didn't say.
+type ProgressFunc func(written, total int64)
+
+type progressKey struct{}
+
+// WithProgress returns a copy of ctx with which the requests whose response body is
+// copied into an io.Writer report their progress to fn, such as to show the download
+// of a large file in a UI. fn is called after each write, from the goroutine that
+// called Do.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// Progress returns the ProgressFunc of ctx, or nil if it was not set up WithProgress.
+func Progress(ctx context.Context) ProgressFunc {
+	if ctx == nil {
+		return nil
+	}
+	fn, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	return fn
+}
+
+type progressWriter struct {
+	w       io.Writer
+	fn      ProgressFunc
+	written int64
+	total   int64
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.written += int64(n)
+	pw.fn(pw.written, pw.total)
+	return n, err
+}
+
+// copyBody copies the body of resp into w, reporting its progress to the ProgressFunc
+// of ctx, and returns the number of bytes written. An error of w, or of the body such
+// as io.ErrUnexpectedEOF for a body cut short, is returned. When w fails, the rest of a
+// small body is drained.
+func copyBody(ctx context.Context, w io.Writer, resp *http.Response) (int64, error) {
+	if fn := Progress(ctx); fn != nil {
+		w = &progressWriter{w: w, fn: fn, total: resp.ContentLength}
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		io.CopyN(ioutil.Discard, resp.Body, maxDrainSize)
+	}
+	return n, err
+}
@@ -0,0 +1,122 @@
+package akamai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingWriter accepts n bytes, and fails the writes after them.
+type failingWriter struct {
+	n int
+}
+
+var errWriteFailed = errors.New("disk full")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errWriteFailed
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestDoWriterFails(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	body := strings.Repeat("x", 50000)
+	mux.HandleFunc("/zone-file", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/dns")
+		fmt.Fprint(w, body)
+	})
+
+	req, _ := client.NewRequest("GET", "zone-file", nil)
+	resp, err := client.Do(context.Background(), req, &failingWriter{n: 1000})
+	assert.True(t, err == errWriteFailed, "got %v", err)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, int64(1000), resp.BytesWritten)
+	}
+
+	// The rest of the body was drained, so the connection is reused.
+	var reused bool
+	req, _ = client.NewRequest("GET", "zone-file", nil)
+	ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) { reused = info.Reused },
+	})
+	var buf bytes.Buffer
+	resp, err = client.Do(ctx, req, &buf)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, int64(len(body)), resp.BytesWritten)
+	assert.Equal(t, body, buf.String())
+	assert.True(t, reused, "the connection was not reused")
+}
+
+func TestDoWriterTruncated(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// The server says the body has 1000 bytes, and closes the connection after 100.
+	mux.HandleFunc("/zone-file", func(w http.ResponseWriter, r *http.Request) {
+		conn, bw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("expect nil, got %v", err)
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(bw, "HTTP/1.1 200 OK\r\nContent-Type: text/dns\r\nContent-Length: 1000\r\n\r\n%s", strings.Repeat("x", 100))
+		bw.Flush()
+	})
+
+	req, _ := client.NewRequest("GET", "zone-file", nil)
+	var buf bytes.Buffer
+	resp, err := client.Do(context.Background(), req, &buf)
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF), "got %v", err)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, int64(100), resp.BytesWritten)
+	}
+	assert.Equal(t, 100, buf.Len())
+}
+
+func TestDoWriterProgress(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	body := strings.Repeat("x", 100000)
+	mux.HandleFunc("/bundle", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+		fmt.Fprint(w, body)
+	})
+
+	var calls [][2]int64
+	ctx := WithProgress(context.Background(), func(written, total int64) {
+		calls = append(calls, [2]int64{written, total})
+	})
+
+	req, _ := client.NewRequest("GET", "bundle", nil)
+	var buf bytes.Buffer
+	resp, err := client.Do(ctx, req, &buf)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, int64(len(body)), resp.BytesWritten)
+	if assert.True(t, len(calls) > 1, "got %v", calls) {
+		for i := 1; i < len(calls); i++ {
+			assert.True(t, calls[i][0] >= calls[i-1][0], "progress went back: %v", calls)
+		}
+		assert.Equal(t, [2]int64{int64(len(body)), int64(len(body))}, calls[len(calls)-1])
+	}
+}
@@ -0,0 +1,106 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrZoneProtected is matched by errors.Is for the changes a client refused to make to
+// a protected zone.
+var ErrZoneProtected = errors.New("zone is protected")
+
+// ZoneProtectedError is returned, before any request is made, by the FastDNSv2 methods
+// that change a zone or its records when the zone is protected. Pattern is the pattern
+// of WithProtectedZones that matched it.
+type ZoneProtectedError struct {
+	Zone    string
+	Pattern string
+}
+
+func (e *ZoneProtectedError) Error() string {
+	return fmt.Sprintf("%v: %v matches the protected zones pattern %q", ErrZoneProtected, e.Zone, e.Pattern)
+}
+
+// Is makes errors.Is(err, ErrZoneProtected) report true.
+func (e *ZoneProtectedError) Is(target error) bool {
+	return target == ErrZoneProtected
+}
+
+// WithProtectedZones protects zones from changes made with the client, whatever the
+// desired state given to it. DeleteZone, UpdateZone, SetZoneComment and the other
+// partial zone updates, ChangeZoneGroup, CreateRecordSet, UpdateRecordSet,
+// DeleteRecordSet, ReplaceRecordSets and SubmitChangeList fail with a
+// *ZoneProtectedError for protected zones, and the record set sync skips their changes.
+// Reads still work.
+//
+// A pattern is either a zone name, which protects that zone only, or a suffix wildcard
+// such as "*.example.com", which protects the zones under example.com but not
+// example.com itself. The pattern "*" protects every zone. Patterns and zones are
+// compared once normalized with NormalizeZoneName, so that an internationalized zone
+// matches whether it is written in Unicode or in its "xn--" form. WithProtectedZones
+// replaces the patterns set before, and must not be called while the client is in use;
+// it returns c so that calls can be chained.
+func (c *Client) WithProtectedZones(patterns []string) *Client {
+	c.protectedZones = nil
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		switch {
+		case p == "":
+			continue
+		case p == "*":
+		case strings.HasPrefix(p, "*."):
+			p = "*." + protectedZoneName(p[2:])
+		default:
+			p = protectedZoneName(p)
+		}
+		c.protectedZones = append(c.protectedZones, p)
+	}
+	return c
+}
+
+// protectedZoneName normalizes a zone name or pattern suffix with NormalizeZoneName.
+// Names it refuses are only lowercased, so that they still protect the zone of the same
+// name rather than being dropped.
+func protectedZoneName(name string) string {
+	if n, err := NormalizeZoneName(name); err == nil {
+		return n
+	}
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// ZoneProtected reports whether zone matches one of the patterns set with
+// WithProtectedZones.
+func (c *Client) ZoneProtected(zone string) bool {
+	return c.protectedZonePattern(zone) != ""
+}
+
+// protectedZonePattern returns the first pattern protecting zone, or "".
+func (c *Client) protectedZonePattern(zone string) string {
+	if len(c.protectedZones) == 0 {
+		return ""
+	}
+
+	zone = protectedZoneName(zone)
+	for _, p := range c.protectedZones {
+		switch {
+		case p == "*":
+			return p
+		case strings.HasPrefix(p, "*."):
+			if strings.HasSuffix(zone, p[1:]) {
+				return p
+			}
+		case zone == p:
+			return p
+		}
+	}
+	return ""
+}
+
+// checkZoneProtected returns a *ZoneProtectedError if zone is protected.
+func (s *FastDNSv2Service) checkZoneProtected(zone string) error {
+	if p := s.client.protectedZonePattern(zone); p != "" {
+		return &ZoneProtectedError{Zone: zone, Pattern: p}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestZoneProtected(t *testing.T) {
+	client, _ := akamaitest.NewServer(t)
+	client.WithProtectedZones([]string{"example.com", "*.corp.example.", " "})
+
+	tests := []struct {
+		zone      string
+		protected bool
+	}{
+		{"example.com", true},
+		{"Example.COM.", true},
+		{"notexample.com", false},
+		{"www.example.com", false},
+		{"example.com.au", false},
+		{"a.corp.example", true},
+		{"b.a.corp.example", true},
+		{"corp.example", false},
+		{"xcorp.example", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.protected, client.ZoneProtected(tt.zone), tt.zone)
+	}
+
+	// Internationalized zones match in either form, whichever form the pattern is in.
+	client.WithProtectedZones([]string{"Bücher.example", "*.xn--mnchen-3ya.de"})
+	for zone, protected := range map[string]bool{
+		"bücher.example":            true,
+		"xn--bcher-kva.example":     true,
+		"BU\u0308CHER.example.":     true,
+		"www.münchen.de":            true,
+		"www.xn--mnchen-3ya.de":     true,
+		"münchen.de":                false,
+		"www.xn--bcher-kva.example": false,
+	} {
+		assert.Equal(t, protected, client.ZoneProtected(zone), zone)
+	}
+
+	client.WithProtectedZones([]string{"*"})
+	assert.True(t, client.ZoneProtected("anything.example"))
+
+	client.WithProtectedZones(nil)
+	assert.False(t, client.ZoneProtected("example.com"))
+}
+
+func TestProtectedZoneWrites(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+	client.WithProtectedZones([]string{"example.com"})
+	ctx := context.Background()
+
+	rs := &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "new.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.9"}}
+	writes := map[string]func() error{
+		"CreateRecordSet": func() error {
+			_, _, err := client.FastDNSv2.CreateRecordSet(ctx, rs)
+			return err
+		},
+		"UpdateRecordSet": func() error {
+			_, _, err := client.FastDNSv2.UpdateRecordSet(ctx, rs)
+			return err
+		},
+		"DeleteRecordSet": func() error {
+			_, err := client.FastDNSv2.DeleteRecordSet(ctx, &akamai.RecordSetOptions{Zone: "example.com", Name: "www.example.com", Type: "A"})
+			return err
+		},
+		"ReplaceRecordSets": func() error {
+			_, err := client.FastDNSv2.ReplaceRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{rs})
+			return err
+		},
+		"UpdateZone": func() error {
+			_, _, err := client.FastDNSv2.UpdateZone(ctx, &akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY", Comment: "changed"})
+			return err
+		},
+		"DeleteZone": func() error {
+			_, _, err := client.FastDNSv2.DeleteZone(ctx, &akamai.ZoneDeleteRequest{Zones: []string{"other.com", "example.com"}}, nil)
+			return err
+		},
+		"SetZoneComment": func() error {
+			_, _, err := client.FastDNSv2.SetZoneComment(ctx, "example.com", "changed")
+			return err
+		},
+		"ChangeZoneGroup": func() error {
+			_, _, err := client.FastDNSv2.ChangeZoneGroup(ctx, "example.com", akamaitest.TestGroupID+1)
+			return err
+		},
+		"SubmitChangeList": func() error {
+			_, err := client.FastDNSv2.SubmitChangeList(ctx, "example.com")
+			return err
+		},
+	}
+	for op, write := range writes {
+		err := write()
+		assert.True(t, errors.Is(err, akamai.ErrZoneProtected), op)
+		var perr *akamai.ZoneProtectedError
+		if assert.True(t, errors.As(err, &perr), op) {
+			assert.Equal(t, "example.com", perr.Zone)
+			assert.Equal(t, "example.com", perr.Pattern)
+		}
+	}
+
+	// Reads still work, and nothing was sent.
+	_, _, err := client.FastDNSv2.GetZone(ctx, "example.com")
+	assert.NoError(t, err)
+	for _, r := range srv.Requests() {
+		assert.True(t, strings.HasPrefix(r, "GET "), r)
+	}
+	assert.Len(t, srv.RecordSets("example.com"), 5)
+}
+
+func TestSyncProtectedZone(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+	client.WithProtectedZones([]string{"example.com"})
+
+	plan, err := client.FastDNSv2.SyncRecordSets(context.Background(), "example.com", syncDesired, &akamai.SyncOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, plan.Changes, 3) {
+		for _, c := range plan.Changes {
+			assert.True(t, c.Skipped, c.Name)
+			assert.NoError(t, c.Err)
+		}
+	}
+	assert.True(t, plan.Empty())
+	for _, r := range srv.Requests() {
+		assert.True(t, strings.HasPrefix(r, "GET "), r)
+	}
+
+	// A plan made before the zone was protected is skipped too.
+	client.WithProtectedZones(nil)
+	plan, err = client.FastDNSv2.PlanRecordSets(context.Background(), "example.com", syncDesired, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.False(t, plan.Empty())
+	client.WithProtectedZones([]string{"*.com"})
+	assert.NoError(t, client.FastDNSv2.ApplySyncPlan(context.Background(), plan, nil))
+	for _, c := range plan.Changes {
+		assert.True(t, c.Skipped, c.Name)
+	}
+}
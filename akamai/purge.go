@@ -0,0 +1,111 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+)
+
+// PurgeService handles communication with the Fast Purge (CCU v3) related endpoints of
+// the Akamai API.
+type PurgeService service
+
+// Networks a purge is made on.
+const (
+	PurgeNetworkStaging    = "staging"
+	PurgeNetworkProduction = "production"
+)
+
+// Actions of a purge. Invalidating marks the objects stale, so that the edge servers
+// revalidate them with the origin; deleting removes them.
+const (
+	PurgeInvalidate = "invalidate"
+	PurgeDelete     = "delete"
+)
+
+// Kinds of objects a purge names.
+const (
+	PurgeURL    = "url"
+	PurgeCPCode = "cpcode"
+	PurgeTag    = "tag"
+)
+
+// PurgeRequest specifies the parameters for the Purge method.
+type PurgeRequest struct {
+	// Action is PurgeInvalidate or PurgeDelete, and defaults to PurgeInvalidate.
+	Action string `json:"-"`
+
+	// Type is the kind of the objects, PurgeURL, PurgeCPCode or PurgeTag, and defaults
+	// to PurgeURL.
+	Type string `json:"-"`
+
+	// Network is PurgeNetworkStaging or PurgeNetworkProduction, and defaults to
+	// PurgeNetworkProduction.
+	Network string `json:"-"`
+
+	// Objects are the URLs, CP codes or cache tags to purge.
+	Objects []string `json:"objects"`
+
+	// Hostname is the host of the URLs given as paths.
+	Hostname string `json:"hostname,omitempty"`
+}
+
+func (p *PurgeRequest) path() string {
+	action, typ, network := p.Action, p.Type, p.Network
+	if action == "" {
+		action = PurgeInvalidate
+	}
+	if typ == "" {
+		typ = PurgeURL
+	}
+	if network == "" {
+		network = PurgeNetworkProduction
+	}
+	return fmt.Sprintf("ccu/v3/%v/%v/%v", action, typ, network)
+}
+
+// PurgeResult is the response to a purge request.
+type PurgeResult struct {
+	HTTPStatus       *int    `json:"httpStatus,omitempty"`
+	Detail           *string `json:"detail,omitempty"`
+	EstimatedSeconds *int    `json:"estimatedSeconds,omitempty"`
+	PurgeID          *string `json:"purgeId,omitempty"`
+	SupportID        *string `json:"supportId,omitempty"`
+
+	// Duplicate reports that the purge was not sent, as it repeats one made earlier
+	// under the same idempotency key. The result is then that of the earlier purge. See
+	// WithIdempotencyKey.
+	Duplicate bool `json:"-"`
+}
+
+// Purge purges the objects of p from the edge servers.
+//
+// If ctx has an idempotency key and the client an IdempotencyStore, a purge repeating
+// one made under the same key, with the same objects, within the TTL of the store, is
+// not sent again; its result is that of the first purge, with Duplicate set, and a nil
+// *Response. See WithIdempotencyKey.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/fast_purge/v3.html
+func (s *PurgeService) Purge(ctx context.Context, p *PurgeRequest) (*PurgeResult, *Response, error) {
+	key := IdempotencyKey(ctx)
+	if key == "" || s.client.idempotencyStore == nil {
+		return s.purge(ctx, p)
+	}
+	return s.client.idempotent(ctx, key, p, func() (*PurgeResult, *Response, error) {
+		return s.purge(ctx, p)
+	})
+}
+
+func (s *PurgeService) purge(ctx context.Context, p *PurgeRequest) (*PurgeResult, *Response, error) {
+	req, err := s.client.NewRequest("POST", p.path(), p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(PurgeResult)
+	resp, err := s.client.Do(WithOperation(ctx, "Purge"), req, result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
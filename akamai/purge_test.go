@@ -0,0 +1,175 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// servePurges answers the purges with a purge ID numbered in the order they are
+// received, and returns the function counting them.
+func servePurges(t *testing.T, mux *http.ServeMux) func() int {
+	var mu sync.Mutex
+	n := 0
+	mux.HandleFunc("/ccu/v3/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var p PurgeRequest
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("expect nil, got %v", err)
+		}
+
+		mu.Lock()
+		n++
+		id := n
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"httpStatus": 201, "detail": "Request accepted", "estimatedSeconds": 5, "purgeId": "purge-%d", "supportId": "support-%d"}`, id, id)
+	})
+	return func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return n
+	}
+}
+
+func TestPurge(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/ccu/v3/delete/cpcode/staging", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var p map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&p)
+		assert.Equal(t, map[string]interface{}{"objects": []interface{}{"12345"}}, p)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"httpStatus": 201, "detail": "Request accepted", "estimatedSeconds": 5, "purgeId": "purge-1", "supportId": "support-1"}`)
+	})
+
+	result, resp, err := client.Purge.Purge(context.Background(), &PurgeRequest{
+		Action:  PurgeDelete,
+		Type:    PurgeCPCode,
+		Network: PurgeNetworkStaging,
+		Objects: []string{"12345"},
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "purge-1", result.GetPurgeID())
+	assert.Equal(t, 5, result.GetEstimatedSeconds())
+	assert.False(t, result.Duplicate)
+}
+
+func TestPurgeIdempotency(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		store func(t *testing.T, now func() time.Time) IdempotencyStore
+	}{
+		{"memory", func(t *testing.T, now func() time.Time) IdempotencyStore {
+			s := NewMemoryIdempotencyStore(time.Minute)
+			s.now = now
+			return s
+		}},
+		{"file", func(t *testing.T, now func() time.Time) IdempotencyStore {
+			s := NewFileIdempotencyStore(filepath.Join(t.TempDir(), "purges.json"), time.Minute)
+			s.now = now
+			return s
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			purges := servePurges(t, mux)
+
+			now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+			client.WithIdempotencyStore(tt.store(t, func() time.Time { return now }))
+
+			ctx := WithIdempotencyKey(context.Background(), "delivery-1")
+			purge := func(ctx context.Context, objects ...string) *PurgeResult {
+				t.Helper()
+				result, _, err := client.Purge.Purge(ctx, &PurgeRequest{Objects: objects})
+				if err != nil {
+					t.Fatalf("expect nil, got %v", err)
+				}
+				return result
+			}
+
+			first := purge(ctx, "https://www.example.com/a", "https://www.example.com/b")
+			assert.Equal(t, "purge-1", first.GetPurgeID())
+			assert.False(t, first.Duplicate)
+
+			// A retry of the same objects, in any order, is not sent.
+			dup := purge(ctx, "https://www.example.com/b", "https://www.example.com/a")
+			assert.Equal(t, "purge-1", dup.GetPurgeID())
+			assert.Equal(t, "support-1", dup.GetSupportID())
+			assert.True(t, dup.Duplicate)
+			assert.Equal(t, 1, purges())
+
+			// Other objects under the same key are purged.
+			other := purge(ctx, "https://www.example.com/c")
+			assert.Equal(t, "purge-2", other.GetPurgeID())
+			assert.False(t, other.Duplicate)
+
+			// Without a key, or under another key, the purge is sent.
+			purge(context.Background(), "https://www.example.com/a", "https://www.example.com/b")
+			purge(WithIdempotencyKey(context.Background(), "delivery-2"), "https://www.example.com/a", "https://www.example.com/b")
+			assert.Equal(t, 4, purges())
+
+			// Once the TTL is over, the purge is sent again.
+			now = now.Add(time.Minute)
+			again := purge(ctx, "https://www.example.com/a", "https://www.example.com/b")
+			assert.Equal(t, "purge-5", again.GetPurgeID())
+			assert.False(t, again.Duplicate)
+			assert.Equal(t, 5, purges())
+		})
+	}
+}
+
+func TestPurgeIdempotencyConcurrent(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	purges := servePurges(t, mux)
+	client.WithIdempotencyStore(NewMemoryIdempotencyStore(0))
+
+	ctx := WithIdempotencyKey(context.Background(), "delivery-1")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, _, err := client.Purge.Purge(ctx, &PurgeRequest{Objects: []string{"https://www.example.com/a"}})
+			if err != nil {
+				t.Errorf("expect nil, got %v", err)
+				return
+			}
+			assert.Equal(t, "purge-1", result.GetPurgeID())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, purges())
+}
+
+func TestFileIdempotencyStoreReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "purges.json")
+	if err := NewFileIdempotencyStore(path, 0).Put("delivery-1 abc", &IdempotencyRecord{PurgeID: "purge-1"}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	r, err := NewFileIdempotencyStore(path, 0).Get("delivery-1 abc")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.NotNil(t, r) {
+		assert.Equal(t, "purge-1", r.PurgeID)
+	}
+}
@@ -0,0 +1,158 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+// Drainer replays the operations of a queue against the API.
+type Drainer struct {
+	Client *akamai.Client
+	Queue  Queue
+
+	// Interval is the time between two passes over the queue while the API answers.
+	// Defaults to 10s.
+	Interval time.Duration
+
+	// MinBackoff and MaxBackoff bound the time Run waits after a pass in which an
+	// operation failed with a retryable error. The wait doubles after every such pass.
+	// They default to 1s and 5m.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// OnFailure is called for the operations that fail with an error that isn't
+	// retryable, such as a 400 or 409. They are removed from the queue, as replaying
+	// them again would fail the same way and hold back the operations of their key.
+	OnFailure func(op *Operation, err error)
+
+	// OnStats is called with the stats of the queue after every pass, to export them
+	// as metrics.
+	OnStats func(s Stats)
+
+	// maintenance counts the replays that failed with akamai.ErrServiceMaintenance.
+	maintenance int64
+}
+
+// Run replays the queue until ctx is done, and returns ctx.Err(), or until the client
+// is closed, and returns akamai.ErrClientClosed. Close waits for the pass in progress
+// to end.
+func (d *Drainer) Run(ctx context.Context) error {
+	parent := ctx
+	ctx, done := d.Client.StartBackground(ctx)
+	defer done()
+	err := d.run(ctx)
+	if parent.Err() == nil && d.Client.Closed() {
+		return akamai.ErrClientClosed
+	}
+	return err
+}
+
+func (d *Drainer) run(ctx context.Context) error {
+	interval := d.Interval
+	if interval == 0 {
+		interval = 10 * time.Second
+	}
+	minBackoff := d.MinBackoff
+	if minBackoff == 0 {
+		minBackoff = time.Second
+	}
+	maxBackoff := d.MaxBackoff
+	if maxBackoff == 0 {
+		maxBackoff = 5 * time.Minute
+	}
+
+	backoff := minBackoff
+	for {
+		wait := interval
+		if err := d.Drain(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// A maintenance window is waited out whole, rather than retried on the
+			// usual schedule, as every request fails until it ends.
+			if remaining, ok := akamai.MaintenanceRemaining(err, time.Now()); ok && remaining > 0 {
+				wait = remaining
+			} else {
+				wait = backoff
+				if backoff *= 2; backoff > maxBackoff {
+					backoff = maxBackoff
+				}
+			}
+		} else {
+			backoff = minBackoff
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Drain makes a single pass over the queue, replaying the pending operations in order.
+// An operation failing with a retryable error stays in the queue, along with the
+// operations of the same key queued after it, and Drain returns the first such error
+// once it has replayed the operations of the other keys. An operation failing because
+// the API is in a maintenance window ends the pass, as the others would fail the same
+// way.
+func (d *Drainer) Drain(ctx context.Context) error {
+	if d.OnStats != nil {
+		defer func() {
+			s := d.Queue.Stats()
+			s.Maintenance = int(atomic.LoadInt64(&d.maintenance))
+			d.OnStats(s)
+		}()
+	}
+
+	pending, err := d.Queue.Pending()
+	if err != nil {
+		return err
+	}
+
+	var first error
+	blocked := map[string]bool{}
+	for _, op := range pending {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if blocked[op.Key] {
+			continue
+		}
+
+		callCtx := ctx
+		if op.Name != "" {
+			callCtx = akamai.WithOperation(ctx, op.Name)
+		}
+		_, err := d.Client.Call(callCtx, op.Method, op.Path, op.body(), nil)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, akamai.ErrServiceMaintenance) {
+			atomic.AddInt64(&d.maintenance, 1)
+			return err
+		}
+		if err != nil && d.Client.ClassifyRetry(callCtx, err) == akamai.Retry {
+			blocked[op.Key] = true
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+		if err != nil && d.OnFailure != nil {
+			d.OnFailure(op, err)
+		}
+
+		if err := d.Queue.Ack(op.ID); err != nil {
+			return err
+		}
+	}
+
+	return first
+}
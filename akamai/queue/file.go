@@ -0,0 +1,250 @@
+package queue
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// compactThreshold is the number of acknowledged operations after which the journal
+// of a FileQueue is rewritten with only the pending ones.
+const compactThreshold = 1000
+
+// FileQueue is a Queue kept in an append-only journal file, so that the operations
+// survive restarts. Every change is synced to disk before it is reported done.
+type FileQueue struct {
+	path string
+	now  func() time.Time
+
+	mu     sync.Mutex
+	f      *os.File
+	ops    []*Operation
+	nextID uint64
+	acked  int
+}
+
+// journalEntry is a line of the journal: either an enqueued operation or the ID of an
+// acknowledged one.
+type journalEntry struct {
+	Op  *Operation `json:"op,omitempty"`
+	Ack uint64     `json:"ack,omitempty"`
+}
+
+var _ Queue = (*FileQueue)(nil)
+
+// OpenFile opens the queue journaled in the file at path, creating it if it doesn't
+// exist. The operations left in the journal by a previous run are pending again.
+func OpenFile(path string) (*FileQueue, error) {
+	q := &FileQueue{path: path, now: time.Now, nextID: 1}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := q.replay(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	// Start over from a journal holding only the pending operations, which also drops
+	// a line left incomplete by a crash.
+	f.Close()
+	if err := q.compact(); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// replay rebuilds the state of the queue from its journal.
+func (q *FileQueue) replay(f *os.File) error {
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, 64<<20)
+	for line := 1; s.Scan(); line++ {
+		var e journalEntry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			// Only the last line can be incomplete, if a write was interrupted.
+			if s.Scan() {
+				return fmt.Errorf("queue journal %v is corrupt at line %d: %v", q.path, line, err)
+			}
+			break
+		}
+
+		switch {
+		case e.Op != nil:
+			q.ops = append(q.ops, e.Op)
+			if e.Op.ID >= q.nextID {
+				q.nextID = e.Op.ID + 1
+			}
+		case e.Ack != 0:
+			q.remove(e.Ack)
+		}
+	}
+	return s.Err()
+}
+
+// Enqueue implements Queue.
+func (q *FileQueue) Enqueue(op *Operation) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.f == nil {
+		return false, os.ErrClosed
+	}
+
+	for i := len(q.ops) - 1; i >= 0; i-- {
+		if q.ops[i].Key == op.Key {
+			if q.ops[i].same(op) {
+				return false, nil
+			}
+			break
+		}
+	}
+
+	op.ID = q.nextID
+	if op.Enqueued.IsZero() {
+		op.Enqueued = q.now()
+	}
+	if err := q.append(journalEntry{Op: op}); err != nil {
+		return false, err
+	}
+
+	q.nextID++
+	c := *op
+	q.ops = append(q.ops, &c)
+	return true, nil
+}
+
+// Pending implements Queue. The operations returned are copies.
+func (q *FileQueue) Pending() ([]*Operation, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ops := make([]*Operation, len(q.ops))
+	for i, op := range q.ops {
+		c := *op
+		ops[i] = &c
+	}
+	return ops, nil
+}
+
+// Ack implements Queue.
+func (q *FileQueue) Ack(id uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.f == nil {
+		return os.ErrClosed
+	}
+	if !q.remove(id) {
+		return nil
+	}
+	if err := q.append(journalEntry{Ack: id}); err != nil {
+		return err
+	}
+
+	q.acked++
+	if q.acked >= compactThreshold {
+		return q.compact()
+	}
+	return nil
+}
+
+// Stats implements Queue.
+func (q *FileQueue) Stats() Stats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	s := Stats{Depth: len(q.ops)}
+	for _, op := range q.ops {
+		if age := q.now().Sub(op.Enqueued); age > s.OldestAge {
+			s.OldestAge = age
+		}
+	}
+	return s
+}
+
+// Close closes the journal. The queue can't be used afterwards.
+func (q *FileQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.f == nil {
+		return nil
+	}
+	err := q.f.Close()
+	q.f = nil
+	return err
+}
+
+func (q *FileQueue) remove(id uint64) bool {
+	for i, op := range q.ops {
+		if op.ID == id {
+			q.ops = append(q.ops[:i], q.ops[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// append writes an entry at the end of the journal and syncs it to disk.
+func (q *FileQueue) append(e journalEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := q.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	return q.f.Sync()
+}
+
+// compact replaces the journal with one holding only the pending operations. The new
+// journal is written aside and renamed over the old one, so that a crash leaves either.
+func (q *FileQueue) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(q.path), filepath.Base(q.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	for _, op := range q.ops {
+		b, err := json.Marshal(journalEntry{Op: op})
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		w.Write(append(b, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), q.path); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	// The renamed file is the journal from now on; it is open at its end.
+	if q.f != nil {
+		q.f.Close()
+	}
+	q.f = tmp
+	q.acked = 0
+	return nil
+}
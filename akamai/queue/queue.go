@@ -0,0 +1,157 @@
+// Package queue keeps mutating Akamai API requests that failed because the API was
+// unavailable, and replays them once it recovers. It is opt-in: requests made through
+// Call rather than the services of akamai.Client are queued when they fail with a
+// retryable error, and a Drainer replays the queue in the background.
+//
+// Requests are replayed in the order they were queued among those of the same key,
+// such as a zone or the resource of a purge, so that a later change never lands before
+// an earlier one.
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+// Operation is a mutating API request kept in a Queue.
+type Operation struct {
+	// ID is assigned by the queue, in the order operations are enqueued.
+	ID uint64 `json:"id"`
+
+	// Key orders the operations: those with the same key are replayed in order, and
+	// one that keeps failing holds back the ones queued after it.
+	Key string `json:"key"`
+
+	// Name is the operation the request is made for, as set with akamai.WithOperation,
+	// if any.
+	Name string `json:"name,omitempty"`
+
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+
+	Enqueued time.Time `json:"enqueued"`
+}
+
+// same reports whether o and other make the same request.
+func (o *Operation) same(other *Operation) bool {
+	return o.Key == other.Key && o.Method == other.Method && o.Path == other.Path &&
+		string(o.Body) == string(other.Body)
+}
+
+// Stats describes the operations waiting in a queue.
+type Stats struct {
+	// Depth is the number of operations in the queue.
+	Depth int
+
+	// OldestAge is how long the oldest operation has been waiting, or zero if the
+	// queue is empty.
+	OldestAge time.Duration
+
+	// Maintenance is the number of replays of a Drainer that failed because the API
+	// was in a maintenance window, counted apart from other failures so that alerts
+	// can ignore them. It is set by the Drainer, in the stats given to OnStats.
+	Maintenance int
+}
+
+// Queue stores operations until they are replayed. Implementations must be safe for
+// concurrent use.
+type Queue interface {
+	// Enqueue adds op to the queue, assigning its ID and, if unset, its Enqueued
+	// time. An operation making the same request as the last queued one of the same
+	// key is dropped as a duplicate, and Enqueue reports false.
+	Enqueue(op *Operation) (bool, error)
+
+	// Pending returns the operations in the queue, in the order they were enqueued.
+	Pending() ([]*Operation, error)
+
+	// Ack removes an operation from the queue once it has been replayed.
+	Ack(id uint64) error
+
+	// Stats returns the depth and age of the queue.
+	Stats() Stats
+}
+
+// ErrQueued is matched by errors.Is for the requests Call queued rather than made.
+var ErrQueued = errors.New("request queued for replay")
+
+// QueuedError is returned by Call for a request that was queued. Err is the error of
+// the attempt to make it, or nil if it was queued behind earlier operations of its key
+// without being attempted.
+type QueuedError struct {
+	Op  *Operation
+	Err error
+}
+
+func (e *QueuedError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%v: %v %v queued behind earlier operations on %v", ErrQueued, e.Op.Method, e.Op.Path, e.Op.Key)
+	}
+	return fmt.Sprintf("%v: %v %v: %v", ErrQueued, e.Op.Method, e.Op.Path, e.Err)
+}
+
+// Is makes errors.Is(err, ErrQueued) report true.
+func (e *QueuedError) Is(target error) bool {
+	return target == ErrQueued
+}
+
+func (e *QueuedError) Unwrap() error {
+	return e.Err
+}
+
+// Call makes a request with c.Call, as the services do, and queues it in q if it fails
+// with an error that c.ClassifyRetry says may be retried, such as rate limiting, a
+// server error, or a network error. The operation set on ctx with akamai.WithOperation
+// is kept with the request, so that the errors of its replays are classified the same
+// way. If operations of the same key are already queued, the request is queued behind them
+// without being made, so that it doesn't overtake them. A queued request returns a
+// *QueuedError.
+//
+// The body is encoded to JSON when the request is queued, so that it can be replayed
+// after a restart; v is only decoded into if the request is made right away.
+func Call(ctx context.Context, c *akamai.Client, q Queue, key, method, urlStr string, body, v interface{}) (*akamai.Response, error) {
+	op := &Operation{Key: key, Name: akamai.Operation(ctx), Method: method, Path: urlStr}
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		op.Body = b
+	}
+
+	pending, err := q.Pending()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pending {
+		if p.Key == key {
+			if _, err := q.Enqueue(op); err != nil {
+				return nil, err
+			}
+			return nil, &QueuedError{Op: op}
+		}
+	}
+
+	resp, err := c.Call(ctx, method, urlStr, op.body(), v)
+	if err == nil || c.ClassifyRetry(ctx, err) != akamai.Retry {
+		return resp, err
+	}
+
+	if _, qerr := q.Enqueue(op); qerr != nil {
+		return resp, qerr
+	}
+	return resp, &QueuedError{Op: op, Err: err}
+}
+
+// body returns the body to send for op, nil if it has none.
+func (o *Operation) body() interface{} {
+	if o.Body == nil {
+		return nil
+	}
+	return o.Body
+}
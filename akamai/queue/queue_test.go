@@ -0,0 +1,312 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+	"github.com/trussworks/akamai-sdk-go/akamai/queue"
+)
+
+// flappingServer answers 503 while it is down, and records the requests it serves
+// while it is up.
+type flappingServer struct {
+	mu       sync.Mutex
+	down     bool
+	requests []string
+}
+
+func (s *flappingServer) setDown(down bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.down = down
+}
+
+func (s *flappingServer) served() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *flappingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.down {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"title":"Service Unavailable","status":503}`))
+		return
+	}
+
+	b, _ := ioutil.ReadAll(r.Body)
+	s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI()+" "+string(b))
+	if r.URL.Path == "/config-dns/v2/zones/bad.example/recordsets" {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"title":"Bad Request","status":400}`))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setup(t *testing.T) (*akamai.Client, *flappingServer) {
+	fs := &flappingServer{}
+	server := httptest.NewServer(fs)
+	t.Cleanup(server.Close)
+
+	client, err := akamai.NewClient(nil, credentials.NewStaticCredentials("secret", "client", "access", server.Listener.Addr().String()))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return client, fs
+}
+
+func TestQueueOutage(t *testing.T) {
+	client, fs := setup(t)
+	path := filepath.Join(t.TempDir(), "queue.journal")
+	q, err := queue.OpenFile(path)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	ctx := context.Background()
+
+	call := func(key, method, urlStr string, body interface{}) error {
+		_, err := queue.Call(ctx, client, q, key, method, urlStr, body, nil)
+		return err
+	}
+
+	// Requests go through while the API is up.
+	assert.NoError(t, call("a.example", "PUT", "/config-dns/v2/zones/a.example/names/www.a.example/types/A", map[string]int{"ttl": 60}))
+	assert.Len(t, fs.served(), 1)
+
+	fs.setDown(true)
+	err = call("a.example", "PUT", "/config-dns/v2/zones/a.example/names/www.a.example/types/A", map[string]int{"ttl": 300})
+	assert.True(t, errors.Is(err, queue.ErrQueued))
+	var ae *akamai.AkamaiError
+	if assert.True(t, errors.As(err, &ae)) {
+		assert.Equal(t, http.StatusServiceUnavailable, ae.Status)
+	}
+
+	// Identical requests are queued once.
+	assert.True(t, errors.Is(call("b.example", "POST", "/ccu/v3/invalidate/url", map[string][]string{"objects": {"https://b.example/"}}), queue.ErrQueued))
+	assert.True(t, errors.Is(call("b.example", "POST", "/ccu/v3/invalidate/url", map[string][]string{"objects": {"https://b.example/"}}), queue.ErrQueued))
+	assert.True(t, errors.Is(call("bad.example", "PUT", "/config-dns/v2/zones/bad.example/recordsets", nil), queue.ErrQueued))
+
+	// The API is back, but the request waits behind the queued one of its key.
+	fs.setDown(false)
+	err = call("a.example", "DELETE", "/config-dns/v2/zones/a.example/names/www.a.example/types/A", nil)
+	var qe *queue.QueuedError
+	if assert.True(t, errors.As(err, &qe)) {
+		assert.NoError(t, qe.Err)
+		assert.Equal(t, "a.example", qe.Op.Key)
+	}
+	assert.Len(t, fs.served(), 1)
+
+	stats := q.Stats()
+	assert.Equal(t, 4, stats.Depth)
+	assert.True(t, stats.OldestAge > 0)
+
+	// The queue survives a restart.
+	assert.NoError(t, q.Close())
+	q, err = queue.OpenFile(path)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	defer q.Close()
+
+	var failed []*queue.Operation
+	var reported []queue.Stats
+	d := &queue.Drainer{
+		Client:    client,
+		Queue:     q,
+		OnFailure: func(op *queue.Operation, err error) { failed = append(failed, op) },
+		OnStats:   func(s queue.Stats) { reported = append(reported, s) },
+	}
+	if err := d.Drain(ctx); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Equal(t, []string{
+		`PUT /config-dns/v2/zones/a.example/names/www.a.example/types/A {"ttl":60}` + "\n",
+		`PUT /config-dns/v2/zones/a.example/names/www.a.example/types/A {"ttl":300}` + "\n",
+		`POST /ccu/v3/invalidate/url {"objects":["https://b.example/"]}` + "\n",
+		`PUT /config-dns/v2/zones/bad.example/recordsets `,
+		`DELETE /config-dns/v2/zones/a.example/names/www.a.example/types/A `,
+	}, fs.served())
+	if assert.Len(t, failed, 1) {
+		assert.Equal(t, "bad.example", failed[0].Key)
+	}
+	assert.Equal(t, []queue.Stats{{}}, reported)
+
+	pending, err := q.Pending()
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestDrainerBlocksKeyOnRetryableError(t *testing.T) {
+	client, fs := setup(t)
+	q, err := queue.OpenFile(filepath.Join(t.TempDir(), "queue.journal"))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	defer q.Close()
+
+	fs.setDown(true)
+	for _, p := range []string{"/one", "/two"} {
+		_, err := queue.Call(context.Background(), client, q, "a.example", "POST", p, nil, nil)
+		assert.True(t, errors.Is(err, queue.ErrQueued))
+	}
+
+	d := &queue.Drainer{Client: client, Queue: q}
+	err = d.Drain(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 2, q.Stats().Depth)
+
+	// Run keeps draining with backoff until the API recovers.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	d.MinBackoff = time.Millisecond
+	d.Interval = time.Millisecond
+	d.OnStats = func(s queue.Stats) {
+		if s.Depth == 0 {
+			cancel()
+		}
+	}
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		fs.setDown(false)
+	}()
+
+	assert.Equal(t, context.Canceled, d.Run(ctx))
+	assert.Equal(t, []string{"POST /one ", "POST /two "}, fs.served())
+}
+
+func TestCallRetryClassification(t *testing.T) {
+	client, fs := setup(t)
+	path := filepath.Join(t.TempDir(), "queue.journal")
+	q, err := queue.OpenFile(path)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	ctx := context.Background()
+	fs.setDown(true)
+
+	// A change list could be created twice, so it isn't queued.
+	_, err = queue.Call(akamai.WithOperation(ctx, "CreateChangeList"), client, q, "a.example", "POST", "/config-dns/v2/changelists?zone=a.example", nil, nil)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, queue.ErrQueued))
+	assert.Equal(t, 0, q.Stats().Depth)
+
+	_, err = queue.Call(akamai.WithOperation(ctx, "UpdateRecordSet"), client, q, "a.example", "PUT", "/config-dns/v2/zones/a.example/names/www.a.example/types/A", nil, nil)
+	assert.True(t, errors.Is(err, queue.ErrQueued))
+
+	// The operation is kept across a restart, and classifies the errors of the replays.
+	assert.NoError(t, q.Close())
+	q, err = queue.OpenFile(path)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	defer q.Close()
+
+	var ops []string
+	client.WithRetryClassifier(func(op string, req *http.Request, status int, err error) akamai.RetryDecision {
+		ops = append(ops, op)
+		return akamai.NoRetry
+	})
+	var failed []*queue.Operation
+	d := &queue.Drainer{Client: client, Queue: q, OnFailure: func(op *queue.Operation, err error) { failed = append(failed, op) }}
+	assert.NoError(t, d.Drain(ctx))
+	assert.Equal(t, []string{"UpdateRecordSet"}, ops)
+	if assert.Len(t, failed, 1) {
+		assert.Equal(t, "UpdateRecordSet", failed[0].Name)
+	}
+	assert.Equal(t, 0, q.Stats().Depth)
+}
+
+func TestOpenFileTruncatedJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.journal")
+	journal := `{"op":{"id":1,"key":"a","method":"POST","path":"/one","enqueued":"2020-01-01T00:00:00Z"}}
+{"op":{"id":2,"key":"a","method":"POST","path":"/two","enqueued":"2020-01-01T00:00:00Z"}}
+{"ack":1}
+{"op":{"id":3,"key":"a","meth`
+	if err := ioutil.WriteFile(path, []byte(journal), 0600); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	q, err := queue.OpenFile(path)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	defer q.Close()
+
+	pending, _ := q.Pending()
+	if assert.Len(t, pending, 1) {
+		assert.Equal(t, uint64(2), pending[0].ID)
+	}
+
+	op := &queue.Operation{Key: "a", Method: "POST", Path: "/three"}
+	ok, err := q.Enqueue(op)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), op.ID)
+
+	if err := ioutil.WriteFile(path, []byte("not json\n{}\n"), 0600); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	_, err = queue.OpenFile(path)
+	assert.Error(t, err)
+}
+
+func TestDrainerMaintenance(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+		b, _ := ioutil.ReadFile("../../testdata/problems/maintenance.json")
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write(b)
+	}))
+	defer server.Close()
+
+	client, err := akamai.NewClient(nil, credentials.NewStaticCredentials("secret", "client", "access", server.Listener.Addr().String()))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	q, err := queue.OpenFile(filepath.Join(t.TempDir(), "queue.journal"))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	q.Enqueue(&queue.Operation{Key: "a.example", Method: "DELETE", Path: "/config-dns/v2/zones/a.example/names/www.a.example/types/A"})
+	q.Enqueue(&queue.Operation{Key: "b.example", Method: "DELETE", Path: "/config-dns/v2/zones/b.example/names/www.b.example/types/A"})
+
+	var reported []queue.Stats
+	d := &queue.Drainer{Client: client, Queue: q, OnStats: func(s queue.Stats) { reported = append(reported, s) }}
+
+	// The pass ends at the first maintenance error, as the other operations would
+	// fail the same way, and the failures are counted apart.
+	for i := 1; i <= 2; i++ {
+		err = d.Drain(context.Background())
+		assert.True(t, errors.Is(err, akamai.ErrServiceMaintenance), "got %v", err)
+		assert.Equal(t, i, attempts)
+		assert.Equal(t, i, reported[len(reported)-1].Maintenance)
+		assert.Equal(t, 2, reported[len(reported)-1].Depth)
+	}
+}
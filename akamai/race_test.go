@@ -0,0 +1,8 @@
+//go:build race
+// +build race
+
+package akamai
+
+// raceEnabled is set when tests run with the race detector, which changes allocation
+// counts.
+const raceEnabled = true
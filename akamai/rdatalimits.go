@@ -0,0 +1,107 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Defaults of the zero fields of RdataLimits.
+const (
+	// DefaultMaxRdata is the number of records a record set may hold.
+	DefaultMaxRdata = 1000
+
+	// DefaultMaxRdataBytes is the size the records of a record set may add up to, that
+	// of the largest DNS message, which a record set must fit in to be served.
+	DefaultMaxRdataBytes = 65535
+)
+
+// RdataLimits caps the records of the record sets the FastDNSv2Service writes, so that
+// the record sets the API would reject are rejected before they are sent. See
+// FastDNSv2Service.WithRdataLimits.
+type RdataLimits struct {
+	// MaxRdata is the number of records a record set may hold. Defaults to
+	// DefaultMaxRdata; a negative value is not enforced.
+	MaxRdata int
+
+	// MaxRdataBytes is the size the records of a record set may add up to, counting the
+	// length of their rdata as given. Defaults to DefaultMaxRdataBytes; a negative value
+	// is not enforced.
+	MaxRdataBytes int
+}
+
+// ErrTooManyRdata is matched by errors.Is for the record sets over the RdataLimits of
+// the service.
+var ErrTooManyRdata = errors.New("record set has too many records")
+
+// TooManyRdataError is returned, before any request is made, for the writes of record
+// sets over the RdataLimits of the service. Rdata and Bytes are the number and size of
+// the records of the record set, and MaxRdata and MaxRdataBytes the limits; the ones
+// that are not enforced are negative.
+type TooManyRdataError struct {
+	Zone string
+	Name string
+	Type string
+
+	Rdata         int
+	MaxRdata      int
+	Bytes         int
+	MaxRdataBytes int
+}
+
+func (e *TooManyRdataError) Error() string {
+	if e.MaxRdata >= 0 && e.Rdata > e.MaxRdata {
+		return fmt.Sprintf("%v: %v %v has %d records, over the maximum of %d", ErrTooManyRdata, e.Name, e.Type, e.Rdata, e.MaxRdata)
+	}
+	return fmt.Sprintf("%v: the records of %v %v add up to %d bytes, over the maximum of %d", ErrTooManyRdata, e.Name, e.Type, e.Bytes, e.MaxRdataBytes)
+}
+
+// Is makes errors.Is(err, ErrTooManyRdata) report true.
+func (e *TooManyRdataError) Is(target error) bool {
+	return target == ErrTooManyRdata
+}
+
+// WithRdataLimits sets the limits of the records of the record sets written with s,
+// which CreateRecordSet, UpdateRecordSet and ReplaceRecordSets check before sending
+// anything, and PlanRecordSets for the record sets it would create or update. A record
+// set over them fails the call with a *TooManyRdataError rather than with the 400 Bad
+// Request of the API, after its upload. A nil limits restores the defaults. See
+// Client.FastDNSv2Service to configure the service of a client.
+//
+// WithRdataLimits must not be called while s is in use; it returns s so that calls can
+// be chained.
+func (s *FastDNSv2Service) WithRdataLimits(limits *RdataLimits) *FastDNSv2Service {
+	s.rdataLimits = limits
+	return s
+}
+
+// checkRdataLimits returns a *TooManyRdataError if rs is over the RdataLimits of s.
+func (s *FastDNSv2Service) checkRdataLimits(rs *RecordSetCreateRequest) error {
+	var limits RdataLimits
+	if s.rdataLimits != nil {
+		limits = *s.rdataLimits
+	}
+	if limits.MaxRdata == 0 {
+		limits.MaxRdata = DefaultMaxRdata
+	}
+	if limits.MaxRdataBytes == 0 {
+		limits.MaxRdataBytes = DefaultMaxRdataBytes
+	}
+
+	size := 0
+	for _, d := range rs.Rdata {
+		size += len(d)
+	}
+	if (limits.MaxRdata < 0 || len(rs.Rdata) <= limits.MaxRdata) && (limits.MaxRdataBytes < 0 || size <= limits.MaxRdataBytes) {
+		return nil
+	}
+	return &TooManyRdataError{
+		Zone:          rs.Zone,
+		Name:          rs.Name,
+		Type:          strings.ToUpper(rs.Type),
+		Rdata:         len(rs.Rdata),
+		MaxRdata:      limits.MaxRdata,
+		Bytes:         size,
+		MaxRdataBytes: limits.MaxRdataBytes,
+	}
+}
@@ -0,0 +1,128 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func rdataOf(n int) []string {
+	rdata := make([]string, n)
+	for i := range rdata {
+		rdata[i] = fmt.Sprintf("\"%03d\"", i)
+	}
+	return rdata
+}
+
+func TestRdataLimits(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	client.FastDNSv2Service().WithRdataLimits(&akamai.RdataLimits{MaxRdata: 3, MaxRdataBytes: 20})
+	ctx := context.Background()
+
+	tests := []struct {
+		rdata []string
+		ok    bool
+	}{
+		{rdataOf(3), true},
+		{rdataOf(4), false},
+		{[]string{strings.Repeat("a", 20)}, true},
+		{[]string{strings.Repeat("a", 10), strings.Repeat("a", 11)}, false},
+	}
+	for i, tt := range tests {
+		rs := &akamai.RecordSetCreateRequest{Zone: "example.com", Name: fmt.Sprintf("r%d.example.com", i), Type: "txt", TTL: akamai.Int(300), Rdata: tt.rdata}
+
+		writes := map[string]func() error{
+			"CreateRecordSet": func() error {
+				_, _, err := client.FastDNSv2.CreateRecordSet(ctx, rs)
+				return err
+			},
+			"UpdateRecordSet": func() error {
+				_, _, err := client.FastDNSv2.UpdateRecordSet(ctx, rs)
+				return err
+			},
+			"ReplaceRecordSets": func() error {
+				_, err := client.FastDNSv2.ReplaceRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{rs})
+				return err
+			},
+		}
+		for name, write := range writes {
+			err := write()
+			if tt.ok {
+				assert.False(t, errors.Is(err, akamai.ErrTooManyRdata), "%v %d: got %v", name, i, err)
+				continue
+			}
+			var tm *akamai.TooManyRdataError
+			if assert.True(t, errors.As(err, &tm), "%v %d: got %v", name, i, err) {
+				assert.Equal(t, rs.Name, tm.Name)
+				assert.Equal(t, "TXT", tm.Type)
+				assert.Equal(t, len(tt.rdata), tm.Rdata)
+				assert.Equal(t, 3, tm.MaxRdata)
+				assert.Equal(t, 20, tm.MaxRdataBytes)
+			}
+		}
+	}
+
+	// The record sets over the limits are never sent.
+	for _, r := range srv.Requests() {
+		assert.NotContains(t, r, "r1.example.com")
+		assert.NotContains(t, r, "r3.example.com")
+	}
+
+	// Negative limits are not enforced.
+	client.FastDNSv2Service().WithRdataLimits(&akamai.RdataLimits{MaxRdata: -1, MaxRdataBytes: -1})
+	_, _, err := client.FastDNSv2.CreateRecordSet(ctx, &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "big.example.com", Type: "TXT", TTL: akamai.Int(300), Rdata: rdataOf(akamai.DefaultMaxRdata + 1)})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// A nil limits restores the defaults.
+	client.FastDNSv2Service().WithRdataLimits(nil)
+	_, _, err = client.FastDNSv2.UpdateRecordSet(ctx, &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "big.example.com", Type: "TXT", TTL: akamai.Int(300), Rdata: rdataOf(akamai.DefaultMaxRdata + 1)})
+	assert.True(t, errors.Is(err, akamai.ErrTooManyRdata), "got %v", err)
+}
+
+func TestPlanRecordSetsRdataLimits(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+	client.FastDNSv2Service().WithRdataLimits(&akamai.RdataLimits{MaxRdata: 2})
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "pool.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}})
+	ctx := context.Background()
+
+	// A record set already over the limits is left alone while unchanged.
+	plan, err := client.FastDNSv2.PlanRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{
+		{Name: "pool.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 1, plan.Unchanged)
+
+	for _, desired := range []*akamai.RecordSetCreateRequest{
+		{Name: "pool.example.com", Type: "A", TTL: akamai.Int(600), Rdata: []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}},
+		{Name: "new.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}},
+	} {
+		_, err := client.FastDNSv2.PlanRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{desired}, nil)
+		var tm *akamai.TooManyRdataError
+		if assert.True(t, errors.As(err, &tm), "%v: got %v", desired.Name, err) {
+			assert.Equal(t, "example.com", tm.Zone)
+			assert.Equal(t, desired.Name, tm.Name)
+			assert.Equal(t, 3, tm.Rdata)
+			assert.Equal(t, 2, tm.MaxRdata)
+		}
+
+		_, err = client.FastDNSv2.SyncRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{desired}, nil)
+		assert.True(t, errors.Is(err, akamai.ErrTooManyRdata), "%v: got %v", desired.Name, err)
+	}
+	for _, rs := range srv.RecordSets("example.com") {
+		assert.NotEqual(t, "new.example.com", rs.GetName())
+		if rs.GetName() == "pool.example.com" {
+			assert.Equal(t, 300, rs.GetTTL())
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package akamai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// ErrReadOnlyMode is matched by errors.Is for the requests a read-only client refused
+// to send, and is returned on its own by helpers such as SyncRecordSets which stop
+// before making changes.
+var ErrReadOnlyMode = errors.New("client is in read-only mode")
+
+// ReadOnlyError is the error of a mutating request that a client in read-only mode did
+// not send. It describes the request that would have been sent.
+type ReadOnlyError struct {
+	Method string
+	URL    string
+
+	// Body is the JSON body of the request, with the values of secret fields such as
+	// TSIG key secrets masked. It is nil for requests without a body or whose body is
+	// not JSON, which is left out as it could hold anything.
+	Body json.RawMessage
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("%v: %v %v was not sent", ErrReadOnlyMode, e.Method, e.URL)
+}
+
+// Is makes errors.Is(err, ErrReadOnlyMode) report true.
+func (e *ReadOnlyError) Is(target error) bool {
+	return target == ErrReadOnlyMode
+}
+
+// WithReadOnly switches the client to read-only mode, in which it sends GET, HEAD and
+// OPTIONS requests but fails the others with a *ReadOnlyError before they leave the
+// process. This is for audits and game days.
+//
+// The allow list lets some mutating requests through. Its entries are a method and a
+// path pattern, as matched by path.Match, such as "POST /config-dns/v2/changelists"
+// to create change lists but not submit them. WithReadOnly must not be called while the
+// client is in use; it returns c so that calls can be chained.
+func (c *Client) WithReadOnly(readOnly bool, allow ...string) *Client {
+	c.readOnly = readOnly
+	c.readOnlyAllow = allow
+	return c
+}
+
+// ReadOnly reports whether the client is in read-only mode.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// checkReadOnly returns a *ReadOnlyError if req must not be sent by the client.
+func (c *Client) checkReadOnly(req *http.Request) error {
+	if !c.readOnly {
+		return nil
+	}
+
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS":
+		return nil
+	}
+
+	for _, a := range c.readOnlyAllow {
+		fields := strings.Fields(a)
+		if len(fields) != 2 || !strings.EqualFold(fields[0], req.Method) {
+			continue
+		}
+		if ok, _ := path.Match(fields[1], req.URL.Path); ok {
+			return nil
+		}
+	}
+
+	return &ReadOnlyError{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Body:   redactedBody(req),
+	}
+}
+
+// readOnlyRedactKeys are the keys masked in the body of a ReadOnlyError by RedactJSON.
+var readOnlyRedactKeys = []string{"*secret*", "*password*", "*tsigkey*"}
+
+// redactedBody returns the JSON body of req with the values of its secret fields
+// masked, or nil if it has none.
+func redactedBody(req *http.Request) json.RawMessage {
+	if req.GetBody == nil {
+		return nil
+	}
+	if mt, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); mt != "application/json" {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+	return RedactJSON(b, readOnlyRedactKeys)
+}
@@ -0,0 +1,84 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestReadOnly(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	client.WithReadOnly(true, "POST /config-dns/v2/changelists")
+	assert.True(t, client.ReadOnly())
+	ctx := context.Background()
+
+	if _, _, err := client.FastDNSv2.GetZone(ctx, "example.com"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	_, _, err := client.FastDNSv2.CreateZone(ctx, "1-ABCDE", &akamai.ZoneCreateRequest{
+		Zone:    "secondary.example",
+		Type:    "SECONDARY",
+		Masters: []string{"192.0.2.53"},
+		TSIGKey: "c2VjcmV0",
+	})
+	assert.True(t, errors.Is(err, akamai.ErrReadOnlyMode))
+	var roe *akamai.ReadOnlyError
+	if assert.True(t, errors.As(err, &roe), "expected a ReadOnlyError, got %v", err) {
+		assert.Equal(t, "POST", roe.Method)
+		assert.True(t, strings.HasSuffix(roe.URL, "/config-dns/v2/zones?contractId=1-ABCDE"), roe.URL)
+		assert.JSONEq(t, `{"zone":"secondary.example","type":"SECONDARY","masters":["192.0.2.53"],"tsigKey":"REDACTED"}`, string(roe.Body))
+	}
+	assert.Nil(t, srv.Zone("secondary.example"))
+
+	_, err = client.FastDNSv2.DeleteRecordSet(ctx, &akamai.RecordSetOptions{Zone: "example.com", Name: "example.com", Type: "NS"})
+	if assert.True(t, errors.As(err, &roe)) {
+		assert.Equal(t, "DELETE", roe.Method)
+		assert.Nil(t, roe.Body)
+	}
+
+	// The allow list lets change lists be created, but not submitted.
+	if _, _, err := client.FastDNSv2.CreateChangeList(ctx, &akamai.ChangeListOptions{Zone: "example.com"}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	_, err = client.FastDNSv2.SubmitChangeList(ctx, "example.com")
+	assert.True(t, errors.Is(err, akamai.ErrReadOnlyMode))
+
+	for _, r := range srv.Requests() {
+		assert.False(t, strings.HasPrefix(r, "DELETE") || strings.HasSuffix(r, "/submit") || r == "POST /config-dns/v2/zones", r)
+	}
+
+	client.WithReadOnly(false)
+	if _, err := client.FastDNSv2.SubmitChangeList(ctx, "example.com"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+}
+
+func TestReadOnlySyncRecordSets(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	client.WithReadOnly(true)
+	ctx := context.Background()
+
+	desired := []*akamai.RecordSetCreateRequest{
+		{Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+	}
+	plan, err := client.FastDNSv2.SyncRecordSets(ctx, "example.com", desired, nil)
+	assert.Equal(t, akamai.ErrReadOnlyMode, err)
+	if assert.NotNil(t, plan) && assert.Len(t, plan.Changes, 1) {
+		assert.Equal(t, akamai.SyncCreate, plan.Changes[0].Action)
+		assert.NoError(t, plan.Changes[0].Err)
+	}
+	assert.Len(t, srv.RecordSets("example.com"), 2)
+
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	plan, err = client.FastDNSv2.SyncRecordSets(ctx, "example.com", desired, nil)
+	assert.NoError(t, err)
+	assert.True(t, plan.Empty())
+}
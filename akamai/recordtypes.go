@@ -0,0 +1,127 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// recordTypesTTL is how long the client remembers the record types a zone supports for
+// its checks of record set writes.
+const recordTypesTTL = 10 * time.Minute
+
+// builtinRecordTypes, sorted, are the record types the client checks writes against when
+// the API can't list those of a zone.
+var builtinRecordTypes = []string{
+	RRTypeA, RRTypeAaaa, RRTypeAfsdb, RRTypeAkamaiCdn, RRTypeAkamaiTlc, RRTypeCaa,
+	RRTypeCname, RRTypeHinfo, RRTypeHttps, RRTypeLoc, RRTypeMx, RRTypeNaptr, RRTypeNs,
+	RRTypePtr, RRTypeRp, RRTypeSoa, RRTypeSpf, RRTypeSrv, RRTypeSshfp, RRTypeSvcb,
+	RRTypeTlsa, RRTypeTxt,
+}
+
+// ErrUnsupportedRecordType is matched by errors.Is for the errors of record set writes
+// of types the zone doesn't support.
+var ErrUnsupportedRecordType = errors.New("unsupported record type")
+
+// UnsupportedRecordTypeError is returned, before any request is made, by
+// CreateRecordSet and UpdateRecordSet for record sets of a type SupportedRecordTypes
+// doesn't list for their name. See Client.DisableRecordTypeCheck.
+type UnsupportedRecordTypeError struct {
+	Zone string
+	Name string
+	Type string
+}
+
+func (e *UnsupportedRecordTypeError) Error() string {
+	return fmt.Sprintf("%v: %v records can't be created at %v in zone %v", ErrUnsupportedRecordType, e.Type, e.Name, e.Zone)
+}
+
+// Is makes errors.Is(err, ErrUnsupportedRecordType) report true.
+func (e *UnsupportedRecordTypeError) Is(target error) bool {
+	return target == ErrUnsupportedRecordType
+}
+
+// SupportedRecordTypes lists the record types that can be created at a name of a zone,
+// sorted, such as to populate the type pickers of a UI. The types the zone supports are
+// listed with GetRecordTypes, so that types Akamai adds are supported without a release
+// of the SDK, and cached by the client for 10 minutes. If the API can't list them, the
+// types the SDK knows of are used instead.
+//
+// The types are then narrowed to the name: SOA records only exist at the apex of the
+// zone, and CNAME records can't be created there.
+func (s *FastDNSv2Service) SupportedRecordTypes(ctx context.Context, zone, name string) ([]string, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, wrapOp("SupportedRecordTypes", zone, name, "", err)
+	}
+	if name, err = s.recordName(name); err != nil {
+		return nil, wrapOp("SupportedRecordTypes", zone, name, "", err)
+	}
+
+	apex := isApex(zone, name)
+	var types []string
+	for _, t := range s.zoneRecordTypes(ctx, zone) {
+		if recordTypeAllowed(t, apex) {
+			types = append(types, t)
+		}
+	}
+
+	return types, nil
+}
+
+// zoneRecordTypes returns the record types a zone supports, in upper case and sorted.
+// They are looked up with GetRecordTypes and cached for recordTypesTTL; if the lookup
+// fails, the builtinRecordTypes are returned, and the lookup is made again next time.
+func (s *FastDNSv2Service) zoneRecordTypes(ctx context.Context, zone string) []string {
+	if s.client.recordTypes == nil {
+		return builtinRecordTypes
+	}
+
+	v, _, err := s.client.recordTypes.get(ctx, zone, func() (interface{}, *Response, error) {
+		types, resp, err := s.GetRecordTypes(ctx, zone)
+		if err != nil {
+			return nil, resp, err
+		}
+		upper := make([]string, len(types))
+		for i, t := range types {
+			upper[i] = strings.ToUpper(t)
+		}
+		sort.Strings(upper)
+		return upper, resp, nil
+	})
+	types, _ := v.([]string)
+	if err != nil || len(types) == 0 {
+		return builtinRecordTypes
+	}
+	return types
+}
+
+// checkRecordType returns an *UnsupportedRecordTypeError if SupportedRecordTypes
+// doesn't list rtype for the name. The zone and name are the normalized ones of a
+// request.
+func (s *FastDNSv2Service) checkRecordType(ctx context.Context, zone, name, rtype string) error {
+	if s.client.DisableRecordTypeCheck {
+		return nil
+	}
+
+	rtype = strings.ToUpper(rtype)
+	types := s.zoneRecordTypes(ctx, zone)
+	if i := sort.SearchStrings(types, rtype); i < len(types) && types[i] == rtype && recordTypeAllowed(rtype, isApex(zone, name)) {
+		return nil
+	}
+	return &UnsupportedRecordTypeError{Zone: zone, Name: name, Type: rtype}
+}
+
+// recordTypeAllowed reports whether records of a type the zone supports can be created
+// at a name: SOA records only exist at the apex, and CNAME records can't be created
+// there.
+func recordTypeAllowed(rtype string, apex bool) bool {
+	return !(rtype == RRTypeSoa && !apex) && !(rtype == RRTypeCname && apex)
+}
+
+func isApex(zone, name string) bool {
+	return strings.EqualFold(strings.TrimSuffix(name, "."), strings.TrimSuffix(zone, "."))
+}
@@ -0,0 +1,96 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupportedRecordTypes(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.DisableZoneTypeCheck = true
+
+	var typesRequests int
+	mux.HandleFunc("/config-dns/v2/data/recordsets/types", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "example.com", r.URL.Query().Get("zone"))
+		typesRequests++
+		fmt.Fprint(w, `{"types": ["A", "CNAME", "SOA", "svcb", "NEWTYPE"]}`)
+	})
+	var creates int
+	mux.HandleFunc("/config-dns/v2/zones/example.com/names/", func(w http.ResponseWriter, r *http.Request) {
+		creates++
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	})
+	ctx := context.Background()
+
+	types, err := client.FastDNSv2.SupportedRecordTypes(ctx, "example.com", "www.example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"A", "CNAME", "NEWTYPE", "SVCB"}, types)
+
+	types, err = client.FastDNSv2.SupportedRecordTypes(ctx, "Example.com.", "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"A", "NEWTYPE", "SOA", "SVCB"}, types)
+	assert.Equal(t, 1, typesRequests, "the types are cached")
+
+	// A type the SDK doesn't know of passes as the API lists it.
+	_, _, err = client.FastDNSv2.CreateRecordSet(ctx, &RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "NEWTYPE", TTL: Int(300), Rdata: []string{"data"}})
+	assert.NoError(t, err)
+
+	for _, rs := range []*RecordSetCreateRequest{
+		{Zone: "example.com", Name: "www.example.com", Type: "TXT", TTL: Int(300), Rdata: []string{`"v"`}},
+		{Zone: "example.com", Name: "example.com", Type: "CNAME", TTL: Int(300), Rdata: []string{"www.example.com."}},
+	} {
+		_, _, err = client.FastDNSv2.UpdateRecordSet(ctx, rs)
+		assert.True(t, errors.Is(err, ErrUnsupportedRecordType), rs.Type)
+	}
+	assert.Equal(t, 1, creates)
+	assert.Equal(t, 1, typesRequests)
+
+	client.DisableRecordTypeCheck = true
+	_, _, err = client.FastDNSv2.CreateRecordSet(ctx, &RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "TXT", TTL: Int(300), Rdata: []string{`"v"`}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, creates)
+}
+
+func TestSupportedRecordTypesFallback(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.DisableZoneTypeCheck = true
+
+	var typesRequests int
+	mux.HandleFunc("/config-dns/v2/data/recordsets/types", func(w http.ResponseWriter, r *http.Request) {
+		typesRequests++
+		http.Error(w, `{"title": "Internal Server Error"}`, http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/config-dns/v2/zones/example.com/names/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	})
+	ctx := context.Background()
+
+	types, err := client.FastDNSv2.SupportedRecordTypes(ctx, "example.com", "www.example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, types, RRTypeHttps)
+	assert.NotContains(t, types, RRTypeSoa)
+	assert.Len(t, types, len(builtinRecordTypes)-1)
+
+	// The built-in types are checked against, and the failure is not cached.
+	_, _, err = client.FastDNSv2.CreateRecordSet(ctx, &RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "TXT", TTL: Int(300), Rdata: []string{`"v"`}})
+	assert.NoError(t, err)
+	_, _, err = client.FastDNSv2.CreateRecordSet(ctx, &RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "NEWTYPE", TTL: Int(300), Rdata: []string{"data"}})
+	assert.True(t, errors.Is(err, ErrUnsupportedRecordType))
+	assert.Equal(t, 3, typesRequests)
+}
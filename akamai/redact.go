@@ -0,0 +1,213 @@
+package akamai
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path"
+	"strings"
+)
+
+// Redact returns a copy of the key whose secret is masked, safe for logging or
+// persisting.
+func (k *TSIGKey) Redact() *TSIGKey {
+	if k == nil {
+		return nil
+	}
+
+	c := *k
+	if c.Secret != nil {
+		c.Secret = String(redacted)
+	}
+	return &c
+}
+
+func (k TSIGKey) String() string {
+	return Stringify(k.Redact())
+}
+
+// GoString masks the secret when the key is printed with %#v.
+func (k TSIGKey) GoString() string {
+	return k.String()
+}
+
+// Redact returns a copy of the zone whose TSIG key secret is masked, safe for logging or
+// persisting.
+func (z *Zone) Redact() *Zone {
+	if z == nil {
+		return nil
+	}
+
+	c := *z
+	c.TSIGKey = z.TSIGKey.Redact()
+	return &c
+}
+
+func (z Zone) String() string {
+	return Stringify(z.Redact())
+}
+
+// GoString masks the TSIG key secret when the zone is printed with %#v.
+func (z Zone) GoString() string {
+	return z.String()
+}
+
+// Redact returns a copy of the zone metadata whose TSIG key secret is masked, safe for
+// logging or persisting.
+func (z *ZoneMetadata) Redact() *ZoneMetadata {
+	if z == nil {
+		return nil
+	}
+
+	c := *z
+	c.TSIGKey = z.TSIGKey.Redact()
+	return &c
+}
+
+func (z ZoneMetadata) String() string {
+	return Stringify(z.Redact())
+}
+
+// GoString masks the TSIG key secret when the zone metadata is printed with %#v.
+func (z ZoneMetadata) GoString() string {
+	return z.String()
+}
+
+// Redact returns a copy of the request whose TSIG key is masked, safe for logging or
+// persisting.
+func (r *ZoneCreateRequest) Redact() *ZoneCreateRequest {
+	if r == nil {
+		return nil
+	}
+
+	c := *r
+	if c.TSIGKey != "" {
+		c.TSIGKey = redacted
+	}
+	return &c
+}
+
+func (r ZoneCreateRequest) String() string {
+	return Stringify(r.Redact())
+}
+
+// GoString masks the TSIG key when the request is printed with %#v.
+func (r ZoneCreateRequest) GoString() string {
+	return r.String()
+}
+
+// DefaultRedactKeys are the keys RedactJSON is given by the wire log of a client by
+// default: the TSIG keys of zone create requests and the secret of TSIG key objects,
+// and any key naming a secret, token or password.
+var DefaultRedactKeys = []string{"tsigKey", "tsigKey.secret", "*secret*", "*token*", "*password*"}
+
+// RedactJSON returns the JSON document b, compacted, with the values of the fields
+// matching keys masked, or nil if b is not JSON. It is safe for logging or persisting
+// request and response bodies.
+//
+// A key is a pattern, as matched by path.Match and regardless of case, against the
+// name of a field, such as "*secret*", or a dotted path of patterns matched against
+// the names of a field and of the objects it is nested in, such as "tsigKey.secret".
+// The strings, numbers and booleans of a matching field are masked, as are those of an
+// array it holds; an object it holds is walked rather than masked, so that a field
+// "tsigKey" masks the key of a zone create request but not the name of a TSIG key
+// object. The order of the fields and the precision of the numbers are kept.
+func RedactJSON(b []byte, keys []string) []byte {
+	patterns := make([][]string, len(keys))
+	for i, k := range keys {
+		patterns[i] = strings.Split(strings.ToLower(k), ".")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var out bytes.Buffer
+	if err := redactValue(dec, &out, nil, patterns, false); err != nil {
+		return nil
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil
+	}
+	return out.Bytes()
+}
+
+// redactValue copies the next JSON value of dec to out, masking it if mask is set and it
+// is not an object. fields holds the names of the fields the value is nested in.
+func redactValue(dec *json.Decoder, out *bytes.Buffer, fields []string, patterns [][]string, mask bool) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		out.WriteByte('{')
+		for i := 0; dec.More(); i++ {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			name, _ := tok.(string)
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			writeJSON(out, name)
+			out.WriteByte(':')
+			nested := append(fields[:len(fields):len(fields)], strings.ToLower(name))
+			if err := redactValue(dec, out, nested, patterns, matchRedactKey(nested, patterns)); err != nil {
+				return err
+			}
+		}
+		out.WriteByte('}')
+		_, err = dec.Token()
+		return err
+	case json.Delim('['):
+		out.WriteByte('[')
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			if err := redactValue(dec, out, fields, patterns, mask); err != nil {
+				return err
+			}
+		}
+		out.WriteByte(']')
+		_, err = dec.Token()
+		return err
+	case nil:
+		out.WriteString("null")
+	default:
+		if mask {
+			tok = redacted
+		}
+		writeJSON(out, tok)
+	}
+	return nil
+}
+
+// writeJSON writes the JSON encoding of a string, json.Number or bool.
+func writeJSON(out *bytes.Buffer, v interface{}) {
+	b, _ := json.Marshal(v)
+	out.Write(b)
+}
+
+// matchRedactKey reports whether the field at the end of fields matches one of
+// patterns, the keys of RedactJSON split at their dots.
+func matchRedactKey(fields []string, patterns [][]string) bool {
+	for _, p := range patterns {
+		if len(p) > len(fields) {
+			continue
+		}
+		tail := fields[len(fields)-len(p):]
+		match := true
+		for i := range p {
+			if ok, _ := path.Match(p[i], tail[i]); !ok {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package akamai
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tsigSecret = "c2VjcmV0LXRzaWcta2V5LW1hdGVyaWFs"
+
+func TestZoneRedaction(t *testing.T) {
+	z := &Zone{
+		Zone: String("example.com"),
+		Type: String("SECONDARY"),
+		TSIGKey: &TSIGKey{
+			Name:      String("transfer"),
+			Algorithm: String("hmac-sha256"),
+			Secret:    String(tsigSecret),
+		},
+		Masters: []*string{String("192.0.2.1")},
+	}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		for _, v := range []interface{}{z, *z, z.TSIGKey, *z.TSIGKey} {
+			out := fmt.Sprintf(format, v)
+			assert.NotContains(t, out, tsigSecret, format)
+			assert.Contains(t, out, redacted, format)
+		}
+	}
+
+	assert.Equal(t, `akamai.Zone{Zone:"example.com", Type:"SECONDARY", TSIGKey:akamai.TSIGKey{Name:"transfer", Algorithm:"hmac-sha256", Secret:"REDACTED"}, Masters:["192.0.2.1"]}`, z.String())
+
+	b, err := json.Marshal(z.Redact())
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.NotContains(t, string(b), tsigSecret)
+
+	// The original is left untouched.
+	assert.Equal(t, tsigSecret, z.TSIGKey.GetSecret())
+
+	var nilZone *Zone
+	assert.Nil(t, nilZone.Redact())
+	assert.Nil(t, (&Zone{}).Redact().TSIGKey)
+}
+
+func TestZoneCreateRequestRedaction(t *testing.T) {
+	zr := &ZoneCreateRequest{Zone: "example.com", Type: "SECONDARY", TSIGKey: tsigSecret}
+
+	for _, format := range []string{"%v", "%+v", "%#v"} {
+		for _, v := range []interface{}{zr, *zr} {
+			assert.NotContains(t, fmt.Sprintf(format, v), tsigSecret, format)
+		}
+	}
+
+	b, err := json.Marshal(zr.Redact())
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.NotContains(t, string(b), tsigSecret)
+	assert.Equal(t, tsigSecret, zr.TSIGKey)
+
+	assert.Equal(t, "", (&ZoneCreateRequest{}).Redact().TSIGKey)
+}
+
+func TestStringify(t *testing.T) {
+	assert.Equal(t, `akamai.RecordSet{Name:"www.example.com", Rdata:["192.0.2.1" "192.0.2.2"], TTL:300}`, Stringify(&RecordSet{
+		Name:  String("www.example.com"),
+		Rdata: []*string{String("192.0.2.1"), String("192.0.2.2")},
+		TTL:   Int(300),
+	}))
+	assert.Equal(t, "<nil>", Stringify((*RecordSet)(nil)))
+	assert.Equal(t, "<nil>", Stringify(nil))
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		keys []string
+		want string
+	}{
+		{
+			name: "nested secret keys",
+			in:   `{"zone": "example.com", "tsigKey": {"name": "transfer", "algorithm": "hmac-sha256", "secret": "` + tsigSecret + `"}, "ttl": 300.50}`,
+			keys: DefaultRedactKeys,
+			want: `{"zone":"example.com","tsigKey":{"name":"transfer","algorithm":"hmac-sha256","secret":"REDACTED"},"ttl":300.50}`,
+		},
+		{
+			name: "dotted path",
+			in:   `{"tsigKey": {"secret": "a"}, "secret": "b", "other": {"secret": "c"}}`,
+			keys: []string{"tsigKey.secret"},
+			want: `{"tsigKey":{"secret":"REDACTED"},"secret":"b","other":{"secret":"c"}}`,
+		},
+		{
+			name: "case and glob",
+			in:   `{"accessToken": "a", "ClientSecret": 42, "refresh_TOKEN": true, "tokenless": null, "name": "n"}`,
+			keys: []string{"*token*", "*secret*"},
+			want: `{"accessToken":"REDACTED","ClientSecret":"REDACTED","refresh_TOKEN":"REDACTED","tokenless":null,"name":"n"}`,
+		},
+		{
+			name: "arrays",
+			in:   `[{"zone": "a.example", "tsigKey": "k1"}, {"zone": "b.example", "apiTokens": ["t1", "t2", {"id": "t3"}]}]`,
+			keys: DefaultRedactKeys,
+			want: `[{"zone":"a.example","tsigKey":"REDACTED"},{"zone":"b.example","apiTokens":["REDACTED","REDACTED",{"id":"t3"}]}]`,
+		},
+		{
+			name: "scalar document",
+			in:   ` "secret" `,
+			keys: DefaultRedactKeys,
+			want: `"secret"`,
+		},
+		{name: "not JSON", in: `secret=abc`, keys: DefaultRedactKeys},
+		{name: "truncated", in: `{"secret": "abc"`, keys: DefaultRedactKeys},
+		{name: "trailing data", in: `{"secret": "abc"} {}`, keys: DefaultRedactKeys},
+		{name: "empty", in: ``, keys: DefaultRedactKeys},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RedactJSON([]byte(tt.in), tt.keys)
+			if tt.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
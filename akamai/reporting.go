@@ -0,0 +1,241 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportingService handles communication with the Reporting API (v1) related
+// endpoints of the Akamai API.
+type ReportingService service
+
+// Intervals reports can aggregate their data by.
+const (
+	ReportIntervalFiveMinutes = "FIVE_MINUTES"
+	ReportIntervalHour        = "HOUR"
+	ReportIntervalDay         = "DAY"
+	ReportIntervalWeek        = "WEEK"
+	ReportIntervalMonth       = "MONTH"
+)
+
+// reportTimeLayout is the ISO 8601 format the Reporting API expects start and end
+// times in. Times are always sent in UTC.
+const reportTimeLayout = "2006-01-02T15:04:05Z"
+
+// ReportVersion describes a version of a report type, including which metrics,
+// filters, and intervals it supports.
+type ReportVersion struct {
+	Name               *string         `json:"name,omitempty"`
+	Version            *FlexibleInt    `json:"version,omitempty"`
+	Status             *string         `json:"status,omitempty"`
+	Description        *string         `json:"description,omitempty"`
+	BusinessObjectName *string         `json:"businessObjectName,omitempty"`
+	DataRetentionDays  *int            `json:"dataRetentionDays,omitempty"`
+	TimeIntervals      []*string       `json:"timeIntervals,omitempty"`
+	Metrics            []*ReportMetric `json:"metrics,omitempty"`
+	Filters            []*ReportFilter `json:"filters,omitempty"`
+}
+
+// ReportMetric describes a metric a report can return.
+type ReportMetric struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Unit        *string `json:"unit,omitempty"`
+}
+
+// ReportFilter describes a filter a report accepts.
+type ReportFilter struct {
+	Name        *string   `json:"name,omitempty"`
+	Type        *string   `json:"type,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Values      []*string `json:"values,omitempty"`
+}
+
+// ReportOptions specifies the parameters to the ReportingService.RunReport method.
+type ReportOptions struct {
+	// Start and End bound the reported time range. They are sent in UTC.
+	Start time.Time
+	End   time.Time
+
+	// Interval is one of the ReportInterval constants.
+	Interval string
+
+	// ObjectIDs are the IDs of the objects to report on, typically CP codes.
+	ObjectIDs []string
+
+	// Metrics limits the returned columns to the given metrics.
+	Metrics []string
+
+	// Filters maps filter names to the values to filter on.
+	Filters map[string][]string
+}
+
+func (opt *ReportOptions) values() url.Values {
+	v := url.Values{}
+	v.Set("start", opt.Start.UTC().Format(reportTimeLayout))
+	v.Set("end", opt.End.UTC().Format(reportTimeLayout))
+
+	if opt.Interval != "" {
+		v.Set("interval", opt.Interval)
+	}
+	if len(opt.ObjectIDs) > 0 {
+		v.Set("objectIds", strings.Join(opt.ObjectIDs, ","))
+	}
+	if len(opt.Metrics) > 0 {
+		v.Set("metrics", strings.Join(opt.Metrics, ","))
+	}
+
+	// Sort the filter names so the query string, and with it the signature, is stable.
+	names := make([]string, 0, len(opt.Filters))
+	for name := range opt.Filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v.Add("filters", name+"="+strings.Join(opt.Filters[name], ","))
+	}
+
+	return v
+}
+
+// ReportData holds the response from RunReport.
+type ReportData struct {
+	Metadata          *ReportMetadata     `json:"metadata,omitempty"`
+	Data              []map[string]string `json:"data,omitempty"`
+	SummaryStatistics map[string]string   `json:"summaryStatistics,omitempty"`
+}
+
+// ReportMetadata describes the report that was run and the columns of its rows.
+type ReportMetadata struct {
+	Name              *string           `json:"name,omitempty"`
+	Version           *FlexibleString   `json:"version,omitempty"`
+	OutputType        *string           `json:"outputType,omitempty"`
+	GroupBy           []*string         `json:"groupBy,omitempty"`
+	Start             *string           `json:"start,omitempty"`
+	End               *string           `json:"end,omitempty"`
+	Interval          *string           `json:"interval,omitempty"`
+	AvailableDataEnds *string           `json:"availableDataEnds,omitempty"`
+	SuppliedDataEnds  *string           `json:"suppliedDataEnds,omitempty"`
+	ObjectType        *string           `json:"objectType,omitempty"`
+	ObjectIDs         []*FlexibleString `json:"objectIds,omitempty"`
+	Columns           []*ReportColumn   `json:"columns,omitempty"`
+}
+
+// ReportColumn describes a column of the report rows.
+type ReportColumn struct {
+	Name  *string `json:"name,omitempty"`
+	Label *string `json:"label,omitempty"`
+}
+
+// Float64s returns the values of a column as numbers, in row order. Rows without the
+// column are returned as 0.
+func (r *ReportData) Float64s(column string) ([]float64, error) {
+	series := make([]float64, len(r.Data))
+	for i, row := range r.Data {
+		v, ok := row[column]
+		if !ok || v == "" {
+			continue
+		}
+
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: column %v is not numeric: %v", i, column, err)
+		}
+		series[i] = f
+	}
+
+	return series, nil
+}
+
+// Sum returns the sum of the numeric values of a column.
+func (r *ReportData) Sum(column string) (float64, error) {
+	series, err := r.Float64s(column)
+	if err != nil {
+		return 0, err
+	}
+
+	var sum float64
+	for _, f := range series {
+		sum += f
+	}
+
+	return sum, nil
+}
+
+// ReportRangeError occurs when the requested time range is too long for the requested
+// interval. Hint holds the explanation Akamai gives, which names the maximum range.
+type ReportRangeError struct {
+	Hint string
+	Err  *AkamaiError
+}
+
+func (e *ReportRangeError) Error() string {
+	return fmt.Sprintf("report time range is too long for the interval: %v", e.Hint)
+}
+
+// Unwrap returns the underlying API error.
+func (e *ReportRangeError) Unwrap() error {
+	return e.Err
+}
+
+// GetReportVersions lists the versions of a report type.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/reporting/v1.html#getreporttypeversions
+func (s *ReportingService) GetReportVersions(ctx context.Context, name string) ([]*ReportVersion, *Response, error) {
+	u := fmt.Sprintf("reporting-api/v1/reports/%v/versions", name)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var versions []*ReportVersion
+	resp, err := s.client.Do(WithOperation(ctx, "GetReportVersions"), req, &versions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return versions, resp, nil
+}
+
+// RunReport runs a version of a report type over the given time range. A time range
+// longer than the interval allows results in a *ReportRangeError.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/reporting/v1.html#getreport
+func (s *ReportingService) RunReport(ctx context.Context, name string, version int, opt ReportOptions) (*ReportData, *Response, error) {
+	u := fmt.Sprintf("reporting-api/v1/reports/%v/versions/%v/report-data?%v", name, version, opt.values().Encode())
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := new(ReportData)
+	resp, err := s.client.Do(WithOperation(ctx, "RunReport"), req, data)
+	if err != nil {
+		var aerr *AkamaiError
+		if errors.As(err, &aerr) && isReportRangeError(aerr) {
+			return nil, resp, &ReportRangeError{Hint: aerr.Detail, Err: aerr}
+		}
+		return nil, resp, err
+	}
+
+	return data, resp, nil
+}
+
+// isReportRangeError reports whether a 400 response complains about the length of the
+// requested time range.
+func isReportRangeError(e *AkamaiError) bool {
+	if e.Status != 400 {
+		return false
+	}
+
+	detail := strings.ToLower(e.Detail + " " + e.Title)
+	return strings.Contains(detail, "interval") || strings.Contains(detail, "range")
+}
@@ -0,0 +1,126 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const reportDataFixture = `{
+	"metadata": {
+		"name": "bytes-by-cpcode",
+		"version": "1",
+		"outputType": "FLAT",
+		"groupBy": ["startdatetime"],
+		"start": "2019-09-01T00:00:00Z",
+		"end": "2019-09-03T00:00:00Z",
+		"interval": "DAY",
+		"objectType": "cpcode",
+		"objectIds": ["12345", "67890"],
+		"columns": [
+			{"name": "startdatetime", "label": "Start Time"},
+			{"name": "edgeBytesSum", "label": "Edge Bytes"},
+			{"name": "edgeHitsSum", "label": "Edge Hits"}
+		]
+	},
+	"data": [
+		{"startdatetime": "2019-09-01T00:00:00Z", "edgeBytesSum": "1024", "edgeHitsSum": "10"},
+		{"startdatetime": "2019-09-02T00:00:00Z", "edgeBytesSum": "2048.5", "edgeHitsSum": "20"}
+	],
+	"summaryStatistics": {}
+}`
+
+func TestReportOptionsValues(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	opt := ReportOptions{
+		Start:     time.Date(2019, 9, 1, 19, 0, 0, 0, est),
+		End:       time.Date(2019, 9, 3, 0, 0, 0, 0, time.UTC),
+		Interval:  ReportIntervalDay,
+		ObjectIDs: []string{"12345", "67890"},
+		Metrics:   []string{"edgeBytesSum", "edgeHitsSum"},
+		Filters:   map[string][]string{"ca": {"cacheable"}, "ip_version": {"ipv4", "ipv6"}},
+	}
+
+	v := opt.values()
+	assert.Equal(t, "2019-09-02T00:00:00Z", v.Get("start"))
+	assert.Equal(t, "2019-09-03T00:00:00Z", v.Get("end"))
+	assert.Equal(t, "DAY", v.Get("interval"))
+	assert.Equal(t, "12345,67890", v.Get("objectIds"))
+	assert.Equal(t, "edgeBytesSum,edgeHitsSum", v.Get("metrics"))
+	assert.Equal(t, []string{"ca=cacheable", "ip_version=ipv4,ipv6"}, v["filters"])
+}
+
+func TestReportingRunReport(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/reporting-api/v1/reports/bytes-by-cpcode/versions/1/report-data", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "2019-09-01T00:00:00Z", r.URL.Query().Get("start"))
+		assert.Equal(t, "12345,67890", r.URL.Query().Get("objectIds"))
+		fmt.Fprint(w, reportDataFixture)
+	})
+
+	report, _, err := client.Reporting.RunReport(context.Background(), "bytes-by-cpcode", 1, ReportOptions{
+		Start:     time.Date(2019, 9, 1, 0, 0, 0, 0, time.UTC),
+		End:       time.Date(2019, 9, 3, 0, 0, 0, 0, time.UTC),
+		Interval:  ReportIntervalDay,
+		ObjectIDs: []string{"12345", "67890"},
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Len(t, report.Metadata.Columns, 3)
+	assert.Equal(t, "2019-09-02T00:00:00Z", report.Data[1]["startdatetime"])
+
+	bytes, err := report.Float64s("edgeBytesSum")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []float64{1024, 2048.5}, bytes)
+
+	hits, err := report.Sum("edgeHitsSum")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, float64(30), hits)
+
+	_, err = report.Float64s("startdatetime")
+	assert.Error(t, err)
+}
+
+func TestReportingRunReportRangeTooLong(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/reporting-api/v1/reports/bytes-by-cpcode/versions/1/report-data", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{
+			"type": "https://problems.luna.akamaiapis.net/reporting-api/bad-request",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "The maximum time range for interval FIVE_MINUTES is 2 days."
+		}`)
+	})
+
+	_, _, err := client.Reporting.RunReport(context.Background(), "bytes-by-cpcode", 1, ReportOptions{
+		Start:    time.Date(2019, 9, 1, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2019, 9, 30, 0, 0, 0, 0, time.UTC),
+		Interval: ReportIntervalFiveMinutes,
+	})
+
+	var rerr *ReportRangeError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("expect *ReportRangeError, got %v", err)
+	}
+	assert.Equal(t, "The maximum time range for interval FIVE_MINUTES is 2 days.", rerr.Hint)
+
+	var aerr *AkamaiError
+	assert.True(t, errors.As(err, &aerr))
+}
@@ -0,0 +1,226 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// RetryDecision is whether a failed request may be made again.
+type RetryDecision int
+
+const (
+	// RetryDefault leaves the decision to DefaultRetryClassifier. It is only returned
+	// by the classifiers given to WithRetryClassifier, for the requests they don't
+	// classify.
+	RetryDefault RetryDecision = iota
+
+	// Retry makes the request again.
+	Retry
+
+	// NoRetry fails the request with its error.
+	NoRetry
+)
+
+func (d RetryDecision) String() string {
+	switch d {
+	case Retry:
+		return "retry"
+	case NoRetry:
+		return "no-retry"
+	}
+	return "default"
+}
+
+// RetryClassifier decides whether a request of the operation op that failed with err
+// may be made again. status is that of the response, or zero if there was none, such as
+// for a network error; req is nil when it isn't known. See WithRetryClassifier.
+type RetryClassifier func(op string, req *http.Request, status int, err error) RetryDecision
+
+// retryClassification is whether the transient failures of each operation are retried.
+// The operations whose requests are safe to repeat are: those that only read, those
+// that set a resource to a given state, such as the PUT of a record set, and deletions.
+// Those that create something or start a job, such as POSTing a change list, are not,
+// as a request that failed after reaching the API may have been carried out, and
+// repeating it would do it twice.
+var retryClassification = map[string]RetryDecision{
+	// Fast DNS.
+	"ListZones":                Retry,
+	"GetZone":                  Retry,
+	"CreateZone":               NoRetry,
+	"UpdateZone":               Retry,
+	"DeleteZone":               NoRetry,
+	"DeleteZoneStatus":         Retry,
+	"DeleteZoneResult":         Retry,
+	"GetRecordSet":             Retry,
+	"GetRecordTypesForName":    Retry,
+	"CreateRecordSet":          NoRetry,
+	"UpdateRecordSet":          Retry,
+	"DeleteRecordSet":          Retry,
+	"GetZoneRecordSets":        Retry,
+	"ForEachRecordSet":         Retry,
+	"ReplaceRecordSets":        Retry,
+	"GetZoneContract":          Retry,
+	"CreateChangeList":         NoRetry,
+	"GetChangeList":            Retry,
+	"GetChangeListRecordSets":  Retry,
+	"DeleteChangeList":         Retry,
+	"SubmitChangeList":         NoRetry,
+	"ValidateChangeList":       Retry,
+	"ChangeZoneGroup":          Retry,
+	"SetZoneComment":           Retry,
+	"SetZoneEndCustomerID":     Retry,
+	"SetZoneLabel":             Retry,
+	"ListZoneVersions":         Retry,
+	"GetZoneVersionRecordSets": Retry,
+	"ListGroups":               Retry,
+	"ListContracts":            Retry,
+	"GetAuthorities":           Retry,
+	"GetRecordTypes":           Retry,
+
+	// Contracts.
+	"ListProducts": Retry,
+
+	// EdgeKV.
+	"InitializeStore":   NoRetry,
+	"ListNamespaces":    Retry,
+	"CreateNamespace":   NoRetry,
+	"GetItem":           Retry,
+	"UpsertItem":        Retry,
+	"DeleteItem":        Retry,
+	"CreateAccessToken": NoRetry,
+
+	// Firewall Rules.
+	"ListServices":        Retry,
+	"ListSubscriptions":   Retry,
+	"UpdateSubscriptions": Retry,
+	"ListCIDRBlocks":      Retry,
+
+	// Edge hostnames, whose changes start change requests.
+	"ListEdgeHostnames":  Retry,
+	"GetEdgeHostname":    Retry,
+	"PatchEdgeHostname":  NoRetry,
+	"DeleteEdgeHostname": NoRetry,
+	"GetChangeRequest":   Retry,
+
+	// Image and Video Manager.
+	"ListPolicySets":   Retry,
+	"GetPolicySet":     Retry,
+	"ListPolicies":     Retry,
+	"GetPolicy":        Retry,
+	"PutPolicy":        Retry,
+	"GetPolicyHistory": Retry,
+
+	// Fast Purge. Purging again is harmless, but counts against the rate limits of
+	// purges; override it to spare them.
+	"Purge": Retry,
+
+	// Reporting.
+	"GetReportVersions": Retry,
+	"RunReport":         Retry,
+
+	// Sandbox.
+	"ListSandboxes": Retry,
+	"GetSandbox":    Retry,
+	"CreateSandbox": NoRetry,
+	"UpdateSandbox": Retry,
+	"DeleteSandbox": Retry,
+	"CloneSandbox":  NoRetry,
+	"RotateJWT":     NoRetry,
+}
+
+// DefaultRetryClassification returns whether DefaultRetryClassifier retries the
+// transient failures of each operation, by operation name. The map is a copy.
+func DefaultRetryClassification() map[string]RetryDecision {
+	m := make(map[string]RetryDecision, len(retryClassification))
+	for op, d := range retryClassification {
+		m[op] = d
+	}
+	return m
+}
+
+// DefaultRetryClassifier is the RetryClassifier of the clients. It retries the
+// transient failures, rate limiting with a 429, server errors and network errors, of
+// the operations that DefaultRetryClassification says are safe to repeat. Other
+// failures are never retried, nor are those of a context that is done. The requests
+// of other operations, such as those made with Call without WithOperation, are
+// retried on transient failures.
+func DefaultRetryClassifier(op string, req *http.Request, status int, err error) RetryDecision {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return NoRetry
+	}
+
+	var ne net.Error
+	transient := status == http.StatusTooManyRequests || status >= 500 || (status == 0 && errors.As(err, &ne))
+	if !transient {
+		return NoRetry
+	}
+	if d, ok := retryClassification[op]; ok {
+		return d
+	}
+	return Retry
+}
+
+// WithRetryClassifier overrides the decisions of ClassifyRetry, such as to retry
+// purges or to stop retrying an operation altogether. The decisions classifier leaves
+// to RetryDefault are those of DefaultRetryClassifier.
+//
+// WithRetryClassifier must not be called while the client is in use; it returns c so
+// that calls can be chained.
+func (c *Client) WithRetryClassifier(classifier func(op string, req *http.Request, status int, err error) RetryDecision) *Client {
+	c.retryClassifier = classifier
+	return c
+}
+
+// ClassifyRetry returns whether a request that failed with err may be made again, for
+// the retry loops built on the client: Retry or NoRetry. The operation is that set on
+// ctx WithOperation or, if none, that of the failed request, or else that of the
+// *OperationError err wraps. The request and status are those of the *AkamaiError err
+// wraps, if any.
+func (c *Client) ClassifyRetry(ctx context.Context, err error) RetryDecision {
+	var req *http.Request
+	status := 0
+	var ae *AkamaiError
+	if errors.As(err, &ae) {
+		status = ae.Status
+		if ae.Response != nil {
+			req = ae.Response.Request
+		}
+	}
+
+	op := Operation(ctx)
+	var oe *OperationError
+	switch {
+	case op != "":
+	case req != nil && Operation(req.Context()) != "":
+		op = Operation(req.Context())
+	case errors.As(err, &oe):
+		op = oe.Op
+	}
+
+	if c.retryClassifier != nil {
+		if d := c.retryClassifier(op, req, status, err); d != RetryDefault {
+			return d
+		}
+	}
+	return DefaultRetryClassifier(op, req, status, err)
+}
+
+type operationKey struct{}
+
+// WithOperation returns a copy of ctx with which the requests are tagged with the name
+// of the operation they are made for, such as "UpdateRecordSet", to classify their
+// failures. The service methods tag their own requests; it is for those made with Call.
+func WithOperation(ctx context.Context, op string) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// Operation returns the name of the operation set on ctx with WithOperation, if any.
+func Operation(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	op, _ := ctx.Value(operationKey{}).(string)
+	return op
+}
@@ -0,0 +1,113 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestDefaultRetryClassifier(t *testing.T) {
+	netErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		op     string
+		status int
+		err    error
+		want   akamai.RetryDecision
+	}{
+		{"GetZone", http.StatusServiceUnavailable, nil, akamai.Retry},
+		{"ListZones", 0, netErr, akamai.Retry},
+		{"UpdateRecordSet", http.StatusTooManyRequests, nil, akamai.Retry},
+		{"ReplaceRecordSets", http.StatusBadGateway, nil, akamai.Retry},
+		{"DeleteRecordSet", http.StatusInternalServerError, nil, akamai.Retry},
+		{"CreateChangeList", http.StatusServiceUnavailable, nil, akamai.NoRetry},
+		{"SubmitChangeList", 0, netErr, akamai.NoRetry},
+		{"CreateRecordSet", http.StatusGatewayTimeout, nil, akamai.NoRetry},
+		{"Purge", http.StatusServiceUnavailable, nil, akamai.Retry},
+		{"GetZone", http.StatusNotFound, nil, akamai.NoRetry},
+		{"UpdateRecordSet", http.StatusConflict, nil, akamai.NoRetry},
+		{"GetZone", 0, context.Canceled, akamai.NoRetry},
+		{"", http.StatusServiceUnavailable, nil, akamai.Retry},
+		{"", http.StatusBadRequest, nil, akamai.NoRetry},
+	}
+	for _, tt := range tests {
+		got := akamai.DefaultRetryClassifier(tt.op, nil, tt.status, tt.err)
+		assert.Equal(t, tt.want, got, "%v %d %v", tt.op, tt.status, tt.err)
+	}
+
+	table := akamai.DefaultRetryClassification()
+	assert.Equal(t, akamai.Retry, table["UpdateRecordSet"])
+	assert.Equal(t, akamai.NoRetry, table["CreateChangeList"])
+	table["UpdateRecordSet"] = akamai.NoRetry
+	assert.Equal(t, akamai.Retry, akamai.DefaultRetryClassification()["UpdateRecordSet"])
+}
+
+func TestClassifyRetry(t *testing.T) {
+	var ops []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"title":"Service Unavailable","status":503}`))
+	}))
+	defer server.Close()
+	client := akamaitest.NewStaticTestClient(t, server.URL)
+	ctx := context.Background()
+
+	calls := []struct {
+		op   string
+		call func() error
+		want akamai.RetryDecision
+	}{
+		{"GetZone", func() error {
+			_, _, err := client.FastDNSv2.GetZone(ctx, "example.com")
+			return err
+		}, akamai.Retry},
+		{"UpdateRecordSet", func() error {
+			_, _, err := client.FastDNSv2.UpdateRecordSet(ctx, &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+			return err
+		}, akamai.Retry},
+		{"CreateChangeList", func() error {
+			_, _, err := client.FastDNSv2.CreateChangeList(ctx, &akamai.ChangeListOptions{Zone: "example.com"})
+			return err
+		}, akamai.NoRetry},
+		{"Purge", func() error {
+			_, _, err := client.Purge.Purge(ctx, &akamai.PurgeRequest{Objects: []string{"https://www.example.com/"}})
+			return err
+		}, akamai.Retry},
+	}
+	for _, c := range calls {
+		err := c.call()
+		if err == nil {
+			t.Fatalf("%v: expect error, got nil", c.op)
+		}
+		assert.Equal(t, c.want, client.ClassifyRetry(ctx, err), c.op)
+	}
+
+	// An override flips the decision for purges, and leaves the others to the defaults.
+	client.WithRetryClassifier(func(op string, req *http.Request, status int, err error) akamai.RetryDecision {
+		ops = append(ops, op)
+		if op == "Purge" {
+			return akamai.NoRetry
+		}
+		return akamai.RetryDefault
+	})
+	for _, c := range calls {
+		want := c.want
+		if c.op == "Purge" {
+			want = akamai.NoRetry
+		}
+		assert.Equal(t, want, client.ClassifyRetry(ctx, c.call()), c.op)
+	}
+	assert.Equal(t, []string{"GetZone", "UpdateRecordSet", "CreateChangeList", "Purge"}, ops)
+
+	// The operation set on ctx wins over that of the request.
+	_, _, err := client.FastDNSv2.GetZone(ctx, "example.com")
+	assert.Equal(t, akamai.NoRetry, client.ClassifyRetry(akamai.WithOperation(ctx, "SubmitChangeList"), err))
+}
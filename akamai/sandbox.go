@@ -0,0 +1,207 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SandboxService handles communication with the Sandbox API (v1) related endpoints
+// of the Akamai API.
+type SandboxService service
+
+// Sandbox represents an Akamai sandbox used to test property changes before activation.
+type Sandbox struct {
+	SandboxID    *string              `json:"sandboxId,omitempty"`
+	Name         *string              `json:"name,omitempty"`
+	IsClonable   *bool                `json:"isClonable,omitempty"`
+	CreatedBy    *string              `json:"createdBy,omitempty"`
+	CreatedDate  *string              `json:"createdDate,omitempty"`
+	Status       *string              `json:"status,omitempty"`
+	Properties   []*SandboxProperty   `json:"properties,omitempty"`
+	JWTToken     *string              `json:"jwtToken,omitempty"`
+	Links        json.RawMessage      `json:"_links,omitempty"`
+	RequestHints *SandboxRequestHints `json:"requestHints,omitempty"`
+}
+
+// SandboxProperty is a property a sandbox serves traffic with.
+type SandboxProperty struct {
+	SandboxPropertyID *string      `json:"sandboxPropertyId,omitempty"`
+	RequestHostnames  []*string    `json:"requestHostnames,omitempty"`
+	CPCode            *FlexibleInt `json:"cpcode,omitempty"`
+}
+
+// SandboxRequestHints holds hints about how to route test traffic to a sandbox.
+type SandboxRequestHints struct {
+	Hostname *string `json:"hostname,omitempty"`
+}
+
+// SandboxList holds the response from ListSandboxes.
+type SandboxList struct {
+	Sandboxes []*Sandbox `json:"sandboxes,omitempty"`
+}
+
+// SandboxCreateRequest specifies the parameters for the CreateSandbox and CloneSandbox
+// methods. Set exactly one of CreateFromProperty and CreateFromRules.
+type SandboxCreateRequest struct {
+	Name               string                    `json:"name,omitempty"`
+	IsClonable         bool                      `json:"isClonable,omitempty"`
+	RequestHostnames   []string                  `json:"requestHostnames,omitempty"`
+	CreateFromProperty *SandboxPropertySpecifier `json:"createFromProperty,omitempty"`
+	CreateFromRules    json.RawMessage           `json:"createFromRules,omitempty"`
+	CPCode             *SandboxCPCode            `json:"cpcode,omitempty"`
+}
+
+// SandboxPropertySpecifier identifies the property version a sandbox is created from.
+// Set either PropertyID or Hostname, and optionally a version.
+type SandboxPropertySpecifier struct {
+	PropertyID      string `json:"propertyId,omitempty"`
+	Hostname        string `json:"hostname,omitempty"`
+	PropertyVersion int    `json:"version,omitempty"`
+}
+
+// SandboxCPCode is the CP code sandbox traffic is reported under.
+type SandboxCPCode struct {
+	CPCode int `json:"cpCodeId"`
+}
+
+// SandboxUpdateRequest specifies the parameters for the UpdateSandbox method.
+type SandboxUpdateRequest struct {
+	Name string `json:"name,omitempty"`
+
+	// IsClonable is left out of the request when nil, so that renaming a sandbox
+	// keeps its setting. Set it with Bool.
+	IsClonable *bool `json:"isClonable,omitempty"`
+}
+
+// ListSandboxes lists the sandboxes of the user.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/sandbox/v1.html#getsandboxes
+func (s *SandboxService) ListSandboxes(ctx context.Context) (*SandboxList, *Response, error) {
+	req, err := s.client.NewRequest("GET", "sandbox-api/v1/sandboxes", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(SandboxList)
+	resp, err := s.client.Do(WithOperation(ctx, "ListSandboxes"), req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
+
+// GetSandbox retrieves a single sandbox.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/sandbox/v1.html#getsandbox
+func (s *SandboxService) GetSandbox(ctx context.Context, sandboxID string) (*Sandbox, *Response, error) {
+	u := fmt.Sprintf("sandbox-api/v1/sandboxes/%v", sandboxID)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sb := new(Sandbox)
+	resp, err := s.client.Do(WithOperation(ctx, "GetSandbox"), req, sb)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sb, resp, nil
+}
+
+// CreateSandbox creates a sandbox from a property version or from a rule tree. The
+// returned sandbox carries the JWT that test traffic has to present.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/sandbox/v1.html#postsandboxes
+func (s *SandboxService) CreateSandbox(ctx context.Context, sb *SandboxCreateRequest) (*Sandbox, *Response, error) {
+	req, err := s.client.NewRequest("POST", "sandbox-api/v1/sandboxes", sb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := new(Sandbox)
+	resp, err := s.client.Do(WithOperation(ctx, "CreateSandbox"), req, created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return created, resp, nil
+}
+
+// UpdateSandbox changes the name or clonability of a sandbox.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/sandbox/v1.html#putsandbox
+func (s *SandboxService) UpdateSandbox(ctx context.Context, sandboxID string, sb *SandboxUpdateRequest) (*Sandbox, *Response, error) {
+	u := fmt.Sprintf("sandbox-api/v1/sandboxes/%v", sandboxID)
+
+	req, err := s.client.NewRequest("PUT", u, sb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := new(Sandbox)
+	resp, err := s.client.Do(WithOperation(ctx, "UpdateSandbox"), req, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated, resp, nil
+}
+
+// DeleteSandbox deletes a sandbox.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/sandbox/v1.html#deletesandbox
+func (s *SandboxService) DeleteSandbox(ctx context.Context, sandboxID string) (*Response, error) {
+	u := fmt.Sprintf("sandbox-api/v1/sandboxes/%v", sandboxID)
+
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(WithOperation(ctx, "DeleteSandbox"), req, nil)
+}
+
+// CloneSandbox creates a new sandbox from an existing clonable sandbox.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/sandbox/v1.html#postclonesandbox
+func (s *SandboxService) CloneSandbox(ctx context.Context, sandboxID string, sb *SandboxCreateRequest) (*Sandbox, *Response, error) {
+	u := fmt.Sprintf("sandbox-api/v1/sandboxes/%v/clone", sandboxID)
+
+	req, err := s.client.NewRequest("POST", u, sb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cloned := new(Sandbox)
+	resp, err := s.client.Do(WithOperation(ctx, "CloneSandbox"), req, cloned)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return cloned, resp, nil
+}
+
+// RotateJWT replaces the JWT of a sandbox. Test traffic presenting the old token is
+// rejected once this returns.
+//
+// Akamai API docs: https://developer.akamai.com/api/core_features/sandbox/v1.html#postrotatejwt
+func (s *SandboxService) RotateJWT(ctx context.Context, sandboxID string) (*Sandbox, *Response, error) {
+	u := fmt.Sprintf("sandbox-api/v1/sandboxes/%v/rotateJWT", sandboxID)
+
+	req, err := s.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sb := new(Sandbox)
+	resp, err := s.client.Do(WithOperation(ctx, "RotateJWT"), req, sb)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sb, resp, nil
+}
@@ -0,0 +1,127 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sandboxFixture = `{
+	"sandboxId": "2c8d7ac1-3d9e-4d1c-aa8e-32c3a1d1a6e0",
+	"name": "checkout-redesign",
+	"isClonable": true,
+	"createdBy": "jdoe",
+	"createdDate": "2019-09-01T10:00:00Z",
+	"status": "ACTIVE",
+	"jwtToken": "%s",
+	"requestHints": {"hostname": "sandbox.akamaized.net"},
+	"properties": [
+		{"sandboxPropertyId": "1234", "requestHostnames": ["www.example.com"], "cpcode": 5678}
+	],
+	"_links": {"self": {"href": "/sandbox-api/v1/sandboxes/2c8d7ac1-3d9e-4d1c-aa8e-32c3a1d1a6e0"}}
+}`
+
+func TestSandboxCreateFromProperty(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/sandbox-api/v1/sandboxes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{
+			"name": "checkout-redesign",
+			"isClonable": true,
+			"requestHostnames": ["www.example.com"],
+			"createFromProperty": {"hostname": "www.example.com", "version": 12}
+		}`, string(body))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, sandboxFixture, "jwt-1")
+	})
+
+	sb, _, err := client.Sandbox.CreateSandbox(context.Background(), &SandboxCreateRequest{
+		Name:             "checkout-redesign",
+		IsClonable:       true,
+		RequestHostnames: []string{"www.example.com"},
+		CreateFromProperty: &SandboxPropertySpecifier{
+			Hostname:        "www.example.com",
+			PropertyVersion: 12,
+		},
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Equal(t, "2c8d7ac1-3d9e-4d1c-aa8e-32c3a1d1a6e0", *sb.SandboxID)
+	assert.Equal(t, "jwt-1", *sb.JWTToken)
+	assert.Equal(t, "sandbox.akamaized.net", *sb.RequestHints.Hostname)
+	if assert.Len(t, sb.Properties, 1) {
+		assert.Equal(t, 5678, sb.Properties[0].GetCPCode())
+	}
+}
+
+func TestSandboxRotateJWT(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/sandbox-api/v1/sandboxes/2c8d7ac1-3d9e-4d1c-aa8e-32c3a1d1a6e0/rotateJWT", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprintf(w, sandboxFixture, "jwt-2")
+	})
+
+	sb, _, err := client.Sandbox.RotateJWT(context.Background(), "2c8d7ac1-3d9e-4d1c-aa8e-32c3a1d1a6e0")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "jwt-2", *sb.JWTToken)
+}
+
+func TestSandboxLifecycle(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/sandbox-api/v1/sandboxes/2c8d7ac1-3d9e-4d1c-aa8e-32c3a1d1a6e0", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprintf(w, sandboxFixture, "jwt-1")
+		case "PUT":
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name": "renamed"}`, string(body))
+			fmt.Fprintf(w, sandboxFixture, "jwt-1")
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc("/sandbox-api/v1/sandboxes/2c8d7ac1-3d9e-4d1c-aa8e-32c3a1d1a6e0/clone", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, sandboxFixture, "jwt-clone")
+	})
+	mux.HandleFunc("/sandbox-api/v1/sandboxes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"sandboxes": [`+sandboxFixture+`]}`, "jwt-1")
+	})
+
+	ctx := context.Background()
+	id := "2c8d7ac1-3d9e-4d1c-aa8e-32c3a1d1a6e0"
+
+	list, _, err := client.Sandbox.ListSandboxes(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, list.Sandboxes, 1)
+
+	_, _, err = client.Sandbox.GetSandbox(ctx, id)
+	assert.NoError(t, err)
+
+	_, _, err = client.Sandbox.UpdateSandbox(ctx, id, &SandboxUpdateRequest{Name: "renamed"})
+	assert.NoError(t, err)
+
+	clone, _, err := client.Sandbox.CloneSandbox(ctx, id, &SandboxCreateRequest{Name: "clone"})
+	assert.NoError(t, err)
+	assert.Equal(t, "jwt-clone", *clone.JWTToken)
+
+	_, err = client.Sandbox.DeleteSandbox(ctx, id)
+	assert.NoError(t, err)
+}
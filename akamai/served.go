@@ -0,0 +1,245 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// DNSResolver looks up the records of a name. It is implemented by *net.Resolver.
+type DNSResolver interface {
+	NSResolver
+	LookupIP(ctx context.Context, network, host string) ([]net.IP, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// VerifyServedOptions specifies the optional parameters to VerifyRecordServed.
+type VerifyServedOptions struct {
+	// Authorities are the name servers to query. Defaults to those of the apex NS
+	// record set of the zone.
+	Authorities []string
+
+	// Quorum is the number of authorities that must serve the expected records. Zero
+	// requires all of them.
+	Quorum int
+
+	// Poll configures the waits between two rounds of queries.
+	Poll PollSpec
+
+	// Resolver returns the resolver that queries the name server ns. Defaults to a
+	// net.Resolver that sends its queries to port 53 of ns.
+	Resolver func(ns string) DNSResolver
+}
+
+// AuthorityAnswer is what an authority answered for a record set in the last round of
+// queries of VerifyRecordServed.
+type AuthorityAnswer struct {
+	Authority string
+	Rdata     []string
+
+	// Served reports whether the answer holds the expected records.
+	Served bool
+	Err    error
+}
+
+// servedTypes are the record types VerifyRecordServed can look up.
+var servedTypes = []string{RRTypeA, RRTypeAaaa, RRTypeCname, RRTypeMx, RRTypeNs, RRTypeTxt, RRTypeSrv}
+
+// VerifyRecordServed queries the authorities of a zone directly until they serve a
+// record set with the expected rdata, as activation doesn't mean that every name server
+// answers with the changes yet. Rdata is compared as RecordSetEqual does, with the
+// forms of names, addresses and TXT strings normalized. Only the A, AAAA, CNAME, MX,
+// NS, TXT and SRV types can be looked up.
+//
+// The authorities are queried in rounds, retried as configured by opt.Poll until all of
+// them, or opt.Quorum, serve the records. It returns the answers of the last round; if
+// the poll gives up, the error is a *PollTimeoutError.
+func (s *FastDNSv2Service) VerifyRecordServed(ctx context.Context, zone, name, rtype string, expectedRdata []string, opt *VerifyServedOptions) ([]*AuthorityAnswer, error) {
+	if opt == nil {
+		opt = &VerifyServedOptions{}
+	}
+	rtype = strings.ToUpper(rtype)
+	if !isServedType(rtype) {
+		return nil, fmt.Errorf("records of type %v can't be looked up, only %v", rtype, strings.Join(servedTypes, ", "))
+	}
+
+	authorities := opt.Authorities
+	if len(authorities) == 0 {
+		rs, _, err := s.GetRecordSet(ctx, &RecordSetOptions{Zone: zone, Name: zone, Type: RRTypeNs})
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range rs.Rdata {
+			authorities = append(authorities, StringValue(ns))
+		}
+	}
+	quorum := opt.Quorum
+	if quorum <= 0 || quorum > len(authorities) {
+		quorum = len(authorities)
+	}
+	resolver := opt.Resolver
+	if resolver == nil {
+		resolver = authorityResolver
+	}
+
+	fqdn := strings.TrimSuffix(name, ".") + "."
+	return clientPoll(ctx, s.client, "VerifyRecordServed", opt.Poll, func(answers []*AuthorityAnswer) string {
+		served := 0
+		for _, a := range answers {
+			if a.Served {
+				served++
+			}
+		}
+		return fmt.Sprintf("served by %d/%d", served, len(answers))
+	}, func(ctx context.Context) ([]*AuthorityAnswer, bool, error) {
+		answers := make([]*AuthorityAnswer, len(authorities))
+		served := 0
+		for i, ns := range authorities {
+			a := &AuthorityAnswer{Authority: ns}
+			a.Rdata, a.Err = lookupRdata(ctx, resolver(ns), fqdn, rtype)
+			if a.Err == nil && RecordSetEqual(rtype, a.Rdata, expectedRdata) {
+				a.Served = true
+				served++
+			}
+			answers[i] = a
+		}
+		return answers, served >= quorum, nil
+	})
+}
+
+// isServedType reports whether VerifyRecordServed can look up records of rtype.
+func isServedType(rtype string) bool {
+	for _, t := range servedTypes {
+		if t == rtype {
+			return true
+		}
+	}
+	return false
+}
+
+// authorityResolver returns a resolver that sends its queries to the name server ns.
+func authorityResolver(ns string) DNSResolver {
+	addr := net.JoinHostPort(strings.TrimSuffix(ns, "."), "53")
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// lookupRdata looks up the records of a name with r, in the rdata form of the API. A
+// name without such records has none, rather than an error.
+func lookupRdata(ctx context.Context, r DNSResolver, name, rtype string) ([]string, error) {
+	var rdata []string
+	var err error
+	switch rtype {
+	case RRTypeA, RRTypeAaaa:
+		network := "ip4"
+		if rtype == RRTypeAaaa {
+			network = "ip6"
+		}
+		var ips []net.IP
+		ips, err = r.LookupIP(ctx, network, name)
+		for _, ip := range ips {
+			rdata = append(rdata, ip.String())
+		}
+	case RRTypeCname:
+		var target string
+		target, err = r.LookupCNAME(ctx, name)
+		// A name without a CNAME record is its own canonical name.
+		if err == nil && !strings.EqualFold(strings.TrimSuffix(target, "."), strings.TrimSuffix(name, ".")) {
+			rdata = append(rdata, target)
+		}
+	case RRTypeMx:
+		var mxs []*net.MX
+		mxs, err = r.LookupMX(ctx, name)
+		for _, mx := range mxs {
+			rdata = append(rdata, fmt.Sprintf("%d %v", mx.Pref, mx.Host))
+		}
+	case RRTypeNs:
+		var nss []*net.NS
+		nss, err = r.LookupNS(ctx, name)
+		for _, ns := range nss {
+			rdata = append(rdata, ns.Host)
+		}
+	case RRTypeTxt:
+		var txts []string
+		txts, err = r.LookupTXT(ctx, name)
+		for _, txt := range txts {
+			rdata = append(rdata, QuoteTXT(txt))
+		}
+	case RRTypeSrv:
+		var srvs []*net.SRV
+		_, srvs, err = r.LookupSRV(ctx, "", "", name)
+		for _, srv := range srvs {
+			rdata = append(rdata, SRVRecord{Priority: int(srv.Priority), Weight: int(srv.Weight), Port: int(srv.Port), Target: srv.Target}.Rdata())
+		}
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, nil
+	}
+	return rdata, err
+}
+
+// RecordSetEqual reports whether two sets of rdata of the given record type hold the
+// same records, in any order. Rdata is compared in a normalized form: host names in
+// lower case without their trailing dot, addresses in their canonical form, TXT rdata
+// as the value UnquoteTXT decodes, and other rdata with its spaces collapsed.
+func RecordSetEqual(rtype string, a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	na := make([]string, len(a))
+	nb := make([]string, len(b))
+	for i := range a {
+		na[i] = normalizeRdata(rtype, a[i])
+		nb[i] = normalizeRdata(rtype, b[i])
+	}
+	sort.Strings(na)
+	sort.Strings(nb)
+
+	for i := range na {
+		if na[i] != nb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeRdata returns the normalized form of rdata that RecordSetEqual compares.
+func normalizeRdata(rtype, rdata string) string {
+	fields := strings.Fields(rdata)
+	switch strings.ToUpper(rtype) {
+	case RRTypeA, RRTypeAaaa:
+		if ip, err := netip.ParseAddr(strings.TrimSpace(rdata)); err == nil {
+			return ip.Unmap().String()
+		}
+	case RRTypeCname, RRTypeNs, RRTypePtr:
+		return normalizeHost(strings.TrimSpace(rdata))
+	case RRTypeMx:
+		if len(fields) == 2 {
+			return fields[0] + " " + normalizeHost(fields[1])
+		}
+	case RRTypeSrv:
+		if len(fields) == 4 {
+			return strings.Join(fields[:3], " ") + " " + normalizeHost(fields[3])
+		}
+	case RRTypeTxt, RRTypeSpf:
+		if v, err := UnquoteTXT(rdata); err == nil {
+			return v
+		}
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,141 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestRecordSetEqual(t *testing.T) {
+	tests := []struct {
+		rtype string
+		a, b  []string
+		equal bool
+	}{
+		{"A", []string{"192.0.2.1", "192.0.2.2"}, []string{"192.0.2.2", "192.0.2.1"}, true},
+		{"AAAA", []string{"2001:DB8:0::1"}, []string{"2001:db8::1"}, true},
+		{"CNAME", []string{"WWW.Example.com."}, []string{"www.example.com"}, true},
+		{"MX", []string{"10  mail.example.com."}, []string{"10 MAIL.example.com"}, true},
+		{"MX", []string{"10 mail.example.com."}, []string{"20 mail.example.com."}, false},
+		{"SRV", []string{"10 60 5060 sip.example.com."}, []string{"10 60 5060 SIP.example.com"}, true},
+		{"TXT", []string{`"v=spf1 " "-all"`}, []string{`"v=spf1 -all"`}, true},
+		{"TXT", []string{`"Case"`}, []string{`"case"`}, false},
+		{"A", []string{"192.0.2.1"}, []string{"192.0.2.1", "192.0.2.2"}, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.equal, akamai.RecordSetEqual(tt.rtype, tt.a, tt.b), "%v %v %v", tt.rtype, tt.a, tt.b)
+	}
+}
+
+// authorityStub answers the lookups sent to a name server from its records, and counts
+// the rounds of queries it received.
+type authorityStub struct {
+	mu      sync.Mutex
+	queries int
+	records func(queries int) []string
+}
+
+func (a *authorityStub) answer() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.queries++
+	return a.records(a.queries)
+}
+
+func (a *authorityStub) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	return nil, errors.New("unexpected lookup")
+}
+
+func (a *authorityStub) LookupIP(ctx context.Context, network, host string) ([]net.IP, error) {
+	var ips []net.IP
+	for _, r := range a.answer() {
+		ips = append(ips, net.ParseIP(r))
+	}
+	if len(ips) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return ips, nil
+}
+
+func (a *authorityStub) LookupCNAME(ctx context.Context, host string) (string, error) {
+	return "", errors.New("unexpected lookup")
+}
+
+func (a *authorityStub) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	return nil, errors.New("unexpected lookup")
+}
+
+func (a *authorityStub) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return a.answer(), nil
+}
+
+func (a *authorityStub) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	return "", nil, errors.New("unexpected lookup")
+}
+
+func TestVerifyRecordServed(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	// a2-2 only serves the new address from its third round of queries.
+	stubs := map[string]*authorityStub{
+		"a1-1.akam.net.": {records: func(int) []string { return []string{"192.0.2.1"} }},
+		"a2-2.akam.net.": {records: func(q int) []string {
+			if q < 3 {
+				return nil
+			}
+			return []string{"192.0.2.1"}
+		}},
+	}
+	opt := &akamai.VerifyServedOptions{
+		Poll:     akamai.PollSpec{Initial: time.Millisecond},
+		Resolver: func(ns string) akamai.DNSResolver { return stubs[ns] },
+	}
+
+	answers, err := client.FastDNSv2.VerifyRecordServed(ctx, "example.com", "www.example.com", "A", []string{"192.0.2.1"}, opt)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, answers, 2) {
+		assert.True(t, answers[0].Served)
+		assert.True(t, answers[1].Served)
+	}
+	assert.Equal(t, 3, stubs["a2-2.akam.net."].queries)
+
+	// A quorum of one is served at once by a1-1, not by a lagging a2-2.
+	stubs["a2-2.akam.net."] = &authorityStub{records: func(int) []string { return []string{"192.0.2.99"} }}
+	opt.Quorum = 1
+	answers, err = client.FastDNSv2.VerifyRecordServed(ctx, "example.com", "www.example.com", "A", []string{"192.0.2.1"}, opt)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, answers, 2) {
+		assert.False(t, answers[1].Served)
+		assert.Equal(t, []string{"192.0.2.99"}, answers[1].Rdata)
+	}
+
+	// The poll gives up when the authorities never all serve the records.
+	opt.Quorum = 0
+	opt.Poll.MaxAttempts = 3
+	answers, err = client.FastDNSv2.VerifyRecordServed(ctx, "example.com", "www.example.com", "A", []string{"192.0.2.1"}, opt)
+	assert.True(t, errors.Is(err, akamai.ErrPollTimeout))
+	assert.Len(t, answers, 2)
+
+	// TXT answers are compared to their values.
+	opt.Authorities = []string{"ns.example.net"}
+	stubs["ns.example.net"] = &authorityStub{records: func(int) []string { return []string{"v=DMARC1; p=reject"} }}
+	_, err = client.FastDNSv2.VerifyRecordServed(ctx, "example.com", "_dmarc.example.com", "TXT", []string{`"v=DMARC1; p=reject"`}, opt)
+	assert.NoError(t, err)
+
+	_, err = client.FastDNSv2.VerifyRecordServed(ctx, "example.com", "example.com", "CAA", nil, opt)
+	assert.Error(t, err)
+}
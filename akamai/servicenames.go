@@ -0,0 +1,94 @@
+package akamai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildSRVName returns the owner name of the SRV records of a service, such as
+// _sip._tcp.example.com for the service "sip" over "tcp" in example.com. The service
+// and protocol are given with or without their leading underscore.
+func BuildSRVName(service, proto, zone string) (string, error) {
+	service = strings.TrimPrefix(service, "_")
+	proto = strings.TrimPrefix(proto, "_")
+	if service == "" || proto == "" {
+		return "", &NameError{Name: fmt.Sprintf("_%v._%v.%v", service, proto, zone), Reason: "empty service or protocol"}
+	}
+	return underscoreName(fmt.Sprintf("_%v._%v", service, proto), zone)
+}
+
+// BuildDMARCName returns the owner name of the DMARC policy of a domain,
+// _dmarc.example.com for example.com.
+func BuildDMARCName(zone string) (string, error) {
+	return underscoreName("_dmarc", zone)
+}
+
+// BuildACMEChallengeName returns the owner name of the ACME DNS-01 challenges of a host,
+// _acme-challenge.www.example.com for www.example.com. The challenges of a wildcard
+// certificate for *.example.com are answered at _acme-challenge.example.com.
+func BuildACMEChallengeName(host string) (string, error) {
+	return underscoreName("_acme-challenge", strings.TrimPrefix(host, "*."))
+}
+
+// underscoreName prefixes the normalized domain with labels, whose underscores are only
+// valid in record names.
+func underscoreName(labels, domain string) (string, error) {
+	d, err := NormalizeRecordName(domain)
+	if err != nil {
+		return "", err
+	}
+	return NormalizeRecordName(labels + "." + d)
+}
+
+// SRVRecord is the rdata of an SRV record, as described by RFC 2782.
+type SRVRecord struct {
+	Priority int
+	Weight   int
+	Port     int
+	Target   string
+}
+
+// Rdata returns the record as the rdata the FastDNS API expects, with its target fully
+// qualified.
+func (r SRVRecord) Rdata() string {
+	target := r.Target
+	if !strings.HasSuffix(target, ".") {
+		target += "."
+	}
+	return fmt.Sprintf("%d %d %d %v", r.Priority, r.Weight, r.Port, target)
+}
+
+// NewSRVRecordSet returns the request creating the SRV record set of a service in zone,
+// named by BuildSRVName.
+func NewSRVRecordSet(zone, service, proto string, ttl int, records ...SRVRecord) (*RecordSetCreateRequest, error) {
+	name, err := BuildSRVName(service, proto, zone)
+	if err != nil {
+		return nil, err
+	}
+	rs := &RecordSetCreateRequest{Zone: zone, Name: name, Type: RRTypeSrv, TTL: Int(ttl)}
+	for _, r := range records {
+		rs.Rdata = append(rs.Rdata, r.Rdata())
+	}
+	return rs, nil
+}
+
+// NewDMARCRecordSet returns the request creating the DMARC policy of zone, such as
+// "v=DMARC1; p=reject", as a TXT record set named by BuildDMARCName.
+func NewDMARCRecordSet(zone string, ttl int, policy string) (*RecordSetCreateRequest, error) {
+	name, err := BuildDMARCName(zone)
+	if err != nil {
+		return nil, err
+	}
+	return NewTXTRecordSet(zone, name, ttl, policy), nil
+}
+
+// NewACMEChallengeRecordSet returns the request creating the ACME DNS-01 challenge
+// record set of a host of zone, named by BuildACMEChallengeName, with one TXT record per
+// key authorization digest.
+func NewACMEChallengeRecordSet(zone, host string, ttl int, digests ...string) (*RecordSetCreateRequest, error) {
+	name, err := BuildACMEChallengeName(host)
+	if err != nil {
+		return nil, err
+	}
+	return NewTXTRecordSet(zone, name, ttl, digests...), nil
+}
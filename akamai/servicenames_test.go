@@ -0,0 +1,99 @@
+package akamai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestBuildServiceNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func() (string, error)
+		expected string
+	}{
+		{"srv", func() (string, error) { return akamai.BuildSRVName("sip", "tcp", "Example.com.") }, "_sip._tcp.example.com"},
+		{"srv with underscores", func() (string, error) { return akamai.BuildSRVName("_xmpp-server", "_tcp", "example.com") }, "_xmpp-server._tcp.example.com"},
+		{"dmarc", func() (string, error) { return akamai.BuildDMARCName("example.com") }, "_dmarc.example.com"},
+		{"acme", func() (string, error) { return akamai.BuildACMEChallengeName("WWW.example.com") }, "_acme-challenge.www.example.com"},
+		{"acme wildcard", func() (string, error) { return akamai.BuildACMEChallengeName("*.example.com") }, "_acme-challenge.example.com"},
+		{"srv without service", func() (string, error) { return akamai.BuildSRVName("", "tcp", "example.com") }, ""},
+		{"invalid zone", func() (string, error) { return akamai.BuildDMARCName("exa mple.com") }, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := tt.build()
+			if tt.expected == "" {
+				assert.Error(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+			assert.Equal(t, tt.expected, name)
+		})
+	}
+}
+
+func TestServiceRecordSets(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+
+	srvRecords, err := akamai.NewSRVRecordSet("example.com", "sip", "tcp", 300,
+		akamai.SRVRecord{Priority: 10, Weight: 60, Port: 5060, Target: "sip1.example.com"},
+		akamai.SRVRecord{Priority: 10, Weight: 40, Port: 5060, Target: "sip2.example.com."},
+	)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"10 60 5060 sip1.example.com.", "10 40 5060 sip2.example.com."}, srvRecords.Rdata)
+
+	dmarc, err := akamai.NewDMARCRecordSet("example.com", 3600, "v=DMARC1; p=reject")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	acme, err := akamai.NewACMEChallengeRecordSet("example.com", "*.example.com", 60, "digest-1", "digest-2")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	for _, rs := range []*akamai.RecordSetCreateRequest{srvRecords, dmarc, acme} {
+		if _, _, err := client.FastDNSv2.CreateRecordSet(ctx, rs); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+
+		types, _, err := client.FastDNSv2.GetRecordTypesForName(ctx, "example.com", rs.Name)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.Equal(t, []string{rs.Type}, types, rs.Name)
+	}
+
+	got, _, err := client.FastDNSv2.GetRecordSet(ctx, &akamai.RecordSetOptions{Zone: "example.com", Name: "_dmarc.example.com", Type: "TXT"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	values, err := got.TXTValues()
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"v=DMARC1; p=reject"}, values)
+
+	// Searches match the leading underscores of owner names.
+	list, _, err := client.FastDNSv2.GetZoneRecordSets(ctx, "example.com", &akamai.ListZoneRecordSetOptions{Search: "_acme-challenge"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, list.RecordSets, 1) {
+		assert.Equal(t, "_acme-challenge.example.com", list.RecordSets[0].GetName())
+	}
+	assert.Contains(t, srv.Requests(), "GET /config-dns/v2/zones/example.com/names/_sip._tcp.example.com/types")
+
+	_, _, err = client.FastDNSv2.GetRecordTypesForName(ctx, "example.com", "_missing.example.com")
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package akamai
+
+// The services of the Akamai API are wired into every Client by NewClient, but can
+// also be constructed on their own. Packages outside of this SDK can build their own
+// services the same way, on top of Client.NewRequest, Client.Do, Client.Call, and
+// AddOptions, without forking the SDK:
+//
+//     type IdentityService struct {
+//         client *akamai.Client
+//     }
+//
+//     func (s *IdentityService) GetClient(ctx context.Context, id string) (*APIClient, *akamai.Response, error) {
+//         c := new(APIClient)
+//         resp, err := s.client.Call(ctx, "GET", "identity-management/v1/api-clients/"+id, nil, c)
+//         return c, resp, err
+//     }
+
+// NewFastDNSv2Service returns a FastDNSv2Service that makes its requests with c.
+func NewFastDNSv2Service(c *Client) *FastDNSv2Service {
+	return &FastDNSv2Service{client: c}
+}
+
+// FastDNSv2Service returns the FastDNSv2Service that NewClient set FastDNSv2 to, to
+// configure it with WithRecordPolicy, WithRdataLimits and WithDefaultTTLPolicy. It is
+// still the service that FastDNSv2 forwards to once wrapped in a decorator such as
+// DataCache, so it can be configured before or after FastDNSv2 is wrapped:
+//
+//	client.FastDNSv2Service().WithDefaultTTLPolicy(akamai.RecommendedDefaultTTLs())
+//	client.FastDNSv2 = akamai.NewDataCache(client.FastDNSv2, time.Minute)
+//
+// A service that FastDNSv2 is replaced with, such as one of NewFastDNSv2Service, is
+// configured on its own.
+func (c *Client) FastDNSv2Service() *FastDNSv2Service {
+	return c.fastDNSv2
+}
+
+// NewContractsService returns a ContractsService that makes its requests with c.
+func NewContractsService(c *Client) *ContractsService {
+	return &ContractsService{client: c}
+}
+
+// NewImagingService returns an ImagingService that makes its requests with c.
+func NewImagingService(c *Client) *ImagingService {
+	return &ImagingService{client: c}
+}
+
+// NewFirewallRulesService returns a FirewallRulesService that makes its requests with c.
+func NewFirewallRulesService(c *Client) *FirewallRulesService {
+	return &FirewallRulesService{client: c}
+}
+
+// NewEdgeKVService returns an EdgeKVService that makes its requests with c.
+func NewEdgeKVService(c *Client) *EdgeKVService {
+	return &EdgeKVService{client: c}
+}
+
+// NewReportingService returns a ReportingService that makes its requests with c.
+func NewReportingService(c *Client) *ReportingService {
+	return &ReportingService{client: c}
+}
+
+// NewHAPIService returns a HAPIService that makes its requests with c.
+func NewHAPIService(c *Client) *HAPIService {
+	return &HAPIService{client: c}
+}
+
+// NewSandboxService returns a SandboxService that makes its requests with c.
+func NewSandboxService(c *Client) *SandboxService {
+	return &SandboxService{client: c}
+}
+
+// NewPurgeService returns a PurgeService that makes its requests with c.
+func NewPurgeService(c *Client) *PurgeService {
+	return &PurgeService{client: c}
+}
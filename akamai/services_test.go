@@ -0,0 +1,121 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// identityService is a service defined outside of the SDK, built only on its
+// exported extension points.
+type identityService struct {
+	client *akamai.Client
+}
+
+type apiClient struct {
+	ClientID   *string `json:"clientId,omitempty"`
+	ClientName *string `json:"clientName,omitempty"`
+}
+
+type apiClientListOptions struct {
+	Actions bool `url:"actions,omitempty"`
+}
+
+func (s *identityService) ListClients(ctx context.Context, opt *apiClientListOptions) ([]*apiClient, *akamai.Response, error) {
+	u, err := akamai.AddOptions("identity-management/v1/api-clients", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var clients []*apiClient
+	resp, err := s.client.Call(ctx, "GET", u, nil, &clients)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return clients, resp, nil
+}
+
+func (s *identityService) LockClient(ctx context.Context, id string) (*akamai.Response, error) {
+	req, err := s.client.NewRequest("PUT", "identity-management/v1/api-clients/"+id+"/lock", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+func newExternalTestClient(t *testing.T, mux *http.ServeMux) *akamai.Client {
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	creds := credentials.NewStaticCredentials("secret", "client-token", "access-token", server.Listener.Addr().String())
+	client, err := akamai.NewClient(nil, creds)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return client
+}
+
+func TestExternalService(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity-management/v1/api-clients", func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "EG1-HMAC-SHA256 client_token=client-token;access_token=access-token;"))
+		assert.Equal(t, "true", r.URL.Query().Get("actions"))
+		fmt.Fprint(w, `[{"clientId": "abc", "clientName": "ci"}]`)
+	})
+	mux.HandleFunc("/identity-management/v1/api-clients/abc/lock", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"title": "Forbidden", "status": 403, "detail": "not allowed to lock clients"}`)
+	})
+
+	s := &identityService{client: newExternalTestClient(t, mux)}
+	ctx := context.Background()
+
+	clients, _, err := s.ListClients(ctx, &apiClientListOptions{Actions: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, clients, 1) {
+		assert.Equal(t, "ci", *clients[0].ClientName)
+	}
+
+	_, err = s.LockClient(ctx, "abc")
+	var aerr *akamai.AkamaiError
+	if assert.True(t, errors.As(err, &aerr)) {
+		assert.Equal(t, 403, aerr.Status)
+		assert.Equal(t, "not allowed to lock clients", aerr.Detail)
+	}
+}
+
+func TestServiceConstructors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/contract-api/v1/contracts/identifiers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `["1-ABCDE"]`)
+	})
+
+	client := newExternalTestClient(t, mux)
+	contracts := akamai.NewContractsService(client)
+
+	ids, _, err := contracts.ListContracts(context.Background())
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"1-ABCDE"}, ids)
+
+	// The services wired by NewClient keep working alongside constructed ones.
+	ids, _, err = client.Contracts.ListContracts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1-ABCDE"}, ids)
+}
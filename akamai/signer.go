@@ -19,7 +19,9 @@ import (
 	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
 )
 
-// Signer applies Akamai Edgegrid signing to a given request.
+// Signer applies Akamai Edgegrid signing to a given request. A Signer may sign
+// requests from several goroutines at once; its fields must not be changed while it
+// does.
 type Signer struct {
 	Credentials *credentials.Credentials
 	// HeadersToSign is a config option of Akamai Edgegrid. User must specify
@@ -28,7 +30,8 @@ type Signer struct {
 	MaxBody       int
 
 	// For testing we need to pass a fake nonce and timestamp
-	// This is a bad strategy, but the signature relies upon it
+	// This is a bad strategy, but the signature relies upon it. They are only read,
+	// and copied into the state of each signature, which is not shared.
 	Timestamp string
 	Nonce     string
 }
@@ -42,40 +45,225 @@ func NewSigner(credentials *credentials.Credentials) *Signer {
 	return a
 }
 
-// Sign signs Akamai requests with the provided body.
-func (s *Signer) Sign(req *http.Request, body io.ReadWriter) (http.Header, error) {
-	creds, err := s.Credentials.Get()
+// RequestSigner signs the requests of a Client, such as by setting their Authorization
+// header. body holds the bytes of the request body, if it has one, so that they can be
+// signed without reading the request body; a signer that reads it must seek it back to
+// its start. body is nil when the request body is not at hand, such as when a request is
+// signed again by Do, and the signer then reads the request body if it needs it.
+//
+// The EdgeGrid Signer is the default. See Client.WithSigner.
+type RequestSigner interface {
+	Sign(req *http.Request, body io.ReadSeeker) error
+}
+
+// Sign signs Akamai requests with the provided body, setting their EdgeGrid
+// Authorization header.
+func (s *Signer) Sign(req *http.Request, body io.ReadSeeker) error {
+	var b []byte
+	if body != nil && req.Method == "POST" {
+		var err error
+		if b, err = readSigned(body, s.maxBody()); err != nil {
+			return err
+		}
+	}
+	_, err := s.sign(req, b)
+	return err
+}
+
+// DebugSigningString returns the data to sign of req, from which its EdgeGrid signature
+// is computed, to debug the signatures that Akamai rejects. It is the tab-separated
+// method, scheme, host, path and query, canonical headers and hash of the body of req,
+// followed by its Authorization header without the signature. It holds neither the
+// client secret nor the signing key derived from it.
+//
+// If req has been signed, the timestamp and nonce of its Authorization header are used,
+// so that the data is the one that was signed; otherwise those of s, or new ones. body
+// is read as by Sign; if it is nil, the request body is read, and left readable. req is
+// not otherwise changed.
+func (s *Signer) DebugSigningString(req *http.Request, body io.ReadSeeker) (string, error) {
+	var b []byte
+	if body != nil && req.Method == "POST" {
+		var err error
+		if b, err = readSigned(body, s.maxBody()); err != nil {
+			return "", err
+		}
+	}
+	return s.signingString(req, b)
+}
+
+// signingString returns the data to sign of req, whose body holds the bytes of body, or
+// whose request body is read if body is nil.
+func (s *Signer) signingString(req *http.Request, body []byte) (string, error) {
+	r := req.Clone(req.Context())
+	ctx, err := s.signingCtx(r, body)
+	if err != nil {
+		return "", err
+	}
+	if fields, ok := authFields(req.Header.Get("Authorization")); ok {
+		ctx.formattedTime, ctx.nonce = fields["timestamp"], fields["nonce"]
+	}
+
+	ctx.buildData()
+	req.Body = r.Body
+	return ctx.signingData, nil
+}
+
+// authFields returns the fields of an EdgeGrid Authorization header, such as its
+// timestamp and nonce, or false if auth is not one.
+func authFields(auth string) (map[string]string, bool) {
+	if !strings.HasPrefix(auth, "EG1-HMAC-SHA256 ") {
+		return nil, false
+	}
+
+	fields := map[string]string{}
+	for _, f := range strings.Split(strings.TrimPrefix(auth, "EG1-HMAC-SHA256 "), ";") {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+	return fields, true
+}
+
+// readSigned reads the first max bytes of body, which are the ones hashed by the
+// signature, and seeks it back to its start.
+func readSigned(body io.ReadSeeker, max int) ([]byte, error) {
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(body, int64(max)))
+	if err != nil {
+		return nil, err
+	}
+	_, err = body.Seek(0, io.SeekStart)
+	return b, err
+}
+
+// sign signs req with the bytes of its body, or reads the request body if body is nil.
+// The clients sign their requests with it rather than with Sign when they have the bytes
+// of the body, so that they are not read again.
+func (s *Signer) sign(req *http.Request, body []byte) (http.Header, error) {
+	ctx, err := s.signingCtx(req, body)
 	if err != nil {
 		return http.Header{}, err
+	}
 
+	if err := ctx.build(); err != nil {
+		return nil, err
 	}
 
-	ctx := &signingCtx{
+	return ctx.SignedHeaderVals, nil
+}
+
+// signingCtx returns the state of a signature of req by s, which build computes.
+func (s *Signer) signingCtx(req *http.Request, body []byte) (signingCtx, error) {
+	creds, err := s.Credentials.Get()
+	if err != nil {
+		return signingCtx{}, err
+	}
+
+	return signingCtx{
 		Request:       req,
 		Body:          body,
 		Query:         req.URL.Query(),
 		credValues:    creds,
 		formattedTime: s.Timestamp,
 		nonce:         s.Nonce,
-		maxBody:       s.MaxBody,
+		maxBody:       s.maxBody(),
 		headersToSign: s.HeadersToSign,
-	}
+	}, nil
+}
 
+// maxBody returns MaxBody, or its default.
+func (s *Signer) maxBody() int {
 	// MaxBody is set in edgegrid Go library, but wasn't found in docs. Set to 131072 in code.
-	if ctx.maxBody == 0 {
-		ctx.maxBody = 131072
+	if s.MaxBody == 0 {
+		return 131072
 	}
+	return s.MaxBody
+}
 
-	if err := ctx.build(); err != nil {
-		return nil, err
+// NoopSigner leaves requests unsigned, such as for a proxy or a fake of the API that
+// signs or authenticates requests itself.
+type NoopSigner struct{}
+
+// Sign does nothing.
+func (NoopSigner) Sign(req *http.Request, body io.ReadSeeker) error {
+	return nil
+}
+
+// StaticHeaderSigner signs requests by setting a header to a fixed value, such as an
+// Authorization header holding a bearer token for a gateway in front of the API.
+type StaticHeaderSigner struct {
+	Name  string
+	Value string
+}
+
+// NewStaticHeaderSigner returns a StaticHeaderSigner setting the header name to value.
+func NewStaticHeaderSigner(name, value string) *StaticHeaderSigner {
+	return &StaticHeaderSigner{Name: name, Value: value}
+}
+
+// Sign sets the header of the signer on req.
+func (s *StaticHeaderSigner) Sign(req *http.Request, body io.ReadSeeker) error {
+	req.Header.Set(s.Name, s.Value)
+	return nil
+}
+
+// WithSigner makes the client sign its requests with signer rather than with the
+// EdgeGrid Signer of its Credentials, such as a NoopSigner for a proxy that signs them.
+// A nil signer restores the default.
+//
+// WithSigner must not be called while the client is in use; it returns c so that calls
+// can be chained.
+func (c *Client) WithSigner(signer RequestSigner) *Client {
+	c.signer = signer
+	return c
+}
+
+// WithSigningDebug makes the client call hook with the data to sign of every request it
+// signs with the EdgeGrid Signer, as returned by Signer.DebugSigningString, such as to
+// log it at a trace level and compare it with the data to sign of a signature rejected
+// by Akamai. Requests signed by another RequestSigner are not passed to hook.
+//
+// hook may be called from several goroutines at once. WithSigningDebug must not be
+// called while the client is in use; it returns c so that calls can be chained.
+func (c *Client) WithSigningDebug(hook func(req *http.Request, signingString string)) *Client {
+	c.signingDebugHook = hook
+	return c
+}
+
+// signRequest signs req, whose body holds the bytes of body, with the signer set with
+// WithSigner, or with the EdgeGrid Signer of the Credentials of the client.
+func (c *Client) signRequest(req *http.Request, body []byte) error {
+	s, ok := c.signer.(*Signer)
+	if c.signer == nil {
+		s, ok = NewSigner(c.Credentials), true
+	}
+	if ok {
+		// The EdgeGrid signer hashes the bytes as they are, rather than reading them.
+		if _, err := s.sign(req, body); err != nil {
+			return err
+		}
+		if c.signingDebugHook != nil {
+			data, err := s.signingString(req, body)
+			if err != nil {
+				return err
+			}
+			c.signingDebugHook(req, data)
+		}
+		return nil
 	}
 
-	return ctx.SignedHeaderVals, nil
+	if body == nil {
+		return c.signer.Sign(req, nil)
+	}
+	return c.signer.Sign(req, bytes.NewReader(body))
 }
 
 type signingCtx struct {
 	Request            *http.Request
-	Body               io.ReadWriter
+	Body               []byte
 	SignedHeaderVals   http.Header
 	UnsignedHeaderVals http.Header
 	Query              url.Values
@@ -97,6 +285,19 @@ type signingCtx struct {
 }
 
 func (ctx *signingCtx) build() error {
+	ctx.buildData()
+
+	ctx.buildSigningKey() // depends on credValues and formattedTime
+
+	ctx.buildSignedAuthHeaders() // depends on like everything
+
+	ctx.Request.Header.Set("Authorization", ctx.signedAuthHeaders)
+	return nil
+}
+
+// buildData builds the data to sign, which is derived from neither the client secret nor
+// the signing key.
+func (ctx *signingCtx) buildData() {
 	if ctx.formattedTime == "" {
 		ctx.buildTime() // no deps
 
@@ -110,19 +311,12 @@ func (ctx *signingCtx) build() error {
 	ctx.buildContentHash()      // no deps
 	ctx.buildAuthHeaders()      // depends on formattedTime and nonce
 
-	ctx.buildSigningKey()  // depends on credValues and formattedTime
 	ctx.buildSigningData() // depends on pathQuery, canonicalHeaders, contentHash, and authHeaders
-
-	ctx.buildSignedAuthHeaders() // depends on like everything
-
-	ctx.Request.Header.Set("Authorization", ctx.signedAuthHeaders)
-	return nil
 }
 
 func (ctx *signingCtx) buildTime() {
 	// format the timestamp for the akamai edgegrid api request
-	local := time.FixedZone("GMT", 0)
-	t := time.Now().In(local)
+	t := time.Now().UTC()
 	ctx.formattedTime = fmt.Sprintf("%d%02d%02dT%02d:%02d:%02d+0000",
 		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second())
 }
@@ -157,7 +351,15 @@ func (ctx *signingCtx) buildSigningKey() {
 //
 // Documentation: https://developer.akamai.com/legacy/introduction/Client_Auth.html
 func (ctx *signingCtx) buildSigningData() {
-	dataSign := []string{
+	fields := ctx.signingFields()
+	ctx.signingData = strings.Join(fields[:], "\t")
+}
+
+// signingFields are the fields of the data to sign, which are joined by tabs. The
+// canonical headers are themselves joined by tabs, so the data to sign can't be split
+// back into them.
+func (ctx *signingCtx) signingFields() [7]string {
+	return [...]string{
 		ctx.Request.Method,
 		ctx.Request.URL.Scheme,
 		ctx.Request.URL.Host,
@@ -166,15 +368,20 @@ func (ctx *signingCtx) buildSigningData() {
 		ctx.contentHash,
 		ctx.authHeaders,
 	}
-	ctx.signingData = strings.Join(dataSign, "\t")
 }
 
+// buildPathQuery signs the path and query as they are sent, escaped, rather than the
+// decoded path, which differs from it for paths holding escaped characters.
 func (ctx *signingCtx) buildPathQuery() {
+	path := ctx.Request.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
 	if ctx.Request.URL.RawQuery == "" {
-		ctx.pathQuery = ctx.Request.URL.Path
+		ctx.pathQuery = path
 		return
 	}
-	ctx.pathQuery = fmt.Sprintf("%s?%s", ctx.Request.URL.Path, ctx.Request.URL.RawQuery)
+	ctx.pathQuery = fmt.Sprintf("%s?%s", path, ctx.Request.URL.RawQuery)
 }
 
 func (ctx *signingCtx) buildCanonicalHeaders() {
@@ -239,22 +446,28 @@ func (ctx *signingCtx) buildCanonicalHeaders() {
 // as the request will be rejected by EdgeGrid.
 func (ctx *signingCtx) buildContentHash() {
 	var (
-		contentHash  string
-		preparedBody string
-		bodyBytes    []byte
+		contentHash string
+		bodyBytes   []byte
 	)
 
-	if ctx.Request.Body != nil {
+	if ctx.Request.Method != "POST" {
+		return
+	}
+
+	if ctx.Body != nil {
+		// The body given to the signer holds the same bytes as the request body, so they
+		// can be hashed without reading and copying the request body.
+		bodyBytes = ctx.Body
+	} else if ctx.Request.Body != nil {
 		bodyBytes, _ = ioutil.ReadAll(ctx.Request.Body)
 		ctx.Request.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
-		preparedBody = string(bodyBytes)
 	}
 
-	if ctx.Request.Method == "POST" && len(preparedBody) > 0 {
-		if len(preparedBody) > ctx.maxBody {
-			preparedBody = preparedBody[0:ctx.maxBody]
+	if len(bodyBytes) > 0 {
+		if len(bodyBytes) > ctx.maxBody {
+			bodyBytes = bodyBytes[0:ctx.maxBody]
 		}
-		h := sha256.Sum256([]byte(preparedBody))
+		h := sha256.Sum256(bodyBytes)
 		contentHash = base64.StdEncoding.EncodeToString(h[:])
 	}
 
@@ -292,3 +505,59 @@ func stringMinifier(in string) (out string) {
 	}
 	return
 }
+
+// VerifyRequest checks the EdgeGrid signature of a request received by a server, such
+// as a fake of the Akamai API, against the given credentials. Only the signature is
+// checked; the timestamp is not compared with the current time. The request body is
+// left readable.
+func VerifyRequest(req *http.Request, cc *credentials.Credentials) error {
+	creds, err := cc.Get()
+	if err != nil {
+		return err
+	}
+
+	auth := req.Header.Get("Authorization")
+	fields, ok := authFields(auth)
+	if !ok {
+		return fmt.Errorf("Authorization header is not an EdgeGrid signature")
+	}
+
+	if fields["client_token"] != creds.ClientToken || fields["access_token"] != creds.AccessToken {
+		return fmt.Errorf("Authorization header was signed for another client")
+	}
+
+	// Servers don't see the scheme and host in the request URL, but they are part of the
+	// signed data.
+	r := req.Clone(req.Context())
+	u := *req.URL
+	if u.Host == "" {
+		u.Host = req.Host
+	}
+	if u.Scheme == "" {
+		u.Scheme = "http"
+		if req.TLS != nil {
+			u.Scheme = "https"
+		}
+	}
+	r.URL = &u
+
+	ctx := &signingCtx{
+		Request:       r,
+		Query:         u.Query(),
+		credValues:    creds,
+		formattedTime: fields["timestamp"],
+		nonce:         fields["nonce"],
+		maxBody:       131072,
+	}
+
+	if err := ctx.build(); err != nil {
+		return err
+	}
+	req.Body = r.Body
+
+	if !hmac.Equal([]byte(ctx.signedAuthHeaders), []byte(auth)) {
+		return fmt.Errorf("Authorization header signature does not match the request")
+	}
+
+	return nil
+}
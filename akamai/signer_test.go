@@ -2,11 +2,15 @@ package akamai
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,6 +20,10 @@ import (
 var (
 	testFile = "../testdata/testdata.json"
 
+	// signingDataFile holds the fields of the data to sign of each test vector of
+	// testFile.
+	signingDataFile = "../testdata/testdata_signing.json"
+
 	akamaiTestHost         = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/"
 	akamaiTestAccessToken  = "akab-access-token-xxx-xxxxxxxxxxxxxxxx"
 	akamaiTestClientToken  = "akab-client-token-xxx-xxxxxxxxxxxxxxxx"
@@ -65,19 +73,76 @@ func TestBuildNonce(t *testing.T) {
 	}
 }
 
-func TestCreateAuthHeader(t *testing.T) {
-	var edgegrid JSONTests
-	byt, err := ioutil.ReadFile(testFile)
-	if err != nil {
-		t.Fatalf("Test file not found, err %s", err)
+// signingEntryPoints are the ways requests are signed with the EdgeGrid Signer, which
+// the test vectors are run against.
+var signingEntryPoints = []struct {
+	name string
+	sign func(s *Signer, req *http.Request, body []byte) error
+}{
+	{"Sign", func(s *Signer, req *http.Request, body []byte) error {
+		return s.Sign(req, bytes.NewReader(body))
+	}},
+	{"Sign/request body", func(s *Signer, req *http.Request, body []byte) error {
+		return s.Sign(req, nil)
+	}},
+	{"Client", func(s *Signer, req *http.Request, body []byte) error {
+		client, err := NewClient(nil, s.Credentials)
+		if err != nil {
+			return err
+		}
+		return client.WithSigner(s).signRequest(req, body)
+	}},
+}
+
+// signingFieldNames name the fields of the data to sign, for the diffs of
+// signingDataDiff.
+var signingFieldNames = []string{"method", "scheme", "host", "path and query", "canonical headers", "content hash", "authorization"}
+
+// signingDataDiff describes the fields of the data to sign that differ.
+func signingDataDiff(want, got []string) string {
+	var b strings.Builder
+	for i, name := range signingFieldNames {
+		var w, g string
+		if i < len(want) {
+			w = want[i]
+		}
+		if i < len(got) {
+			g = got[i]
+		}
+		if w != g {
+			fmt.Fprintf(&b, "\n\t%s:\n\t\twant %q\n\t\tgot  %q", name, w, g)
+		}
 	}
+	return b.String()
+}
 
-	err = json.Unmarshal(byt, &edgegrid)
-	if err != nil {
-		t.Fatalf("JSON is not parsable, err %s", err)
+// readSigningVectors reads the test vectors of testFile, and the data to sign of each
+// from signingDataFile.
+func readSigningVectors(t *testing.T) (edgegrid JSONTests, signingData map[string][]string) {
+	t.Helper()
+	for path, v := range map[string]interface{}{testFile: &edgegrid, signingDataFile: &signingData} {
+		byt, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Test file not found, err %s", err)
+		}
+		if err := json.Unmarshal(byt, v); err != nil {
+			t.Fatalf("JSON of %s is not parsable, err %s", path, err)
+		}
 	}
+	return edgegrid, signingData
+}
 
-	url, err := url.Parse(akamaiTestHost)
+// TestSign runs the EdgeGrid test vectors of testdata.json against each of the
+// signingEntryPoints. The official vectors come first; those after the "PUT test"
+// cover trailing slashes, query encoding, header canonicalization and bodies of other
+// methods than POST, with signatures computed from the EdgeGrid specification
+// independently of this package. The vectors only hold the expected Authorization
+// headers; signingDataFile holds the data to sign of each, so that a failure shows which
+// of its fields differs rather than an opaque signature.
+func TestSign(t *testing.T) {
+	edgegrid, signingData := readSigningVectors(t)
+
+	base, err := url.Parse(akamaiTestHost)
 	if err != nil {
 		t.Fatalf("URL is not parsable, err %s", err)
 	}
@@ -89,32 +154,297 @@ func TestCreateAuthHeader(t *testing.T) {
 		Host:         akamaiTestHost,
 	})
 
-	signer := NewSigner(creds)
+	for _, entry := range signingEntryPoints {
+		for _, edge := range edgegrid.Tests {
+			entry, edge := entry, edge
+			t.Run(entry.name+"/"+edge.Name, func(t *testing.T) {
+				// The paths of the test vectors may hold a query string.
+				u, err := base.Parse(edge.Request.Path)
+				if err != nil {
+					t.Fatalf("URL is not parsable, err %s", err)
+				}
+				var body []byte
+				var r io.Reader
+				if edge.Request.Data != "" {
+					body = []byte(edge.Request.Data)
+					r = bytes.NewReader(body)
+				}
+				req, err := http.NewRequest(edge.Request.Method, u.String(), r)
+				if err != nil {
+					t.Fatalf("expect nil, got %v", err)
+				}
+				for _, header := range edge.Request.Headers {
+					for k, v := range header {
+						req.Header.Set(k, v)
+					}
+				}
 
-	for _, edge := range edgegrid.Tests {
-		url.Path = edge.Request.Path
-		req, _ := http.NewRequest(
-			edge.Request.Method,
-			url.String(),
-			bytes.NewBuffer([]byte(edge.Request.Data)),
-		)
-
-		for _, header := range edge.Request.Headers {
-			for k, v := range header {
-				req.Header.Set(k, v)
-			}
+				signer := &Signer{
+					Credentials:   creds,
+					HeadersToSign: headersToSign,
+					MaxBody:       2048,
+					Timestamp:     timestamp,
+					Nonce:         nonce,
+				}
+				if err := entry.sign(signer, req, body); err != nil {
+					t.Fatalf("expect nil, got %v", err)
+				}
+				if req.Header.Get("Authorization") == edge.ExpectedAuthorization {
+					return
+				}
+
+				// The signature differs: show the data that was signed.
+				ctx, err := signer.signingCtx(req, body)
+				if err != nil {
+					t.Fatalf("expect nil, got %v", err)
+				}
+				if err := ctx.build(); err != nil {
+					t.Fatalf("expect nil, got %v", err)
+				}
+				fields := ctx.signingFields()
+				want, ok := signingData[edge.Name]
+				if !ok {
+					t.Fatalf("the Authorization header is %q, want %q, and %s holds no data to sign for the vector",
+						req.Header.Get("Authorization"), edge.ExpectedAuthorization, signingDataFile)
+				}
+				t.Errorf("the Authorization header is %q, want %q; the data to sign differs in:%s",
+					req.Header.Get("Authorization"), edge.ExpectedAuthorization, signingDataDiff(want, fields[:]))
+			})
 		}
-		signer.Timestamp = timestamp
-		signer.Nonce = nonce
-		signer.MaxBody = 2048
-		signer.HeadersToSign = headersToSign
+	}
+}
 
-		signer.Sign(req, bytes.NewReader([]byte(edge.Request.Data)))
+// TestSigningData checks that the data to sign of signingDataFile is that of the test
+// vectors, by signing it as each vector is.
+func TestSigningData(t *testing.T) {
+	edgegrid, signingData := readSigningVectors(t)
 
-		if assert.Equal(t, edge.ExpectedAuthorization, req.Header.Get("Authorization")) {
-			t.Logf("Pass: %s\n", edge.Name)
-			t.Logf("Expected: %s - Actual %s", edge.ExpectedAuthorization, req.Header.Get("Authorization"))
+	assert.Len(t, signingData, len(edgegrid.Tests))
+	for _, edge := range edgegrid.Tests {
+		fields, ok := signingData[edge.Name]
+		if !assert.True(t, ok, edge.Name) {
+			continue
 		}
+		key := createSignature(timestamp, akamaiTestClientSecret)
+		signature := createSignature(strings.Join(fields, "\t"), key)
+		assert.Equal(t, edge.ExpectedAuthorization, fields[len(fields)-1]+"signature="+signature, edge.Name)
+	}
+}
+
+func TestVerifyRequest(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var verified []error
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		verified = append(verified, VerifyRequest(r, client.Credentials))
+
+		r.URL.RawQuery = "contractId=1-EVIL"
+		verified = append(verified, VerifyRequest(r, client.Credentials))
+
+		other := credentials.NewStaticCredentials(akamaiTestClientSecret, "akab-other-client-token", akamaiTestAccessToken, r.Host)
+		verified = append(verified, VerifyRequest(r, other))
+	})
+
+	req, err := client.NewRequest("POST", "config-dns/v2/zones?contractId=1-ABCDE", map[string]string{"zone": "example.com"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if assert.Len(t, verified, 3) {
+		assert.NoError(t, verified[0])
+		assert.Error(t, verified[1])
+		assert.Error(t, verified[2])
+	}
+}
+
+// bodySigner signs requests with a header holding their body, read from the body given
+// to the signer.
+type bodySigner struct{}
+
+func (bodySigner) Sign(req *http.Request, body io.ReadSeeker) error {
+	if body == nil {
+		req.Header.Set("X-Signed-Body", "")
+		return nil
+	}
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Signed-Body", strings.TrimSpace(string(b)))
+	_, err = body.Seek(0, io.SeekStart)
+	return err
+}
+
+func TestWithSigner(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		signer RequestSigner
+		want   map[string]string
+	}{
+		{"edgegrid", nil, map[string]string{"Authorization": "EG1-HMAC-SHA256 client_token=" + akamaiTestClientToken}},
+		{"noop", NoopSigner{}, map[string]string{"Authorization": ""}},
+		{"static", NewStaticHeaderSigner("Authorization", "Bearer gateway-token"), map[string]string{"Authorization": "Bearer gateway-token"}},
+		{"body", bodySigner{}, map[string]string{"Authorization": "", "X-Signed-Body": `{"name":"example.com"}`}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			client.WithSigner(tt.signer)
+
+			var got http.Header
+			var body []byte
+			mux.HandleFunc("/signed", func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header
+				body, _ = ioutil.ReadAll(r.Body)
+			})
+
+			req, err := client.NewRequest("POST", "signed", map[string]string{"name": "example.com"})
+			if err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+			if _, err := client.Do(context.Background(), req, nil); err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+
+			for k, v := range tt.want {
+				if strings.HasPrefix(v, "EG1-") {
+					assert.True(t, strings.HasPrefix(got.Get(k), v), "%s: %s", k, got.Get(k))
+					continue
+				}
+				assert.Equal(t, v, got.Get(k), k)
+			}
+			assert.Equal(t, `{"name":"example.com"}`, strings.TrimSpace(string(body)), "the request body is sent whole")
+		})
+	}
+}
+
+func TestWithSignerAccountSwitch(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.WithSigner(NewStaticHeaderSigner("X-Gateway-Key", "key"))
+
+	var got http.Header
+	mux.HandleFunc("/signed", func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	})
+
+	req, err := client.NewRequest("GET", "signed", nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	req.Header.Del("X-Gateway-Key")
+
+	// Do signs the request again for the account.
+	if _, err := client.Do(WithAccountSwitchKey(context.Background(), "1-ABCDE"), req, nil); err != nil {
+		t.Fatalf("expect nil, got %v", err)
 	}
+	assert.Equal(t, "key", got.Get("X-Gateway-Key"))
+	assert.Equal(t, "", got.Get("Authorization"))
+}
+
+func TestDebugSigningString(t *testing.T) {
+	edgegrid, signingData := readSigningVectors(t)
+	base, err := url.Parse(akamaiTestHost)
+	if err != nil {
+		t.Fatalf("URL is not parsable, err %s", err)
+	}
+	creds := credentials.NewStaticCredentials(akamaiTestClientSecret, akamaiTestClientToken, akamaiTestAccessToken, akamaiTestHost)
+	signingKey := createSignature(timestamp, akamaiTestClientSecret)
+
+	for _, edge := range edgegrid.Tests {
+		edge := edge
+		t.Run(edge.Name, func(t *testing.T) {
+			want := strings.Join(signingData[edge.Name], "\t")
+			newRequest := func() *http.Request {
+				u, err := base.Parse(edge.Request.Path)
+				if err != nil {
+					t.Fatalf("URL is not parsable, err %s", err)
+				}
+				req, err := http.NewRequest(edge.Request.Method, u.String(), strings.NewReader(edge.Request.Data))
+				if err != nil {
+					t.Fatalf("expect nil, got %v", err)
+				}
+				for _, header := range edge.Request.Headers {
+					for k, v := range header {
+						req.Header.Set(k, v)
+					}
+				}
+				return req
+			}
 
+			// The timestamp and nonce of an unsigned request are those of the signer.
+			signer := &Signer{Credentials: creds, HeadersToSign: headersToSign, MaxBody: 2048, Timestamp: timestamp, Nonce: nonce}
+			req := newRequest()
+			got, err := signer.DebugSigningString(req, strings.NewReader(edge.Request.Data))
+			if err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+			assert.Equal(t, want, got)
+			assert.Empty(t, req.Header.Get("Authorization"), "the request is not signed")
+
+			// Those of a signed request are those it was signed with, and its body is
+			// left readable.
+			req = newRequest()
+			if err := signer.Sign(req, nil); err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+			other := &Signer{Credentials: creds, HeadersToSign: headersToSign, MaxBody: 2048}
+			got, err = other.DebugSigningString(req, nil)
+			if err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+			assert.Equal(t, want, got)
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.Equal(t, edge.Request.Data, string(body))
+
+			assert.NotContains(t, got, akamaiTestClientSecret)
+			assert.NotContains(t, got, signingKey)
+			assert.NotContains(t, got, "signature=")
+		})
+	}
+}
+
+func TestWithSigningDebug(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var debugged []string
+	client.WithSigningDebug(func(req *http.Request, signingString string) {
+		debugged = append(debugged, signingString)
+	})
+
+	var verified error
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		verified = VerifyRequest(r, client.Credentials)
+	})
+
+	req, err := client.NewRequest("POST", "config-dns/v2/zones?contractId=1-ABCDE", map[string]string{"zone": "example.com"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.NoError(t, verified)
+
+	if assert.Len(t, debugged, 1) {
+		fields := strings.Split(debugged[0], "\t")
+		assert.Equal(t, []string{"POST", "http", req.URL.Host, "/config-dns/v2/zones?contractId=1-ABCDE"}, fields[:4])
+
+		// The data ends with the Authorization header, up to its signature.
+		auth := req.Header.Get("Authorization")
+		assert.Equal(t, auth[:strings.Index(auth, "signature=")], fields[len(fields)-1])
+		assert.NotContains(t, debugged[0], akamaiTestClientSecret)
+	}
+
+	// Requests signed by other signers are not debugged.
+	client.WithSigner(NoopSigner{})
+	if _, err := client.NewRequest("GET", "config-dns/v2/zones", nil); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, debugged, 1)
 }
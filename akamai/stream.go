@@ -0,0 +1,116 @@
+package akamai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ArrayStream decodes a JSON object response holding a large array element by element,
+// as it is read, rather than into memory at once. Given to Client.Do as v, it hands the
+// elements of the array under Field to its callback, and decodes the other fields of
+// the object, such as its metadata, into Object.
+//
+// An error of the callback stops the decode, and Do returns it as is, closing the
+// response without reading the rest of it.
+type ArrayStream struct {
+	// Field is the key of the array in the response object, such as "recordsets".
+	Field string
+
+	// Object, if not nil, is a pointer the other fields of the response object are
+	// decoded into, as json.Unmarshal does.
+	Object interface{}
+
+	element func(dec *json.Decoder) error
+}
+
+// NewArrayStream returns an ArrayStream calling fn with the elements of the array under
+// field, decoded as T, and decoding the other fields into object.
+func NewArrayStream[T any](field string, object interface{}, fn func(T) error) *ArrayStream {
+	return &ArrayStream{
+		Field:  field,
+		Object: object,
+		element: func(dec *json.Decoder) error {
+			var el T
+			if err := dec.Decode(&el); err != nil {
+				return err
+			}
+			return fn(el)
+		},
+	}
+}
+
+// decode reads the response object from dec. The fields other than the array are
+// kept raw until the end of the object, and then decoded into Object.
+func (s *ArrayStream) decode(dec *json.Decoder) error {
+	t, err := dec.Token()
+	if err == io.EOF {
+		return nil // an empty body, as decodeBody ignores it
+	}
+	if err != nil {
+		return err
+	}
+	if t != json.Delim('{') {
+		return fmt.Errorf("streamed response is not a JSON object")
+	}
+
+	var rest bytes.Buffer
+	rest.WriteByte('{')
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := t.(string)
+
+		if key == s.Field {
+			if err := s.decodeArray(dec); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		if rest.Len() > 1 {
+			rest.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		rest.Write(k)
+		rest.WriteByte(':')
+		rest.Write(raw)
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	rest.WriteByte('}')
+
+	if s.Object == nil {
+		return nil
+	}
+	return json.Unmarshal(rest.Bytes(), s.Object)
+}
+
+func (s *ArrayStream) decodeArray(dec *json.Decoder) error {
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return nil // null
+	}
+	if t != json.Delim('[') {
+		return fmt.Errorf("streamed field %q is not a JSON array", s.Field)
+	}
+
+	for dec.More() {
+		if err := s.element(dec); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
@@ -0,0 +1,190 @@
+package akamai
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeRecordSets writes a record sets response of n elements, with the metadata after
+// them, stopping early if the client goes away.
+func writeRecordSets(w http.ResponseWriter, r *http.Request, n, total int) {
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, `{"recordsets": [`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		fmt.Fprintf(bw, `{"name": "host%d.example.com", "type": "A", "ttl": 300, "rdata": ["192.0.2.%d"]}`, i, i%256)
+		if i%1000 == 0 && r.Context().Err() != nil {
+			return
+		}
+	}
+	fmt.Fprintf(bw, `], "metadata": {"zone": "example.com", "totalElements": %d, "showAll": true}}`, total)
+	bw.Flush()
+}
+
+func TestForEachRecordSet(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/zones/example.com/recordsets", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "true", r.URL.Query().Get("showAll"))
+		writeRecordSets(w, r, 3, 3)
+	})
+
+	var names []string
+	err := client.FastDNSv2.ForEachRecordSet(context.Background(), "example.com", nil, func(rs *RecordSet) error {
+		names = append(names, rs.GetName())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"host0.example.com", "host1.example.com", "host2.example.com"}, names)
+}
+
+func TestForEachRecordSetTruncated(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// The showAll response is truncated, so the record sets are listed again page by
+	// page.
+	mux.HandleFunc("/config-dns/v2/zones/example.com/recordsets", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("showAll") == "true" {
+			writeRecordSets(w, r, 2, 5)
+			return
+		}
+		page, _ := strconv.Atoi(q.Get("page"))
+		pageSize, _ := strconv.Atoi(q.Get("pageSize"))
+		assert.Equal(t, listAllPageSize, pageSize)
+
+		fmt.Fprint(w, `{"recordsets": [`)
+		for i := (page - 1) * pageSize; i < page*pageSize && i < 5; i++ {
+			if i > (page-1)*pageSize {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"name": "host%d.example.com", "type": "A", "ttl": 300, "rdata": ["192.0.2.1"]}`, i)
+		}
+		fmt.Fprint(w, `], "metadata": {"totalElements": 5}}`)
+	})
+
+	// The record sets of the truncated response are not handed twice.
+	var names []string
+	err := client.FastDNSv2.ForEachRecordSet(context.Background(), "example.com", nil, func(rs *RecordSet) error {
+		names = append(names, rs.GetName())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []string{"host0.example.com", "host1.example.com", "host2.example.com", "host3.example.com", "host4.example.com"}, names)
+}
+
+func TestArrayStreamMetadata(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/zones/example.com/recordsets", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"metadata": {"zone": "example.com", "totalElements": 1}, "recordsets": [{"name": "www.example.com", "type": "A"}], "extra": null}`)
+	})
+
+	var list ListZoneRecordSets
+	var got []*RecordSet
+	stream := NewArrayStream("recordsets", &list, func(rs *RecordSet) error {
+		got = append(got, rs)
+		return nil
+	})
+	req, _ := client.NewRequest("GET", "config-dns/v2/zones/example.com/recordsets", nil)
+	if _, err := client.Do(context.Background(), req, stream); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "example.com", list.Metadata.GetZone())
+	assert.Equal(t, 1, list.Metadata.GetTotalElements())
+	assert.Nil(t, list.RecordSets)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "www.example.com", got[0].GetName())
+	}
+}
+
+func TestForEachRecordSetMemory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("decodes 100,000 record sets")
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	const n = 100000
+	mux.HandleFunc("/config-dns/v2/zones/example.com/recordsets", func(w http.ResponseWriter, r *http.Request) {
+		writeRecordSets(w, r, n, n)
+	})
+
+	// The heap in use is sampled as the record sets are handed over. It only grows by
+	// the names and types kept to skip duplicates, a few MB, rather than with the
+	// response, which is about 10MB and takes several times that once decoded.
+	var ms runtime.MemStats
+	var first, peak uint64
+	count := 0
+	err := client.FastDNSv2.ForEachRecordSet(context.Background(), "example.com", nil, func(rs *RecordSet) error {
+		count++
+		if count%10000 == 0 {
+			runtime.GC()
+			runtime.ReadMemStats(&ms)
+			if first == 0 {
+				first = ms.HeapAlloc
+			}
+			if ms.HeapAlloc > peak {
+				peak = ms.HeapAlloc
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, n, count)
+	t.Logf("heap after 10,000 record sets: %d bytes, peak: %d bytes", first, peak)
+	assert.True(t, peak < first+8<<20, "heap grew from %d to %d bytes", first, peak)
+}
+
+func TestForEachRecordSetCallbackError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	done := make(chan struct{})
+	mux.HandleFunc("/config-dns/v2/zones/example.com/recordsets", func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		// More than the client will read, so that the response is still being written
+		// when the callback fails.
+		writeRecordSets(w, r, 1000000, 1000000)
+	})
+
+	errStop := errors.New("stop")
+	count := 0
+	err := client.FastDNSv2.ForEachRecordSet(context.Background(), "example.com", nil, func(rs *RecordSet) error {
+		if count++; count == 10 {
+			return errStop
+		}
+		return nil
+	})
+	assert.True(t, err == errStop, "got %v", err)
+	assert.Equal(t, 10, count)
+
+	// The response is closed without being read to its end, which ends the handler.
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the response was not closed")
+	}
+}
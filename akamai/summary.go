@@ -0,0 +1,57 @@
+package akamai
+
+import (
+	"context"
+)
+
+// ZoneSummary describes a zone and the record sets it holds.
+type ZoneSummary struct {
+	Zone     string
+	Metadata *ZoneMetadata
+
+	// RecordSets is the number of record sets in the zone, and TypeCounts the number
+	// of record sets of each type.
+	RecordSets int
+	TypeCounts map[string]int
+
+	// Err holds the error that prevented the zone from being summarized.
+	Err error
+}
+
+// SummarizeZones retrieves the metadata and record set counts of zones, concurrently as
+// configured by opt. The summaries are in the order of zones; a zone that could not be
+// summarized has its Err set rather than failing the others.
+func (s *FastDNSv2Service) SummarizeZones(ctx context.Context, zones []string, opt *BulkOptions) []*ZoneSummary {
+	results := RunBulk(ctx, zones, opt, func(ctx context.Context, zone string) (*ZoneSummary, error) {
+		zm, _, err := s.GetZone(ctx, zone)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := &ZoneSummary{Zone: zone, Metadata: zm, TypeCounts: map[string]int{}}
+		if zm.GetType() == "ALIAS" {
+			return sum, nil
+		}
+
+		list, err := s.ListAllZoneRecordSets(ctx, zone, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, rs := range list {
+			sum.RecordSets++
+			sum.TypeCounts[rs.GetType()]++
+		}
+
+		return sum, nil
+	})
+
+	summaries := make([]*ZoneSummary, len(zones))
+	for i, r := range results {
+		summaries[i] = r.Value
+		if r.Err != nil {
+			summaries[i] = &ZoneSummary{Zone: zones[i], Err: r.Err}
+		}
+	}
+
+	return summaries
+}
@@ -0,0 +1,377 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SyncAction is the kind of change a SyncPlan makes to a record set.
+type SyncAction string
+
+// Actions of the changes in a SyncPlan.
+const (
+	SyncCreate SyncAction = "create"
+	SyncUpdate SyncAction = "update"
+	SyncDelete SyncAction = "delete"
+)
+
+// SyncOptions specifies the optional parameters to the record set sync methods.
+type SyncOptions struct {
+	// Prune deletes the record sets of the zone that are not desired. Without it, only
+	// the desired record sets are managed, and record sets are only deleted when a
+	// tombstone, a desired record set with Ensure set to EnsureAbsent, asks for it. The
+	// SOA and apex NS record sets, and those of the ownership registry, are never
+	// deleted.
+	Prune bool
+
+	// Bulk configures the concurrency with which ApplySyncPlan makes its changes.
+	Bulk *BulkOptions
+
+	// Owner, if set, restricts the sync to the record sets Owner claims in the
+	// ownership registry (see ClaimRecord): changing or tombstoning a record set claimed
+	// by another owner, or not claimed at all, fails the plan with a *RecordOwnedError,
+	// and Prune only deletes the record sets Owner claims. ApplySyncPlan claims the
+	// record sets it creates or updates, and releases those it deletes.
+	Owner string
+
+	// AdoptUnowned lets a sync with an Owner change the record sets that no owner
+	// claims, which it then claims.
+	AdoptUnowned bool
+
+	// Attribute makes PlanRecordSets tell who changed the record sets it would update or
+	// delete, in the Attribution of their changes, so that drift made out-of-band can be
+	// looked into before it is overwritten. It costs a GetZone, and a GetRecordHistory
+	// for each such record set.
+	Attribute bool
+}
+
+// SyncChange is a single change of a SyncPlan.
+type SyncChange struct {
+	Action SyncAction
+	Name   string
+	Type   string
+
+	// Current is the record set as it exists in the zone. It is nil for creates.
+	Current *RecordSet
+
+	// Desired is the record set as it should be. It is nil for deletes.
+	Desired *RecordSetCreateRequest
+
+	// TTLDefaulted reports that the TTL of Desired is the default of its type, as the
+	// desired record set had none. See FastDNSv2Service.WithDefaultTTLPolicy.
+	TTLDefaulted bool
+
+	// Attribution tells who changed the zone and the record set, for the updates and
+	// deletes of a plan made with SyncOptions.Attribute. It is nil otherwise.
+	Attribution *ChangeAttribution
+
+	// Skipped reports that the change is not made because the zone is protected. See
+	// Client.WithProtectedZones.
+	Skipped bool
+
+	// Err holds the error of the change once ApplySyncPlan has tried to make it.
+	Err error
+}
+
+// SyncPlan holds the changes needed to bring the record sets of a zone to the desired
+// state, sorted by name and type.
+type SyncPlan struct {
+	Zone      string
+	Changes   []*SyncChange
+	Unchanged int
+
+	// UnchangedTypes counts the unchanged record sets by type.
+	UnchangedTypes map[string]int
+}
+
+// Empty reports whether the zone is already in the desired state, or has no changes
+// that aren't skipped.
+func (p *SyncPlan) Empty() bool {
+	for _, c := range p.Changes {
+		if !c.Skipped {
+			return false
+		}
+	}
+	return true
+}
+
+// SyncError is returned by ApplySyncPlan when some of the changes failed. The error of
+// each change is recorded in its Err field.
+type SyncError struct {
+	Zone   string
+	Failed []*SyncChange
+}
+
+func (e *SyncError) Error() string {
+	return fmt.Sprintf("%d record set changes to zone %v failed, first: %v %v %v: %v",
+		len(e.Failed), e.Zone, e.Failed[0].Action, e.Failed[0].Name, e.Failed[0].Type, e.Failed[0].Err)
+}
+
+// PlanRecordSets compares the record sets of a zone with the desired ones, and returns
+// the changes needed to go from one to the other. Nothing is changed. The changes to a
+// protected zone are marked as skipped. The record sets to create or update, and the
+// tombstones of record sets to delete, are checked against the client's record
+// policies, whose first violation fails the plan. The record sets to create or update
+// over the client's RdataLimits fail it with a *TooManyRdataError, rather than the sync
+// that would apply it.
+// The desired record sets without a TTL are given the default of their type, as set with
+// FastDNSv2Service.WithDefaultTTLPolicy, before they are compared and checked.
+//
+// The desired record sets whose Ensure is EnsureAbsent are tombstones: they are deleted
+// if they exist, whether or not opt.Prune is set, and ignored otherwise. Only their zone,
+// name and type are read. The SOA and apex NS record sets can't be tombstoned.
+func (s *FastDNSv2Service) PlanRecordSets(ctx context.Context, zone string, desired []*RecordSetCreateRequest, opt *SyncOptions) (*SyncPlan, error) {
+	if opt == nil {
+		opt = &SyncOptions{}
+	}
+
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, wrapOp("PlanRecordSets", zone, "", "", err)
+	}
+
+	list, err := s.ListAllZoneRecordSets(ctx, zone, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	current := map[string]*RecordSet{}
+	for _, rs := range list {
+		current[syncKey(rs.GetName(), rs.GetType())] = rs
+	}
+	var claims map[string]string
+	if opt.Owner != "" {
+		claims = zoneClaims(list)
+	}
+
+	plan := &SyncPlan{Zone: zone, UnchangedTypes: map[string]int{}}
+	wanted := map[string]bool{}
+	for _, d := range desired {
+		name, err := s.recordName(d.Name)
+		if err != nil {
+			return nil, wrapOp("PlanRecordSets", zone, d.Name, d.Type, err)
+		}
+
+		key := syncKey(name, d.Type)
+		if wanted[key] {
+			return nil, fmt.Errorf("record set %v %v is desired more than once", d.Name, d.Type)
+		}
+		wanted[key] = true
+
+		rs := *d
+		rs.Zone = zone
+		rs.Name = name
+		cur, ok := current[key]
+		if d.Ensure == EnsureAbsent {
+			if !ok {
+				continue
+			}
+			if isProtectedRecordSet(zone, cur) {
+				return nil, wrapOp("PlanRecordSets", zone, name, d.Type, fmt.Errorf("the %v record set of the zone apex can't be deleted", strings.ToUpper(d.Type)))
+			}
+			if err := checkOwner(zone, cur, claims, opt); err != nil {
+				return nil, err
+			}
+			rs.TTL, rs.Rdata = Int(cur.GetTTL()), currentRdata(cur)
+			if err := s.checkRecordPolicies(&rs); err != nil {
+				return nil, wrapOp("PlanRecordSets", zone, name, d.Type, err)
+			}
+			plan.Changes = append(plan.Changes, &SyncChange{Action: SyncDelete, Name: cur.GetName(), Type: cur.GetType(), Current: cur})
+			continue
+		}
+		defaulted := s.applyDefaultTTL(&rs)
+		if err := checkTTL(rs.TTL); err != nil {
+			return nil, wrapOp("PlanRecordSets", zone, name, d.Type, err)
+		}
+		if !ok || !recordSetEqual(cur, &rs) {
+			if err := s.checkRdataLimits(&rs); err != nil {
+				return nil, wrapOp("PlanRecordSets", zone, name, d.Type, err)
+			}
+			if err := s.checkRecordPolicies(&rs); err != nil {
+				return nil, wrapOp("PlanRecordSets", zone, name, d.Type, err)
+			}
+		}
+		if ok && !recordSetEqual(cur, &rs) {
+			if err := checkOwner(zone, cur, claims, opt); err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case !ok:
+			plan.Changes = append(plan.Changes, &SyncChange{Action: SyncCreate, Name: name, Type: d.Type, Desired: &rs, TTLDefaulted: defaulted})
+		case !recordSetEqual(cur, &rs):
+			plan.Changes = append(plan.Changes, &SyncChange{Action: SyncUpdate, Name: name, Type: d.Type, Current: cur, Desired: &rs, TTLDefaulted: defaulted})
+		default:
+			plan.Unchanged++
+			plan.UnchangedTypes[strings.ToUpper(d.Type)]++
+		}
+	}
+
+	if opt.Prune {
+		for key, cur := range current {
+			if wanted[key] || isProtectedRecordSet(zone, cur) || isRegistryName(cur.GetName()) {
+				continue
+			}
+			if opt.Owner != "" && claims[key] != opt.Owner {
+				continue
+			}
+			plan.Changes = append(plan.Changes, &SyncChange{Action: SyncDelete, Name: cur.GetName(), Type: cur.GetType(), Current: cur})
+		}
+	}
+
+	if s.client.ZoneProtected(zone) {
+		for _, c := range plan.Changes {
+			c.Skipped = true
+		}
+	}
+
+	sort.Slice(plan.Changes, func(i, j int) bool {
+		a, b := plan.Changes[i], plan.Changes[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Type < b.Type
+	})
+
+	if opt.Attribute {
+		if err := s.attributeChanges(ctx, plan, opt); err != nil {
+			return nil, wrapOp("PlanRecordSets", zone, "", "", err)
+		}
+	}
+
+	return plan, nil
+}
+
+// ApplySyncPlan makes the changes of a plan, concurrently as configured by opt.Bulk. All
+// changes are attempted even if some fail; a *SyncError lists the failed ones. Skipped
+// changes are not made, and all the changes to a protected zone are marked as skipped.
+//
+// With opt.Owner set, the record sets that were created or updated are then claimed for
+// it in the ownership registry, and those that were deleted released; a failure to do so
+// is recorded as the error of the change.
+func (s *FastDNSv2Service) ApplySyncPlan(ctx context.Context, plan *SyncPlan, opt *SyncOptions) error {
+	if opt == nil {
+		opt = &SyncOptions{}
+	}
+
+	protected := s.client.ZoneProtected(plan.Zone)
+	var changes []*SyncChange
+	for _, c := range plan.Changes {
+		if protected {
+			c.Skipped = true
+		}
+		if !c.Skipped {
+			changes = append(changes, c)
+		}
+	}
+
+	ctx, unlock, err := s.lockZone(ctx, plan.Zone)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	results := RunBulk(ctx, changes, opt.Bulk, func(ctx context.Context, c *SyncChange) (struct{}, error) {
+		var err error
+		switch c.Action {
+		case SyncCreate:
+			_, _, err = s.CreateRecordSet(ctx, c.Desired)
+		case SyncUpdate:
+			_, _, err = s.UpdateRecordSet(ctx, c.Desired)
+		case SyncDelete:
+			_, err = s.DeleteRecordSet(ctx, &RecordSetOptions{Zone: plan.Zone, Name: c.Name, Type: c.Type})
+		default:
+			err = fmt.Errorf("unknown sync action %q", c.Action)
+		}
+		return struct{}{}, err
+	})
+
+	var failed []*SyncChange
+	for i, r := range results {
+		c := changes[i]
+		c.Err = r.Err
+		if c.Err == nil && opt.Owner != "" {
+			if c.Action == SyncDelete {
+				c.Err = s.ReleaseRecord(ctx, plan.Zone, c.Name, c.Type, opt.Owner)
+			} else {
+				c.Err = s.ClaimRecord(ctx, plan.Zone, c.Name, c.Type, opt.Owner)
+			}
+		}
+		if c.Err != nil {
+			failed = append(failed, c)
+		}
+	}
+	if len(failed) > 0 {
+		return &SyncError{Zone: plan.Zone, Failed: failed}
+	}
+
+	return nil
+}
+
+// SyncRecordSets brings the record sets of a zone to the desired state. It plans the
+// changes with PlanRecordSets and makes them with ApplySyncPlan, and returns the plan
+// whose changes hold their individual errors.
+//
+// The zone is locked in the client's ZoneLocks from the planning to the last change.
+//
+// If the client is in read-only mode, the changes are not made: the plan is returned
+// with ErrReadOnlyMode, unless it is empty.
+func (s *FastDNSv2Service) SyncRecordSets(ctx context.Context, zone string, desired []*RecordSetCreateRequest, opt *SyncOptions) (*SyncPlan, error) {
+	ctx, unlock, err := s.lockZone(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	plan, err := s.PlanRecordSets(ctx, zone, desired, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.client.ReadOnly() {
+		if plan.Empty() {
+			return plan, nil
+		}
+		return plan, ErrReadOnlyMode
+	}
+
+	return plan, s.ApplySyncPlan(ctx, plan, opt)
+}
+
+func syncKey(name, rtype string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "/" + strings.ToUpper(rtype)
+}
+
+// recordSetEqual reports whether a record set already matches the desired one. The
+// order of rdata is not significant, and rdata is compared as RecordSetEqual does.
+func recordSetEqual(cur *RecordSet, d *RecordSetCreateRequest) bool {
+	return cur.GetTTL() == d.GetTTL() && RecordSetEqual(d.Type, currentRdata(cur), d.Rdata)
+}
+
+// checkOwner returns a *RecordOwnedError if a sync with an owner may not change the
+// record set cur, given the claims of the zone.
+func checkOwner(zone string, cur *RecordSet, claims map[string]string, opt *SyncOptions) error {
+	if opt.Owner == "" {
+		return nil
+	}
+	holder := claims[syncKey(cur.GetName(), cur.GetType())]
+	if holder == opt.Owner || holder == "" && opt.AdoptUnowned {
+		return nil
+	}
+	return &RecordOwnedError{Zone: zone, Name: cur.GetName(), Type: strings.ToUpper(cur.GetType()), Owner: opt.Owner, Holder: holder}
+}
+
+// isProtectedRecordSet reports whether a record set is managed by Akamai and must not be
+// pruned.
+func isProtectedRecordSet(zone string, rs *RecordSet) bool {
+	apex := strings.EqualFold(strings.TrimSuffix(rs.GetName(), "."), strings.TrimSuffix(zone, "."))
+	switch strings.ToUpper(rs.GetType()) {
+	case "SOA":
+		return true
+	case RRTypeNs:
+		return apex
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+package akamai_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func newSyncTestServer(t *testing.T) (*akamai.Client, *akamaitest.Server) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	for _, rs := range []*akamai.RecordSetCreateRequest{
+		{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1", "192.0.2.2"}},
+		{Zone: "example.com", Name: "mail.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.10"}},
+		{Zone: "example.com", Name: "old.example.com", Type: "CNAME", TTL: akamai.Int(300), Rdata: []string{"www.example.com."}},
+	} {
+		srv.AddRecordSet(rs)
+	}
+	return client, srv
+}
+
+var syncDesired = []*akamai.RecordSetCreateRequest{
+	{Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.2", "192.0.2.1"}},
+	{Name: "mail.example.com", Type: "A", TTL: akamai.Int(600), Rdata: []string{"192.0.2.10"}},
+	{Name: "api.example.com", Type: "CNAME", TTL: akamai.Int(300), Rdata: []string{"www.example.com."}},
+}
+
+func TestPlanRecordSets(t *testing.T) {
+	client, _ := newSyncTestServer(t)
+
+	plan, err := client.FastDNSv2.PlanRecordSets(context.Background(), "example.com", syncDesired, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 1, plan.Unchanged)
+	assert.Equal(t, map[string]int{"A": 1}, plan.UnchangedTypes)
+	if assert.Len(t, plan.Changes, 2) {
+		assert.Equal(t, akamai.SyncCreate, plan.Changes[0].Action)
+		assert.Equal(t, "api.example.com", plan.Changes[0].Name)
+		assert.Equal(t, "example.com", plan.Changes[0].Desired.Zone)
+		assert.Equal(t, akamai.SyncUpdate, plan.Changes[1].Action)
+		assert.Equal(t, 300, plan.Changes[1].GetCurrent().GetTTL())
+	}
+
+	plan, err = client.FastDNSv2.PlanRecordSets(context.Background(), "example.com", syncDesired, &akamai.SyncOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, plan.Changes, 3) {
+		assert.Equal(t, akamai.SyncDelete, plan.Changes[2].Action)
+		assert.Equal(t, "old.example.com", plan.Changes[2].Name)
+	}
+
+	_, err = client.FastDNSv2.PlanRecordSets(context.Background(), "example.com", append(syncDesired, syncDesired[0]), nil)
+	assert.Error(t, err)
+}
+
+func TestPlanRecordSetsAttribution(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+	ctx := context.Background()
+
+	// The record set drifts out-of-band.
+	srv.ModifiedBy = "mallory"
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.9"}})
+	zone := srv.Zone("example.com")
+
+	plan, err := client.FastDNSv2.PlanRecordSets(ctx, "example.com", syncDesired, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	for _, c := range plan.Changes {
+		assert.Nil(t, c.Attribution, c.Name)
+	}
+
+	plan, err = client.FastDNSv2.PlanRecordSets(ctx, "example.com", syncDesired, &akamai.SyncOptions{Attribute: true, Prune: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	changes := map[string]*akamai.SyncChange{}
+	for _, c := range plan.Changes {
+		changes[c.Name] = c
+	}
+	assert.Nil(t, changes["api.example.com"].Attribution)
+	for _, name := range []string{"mail.example.com", "old.example.com", "www.example.com"} {
+		a := changes[name].GetAttribution()
+		if assert.NotNil(t, a, name) {
+			assert.Equal(t, zone.GetVersionID(), a.ZoneVersionID)
+			assert.Equal(t, "mallory", a.ModifiedBy)
+			assert.Equal(t, zone.GetLastModifiedDate(), a.ModifiedDate)
+			assert.NotNil(t, a.RecordChange, name)
+		}
+	}
+	rc := changes["www.example.com"].GetAttribution().GetRecordChange()
+	assert.Equal(t, zone.GetVersionID(), rc.VersionID)
+	assert.Equal(t, "mallory", rc.LastModifiedBy)
+	assert.Equal(t, akamaitest.TestClientToken, changes["mail.example.com"].GetAttribution().GetRecordChange().LastModifiedBy)
+
+	var diff bytes.Buffer
+	if err := plan.WriteDiff(&diff); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, diff.String(), "@@ update www.example.com A @@ changed by mallory on "+zone.GetLastModifiedDate()+"\n")
+
+	// Without the version history, only the zone is attributed.
+	srv.DisableVersions = true
+	plan, err = client.FastDNSv2.PlanRecordSets(ctx, "example.com", syncDesired, &akamai.SyncOptions{Attribute: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	for _, c := range plan.Changes {
+		if c.Action == akamai.SyncUpdate && assert.NotNil(t, c.Attribution, c.Name) {
+			assert.Equal(t, "mallory", c.Attribution.ModifiedBy)
+			assert.Nil(t, c.Attribution.RecordChange, c.Name)
+		}
+	}
+	diff.Reset()
+	if err := plan.WriteDiff(&diff); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, diff.String(), "@@ update www.example.com A @@ zone last changed by mallory on "+zone.GetLastModifiedDate()+"\n")
+	assert.NotContains(t, diff.String(), "@@ changed by")
+}
+
+func TestSyncRecordSets(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+
+	plan, err := client.FastDNSv2.SyncRecordSets(context.Background(), "example.com", syncDesired, &akamai.SyncOptions{
+		Prune: true,
+		Bulk:  &akamai.BulkOptions{Concurrency: 8},
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, plan.Changes, 3)
+
+	var names []string
+	for _, rs := range srv.RecordSets("example.com") {
+		names = append(names, rs.GetName()+" "+rs.GetType())
+	}
+	assert.Equal(t, []string{"api.example.com CNAME", "example.com NS", "example.com SOA", "mail.example.com A", "www.example.com A"}, names)
+
+	plan, err = client.FastDNSv2.PlanRecordSets(context.Background(), "example.com", syncDesired, &akamai.SyncOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, plan.Empty())
+}
+
+func TestSyncRecordSetsTombstones(t *testing.T) {
+	tombstoned := append([]*akamai.RecordSetCreateRequest{
+		{Name: "old.example.com", Type: "CNAME", Ensure: akamai.EnsureAbsent},
+		{Name: "gone.example.com", Type: "TXT", Ensure: akamai.EnsureAbsent},
+	}, syncDesired...)
+
+	for _, prune := range []bool{false, true} {
+		t.Run(fmt.Sprintf("prune=%v", prune), func(t *testing.T) {
+			client, srv := newSyncTestServer(t)
+			srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "extra.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.9"}})
+			opt := &akamai.SyncOptions{Prune: prune}
+
+			plan, err := client.FastDNSv2.SyncRecordSets(context.Background(), "example.com", tombstoned, opt)
+			if err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+
+			var changes []string
+			for _, c := range plan.Changes {
+				changes = append(changes, fmt.Sprintf("%v %v %v", c.Action, c.Name, c.Type))
+			}
+			want := []string{"create api.example.com CNAME", "update mail.example.com A", "delete old.example.com CNAME"}
+			if prune {
+				want = []string{"create api.example.com CNAME", "delete extra.example.com A", "update mail.example.com A", "delete old.example.com CNAME"}
+			}
+			assert.Equal(t, want, changes, "the tombstone of a missing record set is a no-op")
+
+			_, _, err = client.FastDNSv2.GetRecordSet(context.Background(), &akamai.RecordSetOptions{Zone: "example.com", Name: "old.example.com", Type: "CNAME"})
+			assert.Error(t, err)
+
+			plan, err = client.FastDNSv2.PlanRecordSets(context.Background(), "example.com", tombstoned, opt)
+			if err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+			assert.True(t, plan.Empty())
+		})
+	}
+}
+
+func TestPlanRecordSetsTombstoneChecks(t *testing.T) {
+	client, _ := newSyncTestServer(t)
+	ctx := context.Background()
+	tombstone := []*akamai.RecordSetCreateRequest{{Name: "old.example.com", Type: "CNAME", Ensure: akamai.EnsureAbsent}}
+
+	// The apex SOA and NS record sets can't be tombstoned.
+	_, err := client.FastDNSv2.PlanRecordSets(ctx, "example.com", []*akamai.RecordSetCreateRequest{{Name: "example.com", Type: "SOA", Ensure: akamai.EnsureAbsent}}, nil)
+	assert.Error(t, err)
+
+	// The shipped policies let deletions through; others can see and refuse them.
+	client.FastDNSv2Service().WithRecordPolicy(akamai.TTLBoundsPolicy(600, 0), akamai.ForbiddenTypesPolicy("CNAME"))
+	plan, err := client.FastDNSv2.PlanRecordSets(ctx, "example.com", tombstone, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, plan.Changes, 1)
+
+	var seen *akamai.RecordSetCreateRequest
+	client.FastDNSv2Service().WithRecordPolicy(func(rs *akamai.RecordSetCreateRequest) error {
+		seen = rs
+		if rs.Ensure == akamai.EnsureAbsent {
+			return &akamai.PolicyViolationError{Rule: "no-deletes", Err: errors.New("deletions need a review")}
+		}
+		return nil
+	})
+	_, err = client.FastDNSv2.PlanRecordSets(ctx, "example.com", tombstone, nil)
+	assert.True(t, errors.Is(err, akamai.ErrPolicyViolation), "got %v", err)
+	if assert.NotNil(t, seen) {
+		assert.Equal(t, []string{"www.example.com."}, seen.Rdata)
+		assert.Equal(t, 300, seen.GetTTL())
+	}
+
+	// The deletions of a protected zone are skipped.
+	client, _ = newSyncTestServer(t)
+	client.WithProtectedZones([]string{"example.com"})
+	plan, err = client.FastDNSv2.PlanRecordSets(ctx, "example.com", tombstone, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, plan.Changes, 1) {
+		assert.True(t, plan.Changes[0].Skipped)
+	}
+}
+
+func TestApplySyncPlanPartialFailure(t *testing.T) {
+	client, _ := newSyncTestServer(t)
+
+	plan := &akamai.SyncPlan{
+		Zone: "example.com",
+		Changes: []*akamai.SyncChange{
+			{Action: akamai.SyncDelete, Name: "missing.example.com", Type: "A"},
+			{Action: akamai.SyncDelete, Name: "old.example.com", Type: "CNAME"},
+		},
+	}
+
+	err := client.FastDNSv2.ApplySyncPlan(context.Background(), plan, nil)
+	var serr *akamai.SyncError
+	if assert.True(t, errors.As(err, &serr)) && assert.Len(t, serr.Failed, 1) {
+		assert.Equal(t, "missing.example.com", serr.Failed[0].Name)
+	}
+	assert.Error(t, plan.Changes[0].Err)
+	assert.NoError(t, plan.Changes[1].Err)
+}
+
+func TestSummarizeZones(t *testing.T) {
+	client, srv := newSyncTestServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.net", Type: "PRIMARY"})
+
+	summaries := client.FastDNSv2.SummarizeZones(context.Background(), []string{"example.com", "missing.com", "example.net"}, &akamai.BulkOptions{Concurrency: 3})
+	if assert.Len(t, summaries, 3) {
+		assert.Equal(t, 5, summaries[0].RecordSets)
+		assert.Equal(t, 2, summaries[0].TypeCounts["A"])
+		assert.Equal(t, "PRIMARY", summaries[0].Metadata.GetType())
+		assert.Equal(t, "missing.com", summaries[1].Zone)
+		assert.Error(t, summaries[1].Err)
+		assert.Equal(t, 2, summaries[2].RecordSets)
+	}
+}
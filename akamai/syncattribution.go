@@ -0,0 +1,73 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+)
+
+// attributionVersions is the number of versions of a zone examined for the history of
+// each record set a plan made with SyncOptions.Attribute would update or delete.
+const attributionVersions = 10
+
+// ChangeAttribution tells who changed what an update or delete of a SyncPlan would
+// overwrite. See SyncOptions.Attribute.
+type ChangeAttribution struct {
+	// ZoneVersionID is the version of the zone the plan was made against, and
+	// ModifiedBy and ModifiedDate who made it and when.
+	ZoneVersionID string
+	ModifiedBy    string
+	ModifiedDate  string
+
+	// RecordChange is the version of the zone in which the record set took its current
+	// value, from GetRecordHistory. It is nil if the version history of the zone is
+	// unavailable, or if the record set didn't change in its last 10 versions.
+	RecordChange *RecordHistoryEntry
+}
+
+// attributeChanges sets the Attribution of the updates and deletes of plan. The zone
+// is read with GetZone, whose failure fails the plan; the history of the record sets is
+// looked up with GetRecordHistory, concurrently as configured by opt.Bulk, and left out
+// when it can't be.
+func (s *FastDNSv2Service) attributeChanges(ctx context.Context, plan *SyncPlan, opt *SyncOptions) error {
+	var changes []*SyncChange
+	for _, c := range plan.Changes {
+		if c.Action == SyncUpdate || c.Action == SyncDelete {
+			changes = append(changes, c)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	zm, _, err := s.GetZone(ctx, plan.Zone)
+	if err != nil {
+		return err
+	}
+
+	RunBulk(ctx, changes, opt.Bulk, func(ctx context.Context, c *SyncChange) (struct{}, error) {
+		c.Attribution = &ChangeAttribution{
+			ZoneVersionID: zm.GetVersionId(),
+			ModifiedBy:    zm.GetLastModifiedBy(),
+			ModifiedDate:  zm.GetLastModifiedDate(),
+		}
+		// A single entry is the oldest version examined: the change is older.
+		history, err := s.GetRecordHistory(ctx, plan.Zone, c.Name, c.Type, attributionVersions)
+		if err == nil && len(history) > 1 && history[0].Exists {
+			c.Attribution.RecordChange = history[0]
+		}
+		return struct{}{}, nil
+	})
+	return ctx.Err()
+}
+
+// heading returns the text WriteDiff writes after the range of the hunk of a change
+// with attribution a, "" if a is nil.
+func (a *ChangeAttribution) heading() string {
+	switch {
+	case a == nil:
+		return ""
+	case a.RecordChange != nil:
+		return fmt.Sprintf(" changed by %v on %v", a.RecordChange.LastModifiedBy, a.RecordChange.LastModifiedDate)
+	}
+	return fmt.Sprintf(" zone last changed by %v on %v", a.ModifiedBy, a.ModifiedDate)
+}
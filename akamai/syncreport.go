@@ -0,0 +1,245 @@
+package akamai
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// SyncCounts counts the record sets of a SyncPlan by what the plan does to them.
+type SyncCounts struct {
+	Creates   int `json:"creates"`
+	Updates   int `json:"updates"`
+	Deletes   int `json:"deletes"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Drift returns the number of record sets that differ from the desired state.
+func (c SyncCounts) Drift() int {
+	return c.Creates + c.Updates + c.Deletes
+}
+
+// SyncStats summarizes a SyncPlan, in total and by record type. The counts are meant to
+// be reported as gauges labeled by zone, action and type.
+type SyncStats struct {
+	Zone string `json:"zone"`
+	SyncCounts
+
+	// Types holds the counts of each record type, in upper case.
+	Types map[string]SyncCounts `json:"types"`
+}
+
+// Stats counts the changes of the plan and the record sets it leaves unchanged.
+func (p *SyncPlan) Stats() *SyncStats {
+	st := &SyncStats{Zone: p.Zone, Types: map[string]SyncCounts{}}
+	for _, c := range p.Changes {
+		rtype := strings.ToUpper(c.Type)
+		tc := st.Types[rtype]
+		switch c.Action {
+		case SyncCreate:
+			st.Creates++
+			tc.Creates++
+		case SyncUpdate:
+			st.Updates++
+			tc.Updates++
+		case SyncDelete:
+			st.Deletes++
+			tc.Deletes++
+		}
+		st.Types[rtype] = tc
+	}
+
+	st.Unchanged = p.Unchanged
+	for rtype, n := range p.UnchangedTypes {
+		tc := st.Types[rtype]
+		tc.Unchanged += n
+		st.Types[rtype] = tc
+	}
+
+	return st
+}
+
+// syncPlanJSON is the JSON serialization of a SyncPlan.
+type syncPlanJSON struct {
+	Zone    string            `json:"zone"`
+	Stats   *SyncStats        `json:"stats"`
+	Changes []*syncChangeJSON `json:"changes"`
+}
+
+type syncChangeJSON struct {
+	Action       SyncAction         `json:"action"`
+	Name         string             `json:"name"`
+	Type         string             `json:"type"`
+	Current      *syncRecordSetJSON `json:"current,omitempty"`
+	Desired      *syncRecordSetJSON `json:"desired,omitempty"`
+	TTLDefaulted bool               `json:"ttlDefaulted,omitempty"`
+	Skipped      bool               `json:"skipped,omitempty"`
+	Error        string             `json:"error,omitempty"`
+
+	Attribution *syncAttributionJSON `json:"attribution,omitempty"`
+}
+
+type syncAttributionJSON struct {
+	ZoneVersionID string                `json:"zoneVersionId"`
+	ModifiedBy    string                `json:"modifiedBy"`
+	ModifiedDate  string                `json:"modifiedDate"`
+	RecordChange  *syncRecordChangeJSON `json:"recordChange,omitempty"`
+}
+
+type syncRecordChangeJSON struct {
+	VersionID    string `json:"versionId"`
+	ModifiedBy   string `json:"modifiedBy"`
+	ModifiedDate string `json:"modifiedDate"`
+}
+
+type syncRecordSetJSON struct {
+	TTL   int      `json:"ttl"`
+	Rdata []string `json:"rdata"`
+}
+
+// MarshalJSON serializes the plan with its Stats, for CI artifacts and dashboards. The
+// form is stable: the changes are sorted by name and type whatever the order of the
+// plan, the rdata of each record set is sorted, and only the TTL and rdata of the
+// current and desired record sets are included. The error of a change that failed to
+// apply is included as its message, and its Attribution, if any, as the versions,
+// authors and dates of the changes.
+func (p *SyncPlan) MarshalJSON() ([]byte, error) {
+	out := &syncPlanJSON{Zone: p.Zone, Stats: p.Stats(), Changes: []*syncChangeJSON{}}
+	for _, c := range p.sortedChanges() {
+		cj := &syncChangeJSON{Action: c.Action, Name: c.Name, Type: strings.ToUpper(c.Type), Skipped: c.Skipped, TTLDefaulted: c.TTLDefaulted}
+		if c.Current != nil {
+			cj.Current = &syncRecordSetJSON{TTL: c.Current.GetTTL(), Rdata: currentRdata(c.Current)}
+		}
+		if c.Desired != nil {
+			cj.Desired = &syncRecordSetJSON{TTL: c.Desired.GetTTL(), Rdata: sortedRdata(c.Desired.Rdata)}
+		}
+		if c.Err != nil {
+			cj.Error = c.Err.Error()
+		}
+		if a := c.Attribution; a != nil {
+			cj.Attribution = &syncAttributionJSON{ZoneVersionID: a.ZoneVersionID, ModifiedBy: a.ModifiedBy, ModifiedDate: a.ModifiedDate}
+			if rc := a.RecordChange; rc != nil {
+				cj.Attribution.RecordChange = &syncRecordChangeJSON{VersionID: rc.VersionID, ModifiedBy: rc.LastModifiedBy, ModifiedDate: rc.LastModifiedDate}
+			}
+		}
+		out.Changes = append(out.Changes, cj)
+	}
+	return json.Marshal(out)
+}
+
+// WriteDiff renders the plan to w as a unified diff of the records of the zone, for
+// review by humans. Each change has a hunk; the records of an update whose rdata and TTL
+// are kept are shown as context. Records are written in zone file form, with their rdata
+// sorted, and the changes sorted by name and type. Rdata is matched in the normalized
+// form of RecordSetEqual, so a record only written differently is kept, and shown as
+// the zone holds it. The hunks of the changes with an Attribution say, after their
+// range, who changed the record set and when, or else who last changed the zone.
+func (p *SyncPlan) WriteDiff(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "--- a/%v\n+++ b/%v\n", p.Zone, p.Zone)
+
+	for _, c := range p.sortedChanges() {
+		rtype := strings.ToUpper(c.Type)
+		skipped := ""
+		if c.Skipped {
+			skipped = " (skipped)"
+		}
+		fmt.Fprintf(bw, "@@ %v %v %v%v @@%v\n", c.Action, c.Name, rtype, skipped, c.Attribution.heading())
+
+		var cur, want []string
+		var curTTL, wantTTL int
+		if c.Current != nil {
+			cur, curTTL = currentRdata(c.Current), c.Current.GetTTL()
+		}
+		if c.Desired != nil {
+			want, wantTTL = sortedRdata(c.Desired.Rdata), c.Desired.GetTTL()
+		}
+
+		for _, r := range matchRdata(rtype, cur, want) {
+			if r.cur != "" && r.want != "" && curTTL == wantTTL {
+				fmt.Fprintf(bw, " %v %d %v %v\n", c.Name, curTTL, rtype, r.cur)
+				continue
+			}
+			if r.cur != "" {
+				fmt.Fprintf(bw, "-%v %d %v %v\n", c.Name, curTTL, rtype, r.cur)
+			}
+			if r.want != "" {
+				fmt.Fprintf(bw, "+%v %d %v %v\n", c.Name, wantTTL, rtype, r.want)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// rdataPair is a record of a diff, as written in the current and desired record sets.
+// Either is empty if the record set lacks it.
+type rdataPair struct {
+	cur, want string
+}
+
+// matchRdata pairs the sorted rdata of two record sets of the given type by their
+// normalized form, and returns the pairs sorted by rdata, current first.
+func matchRdata(rtype string, cur, want []string) []rdataPair {
+	var pairs []rdataPair
+	index := map[string]int{}
+	for _, r := range cur {
+		index[normalizeRdata(rtype, r)] = len(pairs)
+		pairs = append(pairs, rdataPair{cur: r})
+	}
+	for _, r := range want {
+		if i, ok := index[normalizeRdata(rtype, r)]; ok && pairs[i].want == "" {
+			pairs[i].want = r
+			continue
+		}
+		pairs = append(pairs, rdataPair{want: r})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].sortKey() < pairs[j].sortKey()
+	})
+	return pairs
+}
+
+func (r rdataPair) sortKey() string {
+	if r.cur != "" {
+		return r.cur
+	}
+	return r.want
+}
+
+// sortedChanges returns the changes of the plan sorted by name and type, as
+// PlanRecordSets makes them, so that plans put together in another order render the
+// same.
+func (p *SyncPlan) sortedChanges() []*SyncChange {
+	changes := append([]*SyncChange(nil), p.Changes...)
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Action < b.Action
+	})
+	return changes
+}
+
+func currentRdata(rs *RecordSet) []string {
+	rdata := make([]string, len(rs.Rdata))
+	for i, r := range rs.Rdata {
+		rdata[i] = StringValue(r)
+	}
+	sort.Strings(rdata)
+	return rdata
+}
+
+func sortedRdata(rdata []string) []string {
+	sorted := append([]string{}, rdata...)
+	sort.Strings(sorted)
+	return sorted
+}
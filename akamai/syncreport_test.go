@@ -0,0 +1,109 @@
+package akamai_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files of the tests")
+
+// fixturePlan creates, updates and deletes record sets of several types, with one
+// failed change and two attributed ones.
+func fixturePlan() *akamai.SyncPlan {
+	return &akamai.SyncPlan{
+		Zone: "example.com",
+		Changes: []*akamai.SyncChange{
+			{
+				Action:  akamai.SyncCreate,
+				Name:    "api.example.com",
+				Type:    "CNAME",
+				Desired: &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "api.example.com", Type: "CNAME", TTL: akamai.Int(300), Rdata: []string{"www.example.com."}},
+			},
+			{
+				Action:  akamai.SyncUpdate,
+				Name:    "mail.example.com",
+				Type:    "A",
+				Current: &akamai.RecordSet{Name: akamai.String("mail.example.com"), Type: akamai.String("A"), TTL: akamai.Int(300), Rdata: []*string{akamai.String("192.0.2.10")}},
+				Desired: &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "mail.example.com", Type: "A", TTL: akamai.Int(600), Rdata: []string{"192.0.2.10"}},
+				Attribution: &akamai.ChangeAttribution{
+					ZoneVersionID: "v3",
+					ModifiedBy:    "carol",
+					ModifiedDate:  "2026-01-03T00:00:00Z",
+					RecordChange:  &akamai.RecordHistoryEntry{VersionID: "v2", LastModifiedBy: "bob", LastModifiedDate: "2026-01-02T00:00:00Z", Exists: true, TTL: 300, Rdata: []string{"192.0.2.10"}},
+				},
+			},
+			{
+				Action:  akamai.SyncDelete,
+				Name:    "old.example.com",
+				Type:    "TXT",
+				Current: &akamai.RecordSet{Name: akamai.String("old.example.com"), Type: akamai.String("TXT"), TTL: akamai.Int(3600), Rdata: []*string{akamai.String(`"legacy"`)}},
+				Err:     errors.New("record set is locked"),
+				Attribution: &akamai.ChangeAttribution{
+					ZoneVersionID: "v3",
+					ModifiedBy:    "carol",
+					ModifiedDate:  "2026-01-03T00:00:00Z",
+				},
+			},
+			{
+				Action:  akamai.SyncUpdate,
+				Name:    "www.example.com",
+				Type:    "A",
+				Current: &akamai.RecordSet{Name: akamai.String("www.example.com"), Type: akamai.String("A"), TTL: akamai.Int(300), Rdata: []*string{akamai.String("192.0.2.2"), akamai.String("192.0.2.1")}},
+				Desired: &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.3", "192.0.2.1"}},
+			},
+		},
+		Unchanged:      3,
+		UnchangedTypes: map[string]int{"A": 1, "MX": 2},
+	}
+}
+
+// checkGolden compares got with the golden file at path, or rewrites it with -update.
+func checkGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *updateGolden {
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestSyncPlanStats(t *testing.T) {
+	st := fixturePlan().Stats()
+	assert.Equal(t, akamai.SyncCounts{Creates: 1, Updates: 2, Deletes: 1, Unchanged: 3}, st.SyncCounts)
+	assert.Equal(t, 4, st.Drift())
+	assert.Equal(t, map[string]akamai.SyncCounts{
+		"A":     {Updates: 2, Unchanged: 1},
+		"CNAME": {Creates: 1},
+		"MX":    {Unchanged: 2},
+		"TXT":   {Deletes: 1},
+	}, st.Types)
+}
+
+func TestSyncPlanJSON(t *testing.T) {
+	b, err := json.MarshalIndent(fixturePlan(), "", "  ")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	checkGolden(t, "../testdata/sync/plan.json", append(b, '\n'))
+}
+
+func TestSyncPlanWriteDiff(t *testing.T) {
+	var buf bytes.Buffer
+	if err := fixturePlan().WriteDiff(&buf); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	checkGolden(t, "../testdata/sync/plan.diff", buf.Bytes())
+}
@@ -0,0 +1,100 @@
+package akamai
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timings breaks down the time a request took, to tell slow connections from slow
+// servers. The phases that didn't happen, such as the DNS lookup of an IP address or
+// the connection and TLS handshake of a reused connection, are zero.
+type Timings struct {
+	DNS             time.Duration
+	Connect         time.Duration
+	TLSHandshake    time.Duration
+	TimeToFirstByte time.Duration
+	Total           time.Duration
+
+	// Reused reports whether the request was sent on a connection kept from an earlier
+	// request. Requests that are seldom sent on reused connections hint at a pool that
+	// is churning.
+	Reused bool
+}
+
+// WithTimings makes the client trace the requests it sends, and record their Timings in
+// their Response. If hook is not nil, it is called with the timings of every request
+// once its response is read, or it failed, such as to feed metrics. Without
+// WithTimings requests are not traced, at no cost.
+//
+// WithTimings must not be called while the client is in use; it returns c so that calls
+// can be chained.
+func (c *Client) WithTimings(hook func(req *http.Request, t *Timings)) *Client {
+	c.timings = true
+	c.timingsHook = hook
+	return c
+}
+
+// timingsTrace records the Timings of a request from the events of its
+// httptrace.ClientTrace, which may be called from several goroutines.
+type timingsTrace struct {
+	mu      sync.Mutex
+	t       *Timings
+	start   time.Time
+	dns     time.Time
+	connect time.Time
+	tls     time.Time
+}
+
+// traceTimings returns req traced to record its timings, starting now.
+func traceTimings(req *http.Request) (*http.Request, *timingsTrace) {
+	tt := &timingsTrace{t: &Timings{}, start: time.Now()}
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { tt.begin(&tt.dns) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { tt.end(&tt.dns, &tt.t.DNS) },
+		ConnectStart: func(string, string) {
+			tt.begin(&tt.connect)
+		},
+		ConnectDone: func(string, string, error) {
+			tt.end(&tt.connect, &tt.t.Connect)
+		},
+		TLSHandshakeStart: func() { tt.begin(&tt.tls) },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			tt.end(&tt.tls, &tt.t.TLSHandshake)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			tt.mu.Lock()
+			tt.t.Reused = info.Reused
+			tt.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			tt.end(&tt.start, &tt.t.TimeToFirstByte)
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), tt
+}
+
+func (tt *timingsTrace) begin(at *time.Time) {
+	tt.mu.Lock()
+	*at = time.Now()
+	tt.mu.Unlock()
+}
+
+func (tt *timingsTrace) end(since *time.Time, d *time.Duration) {
+	tt.mu.Lock()
+	*d = time.Since(*since)
+	tt.mu.Unlock()
+}
+
+// done records the total time of the request, and passes its timings to the hook.
+func (tt *timingsTrace) done(c *Client, req *http.Request) {
+	tt.mu.Lock()
+	tt.t.Total = time.Since(tt.start)
+	tt.mu.Unlock()
+
+	if c.timingsHook != nil {
+		c.timingsHook(req, tt.t)
+	}
+}
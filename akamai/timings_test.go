@@ -0,0 +1,95 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+func newTLSTestClient(t *testing.T) (*Client, func()) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"zones": []}`)
+	}))
+
+	creds := credentials.NewStaticCredentials(akamaiTestClientSecret, akamaiTestClientToken, akamaiTestAccessToken, server.Listener.Addr().String())
+	client, err := NewClient(server.Client(), creds)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return client, server.Close
+}
+
+func TestWithTimings(t *testing.T) {
+	client, teardown := newTLSTestClient(t)
+	defer teardown()
+
+	var hooked []*Timings
+	client.WithTimings(func(req *http.Request, timings *Timings) {
+		assert.Equal(t, "/config-dns/v2/zones", req.URL.Path)
+		hooked = append(hooked, timings)
+	})
+
+	_, first, err := client.FastDNSv2.ListZones(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	_, second, err := client.FastDNSv2.ListZones(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if assert.NotNil(t, first.Timings) {
+		tm := first.Timings
+		assert.Equal(t, int64(0), int64(tm.DNS), "the server is reached by its IP address")
+		assert.True(t, tm.Connect > 0, "connect %v", tm.Connect)
+		assert.True(t, tm.TLSHandshake > 0, "TLS handshake %v", tm.TLSHandshake)
+		assert.True(t, tm.TimeToFirstByte > 0, "time to first byte %v", tm.TimeToFirstByte)
+		assert.True(t, tm.Total >= tm.TimeToFirstByte, "total %v", tm.Total)
+		assert.False(t, tm.Reused)
+	}
+	if assert.NotNil(t, second.Timings) {
+		tm := second.Timings
+		assert.True(t, tm.Reused, "the connection is kept for the second request")
+		assert.Equal(t, int64(0), int64(tm.Connect))
+		assert.Equal(t, int64(0), int64(tm.TLSHandshake))
+		assert.True(t, tm.TimeToFirstByte > 0)
+	}
+	assert.Equal(t, []*Timings{first.Timings, second.Timings}, hooked)
+}
+
+func TestWithoutTimings(t *testing.T) {
+	client, teardown := newTLSTestClient(t)
+	defer teardown()
+
+	_, resp, err := client.FastDNSv2.ListZones(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Nil(t, resp.Timings)
+
+	if raceEnabled {
+		return
+	}
+
+	// Requests that are not traced don't pay for it.
+	ctx := context.Background()
+	call := func(c *Client) func() {
+		return func() {
+			if _, _, err := c.FastDNSv2.GetZone(ctx, "example.com"); err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+		}
+	}
+	plain := newAllocsTestClient(t, http.StatusOK, `{"zone": "example.com"}`)
+	traced := newAllocsTestClient(t, http.StatusOK, `{"zone": "example.com"}`).WithTimings(nil)
+	off, on := testing.AllocsPerRun(100, call(plain)), testing.AllocsPerRun(100, call(traced))
+	assert.True(t, off < on, "expect fewer allocations without timings, got %v and %v", off, on)
+}
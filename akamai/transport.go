@@ -0,0 +1,146 @@
+package akamai
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Defaults of the transport NewClient builds when it isn't given an http.Client. See
+// TransportOptions.
+const (
+	DefaultMaxIdleConnsPerHost   = 16
+	DefaultTLSHandshakeTimeout   = 10 * time.Second
+	DefaultResponseHeaderTimeout = 60 * time.Second
+	DefaultIdleConnTimeout       = 90 * time.Second
+)
+
+// TransportOptions tunes the transport built by NewTransport. The zero values take the
+// defaults.
+type TransportOptions struct {
+	// MaxIdleConnsPerHost is the number of connections to the API host kept open
+	// between requests. All the requests go to the same gateway, so it should be at
+	// least the concurrency of the bulk and sync methods, such as
+	// SyncOptions.Bulk.Concurrency; the 2 of http.DefaultTransport makes concurrent
+	// requests open a new connection most of the time.
+	MaxIdleConnsPerHost int
+
+	// TLSHandshakeTimeout and ResponseHeaderTimeout bound the TLS handshake of a new
+	// connection, and the wait for the headers of a response once the request is sent.
+	// Slow operations of the APIs, such as zone deletions, are asynchronous, so a
+	// response should not take long to start.
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+
+	// IdleConnTimeout is how long an idle connection is kept open.
+	IdleConnTimeout time.Duration
+
+	// DisableHTTP2 makes the transport speak HTTP/1.1 only. HTTP/2 multiplexes the
+	// concurrent requests over a few connections to the gateway.
+	DisableHTTP2 bool
+
+	// MinTLSVersion is the lowest TLS version the transport negotiates, such as
+	// tls.VersionTLS12. Defaults to that of crypto/tls.
+	MinTLSVersion uint16
+
+	// RootCAs, if set, are the only certificate authorities the certificates of the
+	// servers are verified against, such as to pin the CA of the Akamai gateway.
+	// Defaults to the roots of the system.
+	RootCAs *x509.CertPool
+}
+
+// NewTransport returns a transport tuned for the Akamai APIs: it keeps enough idle
+// connections to the API host for concurrent requests, bounds the TLS handshake and the
+// wait for response headers, and negotiates HTTP/2. It honors the proxy environment
+// variables as http.DefaultTransport does. opt may be nil.
+func NewTransport(opt *TransportOptions) *http.Transport {
+	if opt == nil {
+		opt = &TransportOptions{}
+	}
+
+	t := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     !opt.DisableHTTP2,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   orDefault(opt.MaxIdleConnsPerHost, DefaultMaxIdleConnsPerHost),
+		IdleConnTimeout:       orDefault(opt.IdleConnTimeout, DefaultIdleConnTimeout),
+		TLSHandshakeTimeout:   orDefault(opt.TLSHandshakeTimeout, DefaultTLSHandshakeTimeout),
+		ResponseHeaderTimeout: orDefault(opt.ResponseHeaderTimeout, DefaultResponseHeaderTimeout),
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if t.MaxIdleConns < t.MaxIdleConnsPerHost {
+		t.MaxIdleConns = t.MaxIdleConnsPerHost
+	}
+	if opt.MinTLSVersion != 0 || opt.RootCAs != nil {
+		t.TLSClientConfig = &tls.Config{MinVersion: opt.MinTLSVersion, RootCAs: opt.RootCAs}
+	}
+	return t
+}
+
+// ErrCustomHTTPClient is returned by the TLS options of a client given an http.Client,
+// whose transport is the caller's and is never modified. Build the transport with
+// NewTransport and its TransportOptions instead.
+var ErrCustomHTTPClient = errors.New("the TLS options of a client can't be set on the transport of a given http.Client")
+
+// WithMinTLSVersion sets the lowest TLS version the client negotiates, such as
+// tls.VersionTLS12, on the transport NewClient built for it. It fails with
+// ErrCustomHTTPClient for a client given an http.Client.
+//
+// WithMinTLSVersion must not be called while the client is in use.
+func (c *Client) WithMinTLSVersion(v uint16) error {
+	tc, err := c.tlsConfig()
+	if err != nil {
+		return err
+	}
+	tc.MinVersion = v
+	return nil
+}
+
+// WithPinnedCAs makes the client only trust server certificates issued by the
+// certificate authorities of pool, such as the CA of the Akamai gateway, rather than the
+// roots of the system, on the transport NewClient built for it. A nil pool restores the
+// roots of the system. It fails with ErrCustomHTTPClient for a client given an
+// http.Client.
+//
+// WithPinnedCAs must not be called while the client is in use.
+func (c *Client) WithPinnedCAs(pool *x509.CertPool) error {
+	tc, err := c.tlsConfig()
+	if err != nil {
+		return err
+	}
+	tc.RootCAs = pool
+	return nil
+}
+
+// tlsConfig returns the TLS configuration of the transport NewClient built for c,
+// adding one if it has none.
+func (c *Client) tlsConfig() (*tls.Config, error) {
+	if c.transport == nil {
+		return nil, ErrCustomHTTPClient
+	}
+	if c.transport.TLSClientConfig == nil {
+		c.transport.TLSClientConfig = &tls.Config{}
+	}
+	return c.transport.TLSClientConfig, nil
+}
+
+// Transport returns the transport NewClient built for the client, to inspect its
+// settings. It is nil for the clients given an http.Client, whose transport is theirs.
+func (c *Client) Transport() *http.Transport {
+	return c.transport
+}
+
+// orDefault returns v if it is set, and def otherwise.
+func orDefault[T int | time.Duration](v, def T) T {
+	if v > 0 {
+		return v
+	}
+	return def
+}
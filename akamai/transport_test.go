@@ -0,0 +1,228 @@
+package akamai
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+func TestNewClientTransport(t *testing.T) {
+	creds := credentials.NewStaticCredentials("secret", "client", "access", "akaa-baseurl.luna.akamaiapis.net")
+	c, err := NewClient(nil, creds)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	tr := c.Transport()
+	if assert.NotNil(t, tr) {
+		assert.Equal(t, DefaultMaxIdleConnsPerHost, tr.MaxIdleConnsPerHost)
+		assert.Equal(t, DefaultTLSHandshakeTimeout, tr.TLSHandshakeTimeout)
+		assert.Equal(t, DefaultResponseHeaderTimeout, tr.ResponseHeaderTimeout)
+		assert.Equal(t, DefaultIdleConnTimeout, tr.IdleConnTimeout)
+		assert.True(t, tr.ForceAttemptHTTP2)
+		assert.NotNil(t, tr.Proxy)
+		assert.True(t, c.client.Transport == tr, "the client makes its requests with the transport")
+		assert.True(t, c.client != http.DefaultClient)
+	}
+}
+
+func TestNewTransportOptions(t *testing.T) {
+	tr := NewTransport(&TransportOptions{
+		MaxIdleConnsPerHost:   200,
+		TLSHandshakeTimeout:   time.Second,
+		ResponseHeaderTimeout: 2 * time.Second,
+		IdleConnTimeout:       3 * time.Second,
+		DisableHTTP2:          true,
+	})
+	assert.Equal(t, 200, tr.MaxIdleConnsPerHost)
+	assert.Equal(t, 200, tr.MaxIdleConns, "the idle connections to the host are not capped by the total")
+	assert.Equal(t, time.Second, tr.TLSHandshakeTimeout)
+	assert.Equal(t, 2*time.Second, tr.ResponseHeaderTimeout)
+	assert.Equal(t, 3*time.Second, tr.IdleConnTimeout)
+	assert.False(t, tr.ForceAttemptHTTP2)
+}
+
+func TestNewClientKeepsHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"zones": []}`)
+	}))
+	defer server.Close()
+
+	tr := &http.Transport{MaxIdleConnsPerHost: 1}
+	hc := &http.Client{Transport: tr, Timeout: time.Minute}
+	wantClient, wantTransport := *hc, tr.Clone()
+
+	creds := credentials.NewStaticCredentials(akamaiTestClientSecret, akamaiTestClientToken, akamaiTestAccessToken, server.Listener.Addr().String())
+	c, err := NewClient(hc, creds)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	c.BaseURL, _ = url.Parse(server.URL + "/")
+	c.WithTimings(nil)
+	assert.Nil(t, c.Transport())
+
+	if _, _, err := c.FastDNSv2.ListZones(context.Background(), nil); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	pool := NewClientPool(hc, "../testdata/edgerc")
+	if _, err := pool.ForProfile("tenant-a"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.True(t, hc.Transport == tr)
+	assert.Equal(t, wantClient.Timeout, hc.Timeout)
+	assert.Nil(t, hc.CheckRedirect)
+	assert.Nil(t, hc.Jar)
+	assert.Equal(t, wantTransport.MaxIdleConnsPerHost, tr.MaxIdleConnsPerHost)
+	assert.Equal(t, wantTransport.ForceAttemptHTTP2, tr.ForceAttemptHTTP2)
+	assert.Equal(t, wantTransport.ResponseHeaderTimeout, tr.ResponseHeaderTimeout)
+}
+
+// BenchmarkTransport compares bursts of concurrent requests, such as those of a sync, to
+// a TLS server that takes a few milliseconds to answer, made with the settings of
+// http.DefaultTransport and with those of NewTransport. Between bursts the default
+// transport only keeps 2 of the connections open, so every burst pays for new
+// connections and TLS handshakes; conns/op is their number per burst.
+func BenchmarkTransport(b *testing.B) {
+	const burst = 16
+
+	for _, bm := range []struct {
+		name      string
+		transport func() *http.Transport
+	}{
+		{"default", func() *http.Transport { return http.DefaultTransport.(*http.Transport).Clone() }},
+		{"tuned", func() *http.Transport { return NewTransport(nil) }},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			var conns int64
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(2 * time.Millisecond)
+				fmt.Fprint(w, `{"zones": []}`)
+			}))
+			server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					atomic.AddInt64(&conns, 1)
+				}
+			}
+			server.StartTLS()
+			defer server.Close()
+
+			tr := bm.transport()
+			tr.TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+			defer tr.CloseIdleConnections()
+
+			creds := credentials.NewStaticCredentials(akamaiTestClientSecret, akamaiTestClientToken, akamaiTestAccessToken, server.Listener.Addr().String())
+			c, err := NewClient(&http.Client{Transport: tr}, creds)
+			if err != nil {
+				b.Fatal(err)
+			}
+			c.BaseURL, _ = url.Parse(server.URL + "/")
+			ctx := context.Background()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				var wg sync.WaitGroup
+				for j := 0; j < burst; j++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						if _, _, err := c.FastDNSv2.ListZones(ctx, nil); err != nil {
+							b.Error(err)
+						}
+					}()
+				}
+				wg.Wait()
+			}
+			b.ReportMetric(float64(atomic.LoadInt64(&conns))/float64(b.N), "conns/op")
+		})
+	}
+}
+
+func TestWithPinnedCAs(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"zones": []}`)
+	}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	pinned := x509.NewCertPool()
+	pinned.AddCert(server.Certificate())
+
+	newClient := func(t *testing.T) *Client {
+		creds := credentials.NewStaticCredentials(akamaiTestClientSecret, akamaiTestClientToken, akamaiTestAccessToken, server.Listener.Addr().String())
+		c, err := NewClient(nil, creds)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		return c
+	}
+
+	// The CA of the server is not among the roots of the system.
+	c := newClient(t)
+	_, _, err := c.FastDNSv2.ListZones(context.Background(), nil)
+	var unknown x509.UnknownAuthorityError
+	assert.True(t, errors.As(err, &unknown), "got %v", err)
+
+	c = newClient(t)
+	if err := c.WithPinnedCAs(pinned); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if _, _, err := c.FastDNSv2.ListZones(context.Background(), nil); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// The server speaks up to TLS 1.2.
+	if err := c.WithMinTLSVersion(tls.VersionTLS13); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	c.Transport().CloseIdleConnections()
+	_, _, err = c.FastDNSv2.ListZones(context.Background(), nil)
+	assert.Error(t, err)
+
+	// Nor is it when other CAs are pinned.
+	c = newClient(t)
+	if err := c.WithPinnedCAs(x509.NewCertPool()); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	_, _, err = c.FastDNSv2.ListZones(context.Background(), nil)
+	assert.True(t, errors.As(err, &unknown), "got %v", err)
+}
+
+func TestTLSOptionsCustomHTTPClient(t *testing.T) {
+	tr := &http.Transport{}
+	creds := credentials.NewStaticCredentials("secret", "client", "access", "akaa-baseurl.luna.akamaiapis.net")
+	c, err := NewClient(&http.Client{Transport: tr}, creds)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Equal(t, ErrCustomHTTPClient, c.WithMinTLSVersion(tls.VersionTLS12))
+	assert.Equal(t, ErrCustomHTTPClient, c.WithPinnedCAs(x509.NewCertPool()))
+	assert.Nil(t, tr.TLSClientConfig, "the transport of the caller is left as is")
+
+	pool := x509.NewCertPool()
+	tr = NewTransport(&TransportOptions{MinTLSVersion: tls.VersionTLS12, RootCAs: pool})
+	if assert.NotNil(t, tr.TLSClientConfig) {
+		assert.Equal(t, uint16(tls.VersionTLS12), tr.TLSClientConfig.MinVersion)
+		assert.True(t, tr.TLSClientConfig.RootCAs == pool)
+	}
+	assert.Nil(t, NewTransport(nil).TLSClientConfig)
+}
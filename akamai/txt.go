@@ -0,0 +1,159 @@
+package akamai
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxTXTStringLength is the maximum length in bytes of a DNS character-string.
+const maxTXTStringLength = 255
+
+// QuoteTXT encodes a TXT value as the rdata the FastDNS API expects. The escaping
+// contract is:
+//
+//   - The value is split into character-strings of at most 255 bytes, never in the
+//     middle of a UTF-8 sequence, each enclosed in double quotes and separated by a
+//     single space. An empty value is encoded as "".
+//   - Within a character-string, a double quote is written \" and a backslash \\.
+//   - Bytes below 0x20 and 0x7f are written as \DDD, their decimal value on three
+//     digits. All other bytes, including "<", "&" and non-ASCII UTF-8, are written
+//     as is.
+//
+// The rdata is then sent as a JSON string, with no further escaping than JSON's own:
+// NewRequest does not escape HTML characters. UnquoteTXT reverses QuoteTXT, so a value
+// read back with it is byte-identical to the value written.
+func QuoteTXT(value string) string {
+	var b strings.Builder
+	b.Grow(len(value) + 2 + 3*(len(value)/maxTXTStringLength))
+
+	for first := true; first || value != ""; first = false {
+		n := len(value)
+		if n > maxTXTStringLength {
+			n = maxTXTStringLength
+			for n > 0 && !utf8.RuneStart(value[n]) {
+				n--
+			}
+			if n == 0 {
+				n = maxTXTStringLength
+			}
+		}
+
+		if !first {
+			b.WriteByte(' ')
+		}
+		b.WriteByte('"')
+		for i := 0; i < n; i++ {
+			switch c := value[i]; {
+			case c == '"' || c == '\\':
+				b.WriteByte('\\')
+				b.WriteByte(c)
+			case c < 0x20 || c == 0x7f:
+				fmt.Fprintf(&b, "\\%03d", c)
+			default:
+				b.WriteByte(c)
+			}
+		}
+		b.WriteByte('"')
+
+		value = value[n:]
+	}
+
+	return b.String()
+}
+
+// UnquoteTXT decodes the rdata of a TXT record into its value, concatenating its
+// character-strings. It accepts the escaping described by QuoteTXT, as well as
+// unquoted character-strings and \X escapes of any other character.
+func UnquoteTXT(rdata string) (string, error) {
+	var b strings.Builder
+	b.Grow(len(rdata))
+
+	i := 0
+	for i < len(rdata) {
+		switch rdata[i] {
+		case ' ', '\t':
+			i++
+			continue
+		}
+
+		quoted := rdata[i] == '"'
+		if quoted {
+			i++
+		}
+
+		for {
+			if i == len(rdata) {
+				if quoted {
+					return "", fmt.Errorf("unterminated character-string in TXT rdata %q", rdata)
+				}
+				break
+			}
+
+			c := rdata[i]
+			if quoted && c == '"' {
+				i++
+				break
+			}
+			if !quoted && (c == ' ' || c == '\t') {
+				break
+			}
+			if !quoted && c == '"' {
+				return "", fmt.Errorf("unexpected quote in TXT rdata %q", rdata)
+			}
+
+			if c != '\\' {
+				b.WriteByte(c)
+				i++
+				continue
+			}
+
+			if i+1 == len(rdata) {
+				return "", fmt.Errorf("trailing backslash in TXT rdata %q", rdata)
+			}
+			if isDigit(rdata[i+1]) {
+				if i+3 >= len(rdata) || !isDigit(rdata[i+2]) || !isDigit(rdata[i+3]) {
+					return "", fmt.Errorf("invalid \\DDD escape in TXT rdata %q", rdata)
+				}
+				v := int(rdata[i+1]-'0')*100 + int(rdata[i+2]-'0')*10 + int(rdata[i+3]-'0')
+				if v > 255 {
+					return "", fmt.Errorf("invalid \\DDD escape in TXT rdata %q", rdata)
+				}
+				b.WriteByte(byte(v))
+				i += 4
+				continue
+			}
+			b.WriteByte(rdata[i+1])
+			i += 2
+		}
+	}
+
+	return b.String(), nil
+}
+
+// NewTXTRecordSet returns the request creating a TXT record set with one record per
+// value, each encoded with QuoteTXT.
+func NewTXTRecordSet(zone, name string, ttl int, values ...string) *RecordSetCreateRequest {
+	rs := &RecordSetCreateRequest{Zone: zone, Name: name, Type: RRTypeTxt, TTL: Int(ttl)}
+	for _, v := range values {
+		rs.Rdata = append(rs.Rdata, QuoteTXT(v))
+	}
+	return rs
+}
+
+// TXTValues decodes the values of the records of a TXT record set with UnquoteTXT.
+func (rs *RecordSet) TXTValues() ([]string, error) {
+	values := make([]string, len(rs.Rdata))
+	for i, rdata := range rs.Rdata {
+		v, err := UnquoteTXT(StringValue(rdata))
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
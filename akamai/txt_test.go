@@ -0,0 +1,110 @@
+package akamai_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// trickyTXT holds quotes, backslashes, HTML characters, a control character and emoji,
+// and is longer than a single character-string.
+var trickyTXT = `v=DKIM1; n="quoted \"twice\""; p=C:\keys\<a&b>;` + "\t" + strings.Repeat("🔑x", 50) + strings.Repeat("y", 30)
+
+func TestQuoteTXT(t *testing.T) {
+	tests := []struct {
+		value string
+		rdata string
+	}{
+		{"", `""`},
+		{"v=spf1 -all", `"v=spf1 -all"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\dir`, `"C:\\dir"`},
+		{"<a&b>", `"<a&b>"`},
+		{"tab\there\x7f", `"tab\009here\127"`},
+		{"🔑", `"🔑"`},
+		{strings.Repeat("a", 256), `"` + strings.Repeat("a", 255) + `" "a"`},
+		{strings.Repeat("a", 254) + "é", `"` + strings.Repeat("a", 254) + `" "é"`},
+	}
+
+	for _, tt := range tests {
+		rdata := akamai.QuoteTXT(tt.value)
+		assert.Equal(t, tt.rdata, rdata)
+
+		value, err := akamai.UnquoteTXT(rdata)
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.Equal(t, tt.value, value)
+	}
+}
+
+func TestUnquoteTXT(t *testing.T) {
+	value, err := akamai.UnquoteTXT(`"a" "b c"  unquoted\ d \"e\065`)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, `ab cunquoted d"eA`, value)
+
+	for _, rdata := range []string{`"open`, `trailing\`, `"bad \25"`, `"big \256"`, `a"b`} {
+		_, err := akamai.UnquoteTXT(rdata)
+		assert.Error(t, err, rdata)
+	}
+}
+
+func TestTXTRoundTrip(t *testing.T) {
+	assert.True(t, len(trickyTXT) > 300)
+
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	_, _, err := client.FastDNSv2.CreateRecordSet(ctx, akamai.NewTXTRecordSet("example.com", "txt.example.com", 300, trickyTXT, "plain"))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	rs, _, err := client.FastDNSv2.GetRecordSet(ctx, &akamai.RecordSetOptions{Zone: "example.com", Name: "txt.example.com", Type: "TXT"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	values, err := rs.TXTValues()
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, values, 2) {
+		assert.True(t, values[0] == trickyTXT, "value read back differs: %q", values[0])
+		assert.Equal(t, "plain", values[1])
+	}
+}
+
+func TestTXTRequestBody(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := akamai.NewClient(nil, credentials.NewStaticCredentials("secret", "client", "access", server.Listener.Addr().String()))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	_, _, err = client.FastDNSv2.CreateRecordSet(context.Background(), akamai.NewTXTRecordSet("example.com", "txt.example.com", 300, `<a&b> "q" \`))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, bytes.Contains(body, []byte(`"rdata":["\"<a&b> \\\"q\\\" \\\\\""]`)), "unexpected body %s", body)
+}
@@ -0,0 +1,198 @@
+package akamai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// DefaultWireLogMaxBodySize is the default MaxBodySize of WireLogOptions.
+const DefaultWireLogMaxBodySize = 4096
+
+// WireLog is the concise log of a request the client sent, given to the hook set with
+// WithWireLog.
+type WireLog struct {
+	Method   string
+	Path     string
+	Status   int
+	Duration time.Duration
+
+	// RequestBody and ResponseBody are the JSON bodies of the request and response,
+	// compacted or pretty-printed, with their secret fields masked by RedactJSON. They
+	// are nil for the bodies that are not JSON, are larger than the MaxBodySize of the
+	// WireLogOptions, or, for the response, were not read whole.
+	RequestBody  json.RawMessage
+	ResponseBody json.RawMessage
+
+	// Err is the error of a request that got no response, whose Status is then zero.
+	Err error
+}
+
+// String formats the log on a single line, unless its bodies are pretty-printed, such
+// as: PUT /config-dns/v2/zones/example.com 200 35ms request={...} response={...}.
+func (l *WireLog) String() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%v %v", l.Method, l.Path)
+	if l.Err != nil {
+		fmt.Fprintf(&b, " error=%q", l.Err.Error())
+	} else {
+		fmt.Fprintf(&b, " %d", l.Status)
+	}
+	fmt.Fprintf(&b, " %v", l.Duration.Round(time.Millisecond))
+	if l.RequestBody != nil {
+		fmt.Fprintf(&b, " request=%s", l.RequestBody)
+	}
+	if l.ResponseBody != nil {
+		fmt.Fprintf(&b, " response=%s", l.ResponseBody)
+	}
+	return b.String()
+}
+
+// WireLogOptions specifies the optional parameters to WithWireLog.
+type WireLogOptions struct {
+	// MaxBodySize is the size, in bytes, of the largest bodies logged. Defaults to
+	// DefaultWireLogMaxBodySize; a negative size logs no bodies.
+	MaxBodySize int
+
+	// Pretty indents the bodies logged, rather than compacting them on a single line.
+	Pretty bool
+
+	// RedactKeys are the keys of the fields of the bodies masked by RedactJSON.
+	// Defaults to DefaultRedactKeys.
+	RedactKeys []string
+}
+
+// WithWireLog makes the client call hook with the WireLog of every request it sends,
+// once its response is read or it failed. It is meant for day-to-day debugging, such as
+// with log.Print(l), and is cheaper than logging whole requests: bodies are only logged
+// when they are JSON and small, and their secrets are masked. opt may be nil. A nil
+// hook turns the wire log off.
+//
+// WithWireLog must not be called while the client is in use; it returns c so that calls
+// can be chained.
+func (c *Client) WithWireLog(hook func(l *WireLog), opt *WireLogOptions) *Client {
+	o := WireLogOptions{MaxBodySize: DefaultWireLogMaxBodySize, RedactKeys: DefaultRedactKeys}
+	if opt != nil {
+		o.Pretty = opt.Pretty
+		if opt.MaxBodySize != 0 {
+			o.MaxBodySize = opt.MaxBodySize
+		}
+		if opt.RedactKeys != nil {
+			o.RedactKeys = opt.RedactKeys
+		}
+	}
+	c.wireLogHook = hook
+	c.wireLogOptions = o
+	return c
+}
+
+// wireLogRecord records the WireLog of a request. Its methods do nothing on a nil
+// record, that of the clients without a wire log.
+type wireLogRecord struct {
+	log   *WireLog
+	opt   *WireLogOptions
+	start time.Time
+	body  *wireLogBody
+}
+
+// startWireLog returns the record of the WireLog of req, starting now, or nil if the
+// client has no wire log.
+func (c *Client) startWireLog(req *http.Request) *wireLogRecord {
+	if c.wireLogHook == nil {
+		return nil
+	}
+	r := &wireLogRecord{
+		log:   &WireLog{Method: req.Method, Path: req.URL.Path},
+		opt:   &c.wireLogOptions,
+		start: time.Now(),
+	}
+	if req.GetBody != nil && r.fits(req.ContentLength) && isJSONMediaType(mediaType(req.Header.Get("Content-Type"))) {
+		if body, err := req.GetBody(); err == nil {
+			b, err := ioutil.ReadAll(io.LimitReader(body, int64(r.opt.MaxBodySize)+1))
+			body.Close()
+			if err == nil && len(b) <= r.opt.MaxBodySize {
+				r.log.RequestBody = r.format(b)
+			}
+		}
+	}
+	return r
+}
+
+// fits reports whether a body of length n, or -1 if unknown, may be logged.
+func (r *wireLogRecord) fits(n int64) bool {
+	return r.opt.MaxBodySize >= 0 && n <= int64(r.opt.MaxBodySize)
+}
+
+// format returns the JSON body b redacted, and pretty-printed if the options say so.
+func (r *wireLogRecord) format(b []byte) json.RawMessage {
+	b = RedactJSON(b, r.opt.RedactKeys)
+	if b == nil || !r.opt.Pretty {
+		return b
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, b, "", "  "); err != nil {
+		return nil
+	}
+	return out.Bytes()
+}
+
+// response records the status of resp, and has its body captured as it is read.
+func (r *wireLogRecord) response(resp *http.Response) {
+	if r == nil {
+		return
+	}
+	r.log.Status = resp.StatusCode
+	if r.fits(resp.ContentLength) && isJSONMediaType(mediaType(resp.Header.Get("Content-Type"))) {
+		r.body = &wireLogBody{ReadCloser: resp.Body, max: r.opt.MaxBodySize}
+		resp.Body = r.body
+	}
+}
+
+// fail records the error of a request that got no response.
+func (r *wireLogRecord) fail(err error) {
+	if r == nil {
+		return
+	}
+	r.log.Err = err
+}
+
+// done calls the hook of c with the WireLog.
+func (r *wireLogRecord) done(c *Client) {
+	if r == nil {
+		return
+	}
+	r.log.Duration = time.Since(r.start)
+	if r.body != nil && r.body.eof && !r.body.over {
+		r.log.ResponseBody = r.format(r.body.buf.Bytes())
+	}
+	c.wireLogHook(r.log)
+}
+
+// wireLogBody captures a response body up to max bytes as it is read.
+type wireLogBody struct {
+	io.ReadCloser
+	max  int
+	buf  bytes.Buffer
+	over bool
+	eof  bool
+}
+
+func (b *wireLogBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if !b.over {
+		if b.buf.Len()+n > b.max {
+			b.over = true
+			b.buf = bytes.Buffer{}
+		} else {
+			b.buf.Write(p[:n])
+		}
+	}
+	if err == io.EOF {
+		b.eof = true
+	}
+	return n, err
+}
@@ -0,0 +1,104 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWireLog(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"zone": "secondary.example", "type": "SECONDARY", "tsigKey": {"name": "transfer", "algorithm": "hmac-sha256", "secret": "`+tsigSecret+`"}}`)
+	})
+	mux.HandleFunc("/config-dns/v2/zones/big.example", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"zone": "big.example", "comment": %q}`, strings.Repeat("x", DefaultWireLogMaxBodySize))
+	})
+	mux.HandleFunc("/config-dns/v2/zones/text.example", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, `{"secret": "`+tsigSecret+`"}`)
+	})
+
+	var logs []*WireLog
+	client.WithWireLog(func(l *WireLog) { logs = append(logs, l) }, nil)
+	ctx := context.Background()
+
+	_, _, err := client.FastDNSv2.CreateZone(ctx, "1-ABCD", &ZoneCreateRequest{Zone: "secondary.example", Type: "SECONDARY", TSIGKey: tsigSecret, Masters: []string{"192.0.2.53"}})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	_, _, err = client.FastDNSv2.GetZone(ctx, "big.example")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	var text string
+	req, _ := client.NewRequest("GET", "config-dns/v2/zones/text.example", nil)
+	if _, err := client.Do(ctx, req, &text); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if !assert.Len(t, logs, 3) {
+		return
+	}
+
+	l := logs[0]
+	assert.Equal(t, "POST", l.Method)
+	assert.Equal(t, "/config-dns/v2/zones", l.Path)
+	assert.Equal(t, http.StatusCreated, l.Status)
+	assert.True(t, l.Duration > 0, "duration %v", l.Duration)
+	assert.Equal(t, `{"zone":"secondary.example","type":"SECONDARY","tsigKey":"REDACTED","masters":["192.0.2.53"]}`, string(l.RequestBody))
+	assert.Equal(t, `{"zone":"secondary.example","type":"SECONDARY","tsigKey":{"name":"transfer","algorithm":"hmac-sha256","secret":"REDACTED"}}`, string(l.ResponseBody))
+	s := l.String()
+	assert.True(t, strings.HasPrefix(s, "POST /config-dns/v2/zones 201 "), s)
+	assert.NotContains(t, s, "\n")
+	assert.NotContains(t, s, tsigSecret)
+
+	// The bodies over the size cap, and those that are not JSON, are left out.
+	assert.Equal(t, http.StatusOK, logs[1].Status)
+	assert.Nil(t, logs[1].ResponseBody)
+	assert.Nil(t, logs[2].RequestBody)
+	assert.Nil(t, logs[2].ResponseBody)
+	assert.Equal(t, `{"secret": "`+tsigSecret+`"}`, text, "the body is still read by the caller")
+}
+
+func TestWireLogOptions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/zones/example.com", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"zone": "example.com", "comment": "internal"}`)
+	})
+
+	var logs []*WireLog
+	client.WithWireLog(func(l *WireLog) { logs = append(logs, l) }, &WireLogOptions{Pretty: true, RedactKeys: []string{"comment"}})
+	if _, _, err := client.FastDNSv2.GetZone(context.Background(), "example.com"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	client.WithWireLog(func(l *WireLog) { logs = append(logs, l) }, &WireLogOptions{MaxBodySize: -1})
+	if _, _, err := client.FastDNSv2.GetZone(context.Background(), "example.com"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// A request without response is logged with its error.
+	client.BaseURL.Host = "127.0.0.1:1"
+	_, _, err := client.FastDNSv2.GetZone(context.Background(), "example.com")
+	assert.Error(t, err)
+
+	if assert.Len(t, logs, 3) {
+		assert.Equal(t, "{\n  \"zone\": \"example.com\",\n  \"comment\": \"REDACTED\"\n}", string(logs[0].ResponseBody))
+		assert.Nil(t, logs[1].ResponseBody)
+		assert.Equal(t, 0, logs[2].Status)
+		assert.Error(t, logs[2].Err)
+		assert.Contains(t, logs[2].String(), "GET /config-dns/v2/zones/example.com error=")
+	}
+}
@@ -0,0 +1,62 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+)
+
+// ZoneWithContract is a zone listed by ListZonesWithContracts, with the contract it
+// belongs to. Contract is nil if the contract could not be retrieved.
+type ZoneWithContract struct {
+	Zone     *Zone
+	Contract *Contract
+}
+
+// ListZonesWithContracts lists the zones ListAllZones lists with opt, each with its
+// contract, such as for inventory reports. Each distinct contract is retrieved once,
+// with GetZoneContract on one of its zones, concurrently with RunBulk.
+//
+// A contract that can't be retrieved doesn't fail the listing: its zones are returned
+// with a nil Contract, and the error is among the warnings. The error is only returned
+// when the zones can't be listed.
+func (s *FastDNSv2Service) ListZonesWithContracts(ctx context.Context, opt *ZoneListOptions) ([]*ZoneWithContract, []error, error) {
+	zones, err := s.ListAllZones(ctx, opt)
+	if err != nil {
+		return nil, nil, wrapOp("ListZonesWithContracts", "", "", "", err)
+	}
+
+	// The first zone of each contract is the one its contract is retrieved with.
+	var first []*Zone
+	seen := map[string]bool{}
+	for _, z := range zones {
+		id := TrimContractPrefix(z.GetContractID())
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		first = append(first, z)
+	}
+
+	results := RunBulk(ctx, first, nil, func(ctx context.Context, z *Zone) (*Contract, error) {
+		c, _, err := s.GetZoneContract(ctx, z.GetZone())
+		return c, err
+	})
+
+	contracts := map[string]*Contract{}
+	var warnings []error
+	for i, r := range results {
+		id := TrimContractPrefix(first[i].GetContractID())
+		if r.Err != nil {
+			warnings = append(warnings, fmt.Errorf("contract %v: %w", id, r.Err))
+			continue
+		}
+		contracts[id] = r.Value
+	}
+
+	list := make([]*ZoneWithContract, len(zones))
+	for i, z := range zones {
+		list[i] = &ZoneWithContract{Zone: z, Contract: contracts[TrimContractPrefix(z.GetContractID())]}
+	}
+
+	return list, warnings, nil
+}
@@ -0,0 +1,68 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListZonesWithContracts(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"metadata": {"totalElements": 4}, "zones": [
+			{"zone": "a.com", "contractId": "1-AAAAA"},
+			{"zone": "b.com", "contractId": "1-BBBBB"},
+			{"zone": "c.com", "contractId": "1-AAAAA"},
+			{"zone": "d.com", "contractId": "1-CCCCC"}
+		]}`)
+	})
+
+	var mu sync.Mutex
+	lookups := map[string]int{}
+	mux.HandleFunc("/config-dns/v2/zones/", func(w http.ResponseWriter, r *http.Request) {
+		zone := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/config-dns/v2/zones/"), "/contract")
+		mu.Lock()
+		lookups[zone]++
+		mu.Unlock()
+
+		switch zone {
+		case "a.com":
+			fmt.Fprint(w, `{"contractId": "1-AAAAA", "contractName": "Alpha", "zoneCount": 2, "maximumZones": 100}`)
+		case "b.com":
+			fmt.Fprint(w, `{"contractId": "1-BBBBB", "contractName": "Bravo", "zoneCount": 1, "maximumZones": 10}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	zones, warnings, err := client.FastDNSv2.ListZonesWithContracts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.Equal(t, map[string]int{"a.com": 1, "b.com": 1, "d.com": 1}, lookups, "each contract is retrieved once")
+	if assert.Len(t, zones, 4) {
+		names := map[string]string{}
+		for _, z := range zones {
+			names[z.Zone.GetZone()] = z.Contract.GetContractName()
+		}
+		assert.Equal(t, map[string]string{"a.com": "Alpha", "b.com": "Bravo", "c.com": "Alpha", "d.com": ""}, names)
+		assert.Nil(t, zones[3].Contract)
+		assert.Equal(t, 100, zones[2].Contract.MaximumZones)
+	}
+
+	if assert.Len(t, warnings, 1) {
+		assert.True(t, strings.HasPrefix(warnings[0].Error(), "contract 1-CCCCC: "), warnings[0].Error())
+		var ae *AkamaiError
+		assert.True(t, errors.As(warnings[0], &ae))
+	}
+}
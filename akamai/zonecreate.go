@@ -0,0 +1,102 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// CreateZoneOptions specifies the optional parameters to CreateZoneWithOptions.
+type CreateZoneOptions struct {
+	// AdoptExisting makes a zone that already exists count as created, if its type and
+	// contract are those of the request, so that the scripts creating zones can be run
+	// again.
+	AdoptExisting bool
+}
+
+// CreateZoneResult is the zone created or adopted by CreateZoneWithOptions.
+type CreateZoneResult struct {
+	Zone *Zone
+
+	// Adopted reports whether the zone already existed, and was adopted rather than
+	// created.
+	Adopted bool
+}
+
+// ErrZoneMismatch is matched by errors.Is for the errors of CreateZoneWithOptions for
+// existing zones that differ from the request.
+var ErrZoneMismatch = errors.New("existing zone does not match the request")
+
+// ZoneMismatchError is returned by CreateZoneWithOptions when the zone to adopt differs
+// from the request in Field, "type" or "contract".
+type ZoneMismatchError struct {
+	Zone  string
+	Field string
+	Want  string
+	Got   string
+}
+
+func (e *ZoneMismatchError) Error() string {
+	return fmt.Sprintf("zone %v already exists with %v %v, not %v", e.Zone, e.Field, e.Got, e.Want)
+}
+
+// Is makes errors.Is(err, ErrZoneMismatch) report true.
+func (e *ZoneMismatchError) Is(target error) bool {
+	return target == ErrZoneMismatch
+}
+
+// CreateZoneWithOptions creates a zone as CreateZone does. With AdoptExisting, a zone
+// that already exists, for which the API answers 409 Conflict, is read and returned as
+// Adopted if its type and contract are those of the request, and fails with a
+// *ZoneMismatchError otherwise. The zone is then left as is: the other fields of the
+// request, such as its comment, are not applied to it. opt may be nil.
+func (s *FastDNSv2Service) CreateZoneWithOptions(ctx context.Context, cid string, zone *ZoneCreateRequest, opt *CreateZoneOptions) (*CreateZoneResult, *Response, error) {
+	if opt == nil {
+		opt = &CreateZoneOptions{}
+	}
+
+	z, resp, err := s.CreateZone(ctx, cid, zone)
+	switch {
+	case err == nil:
+		return &CreateZoneResult{Zone: z}, resp, nil
+	case !opt.AdoptExisting || !isStatus(err, http.StatusConflict):
+		return nil, resp, err
+	}
+
+	zm, resp, err := s.GetZone(ctx, zone.Zone)
+	if err != nil {
+		return nil, resp, err
+	}
+	if !strings.EqualFold(zm.GetType(), zone.Type) {
+		return nil, resp, wrapOp("CreateZoneWithOptions", zone.Zone, "", "", &ZoneMismatchError{Zone: zone.Zone, Field: "type", Want: strings.ToUpper(zone.Type), Got: zm.GetType()})
+	}
+	if TrimContractPrefix(zm.GetContractID()) != TrimContractPrefix(cid) {
+		return nil, resp, wrapOp("CreateZoneWithOptions", zone.Zone, "", "", &ZoneMismatchError{Zone: zone.Zone, Field: "contract", Want: TrimContractPrefix(cid), Got: zm.GetContractID()})
+	}
+	return &CreateZoneResult{Zone: zoneFromMetadata(zm), Adopted: true}, resp, nil
+}
+
+// zoneFromMetadata returns the Zone holding the fields of zm.
+func zoneFromMetadata(zm *ZoneMetadata) *Zone {
+	return &Zone{
+		ContractID:         zm.ContractID,
+		GroupID:            zm.GroupID,
+		Zone:               zm.Zone,
+		Type:               zm.Type,
+		Comment:            zm.Comment,
+		EndCustomerID:      zm.EndCustomerID,
+		Target:             zm.Target,
+		TSIGKey:            zm.TSIGKey,
+		Masters:            zm.Masters,
+		AliasCount:         zm.AliasCount,
+		SignAndServe:       zm.SignAndServe,
+		SignAndServeAlgo:   zm.SignAndServeAlgorithm,
+		VersionID:          zm.VersionId,
+		LastModifiedDate:   zm.LastModifiedDate,
+		LastModifiedBy:     zm.LastModifiedBy,
+		LastActivationDate: zm.LastActivationDate,
+		ActivationState:    zm.ActivationState,
+	}
+}
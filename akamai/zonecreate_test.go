@@ -0,0 +1,64 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestCreateZoneAdoptExisting(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+	adopt := &akamai.CreateZoneOptions{AdoptExisting: true}
+
+	res, _, err := client.FastDNSv2.CreateZoneWithOptions(ctx, akamaitest.TestContractID, &akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"}, adopt)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.False(t, res.Adopted)
+	assert.Equal(t, "example.com", res.Zone.GetZone())
+
+	// Running it again adopts the zone, whatever the form of the contract ID.
+	res, _, err = client.FastDNSv2.CreateZoneWithOptions(ctx, "ctr_"+akamaitest.TestContractID, &akamai.ZoneCreateRequest{Zone: "example.com", Type: "primary"}, adopt)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.True(t, res.Adopted)
+	assert.Equal(t, "example.com", res.Zone.GetZone())
+	assert.Equal(t, srv.Zone("example.com").GetVersionID(), res.Zone.GetVersionID())
+
+	// Without AdoptExisting, the conflict is returned.
+	_, _, err = client.FastDNSv2.CreateZoneWithOptions(ctx, akamaitest.TestContractID, &akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"}, nil)
+	var ae *akamai.AkamaiError
+	if assert.True(t, errors.As(err, &ae), "got %v", err) {
+		assert.Equal(t, 409, ae.Status)
+	}
+}
+
+func TestCreateZoneAdoptMismatch(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "SECONDARY"})
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.net", Type: "PRIMARY"})
+	adopt := &akamai.CreateZoneOptions{AdoptExisting: true}
+
+	_, _, err := client.FastDNSv2.CreateZoneWithOptions(ctx, akamaitest.TestContractID, &akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"}, adopt)
+	var me *akamai.ZoneMismatchError
+	if assert.True(t, errors.As(err, &me), "got %v", err) {
+		assert.Equal(t, "type", me.Field)
+		assert.Equal(t, "PRIMARY", me.Want)
+		assert.Equal(t, "SECONDARY", me.Got)
+	}
+	assert.True(t, errors.Is(err, akamai.ErrZoneMismatch))
+
+	_, _, err = client.FastDNSv2.CreateZoneWithOptions(ctx, "9-OTHER", &akamai.ZoneCreateRequest{Zone: "example.net", Type: "PRIMARY"}, adopt)
+	if assert.True(t, errors.As(err, &me), "got %v", err) {
+		assert.Equal(t, "contract", me.Field)
+		assert.Equal(t, "9-OTHER", me.Want)
+		assert.Equal(t, akamaitest.TestContractID, me.Got)
+	}
+}
@@ -0,0 +1,70 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ListZonesByGroup lists the zones of the group gid, with the other options of opt.
+func (s *FastDNSv2Service) ListZonesByGroup(ctx context.Context, gid int, opt *ZoneListOptions) (*ZoneList, *Response, error) {
+	var o ZoneListOptions
+	if opt != nil {
+		o = *opt
+	}
+	o.GroupID = gid
+	return s.ListZones(ctx, &o)
+}
+
+// zoneGroupChange is the body of ChangeZoneGroup requests.
+type zoneGroupChange struct {
+	GroupID int `json:"groupId"`
+}
+
+// ChangeZoneGroup moves a zone to the group gid, within its contract.
+//
+// The API may move the zone asynchronously, in which case it answers 202 Accepted:
+// ChangeZoneGroup then returns a nil zone with the response, and WaitForZoneGroup waits
+// for the move to be done.
+func (s *FastDNSv2Service) ChangeZoneGroup(ctx context.Context, zone string, gid int) (*Zone, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp("ChangeZoneGroup", zone, "", "", err)
+	}
+	if err := s.checkZoneProtected(zone); err != nil {
+		return nil, nil, wrapOp("ChangeZoneGroup", zone, "", "", err)
+	}
+
+	u := fmt.Sprintf("config-dns/v2/zones/%v/group", zone)
+	req, err := s.client.NewRequest("PUT", u, &zoneGroupChange{GroupID: gid})
+	if err != nil {
+		return nil, nil, wrapOp("ChangeZoneGroup", zone, "", "", err)
+	}
+
+	z := new(Zone)
+	resp, err := s.client.Do(WithOperation(ctx, "ChangeZoneGroup"), req, z)
+	var accepted *AcceptedError
+	if errors.As(err, &accepted) {
+		return nil, resp, nil
+	}
+	if err != nil {
+		return nil, resp, wrapOp("ChangeZoneGroup", zone, "", "", err)
+	}
+
+	return z, resp, nil
+}
+
+// WaitForZoneGroup polls a zone every interval until it is in the group gid, as after
+// an asynchronous ChangeZoneGroup, and returns its metadata.
+func (s *FastDNSv2Service) WaitForZoneGroup(ctx context.Context, zone string, gid int, interval time.Duration) (*ZoneMetadata, error) {
+	return clientPoll(ctx, s.client, "WaitForZoneGroup", pollEvery(interval), func(zm *ZoneMetadata) string {
+		return fmt.Sprintf("group %d", zm.GetGroupID())
+	}, func(ctx context.Context) (*ZoneMetadata, bool, error) {
+		zm, _, err := s.GetZone(ctx, zone)
+		if err != nil {
+			return nil, false, err
+		}
+		return zm, zm.GetGroupID() == gid, nil
+	})
+}
@@ -0,0 +1,85 @@
+package akamai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestZoneGroupDecode(t *testing.T) {
+	var zm akamai.ZoneMetadata
+	if err := json.Unmarshal([]byte(`{"contractId": "1-ABCDE", "groupId": 12345, "zone": "example.com"}`), &zm); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 12345, zm.GetGroupID())
+
+	var z akamai.Zone
+	if err := json.Unmarshal([]byte(`{"zone": "example.com"}`), &z); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Nil(t, z.GroupID)
+}
+
+func TestChangeZoneGroup(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddGroup(2, "team-b")
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "a.example", Type: "PRIMARY"})
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "b.example", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	z, _, err := client.FastDNSv2.ChangeZoneGroup(ctx, "b.example", 2)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 2, z.GetGroupID())
+
+	zones, _, err := client.FastDNSv2.ListZonesByGroup(ctx, 2, &akamai.ZoneListOptions{ShowAll: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, zones.Zones, 1) {
+		assert.Equal(t, "b.example", zones.Zones[0].GetZone())
+	}
+
+	zones, _, err = client.FastDNSv2.ListZonesByGroup(ctx, akamaitest.TestGroupID, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, zones.Zones, 1) {
+		assert.Equal(t, "a.example", zones.Zones[0].GetZone())
+	}
+
+	_, _, err = client.FastDNSv2.ChangeZoneGroup(ctx, "a.example", 99)
+	var aerr *akamai.AkamaiError
+	if assert.True(t, errors.As(err, &aerr)) {
+		assert.Equal(t, http.StatusBadRequest, aerr.Status)
+	}
+}
+
+func TestChangeZoneGroupAsync(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddGroup(2, "team-b")
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	srv.AsyncGroupChanges = true
+	ctx := context.Background()
+
+	z, resp, err := client.FastDNSv2.ChangeZoneGroup(ctx, "example.com", 2)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Nil(t, z)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	zm, err := client.FastDNSv2.WaitForZoneGroup(ctx, "example.com", 2, time.Millisecond)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, 2, zm.GetGroupID())
+}
@@ -0,0 +1,213 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ZoneLabels are key=value labels of a zone, such as the team owning it. Zones have no
+// labels of their own, so they are kept in the zone comment, as "team=edge;env=prod".
+// A comment with other text keeps it, and holds the labels in brackets at its end:
+// "Managed by Terraform [team=edge;env=prod]". The characters ;=[]\ of the keys and
+// values are escaped with a backslash.
+//
+// A comment made only of key=value pairs separated by semicolons is taken as labels,
+// even if it was written as text.
+type ZoneLabels map[string]string
+
+// ParseZoneLabels returns the labels of a zone comment, and the rest of its text as is.
+// A comment without labels is returned whole as text.
+func ParseZoneLabels(comment string) (ZoneLabels, string) {
+	if labels, ok := parseLabelList(comment); ok {
+		return labels, ""
+	}
+
+	if strings.HasSuffix(comment, "]") && !isEscapedAt(comment, len(comment)-1) {
+		if start := lastUnescaped(comment, '['); start >= 0 {
+			if labels, ok := parseLabelList(comment[start+1 : len(comment)-1]); ok {
+				return labels, strings.TrimSuffix(comment[:start], " ")
+			}
+		}
+	}
+
+	return ZoneLabels{}, comment
+}
+
+// Comment returns the zone comment holding the labels along with text, the inverse of
+// ParseZoneLabels. The labels are sorted by key.
+func (l ZoneLabels) Comment(text string) string {
+	if len(l) == 0 {
+		return text
+	}
+
+	keys := make([]string, 0, len(l))
+	for k := range l {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = escapeLabel(k) + "=" + escapeLabel(l[k])
+	}
+	list := strings.Join(pairs, ";")
+
+	if text == "" {
+		return list
+	}
+	return text + " [" + list + "]"
+}
+
+// parseLabelList parses "k=v;k=v". It reports false if s is not such a list.
+func parseLabelList(s string) (ZoneLabels, bool) {
+	if s == "" {
+		return nil, false
+	}
+
+	labels := ZoneLabels{}
+	for _, pair := range splitUnescaped(s, ';') {
+		kv := splitUnescaped(pair, '=')
+		if len(kv) != 2 {
+			return nil, false
+		}
+		k, ok := unescapeLabel(strings.TrimSpace(kv[0]))
+		if !ok || k == "" {
+			return nil, false
+		}
+		v, ok := unescapeLabel(kv[1])
+		if !ok {
+			return nil, false
+		}
+		labels[k] = v
+	}
+	return labels, true
+}
+
+const labelSpecials = `\;=[]`
+
+func escapeLabel(s string) string {
+	if !strings.ContainsAny(s, labelSpecials) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(labelSpecials, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// unescapeLabel undoes escapeLabel. It reports false for unescaped special characters
+// and dangling backslashes, which aren't in labels.
+func unescapeLabel(s string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			if i+1 == len(s) || !strings.ContainsRune(labelSpecials, rune(s[i+1])) {
+				return "", false
+			}
+			i++
+			b.WriteByte(s[i])
+		case strings.ContainsRune(labelSpecials, rune(c)):
+			return "", false
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), true
+}
+
+// splitUnescaped splits s around the occurrences of sep that are not escaped.
+func splitUnescaped(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// lastUnescaped returns the index of the last occurrence of c in s that is not escaped,
+// or -1.
+func lastUnescaped(s string, c byte) int {
+	last := -1
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case c:
+			last = i
+		}
+	}
+	return last
+}
+
+// isEscapedAt reports whether the byte at i of s is escaped by a backslash.
+func isEscapedAt(s string, i int) bool {
+	n := 0
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// GetZoneLabels returns the labels of a zone, from its comment. See ZoneLabels.
+func (s *FastDNSv2Service) GetZoneLabels(ctx context.Context, zone string) (ZoneLabels, *Response, error) {
+	zm, resp, err := s.GetZone(ctx, zone)
+	if err != nil {
+		if oe, ok := OperationFromError(err); ok {
+			oe.Op = "GetZoneLabels"
+		}
+		return nil, resp, err
+	}
+
+	labels, _ := ParseZoneLabels(zm.GetComment())
+	return labels, resp, nil
+}
+
+// SetZoneLabel sets a label of a zone, or removes it if value is empty, keeping the
+// other labels and the text of its comment. See ZoneLabels and updateZoneFields.
+func (s *FastDNSv2Service) SetZoneLabel(ctx context.Context, zone, key, value string) (*Zone, *Response, error) {
+	if key == "" {
+		return nil, nil, wrapOp("SetZoneLabel", zone, "", "", errors.New("empty label key"))
+	}
+	return s.updateZoneFields(ctx, "SetZoneLabel", zone, func(zu *zoneUpdate) {
+		labels, text := ParseZoneLabels(zu.Comment)
+		if value == "" {
+			delete(labels, key)
+		} else {
+			labels[key] = value
+		}
+		zu.Comment = labels.Comment(text)
+	})
+}
+
+// ListZonesByLabel returns the zones whose label key is value, or that have the label
+// key if value is empty. The zones are listed with ListAllZones and filtered by the
+// client.
+func (s *FastDNSv2Service) ListZonesByLabel(ctx context.Context, key, value string) ([]*Zone, error) {
+	zones, err := s.ListAllZones(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*Zone
+	for _, z := range zones {
+		labels, _ := ParseZoneLabels(z.GetComment())
+		if v, ok := labels[key]; ok && (value == "" || v == value) {
+			matched = append(matched, z)
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,109 @@
+package akamai_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestZoneLabelsRoundTrip(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		comment string
+		labels  akamai.ZoneLabels
+		text    string
+	}{
+		{"empty", "", akamai.ZoneLabels{}, ""},
+		{"labels", "env=prod;team=edge", akamai.ZoneLabels{"team": "edge", "env": "prod"}, ""},
+		{"text", "Managed by Terraform", akamai.ZoneLabels{}, "Managed by Terraform"},
+		{"text and labels", "Managed by Terraform [env=prod;team=edge]", akamai.ZoneLabels{"team": "edge", "env": "prod"}, "Managed by Terraform"},
+		{"text with delimiters", "a=b; see [runbook]; c=", akamai.ZoneLabels{}, "a=b; see [runbook]; c="},
+		{"text with brackets and labels", "see [runbook] [team=edge]", akamai.ZoneLabels{"team": "edge"}, "see [runbook]"},
+		{"escaped delimiters", `note=a\;b\=c;path=C:\\dns;tag=\[x\]`, akamai.ZoneLabels{"note": "a;b=c", "path": `C:\dns`, "tag": "[x]"}, ""},
+		{"escaped delimiters and text", `Owner: see wiki [note=a\;b\=c\]]`, akamai.ZoneLabels{"note": "a;b=c]"}, "Owner: see wiki"},
+		{"empty value", "team=", akamai.ZoneLabels{"team": ""}, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			labels, text := akamai.ParseZoneLabels(tt.comment)
+			assert.Equal(t, tt.labels, labels)
+			assert.Equal(t, tt.text, text)
+			assert.Equal(t, tt.comment, labels.Comment(text), "the comment is written back verbatim")
+		})
+	}
+}
+
+func TestParseZoneLabelsNotLabels(t *testing.T) {
+	for _, comment := range []string{
+		"=prod",
+		"team=edge;;env=prod",
+		"team=edge=core",
+		`team=edge\`,
+		"[team=edge",
+		"see [runbook=]x",
+	} {
+		labels, text := akamai.ParseZoneLabels(comment)
+		assert.Equal(t, akamai.ZoneLabels{}, labels, comment)
+		assert.Equal(t, comment, text)
+	}
+}
+
+func TestSetZoneLabel(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY", Comment: "Managed by Terraform; do not edit"})
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.net", Type: "PRIMARY", Comment: "team=core"})
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.org", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	if _, _, err := client.FastDNSv2.SetZoneLabel(ctx, "example.com", "team", "edge;dns"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if _, _, err := client.FastDNSv2.SetZoneLabel(ctx, "example.com", "env", "prod"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, `Managed by Terraform; do not edit [env=prod;team=edge\;dns]`, srv.Zone("example.com").GetComment())
+
+	labels, _, err := client.FastDNSv2.GetZoneLabels(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, akamai.ZoneLabels{"team": "edge;dns", "env": "prod"}, labels)
+
+	if _, _, err := client.FastDNSv2.SetZoneLabel(ctx, "example.net", "env", "prod"); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "env=prod;team=core", srv.Zone("example.net").GetComment())
+
+	zones, err := client.FastDNSv2.ListZonesByLabel(ctx, "env", "prod")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	var names []string
+	for _, z := range zones {
+		names = append(names, z.GetZone())
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"example.com", "example.net"}, names)
+
+	zones, err = client.FastDNSv2.ListZonesByLabel(ctx, "team", "core")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, zones, 1) {
+		assert.Equal(t, "example.net", zones[0].GetZone())
+	}
+
+	// Removing the last label leaves the text alone.
+	for _, key := range []string{"team", "env"} {
+		if _, _, err := client.FastDNSv2.SetZoneLabel(ctx, "example.com", key, ""); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+	}
+	assert.Equal(t, "Managed by Terraform; do not edit", srv.Zone("example.com").GetComment())
+
+	_, _, err = client.FastDNSv2.SetZoneLabel(ctx, "example.org", "", "x")
+	assert.Error(t, err)
+}
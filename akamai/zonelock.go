@@ -0,0 +1,143 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ZoneLocks is a registry of per-zone locks that serializes the operations a process
+// makes on the same zone, such as the change lists of which Edge DNS allows only one per
+// zone, while operations on different zones proceed in parallel. It is safe for
+// concurrent use.
+//
+// Set it on Client.ZoneLocks to have SyncRecordSets, ApplySyncPlan, ReplaceRecordSets
+// and OnboardZones hold the lock of their zone, and use Do or Lock to hold it around
+// sequences of calls, such as the creation, edition and submission of a change list.
+// Clients sharing a registry serialize with each other.
+//
+// The locks are reentrant through contexts: the context returned by Lock, and given to
+// the function of Do, holds the lock, and locking the same zone with it again doesn't
+// wait.
+type ZoneLocks struct {
+	mu    sync.Mutex
+	zones map[string]*zoneLock
+}
+
+type zoneLock struct {
+	sem chan struct{}
+
+	// refs counts the holder and waiters of the lock, which is dropped from the
+	// registry once it has none.
+	refs int
+}
+
+// heldZoneKey is the key of the contexts holding the lock of zone in locks.
+type heldZoneKey struct {
+	locks *ZoneLocks
+	zone  string
+}
+
+// NewZoneLocks returns an empty registry of zone locks.
+func NewZoneLocks() *ZoneLocks {
+	return &ZoneLocks{zones: map[string]*zoneLock{}}
+}
+
+// Lock waits until it holds the lock of zone, and returns a copy of ctx that holds it
+// with the function that releases it. If ctx is done first, it returns ctx.Err(). A nil
+// registry doesn't lock.
+func (l *ZoneLocks) Lock(ctx context.Context, zone string) (context.Context, func(), error) {
+	return l.lock(ctx, zone, true)
+}
+
+// TryLock takes the lock of zone if it is free, and returns a copy of ctx that holds it
+// with the function that releases it. It reports false, without waiting and with a nil
+// function, if the lock is held elsewhere.
+func (l *ZoneLocks) TryLock(ctx context.Context, zone string) (context.Context, func(), bool) {
+	ctx, unlock, err := l.lock(ctx, zone, false)
+	return ctx, unlock, err == nil
+}
+
+// Do calls fn holding the lock of zone, with a context that holds it. It returns the
+// error of fn, or ctx.Err() if ctx is done before the lock is free.
+func (l *ZoneLocks) Do(ctx context.Context, zone string, fn func(ctx context.Context) error) error {
+	ctx, unlock, err := l.Lock(ctx, zone)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return fn(ctx)
+}
+
+// errZoneLocked is returned by lock when it doesn't wait for a held lock.
+var errZoneLocked = errors.New("zone is locked")
+
+func (l *ZoneLocks) lock(ctx context.Context, zone string, wait bool) (context.Context, func(), error) {
+	if l == nil {
+		return ctx, func() {}, nil
+	}
+
+	key := heldZoneKey{locks: l, zone: zoneLockName(zone)}
+	if ctx.Value(key) != nil {
+		return ctx, func() {}, nil
+	}
+
+	l.mu.Lock()
+	if l.zones == nil {
+		l.zones = map[string]*zoneLock{}
+	}
+	zl, ok := l.zones[key.zone]
+	if !ok {
+		zl = &zoneLock{sem: make(chan struct{}, 1)}
+		l.zones[key.zone] = zl
+	}
+	zl.refs++
+	l.mu.Unlock()
+
+	release := func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if zl.refs--; zl.refs == 0 {
+			delete(l.zones, key.zone)
+		}
+	}
+
+	select {
+	case zl.sem <- struct{}{}:
+	default:
+		if !wait {
+			release()
+			return ctx, nil, errZoneLocked
+		}
+		select {
+		case zl.sem <- struct{}{}:
+		case <-ctx.Done():
+			release()
+			return ctx, nil, ctx.Err()
+		}
+	}
+
+	var once sync.Once
+	unlock := func() {
+		once.Do(func() {
+			<-zl.sem
+			release()
+		})
+	}
+	return context.WithValue(ctx, key, true), unlock, nil
+}
+
+// zoneLockName returns the name zone is locked under, so that the spellings of a zone
+// share its lock.
+func zoneLockName(zone string) string {
+	if n, err := NormalizeZoneName(zone); err == nil {
+		return n
+	}
+	return strings.ToLower(strings.TrimSuffix(zone, "."))
+}
+
+// lockZone takes the lock of zone in the client's registry, if it has one.
+func (s *FastDNSv2Service) lockZone(ctx context.Context, zone string) (context.Context, func(), error) {
+	return s.client.ZoneLocks.Lock(ctx, zone)
+}
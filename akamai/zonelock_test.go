@@ -0,0 +1,147 @@
+package akamai_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestZoneLocksSerializeZones(t *testing.T) {
+	locks := akamai.NewZoneLocks()
+	zones := []string{"a.example", "b.example", "c.example", "d.example"}
+
+	var (
+		inFlight [4]int32
+		maxSeen  [4]int32
+		total    int32
+		parallel int32
+		wg       sync.WaitGroup
+	)
+	for g := 0; g < 32; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				// Every goroutine works on an overlapping pair of zones, spelled
+				// differently.
+				z := (g + i) % len(zones)
+				name := zones[z]
+				if i%2 == 0 {
+					name = "B" + name[1:] + "."
+					z = 1
+				}
+
+				err := locks.Do(context.Background(), name, func(ctx context.Context) error {
+					n := atomic.AddInt32(&inFlight[z], 1)
+					for {
+						m := atomic.LoadInt32(&maxSeen[z])
+						if n <= m || atomic.CompareAndSwapInt32(&maxSeen[z], m, n) {
+							break
+						}
+					}
+					if atomic.AddInt32(&total, 1) > 1 {
+						atomic.StoreInt32(&parallel, 1)
+					}
+					time.Sleep(50 * time.Microsecond)
+					atomic.AddInt32(&total, -1)
+					atomic.AddInt32(&inFlight[z], -1)
+					return nil
+				})
+				assert.NoError(t, err)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	for z := range zones {
+		assert.Equal(t, int32(1), maxSeen[z], "zone %v", zones[z])
+	}
+	assert.Equal(t, int32(1), parallel, "different zones must proceed in parallel")
+}
+
+func TestZoneLocksTryLockAndTimeout(t *testing.T) {
+	locks := akamai.NewZoneLocks()
+	ctx := context.Background()
+
+	held, unlock, err := locks.Lock(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	_, _, ok := locks.TryLock(ctx, "Example.com.")
+	assert.False(t, ok)
+
+	// Locking again with the context holding the lock doesn't wait.
+	_, again, ok := locks.TryLock(held, "example.com")
+	assert.True(t, ok)
+	again()
+
+	_, other, ok := locks.TryLock(ctx, "example.net")
+	assert.True(t, ok)
+	other()
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, _, err = locks.Lock(timeout, "example.com")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	unlock()
+	unlock() // Releasing twice is harmless.
+
+	_, unlock, ok = locks.TryLock(ctx, "example.com")
+	assert.True(t, ok)
+	unlock()
+
+	var nilLocks *akamai.ZoneLocks
+	assert.NoError(t, nilLocks.Do(ctx, "example.com", func(ctx context.Context) error { return nil }))
+}
+
+func TestSyncRecordSetsHoldsZoneLock(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	client.ZoneLocks = akamai.NewZoneLocks()
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		srv.AddZone(&akamai.ZoneCreateRequest{Zone: fmt.Sprintf("z%d.example", i), Type: "PRIMARY"})
+	}
+
+	// The zone is held elsewhere: the sync waits for it until its context is done.
+	_, unlock, err := client.ZoneLocks.Lock(ctx, "z0.example")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	desired := []*akamai.RecordSetCreateRequest{{Name: "www.z0.example", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}}}
+	_, err = client.FastDNSv2.SyncRecordSets(timeout, "z0.example", desired, nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Empty(t, srv.Requests())
+	unlock()
+
+	// Concurrent syncs of overlapping zones all apply.
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			zone := fmt.Sprintf("z%d.example", g%4)
+			desired := []*akamai.RecordSetCreateRequest{
+				{Name: fmt.Sprintf("host%d.%v", g, zone), Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}},
+			}
+			_, err := client.FastDNSv2.SyncRecordSets(ctx, zone, desired, nil)
+			assert.NoError(t, err)
+		}(g)
+	}
+	wg.Wait()
+
+	for i := 0; i < 4; i++ {
+		// SOA, NS and the four hosts synced into the zone.
+		assert.Len(t, srv.RecordSets(fmt.Sprintf("z%d.example", i)), 6)
+	}
+}
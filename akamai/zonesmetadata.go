@@ -0,0 +1,102 @@
+package akamai
+
+import (
+	"context"
+)
+
+// ZonesMetadataOptions specifies the optional parameters to GetZonesMetadata.
+type ZonesMetadataOptions struct {
+	// ContractIDs, if set, restricts the listing to the zones of these contracts, a
+	// comma-separated list, as the ContractIDs of ZoneListOptions does. The zones of
+	// other contracts are then read with GetZone.
+	ContractIDs string
+
+	// Detail reads every zone with GetZone rather than from the listing, for the callers
+	// that need the zones as the detail endpoint returns them.
+	Detail bool
+
+	// Bulk configures the concurrency of the GetZone calls.
+	Bulk *BulkOptions
+}
+
+// ZoneMetadataResult is the metadata of a zone read by GetZonesMetadata, or the error
+// reading it.
+type ZoneMetadataResult struct {
+	Metadata *ZoneMetadata
+
+	// Listed reports whether the metadata is that of the listing of the zones, rather
+	// than of GetZone.
+	Listed bool
+
+	Err error
+}
+
+// GetZonesMetadata reads the metadata of many zones, such as for inventory jobs, with
+// as few requests as it can. The zones are listed with ListAllZones, which pages
+// through them as it does, and only the zones the listing doesn't hold, or all of them
+// if the listing fails, are read with GetZone, concurrently with RunBulk. A single zone
+// is always read with GetZone, as is every zone with opt.Detail. opt may be nil.
+//
+// The results are keyed by zone, as given, each with its own error, such as that of a
+// zone that doesn't exist. The error returned is that of ctx, if it is done before all
+// the zones are read.
+func (s *FastDNSv2Service) GetZonesMetadata(ctx context.Context, zones []string, opt *ZonesMetadataOptions) (map[string]*ZoneMetadataResult, error) {
+	if opt == nil {
+		opt = &ZonesMetadataOptions{}
+	}
+
+	results := make(map[string]*ZoneMetadataResult, len(zones))
+	if len(zones) > 1 && !opt.Detail {
+		listed, err := s.ListAllZones(ctx, &ZoneListOptions{ContractIDs: opt.ContractIDs})
+		if err == nil {
+			byName := make(map[string]*Zone, len(listed))
+			for _, z := range listed {
+				byName[normalizeHost(z.GetZone())] = z
+			}
+			for _, zone := range zones {
+				if z, ok := byName[normalizeHost(zone)]; ok {
+					results[zone] = &ZoneMetadataResult{Metadata: metadataFromZone(z), Listed: true}
+				}
+			}
+		}
+	}
+
+	var rest []string
+	for _, zone := range zones {
+		if _, ok := results[zone]; !ok {
+			results[zone] = nil
+			rest = append(rest, zone)
+		}
+	}
+	for i, r := range RunBulk(ctx, rest, opt.Bulk, func(ctx context.Context, zone string) (*ZoneMetadata, error) {
+		zm, _, err := s.GetZone(ctx, zone)
+		return zm, err
+	}) {
+		results[rest[i]] = &ZoneMetadataResult{Metadata: r.Value, Err: r.Err}
+	}
+
+	return results, ctx.Err()
+}
+
+// metadataFromZone returns the ZoneMetadata holding the fields of a listed zone.
+func metadataFromZone(z *Zone) *ZoneMetadata {
+	return &ZoneMetadata{
+		ContractID:            z.ContractID,
+		GroupID:               z.GroupID,
+		Zone:                  z.Zone,
+		Type:                  z.Type,
+		EndCustomerID:         z.EndCustomerID,
+		Target:                z.Target,
+		TSIGKey:               z.TSIGKey,
+		Masters:               z.Masters,
+		AliasCount:            z.AliasCount,
+		SignAndServe:          z.SignAndServe,
+		SignAndServeAlgorithm: z.SignAndServeAlgo,
+		VersionId:             z.VersionID,
+		LastModifiedDate:      z.LastModifiedDate,
+		LastModifiedBy:        z.LastModifiedBy,
+		LastActivationDate:    z.LastActivationDate,
+		ActivationState:       z.ActivationState,
+		Comment:               z.Comment,
+	}
+}
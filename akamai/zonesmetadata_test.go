@@ -0,0 +1,76 @@
+package akamai_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestGetZonesMetadata(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+
+	var zones []string
+	for i := 0; i < 40; i++ {
+		zone := fmt.Sprintf("zone%02d.example", i)
+		srv.AddZone(&akamai.ZoneCreateRequest{Zone: zone, Type: "PRIMARY", Comment: zone})
+		if i%2 == 0 {
+			zones = append(zones, zone)
+		}
+	}
+	zones = append(zones, "missing.example", "Zone01.example.")
+
+	before := len(srv.Requests())
+	results, err := client.FastDNSv2.GetZonesMetadata(ctx, zones, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// One listing, and a GetZone for the zone it doesn't hold, rather than 22 GetZone.
+	assert.Len(t, srv.Requests()[before:], 2)
+	assert.Equal(t, 1, countRequests(srv, "GET /config-dns/v2/zones/missing.example"))
+
+	assert.Len(t, results, len(zones))
+	for _, zone := range zones[:20] {
+		r := results[zone]
+		if assert.NotNil(t, r, zone) {
+			assert.NoError(t, r.Err)
+			assert.True(t, r.Listed, zone)
+			assert.Equal(t, zone, r.Metadata.GetZone())
+			assert.Equal(t, zone, r.Metadata.GetComment())
+			assert.Equal(t, srv.Zone(zone).GetVersionID(), r.Metadata.GetVersionId())
+		}
+	}
+	assert.True(t, results["Zone01.example."].Listed)
+	assert.Equal(t, "zone01.example", results["Zone01.example."].Metadata.GetZone())
+	assert.Nil(t, results["missing.example"].Metadata)
+	assert.True(t, isStatus(results["missing.example"].Err, 404), "got %v", results["missing.example"].Err)
+
+	// Detail reads every zone with GetZone.
+	before = len(srv.Requests())
+	results, err = client.FastDNSv2.GetZonesMetadata(ctx, zones[:5], &akamai.ZonesMetadataOptions{Detail: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, srv.Requests()[before:], 5)
+	for _, zone := range zones[:5] {
+		assert.False(t, results[zone].Listed)
+		assert.Equal(t, zone, results[zone].Metadata.GetZone())
+	}
+
+	// The zones of other contracts than those listed are read with GetZone.
+	before = len(srv.Requests())
+	results, err = client.FastDNSv2.GetZonesMetadata(ctx, zones[:3], &akamai.ZonesMetadataOptions{ContractIDs: "9-OTHER"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, srv.Requests()[before:], 4)
+	for _, zone := range zones[:3] {
+		assert.NoError(t, results[zone].Err)
+		assert.False(t, results[zone].Listed)
+	}
+}
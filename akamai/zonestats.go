@@ -0,0 +1,179 @@
+package akamai
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// ZoneStats counts the record sets and records of a zone, for hygiene reports.
+type ZoneStats struct {
+	Zone string
+
+	// RecordSets and Records are the numbers of record sets and of records of each
+	// type, and TotalRecordSets and TotalRecords those of the whole zone.
+	RecordSets      map[string]int
+	Records         map[string]int
+	TotalRecordSets int
+	TotalRecords    int
+
+	// MinTTL and MaxTTL are the lowest and highest TTL of the record sets of the zone,
+	// or zero if it has none.
+	MinTTL int
+	MaxTTL int
+}
+
+// ZoneStats counts the record sets and records of a zone by type. The record sets are
+// read with ForEachRecordSet, so that the memory used doesn't grow with the size of the
+// zone.
+func (s *FastDNSv2Service) ZoneStats(ctx context.Context, zone string) (*ZoneStats, error) {
+	stats := &ZoneStats{Zone: zone, RecordSets: map[string]int{}, Records: map[string]int{}}
+	err := s.ForEachRecordSet(ctx, zone, nil, func(rs *RecordSet) error {
+		rtype := strings.ToUpper(rs.GetType())
+		stats.RecordSets[rtype]++
+		stats.Records[rtype] += len(rs.Rdata)
+		stats.TotalRecordSets++
+		stats.TotalRecords += len(rs.Rdata)
+
+		ttl := rs.GetTTL()
+		if stats.TotalRecordSets == 1 || ttl < stats.MinTTL {
+			stats.MinTTL = ttl
+		}
+		if ttl > stats.MaxTTL {
+			stats.MaxTTL = ttl
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, wrapOp("ZoneStats", zone, "", "", err)
+	}
+	return stats, nil
+}
+
+// HostResolver looks up the addresses of a host. It is implemented by *net.Resolver.
+type HostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// DanglingCNAMEOptions specifies the optional parameters to FindDanglingCNAMEs.
+type DanglingCNAMEOptions struct {
+	// Resolver, if set, looks up the targets that are outside the zones given to
+	// FindDanglingCNAMEs, which are otherwise not checked.
+	Resolver HostResolver
+}
+
+// DanglingCNAME is a CNAME record set found by FindDanglingCNAMEs, whose target doesn't
+// exist.
+type DanglingCNAME struct {
+	Zone   string
+	Name   string
+	Target string
+
+	// External reports whether the target is outside the zones given to
+	// FindDanglingCNAMEs, and was looked up in the DNS.
+	External bool
+
+	// Err is the error of the lookup of an External target, such as a *net.DNSError for
+	// a name that doesn't exist. A target whose lookup fails for another reason, such as
+	// a timeout, is reported too, as it couldn't be found.
+	Err error
+}
+
+// FindDanglingCNAMEs finds the CNAME record sets of zones whose target doesn't exist.
+// The targets within the zones are looked up among the record sets of the zones, of
+// any type, including those matched by a wildcard. The targets outside of them are only
+// looked up in the DNS with the Resolver of opt, if it is set. opt may be nil.
+//
+// The record sets are read with ForEachRecordSet; only their names and the CNAME
+// record sets are kept. The findings are sorted by zone, in the order of zones, and by
+// name.
+func (s *FastDNSv2Service) FindDanglingCNAMEs(ctx context.Context, zones []string, opt *DanglingCNAMEOptions) ([]*DanglingCNAME, error) {
+	if opt == nil {
+		opt = &DanglingCNAMEOptions{}
+	}
+
+	names := map[string]bool{}
+	var cnames []*DanglingCNAME
+	for _, zone := range zones {
+		err := s.ForEachRecordSet(ctx, zone, nil, func(rs *RecordSet) error {
+			names[normalizeHost(rs.GetName())] = true
+			if strings.EqualFold(rs.GetType(), RRTypeCname) && len(rs.Rdata) > 0 {
+				cnames = append(cnames, &DanglingCNAME{Zone: zone, Name: rs.GetName(), Target: normalizeHost(StringValue(rs.Rdata[0]))})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, wrapOp("FindDanglingCNAMEs", zone, "", "", err)
+		}
+	}
+
+	var found []*DanglingCNAME
+	for _, c := range cnames {
+		if zone := enclosingZone(c.Target, zones); zone != "" {
+			if !nameExists(c.Target, zone, names) {
+				found = append(found, c)
+			}
+			continue
+		}
+		if opt.Resolver == nil {
+			continue
+		}
+
+		c.External = true
+		if _, err := opt.Resolver.LookupHost(ctx, c.Target); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			c.Err = err
+			found = append(found, c)
+		}
+	}
+
+	order := map[string]int{}
+	for i, z := range zones {
+		order[z] = i
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].Zone != found[j].Zone {
+			return order[found[i].Zone] < order[found[j].Zone]
+		}
+		return found[i].Name < found[j].Name
+	})
+	return found, nil
+}
+
+// enclosingZone returns the zone of zones that name is in, the longest if they nest, or
+// "" if none.
+func enclosingZone(name string, zones []string) string {
+	best := ""
+	for _, z := range zones {
+		z = normalizeHost(z)
+		if (name == z || strings.HasSuffix(name, "."+z)) && len(z) > len(best) {
+			best = z
+		}
+	}
+	return best
+}
+
+// nameExists reports whether name, in zone, is among names, or is matched by a wildcard
+// among them: that of its closest ancestor in zone that has one.
+func nameExists(name, zone string, names map[string]bool) bool {
+	if names[name] {
+		return true
+	}
+	for parent := name; parent != zone; {
+		_, rest, ok := strings.Cut(parent, ".")
+		if !ok {
+			return false
+		}
+		parent = rest
+		if names["*."+parent] {
+			return true
+		}
+		if names[parent] {
+			// A name that exists stops the wildcards of its ancestors.
+			return false
+		}
+	}
+	return false
+}
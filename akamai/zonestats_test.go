@@ -0,0 +1,101 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+// hostResolver answers host lookups from a set of names, and fails for the others.
+type hostResolver map[string]bool
+
+func (r hostResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if !r[host] {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return []string{"192.0.2.1"}, nil
+}
+
+// newHygieneServer returns a fake server with two zones, whose CNAME record sets point
+// within a zone, across zones, at a wildcard, and outside of them. Two are dangling:
+// old.example.com within the zones, and gone.example.com outside of them.
+func newHygieneServer(t *testing.T) (*akamai.Client, *akamaitest.Server) {
+	client, srv := akamaitest.NewServer(t)
+	for _, z := range []string{"example.com", "example.net"} {
+		srv.AddZone(&akamai.ZoneCreateRequest{Zone: z, Type: "PRIMARY"})
+	}
+	for _, rs := range []*akamai.RecordSetCreateRequest{
+		{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(60), Rdata: []string{"192.0.2.1", "192.0.2.2"}},
+		{Zone: "example.com", Name: "app.example.com", Type: "CNAME", TTL: akamai.Int(300), Rdata: []string{"www.example.com."}},
+		{Zone: "example.com", Name: "old.example.com", Type: "CNAME", TTL: akamai.Int(300), Rdata: []string{"retired.example.com."}},
+		{Zone: "example.com", Name: "api.example.com", Type: "CNAME", TTL: akamai.Int(300), Rdata: []string{"API.example.net."}},
+		{Zone: "example.com", Name: "tenant.example.com", Type: "CNAME", TTL: akamai.Int(300), Rdata: []string{"a.apps.example.net."}},
+		{Zone: "example.com", Name: "cdn.example.com", Type: "CNAME", TTL: akamai.Int(300), Rdata: []string{"edge.provider.test."}},
+		{Zone: "example.com", Name: "gone.example.com", Type: "CNAME", TTL: akamai.Int(300), Rdata: []string{"deleted.provider.test."}},
+		{Zone: "example.net", Name: "api.example.net", Type: "A", TTL: akamai.Int(86400), Rdata: []string{"192.0.2.3"}},
+		{Zone: "example.net", Name: "*.apps.example.net", Type: "A", TTL: akamai.Int(600), Rdata: []string{"192.0.2.4"}},
+	} {
+		srv.AddRecordSet(rs)
+	}
+	return client, srv
+}
+
+func TestZoneStats(t *testing.T) {
+	client, _ := newHygieneServer(t)
+
+	stats, err := client.FastDNSv2.ZoneStats(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, map[string]int{"A": 1, "CNAME": 6, "NS": 1, "SOA": 1}, stats.RecordSets)
+	assert.Equal(t, 9, stats.TotalRecordSets)
+	assert.Equal(t, 2, stats.Records["A"])
+	assert.Equal(t, 6, stats.Records["CNAME"])
+	assert.Equal(t, 60, stats.MinTTL)
+	assert.Equal(t, 86400, stats.MaxTTL)
+
+	_, err = client.FastDNSv2.ZoneStats(context.Background(), "missing.example")
+	assert.Error(t, err)
+}
+
+func TestFindDanglingCNAMEs(t *testing.T) {
+	client, _ := newHygieneServer(t)
+	ctx := context.Background()
+	zones := []string{"example.com", "example.net"}
+
+	// Without a resolver, the targets outside the zones are not checked.
+	found, err := client.FastDNSv2.FindDanglingCNAMEs(ctx, zones, nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, []*akamai.DanglingCNAME{{Zone: "example.com", Name: "old.example.com", Target: "retired.example.com"}}, found)
+
+	found, err = client.FastDNSv2.FindDanglingCNAMEs(ctx, zones, &akamai.DanglingCNAMEOptions{Resolver: hostResolver{"edge.provider.test": true}})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, found, 2) {
+		gone := found[0]
+		assert.Equal(t, "gone.example.com", gone.Name)
+		assert.Equal(t, "deleted.provider.test", gone.Target)
+		assert.True(t, gone.External)
+		var dnsErr *net.DNSError
+		if assert.True(t, errors.As(gone.Err, &dnsErr), "got %v", gone.Err) {
+			assert.True(t, dnsErr.IsNotFound)
+		}
+		assert.Equal(t, "old.example.com", found[1].Name)
+		assert.False(t, found[1].External)
+	}
+
+	// A target in a zone that is not given is external.
+	found, err = client.FastDNSv2.FindDanglingCNAMEs(ctx, zones[:1], nil)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, found, 1)
+}
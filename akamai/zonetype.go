@@ -0,0 +1,83 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// zoneTypeTTL is how long the client remembers the type of a zone for its checks of
+// record set writes.
+const zoneTypeTTL = 10 * time.Minute
+
+// ErrZoneNotEditable is matched by errors.Is for the errors of record set writes to
+// zones whose records can't be edited through the API.
+var ErrZoneNotEditable = errors.New("zone records are not editable")
+
+// ZoneNotEditableError is returned, before any request is made, by CreateRecordSet,
+// UpdateRecordSet and DeleteRecordSet for zones whose records can't be edited: the
+// records of SECONDARY zones are transferred from their masters, and ALIAS zones serve
+// the records of their target. See Client.DisableZoneTypeCheck.
+type ZoneNotEditableError struct {
+	Zone string
+	Type string
+}
+
+func (e *ZoneNotEditableError) Error() string {
+	reason := "its records can't be edited"
+	switch e.Type {
+	case "SECONDARY":
+		reason = "its records are transferred from its masters"
+	case "ALIAS":
+		reason = "it serves the records of its target zone"
+	}
+	return fmt.Sprintf("%v: %v is a %v zone and %v", ErrZoneNotEditable, e.Zone, e.Type, reason)
+}
+
+// Is makes errors.Is(err, ErrZoneNotEditable) report true.
+func (e *ZoneNotEditableError) Is(target error) bool {
+	return target == ErrZoneNotEditable
+}
+
+// checkZoneEditable returns a *ZoneNotEditableError if zone is a SECONDARY or ALIAS
+// zone. The zone type is looked up with GetZone and cached for zoneTypeTTL, so that
+// writes don't each make a request. If the lookup fails the write is let through, for
+// the API to answer it.
+func (s *FastDNSv2Service) checkZoneEditable(ctx context.Context, zone string) error {
+	if s.client.DisableZoneTypeCheck || s.client.zoneTypes == nil {
+		return nil
+	}
+
+	v, _, err := s.client.zoneTypes.get(ctx, zone, func() (interface{}, *Response, error) {
+		zm, resp, err := s.GetZone(ctx, zone)
+		if err != nil {
+			return nil, resp, err
+		}
+		return zm.GetType(), resp, nil
+	})
+	if err != nil {
+		return nil
+	}
+
+	switch t, _ := v.(string); strings.ToUpper(t) {
+	case "SECONDARY", "ALIAS":
+		return &ZoneNotEditableError{Zone: zone, Type: strings.ToUpper(t)}
+	}
+	return nil
+}
+
+// rememberZoneType caches the type of a zone the client created or read.
+func (s *FastDNSv2Service) rememberZoneType(zone, zoneType string) {
+	if s.client.zoneTypes != nil && zone != "" && zoneType != "" {
+		s.client.zoneTypes.set(zone, zoneType)
+	}
+}
+
+// forgetZoneType drops the cached type of a zone the client deleted.
+func (s *FastDNSv2Service) forgetZoneType(zone string) {
+	if s.client.zoneTypes != nil {
+		s.client.zoneTypes.forget(zone)
+	}
+}
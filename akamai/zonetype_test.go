@@ -0,0 +1,83 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestZoneTypeCheck(t *testing.T) {
+	tests := []struct {
+		zoneType string
+		editable bool
+	}{
+		{"PRIMARY", true},
+		{"SECONDARY", false},
+		{"ALIAS", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.zoneType, func(t *testing.T) {
+			client, srv := akamaitest.NewServer(t)
+			srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: tt.zoneType, Masters: []string{"192.0.2.1"}, Target: "target.example"})
+			srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "old.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+			ctx := context.Background()
+
+			rs := &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}}
+			_, _, createErr := client.FastDNSv2.CreateRecordSet(ctx, rs)
+			_, _, updateErr := client.FastDNSv2.UpdateRecordSet(ctx, rs)
+			_, deleteErr := client.FastDNSv2.DeleteRecordSet(ctx, &akamai.RecordSetOptions{Zone: "example.com", Name: "old.example.com", Type: "A"})
+
+			if tt.editable {
+				assert.NoError(t, createErr)
+				assert.NoError(t, updateErr)
+				assert.NoError(t, deleteErr)
+				return
+			}
+
+			for _, err := range []error{createErr, updateErr, deleteErr} {
+				assert.True(t, errors.Is(err, akamai.ErrZoneNotEditable), "%v", err)
+				var ne *akamai.ZoneNotEditableError
+				if assert.True(t, errors.As(err, &ne)) {
+					assert.Equal(t, "example.com", ne.Zone)
+					assert.Equal(t, tt.zoneType, ne.Type)
+				}
+			}
+			assert.Equal(t, []string{"GET /config-dns/v2/zones/example.com"}, srv.Requests(), "the zone type is looked up once and no write is sent")
+		})
+	}
+}
+
+func TestZoneTypeCheckCache(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+
+	// The type of the zones the client creates or reads is known without a lookup.
+	if _, _, err := client.FastDNSv2.CreateZone(ctx, "1-ABCDE", &akamai.ZoneCreateRequest{Zone: "example.com", Type: "SECONDARY", Masters: []string{"192.0.2.1"}}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	_, _, err := client.FastDNSv2.CreateRecordSet(ctx, &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	assert.True(t, errors.Is(err, akamai.ErrZoneNotEditable))
+	assert.Equal(t, []string{"POST /config-dns/v2/zones"}, srv.Requests())
+
+	// Missing zones are left for the API to answer.
+	_, _, err = client.FastDNSv2.CreateRecordSet(ctx, &akamai.RecordSetCreateRequest{Zone: "missing.example", Name: "www.missing.example", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	var aerr *akamai.AkamaiError
+	assert.True(t, errors.As(err, &aerr))
+	assert.False(t, errors.Is(err, akamai.ErrZoneNotEditable))
+}
+
+func TestZoneTypeCheckDisabled(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "SECONDARY", Masters: []string{"192.0.2.1"}})
+	client.DisableZoneTypeCheck = true
+	client.DisableRecordTypeCheck = true
+
+	_, _, err := client.FastDNSv2.CreateRecordSet(context.Background(), &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	assert.False(t, errors.Is(err, akamai.ErrZoneNotEditable))
+	assert.Equal(t, []string{"POST /config-dns/v2/zones/example.com/names/www.example.com/types/A"}, srv.Requests())
+}
@@ -0,0 +1,102 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// maxZoneUpdateAttempts is the number of times the partial zone updates read and write
+// a zone that keeps being changed concurrently before giving up.
+const maxZoneUpdateAttempts = 3
+
+// zoneUpdate is the body of the zone updates made by the partial update helpers. Unlike
+// ZoneCreateRequest, it carries the fields of the zone as GetZone returns them, so that
+// they are written back untouched, and the version the update applies to.
+type zoneUpdate struct {
+	Zone             string    `json:"zone"`
+	Type             string    `json:"type"`
+	Comment          string    `json:"comment,omitempty"`
+	EndCustomerID    string    `json:"endCustomerId,omitempty"`
+	Target           *string   `json:"target,omitempty"`
+	TSIGKey          *TSIGKey  `json:"tsigKey,omitempty"`
+	Masters          []*string `json:"masters,omitempty"`
+	SignAndServe     *bool     `json:"signAndServe,omitempty"`
+	SignAndServeAlgo *string   `json:"signAndServeAlgorithm,omitempty"`
+	VersionID        *string   `json:"versionId,omitempty"`
+}
+
+func newZoneUpdate(zm *ZoneMetadata) *zoneUpdate {
+	return &zoneUpdate{
+		Zone:             zm.GetZone(),
+		Type:             zm.GetType(),
+		Comment:          zm.GetComment(),
+		EndCustomerID:    zm.GetEndCustomerID(),
+		Target:           zm.Target,
+		TSIGKey:          zm.TSIGKey,
+		Masters:          zm.Masters,
+		SignAndServe:     zm.SignAndServe,
+		SignAndServeAlgo: zm.SignAndServeAlgorithm,
+		VersionID:        zm.VersionId,
+	}
+}
+
+// SetZoneComment changes the comment of a zone, keeping its other fields as they are.
+// See updateZoneFields.
+func (s *FastDNSv2Service) SetZoneComment(ctx context.Context, zone, comment string) (*Zone, *Response, error) {
+	return s.updateZoneFields(ctx, "SetZoneComment", zone, func(zu *zoneUpdate) {
+		zu.Comment = comment
+	})
+}
+
+// SetZoneEndCustomerID changes the end customer ID of a zone, keeping its other fields
+// as they are. See updateZoneFields.
+func (s *FastDNSv2Service) SetZoneEndCustomerID(ctx context.Context, zone, id string) (*Zone, *Response, error) {
+	return s.updateZoneFields(ctx, "SetZoneEndCustomerID", zone, func(zu *zoneUpdate) {
+		zu.EndCustomerID = id
+	})
+}
+
+// updateZoneFields reads a zone, applies set to it and writes it back, so that the
+// fields set leaves alone, such as the masters, TSIG key and sign-and-serve settings,
+// are kept.
+//
+// The update carries the version of the zone it was read at. If the API rejects it with
+// a 409 Conflict because the zone changed in between, the zone is read and updated again,
+// up to maxZoneUpdateAttempts times.
+func (s *FastDNSv2Service) updateZoneFields(ctx context.Context, op, zone string, set func(zu *zoneUpdate)) (*Zone, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp(op, zone, "", "", err)
+	}
+	if err := s.checkZoneProtected(zone); err != nil {
+		return nil, nil, wrapOp(op, zone, "", "", err)
+	}
+
+	u := fmt.Sprintf("config-dns/v2/zones/%v", zone)
+	for attempt := 1; ; attempt++ {
+		zm, resp, err := s.GetZone(ctx, zone)
+		if err != nil {
+			return nil, resp, wrapOp(op, zone, "", "", err)
+		}
+
+		zu := newZoneUpdate(zm)
+		set(zu)
+
+		req, err := s.client.NewRequest("PUT", u, zu)
+		if err != nil {
+			return nil, nil, wrapOp(op, zone, "", "", err)
+		}
+
+		z := new(Zone)
+		resp, err = s.client.Do(WithOperation(ctx, op), req, z)
+		if isStatus(err, http.StatusConflict) && zu.VersionID != nil && attempt < maxZoneUpdateAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, resp, wrapOp(op, zone, "", "", err)
+		}
+
+		return z, resp, nil
+	}
+}
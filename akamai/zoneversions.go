@@ -0,0 +1,250 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxRecordHistoryVersions is the most zone versions GetRecordHistory fetches.
+const maxRecordHistoryVersions = 100
+
+// ZoneVersion describes a version of a zone, which every change to the zone creates.
+type ZoneVersion struct {
+	VersionID          *string          `json:"versionId,omitempty"`
+	LastModifiedDate   *string          `json:"lastModifiedDate,omitempty"`
+	LastModifiedBy     *string          `json:"lastModifiedBy,omitempty"`
+	LastActivationDate *string          `json:"lastActivationDate,omitempty"`
+	ActivationState    *ActivationState `json:"activationState,omitempty"`
+}
+
+// ZoneVersionList holds the response from ListZoneVersions.
+type ZoneVersionList struct {
+	Metadata *ZoneVersionListMetadata `json:"metadata,omitempty"`
+	Versions []*ZoneVersion           `json:"versions,omitempty"`
+}
+
+// ZoneVersionListMetadata holds the metadata response from ListZoneVersions.
+type ZoneVersionListMetadata struct {
+	Zone          *string `json:"zone,omitempty"`
+	Page          *int    `json:"page,omitempty"`
+	PageSize      *int    `json:"pageSize,omitempty"`
+	ShowAll       *bool   `json:"showAll,omitempty"`
+	TotalElements *int    `json:"totalElements,omitempty"`
+}
+
+// ZoneVersionListOptions are optional query parameters.
+type ZoneVersionListOptions struct {
+	Page     int  `url:"page,omitempty"`
+	PageSize int  `url:"pageSize,omitempty"`
+	ShowAll  bool `url:"showAll,omitempty"`
+}
+
+// ListZoneVersions lists the versions of a zone, newest first. This operation is
+// paginated.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getzoneversions
+func (s *FastDNSv2Service) ListZoneVersions(ctx context.Context, zone string, opt *ZoneVersionListOptions) (*ZoneVersionList, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp("ListZoneVersions", zone, "", "", err)
+	}
+
+	if opt != nil {
+		if err := validatePaging(opt.ShowAll, opt.Page != 0 || opt.PageSize != 0); err != nil {
+			return nil, nil, wrapOp("ListZoneVersions", zone, "", "", err)
+		}
+	}
+
+	u, err := addOptions(fmt.Sprintf("/config-dns/v2/zones/%v/versions", zone), opt)
+	if err != nil {
+		return nil, nil, wrapOp("ListZoneVersions", zone, "", "", err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, wrapOp("ListZoneVersions", zone, "", "", err)
+	}
+
+	var list *ZoneVersionList
+	resp, err := s.client.Do(WithOperation(ctx, "ListZoneVersions"), req, &list)
+	if err != nil {
+		return nil, resp, wrapOp("ListZoneVersions", zone, "", "", err)
+	}
+
+	return list, resp, nil
+}
+
+// GetZoneVersionRecordSets lists the record sets a zone held in one of its versions.
+// This operation is paginated. Their names are lowercased as GetZoneRecordSets does.
+//
+// Akamai API docs: https://developer.akamai.com/api/web_performance/fast_dns_zone_management/v2.html#getversionrecordsets
+func (s *FastDNSv2Service) GetZoneVersionRecordSets(ctx context.Context, zone, versionID string, opt *ListZoneRecordSetOptions) (*ListZoneRecordSets, *Response, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, nil, wrapOp("GetZoneVersionRecordSets", zone, "", "", err)
+	}
+
+	if opt != nil {
+		if err := validateSearch(opt.Search); err != nil {
+			return nil, nil, wrapOp("GetZoneVersionRecordSets", zone, "", "", err)
+		}
+		if err := validatePaging(opt.ShowAll, opt.Page != 0 || opt.PageSize != 0); err != nil {
+			return nil, nil, wrapOp("GetZoneVersionRecordSets", zone, "", "", err)
+		}
+	}
+
+	u, err := addOptions(fmt.Sprintf("/config-dns/v2/zones/%v/versions/%v/recordsets", zone, versionID), opt)
+	if err != nil {
+		return nil, nil, wrapOp("GetZoneVersionRecordSets", zone, "", "", err)
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, wrapOp("GetZoneVersionRecordSets", zone, "", "", err)
+	}
+
+	var list *ListZoneRecordSets
+	resp, err := s.client.Do(WithOperation(ctx, "GetZoneVersionRecordSets"), req, &list)
+	if err != nil {
+		return nil, resp, wrapOp("GetZoneVersionRecordSets", zone, "", "", err)
+	}
+	if list != nil {
+		s.readRecordNames(list.RecordSets)
+	}
+
+	return list, resp, nil
+}
+
+// CompareZoneVersions returns the changes made to the record sets of a zone from one of
+// its versions to another, as DiffZones reports them: the Current record sets of the
+// changes are those of version v1, and the Desired ones those of v2. The record sets of
+// both versions are listed with GetZoneVersionRecordSets, concurrently.
+func (s *FastDNSv2Service) CompareZoneVersions(ctx context.Context, zone, v1, v2 string) (*SyncPlan, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, wrapOp("CompareZoneVersions", zone, "", "", err)
+	}
+
+	results := RunBulk(ctx, []string{v1, v2}, nil, func(ctx context.Context, v string) ([]*RecordSet, error) {
+		list, _, err := s.GetZoneVersionRecordSets(ctx, zone, v, &ListZoneRecordSetOptions{ShowAll: true})
+		if err != nil {
+			return nil, err
+		}
+		return list.RecordSets, nil
+	})
+	for _, r := range results {
+		if r.Err != nil {
+			return nil, wrapOp("CompareZoneVersions", zone, "", "", r.Err)
+		}
+	}
+
+	return DiffZones(zone, results[0].Value, results[1].Value), nil
+}
+
+// RecordHistoryEntry is the value a record set took in a version of its zone.
+type RecordHistoryEntry struct {
+	VersionID        string
+	LastModifiedBy   string
+	LastModifiedDate string
+
+	// Exists is false for the versions the zone had no such record set in, such as
+	// before its creation or after its deletion.
+	Exists bool
+	Rdata  []string
+	TTL    int
+}
+
+// GetRecordHistory returns the changes made to a record set over the last limit
+// versions of its zone, newest first, to tell who changed it and when. Of the
+// consecutive versions in which the record set kept the same value, only the oldest,
+// which made the change, is returned. A limit of zero, or above 100, examines the last
+// 100 versions.
+//
+// It makes a request for each version examined, concurrently with RunBulk, and fails if
+// any of them does.
+func (s *FastDNSv2Service) GetRecordHistory(ctx context.Context, zone, name, rtype string, limit int) ([]*RecordHistoryEntry, error) {
+	zone, err := s.zoneName(zone)
+	if err != nil {
+		return nil, wrapOp("GetRecordHistory", zone, name, rtype, err)
+	}
+	name, err = s.recordName(name)
+	if err != nil {
+		return nil, wrapOp("GetRecordHistory", zone, name, rtype, err)
+	}
+	rtype = strings.ToUpper(rtype)
+
+	if limit <= 0 || limit > maxRecordHistoryVersions {
+		limit = maxRecordHistoryVersions
+	}
+
+	versions, _, err := s.ListZoneVersions(ctx, zone, &ZoneVersionListOptions{PageSize: limit})
+	if err != nil {
+		return nil, wrapOp("GetRecordHistory", zone, name, rtype, err)
+	}
+	list := versions.Versions
+	if len(list) > limit {
+		list = list[:limit]
+	}
+
+	opt := &ListZoneRecordSetOptions{Search: name, Types: rtype, ShowAll: true}
+	results := RunBulk(ctx, list, nil, func(ctx context.Context, v *ZoneVersion) (*RecordHistoryEntry, error) {
+		records, _, err := s.GetZoneVersionRecordSets(ctx, zone, v.GetVersionID(), opt)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := &RecordHistoryEntry{
+			VersionID:        v.GetVersionID(),
+			LastModifiedBy:   v.GetLastModifiedBy(),
+			LastModifiedDate: v.GetLastModifiedDate(),
+		}
+		for _, rs := range records.RecordSets {
+			if !strings.EqualFold(strings.TrimSuffix(rs.GetName(), "."), name) || !strings.EqualFold(rs.GetType(), rtype) {
+				continue
+			}
+			entry.Exists = true
+			entry.TTL = rs.GetTTL()
+			for _, d := range rs.Rdata {
+				entry.Rdata = append(entry.Rdata, *d)
+			}
+			break
+		}
+		return entry, nil
+	})
+
+	var history []*RecordHistoryEntry
+	for _, r := range results {
+		if r.Err != nil {
+			return nil, wrapOp("GetRecordHistory", zone, name, rtype, r.Err)
+		}
+		// The versions are newest first: an entry replaces the previous one when it
+		// holds the same value, as it is the older version that made the change.
+		if n := len(history); n > 0 && sameRecordValue(history[n-1], r.Value) {
+			history[n-1] = r.Value
+			continue
+		}
+		history = append(history, r.Value)
+	}
+
+	return history, nil
+}
+
+// sameRecordValue reports whether two history entries hold the same record set value,
+// regardless of the order of their rdata.
+func sameRecordValue(a, b *RecordHistoryEntry) bool {
+	if a.Exists != b.Exists || a.TTL != b.TTL || len(a.Rdata) != len(b.Rdata) {
+		return false
+	}
+	ra := append([]string(nil), a.Rdata...)
+	rb := append([]string(nil), b.Rdata...)
+	sort.Strings(ra)
+	sort.Strings(rb)
+	for i := range ra {
+		if ra[i] != rb[i] {
+			return false
+		}
+	}
+	return true
+}
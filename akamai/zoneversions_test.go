@@ -0,0 +1,109 @@
+package akamai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+func TestGetRecordHistory(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	ctx := context.Background()
+
+	// Five versions, of which the second creates the record set and the fourth changes
+	// it.
+	var versions []string
+	step := func(by string, rs *akamai.RecordSetCreateRequest) {
+		srv.ModifiedBy = by
+		if rs == nil {
+			srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+		} else if err := srv.AddRecordSet(rs); err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		versions = append(versions, srv.Zone("example.com").GetVersionID())
+	}
+	step("creator", nil)
+	step("alice", &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	step("carol", &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "mail.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.9"}})
+	step("bob", &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(60), Rdata: []string{"192.0.2.2"}})
+	step("carol", &akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "AAAA", TTL: akamai.Int(300), Rdata: []string{"2001:db8::1"}})
+
+	list, _, err := client.FastDNSv2.ListZoneVersions(ctx, "example.com", &akamai.ZoneVersionListOptions{ShowAll: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, list.Versions, 5) {
+		assert.Equal(t, versions[4], list.Versions[0].GetVersionID())
+		assert.Equal(t, "carol", list.Versions[0].GetLastModifiedBy())
+	}
+
+	history, err := client.FastDNSv2.GetRecordHistory(ctx, "Example.com.", "WWW.example.com", "a", 0)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, history, 3) {
+		assert.Equal(t, versions[3], history[0].VersionID)
+		assert.Equal(t, "bob", history[0].LastModifiedBy)
+		assert.NotEmpty(t, history[0].LastModifiedDate)
+		assert.True(t, history[0].Exists)
+		assert.Equal(t, []string{"192.0.2.2"}, history[0].Rdata)
+		assert.Equal(t, 60, history[0].TTL)
+
+		assert.Equal(t, versions[1], history[1].VersionID)
+		assert.Equal(t, "alice", history[1].LastModifiedBy)
+		assert.Equal(t, []string{"192.0.2.1"}, history[1].Rdata)
+		assert.Equal(t, 300, history[1].TTL)
+
+		assert.Equal(t, versions[0], history[2].VersionID)
+		assert.False(t, history[2].Exists)
+	}
+
+	// The limit caps the versions fetched.
+	before := len(srv.Requests())
+	history, err = client.FastDNSv2.GetRecordHistory(ctx, "example.com", "www.example.com", "A", 2)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, history, 1) {
+		assert.Equal(t, "bob", history[0].LastModifiedBy)
+	}
+	assert.Len(t, srv.Requests()[before:], 3)
+
+	_, err = client.FastDNSv2.GetRecordHistory(ctx, "missing.example", "www.missing.example", "A", 0)
+	assert.Error(t, err)
+}
+
+func TestCompareZoneVersions(t *testing.T) {
+	client, since, _ := changedSinceZone(t)
+	ctx := context.Background()
+
+	list, _, err := client.FastDNSv2.ListZoneVersions(ctx, "example.com", &akamai.ZoneVersionListOptions{ShowAll: true})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	latest := list.Versions[0].GetVersionID()
+
+	plan, err := client.FastDNSv2.CompareZoneVersions(ctx, "Example.com.", since, latest)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "example.com", plan.Zone)
+	assertChangedSince(t, plan)
+
+	// The other way round, the changes are undone.
+	plan, err = client.FastDNSv2.CompareZoneVersions(ctx, "example.com", latest, since)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	var actions []akamai.SyncAction
+	for _, c := range plan.Changes {
+		actions = append(actions, c.Action)
+	}
+	assert.Equal(t, []akamai.SyncAction{akamai.SyncDelete, akamai.SyncCreate, akamai.SyncUpdate}, actions)
+
+	_, err = client.FastDNSv2.CompareZoneVersions(ctx, "example.com", since, "missing")
+	assert.NotNil(t, err)
+}
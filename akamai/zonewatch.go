@@ -0,0 +1,151 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// defaultWatchMaxBackoff caps the wait of a ZoneWatcher after consecutive errors.
+const defaultWatchMaxBackoff = time.Minute
+
+// ZoneChange is a change of the version of a zone, as seen by a ZoneWatcher.
+type ZoneChange struct {
+	Zone string
+
+	// OldVersionID and NewVersionID are the versions of the zone before and after the
+	// change. Several changes made between two polls are seen as one.
+	OldVersionID string
+	NewVersionID string
+
+	// ModifiedBy is the user who made the change, and Modified when, from the
+	// LastModifiedBy and LastModifiedDate of the zone. Modified is zero if the date
+	// can't be read.
+	ModifiedBy string
+	Modified   time.Time
+
+	// Metadata is the zone as read when the change was seen.
+	Metadata *ZoneMetadata
+}
+
+// ZoneWatcher watches zones for changes, such as to invalidate caches when they are
+// modified, by polling their metadata with GetZone and comparing their VersionId. The
+// polls are conditional requests, made WithIfNoneMatch the entity tag of the last
+// response, so that an unchanged zone costs a bodyless 304 Not Modified.
+//
+// Its fields must be set before WatchZone is called.
+type ZoneWatcher struct {
+	api FastDNSv2API
+
+	// OnError, if set, is called with the errors of the polls that fail, from the
+	// goroutine of the watch. The watch goes on after them.
+	OnError func(zone string, err error)
+
+	// MaxBackoff caps the wait after consecutive failed polls, which doubles from the
+	// interval of the watch. Defaults to 1m, or the interval if it is longer.
+	MaxBackoff time.Duration
+}
+
+// NewZoneWatcher returns a ZoneWatcher that reads zones with api, such as a client's
+// FastDNSv2.
+func NewZoneWatcher(api FastDNSv2API) *ZoneWatcher {
+	return &ZoneWatcher{api: api}
+}
+
+// WatchZone reads the version of a zone, and then polls it every interval in a new
+// goroutine, sending a ZoneChange on the returned channel whenever it changes. The
+// channel is unbuffered: the watch waits for each change to be received before polling
+// again. It is closed once ctx is done, or the client of the watcher is closed.
+//
+// The error of the first read, such as for a zone that doesn't exist, is returned
+// without starting the watch. The errors of the polls that follow are handed to
+// OnError, and back the polls off until one succeeds.
+func (w *ZoneWatcher) WatchZone(ctx context.Context, zone string, interval time.Duration) (<-chan ZoneChange, error) {
+	if interval <= 0 {
+		return nil, errors.New("the interval of a zone watch must be positive")
+	}
+
+	z, resp, err := w.api.GetZone(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ZoneChange)
+	ctx, done := startBackground(ctx, w.api)
+	go func() {
+		defer done()
+		w.watch(ctx, ch, zone, interval, z, etag(resp))
+	}()
+	return ch, nil
+}
+
+func (w *ZoneWatcher) watch(ctx context.Context, ch chan<- ZoneChange, zone string, interval time.Duration, last *ZoneMetadata, tag string) {
+	defer close(ch)
+
+	maxBackoff := w.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultWatchMaxBackoff
+	}
+	if maxBackoff < interval {
+		maxBackoff = interval
+	}
+
+	wait := interval
+	for {
+		if sleepContext(ctx, wait) != nil {
+			return
+		}
+
+		pollCtx := ctx
+		if tag != "" {
+			pollCtx = WithIfNoneMatch(ctx, tag)
+		}
+		z, resp, err := w.api.GetZone(pollCtx, zone)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case errors.Is(err, ErrNotModified):
+			wait = interval
+			continue
+		case err != nil:
+			if w.OnError != nil {
+				w.OnError(zone, err)
+			}
+			if wait *= 2; wait > maxBackoff {
+				wait = maxBackoff
+			}
+			continue
+		}
+		wait = interval
+		tag = etag(resp)
+
+		if z.GetVersionId() == last.GetVersionId() {
+			last = z
+			continue
+		}
+
+		change := ZoneChange{
+			Zone:         zone,
+			OldVersionID: last.GetVersionId(),
+			NewVersionID: z.GetVersionId(),
+			ModifiedBy:   z.GetLastModifiedBy(),
+			Metadata:     z,
+		}
+		change.Modified, _ = time.Parse(time.RFC3339, z.GetLastModifiedDate())
+		last = z
+
+		select {
+		case ch <- change:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// etag returns the entity tag of a response, or "" if it has none.
+func etag(resp *Response) string {
+	if resp == nil || resp.Response == nil {
+		return ""
+	}
+	return resp.Header.Get("ETag")
+}
@@ -0,0 +1,161 @@
+package akamai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+// zonePolls counts the GetZone requests the server received for zone.
+func zonePolls(srv *akamaitest.Server, zone string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r == "GET /config-dns/v2/zones/"+zone {
+			n++
+		}
+	}
+	return n
+}
+
+func TestZoneWatcher(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	old := srv.Zone("example.com").GetVersionID()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, err := akamai.NewZoneWatcher(client.FastDNSv2).WatchZone(ctx, "example.com", time.Millisecond)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	// The version changes after a few polls.
+	for zonePolls(srv, "example.com") < 4 {
+		time.Sleep(time.Millisecond)
+	}
+	srv.ModifiedBy = "jdoe"
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+
+	select {
+	case c := <-changes:
+		assert.Equal(t, "example.com", c.Zone)
+		assert.Equal(t, old, c.OldVersionID)
+		assert.Equal(t, srv.Zone("example.com").GetVersionID(), c.NewVersionID)
+		assert.Equal(t, "jdoe", c.ModifiedBy)
+		assert.False(t, c.Modified.IsZero())
+		assert.Equal(t, c.NewVersionID, c.Metadata.GetVersionId())
+	case <-time.After(5 * time.Second):
+		t.Fatal("expect a change")
+	}
+
+	cancel()
+	for range changes {
+	}
+}
+
+func TestZoneWatcherMissingZone(t *testing.T) {
+	client, _ := akamaitest.NewServer(t)
+
+	_, err := akamai.NewZoneWatcher(client.FastDNSv2).WatchZone(context.Background(), "missing.example", time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestGetZoneIfNoneMatch(t *testing.T) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	ctx := context.Background()
+
+	_, resp, err := client.FastDNSv2.GetZone(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	etag := resp.Header.Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	_, resp, err = client.FastDNSv2.GetZone(akamai.WithIfNoneMatch(ctx, etag), "example.com")
+	assert.True(t, errors.Is(err, akamai.ErrNotModified), "got %v", err)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	}
+
+	srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"192.0.2.1"}})
+	z, _, err := client.FastDNSv2.GetZone(akamai.WithIfNoneMatch(ctx, etag), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, srv.Zone("example.com").GetVersionID(), z.GetVersionId())
+}
+
+func TestZoneWatcherErrors(t *testing.T) {
+	// The second to fourth polls fail, and the version changes from the fifth on.
+	var (
+		mu    sync.Mutex
+		polls int
+		times []time.Time
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls++
+		n := polls
+		times = append(times, time.Now())
+		mu.Unlock()
+
+		version := "v1"
+		switch {
+		case n >= 2 && n <= 4:
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"title": "Internal Server Error", "status": 500}`)
+			return
+		case n >= 5:
+			version = "v2"
+		}
+		fmt.Fprintf(w, `{"zone": "example.com", "type": "PRIMARY", "versionId": %q, "lastModifiedBy": "jdoe", "lastModifiedDate": "2026-01-02T03:04:05Z"}`, version)
+	}))
+	defer ts.Close()
+	client := akamaitest.NewStaticTestClient(t, ts.URL)
+
+	var errs []error
+	watcher := akamai.NewZoneWatcher(client.FastDNSv2)
+	watcher.MaxBackoff = 8 * time.Millisecond
+	watcher.OnError = func(zone string, err error) {
+		assert.Equal(t, "example.com", zone)
+		errs = append(errs, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, err := watcher.WatchZone(ctx, "example.com", 2*time.Millisecond)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	select {
+	case c := <-changes:
+		assert.Equal(t, "v1", c.OldVersionID)
+		assert.Equal(t, "v2", c.NewVersionID)
+		assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), c.Modified)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expect a change")
+	}
+	cancel()
+	for range changes {
+	}
+
+	// The errors were handed to OnError, and backed the polls off.
+	if assert.Len(t, errs, 3) {
+		var ae *akamai.AkamaiError
+		assert.True(t, errors.As(errs[0], &ae), "got %v", errs[0])
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.True(t, times[4].Sub(times[3]) >= 8*time.Millisecond, "the wait after the third error is %v", times[4].Sub(times[3]))
+}
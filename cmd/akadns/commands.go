@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+// commandFunc runs a command with the arguments that follow its name.
+type commandFunc func(ctx context.Context, e *env, name string, args []string) error
+
+// commands maps the command names, "group verb", to their implementation.
+var commands map[string]commandFunc
+
+func init() {
+	commands = map[string]commandFunc{
+		"zones list":        zonesList,
+		"zone get":          zoneGet,
+		"zone export":       zoneExport,
+		"zone import":       zoneImport,
+		"records list":      recordsList,
+		"records get":       recordsGet,
+		"records set":       recordsSet,
+		"records delete":    recordsDelete,
+		"changelist submit": changeListSubmit,
+	}
+}
+
+func zonesList(ctx context.Context, e *env, name string, args []string) error {
+	fs := e.newFlagSet(name, "[flags]")
+	contracts := fs.String("contracts", "", "comma separated contract IDs")
+	types := fs.String("types", "", "comma separated zone types")
+	search := fs.String("search", "", "only list zones containing this text")
+	if _, err := parseArgs(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	list, _, err := e.client.FastDNSv2.ListZones(ctx, &akamai.ZoneListOptions{
+		ContractIDs: *contracts,
+		Types:       *types,
+		Search:      *search,
+		ShowAll:     true,
+	})
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{{"ZONE", "TYPE", "CONTRACT", "STATE"}}
+	for _, z := range list.Zones {
+		rows = append(rows, []string{z.GetZone(), z.GetType(), z.GetContractID(), string(z.GetActivationState())})
+	}
+	return e.out.write(list.Zones, rows)
+}
+
+func zoneGet(ctx context.Context, e *env, name string, args []string) error {
+	fs := e.newFlagSet(name, "<zone>")
+	rest, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	zm, _, err := e.client.FastDNSv2.GetZone(ctx, rest[0])
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{
+		{"ZONE", zm.GetZone()},
+		{"TYPE", zm.GetType()},
+		{"CONTRACT", zm.GetContractID()},
+		{"STATE", string(zm.GetActivationState())},
+		{"VERSION", zm.GetVersionId()},
+		{"MODIFIED", zm.GetLastModifiedDate()},
+		{"MODIFIED BY", zm.GetLastModifiedBy()},
+		{"COMMENT", zm.GetComment()},
+	}
+	return e.out.write(zm, rows)
+}
+
+func zoneExport(ctx context.Context, e *env, name string, args []string) error {
+	fs := e.newFlagSet(name, "<zone>")
+	rest, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	list, _, err := e.client.FastDNSv2.GetZoneRecordSets(ctx, rest[0], &akamai.ListZoneRecordSetOptions{ShowAll: true})
+	if err != nil {
+		return err
+	}
+
+	records := akamai.SortRecordSets(list.RecordSets)
+	return e.out.text(records, formatZoneFile(rest[0], records))
+}
+
+func zoneImport(ctx context.Context, e *env, name string, args []string) error {
+	fs := e.newFlagSet(name, "[flags] <zone> <zone file>")
+	dryRun := fs.Bool("dry-run", false, "print the changes without making them")
+	prune := fs.Bool("prune", false, "delete the record sets missing from the zone file")
+	rest, err := parseArgs(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	zone, path := rest[0], rest[1]
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	desired, err := parseZoneFile(f, zone)
+	if err != nil {
+		return fmt.Errorf("%v: %v", path, err)
+	}
+
+	opt := &akamai.SyncOptions{Prune: *prune}
+	plan, err := e.client.FastDNSv2.PlanRecordSets(ctx, zone, desired, opt)
+	if err != nil {
+		return err
+	}
+
+	if err := e.out.text(plan, formatPlan(plan)); err != nil {
+		return err
+	}
+	if *dryRun || plan.Empty() {
+		return nil
+	}
+
+	return e.client.FastDNSv2.ApplySyncPlan(ctx, plan, opt)
+}
+
+func recordsList(ctx context.Context, e *env, name string, args []string) error {
+	fs := e.newFlagSet(name, "[flags] <zone>")
+	types := fs.String("types", "", "comma separated record types")
+	rest, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	list, _, err := e.client.FastDNSv2.GetZoneRecordSets(ctx, rest[0], &akamai.ListZoneRecordSetOptions{
+		Types:   *types,
+		ShowAll: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.out.write(list.RecordSets, recordSetRows(list.RecordSets...))
+}
+
+func recordsGet(ctx context.Context, e *env, name string, args []string) error {
+	fs := e.newFlagSet(name, "<zone> <name> <type>")
+	rest, err := parseArgs(fs, args, 3, 3)
+	if err != nil {
+		return err
+	}
+
+	rs, _, err := e.client.FastDNSv2.GetRecordSet(ctx, &akamai.RecordSetOptions{
+		Zone: rest[0],
+		Name: rest[1],
+		Type: strings.ToUpper(rest[2]),
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.out.write(rs, recordSetRows(rs))
+}
+
+func recordsSet(ctx context.Context, e *env, name string, args []string) error {
+	fs := e.newFlagSet(name, "<zone> <name> <type> <ttl> <rdata>...")
+	rest, err := parseArgs(fs, args, 5, -1)
+	if err != nil {
+		return err
+	}
+
+	ttl, err := strconv.Atoi(rest[3])
+	if err != nil || ttl <= 0 {
+		fmt.Fprintf(e.stderr, "invalid ttl %q\n", rest[3])
+		fs.Usage()
+		return errUsage
+	}
+
+	req := &akamai.RecordSetCreateRequest{
+		Zone:  rest[0],
+		Name:  rest[1],
+		Type:  strings.ToUpper(rest[2]),
+		TTL:   akamai.Int(ttl),
+		Rdata: rest[4:],
+	}
+
+	_, _, err = e.client.FastDNSv2.GetRecordSet(ctx, &akamai.RecordSetOptions{Zone: req.Zone, Name: req.Name, Type: req.Type})
+	var rs *akamai.RecordSet
+	switch {
+	case err == nil:
+		rs, _, err = e.client.FastDNSv2.UpdateRecordSet(ctx, req)
+	case isNotFound(err):
+		rs, _, err = e.client.FastDNSv2.CreateRecordSet(ctx, req)
+	}
+	if err != nil {
+		return err
+	}
+
+	return e.out.write(rs, recordSetRows(rs))
+}
+
+func recordsDelete(ctx context.Context, e *env, name string, args []string) error {
+	fs := e.newFlagSet(name, "<zone> <name> <type>")
+	rest, err := parseArgs(fs, args, 3, 3)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.client.FastDNSv2.DeleteRecordSet(ctx, &akamai.RecordSetOptions{
+		Zone: rest[0],
+		Name: rest[1],
+		Type: strings.ToUpper(rest[2]),
+	})
+	return err
+}
+
+func changeListSubmit(ctx context.Context, e *env, name string, args []string) error {
+	fs := e.newFlagSet(name, "<zone>")
+	rest, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.client.FastDNSv2.SubmitChangeList(ctx, rest[0])
+	return err
+}
+
+// recordSetRows returns the table of record sets, with one row per rdata.
+func recordSetRows(records ...*akamai.RecordSet) [][]string {
+	rows := [][]string{{"NAME", "TYPE", "TTL", "RDATA"}}
+	for _, rs := range records {
+		for _, rdata := range rs.Rdata {
+			rows = append(rows, []string{rs.GetName(), rs.GetType(), strconv.Itoa(rs.GetTTL()), akamai.StringValue(rdata)})
+		}
+	}
+	return rows
+}
+
+// formatPlan renders the changes of a plan as a diff: "+" for the records created,
+// "-" for the ones deleted, and both for updated record sets.
+func formatPlan(plan *akamai.SyncPlan) string {
+	var b strings.Builder
+	for _, c := range plan.Changes {
+		fmt.Fprintf(&b, "%v %v %v\n", c.Action, c.Name, c.Type)
+		if c.Current != nil {
+			for _, rdata := range c.Current.Rdata {
+				fmt.Fprintf(&b, "- %v\t%d\t%v\n", c.Name, c.Current.GetTTL(), akamai.StringValue(rdata))
+			}
+		}
+		if c.Desired != nil {
+			for _, rdata := range c.Desired.Rdata {
+				fmt.Fprintf(&b, "+ %v\t%d\t%v\n", c.Name, c.Desired.GetTTL(), rdata)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "%d to change, %d unchanged\n", len(plan.Changes), plan.Unchanged)
+	return b.String()
+}
+
+// isNotFound reports whether err is an API error for a missing resource.
+func isNotFound(err error) bool {
+	var ae *akamai.AkamaiError
+	return errors.As(err, &ae) && ae.Status == http.StatusNotFound
+}
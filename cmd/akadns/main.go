@@ -0,0 +1,144 @@
+// Command akadns manages FastDNS zones and record sets from the command line.
+//
+// Usage:
+//
+//	akadns [-format table|json] [-edgerc file] [-section name] <command> [arguments]
+//
+// The commands are:
+//
+//	zones list [-contracts ids] [-types types] [-search text]
+//	zone get <zone>
+//	zone export <zone>
+//	zone import [-dry-run] [-prune] <zone> <zone file>
+//	records list [-types types] <zone>
+//	records get <zone> <name> <type>
+//	records set <zone> <name> <type> <ttl> <rdata>...
+//	records delete <zone> <name> <type>
+//	changelist submit <zone>
+//
+// Credentials are read from the AKAMAI_* environment variables when AKAMAI_HOST is set,
+// and from the given section of the .edgerc file otherwise.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// errUsage is returned for invalid command lines, after the usage has been printed.
+var errUsage = errors.New("invalid usage")
+
+// clientFunc creates the client the commands use. Tests replace it with one that
+// returns a fake.
+type clientFunc func(edgerc, section string) (*akamai.Client, error)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr, newClient); err != nil {
+		if err != errUsage {
+			fmt.Fprintf(os.Stderr, "akadns: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// newClient returns a client using the environment credentials if they are set, and
+// the .edgerc file otherwise.
+func newClient(edgerc, section string) (*akamai.Client, error) {
+	cc := credentials.NewSharedCredentials(edgerc, section)
+	if os.Getenv("AKAMAI_HOST") != "" {
+		cc = credentials.NewEnvCredentials()
+	}
+
+	return akamai.NewClient(nil, cc)
+}
+
+// env holds what the commands need to run.
+type env struct {
+	client *akamai.Client
+	out    *output
+	stderr io.Writer
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, mkClient clientFunc) error {
+	fs := flag.NewFlagSet("akadns", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "table", "output format, table or json")
+	edgerc := fs.String("edgerc", "", "path of the .edgerc file, defaults to ~/.edgerc")
+	section := fs.String("section", "default", "section of the .edgerc file")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: akadns [flags] <command> [arguments]")
+		fmt.Fprintln(stderr, "\ncommands: zones list, zone get|export|import, records list|get|set|delete, changelist submit")
+		fmt.Fprintln(stderr, "\nflags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(stderr, "unknown format %q\n", *format)
+		fs.Usage()
+		return errUsage
+	}
+
+	rest := fs.Args()
+	if len(rest) < 2 {
+		fs.Usage()
+		return errUsage
+	}
+
+	cmd, ok := commands[rest[0]+" "+rest[1]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", rest[0]+" "+rest[1])
+		fs.Usage()
+		return errUsage
+	}
+
+	client, err := mkClient(*edgerc, *section)
+	if err != nil {
+		return err
+	}
+
+	e := &env{
+		client: client,
+		out:    &output{w: stdout, json: *format == "json"},
+		stderr: stderr,
+	}
+
+	return cmd(ctx, e, rest[0]+" "+rest[1], rest[2:])
+}
+
+// newFlagSet returns the flag set of a command, whose usage lists the expected
+// arguments.
+func (e *env) newFlagSet(name, arguments string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "usage: akadns %v %v\n", name, arguments)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses the flags of a command and checks the number of remaining
+// arguments. A negative max allows any number of arguments.
+func parseArgs(fs *flag.FlagSet, args []string, min, max int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, errUsage
+	}
+
+	rest := fs.Args()
+	if len(rest) < min || (max >= 0 && len(rest) > max) {
+		fs.Usage()
+		return nil, errUsage
+	}
+
+	return rest, nil
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+)
+
+// runWith runs akadns with a client returned by the fake server, and returns what it
+// printed on stdout and stderr.
+func runWith(t *testing.T, client *akamai.Client, args ...string) (string, string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	mkClient := func(edgerc, section string) (*akamai.Client, error) {
+		return client, nil
+	}
+	err := run(context.Background(), args, &stdout, &stderr, mkClient)
+	return stdout.String(), stderr.String(), err
+}
+
+func newTestServer(t *testing.T) (*akamai.Client, *akamaitest.Server) {
+	client, srv := akamaitest.NewServer(t)
+	srv.AddZone(&akamai.ZoneCreateRequest{Zone: "example.com", Type: "PRIMARY"})
+	if err := srv.AddRecordSet(&akamai.RecordSetCreateRequest{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"10.0.0.1"}}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	return client, srv
+}
+
+func TestRunUsage(t *testing.T) {
+	client, _ := akamaitest.NewClient()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no command", nil},
+		{"unknown command", []string{"zones", "frobnicate"}},
+		{"unknown format", []string{"-format", "yaml", "zones", "list"}},
+		{"unknown flag", []string{"-verbose", "zones", "list"}},
+		{"missing zone", []string{"zone", "get"}},
+		{"extra argument", []string{"zone", "get", "example.com", "example.net"}},
+		{"missing rdata", []string{"records", "set", "example.com", "www.example.com", "A", "300"}},
+		{"invalid ttl", []string{"records", "set", "example.com", "www.example.com", "A", "soon", "10.0.0.1"}},
+		{"unknown command flag", []string{"records", "list", "-sort", "example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, err := runWith(t, client, tt.args...)
+			assert.Equal(t, errUsage, err)
+			assert.Contains(t, stderr, "usage: akadns")
+		})
+	}
+}
+
+func TestRunClientError(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	mkClient := func(edgerc, section string) (*akamai.Client, error) {
+		assert.Equal(t, "/tmp/edgerc", edgerc)
+		assert.Equal(t, "dns", section)
+		return nil, os.ErrNotExist
+	}
+
+	err := run(context.Background(), []string{"-edgerc", "/tmp/edgerc", "-section", "dns", "zones", "list"}, &stdout, &stderr, mkClient)
+	assert.Equal(t, os.ErrNotExist, err)
+}
+
+func TestZonesList(t *testing.T) {
+	client, _ := newTestServer(t)
+
+	stdout, _, err := runWith(t, client, "zones", "list")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, stdout, "ZONE")
+	assert.Contains(t, stdout, "example.com")
+	assert.Contains(t, stdout, "PRIMARY")
+
+	stdout, _, err = runWith(t, client, "-format", "json", "zones", "list")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	var zones []*akamai.Zone
+	if err := json.Unmarshal([]byte(stdout), &zones); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, zones, 1)
+	assert.Equal(t, "example.com", zones[0].GetZone())
+}
+
+func TestZoneGet(t *testing.T) {
+	client, _ := newTestServer(t)
+
+	stdout, _, err := runWith(t, client, "zone", "get", "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, stdout, "ZONE         example.com\n")
+
+	_, _, err = runWith(t, client, "zone", "get", "missing.com")
+	assert.Error(t, err)
+}
+
+func TestRecords(t *testing.T) {
+	client, srv := newTestServer(t)
+
+	stdout, _, err := runWith(t, client, "records", "get", "example.com", "www.example.com", "a")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "NAME             TYPE  TTL  RDATA\nwww.example.com  A     300  10.0.0.1\n", stdout)
+
+	_, _, err = runWith(t, client, "records", "set", "example.com", "www.example.com", "A", "60", "10.0.0.2", "10.0.0.3")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	_, _, err = runWith(t, client, "records", "set", "example.com", "api.example.com", "CNAME", "60", "www.example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	stdout, _, err = runWith(t, client, "records", "list", "-types", "A,CNAME", "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, stdout, "www.example.com  A      60   10.0.0.2")
+	assert.Contains(t, stdout, "www.example.com  A      60   10.0.0.3")
+	assert.Contains(t, stdout, "api.example.com  CNAME  60   www.example.com")
+
+	_, _, err = runWith(t, client, "records", "delete", "example.com", "api.example.com", "CNAME")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	for _, rs := range srv.RecordSets("example.com") {
+		assert.NotEqual(t, "api.example.com", rs.GetName())
+	}
+}
+
+func TestZoneExportImport(t *testing.T) {
+	client, srv := newTestServer(t)
+
+	stdout, _, err := runWith(t, client, "zone", "export", "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, stdout, "$ORIGIN example.com.\n")
+	assert.Contains(t, stdout, "www.example.com.\t300\tIN\tA\t10.0.0.1\n")
+
+	path := filepath.Join(t.TempDir(), "example.com.zone")
+	zoneFile := "$TTL 600\nwww IN A 10.0.0.9\nmail 300 MX 10 mx.example.com.\n"
+	if err := os.WriteFile(path, []byte(zoneFile), 0o600); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	before := srv.RecordSets("example.com")
+	stdout, _, err = runWith(t, client, "zone", "import", "-dry-run", "example.com", path)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Contains(t, stdout, "create mail.example.com MX\n+ mail.example.com\t300\t10 mx.example.com.\n")
+	assert.Contains(t, stdout, "update www.example.com A\n- www.example.com\t300\t10.0.0.1\n+ www.example.com\t600\t10.0.0.9\n")
+	assert.Contains(t, stdout, "2 to change, 0 unchanged\n")
+	assert.Equal(t, before, srv.RecordSets("example.com"))
+
+	_, _, err = runWith(t, client, "zone", "import", "example.com", path)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	stdout, _, err = runWith(t, client, "zone", "import", "-dry-run", "example.com", path)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Equal(t, "0 to change, 2 unchanged\n", stdout)
+}
+
+func TestChangeListSubmit(t *testing.T) {
+	client, fakes := akamaitest.NewClient()
+	fakes.FastDNSv2.SubmitChangeListFunc = func(ctx context.Context, zone string) (*akamai.Response, error) {
+		return nil, nil
+	}
+
+	_, _, err := runWith(t, client, "changelist", "submit", "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	calls := fakes.FastDNSv2.Calls()
+	assert.Len(t, calls, 1)
+	assert.Equal(t, "SubmitChangeList", calls[0].Method)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// output writes command results either as a table or as JSON.
+type output struct {
+	w    io.Writer
+	json bool
+}
+
+// write prints v as indented JSON in JSON mode, and otherwise prints the rows as a
+// table whose first row is the header.
+func (o *output) write(v interface{}, rows [][]string) error {
+	if o.json {
+		enc := json.NewEncoder(o.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(o.w, 0, 4, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// text prints free-form output, such as zone files and plans, in either mode. In JSON
+// mode v is printed instead.
+func (o *output) text(v interface{}, s string) error {
+	if o.json {
+		return o.write(v, nil)
+	}
+
+	_, err := io.WriteString(o.w, s)
+	return err
+}
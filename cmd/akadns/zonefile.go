@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+// formatZoneFile renders record sets in the zone file format, one record per rdata,
+// sorted by name, type and rdata so that exports of the same zone are identical. TXT
+// rdata is re-encoded with akamai.QuoteTXT, so that it reads back unchanged.
+func formatZoneFile(zone string, records []*akamai.RecordSet) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %v.\n", strings.TrimSuffix(zone, "."))
+	for _, rs := range akamai.SortRecordSets(records) {
+		for _, rdata := range rs.Rdata {
+			data := akamai.StringValue(rdata)
+			if rs.GetType() == akamai.RRTypeTxt {
+				if value, err := akamai.UnquoteTXT(data); err == nil {
+					data = akamai.QuoteTXT(value)
+				}
+			}
+			fmt.Fprintf(&b, "%v.\t%d\tIN\t%v\t%v\n", strings.TrimSuffix(rs.GetName(), "."), rs.GetTTL(), rs.GetType(), data)
+		}
+	}
+	return b.String()
+}
+
+// parseZoneFile reads the record sets of a zone file. It understands $ORIGIN and $TTL,
+// comments, parentheses spanning lines, relative names, and records that omit their
+// name, TTL or class. SOA records are skipped, as Akamai manages them. The
+// character-strings of a TXT record are joined into one value, encoded with
+// akamai.QuoteTXT.
+func parseZoneFile(r io.Reader, zone string) ([]*akamai.RecordSetCreateRequest, error) {
+	origin := strings.TrimSuffix(zone, ".")
+	defaultTTL := 0
+	lastName := ""
+
+	var records []*akamai.RecordSetCreateRequest
+	byKey := map[string]*akamai.RecordSetCreateRequest{}
+
+	lines, err := logicalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range lines {
+		fields := splitFields(l.text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "$ORIGIN":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN takes one argument", l.number)
+			}
+			origin = absoluteName(fields[1], origin)
+			continue
+		case "$TTL":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: $TTL takes one argument", l.number)
+			}
+			ttl, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid $TTL %q", l.number, fields[1])
+			}
+			defaultTTL = ttl
+			continue
+		}
+
+		name := lastName
+		if !l.indented {
+			name = absoluteName(fields[0], origin)
+			fields = fields[1:]
+		}
+		if name == "" {
+			return nil, fmt.Errorf("line %d: record has no name", l.number)
+		}
+		lastName = name
+
+		ttl := defaultTTL
+		for len(fields) > 0 {
+			if n, err := strconv.Atoi(fields[0]); err == nil {
+				ttl = n
+				fields = fields[1:]
+			} else if strings.EqualFold(fields[0], "IN") {
+				fields = fields[1:]
+			} else {
+				break
+			}
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: record needs a type and data", l.number)
+		}
+		if ttl <= 0 {
+			return nil, fmt.Errorf("line %d: record has no TTL and there is no $TTL", l.number)
+		}
+
+		rtype := strings.ToUpper(fields[0])
+		if rtype == "SOA" {
+			continue
+		}
+		rdata := strings.Join(fields[1:], " ")
+		if rtype == akamai.RRTypeTxt {
+			value, err := akamai.UnquoteTXT(rdata)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", l.number, err)
+			}
+			rdata = akamai.QuoteTXT(value)
+		}
+
+		key := name + "/" + rtype
+		rs, ok := byKey[key]
+		if !ok {
+			rs = &akamai.RecordSetCreateRequest{Zone: strings.TrimSuffix(zone, "."), Name: name, Type: rtype, TTL: akamai.Int(ttl)}
+			byKey[key] = rs
+			records = append(records, rs)
+		}
+		rs.Rdata = append(rs.Rdata, rdata)
+	}
+
+	return records, nil
+}
+
+type logicalLine struct {
+	number   int
+	indented bool
+	text     string
+}
+
+// logicalLines strips comments and joins the lines that parentheses span.
+func logicalLines(r io.Reader) ([]logicalLine, error) {
+	var lines []logicalLine
+	var cur *logicalLine
+	depth := 0
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		text := stripComment(sc.Text())
+		if cur == nil {
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			cur = &logicalLine{number: n, indented: text[0] == ' ' || text[0] == '\t'}
+		}
+
+		text, delta := stripParens(text)
+		depth += delta
+		cur.text += " " + text
+
+		if depth <= 0 {
+			lines = append(lines, *cur)
+			cur = nil
+			depth = 0
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if cur != nil {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", cur.number)
+	}
+
+	return lines, nil
+}
+
+// stripComment removes the comment of a line, leaving semicolons in quoted strings or
+// escaped with a backslash.
+func stripComment(s string) string {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				return s[:i]
+			}
+		}
+	}
+	return s
+}
+
+// stripParens replaces the parentheses outside quoted strings with spaces, and returns
+// by how much they change the nesting depth.
+func stripParens(s string) (string, int) {
+	b := []byte(s)
+	depth := 0
+	quoted := false
+	for i := 0; i < len(b); i++ {
+		switch b[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted = !quoted
+		case '(', ')':
+			if quoted {
+				continue
+			}
+			if b[i] == '(' {
+				depth++
+			} else {
+				depth--
+			}
+			b[i] = ' '
+		}
+	}
+	return string(b), depth
+}
+
+// splitFields splits a line on whitespace, keeping quoted strings and backslash
+// escapes whole.
+func splitFields(s string) []string {
+	var fields []string
+	var cur strings.Builder
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			cur.WriteByte(c)
+			cur.WriteByte(s[i+1])
+			i++
+		case c == '"':
+			quoted = !quoted
+			cur.WriteByte(c)
+		case !quoted && (c == ' ' || c == '\t'):
+			if cur.Len() > 0 {
+				fields = append(fields, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if cur.Len() > 0 {
+		fields = append(fields, cur.String())
+	}
+	return fields
+}
+
+// absoluteName returns the fully qualified name, without trailing dot, of a name
+// relative to origin.
+func absoluteName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(strings.TrimSuffix(name, "."))
+	case origin == "":
+		return strings.ToLower(name)
+	default:
+		return strings.ToLower(name + "." + origin)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trussworks/akamai-sdk-go/akamai"
+)
+
+func TestParseZoneFile(t *testing.T) {
+	zoneFile := `$ORIGIN example.com.
+$TTL 3600
+@	IN	SOA	a1.akam.net. hostmaster.example.com. (
+		2021010101 ; serial
+		3600 600 604800 300 )
+@		NS	a1.akam.net.
+		NS	a2.akam.net.
+www	300	IN	A	10.0.0.1
+www		IN	A	10.0.0.2 ; second address
+txt		TXT	"v=spf1 -all; strict"
+other.net.	60	CNAME	www.example.com.
+`
+
+	records, err := parseZoneFile(strings.NewReader(zoneFile), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	expected := []*akamai.RecordSetCreateRequest{
+		{Zone: "example.com", Name: "example.com", Type: "NS", TTL: akamai.Int(3600), Rdata: []string{"a1.akam.net.", "a2.akam.net."}},
+		{Zone: "example.com", Name: "www.example.com", Type: "A", TTL: akamai.Int(300), Rdata: []string{"10.0.0.1", "10.0.0.2"}},
+		{Zone: "example.com", Name: "txt.example.com", Type: "TXT", TTL: akamai.Int(3600), Rdata: []string{`"v=spf1 -all; strict"`}},
+		{Zone: "example.com", Name: "other.net", Type: "CNAME", TTL: akamai.Int(60), Rdata: []string{"www.example.com."}},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		zoneFile string
+		err      string
+	}{
+		{"no ttl", "www IN A 10.0.0.1\n", "line 1: record has no TTL"},
+		{"no data", "$TTL 60\nwww IN A\n", "line 2: record needs a type and data"},
+		{"no name", "$TTL 60\n  IN A 10.0.0.1\n", "line 2: record has no name"},
+		{"bad ttl", "$TTL soon\n", "line 1: invalid $TTL"},
+		{"unbalanced", "$TTL 60\n@ SOA a. b. ( 1 2\n", "line 2: unbalanced parentheses"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseZoneFile(strings.NewReader(tt.zoneFile), "example.com")
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
+
+func TestFormatZoneFileRoundTrip(t *testing.T) {
+	records := []*akamai.RecordSet{
+		{Name: akamai.String("www.example.com"), Type: akamai.String("A"), TTL: akamai.Int(300), Rdata: []*string{akamai.String("10.0.0.2"), akamai.String("10.0.0.1")}},
+		{Name: akamai.String("example.com"), Type: akamai.String("MX"), TTL: akamai.Int(60), Rdata: []*string{akamai.String("10 mx.example.com.")}},
+	}
+
+	// Records are sorted by name, type and rdata.
+	zoneFile := formatZoneFile("example.com", records)
+	assert.Equal(t, "$ORIGIN example.com.\n"+
+		"example.com.\t60\tIN\tMX\t10 mx.example.com.\n"+
+		"www.example.com.\t300\tIN\tA\t10.0.0.1\n"+
+		"www.example.com.\t300\tIN\tA\t10.0.0.2\n", zoneFile)
+	assert.Equal(t, zoneFile, formatZoneFile("example.com", []*akamai.RecordSet{records[1], records[0]}))
+
+	parsed, err := parseZoneFile(strings.NewReader(zoneFile), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	assert.Len(t, parsed, 2)
+	assert.Equal(t, "example.com", parsed[0].Name)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, parsed[1].Rdata)
+}
+
+func TestZoneFileTXT(t *testing.T) {
+	value := `say "hi" (twice); C:\dir <a&b> 🔑` + strings.Repeat("z", 280)
+	records := []*akamai.RecordSet{
+		{Name: akamai.String("txt.example.com"), Type: akamai.String("TXT"), TTL: akamai.Int(300), Rdata: []*string{akamai.String(akamai.QuoteTXT(value))}},
+	}
+
+	parsed, err := parseZoneFile(strings.NewReader(formatZoneFile("example.com", records)), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, parsed, 1) && assert.Len(t, parsed[0].Rdata, 1) {
+		got, err := akamai.UnquoteTXT(parsed[0].Rdata[0])
+		if err != nil {
+			t.Fatalf("expect nil, got %v", err)
+		}
+		assert.Equal(t, value, got)
+	}
+
+	parsed, err = parseZoneFile(strings.NewReader("$TTL 60\ntxt TXT ( \"part one\"\n \"part two\" ) ; comment\nbare TXT hello\n"), "example.com")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+	if assert.Len(t, parsed, 2) {
+		assert.Equal(t, []string{`"part onepart two"`}, parsed[0].Rdata)
+		assert.Equal(t, []string{`"hello"`}, parsed[1].Rdata)
+	}
+
+	_, err = parseZoneFile(strings.NewReader("$TTL 60\ntxt TXT \"open\\\"\n"), "example.com")
+	assert.Error(t, err)
+}
@@ -1,6 +1,6 @@
 module github.com/trussworks/akamai-sdk-go
 
-go 1.12
+go 1.18
 
 require (
 	github.com/go-ini/ini v1.42.0
@@ -8,4 +8,11 @@ require (
 	github.com/google/uuid v1.1.1
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/stretchr/testify v1.3.0
+	golang.org/x/net v0.35.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/text v0.22.0 // indirect
 )
@@ -0,0 +1,234 @@
+// Command capture fetches fresh responses from the FastDNS v2 API for the contract
+// tests of the SDK, sanitizes them, and writes them to testdata/contract/fastdns.
+//
+// Usage, from the root of the repository:
+//
+//	go run ./internal/capture [-edgerc file] [-section name] -zone <zone> [-record name] [-type type] [-delete-request id]
+//
+// Only GET requests are made, with the client in read-only mode. The zone should be a
+// test zone holding the record set given by -record and -type; the change list
+// fixtures are only written if the zone has a change list. The delete request fixtures
+// are only written when the ID of a past DeleteZone request is given.
+//
+// The zone name, contract IDs, host and user names are replaced by those of the
+// fixtures, but the rest of the responses is kept as is: review the diff before
+// committing it.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/trussworks/akamai-sdk-go/akamai"
+	"github.com/trussworks/akamai-sdk-go/akamai/akamaitest"
+	"github.com/trussworks/akamai-sdk-go/akamai/credentials"
+)
+
+// fixtureZone is the zone name the fixtures use.
+const fixtureZone = "example.com"
+
+// endpoint is a request whose response is written to the fixture of the same name.
+type endpoint struct {
+	name string
+	path string
+
+	// optional endpoints are skipped when they answer 404.
+	optional bool
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "capture: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("capture", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	edgerc := fs.String("edgerc", "", "path of the .edgerc file, defaults to ~/.edgerc")
+	section := fs.String("section", "default", "section of the .edgerc file")
+	zone := fs.String("zone", "", "test zone to capture the responses of")
+	record := fs.String("record", "", "name of a record set of the zone, defaults to www.<zone>")
+	rtype := fs.String("type", "A", "type of the record set")
+	deleteRequest := fs.String("delete-request", "", "ID of a past DeleteZone request")
+	out := fs.String("out", "testdata/contract/fastdns", "directory the fixtures are written to")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *zone == "" {
+		fs.Usage()
+		return errors.New("-zone is required")
+	}
+	if *record == "" {
+		*record = "www." + *zone
+	}
+
+	client, err := akamai.NewClient(nil, credentials.NewSharedCredentials(*edgerc, *section))
+	if err != nil {
+		return err
+	}
+	client.WithReadOnly(true)
+
+	contract, _, err := client.FastDNSv2.GetZoneContract(ctx, *zone)
+	if err != nil {
+		return err
+	}
+
+	endpoints := []endpoint{
+		{name: "list_zones", path: "config-dns/v2/zones?contractIds=" + contract.GetContractID()},
+		{name: "get_zone", path: "config-dns/v2/zones/" + *zone},
+		{name: "get_zone_contract", path: "config-dns/v2/zones/" + *zone + "/contract"},
+		{name: "get_zone_record_sets", path: "config-dns/v2/zones/" + *zone + "/recordsets"},
+		{name: "get_record_set", path: fmt.Sprintf("config-dns/v2/zones/%v/names/%v/types/%v", *zone, *record, *rtype)},
+		{name: "get_change_list", path: "config-dns/v2/changelists/" + *zone, optional: true},
+		{name: "get_change_list_record_sets", path: "config-dns/v2/changelists/" + *zone + "/recordsets", optional: true},
+		{name: "list_groups", path: "config-dns/v2/data/groups/"},
+		{name: "list_contracts", path: "config-dns/v2/data/contracts"},
+		{name: "get_authorities", path: "config-dns/v2/data/authorities?contractIds=" + contract.GetContractID()},
+		{name: "get_record_types", path: "config-dns/v2/data/recordsets/types?zone=" + *zone},
+	}
+	if *deleteRequest != "" {
+		endpoints = append(endpoints,
+			endpoint{name: "delete_zone_status", path: "config-dns/v2/zones/delete-requests/" + *deleteRequest},
+			endpoint{name: "delete_zone_result", path: "config-dns/v2/zones/delete-requests/" + *deleteRequest + "/result"},
+		)
+	}
+
+	s := &sanitizer{zone: strings.ToLower(strings.TrimSuffix(*zone, ".")), host: client.BaseURL.Host, contracts: map[string]string{}}
+	for _, e := range endpoints {
+		var raw json.RawMessage
+		_, err := client.Call(ctx, "GET", e.path, nil, &raw)
+		var ae *akamai.AkamaiError
+		if e.optional && errors.As(err, &ae) && ae.Status == http.StatusNotFound {
+			fmt.Fprintf(stderr, "skipped %v: %v\n", e.name, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%v: %w", e.name, err)
+		}
+
+		b, err := s.sanitize(raw)
+		if err != nil {
+			return fmt.Errorf("%v: %w", e.name, err)
+		}
+
+		path := filepath.Join(*out, e.name+".json")
+		if err := ioutil.WriteFile(path, b, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(stderr, "wrote %v\n", path)
+	}
+
+	return nil
+}
+
+// sanitizer replaces the identifying parts of responses with those of the fixtures.
+type sanitizer struct {
+	zone string
+	host string
+
+	// contracts maps the real contract IDs to those of the fixtures.
+	contracts map[string]string
+}
+
+// Fields whose values are replaced wholesale.
+var replacedFields = map[string]string{
+	"lastModifiedBy": "akamaitest",
+	"contractName":   "akamaitest",
+	"groupName":      "akamaitest",
+	"secret":         "REDACTED",
+}
+
+// sanitize returns the sanitized and indented form of a JSON response. Numbers are
+// kept as they were written, so that the fixtures keep the types of the API.
+func (s *sanitizer) sanitize(raw []byte) ([]byte, error) {
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	s.collectContracts(v)
+	b, err := json.MarshalIndent(s.walk("", v), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// collectContracts assigns fixture IDs to the contract IDs found in v, in sorted order
+// so that they are stable across runs.
+func (s *sanitizer) collectContracts(v interface{}) {
+	var ids []string
+	var collect func(key string, v interface{})
+	collect = func(key string, v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, e := range v {
+				collect(k, e)
+			}
+		case []interface{}:
+			for _, e := range v {
+				collect(key, e)
+			}
+		case string:
+			if key == "contractId" || key == "contractIds" {
+				ids = append(ids, v)
+			}
+		}
+	}
+	collect("", v)
+
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, ok := s.contracts[id]; !ok {
+			s.contracts[id] = fmt.Sprintf("%d-AKAMAITEST", len(s.contracts)+1)
+		}
+	}
+}
+
+func (s *sanitizer) walk(key string, v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = s.walk(k, e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = s.walk(key, e)
+		}
+	case string:
+		if r, ok := replacedFields[key]; ok {
+			return r
+		}
+		return s.replace(v)
+	}
+	return v
+}
+
+// replace replaces the zone, host and contract IDs in a string value.
+func (s *sanitizer) replace(v string) string {
+	if id, ok := s.contracts[v]; ok {
+		return id
+	}
+	if s.host != "" {
+		v = strings.Replace(v, s.host, akamaitest.CassetteHost, -1)
+	}
+	if s.zone != "" && s.zone != fixtureZone {
+		v = strings.Replace(v, s.zone, fixtureZone, -1)
+	}
+	return v
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	s := &sanitizer{zone: "corp.example.org", host: "akab-real.luna.akamaiapis.net", contracts: map[string]string{}}
+
+	b, err := s.sanitize([]byte(`{
+		"metadata": {"page": 1, "pageSize": 25, "totalElements": 1, "contractIds": ["C-2REAL", "C-1REAL"]},
+		"zones": [{"contractId": "C-1REAL", "zone": "corp.example.org", "lastModifiedBy": "jane.doe@corp.example.org",
+			"aliasCount": "3", "tsigKey": {"name": "xfr", "secret": "c2VjcmV0"},
+			"link": "https://akab-real.luna.akamaiapis.net/config-dns/v2/zones/corp.example.org"}]
+	}`))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	assert.JSONEq(t, `{
+		"metadata": {"page": 1, "pageSize": 25, "totalElements": 1, "contractIds": ["2-AKAMAITEST", "1-AKAMAITEST"]},
+		"zones": [{"contractId": "1-AKAMAITEST", "zone": "example.com", "lastModifiedBy": "akamaitest",
+			"aliasCount": "3", "tsigKey": {"name": "xfr", "secret": "REDACTED"},
+			"link": "https://akaa-akamaitest.luna.akamaiapis.net/config-dns/v2/zones/example.com"}]
+	}`, string(b))
+	assert.Contains(t, string(b), `"page": 1`, "numbers are kept as they were")
+}